@@ -1,29 +1,73 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/ruanlas/wallet-core-api/internal/blobstorage"
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/idempotency"
 	"github.com/ruanlas/wallet-core-api/internal/routes"
+	"github.com/ruanlas/wallet-core-api/internal/trash"
 	v1 "github.com/ruanlas/wallet-core-api/internal/v1"
+	"github.com/ruanlas/wallet-core-api/internal/v1/analytics"
+	analyticsservice "github.com/ruanlas/wallet-core-api/internal/v1/analytics/anservice"
+	analyticsrepository "github.com/ruanlas/wallet-core-api/internal/v1/analytics/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment"
+	attachmentservice "github.com/ruanlas/wallet-core-api/internal/v1/attachment/atservice"
+	attachmentrepository "github.com/ruanlas/wallet-core-api/internal/v1/attachment/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit"
+	auditservice "github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	auditrepository "github.com/ruanlas/wallet-core-api/internal/v1/audit/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule"
+	categoryruleservice "github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	categoryrulerepository "github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion"
+	categorysuggestionservice "github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/csservice"
+	categorysuggestionrepository "github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate"
+	exchangerateservice "github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	exchangeraterepository "github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/forecast"
+	forecastservice "github.com/ruanlas/wallet-core-api/internal/v1/forecast/fcservice"
+	forecastrepository "github.com/ruanlas/wallet-core-api/internal/v1/forecast/repository"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gain"
 	gainservice "github.com/ruanlas/wallet-core-api/internal/v1/gain/gservice"
 	gainrepository "github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection"
 	gainprojectionservice "github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/gpservice"
 	gainprojectionrepository "github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/goal"
+	goalservice "github.com/ruanlas/wallet-core-api/internal/v1/goal/glservice"
+	goalrepository "github.com/ruanlas/wallet-core-api/internal/v1/goal/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment"
+	investmentservice "github.com/ruanlas/wallet-core-api/internal/v1/investment/ivservice"
+	investmentrepository "github.com/ruanlas/wallet-core-api/internal/v1/investment/repository"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection"
 	invoiceprojectionservice "github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/ipservice"
 	invoiceprojectionrepository "github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/loan"
+	loanservice "github.com/ruanlas/wallet-core-api/internal/v1/loan/lnservice"
+	loanrepository "github.com/ruanlas/wallet-core-api/internal/v1/loan/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split"
+	splitrepository "github.com/ruanlas/wallet-core-api/internal/v1/split/repository"
+	splitservice "github.com/ruanlas/wallet-core-api/internal/v1/split/spservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport"
+	taxreportrepository "github.com/ruanlas/wallet-core-api/internal/v1/taxreport/repository"
+	taxreportservice "github.com/ruanlas/wallet-core-api/internal/v1/taxreport/trservice"
 
 	invoiceservice "github.com/ruanlas/wallet-core-api/internal/v1/invoice/iservice"
 	invoicerepository "github.com/ruanlas/wallet-core-api/internal/v1/invoice/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	uuid "github.com/satori/go.uuid"
 	"go.elastic.co/apm/module/apmsql"
 	_ "go.elastic.co/apm/module/apmsql/mysql"
@@ -83,31 +127,139 @@ func init() {
 func main() {
 	fmt.Println("Project Started!")
 
+	transactor := dbtx.NewTransactor(db)
+	auditRepository := auditrepository.New(db)
+	auditStorageProcess := auditservice.NewStorageProcess(auditRepository, uuid.NewV4)
+	referenceChecker := validation.NewReferenceChecker(db)
+	auditReadingProcess := auditservice.NewReadingProcess(auditRepository)
+	auditHandler := audit.NewHandler(auditReadingProcess)
+
+	exchangeRateRepository := exchangeraterepository.New(db)
+	exchangeRateStorageProcess := exchangerateservice.NewStorageProcess(exchangeRateRepository, uuid.NewV4)
+	exchangeRateReadingProcess := exchangerateservice.NewReadingProcess(exchangeRateRepository)
+	exchangeRateConverter := exchangerateservice.NewConverter(exchangeRateRepository)
+	exchangeRateHandler := exchangerate.NewHandler(exchangeRateStorageProcess, exchangeRateReadingProcess)
+
+	categoryRuleRepository := categoryrulerepository.New(db)
+	categoryRuleStorageProcess := categoryruleservice.NewStorageProcess(categoryRuleRepository, uuid.NewV4, referenceChecker)
+	categoryRuleReadingProcess := categoryruleservice.NewReadingProcess(categoryRuleRepository)
+	categorizer := categoryruleservice.NewCategorizer(categoryRuleRepository, uuid.NewV4)
+	categoryRuleHandler := categoryrule.NewHandler(categoryRuleStorageProcess, categoryRuleReadingProcess)
+
+	categorySuggestionRepository := categorysuggestionrepository.New(db)
+	suggester := categorysuggestionservice.NewSuggester(categorySuggestionRepository)
+	categorySuggestionReadingProcess := categorysuggestionservice.NewReadingProcess(suggester)
+	categorySuggestionHandler := categorysuggestion.NewHandler(categorySuggestionReadingProcess)
+
 	gainProjectionRepository := gainprojectionrepository.New(db)
-	gainProjectionStorageProcess := gainprojectionservice.NewStorageProcess(gainProjectionRepository, uuid.NewV4)
+	gainProjectionStorageProcess := gainprojectionservice.NewStorageProcess(gainProjectionRepository, uuid.NewV4, auditStorageProcess, referenceChecker, transactor)
 	gainProjectionReadingProcess := gainprojectionservice.NewReadingProcess(gainProjectionRepository)
 	gainProjectionHandler := gainprojection.NewHandler(gainProjectionStorageProcess, gainProjectionReadingProcess)
 
 	gainRepository := gainrepository.New(db)
-	gainStorageProcess := gainservice.NewStorageProcess(gainRepository, uuid.NewV4)
-	gainReadingProcess := gainservice.NewReadingProcess(gainRepository)
+	gainStorageProcess := gainservice.NewStorageProcess(gainRepository, uuid.NewV4, auditStorageProcess, referenceChecker, categorizer, suggester, transactor)
+	gainReadingProcess := gainservice.NewReadingProcess(gainRepository, exchangeRateConverter)
 	gainHandler := gain.NewHandler(gainStorageProcess, gainReadingProcess)
 
 	invoiceProjectionRepository := invoiceprojectionrepository.New(db)
-	invoiceProjectionStorageProcess := invoiceprojectionservice.NewStorageProcess(invoiceProjectionRepository, uuid.NewV4)
+	invoiceProjectionStorageProcess := invoiceprojectionservice.NewStorageProcess(invoiceProjectionRepository, uuid.NewV4, auditStorageProcess, referenceChecker, transactor)
 	invoiceProjectionReadingProcess := invoiceprojectionservice.NewReadingProcess(invoiceProjectionRepository)
 	invoiceProjectionHandler := invoiceprojection.NewHandler(invoiceProjectionStorageProcess, invoiceProjectionReadingProcess)
 
 	invoiceRepository := invoicerepository.New(db)
-	invoiceStorageProcess := invoiceservice.NewStorageProcess(invoiceRepository, uuid.NewV4)
-	invoiceReadingProcess := invoiceservice.NewReadingProcess(invoiceRepository)
+	invoiceStorageProcess := invoiceservice.NewStorageProcess(invoiceRepository, uuid.NewV4, auditStorageProcess, referenceChecker, categorizer, suggester, transactor)
+	invoiceReadingProcess := invoiceservice.NewReadingProcess(invoiceRepository, exchangeRateConverter)
 	invoiceHandler := invoice.NewHandler(invoiceStorageProcess, invoiceReadingProcess)
 
-	apiV1 := v1.NewApi(gainProjectionHandler, gainHandler, invoiceProjectionHandler, invoiceHandler)
-	router := routes.NewRouter(apiV1)
+	attachmentRepository := attachmentrepository.New(db)
+	blobStorage := getBlobStorage()
+	attachmentStorageProcess := attachmentservice.NewStorageProcess(attachmentRepository, blobStorage, uuid.NewV4)
+	attachmentReadingProcess := attachmentservice.NewReadingProcess(attachmentRepository, blobStorage)
+	attachmentHandler := attachment.NewHandler(attachmentStorageProcess, attachmentReadingProcess)
+
+	forecastRepository := forecastrepository.New(db)
+	forecastReadingProcess := forecastservice.NewReadingProcess(forecastRepository, exchangeRateConverter)
+	forecastHandler := forecast.NewHandler(forecastReadingProcess)
+
+	analyticsRepository := analyticsrepository.New(db)
+	analyticsReadingProcess := analyticsservice.NewReadingProcess(analyticsRepository, exchangeRateConverter)
+	analyticsHandler := analytics.NewHandler(analyticsReadingProcess)
+
+	taxReportRepository := taxreportrepository.New(db)
+	taxReportStorageProcess := taxreportservice.NewStorageProcess(taxReportRepository, referenceChecker)
+	taxReportReadingProcess := taxreportservice.NewReadingProcess(taxReportRepository, exchangeRateConverter)
+	taxReportHandler := taxreport.NewHandler(taxReportStorageProcess, taxReportReadingProcess)
+
+	goalRepository := goalrepository.New(db)
+	goalStorageProcess := goalservice.NewStorageProcess(goalRepository, uuid.NewV4, referenceChecker, invoiceProjectionStorageProcess, exchangeRateConverter, time.Now, transactor)
+	goalReadingProcess := goalservice.NewReadingProcess(goalRepository, exchangeRateConverter, time.Now)
+	goalHandler := goal.NewHandler(goalStorageProcess, goalReadingProcess)
+
+	loanRepository := loanrepository.New(db)
+	loanStorageProcess := loanservice.NewStorageProcess(loanRepository, uuid.NewV4, referenceChecker, invoiceProjectionStorageProcess, time.Now, transactor)
+	loanReadingProcess := loanservice.NewReadingProcess(loanRepository, time.Now)
+	loanHandler := loan.NewHandler(loanStorageProcess, loanReadingProcess)
+
+	investmentRepository := investmentrepository.New(db)
+	investmentStorageProcess := investmentservice.NewStorageProcess(investmentRepository, uuid.NewV4, exchangeRateConverter, time.Now, transactor)
+	investmentReadingProcess := investmentservice.NewReadingProcess(investmentRepository, exchangeRateConverter)
+	investmentHandler := investment.NewHandler(investmentStorageProcess, investmentReadingProcess)
+
+	splitRepository := splitrepository.New(db)
+	splitStorageProcess := splitservice.NewStorageProcess(splitRepository, uuid.NewV4, gainProjectionStorageProcess, invoiceProjectionStorageProcess, time.Now, transactor)
+	splitReadingProcess := splitservice.NewReadingProcess(splitRepository)
+	splitHandler := split.NewHandler(splitStorageProcess, splitReadingProcess)
+
+	trashPurger := trash.NewPurger(getTrashRetention(), trash.DEFAULT_PURGE_INTERVAL, time.Now,
+		gainRepository, invoiceRepository, gainProjectionRepository, invoiceProjectionRepository, attachmentStorageProcess)
+	go trashPurger.Start(context.Background())
+
+	idempotencyRepository := idempotency.NewRepository(db)
+	idempotencyTTL := getIdempotencyTTL()
+	idempotencyPurger := trash.NewPurger(idempotencyTTL, idempotency.DEFAULT_PURGE_INTERVAL, time.Now, idempotencyRepository)
+	go idempotencyPurger.Start(context.Background())
+	idempotencyMiddleware := idempotency.NewMiddleware(idempotencyRepository, idempotencyTTL, time.Now)
+
+	apiV1 := v1.NewApi(gainProjectionHandler, gainHandler, invoiceProjectionHandler, invoiceHandler, auditHandler, exchangeRateHandler, attachmentHandler, categoryRuleHandler, categorySuggestionHandler, forecastHandler, analyticsHandler, taxReportHandler, goalHandler, loanHandler, investmentHandler, splitHandler)
+	router := routes.NewRouter(apiV1, idempotencyMiddleware)
 	router.SetupRoutes()
 }
 
+func getTrashRetention() time.Duration {
+	retentionDays, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || retentionDays <= 0 {
+		retentionDays = trash.DEFAULT_RETENTION_DAYS
+	}
+	return time.Duration(retentionDays) * 24 * time.Hour
+}
+
+func getIdempotencyTTL() time.Duration {
+	ttlHours, err := strconv.Atoi(os.Getenv("IDEMPOTENCY_TTL_HOURS"))
+	if err != nil || ttlHours <= 0 {
+		ttlHours = idempotency.DEFAULT_TTL_HOURS
+	}
+	return time.Duration(ttlHours) * time.Hour
+}
+
+// getBlobStorage selects where the attached files are kept, the local directory is the default
+func getBlobStorage() blobstorage.Storage {
+	if os.Getenv("BLOB_STORAGE_DRIVER") == blobstorage.DRIVER_S3 {
+		config := blobstorage.S3Config{
+			Endpoint:        os.Getenv("BLOB_STORAGE_S3_ENDPOINT"),
+			Region:          os.Getenv("BLOB_STORAGE_S3_REGION"),
+			Bucket:          os.Getenv("BLOB_STORAGE_S3_BUCKET"),
+			AccessKeyId:     os.Getenv("BLOB_STORAGE_S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("BLOB_STORAGE_S3_SECRET_ACCESS_KEY"),
+		}
+		return blobstorage.NewS3Storage(config, &http.Client{Timeout: time.Minute}, time.Now)
+	}
+	path := os.Getenv("BLOB_STORAGE_PATH")
+	if path == "" {
+		path = blobstorage.DEFAULT_LOCAL_PATH
+	}
+	return blobstorage.NewLocalStorage(path)
+}
+
 func startPrometheus() {
 	prometheusPort := os.Getenv("PROMETHEUS_PORT")
 	log.Println("Prometheus metrics on /metrics port", prometheusPort)
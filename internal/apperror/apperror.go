@@ -0,0 +1,97 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+type Kind string
+
+const (
+	KIND_NOT_FOUND  Kind = "not_found"
+	KIND_CONFLICT   Kind = "conflict"
+	KIND_VALIDATION Kind = "validation"
+	KIND_FORBIDDEN  Kind = "forbidden"
+	KIND_UPSTREAM   Kind = "upstream"
+)
+
+// Error is a failure of the domain that can be explained to the client.
+// The message is safe to be exposed, while the wrapped error is kept only for logs and tracing.
+type Error struct {
+	Kind    Kind
+	Status  int
+	Message string
+	Err     error
+}
+
+func (appErr *Error) Error() string {
+	if appErr.Err == nil {
+		return appErr.Message
+	}
+	return appErr.Message + ": " + appErr.Err.Error()
+}
+
+func (appErr *Error) Unwrap() error {
+	return appErr.Err
+}
+
+// Wrap returns a copy of the error keeping the cause that originated it
+func (appErr *Error) Wrap(err error) *Error {
+	wrapped := *appErr
+	wrapped.Err = err
+	return &wrapped
+}
+
+func NotFound(message string) *Error {
+	return &Error{Kind: KIND_NOT_FOUND, Status: http.StatusNotFound, Message: message}
+}
+
+func Conflict(message string) *Error {
+	return &Error{Kind: KIND_CONFLICT, Status: http.StatusConflict, Message: message}
+}
+
+// PreconditionFailed is the conflict caused by a stale version informed in the If-Match header
+func PreconditionFailed(message string) *Error {
+	return &Error{Kind: KIND_CONFLICT, Status: http.StatusPreconditionFailed, Message: message}
+}
+
+func Validation(message string) *Error {
+	return &Error{Kind: KIND_VALIDATION, Status: http.StatusBadRequest, Message: message}
+}
+
+// Unprocessable is the validation failure of a request that is well formed but can not be processed
+func Unprocessable(message string) *Error {
+	return &Error{Kind: KIND_VALIDATION, Status: http.StatusUnprocessableEntity, Message: message}
+}
+
+// PreconditionRequired is the validation failure of a request that must be conditional
+func PreconditionRequired(message string) *Error {
+	return &Error{Kind: KIND_VALIDATION, Status: http.StatusPreconditionRequired, Message: message}
+}
+
+// TooLarge is the validation failure of a request whose payload exceeds the accepted size
+func TooLarge(message string) *Error {
+	return &Error{Kind: KIND_VALIDATION, Status: http.StatusRequestEntityTooLarge, Message: message}
+}
+
+// UnsupportedMediaType is the validation failure of a request whose payload has a type that is not accepted
+func UnsupportedMediaType(message string) *Error {
+	return &Error{Kind: KIND_VALIDATION, Status: http.StatusUnsupportedMediaType, Message: message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Kind: KIND_FORBIDDEN, Status: http.StatusForbidden, Message: message}
+}
+
+func Upstream(message string) *Error {
+	return &Error{Kind: KIND_UPSTREAM, Status: http.StatusBadGateway, Message: message}
+}
+
+// KindOf returns the kind of the first Error found in the chain, or an empty kind when there is none
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return ""
+}
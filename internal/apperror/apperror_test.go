@@ -0,0 +1,51 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstructors(t *testing.T) {
+	cases := []struct {
+		err    *Error
+		kind   Kind
+		status int
+	}{
+		{NotFound("Gain not found"), KIND_NOT_FOUND, http.StatusNotFound},
+		{Conflict("A gain is already created"), KIND_CONFLICT, http.StatusConflict},
+		{PreconditionFailed("The gain was modified by another request"), KIND_CONFLICT, http.StatusPreconditionFailed},
+		{Validation("A param month 13 is invalid"), KIND_VALIDATION, http.StatusBadRequest},
+		{Unprocessable("The key was already used"), KIND_VALIDATION, http.StatusUnprocessableEntity},
+		{PreconditionRequired("The If-Match header is required"), KIND_VALIDATION, http.StatusPreconditionRequired},
+		{TooLarge("The file must have at most 10 MB"), KIND_VALIDATION, http.StatusRequestEntityTooLarge},
+		{UnsupportedMediaType("The file type text/plain is not accepted"), KIND_VALIDATION, http.StatusUnsupportedMediaType},
+		{Forbidden("The record belongs to another user"), KIND_FORBIDDEN, http.StatusForbidden},
+		{Upstream("The identity provider is unavailable"), KIND_UPSTREAM, http.StatusBadGateway},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.kind, c.err.Kind)
+		assert.Equal(t, c.status, c.err.Status)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	cause := errors.New("dial tcp: connection refused")
+	base := Upstream("The identity provider is unavailable")
+	err := base.Wrap(cause)
+
+	assert.ErrorIs(t, err, cause)
+	assert.Nil(t, base.Err)
+	assert.Equal(t, "The identity provider is unavailable", base.Error())
+	assert.Equal(t, "The identity provider is unavailable: dial tcp: connection refused", err.Error())
+}
+
+func TestKindOf(t *testing.T) {
+	err := fmt.Errorf("get gain: %w", NotFound("Gain not found"))
+	assert.Equal(t, KIND_NOT_FOUND, KindOf(err))
+	assert.Equal(t, Kind(""), KindOf(errors.New("An error has been ocurred")))
+	assert.Equal(t, Kind(""), KindOf(nil))
+}
@@ -0,0 +1,41 @@
+package blobstorage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStoragePutGetDelete(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+	ctx := context.TODO()
+	key := "5832a502/gain/cd1cc27b/receipt"
+
+	err := storage.Put(ctx, key, strings.NewReader("%PDF-1.4"), 8, "application/pdf")
+	assert.NoError(t, err)
+
+	content, err := storage.Get(ctx, key)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(content)
+	content.Close()
+	assert.Equal(t, "%PDF-1.4", string(data))
+
+	assert.NoError(t, storage.Delete(ctx, key))
+	_, err = storage.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, storage.Delete(ctx, key))
+}
+
+func TestLocalStorageInvalidKey(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+	ctx := context.TODO()
+	for _, key := range []string{"", "../outside", "/etc/passwd", "gain\\..\\..\\outside"} {
+		assert.Error(t, storage.Put(ctx, key, strings.NewReader("content"), 7, "text/plain"), key)
+		_, err := storage.Get(ctx, key)
+		assert.Error(t, err, key)
+		assert.Error(t, storage.Delete(ctx, key), key)
+	}
+}
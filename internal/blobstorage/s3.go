@@ -0,0 +1,163 @@
+package blobstorage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service         = "s3"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3DateFormat      = "20060102T150405Z"
+)
+
+// S3Config points to a bucket of any S3 compatible service, such as AWS S3 or MinIO
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyId     string
+	SecretAccessKey string
+}
+
+type s3Storage struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Storage keeps the files in a bucket, addressed with the path style (endpoint/bucket/key) and
+// authenticated with the AWS Signature Version 4
+func NewS3Storage(config S3Config, client *http.Client, now func() time.Time) Storage {
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	return &s3Storage{config: config, client: client, now: now}
+}
+
+func (s3 *s3Storage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	request, err := s3.newRequest(ctx, http.MethodPut, key, content)
+	if err != nil {
+		return err
+	}
+	request.ContentLength = size
+	request.Header.Set("Content-Type", contentType)
+	response, err := s3.do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (s3 *s3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	request, err := s3.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s3.do(request)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+func (s3 *s3Storage) Delete(ctx context.Context, key string) error {
+	request, err := s3.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	response, err := s3.do(request)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (s3 *s3Storage) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	if key == "" {
+		return nil, fmt.Errorf("invalid blob key %q", key)
+	}
+	objectUrl := fmt.Sprintf("%s/%s/%s", s3.config.Endpoint, s3.config.Bucket, encodePath(key))
+	return http.NewRequestWithContext(ctx, method, objectUrl, body)
+}
+
+func (s3 *s3Storage) do(request *http.Request) (*http.Response, error) {
+	s3.sign(request)
+	response, err := s3.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return nil, ErrNotFound
+	}
+	if response.StatusCode >= http.StatusMultipleChoices {
+		defer response.Body.Close()
+		detail, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return nil, fmt.Errorf("blob storage answered %d: %s", response.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return response, nil
+}
+
+// sign adds the Authorization header of the AWS Signature Version 4, the payload is not signed
+// so the content can be streamed without being read twice
+func (s3 *s3Storage) sign(request *http.Request) {
+	now := s3.now().UTC()
+	amzDate := now.Format(s3DateFormat)
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", now.Format("20060102"), s3.config.Region, s3Service)
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		"host:" + request.URL.Host,
+		"x-amz-content-sha256:" + s3UnsignedPayload,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hashHex(canonicalRequest)}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s3.config.SecretAccessKey), now.Format("20060102"))
+	signingKey = hmacSHA256(signingKey, s3.config.Region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3.config.AccessKeyId, scope, signedHeaders, signature))
+}
+
+func encodePath(key string) string {
+	segments := strings.Split(key, "/")
+	for index, segment := range segments {
+		segments[index] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func hashHex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, content string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(content))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,102 @@
+package blobstorage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var s3Now = func() time.Time { return time.Date(2024, 1, 15, 12, 30, 0, 0, time.UTC) }
+
+func newS3Mock(t *testing.T, handler http.HandlerFunc) (Storage, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	config := S3Config{
+		Endpoint:        server.URL + "/",
+		Region:          "us-east-1",
+		Bucket:          "wallet",
+		AccessKeyId:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	return NewS3Storage(config, server.Client(), s3Now), server
+}
+
+func TestS3StoragePut(t *testing.T) {
+	storage, _ := newS3Mock(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/wallet/user/gain/cd1cc27b/receipt", r.URL.Path)
+		assert.Equal(t, "application/pdf", r.Header.Get("Content-Type"))
+		assert.Equal(t, int64(8), r.ContentLength)
+		assert.Equal(t, "%PDF-1.4", string(body))
+		assert.Equal(t, "20240115T123000Z", r.Header.Get("X-Amz-Date"))
+		assert.Equal(t, "UNSIGNED-PAYLOAD", r.Header.Get("X-Amz-Content-Sha256"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240115/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	err := storage.Put(context.TODO(), "user/gain/cd1cc27b/receipt", strings.NewReader("%PDF-1.4"), 8, "application/pdf")
+	assert.NoError(t, err)
+}
+
+func TestS3StorageGet(t *testing.T) {
+	storage, _ := newS3Mock(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.URL.Path == "/wallet/user/gain/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("%PDF-1.4"))
+	})
+
+	content, err := storage.Get(context.TODO(), "user/gain/receipt")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(content)
+	content.Close()
+	assert.Equal(t, "%PDF-1.4", string(data))
+
+	_, err = storage.Get(context.TODO(), "user/gain/missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestS3StorageDelete(t *testing.T) {
+	storage, _ := newS3Mock(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		switch r.URL.Path {
+		case "/wallet/user/gain/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/wallet/user/gain/denied":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("AccessDenied"))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	assert.NoError(t, storage.Delete(context.TODO(), "user/gain/receipt"))
+	assert.NoError(t, storage.Delete(context.TODO(), "user/gain/missing"))
+	err := storage.Delete(context.TODO(), "user/gain/denied")
+	assert.EqualError(t, err, "blob storage answered 403: AccessDenied")
+}
+
+func TestS3StorageSignature(t *testing.T) {
+	var first, second string
+	storage, _ := newS3Mock(t, func(w http.ResponseWriter, r *http.Request) {
+		if first == "" {
+			first = r.Header.Get("Authorization")
+		} else {
+			second = r.Header.Get("Authorization")
+		}
+	})
+
+	storage.Delete(context.TODO(), "user/gain/receipt")
+	storage.Delete(context.TODO(), "user/gain/another")
+	assert.NotEqual(t, first, second)
+}
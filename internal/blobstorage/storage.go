@@ -0,0 +1,23 @@
+package blobstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+const (
+	DRIVER_LOCAL = "local"
+	DRIVER_S3    = "s3"
+
+	DEFAULT_LOCAL_PATH = "data/attachments"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Storage keeps the content of the files outside of the database, addressed by a key such as "user/gain/id/attachment"
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,224 @@
+package boleto
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const (
+	// KIND_BANK is the boleto issued by a bank to charge a bill, whose barcode starts with the bank code
+	KIND_BANK = "bank"
+	// KIND_COLLECTION is the boleto of utilities and taxes (arrecadação), whose barcode starts with 8
+	KIND_COLLECTION = "collection"
+
+	BARCODE_LENGTH         = 44
+	BANK_LINE_LENGTH       = 47
+	COLLECTION_LINE_LENGTH = 48
+
+	// factorRollover is the number of days after which the due date factor restarts from 1000 (2025-02-22)
+	factorRollover = 9000
+)
+
+var (
+	ErrInvalidLength      = errors.New("the code must have 44, 47 or 48 digits")
+	ErrInvalidCheckDigit  = errors.New("the check digits of the code do not match")
+	ErrInvalidCollection  = errors.New("the collection code must start with 8")
+	ErrInvalidValueFormat = errors.New("the value identifier of the collection code is not valid")
+
+	// factorBase is the date of the due date factor 0, the factor 1000 is 2000-07-03
+	factorBase = time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)
+)
+
+// Boleto is the data read from a barcode or a linha digitável
+type Boleto struct {
+	Kind          string
+	Barcode       string
+	DigitableLine string
+	BankCode      string
+	Value         money.Money
+	DueDate       *time.Time
+}
+
+// Parse reads a barcode (44 digits) or a linha digitável (47 digits for the bank boletos and 48 for
+// the collection ones), the dots, spaces and dashes are ignored. Every check digit is validated.
+// The due date factor restarted in 2025, so now is used to choose the closest of its two possible dates.
+func Parse(code string, now time.Time) (*Boleto, error) {
+	digits := onlyDigits(code)
+	switch len(digits) {
+	case BARCODE_LENGTH:
+		if digits[0] == '8' {
+			return parseCollectionBarcode(digits)
+		}
+		return parseBankBarcode(digits, now)
+	case BANK_LINE_LENGTH:
+		return parseBankLine(digits, now)
+	case COLLECTION_LINE_LENGTH:
+		return parseCollectionLine(digits)
+	}
+	return nil, ErrInvalidLength
+}
+
+func onlyDigits(code string) string {
+	var builder strings.Builder
+	for _, char := range code {
+		if char >= '0' && char <= '9' {
+			builder.WriteRune(char)
+		} else if !strings.ContainsRune(" .-\t\n", char) {
+			return ""
+		}
+	}
+	return builder.String()
+}
+
+func parseBankLine(line string, now time.Time) (*Boleto, error) {
+	fields := []string{line[0:10], line[10:21], line[21:32]}
+	for _, field := range fields {
+		if modulo10(field[:len(field)-1]) != field[len(field)-1] {
+			return nil, ErrInvalidCheckDigit
+		}
+	}
+	barcode := line[0:4] + line[32:47] + line[4:9] + line[10:20] + line[21:31]
+	return parseBankBarcode(barcode, now)
+}
+
+func parseBankBarcode(barcode string, now time.Time) (*Boleto, error) {
+	if bankModulo11(barcode[:4]+barcode[5:]) != barcode[4] {
+		return nil, ErrInvalidCheckDigit
+	}
+	factor, _ := strconv.Atoi(barcode[5:9])
+	cents, _ := strconv.ParseInt(barcode[9:19], 10, 64)
+	return &Boleto{
+		Kind:          KIND_BANK,
+		Barcode:       barcode,
+		DigitableLine: bankLine(barcode),
+		BankCode:      barcode[0:3],
+		Value:         money.FromCents(cents),
+		DueDate:       dueDate(factor, now),
+	}, nil
+}
+
+func parseCollectionLine(line string) (*Boleto, error) {
+	if line[0] != '8' {
+		return nil, ErrInvalidCollection
+	}
+	checkDigit, err := collectionCheckDigit(line[2])
+	if err != nil {
+		return nil, err
+	}
+	var barcode strings.Builder
+	for block := 0; block < 4; block++ {
+		field := line[block*12 : block*12+12]
+		if checkDigit(field[:11]) != field[11] {
+			return nil, ErrInvalidCheckDigit
+		}
+		barcode.WriteString(field[:11])
+	}
+	return parseCollectionBarcode(barcode.String())
+}
+
+func parseCollectionBarcode(barcode string) (*Boleto, error) {
+	checkDigit, err := collectionCheckDigit(barcode[2])
+	if err != nil {
+		return nil, err
+	}
+	if checkDigit(barcode[:3]+barcode[4:]) != barcode[3] {
+		return nil, ErrInvalidCheckDigit
+	}
+	boleto := &Boleto{Kind: KIND_COLLECTION, Barcode: barcode, DigitableLine: collectionLine(barcode, checkDigit)}
+	// The identifiers 7 and 9 tell the value is a reference, such as an amount in another index, not a value in reais
+	if barcode[2] == '6' || barcode[2] == '8' {
+		cents, _ := strconv.ParseInt(barcode[4:15], 10, 64)
+		boleto.Value = money.FromCents(cents)
+	}
+	return boleto, nil
+}
+
+func collectionCheckDigit(valueIdentifier byte) (func(string) byte, error) {
+	switch valueIdentifier {
+	case '6', '7':
+		return modulo10, nil
+	case '8', '9':
+		return collectionModulo11, nil
+	}
+	return nil, ErrInvalidValueFormat
+}
+
+func dueDate(factor int, now time.Time) *time.Time {
+	if factor == 0 {
+		return nil
+	}
+	date := factorBase.AddDate(0, 0, factor)
+	rolled := date.AddDate(0, 0, factorRollover)
+	if rolled.Sub(now).Abs() < date.Sub(now).Abs() {
+		date = rolled
+	}
+	return &date
+}
+
+func bankLine(barcode string) string {
+	field1 := barcode[0:4] + barcode[19:24]
+	field1 += string(modulo10(field1))
+	field2 := barcode[24:34]
+	field2 += string(modulo10(field2))
+	field3 := barcode[34:44]
+	field3 += string(modulo10(field3))
+	return fmt.Sprintf("%s.%s %s.%s %s.%s %s %s",
+		field1[:5], field1[5:], field2[:5], field2[5:], field3[:5], field3[5:], barcode[4:5], barcode[5:19])
+}
+
+func collectionLine(barcode string, checkDigit func(string) byte) string {
+	blocks := make([]string, 4)
+	for block := range blocks {
+		field := barcode[block*11 : block*11+11]
+		blocks[block] = fmt.Sprintf("%s-%c", field, checkDigit(field))
+	}
+	return strings.Join(blocks, " ")
+}
+
+// modulo10 weights the digits with 2 and 1 from right to left, adding the digits of each product
+func modulo10(digits string) byte {
+	sum := 0
+	weight := 2
+	for index := len(digits) - 1; index >= 0; index-- {
+		product := int(digits[index]-'0') * weight
+		sum += product/10 + product%10
+		weight = 3 - weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// modulo11 weights the digits from 2 to 9 from right to left and returns the remainder of the sum
+func modulo11(digits string) int {
+	sum := 0
+	weight := 2
+	for index := len(digits) - 1; index >= 0; index-- {
+		sum += int(digits[index]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+	return sum % 11
+}
+
+// bankModulo11 is the general check digit of the bank boletos, which is never 0
+func bankModulo11(digits string) byte {
+	digit := 11 - modulo11(digits)
+	if digit == 0 || digit == 10 || digit == 11 {
+		digit = 1
+	}
+	return byte('0' + digit)
+}
+
+func collectionModulo11(digits string) byte {
+	remainder := modulo11(digits)
+	if remainder == 0 || remainder == 1 {
+		return '0'
+	}
+	return byte('0' + 11 - remainder)
+}
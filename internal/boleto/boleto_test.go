@@ -0,0 +1,91 @@
+package boleto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var nowMock = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func TestParseBankLine(t *testing.T) {
+	boleto, err := Parse("34191.09123 34567.812341 56789.012301 9 10160000123456", nowMock)
+	assert.NoError(t, err)
+	assert.Equal(t, KIND_BANK, boleto.Kind)
+	assert.Equal(t, "341", boleto.BankCode)
+	assert.Equal(t, "34199101600001234561091234567812345678901230", boleto.Barcode)
+	assert.Equal(t, "34191.09123 34567.812341 56789.012301 9 10160000123456", boleto.DigitableLine)
+	assert.Equal(t, money.FromCents(123456), boleto.Value)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), *boleto.DueDate)
+}
+
+func TestParseBankBarcode(t *testing.T) {
+	boleto, err := Parse("00195935100000079900000002820379001000012317", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "001", boleto.BankCode)
+	assert.Equal(t, "00190.00009 02820.379002 10000.123173 5 93510000007990", boleto.DigitableLine)
+	assert.Equal(t, money.FromCents(7990), boleto.Value)
+	assert.Equal(t, time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC), *boleto.DueDate)
+}
+
+func TestParseBankWithoutDueDate(t *testing.T) {
+	boleto, err := Parse("23793381288600000000900000040006700000000000000", nowMock)
+	assert.NoError(t, err)
+	assert.Nil(t, boleto.DueDate)
+	assert.Equal(t, money.Money(0), boleto.Value)
+}
+
+func TestParseCollectionLine(t *testing.T) {
+	boleto, err := Parse("83650000001-0 57890048123-0 45678901234-5 56789012345-6", nowMock)
+	assert.NoError(t, err)
+	assert.Equal(t, KIND_COLLECTION, boleto.Kind)
+	assert.Equal(t, "83650000001578900481234567890123456789012345", boleto.Barcode)
+	assert.Equal(t, "83650000001-0 57890048123-0 45678901234-5 56789012345-6", boleto.DigitableLine)
+	assert.Equal(t, money.FromCents(15789), boleto.Value)
+	assert.Nil(t, boleto.DueDate)
+	assert.Empty(t, boleto.BankCode)
+}
+
+func TestParseCollectionModulo11(t *testing.T) {
+	boleto, err := Parse("828100000046500000010989765432109870654321098762", nowMock)
+	assert.NoError(t, err)
+	assert.Equal(t, "82810000004500000010987654321098765432109876", boleto.Barcode)
+	assert.Equal(t, money.FromCents(45000), boleto.Value)
+
+	boleto, err = Parse("82810000004500000010987654321098765432109876", nowMock)
+	assert.NoError(t, err)
+	assert.Equal(t, "82810000004-6 50000001098-9 76543210987-0 65432109876-2", boleto.DigitableLine)
+}
+
+func TestParseCollectionReferenceValue(t *testing.T) {
+	boleto, err := Parse("817300000004100000020006000000000000000000000000", nowMock)
+	assert.NoError(t, err)
+	assert.Equal(t, money.Money(0), boleto.Value)
+}
+
+func TestParseInvalid(t *testing.T) {
+	cases := map[string]error{
+		"":     ErrInvalidLength,
+		"1234": ErrInvalidLength,
+		"34191.09123 34567.81234x 56789.012301 9 10160000123456": ErrInvalidLength,
+		"34191.09124 34567.812341 56789.012301 9 10160000123456": ErrInvalidCheckDigit,
+		"34191.09123 34567.812341 56789.012301 8 10160000123456": ErrInvalidCheckDigit,
+		"34199101600001234561091234567812345678901231":           ErrInvalidCheckDigit,
+		"836500000011578900481230456789012345567890123456":       ErrInvalidCheckDigit,
+		"736500000010578900481230456789012345567890123456":       ErrInvalidCollection,
+		"83550000001578900481234567890123456789012345":           ErrInvalidValueFormat,
+	}
+	for code, expected := range cases {
+		_, err := Parse(code, nowMock)
+		assert.ErrorIs(t, err, expected, code)
+	}
+}
+
+func TestDueDateRollover(t *testing.T) {
+	assert.Equal(t, time.Date(2000, 7, 3, 0, 0, 0, 0, time.UTC), *dueDate(1000, time.Date(2000, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2025, 2, 21, 0, 0, 0, 0, time.UTC), *dueDate(9999, nowMock))
+	assert.Equal(t, time.Date(2025, 2, 22, 0, 0, 0, 0, time.UTC), *dueDate(1000, nowMock))
+	assert.Nil(t, dueDate(0, nowMock))
+}
@@ -0,0 +1,97 @@
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+)
+
+type contextKey struct{}
+
+// Conn is what the repositories need to run their statements, satisfied by the database and by a transaction
+type Conn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Transactor runs a function in a database transaction, the repositories called with the context
+// received by the function join that transaction instead of beginning their own
+type Transactor interface {
+	Within(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) Transactor {
+	return &transactor{db: db}
+}
+
+// Within commits the transaction when the function succeeds and rolls it back otherwise.
+// When the context already carries a transaction the function just joins it
+func (t *transactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	if FromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = fn(context.WithValue(ctx, contextKey{}, tx))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func FromContext(ctx context.Context) *sql.Tx {
+	if ctx == nil {
+		return nil
+	}
+	tx, _ := ctx.Value(contextKey{}).(*sql.Tx)
+	return tx
+}
+
+// Tx is the transaction used by a repository, when it was joined from the context it is only
+// finished by the one who began it
+type Tx struct {
+	*sql.Tx
+	joined bool
+}
+
+func (tx *Tx) Commit() error {
+	if tx.joined {
+		return nil
+	}
+	return tx.Tx.Commit()
+}
+
+func (tx *Tx) Rollback() error {
+	if tx.joined {
+		return nil
+	}
+	return tx.Tx.Rollback()
+}
+
+// Begin joins the transaction of the context or begins a new one in the database
+func Begin(ctx context.Context, db *sql.DB) (*Tx, error) {
+	if tx := FromContext(ctx); tx != nil {
+		return &Tx{Tx: tx, joined: true}, nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx}, nil
+}
+
+// Get returns the transaction of the context, so the reads made inside it see its writes, or the database
+func Get(ctx context.Context, db *sql.DB) Conn {
+	if tx := FromContext(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,79 @@
+package dbtx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const insertQuery = "INSERT INTO audit_log (id) VALUES (?)"
+
+func TestWithinCommitsTheJoinedStatements(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer dbMock.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(insertQuery).WithArgs("1").WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectExec(insertQuery).WithArgs("2").WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	err = NewTransactor(dbMock).Within(context.Background(), func(ctx context.Context) error {
+		for _, id := range []string{"1", "2"} {
+			tx, err := Begin(ctx, dbMock)
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, insertQuery, id)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+			err = tx.Commit()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestWithinRollsBackOnError(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer dbMock.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(insertQuery).WithArgs("1").WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectRollback()
+
+	err = NewTransactor(dbMock).Within(context.Background(), func(ctx context.Context) error {
+		_, err := Get(ctx, dbMock).ExecContext(ctx, insertQuery, "1")
+		if err != nil {
+			return err
+		}
+		return errors.New("Audit failed")
+	})
+	assert.EqualError(t, err, "Audit failed")
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBeginWithoutTransaction(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer dbMock.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectCommit()
+
+	tx, err := Begin(context.Background(), dbMock)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, dbMock, Get(context.Background(), dbMock))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
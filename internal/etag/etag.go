@@ -0,0 +1,34 @@
+package etag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+)
+
+const (
+	ETAG_HEADER     = "ETag"
+	IF_MATCH_HEADER = "If-Match"
+)
+
+var ErrInvalid = apperror.PreconditionFailed("The If-Match header must contain the ETag returned by the resource")
+
+// Format builds the strong ETag that represents a version of a record
+func Format(version uint) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// Parse reads the version from an If-Match header value, the weak form (W/"1") is also accepted
+func Parse(value string) (uint, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return 0, ErrInvalid
+	}
+	version, err := strconv.ParseUint(value[1:len(value)-1], 10, 32)
+	if err != nil || version == 0 {
+		return 0, ErrInvalid
+	}
+	return uint(version), nil
+}
@@ -0,0 +1,28 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, `"3"`, Format(3))
+}
+
+func TestParseSuccess(t *testing.T) {
+	version, err := Parse(`"3"`)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), version)
+
+	version, err = Parse(` W/"12" `)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(12), version)
+}
+
+func TestParseFail(t *testing.T) {
+	for _, value := range []string{"", "3", `"`, `""`, `"abc"`, `"0"`, `"-1"`, "*"} {
+		_, err := Parse(value)
+		assert.ErrorIs(t, err, ErrInvalid, value)
+	}
+}
@@ -0,0 +1,161 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+)
+
+const (
+	IDEMPOTENCY_KEY_HEADER     = "Idempotency-Key"
+	IDEMPOTENT_REPLAYED_HEADER = "Idempotent-Replayed"
+	MAX_KEY_LENGTH             = 255
+	DEFAULT_TTL_HOURS          = 24
+	DEFAULT_PURGE_INTERVAL     = time.Hour
+	// MAX_BODY_SIZE bounds the payload kept in memory to be hashed, it fits the attachment uploads of up to 10 MB
+	MAX_BODY_SIZE = 11 << 20
+)
+
+type middleware struct {
+	repository Repository
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// NewMiddleware makes POST requests carrying an Idempotency-Key header safe to retry: the first response is
+// stored per user for the ttl and replayed to any later request with the same key and payload
+func NewMiddleware(repository Repository, ttl time.Duration, now func() time.Time) gin.HandlerFunc {
+	m := &middleware{repository: repository, ttl: ttl, now: now}
+	return m.handle
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(data string) (int, error) {
+	r.body.WriteString(data)
+	return r.ResponseWriter.WriteString(data)
+}
+
+func hashRequest(method string, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (m *middleware) handle(c *gin.Context) {
+	key := c.GetHeader(IDEMPOTENCY_KEY_HEADER)
+	if c.Request.Method != http.MethodPost || key == "" {
+		return
+	}
+	if len(key) > MAX_KEY_LENGTH {
+		problem.Abort(c, apperror.Validation("The Idempotency-Key must have at most 255 characters"))
+		return
+	}
+	user := idpauth.GetUser(c.GetHeader(idpauth.AUTH_HEADER))
+	if user == nil {
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MAX_BODY_SIZE))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		problem.Abort(c, apperror.TooLarge(fmt.Sprintf("The request body must have at most %d MB", MAX_BODY_SIZE>>20)))
+		return
+	}
+	if err != nil {
+		problem.Abort(c, apperror.Validation("The request body could not be read").Wrap(err))
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	requestHash := hashRequest(c.Request.Method, c.Request.URL.Path, body)
+
+	ctx := c.Request.Context()
+	record, err := m.repository.Get(ctx, user.Id, key)
+	if err != nil {
+		problem.Abort(c, err)
+		return
+	}
+	if record != nil && record.CreatedAt.Before(m.now().Add(-m.ttl)) {
+		err = m.repository.Release(ctx, user.Id, key)
+		if err != nil {
+			problem.Abort(c, err)
+			return
+		}
+		record = nil
+	}
+
+	if record != nil {
+		if record.RequestHash != requestHash {
+			problem.Abort(c, apperror.Unprocessable("The Idempotency-Key was already used with a different payload"))
+			return
+		}
+		if !record.IsCompleted() {
+			problem.Abort(c, apperror.Conflict("A request with this Idempotency-Key is still being processed"))
+			return
+		}
+		contentType := "application/json; charset=utf-8"
+		if record.StatusCode >= http.StatusBadRequest {
+			contentType = problem.CONTENT_TYPE
+		}
+		c.Header(IDEMPOTENT_REPLAYED_HEADER, "true")
+		c.Data(record.StatusCode, contentType, record.ResponseBody)
+		c.Abort()
+		return
+	}
+
+	newRecord := Record{UserId: user.Id, Key: key, RequestHash: requestHash, CreatedAt: m.now()}
+	reserved, err := m.repository.Reserve(ctx, newRecord)
+	if err != nil {
+		problem.Abort(c, err)
+		return
+	}
+	if !reserved {
+		problem.Abort(c, apperror.Conflict("A request with this Idempotency-Key is still being processed"))
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+	c.Writer = recorder
+	c.Next()
+
+	// The client may have gone away meanwhile, but the outcome must still be recorded
+	ctx = context.WithoutCancel(ctx)
+	// Server errors are not stored so the client is able to retry the request with the same key
+	if recorder.Status() >= http.StatusInternalServerError {
+		err = m.repository.Release(ctx, user.Id, key)
+		if err != nil {
+			log.Println("Idempotency key release failed:", err)
+		}
+		return
+	}
+	newRecord.StatusCode = recorder.Status()
+	newRecord.ResponseBody = recorder.body.Bytes()
+	err = m.repository.Complete(ctx, newRecord)
+	if err != nil {
+		log.Println("Idempotency key completion failed:", err)
+	}
+}
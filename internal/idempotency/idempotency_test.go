@@ -0,0 +1,215 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+const userIdMock = "5832a502-bede-492d-8dc1-b13b32c30f29"
+
+type repositoryMock struct {
+	records   map[string]*Record
+	getErr    error
+	completed int
+	released  int
+}
+
+func newRepositoryMock() *repositoryMock {
+	return &repositoryMock{records: map[string]*Record{}}
+}
+
+func (r *repositoryMock) Get(ctx context.Context, userId string, key string) (*Record, error) {
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	record, ok := r.records[userId+key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *record
+	return &copied, nil
+}
+
+func (r *repositoryMock) Reserve(ctx context.Context, record Record) (bool, error) {
+	if _, ok := r.records[record.UserId+record.Key]; ok {
+		return false, nil
+	}
+	r.records[record.UserId+record.Key] = &record
+	return true, nil
+}
+
+func (r *repositoryMock) Complete(ctx context.Context, record Record) error {
+	r.completed++
+	r.records[record.UserId+record.Key] = &record
+	return nil
+}
+
+func (r *repositoryMock) Release(ctx context.Context, userId string, key string) error {
+	r.released++
+	delete(r.records, userId+key)
+	return nil
+}
+
+func (r *repositoryMock) Purge(ctx context.Context, createdBefore time.Time) error {
+	return nil
+}
+
+func setupRouter(repository Repository, now time.Time, handlerCalls *int, statusCode int) *gin.Engine {
+	router := gin.Default()
+	router.Use(NewMiddleware(repository, 24*time.Hour, func() time.Time { return now }))
+	handler := func(c *gin.Context) {
+		*handlerCalls++
+		c.JSON(statusCode, gin.H{"calls": *handlerCalls})
+	}
+	router.POST("/v1/gain", handler)
+	router.GET("/v1/gain", handler)
+	return router
+}
+
+func doRequest(router *gin.Engine, method string, key string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, "/v1/gain", strings.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, tokenMock)
+	if key != "" {
+		req.Header.Add(IDEMPOTENCY_KEY_HEADER, key)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddlewareReplayStoredResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repositoryMock := newRepositoryMock()
+	handlerCalls := 0
+	router := setupRouter(repositoryMock, time.Now(), &handlerCalls, http.StatusCreated)
+
+	first := doRequest(router, http.MethodPost, "key-1", `{"value":10}`)
+	second := doRequest(router, http.MethodPost, "key-1", `{"value":10}`)
+
+	assert.Equal(t, 1, handlerCalls)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get(IDEMPOTENT_REPLAYED_HEADER))
+	assert.Equal(t, 1, repositoryMock.completed)
+}
+
+func TestMiddlewareRejectDifferentPayload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repositoryMock := newRepositoryMock()
+	handlerCalls := 0
+	router := setupRouter(repositoryMock, time.Now(), &handlerCalls, http.StatusCreated)
+
+	doRequest(router, http.MethodPost, "key-1", `{"value":10}`)
+	w := doRequest(router, http.MethodPost, "key-1", `{"value":20}`)
+
+	assert.Equal(t, 1, handlerCalls)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestMiddlewareRequestInProgress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repositoryMock := newRepositoryMock()
+	handlerCalls := 0
+	now := time.Now()
+	router := setupRouter(repositoryMock, now, &handlerCalls, http.StatusCreated)
+	repositoryMock.records[userIdMock+"key-1"] = &Record{
+		UserId:      userIdMock,
+		Key:         "key-1",
+		RequestHash: hashRequest(http.MethodPost, "/v1/gain", []byte(`{"value":10}`)),
+		CreatedAt:   now,
+	}
+
+	w := doRequest(router, http.MethodPost, "key-1", `{"value":10}`)
+
+	assert.Equal(t, 0, handlerCalls)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestMiddlewareExpiredKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repositoryMock := newRepositoryMock()
+	handlerCalls := 0
+	now := time.Now()
+	router := setupRouter(repositoryMock, now, &handlerCalls, http.StatusCreated)
+	repositoryMock.records[userIdMock+"key-1"] = &Record{
+		UserId:       userIdMock,
+		Key:          "key-1",
+		RequestHash:  hashRequest(http.MethodPost, "/v1/gain", []byte(`{"value":20}`)),
+		StatusCode:   http.StatusCreated,
+		ResponseBody: []byte(`{"calls":0}`),
+		CreatedAt:    now.Add(-25 * time.Hour),
+	}
+
+	w := doRequest(router, http.MethodPost, "key-1", `{"value":10}`)
+
+	assert.Equal(t, 1, handlerCalls)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 1, repositoryMock.released)
+}
+
+func TestMiddlewareServerErrorReleaseKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repositoryMock := newRepositoryMock()
+	handlerCalls := 0
+	router := setupRouter(repositoryMock, time.Now(), &handlerCalls, http.StatusInternalServerError)
+
+	doRequest(router, http.MethodPost, "key-1", `{"value":10}`)
+	doRequest(router, http.MethodPost, "key-1", `{"value":10}`)
+
+	assert.Equal(t, 2, handlerCalls)
+	assert.Equal(t, 2, repositoryMock.released)
+	assert.Equal(t, 0, repositoryMock.completed)
+}
+
+func TestMiddlewareIgnoreRequestWithoutKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repositoryMock := newRepositoryMock()
+	handlerCalls := 0
+	router := setupRouter(repositoryMock, time.Now(), &handlerCalls, http.StatusCreated)
+
+	doRequest(router, http.MethodPost, "", `{"value":10}`)
+	doRequest(router, http.MethodPost, "", `{"value":10}`)
+	doRequest(router, http.MethodGet, "key-1", "")
+	doRequest(router, http.MethodGet, "key-1", "")
+
+	assert.Equal(t, 4, handlerCalls)
+	assert.Empty(t, repositoryMock.records)
+}
+
+func TestMiddlewareRepositoryFail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repositoryMock := newRepositoryMock()
+	repositoryMock.getErr = errors.New("An error has been ocurred")
+	handlerCalls := 0
+	router := setupRouter(repositoryMock, time.Now(), &handlerCalls, http.StatusCreated)
+
+	w := doRequest(router, http.MethodPost, "key-1", `{"value":10}`)
+
+	assert.Equal(t, 0, handlerCalls)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestMiddlewareBodyTooLarge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repositoryMock := newRepositoryMock()
+	handlerCalls := 0
+	router := setupRouter(repositoryMock, time.Now(), &handlerCalls, http.StatusCreated)
+
+	w := doRequest(router, http.MethodPost, "key-1", strings.Repeat("a", MAX_BODY_SIZE+1))
+
+	assert.Equal(t, 0, handlerCalls)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"The request body must have at most 11 MB"}`, w.Body.String())
+	assert.Empty(t, repositoryMock.records)
+}
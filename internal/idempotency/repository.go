@@ -0,0 +1,142 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Repository interface {
+	Get(ctx context.Context, userId string, key string) (*Record, error)
+	Reserve(ctx context.Context, record Record) (bool, error)
+	Complete(ctx context.Context, record Record) error
+	Release(ctx context.Context, userId string, key string) error
+	Purge(ctx context.Context, createdBefore time.Time) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Get(ctx context.Context, userId string, key string) (*Record, error) {
+	results, err := r.db.QueryContext(ctx, `
+		SELECT
+			user_id,
+			idempotency_key,
+			request_hash,
+			status_code,
+			response_body,
+			created_at
+		FROM
+			idempotency_key
+		WHERE user_id = ? AND idempotency_key = ?`, userId, key)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	if !results.Next() {
+		return nil, nil
+	}
+	var record Record
+	var responseBody sql.NullString
+	var createdAtTimestamp sql.NullInt64
+	err = results.Scan(
+		&record.UserId,
+		&record.Key,
+		&record.RequestHash,
+		&record.StatusCode,
+		&responseBody,
+		&createdAtTimestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	record.ResponseBody = []byte(responseBody.String)
+	record.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+	return &record, nil
+}
+
+// Reserve stores the key as in progress and reports false when another request already holds it
+func (r *repository) Reserve(ctx context.Context, record Record) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT IGNORE INTO idempotency_key (user_id, idempotency_key, request_hash, status_code, created_at) 
+		VALUES (?, ?, ?, 0, ?)`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+	result, err := stmt.Exec(record.UserId, record.Key, record.RequestHash, record.CreatedAt.Unix())
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+func (r *repository) Complete(ctx context.Context, record Record) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		UPDATE idempotency_key SET status_code = ?, response_body = ? 
+		WHERE user_id = ? AND idempotency_key = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(record.StatusCode, string(record.ResponseBody), record.UserId, record.Key)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *repository) Release(ctx context.Context, userId string, key string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM idempotency_key WHERE user_id = ? AND idempotency_key = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(userId, key)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *repository) Purge(ctx context.Context, createdBefore time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM idempotency_key WHERE created_at < ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(createdBefore.Unix())
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
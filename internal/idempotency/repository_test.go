@@ -0,0 +1,192 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRecordSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	createdAt := time.Unix(time.Now().Unix(), 0)
+	_repository := NewRepository(dbMock)
+
+	rows := sqlMock.NewRows([]string{"user_id", "idempotency_key", "request_hash", "status_code", "response_body", "created_at"}).
+		AddRow("User1", "key-1", "hash", 201, `{"id":"1"}`, createdAt.Unix())
+	sqlMock.ExpectQuery(`
+		SELECT
+			user_id,
+			idempotency_key,
+			request_hash,
+			status_code,
+			response_body,
+			created_at
+		FROM
+			idempotency_key
+		WHERE user_id = ? AND idempotency_key = ?`).
+		WithArgs("User1", "key-1").
+		WillReturnRows(rows)
+
+	record, err := _repository.Get(context.Background(), "User1", "key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Record{
+		UserId:       "User1",
+		Key:          "key-1",
+		RequestHash:  "hash",
+		StatusCode:   201,
+		ResponseBody: []byte(`{"id":"1"}`),
+		CreatedAt:    createdAt,
+	}, record)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetRecordNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := NewRepository(dbMock)
+
+	rows := sqlMock.NewRows([]string{"user_id", "idempotency_key", "request_hash", "status_code", "response_body", "created_at"})
+	sqlMock.ExpectQuery(`
+		SELECT
+			user_id,
+			idempotency_key,
+			request_hash,
+			status_code,
+			response_body,
+			created_at
+		FROM
+			idempotency_key
+		WHERE user_id = ? AND idempotency_key = ?`).
+		WithArgs("User1", "key-1").
+		WillReturnRows(rows)
+
+	record, err := _repository.Get(context.Background(), "User1", "key-1")
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func TestReserveRecord(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	record := Record{UserId: "User1", Key: "key-1", RequestHash: "hash", CreatedAt: now}
+	_repository := NewRepository(dbMock)
+
+	for _, rowsAffected := range []int64{1, 0} {
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectPrepare(`
+		INSERT IGNORE INTO idempotency_key (user_id, idempotency_key, request_hash, status_code, created_at) 
+		VALUES (?, ?, ?, 0, ?)`).
+			ExpectExec().
+			WithArgs("User1", "key-1", "hash", now.Unix()).
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+		sqlMock.ExpectCommit()
+	}
+
+	reserved, err := _repository.Reserve(context.Background(), record)
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+
+	reserved, err = _repository.Reserve(context.Background(), record)
+	assert.NoError(t, err)
+	assert.False(t, reserved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCompleteRecordSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	record := Record{UserId: "User1", Key: "key-1", StatusCode: 201, ResponseBody: []byte(`{"id":"1"}`)}
+	_repository := NewRepository(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		UPDATE idempotency_key SET status_code = ?, response_body = ? 
+		WHERE user_id = ? AND idempotency_key = ?`).
+		ExpectExec().
+		WithArgs(201, `{"id":"1"}`, "User1", "key-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.Complete(context.Background(), record)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestReleaseRecordFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := NewRepository(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`DELETE FROM idempotency_key WHERE user_id = ? AND idempotency_key = ?`).
+		ExpectExec().
+		WithArgs("User1", "key-1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.Release(context.Background(), "User1", "key-1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPurgeRecordSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := NewRepository(dbMock)
+	createdBefore := time.Now().Add(-24 * time.Hour)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`DELETE FROM idempotency_key WHERE created_at < ?`).
+		ExpectExec().
+		WithArgs(createdBefore.Unix()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	sqlMock.ExpectCommit()
+
+	err = _repository.Purge(context.Background(), createdBefore)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,17 @@
+package idempotency
+
+import "time"
+
+type Record struct {
+	UserId       string
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	CreatedAt    time.Time
+}
+
+// IsCompleted reports whether the request that reserved the key has already stored its response
+func (r *Record) IsCompleted() bool {
+	return r.StatusCode != 0
+}
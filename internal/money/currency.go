@@ -0,0 +1,15 @@
+package money
+
+import "strings"
+
+// CURRENCY_DEFAULT is the currency assumed for the records that do not inform one
+const CURRENCY_DEFAULT = "BRL"
+
+// NormalizeCurrency returns the ISO 4217 code in upper case, the blank code is the default currency
+func NormalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return CURRENCY_DEFAULT
+	}
+	return code
+}
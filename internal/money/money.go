@@ -0,0 +1,243 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount of currency kept in cents, it matches the DECIMAL(15,2) columns.
+// On the wire it keeps being a plain JSON number, such as 1500 or 15.5
+type Money int64
+
+func FromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// FromFloat converts a float amount rounding half away from zero to the nearest cent
+func FromFloat(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// Parse reads a decimal amount such as "1500", "15.5" or "-0.25".
+// Digits beyond the cents are rounded half away from zero, as done by the database.
+func Parse(text string) (Money, error) {
+	text = strings.TrimSpace(text)
+	if strings.ContainsAny(text, "eE") {
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q", text)
+		}
+		return FromFloat(number), nil
+	}
+	negative := strings.HasPrefix(text, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+	integerPart, fractionPart, _ := strings.Cut(digits, ".")
+	if integerPart == "" && fractionPart == "" {
+		return 0, fmt.Errorf("invalid amount %q", text)
+	}
+	if integerPart == "" {
+		integerPart = "0"
+	}
+	units, err := strconv.ParseInt(integerPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", text)
+	}
+	if units > math.MaxInt64/100-1 {
+		return 0, fmt.Errorf("amount %q is out of range", text)
+	}
+	for _, digit := range fractionPart {
+		if digit < '0' || digit > '9' {
+			return 0, fmt.Errorf("invalid amount %q", text)
+		}
+	}
+	fraction := (fractionPart + "000")[:3]
+	thousandths, _ := strconv.ParseInt(fraction, 10, 64)
+	cents := units*100 + thousandths/10
+	if thousandths%10 >= 5 {
+		cents++
+	}
+	if negative {
+		cents = -cents
+	}
+	return Money(cents), nil
+}
+
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+// Float64 is meant for ratios and reports only, never to store or sum amounts
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+// Multiply returns the amount times a quantity, such as the total of a recurrence
+func (m Money) Multiply(quantity int64) Money {
+	return m * Money(quantity)
+}
+
+// Split divides the amount in parts that sum exactly the amount,
+// the remaining cents are given to the first parts
+func (m Money) Split(parts int) []Money {
+	if parts <= 0 {
+		return nil
+	}
+	quotient := m / Money(parts)
+	remainder := m % Money(parts)
+	result := make([]Money, parts)
+	for index := range result {
+		result[index] = quotient
+		if remainder > 0 {
+			result[index]++
+			remainder--
+		} else if remainder < 0 {
+			result[index]--
+			remainder++
+		}
+	}
+	return result
+}
+
+// Allocate divides the amount proportionally to the weights in parts that sum exactly the amount,
+// the remaining cents are given to the first parts with weight
+func (m Money) Allocate(weights ...uint) []Money {
+	var totalWeight uint
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return nil
+	}
+	result := make([]Money, len(weights))
+	remainder := m
+	for index, weight := range weights {
+		result[index] = m * Money(weight) / Money(totalWeight)
+		remainder -= result[index]
+	}
+	for index := 0; remainder != 0; index = (index + 1) % len(weights) {
+		if weights[index] == 0 {
+			continue
+		}
+		if remainder > 0 {
+			result[index]++
+			remainder--
+		} else {
+			result[index]--
+			remainder++
+		}
+	}
+	return result
+}
+
+func Sum(amounts ...Money) Money {
+	var total Money
+	for _, amount := range amounts {
+		total += amount
+	}
+	return total
+}
+
+// String formats the amount with two decimal places, as stored in the database
+func (m Money) String() string {
+	cents := int64(m)
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON writes the shortest decimal form, the same produced before for float64 values
+func (m Money) MarshalJSON() ([]byte, error) {
+	text := m.String()
+	text = strings.TrimSuffix(text, "0")
+	text = strings.TrimSuffix(text, ".0")
+	return []byte(text), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if strings.HasPrefix(text, `"`) || !json.Valid(data) {
+		return &json.UnmarshalTypeError{Value: "string", Type: reflect.TypeOf(*m)}
+	}
+	amount, err := Parse(text)
+	if err != nil {
+		return &json.UnmarshalTypeError{Value: "number " + text, Type: reflect.TypeOf(*m)}
+	}
+	*m = amount
+	return nil
+}
+
+// Scan reads a DECIMAL column, which the driver delivers as text
+func (m *Money) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		amount, err := Parse(string(value))
+		if err != nil {
+			return err
+		}
+		*m = amount
+		return nil
+	case string:
+		amount, err := Parse(value)
+		if err != nil {
+			return err
+		}
+		*m = amount
+		return nil
+	case int64:
+		*m = Money(value * 100)
+		return nil
+	case float64:
+		*m = FromFloat(value)
+		return nil
+	}
+	return fmt.Errorf("unsupported type %T for money", src)
+}
+
+// Value writes the amount as an exact decimal text
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// NullMoney represents an amount that may be null, like the sql.Null types
+type NullMoney struct {
+	Money Money
+	Valid bool
+}
+
+func (n *NullMoney) Scan(src any) error {
+	if src == nil {
+		n.Money, n.Valid = 0, false
+		return nil
+	}
+	n.Valid = true
+	return n.Money.Scan(src)
+}
+
+func (n NullMoney) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Money.Value()
+}
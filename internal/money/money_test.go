@@ -0,0 +1,141 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]Money{
+		"1500":     150000,
+		"1500.00":  150000,
+		"15.5":     1550,
+		"0.1":      10,
+		".25":      25,
+		"-0.25":    -25,
+		"10.005":   1001,
+		"10.004":   1000,
+		"-10.005":  -1001,
+		"1.5e2":    15000,
+		" 200.30 ": 20030,
+	}
+	for text, expected := range cases {
+		amount, err := Parse(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, expected, amount, text)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, text := range []string{"", ".", "-", "ten", "10.5a", "1.2.3", "99999999999999999999"} {
+		_, err := Parse(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestSumIsExact(t *testing.T) {
+	var floatTotal float64
+	var total Money
+	for i := 0; i < 1000; i++ {
+		floatTotal += 0.1
+		total = total.Add(FromFloat(0.1))
+	}
+	assert.NotEqual(t, 100.0, floatTotal)
+	assert.Equal(t, FromCents(10000), total)
+	assert.Equal(t, FromCents(350), Sum(FromCents(100), FromCents(200), FromCents(50)))
+	assert.Equal(t, FromCents(50), FromCents(200).Sub(FromCents(150)))
+	assert.Equal(t, FromCents(3600), FromCents(300).Multiply(12))
+}
+
+func TestSplit(t *testing.T) {
+	assert.Equal(t, []Money{34, 33, 33}, FromCents(100).Split(3))
+	assert.Equal(t, []Money{-34, -33, -33}, FromCents(-100).Split(3))
+	assert.Equal(t, []Money{5000, 5000}, FromCents(10000).Split(2))
+	assert.Nil(t, FromCents(100).Split(0))
+	assert.Equal(t, FromCents(100000), Sum(FromCents(100000).Split(7)...))
+}
+
+func TestAllocate(t *testing.T) {
+	assert.Equal(t, []Money{3334, 3333, 3333}, FromCents(10000).Allocate(1, 1, 1))
+	assert.Equal(t, []Money{2500, 5000, 2500}, FromCents(10000).Allocate(1, 2, 1))
+	assert.Equal(t, []Money{0, 34, 67}, FromCents(101).Allocate(0, 1, 2))
+	assert.Equal(t, []Money{-34, -67}, FromCents(-101).Allocate(1, 2))
+	assert.Nil(t, FromCents(100).Allocate(0, 0))
+	assert.Nil(t, FromCents(100).Allocate())
+	assert.Equal(t, FromCents(4958), Sum(FromCents(4958).Allocate(3, 2, 2, 1)...))
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "1500.00", FromCents(150000).String())
+	assert.Equal(t, "0.05", FromCents(5).String())
+	assert.Equal(t, "-12.30", FromCents(-1230).String())
+}
+
+func TestJSON(t *testing.T) {
+	type payload struct {
+		Value Money  `json:"value"`
+		Other *Money `json:"other,omitempty"`
+	}
+	data, err := json.Marshal(payload{Value: FromCents(150000)})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"value":1500}`, string(data))
+
+	data, _ = json.Marshal(payload{Value: FromCents(1550)})
+	assert.Equal(t, `{"value":15.5}`, string(data))
+	data, _ = json.Marshal(payload{Value: FromCents(1525)})
+	assert.Equal(t, `{"value":15.25}`, string(data))
+	data, _ = json.Marshal(payload{Value: FromCents(-5)})
+	assert.Equal(t, `{"value":-0.05}`, string(data))
+
+	var decoded payload
+	err = json.Unmarshal([]byte(`{"value": 1500.1, "other": 2}`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, FromCents(150010), decoded.Value)
+	assert.Equal(t, FromCents(200), *decoded.Other)
+
+	err = json.Unmarshal([]byte(`{"value": null}`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, FromCents(150010), decoded.Value)
+}
+
+func TestJSONInvalidType(t *testing.T) {
+	var decoded struct {
+		Value Money `json:"value"`
+	}
+	err := json.Unmarshal([]byte(`{"value": "ten"}`), &decoded)
+	var typeErr *json.UnmarshalTypeError
+	assert.True(t, errors.As(err, &typeErr))
+}
+
+func TestScan(t *testing.T) {
+	var amount Money
+	assert.NoError(t, amount.Scan([]byte("1500.10")))
+	assert.Equal(t, FromCents(150010), amount)
+	assert.NoError(t, amount.Scan("0.99"))
+	assert.Equal(t, FromCents(99), amount)
+	assert.NoError(t, amount.Scan(float64(10.5)))
+	assert.Equal(t, FromCents(1050), amount)
+	assert.NoError(t, amount.Scan(int64(3)))
+	assert.Equal(t, FromCents(300), amount)
+	assert.Error(t, amount.Scan(true))
+
+	var nullable NullMoney
+	assert.NoError(t, nullable.Scan(nil))
+	assert.False(t, nullable.Valid)
+	assert.NoError(t, nullable.Scan([]byte("20.00")))
+	assert.True(t, nullable.Valid)
+	assert.Equal(t, FromCents(2000), nullable.Money)
+}
+
+func TestValue(t *testing.T) {
+	value, err := FromCents(150010).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1500.10", value)
+
+	value, err = NullMoney{}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
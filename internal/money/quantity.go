@@ -0,0 +1,109 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// Quantity is a number of units of an asset kept with eight decimal places, such as 0.00125 of a crypto coin.
+// It shares the scale and the decimal form of the Rate, matching the same DECIMAL(18,8) columns
+type Quantity int64
+
+// ParseQuantity reads a decimal quantity such as "10.5", digits beyond the eighth decimal place are rounded
+func ParseQuantity(text string) (Quantity, error) {
+	rate, err := ParseRate(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", text)
+	}
+	return Quantity(rate), nil
+}
+
+// String formats the quantity without the trailing zeros, such as "10.5"
+func (q Quantity) String() string {
+	return Rate(q).String()
+}
+
+// Times returns the amount of the quantity at the unit price, rounding half away from zero to the cent
+func (m Money) Times(quantity Quantity) Money {
+	return m.Convert(Rate(quantity))
+}
+
+// Prorate returns the share of the amount for the part of the whole, such as the cost of the units sold from a position,
+// rounding half away from zero to the cent
+func (m Money) Prorate(part Quantity, whole Quantity) Money {
+	if whole == 0 {
+		return 0
+	}
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(int64(part)))
+	divisor := big.NewInt(int64(whole))
+	quotient, remainder := new(big.Int).QuoRem(product, divisor, new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).CmpAbs(divisor) >= 0 {
+		if product.Sign()*divisor.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Money(quotient.Int64())
+}
+
+// Per returns the amount of each unit of the quantity, such as the average price of a position from its cost
+func (m Money) Per(quantity Quantity) Money {
+	return m.Prorate(Quantity(RATE_SCALE), quantity)
+}
+
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if strings.HasPrefix(text, `"`) || !json.Valid(data) {
+		return &json.UnmarshalTypeError{Value: "string", Type: reflect.TypeOf(*q)}
+	}
+	quantity, err := ParseQuantity(text)
+	if err != nil {
+		return &json.UnmarshalTypeError{Value: "number " + text, Type: reflect.TypeOf(*q)}
+	}
+	*q = quantity
+	return nil
+}
+
+// Scan reads a DECIMAL column, which the driver delivers as text
+func (q *Quantity) Scan(src any) error {
+	switch value := src.(type) {
+	case []byte:
+		return q.parse(string(value))
+	case string:
+		return q.parse(value)
+	case int64:
+		*q = Quantity(value * RATE_SCALE)
+		return nil
+	case float64:
+		*q = Quantity(math.Round(value * RATE_SCALE))
+		return nil
+	}
+	return fmt.Errorf("unsupported type %T for quantity", src)
+}
+
+func (q *Quantity) parse(text string) error {
+	quantity, err := ParseQuantity(text)
+	if err != nil {
+		return err
+	}
+	*q = quantity
+	return nil
+}
+
+// Value writes the quantity as an exact decimal text
+func (q Quantity) Value() (driver.Value, error) {
+	return q.String(), nil
+}
@@ -0,0 +1,67 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuantity(t *testing.T) {
+	quantity, err := ParseQuantity("10.5")
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity(1050000000), quantity)
+	assert.Equal(t, "10.5", quantity.String())
+	assert.Equal(t, "0.00125", Quantity(125000).String())
+
+	_, err = ParseQuantity("ten")
+	assert.EqualError(t, err, `invalid quantity "ten"`)
+}
+
+func TestTimes(t *testing.T) {
+	assert.Equal(t, FromCents(341250), FromCents(3250).Times(Quantity(10500000000)))
+	// 0.00125 units at 250000.00 each
+	assert.Equal(t, FromCents(31250), FromCents(25000000).Times(Quantity(125000)))
+}
+
+func TestProrate(t *testing.T) {
+	// a third of the cost of a position of 3 units
+	assert.Equal(t, FromCents(3333), FromCents(10000).Prorate(Quantity(100000000), Quantity(300000000)))
+	assert.Equal(t, FromCents(6667), FromCents(20000).Prorate(Quantity(100000000), Quantity(300000000)))
+	assert.Equal(t, FromCents(-3333), FromCents(-10000).Prorate(Quantity(100000000), Quantity(300000000)))
+	assert.Equal(t, FromCents(0), FromCents(10000).Prorate(Quantity(100000000), 0))
+}
+
+func TestPer(t *testing.T) {
+	assert.Equal(t, FromCents(3310), FromCents(99300).Per(Quantity(3000000000)))
+	assert.Equal(t, FromCents(25000000), FromCents(31250).Per(Quantity(125000)))
+}
+
+func TestQuantityJSON(t *testing.T) {
+	var payload struct {
+		Quantity Quantity `json:"quantity"`
+	}
+	err := json.Unmarshal([]byte(`{"quantity": 0.00125}`), &payload)
+	assert.NoError(t, err)
+	assert.Equal(t, Quantity(125000), payload.Quantity)
+
+	body, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"quantity":0.00125}`, string(body))
+
+	err = json.Unmarshal([]byte(`{"quantity": "1"}`), &payload)
+	assert.Error(t, err)
+}
+
+func TestQuantityScan(t *testing.T) {
+	var quantity Quantity
+	assert.NoError(t, quantity.Scan([]byte("10.50000000")))
+	assert.Equal(t, Quantity(1050000000), quantity)
+	assert.NoError(t, quantity.Scan(int64(3)))
+	assert.Equal(t, Quantity(300000000), quantity)
+	assert.Error(t, quantity.Scan(true))
+
+	value, err := Quantity(1050000000).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.5", value)
+}
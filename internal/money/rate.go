@@ -0,0 +1,150 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RATE_SCALE is the number of units of a Rate that represent 1, it matches the DECIMAL(18,8) columns
+const RATE_SCALE = 100000000
+
+// Rate is an exchange rate kept with eight decimal places, such as 5.12345678 BRL for each USD
+type Rate int64
+
+// ParseRate reads a decimal rate such as "5.1234", digits beyond the eighth decimal place are rounded
+func ParseRate(text string) (Rate, error) {
+	text = strings.TrimSpace(text)
+	negative := strings.HasPrefix(text, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+	integerPart, fractionPart, _ := strings.Cut(digits, ".")
+	if integerPart == "" && fractionPart == "" {
+		return 0, fmt.Errorf("invalid rate %q", text)
+	}
+	if integerPart == "" {
+		integerPart = "0"
+	}
+	units, err := strconv.ParseInt(integerPart, 10, 64)
+	if err != nil || units > math.MaxInt64/RATE_SCALE-1 {
+		return 0, fmt.Errorf("invalid rate %q", text)
+	}
+	for _, digit := range fractionPart {
+		if digit < '0' || digit > '9' {
+			return 0, fmt.Errorf("invalid rate %q", text)
+		}
+	}
+	fraction := (fractionPart + "000000000")[:9]
+	scaled, _ := strconv.ParseInt(fraction, 10, 64)
+	value := units*RATE_SCALE + scaled/10
+	if scaled%10 >= 5 {
+		value++
+	}
+	if negative {
+		value = -value
+	}
+	return Rate(value), nil
+}
+
+// String formats the rate without the trailing zeros, such as "5.1234"
+func (r Rate) String() string {
+	value := int64(r)
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	fraction := strings.TrimRight(fmt.Sprintf("%08d", value%RATE_SCALE), "0")
+	if fraction == "" {
+		return fmt.Sprintf("%s%d", sign, value/RATE_SCALE)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, value/RATE_SCALE, fraction)
+}
+
+// Convert returns the amount in the quote currency of the rate, rounding half away from zero to the cent
+func (m Money) Convert(rate Rate) Money {
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(int64(rate)))
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(RATE_SCALE), new(big.Int))
+	if new(big.Int).Abs(remainder).Cmp(big.NewInt(RATE_SCALE/2)) >= 0 {
+		if product.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Money(quotient.Int64())
+}
+
+// Invert returns the rate of the opposite direction, such as USD for each BRL from BRL for each USD
+func (r Rate) Invert() Rate {
+	if r == 0 {
+		return 0
+	}
+	scale := big.NewInt(RATE_SCALE)
+	numerator := new(big.Int).Mul(scale, scale)
+	quotient, remainder := new(big.Int).QuoRem(numerator, big.NewInt(int64(r)), new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).CmpAbs(big.NewInt(int64(r))) >= 0 {
+		if r < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Rate(quotient.Int64())
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if strings.HasPrefix(text, `"`) || !json.Valid(data) {
+		return &json.UnmarshalTypeError{Value: "string", Type: reflect.TypeOf(*r)}
+	}
+	rate, err := ParseRate(text)
+	if err != nil {
+		return &json.UnmarshalTypeError{Value: "number " + text, Type: reflect.TypeOf(*r)}
+	}
+	*r = rate
+	return nil
+}
+
+// Scan reads a DECIMAL column, which the driver delivers as text
+func (r *Rate) Scan(src any) error {
+	switch value := src.(type) {
+	case []byte:
+		rate, err := ParseRate(string(value))
+		if err != nil {
+			return err
+		}
+		*r = rate
+		return nil
+	case string:
+		rate, err := ParseRate(value)
+		if err != nil {
+			return err
+		}
+		*r = rate
+		return nil
+	case int64:
+		*r = Rate(value * RATE_SCALE)
+		return nil
+	case float64:
+		*r = Rate(math.Round(value * RATE_SCALE))
+		return nil
+	}
+	return fmt.Errorf("unsupported type %T for rate", src)
+}
+
+// Value writes the rate as an exact decimal text
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
@@ -0,0 +1,82 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRate(t *testing.T) {
+	cases := map[string]Rate{
+		"5":           500000000,
+		"5.1234":      512340000,
+		"0.18":        18000000,
+		"5.123456789": 512345679,
+		" 1.00000001": 100000001,
+	}
+	for text, expected := range cases {
+		rate, err := ParseRate(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, expected, rate, text)
+	}
+	for _, text := range []string{"", ".", "five", "5,12", "1.2.3"} {
+		_, err := ParseRate(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestRateString(t *testing.T) {
+	assert.Equal(t, "5.1234", Rate(512340000).String())
+	assert.Equal(t, "5", Rate(500000000).String())
+	assert.Equal(t, "0.00000001", Rate(1).String())
+}
+
+func TestConvert(t *testing.T) {
+	usdToBrl, _ := ParseRate("5.1234")
+	assert.Equal(t, FromCents(51234), FromCents(10000).Convert(usdToBrl))
+	assert.Equal(t, FromCents(5), FromCents(1).Convert(usdToBrl))
+	assert.Equal(t, FromCents(-5), FromCents(-1).Convert(usdToBrl))
+
+	brlToUsd, _ := ParseRate("0.19518")
+	assert.Equal(t, FromCents(2928), FromCents(15000).Convert(brlToUsd))
+	assert.Equal(t, FromCents(15000), FromCents(15000).Convert(Rate(RATE_SCALE)))
+}
+
+func TestInvert(t *testing.T) {
+	usdToBrl, _ := ParseRate("5")
+	assert.Equal(t, "0.2", usdToBrl.Invert().String())
+	usdToBrl, _ = ParseRate("5.1234")
+	assert.Equal(t, "0.19518289", usdToBrl.Invert().String())
+	assert.Equal(t, Rate(0), Rate(0).Invert())
+}
+
+func TestRateJSON(t *testing.T) {
+	var payload struct {
+		Rate Rate `json:"rate"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"rate": 5.1234}`), &payload))
+	assert.Equal(t, Rate(512340000), payload.Rate)
+
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"rate":5.1234}`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"rate": "5.1234"}`), &payload))
+}
+
+func TestRateScan(t *testing.T) {
+	var rate Rate
+	assert.NoError(t, rate.Scan([]byte("5.12340000")))
+	assert.Equal(t, Rate(512340000), rate)
+	assert.Error(t, rate.Scan(true))
+
+	value, err := Rate(512340000).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "5.1234", value)
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	assert.Equal(t, "BRL", NormalizeCurrency(""))
+	assert.Equal(t, "USD", NormalizeCurrency(" usd "))
+}
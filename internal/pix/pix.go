@@ -0,0 +1,162 @@
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+// The ids of the fields of the BR Code, the EMV QR code adopted by the PIX
+const (
+	idPayloadFormat      = "00"
+	idInitiationMethod   = "01"
+	idMerchantAccountMin = 26
+	idMerchantAccountMax = 51
+	idCurrency           = "53"
+	idAmount             = "54"
+	idMerchantName       = "59"
+	idMerchantCity       = "60"
+	idPostalCode         = "61"
+	idAdditionalData     = "62"
+	idCRC                = "63"
+
+	idAccountGUI  = "00"
+	idAccountKey  = "01"
+	idAccountInfo = "02"
+	idAccountURL  = "25"
+	idTxId        = "05"
+
+	// GUI identifies the merchant account of the PIX among the other payment arrangements
+	GUI = "br.gov.bcb.pix"
+	// CURRENCY_BRL is the ISO 4217 numeric code of the real, the only currency of the PIX
+	CURRENCY_BRL = "986"
+
+	initiationDynamic = "12"
+	// noTxId is the txid of the static codes that do not identify the payment
+	noTxId = "***"
+)
+
+var (
+	ErrInvalidFormat   = errors.New("the code is not a BR Code")
+	ErrInvalidCRC      = errors.New("the CRC of the code does not match")
+	ErrNotPix          = errors.New("the code has no PIX merchant account")
+	ErrInvalidAmount   = errors.New("the amount of the code is not valid")
+	ErrInvalidCurrency = errors.New("the currency of the code is not the real")
+)
+
+// Payment is the data read from a PIX copia e cola or from the content of its QR code
+type Payment struct {
+	Key          string
+	URL          string
+	Info         string
+	MerchantName string
+	MerchantCity string
+	PostalCode   string
+	Amount       money.Money
+	TxId         string
+	Dynamic      bool
+}
+
+// Parse reads the BR Code of a PIX, validating its CRC. The amount is zero when the payer chooses it.
+// The dynamic codes carry a URL instead of the key, the payment data behind it is not fetched.
+func Parse(code string) (*Payment, error) {
+	code = strings.TrimSpace(code)
+	if len(code) < 8 || !strings.HasPrefix(code, idPayloadFormat+"0201") {
+		return nil, ErrInvalidFormat
+	}
+	crcField := code[len(code)-8:]
+	if crcField[:4] != idCRC+"04" {
+		return nil, ErrInvalidFormat
+	}
+	if !strings.EqualFold(crcField[4:], fmt.Sprintf("%04X", crc16(code[:len(code)-4]))) {
+		return nil, ErrInvalidCRC
+	}
+	fields, err := readFields(code[:len(code)-8])
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &Payment{
+		MerchantName: strings.TrimSpace(fields[idMerchantName]),
+		MerchantCity: strings.TrimSpace(fields[idMerchantCity]),
+		PostalCode:   fields[idPostalCode],
+		Dynamic:      fields[idInitiationMethod] == initiationDynamic,
+	}
+	if !readAccount(fields, payment) {
+		return nil, ErrNotPix
+	}
+	if currency, ok := fields[idCurrency]; ok && currency != CURRENCY_BRL {
+		return nil, ErrInvalidCurrency
+	}
+	if amount, ok := fields[idAmount]; ok {
+		payment.Amount, err = money.Parse(amount)
+		if err != nil || payment.Amount < 0 {
+			return nil, ErrInvalidAmount
+		}
+	}
+	if additionalData, ok := fields[idAdditionalData]; ok {
+		subfields, err := readFields(additionalData)
+		if err != nil {
+			return nil, err
+		}
+		if txId := subfields[idTxId]; txId != noTxId {
+			payment.TxId = txId
+		}
+	}
+	return payment, nil
+}
+
+// readAccount fills the payment with the first merchant account of the PIX, the others belong to card brands
+func readAccount(fields map[string]string, payment *Payment) bool {
+	for id := idMerchantAccountMin; id <= idMerchantAccountMax; id++ {
+		account, ok := fields[strconv.Itoa(id)]
+		if !ok {
+			continue
+		}
+		subfields, err := readFields(account)
+		if err != nil || !strings.EqualFold(subfields[idAccountGUI], GUI) {
+			continue
+		}
+		payment.Key = subfields[idAccountKey]
+		payment.Info = subfields[idAccountInfo]
+		payment.URL = subfields[idAccountURL]
+		return payment.Key != "" || payment.URL != ""
+	}
+	return false
+}
+
+// readFields splits the TLV fields, each one an id of 2 digits, the length in 2 digits and the value
+func readFields(data string) (map[string]string, error) {
+	fields := map[string]string{}
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return nil, ErrInvalidFormat
+		}
+		length, err := strconv.Atoi(data[2:4])
+		if err != nil || len(data) < 4+length {
+			return nil, ErrInvalidFormat
+		}
+		fields[data[:2]] = data[4 : 4+length]
+		data = data[4+length:]
+	}
+	return fields, nil
+}
+
+// crc16 is the CRC-16/CCITT-FALSE (polynomial 0x1021 and initial value 0xFFFF) required by the BR Code
+func crc16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for index := 0; index < len(data); index++ {
+		crc ^= uint16(data[index]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
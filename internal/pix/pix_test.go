@@ -0,0 +1,87 @@
+package pix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatic(t *testing.T) {
+	code := "00020126550014br.gov.bcb.pix0119padaria@example.com0210Pedido 123520458125303986540525.905802BR5918PADARIA PAO QUENTE6009SAO PAULO62100506PED12363042D78"
+	payment, err := Parse(code)
+	assert.NoError(t, err)
+	assert.Equal(t, &Payment{
+		Key:          "padaria@example.com",
+		Info:         "Pedido 123",
+		MerchantName: "PADARIA PAO QUENTE",
+		MerchantCity: "SAO PAULO",
+		Amount:       money.FromCents(2590),
+		TxId:         "PED123",
+	}, payment)
+}
+
+func TestParseWithoutAmount(t *testing.T) {
+	code := "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+	payment, err := Parse(code)
+	assert.NoError(t, err)
+	assert.Equal(t, "123e4567-e12b-12d1-a456-426655440000", payment.Key)
+	assert.Equal(t, "Fulano de Tal", payment.MerchantName)
+	assert.Equal(t, "BRASILIA", payment.MerchantCity)
+	assert.Equal(t, money.Money(0), payment.Amount)
+	assert.Empty(t, payment.TxId)
+}
+
+func TestParseDynamic(t *testing.T) {
+	code := " 00020101021226760014BR.GOV.BCB.PIX2554pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca255204000053039865802BR5915MERCADO CENTRAL6008CURITIBA62070503***6304e226\n"
+	payment, err := Parse(code)
+	assert.NoError(t, err)
+	assert.True(t, payment.Dynamic)
+	assert.Empty(t, payment.Key)
+	assert.Equal(t, "pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25", payment.URL)
+	assert.Equal(t, "MERCADO CENTRAL", payment.MerchantName)
+}
+
+func TestParseInvalid(t *testing.T) {
+	cases := map[string]error{
+		"":         ErrInvalidFormat,
+		"pix":      ErrInvalidFormat,
+		"00020126": ErrInvalidFormat,
+		"00020126550014br.gov.bcb.pix0119padaria@example.com0210Pedido 123520458125303986540525.905802BR5918PADARIA PAO QUENTE6009SAO PAULO62100506PED12363042D79": ErrInvalidCRC,
+		"00020126550014br.gov.bcb.pix0119padaria@example.com0210Pedido 123520458125303986540525.905802BR5918PADARIA PAO QUENTE6009SAO PAULO62100506PED1236304":     ErrInvalidFormat,
+	}
+	for code, expected := range cases {
+		_, err := Parse(code)
+		assert.ErrorIs(t, err, expected, code)
+	}
+}
+
+func TestParseNotPix(t *testing.T) {
+	code := withCRC("00020126160012br.com.other5204000053039865802BR5905LOJA 6006RECIFE")
+	_, err := Parse(code)
+	assert.ErrorIs(t, err, ErrNotPix)
+}
+
+func TestParseInvalidCurrency(t *testing.T) {
+	code := withCRC("00020126360014br.gov.bcb.pix0114+55119999999995204000053038405802BR5904LOJA6006RECIFE")
+	_, err := Parse(code)
+	assert.ErrorIs(t, err, ErrInvalidCurrency)
+}
+
+func TestParseInvalidAmount(t *testing.T) {
+	code := withCRC("00020126360014br.gov.bcb.pix0114+5511999999999520400005303986540410,05802BR5904LOJA6006RECIFE")
+	_, err := Parse(code)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestParseTruncatedField(t *testing.T) {
+	code := withCRC("00020126360014br.gov.bcb.pix0114+5511999999999520400005303986599904LOJA")
+	_, err := Parse(code)
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func withCRC(payload string) string {
+	payload += "6304"
+	return payload + fmt.Sprintf("%04X", crc16(payload))
+}
@@ -0,0 +1,65 @@
+package problem
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/requestid"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+const (
+	CONTENT_TYPE = "application/problem+json"
+	TYPE_DEFAULT = "about:blank"
+)
+
+const MESSAGE_INTERNAL_ERROR = "An unexpected error has occurred, try again later"
+
+// Problem is the RFC 7807 body returned when a request fails
+type Problem struct {
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Status int               `json:"status"`
+	Detail string            `json:"detail,omitempty"`
+	Errors validation.Errors `json:"errors,omitempty"`
+}
+
+// New translates an error into the problem exposed to the client.
+// Errors that are not part of the domain are reported as internal errors without their text.
+func New(err error) Problem {
+	var validationErrs validation.Errors
+	if errors.As(err, &validationErrs) {
+		return build(http.StatusUnprocessableEntity, validation.MESSAGE_INVALID_REQUEST, validationErrs)
+	}
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return build(appErr.Status, appErr.Message, nil)
+	}
+	return build(http.StatusInternalServerError, MESSAGE_INTERNAL_ERROR, nil)
+}
+
+func build(status int, detail string, errs validation.Errors) Problem {
+	return Problem{Type: TYPE_DEFAULT, Title: http.StatusText(status), Status: status, Detail: detail, Errors: errs}
+}
+
+// Middleware writes the last error registered by the handler with c.Error as a problem response
+func Middleware(c *gin.Context) {
+	c.Next()
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+	Abort(c, c.Errors.Last().Err)
+}
+
+// Abort stops the chain answering with the problem that represents the error
+func Abort(c *gin.Context, err error) {
+	problem := New(err)
+	if problem.Status >= http.StatusInternalServerError {
+		log.Printf("Request %s failed: %v", requestid.FromContext(c.Request.Context()), err)
+	}
+	c.Header("Content-Type", CONTENT_TYPE)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
@@ -0,0 +1,70 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router := gin.New()
+	router.Use(Middleware)
+	router.GET("/v1/gain/:id", handler)
+	req, _ := http.NewRequest("GET", "/v1/gain/cd1cc27b-28a1-47dc-ac76-70e8185e159d", nil)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddlewareDomainError(t *testing.T) {
+	w := serve(func(c *gin.Context) {
+		c.Error(apperror.NotFound("Gain not found"))
+	})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, CONTENT_TYPE, w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"type":"about:blank","title":"Not Found","status":404,"detail":"Gain not found"}`, w.Body.String())
+}
+
+func TestMiddlewareValidationErrors(t *testing.T) {
+	w := serve(func(c *gin.Context) {
+		c.Error(validation.Errors{validation.NotFound("category_id")})
+	})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"category_id","code":"not_found","message":"The category_id does not exist"}]}`, w.Body.String())
+}
+
+func TestMiddlewareHidesInternalError(t *testing.T) {
+	w := serve(func(c *gin.Context) {
+		c.Error(errors.New("Error 1045: Access denied for user 'root'@'localhost'"))
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"An unexpected error has occurred, try again later"}`, w.Body.String())
+}
+
+func TestMiddlewareHidesWrappedCause(t *testing.T) {
+	w := serve(func(c *gin.Context) {
+		c.Error(apperror.Upstream("The identity provider is unavailable").Wrap(errors.New("dial tcp 10.0.0.3:8081: connection refused")))
+	})
+
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Equal(t, `{"type":"about:blank","title":"Bad Gateway","status":502,"detail":"The identity provider is unavailable"}`, w.Body.String())
+}
+
+func TestMiddlewareKeepsWrittenResponse(t *testing.T) {
+	w := serve(func(c *gin.Context) {
+		c.Error(errors.New("An error has been ocurred"))
+		c.JSON(http.StatusOK, gin.H{"id": "cd1cc27b-28a1-47dc-ac76-70e8185e159d"})
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"id":"cd1cc27b-28a1-47dc-ac76-70e8185e159d"}`, w.Body.String())
+}
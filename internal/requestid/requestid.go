@@ -0,0 +1,29 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+)
+
+const REQUEST_ID_HEADER = "X-Request-Id"
+
+type contextKey struct{}
+
+func Middleware(ctx *gin.Context) {
+	requestId := ctx.GetHeader(REQUEST_ID_HEADER)
+	if requestId == "" {
+		requestId = uuid.NewV4().String()
+	}
+	ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), contextKey{}, requestId))
+	ctx.Header(REQUEST_ID_HEADER, requestId)
+}
+
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestId, _ := ctx.Value(contextKey{}).(string)
+	return requestId
+}
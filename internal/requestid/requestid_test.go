@@ -0,0 +1,48 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareKeepsRequestId(t *testing.T) {
+	var requestIdFound string
+	router := gin.Default()
+	router.Use(Middleware)
+	router.GET("/test", func(c *gin.Context) {
+		requestIdFound = FromContext(c.Request.Context())
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Add(REQUEST_ID_HEADER, "4a0c1ed5-2d1a-4a4c-8a5c-2b2a1e2f3c4d")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "4a0c1ed5-2d1a-4a4c-8a5c-2b2a1e2f3c4d", requestIdFound)
+	assert.Equal(t, "4a0c1ed5-2d1a-4a4c-8a5c-2b2a1e2f3c4d", w.Header().Get(REQUEST_ID_HEADER))
+}
+
+func TestMiddlewareGeneratesRequestId(t *testing.T) {
+	var requestIdFound string
+	router := gin.Default()
+	router.Use(Middleware)
+	router.GET("/test", func(c *gin.Context) {
+		requestIdFound = FromContext(c.Request.Context())
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	router.ServeHTTP(w, req)
+
+	assert.NotEmpty(t, requestIdFound)
+	assert.Equal(t, requestIdFound, w.Header().Get(REQUEST_ID_HEADER))
+}
+
+func TestFromContextWithoutRequestId(t *testing.T) {
+	assert.Equal(t, "", FromContext(context.TODO()))
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/ruanlas/wallet-core-api/docs"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/requestid"
 	v1 "github.com/ruanlas/wallet-core-api/internal/v1"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -14,18 +16,19 @@ import (
 )
 
 type Router struct {
-	apiV1 v1.Api
+	apiV1                 v1.Api
+	idempotencyMiddleware gin.HandlerFunc
 }
 
-func NewRouter(apiV1 v1.Api) *Router {
-	return &Router{apiV1: apiV1}
+func NewRouter(apiV1 v1.Api, idempotencyMiddleware gin.HandlerFunc) *Router {
+	return &Router{apiV1: apiV1, idempotencyMiddleware: idempotencyMiddleware}
 }
 
 func (r *Router) SetupRoutes() {
 	servicePort := os.Getenv("SERVICE_PORT")
 	serviceHost := os.Getenv("SERVICE_HOST")
 	router := gin.Default()
-	router.Use(apmgin.Middleware(router), idpauth.AuthenticationMiddleware, idpauth.AuthorizationMiddleware)
+	router.Use(apmgin.Middleware(router), requestid.Middleware, idpauth.AuthenticationMiddleware, idpauth.AuthorizationMiddleware, r.idempotencyMiddleware, problem.Middleware)
 
 	docs.SwaggerInfo.BasePath = "/"
 	docs.SwaggerInfo.Host = fmt.Sprintf("%s:%s", serviceHost, servicePort)
@@ -33,29 +36,108 @@ func (r *Router) SetupRoutes() {
 	v1router := router.Group("/v1")
 	v1router.POST("/gain-projection", r.apiV1.GetGainProjectionHandler().Create)
 	v1router.GET("/gain-projection", r.apiV1.GetGainProjectionHandler().GetAll)
+	v1router.GET("/gain-projection/trash", r.apiV1.GetGainProjectionHandler().GetTrash)
 	v1router.GET("/gain-projection/:id", r.apiV1.GetGainProjectionHandler().GetById)
 	v1router.PUT("/gain-projection/:id", r.apiV1.GetGainProjectionHandler().Update)
+	v1router.PATCH("/gain-projection/:id", r.apiV1.GetGainProjectionHandler().Patch)
 	v1router.DELETE("/gain-projection/:id", r.apiV1.GetGainProjectionHandler().Delete)
+	v1router.POST("/gain-projection/:id/restore", r.apiV1.GetGainProjectionHandler().Restore)
+	v1router.POST("/gain-projection/batch/create-gain", r.apiV1.GetGainProjectionHandler().CreateGainBatch)
 	v1router.POST("/gain-projection/:id/create-gain", r.apiV1.GetGainProjectionHandler().CreateGain)
+	v1router.POST("/gain-projection/:id/revert-gain", r.apiV1.GetGainProjectionHandler().RevertGain)
 
 	v1router.POST("/gain", r.apiV1.GetGainHandler().Create)
+	v1router.POST("/gain/pix", r.apiV1.GetGainHandler().CreateFromPix)
 	v1router.GET("/gain", r.apiV1.GetGainHandler().GetAll)
+	v1router.GET("/gain/trash", r.apiV1.GetGainHandler().GetTrash)
+	v1router.POST("/gain/batch", r.apiV1.GetGainHandler().CreateBatch)
+	v1router.PUT("/gain/batch", r.apiV1.GetGainHandler().UpdateBatch)
+	v1router.DELETE("/gain/batch", r.apiV1.GetGainHandler().DeleteBatch)
+	v1router.POST("/gain/apply-rules", r.apiV1.GetGainHandler().ApplyRules)
 	v1router.GET("/gain/:id", r.apiV1.GetGainHandler().GetById)
 	v1router.PUT("/gain/:id", r.apiV1.GetGainHandler().Update)
+	v1router.PATCH("/gain/:id", r.apiV1.GetGainHandler().Patch)
 	v1router.DELETE("/gain/:id", r.apiV1.GetGainHandler().Delete)
+	v1router.POST("/gain/:id/restore", r.apiV1.GetGainHandler().Restore)
 
 	v1router.POST("/invoice-projection", r.apiV1.GetInvoiceProjectionHandler().Create)
 	v1router.GET("/invoice-projection", r.apiV1.GetInvoiceProjectionHandler().GetAll)
+	v1router.GET("/invoice-projection/trash", r.apiV1.GetInvoiceProjectionHandler().GetTrash)
+	v1router.POST("/invoice-projection/boleto", r.apiV1.GetInvoiceProjectionHandler().CreateFromBoleto)
+	v1router.POST("/invoice-projection/boleto/parse", r.apiV1.GetInvoiceProjectionHandler().ParseBoleto)
 	v1router.GET("/invoice-projection/:id", r.apiV1.GetInvoiceProjectionHandler().GetById)
 	v1router.PUT("/invoice-projection/:id", r.apiV1.GetInvoiceProjectionHandler().Update)
+	v1router.PATCH("/invoice-projection/:id", r.apiV1.GetInvoiceProjectionHandler().Patch)
 	v1router.DELETE("/invoice-projection/:id", r.apiV1.GetInvoiceProjectionHandler().Delete)
+	v1router.POST("/invoice-projection/:id/restore", r.apiV1.GetInvoiceProjectionHandler().Restore)
+	v1router.POST("/invoice-projection/batch/create-invoice", r.apiV1.GetInvoiceProjectionHandler().CreateInvoiceBatch)
 	v1router.POST("/invoice-projection/:id/create-invoice", r.apiV1.GetInvoiceProjectionHandler().CreateInvoice)
+	v1router.POST("/invoice-projection/:id/revert-invoice", r.apiV1.GetInvoiceProjectionHandler().RevertInvoice)
 
 	v1router.POST("/invoice", r.apiV1.GetInvoiceHandler().Create)
+	v1router.POST("/invoice/pix", r.apiV1.GetInvoiceHandler().CreateFromPix)
 	v1router.GET("/invoice", r.apiV1.GetInvoiceHandler().GetAll)
+	v1router.GET("/invoice/trash", r.apiV1.GetInvoiceHandler().GetTrash)
+	v1router.POST("/invoice/batch", r.apiV1.GetInvoiceHandler().CreateBatch)
+	v1router.PUT("/invoice/batch", r.apiV1.GetInvoiceHandler().UpdateBatch)
+	v1router.DELETE("/invoice/batch", r.apiV1.GetInvoiceHandler().DeleteBatch)
+	v1router.POST("/invoice/apply-rules", r.apiV1.GetInvoiceHandler().ApplyRules)
 	v1router.GET("/invoice/:id", r.apiV1.GetInvoiceHandler().GetById)
 	v1router.PUT("/invoice/:id", r.apiV1.GetInvoiceHandler().Update)
+	v1router.PATCH("/invoice/:id", r.apiV1.GetInvoiceHandler().Patch)
 	v1router.DELETE("/invoice/:id", r.apiV1.GetInvoiceHandler().Delete)
+	v1router.POST("/invoice/:id/restore", r.apiV1.GetInvoiceHandler().Restore)
+
+	v1router.GET("/audit", r.apiV1.GetAuditHandler().GetActivity)
+	v1router.GET("/audit/:entity/:id", r.apiV1.GetAuditHandler().GetHistory)
+
+	v1router.POST("/exchange-rate", r.apiV1.GetExchangeRateHandler().Create)
+	v1router.GET("/exchange-rate", r.apiV1.GetExchangeRateHandler().GetAll)
+	v1router.POST("/exchange-rate/import", r.apiV1.GetExchangeRateHandler().Import)
+
+	v1router.POST("/attachment/:entity/:id", r.apiV1.GetAttachmentHandler().Upload)
+	v1router.GET("/attachment/:entity/:id", r.apiV1.GetAttachmentHandler().GetAll)
+	v1router.GET("/attachment/:entity/:id/:attachment_id", r.apiV1.GetAttachmentHandler().Download)
+	v1router.DELETE("/attachment/:entity/:id/:attachment_id", r.apiV1.GetAttachmentHandler().Delete)
+
+	v1router.POST("/category-rule", r.apiV1.GetCategoryRuleHandler().Create)
+	v1router.GET("/category-rule", r.apiV1.GetCategoryRuleHandler().GetAll)
+	v1router.DELETE("/category-rule/:id", r.apiV1.GetCategoryRuleHandler().Delete)
+
+	v1router.GET("/category-suggestion", r.apiV1.GetCategorySuggestionHandler().GetSuggestions)
+
+	v1router.GET("/forecast", r.apiV1.GetForecastHandler().GetForecast)
+
+	v1router.GET("/analytics/category-trends", r.apiV1.GetAnalyticsHandler().GetCategoryTrends)
+	v1router.GET("/analytics/passive-income", r.apiV1.GetAnalyticsHandler().GetPassiveIncome)
+
+	v1router.PUT("/tax-report/mapping", r.apiV1.GetTaxReportHandler().SaveMapping)
+	v1router.GET("/tax-report/mapping", r.apiV1.GetTaxReportHandler().GetMappings)
+	v1router.GET("/tax-report", r.apiV1.GetTaxReportHandler().GetReport)
+
+	v1router.POST("/goal", r.apiV1.GetGoalHandler().Create)
+	v1router.GET("/goal", r.apiV1.GetGoalHandler().GetAll)
+	v1router.GET("/goal/:id", r.apiV1.GetGoalHandler().GetById)
+	v1router.DELETE("/goal/:id", r.apiV1.GetGoalHandler().Delete)
+
+	v1router.POST("/loan", r.apiV1.GetLoanHandler().Create)
+	v1router.GET("/loan", r.apiV1.GetLoanHandler().GetAll)
+	v1router.GET("/loan/:id", r.apiV1.GetLoanHandler().GetById)
+	v1router.GET("/loan/:id/payoff", r.apiV1.GetLoanHandler().SimulatePayoff)
+
+	v1router.POST("/asset", r.apiV1.GetInvestmentHandler().CreateAsset)
+	v1router.GET("/asset", r.apiV1.GetInvestmentHandler().GetAll)
+	v1router.GET("/asset/:id", r.apiV1.GetInvestmentHandler().GetById)
+	v1router.PUT("/asset/:id/price", r.apiV1.GetInvestmentHandler().UpdatePrice)
+	v1router.POST("/asset/:id/operation", r.apiV1.GetInvestmentHandler().CreateOperation)
+	v1router.PUT("/asset/:id/dividend/:gain_id", r.apiV1.GetInvestmentHandler().LinkDividend)
+	v1router.DELETE("/asset/:id/dividend/:gain_id", r.apiV1.GetInvestmentHandler().UnlinkDividend)
+
+	v1router.POST("/split", r.apiV1.GetSplitHandler().Create)
+	v1router.POST("/split/debt", r.apiV1.GetSplitHandler().CreateDebt)
+	v1router.GET("/split/balance", r.apiV1.GetSplitHandler().GetBalances)
+	v1router.GET("/split/:id", r.apiV1.GetSplitHandler().GetById)
+	v1router.POST("/split/contact/:id/settle", r.apiV1.GetSplitHandler().Settle)
 
 	serviceAddr := fmt.Sprintf(":%s", servicePort)
 	router.Run(serviceAddr)
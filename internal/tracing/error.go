@@ -1,10 +1,16 @@
 package tracing
 
-import "go.elastic.co/apm"
+import (
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"go.elastic.co/apm"
+)
 
+// SendSpanErr ends the span reporting the error, failures of the domain are expected and not reported
 func SendSpanErr(span *apm.Span, err error) {
-	apmErr := apm.DefaultTracer.NewError(err)
-	apmErr.SetSpan(span)
-	apmErr.Send()
+	if apperror.KindOf(err) == "" {
+		apmErr := apm.DefaultTracer.NewError(err)
+		apmErr.SetSpan(span)
+		apmErr.Send()
+	}
 	span.End()
 }
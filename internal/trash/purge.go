@@ -0,0 +1,62 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	DEFAULT_RETENTION_DAYS = 30
+	DEFAULT_PURGE_INTERVAL = 24 * time.Hour
+)
+
+type Purgeable interface {
+	Purge(ctx context.Context, deletedBefore time.Time) error
+}
+
+type Purger interface {
+	PurgeExpired(ctx context.Context) error
+	Start(ctx context.Context)
+}
+
+type purger struct {
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+	targets   []Purgeable
+}
+
+func NewPurger(retention time.Duration, interval time.Duration, now func() time.Time, targets ...Purgeable) Purger {
+	return &purger{retention: retention, interval: interval, now: now, targets: targets}
+}
+
+// PurgeExpired permanently removes every record that has been in the trash for longer than the retention.
+// A target that fails is logged and does not keep the next ones from being purged, the failures are returned together
+func (p *purger) PurgeExpired(ctx context.Context) error {
+	deletedBefore := p.now().Add(-p.retention)
+	errs := []error{}
+	for _, target := range p.targets {
+		err := target.Purge(ctx, deletedBefore)
+		if err != nil {
+			log.Println("Trash purge failed:", err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *purger) Start(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		// each failure was already logged by PurgeExpired
+		p.PurgeExpired(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type purgeableMock struct {
+	err           error
+	deletedBefore time.Time
+	calls         int
+}
+
+func (p *purgeableMock) Purge(ctx context.Context, deletedBefore time.Time) error {
+	p.calls++
+	p.deletedBefore = deletedBefore
+	return p.err
+}
+
+func TestPurgeExpiredSuccess(t *testing.T) {
+	now := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	gainMock := &purgeableMock{}
+	invoiceMock := &purgeableMock{}
+
+	_purger := NewPurger(30*24*time.Hour, time.Hour, func() time.Time { return now }, gainMock, invoiceMock)
+	err := _purger.PurgeExpired(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, gainMock.calls)
+	assert.Equal(t, 1, invoiceMock.calls)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), gainMock.deletedBefore)
+}
+
+func TestPurgeExpiredFail(t *testing.T) {
+	now := time.Now()
+	gainMock := &purgeableMock{err: errors.New("An error has been ocurred")}
+	invoiceMock := &purgeableMock{}
+
+	_purger := NewPurger(30*24*time.Hour, time.Hour, func() time.Time { return now }, gainMock, invoiceMock)
+	err := _purger.PurgeExpired(context.TODO())
+	assert.Error(t, err)
+	assert.Equal(t, 1, invoiceMock.calls)
+}
+
+func TestPurgeExpiredJoinsTheFailures(t *testing.T) {
+	now := time.Now()
+	gainErr := errors.New("Cannot delete the gain")
+	invoiceErr := errors.New("Cannot delete the invoice")
+	gainMock := &purgeableMock{err: gainErr}
+	attachmentMock := &purgeableMock{}
+	invoiceMock := &purgeableMock{err: invoiceErr}
+
+	_purger := NewPurger(30*24*time.Hour, time.Hour, func() time.Time { return now }, gainMock, attachmentMock, invoiceMock)
+	err := _purger.PurgeExpired(context.TODO())
+	assert.ErrorIs(t, err, gainErr)
+	assert.ErrorIs(t, err, invoiceErr)
+	assert.Equal(t, 1, attachmentMock.calls)
+	assert.Equal(t, 1, invoiceMock.calls)
+}
@@ -0,0 +1,48 @@
+package anservice
+
+type SearchParamsBuilder struct {
+	entity              string
+	month               uint
+	year                uint
+	months              uint
+	movingAverageMonths uint
+	currency            string
+}
+
+func NewSearchParamsBuilder() *SearchParamsBuilder {
+	return &SearchParamsBuilder{}
+}
+func (builder *SearchParamsBuilder) AddEntity(entity string) *SearchParamsBuilder {
+	builder.entity = entity
+	return builder
+}
+func (builder *SearchParamsBuilder) AddMonth(month uint) *SearchParamsBuilder {
+	builder.month = month
+	return builder
+}
+func (builder *SearchParamsBuilder) AddYear(year uint) *SearchParamsBuilder {
+	builder.year = year
+	return builder
+}
+func (builder *SearchParamsBuilder) AddMonths(months uint) *SearchParamsBuilder {
+	builder.months = months
+	return builder
+}
+func (builder *SearchParamsBuilder) AddMovingAverageMonths(movingAverageMonths uint) *SearchParamsBuilder {
+	builder.movingAverageMonths = movingAverageMonths
+	return builder
+}
+func (builder *SearchParamsBuilder) AddCurrency(currency string) *SearchParamsBuilder {
+	builder.currency = currency
+	return builder
+}
+func (builder *SearchParamsBuilder) Build() *SearchParams {
+	return &SearchParams{
+		entity:              builder.entity,
+		month:               builder.month,
+		year:                builder.year,
+		months:              builder.months,
+		movingAverageMonths: builder.movingAverageMonths,
+		currency:            builder.currency,
+	}
+}
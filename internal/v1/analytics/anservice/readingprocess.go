@@ -0,0 +1,227 @@
+package anservice
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/analytics/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+)
+
+type ReadingProcess interface {
+	GetCategoryTrends(searchCtx SearchContext) (*CategoryTrendListResponse, error)
+	GetPassiveIncome(searchCtx SearchContext) (*PassiveIncomeReportResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+	converter  erservice.Converter
+}
+
+func NewReadingProcess(repository repository.Repository, converter erservice.Converter) ReadingProcess {
+	return &readingProcess{repository: repository, converter: converter}
+}
+
+// GetCategoryTrends returns the trends of the months window ending on the informed month. The monthly totals of every
+// currency are converted to the informed one on the first day of their month, the twelve months before the window are
+// read as well so the comparisons and the averages of its first months are complete.
+func (rp *readingProcess) GetCategoryTrends(searchCtx SearchContext) (*CategoryTrendListResponse, error) {
+	search := searchCtx.Params
+	user := idpauth.GetUser(searchCtx.UserToken)
+	currency := money.NormalizeCurrency(search.currency)
+	end := time.Date(int(search.year), time.Month(search.month), 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 1-int(search.months), 0)
+	historyStart := start.AddDate(-1, 0, 0)
+
+	queryParams := repository.NewQueryParamsBuilder().
+		AddUserId(user.Id).
+		AddStart(historyStart).
+		AddEnd(end).
+		Build()
+	getTotals := rp.repository.GetInvoiceTotals
+	if search.entity == ENTITY_GAIN {
+		getTotals = rp.repository.GetGainTotals
+	}
+	totalList, err := getTotals(searchCtx.Ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// monthTotals holds the converted totals of each category by month, counted from the start of the history
+	historyMonths := int(search.months) + 12
+	categories := []CategoryTrendResponse{}
+	monthTotals := [][]money.Money{}
+	for _, total := range *totalList {
+		value, err := rp.convert(searchCtx.Ctx, user.Id, total.Value, total.Currency, currency, total.PayDate)
+		if err != nil {
+			return nil, err
+		}
+		if len(categories) == 0 || categories[len(categories)-1].CategoryId != total.Category.Id {
+			categories = append(categories, CategoryTrendResponse{
+				CategoryId: total.Category.Id,
+				Category:   total.Category.Category,
+				Months:     []MonthTrendResponse{},
+			})
+			monthTotals = append(monthTotals, make([]money.Money, historyMonths))
+		}
+		monthTotals[len(monthTotals)-1][monthIndex(historyStart, total.PayDate)] += value
+	}
+
+	for index := range categories {
+		category := &categories[index]
+		totals := monthTotals[index]
+		for month := 12; month < historyMonths; month++ {
+			date := historyStart.AddDate(0, month, 0)
+			monthTrend := MonthTrendResponse{
+				Month:              uint(date.Month()),
+				Year:               uint(date.Year()),
+				Total:              totals[month],
+				PreviousMonthTotal: totals[month-1],
+				PreviousYearTotal:  totals[month-12],
+				MovingAverage:      money.Sum(totals[month+1-int(search.movingAverageMonths):month+1]...).Prorate(1, money.Quantity(search.movingAverageMonths)),
+			}
+			monthTrend.MonthOverMonthPercent = getPercent(monthTrend.Total, monthTrend.PreviousMonthTotal)
+			monthTrend.YearOverYearPercent = getPercent(monthTrend.Total, monthTrend.PreviousYearTotal)
+			category.Total += monthTrend.Total
+			category.Months = append(category.Months, monthTrend)
+		}
+	}
+
+	return &CategoryTrendListResponse{
+		Entity:              search.entity,
+		Currency:            currency,
+		StartMonth:          uint(start.Month()),
+		StartYear:           uint(start.Year()),
+		EndMonth:            uint(end.Month()),
+		EndYear:             uint(end.Year()),
+		MovingAverageMonths: search.movingAverageMonths,
+		Categories:          categories,
+	}, nil
+}
+
+// GetPassiveIncome compares the passive income with the active income and with the expenses of each month of the window.
+// As in the trends, the monthly totals of every currency are converted to the informed one on the first day of their month.
+func (rp *readingProcess) GetPassiveIncome(searchCtx SearchContext) (*PassiveIncomeReportResponse, error) {
+	search := searchCtx.Params
+	user := idpauth.GetUser(searchCtx.UserToken)
+	currency := money.NormalizeCurrency(search.currency)
+	end := time.Date(int(search.year), time.Month(search.month), 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 1-int(search.months), 0)
+
+	queryParams := repository.NewQueryParamsBuilder().
+		AddUserId(user.Id).
+		AddStart(start).
+		AddEnd(end).
+		Build()
+	gainTotalList, err := rp.repository.GetGainTotals(searchCtx.Ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	invoiceTotalList, err := rp.repository.GetInvoiceTotals(searchCtx.Ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PassiveIncomeReportResponse{
+		Currency:   currency,
+		StartMonth: uint(start.Month()),
+		StartYear:  uint(start.Year()),
+		EndMonth:   uint(end.Month()),
+		EndYear:    uint(end.Year()),
+		Months:     []PassiveIncomeMonthResponse{},
+		Categories: []CategoryIncomeResponse{},
+	}
+	for month := 0; month < int(search.months); month++ {
+		date := start.AddDate(0, month, 0)
+		report.Months = append(report.Months, PassiveIncomeMonthResponse{Month: uint(date.Month()), Year: uint(date.Year())})
+	}
+	categoryIndexes := map[uint]int{}
+	for _, total := range *gainTotalList {
+		value, err := rp.convert(searchCtx.Ctx, user.Id, total.Value, total.Currency, currency, total.PayDate)
+		if err != nil {
+			return nil, err
+		}
+		index, found := categoryIndexes[total.Category.Id]
+		if !found {
+			index = len(report.Categories)
+			categoryIndexes[total.Category.Id] = index
+			report.Categories = append(report.Categories, CategoryIncomeResponse{CategoryId: total.Category.Id, Category: total.Category.Category})
+		}
+		month := &report.Months[monthIndex(start, total.PayDate)]
+		if total.IsPassive {
+			month.PassiveIncome += value
+			report.Categories[index].PassiveIncome += value
+		} else {
+			month.ActiveIncome += value
+			report.Categories[index].ActiveIncome += value
+		}
+	}
+	for _, total := range *invoiceTotalList {
+		value, err := rp.convert(searchCtx.Ctx, user.Id, total.Value, total.Currency, currency, total.PayDate)
+		if err != nil {
+			return nil, err
+		}
+		report.Months[monthIndex(start, total.PayDate)].Expenses += value
+	}
+
+	for index := range report.Months {
+		month := &report.Months[index]
+		month.PassiveShare = getRatio(month.PassiveIncome, month.PassiveIncome+month.ActiveIncome)
+		month.CoverageRatio = getRatio(month.PassiveIncome, month.Expenses)
+		report.PassiveIncome += month.PassiveIncome
+		report.ActiveIncome += month.ActiveIncome
+		report.Expenses += month.Expenses
+	}
+	report.PassiveShare = getRatio(report.PassiveIncome, report.PassiveIncome+report.ActiveIncome)
+	report.CoverageRatio = getRatio(report.PassiveIncome, report.Expenses)
+	// the categories with more passive income come first
+	sort.SliceStable(report.Categories, func(i, j int) bool {
+		a, b := report.Categories[i], report.Categories[j]
+		if a.PassiveIncome != b.PassiveIncome {
+			return a.PassiveIncome > b.PassiveIncome
+		}
+		if a.ActiveIncome != b.ActiveIncome {
+			return a.ActiveIncome > b.ActiveIncome
+		}
+		return a.CategoryId < b.CategoryId
+	})
+	return report, nil
+}
+
+func (rp *readingProcess) convert(ctx context.Context, userId string, amount money.Money, from string, to string, date time.Time) (money.Money, error) {
+	return rp.converter.Convert(erservice.ConvertContext{
+		Ctx:    ctx,
+		UserId: userId,
+		Amount: amount,
+		From:   from,
+		To:     to,
+		Date:   date,
+	})
+}
+
+// monthIndex counts the months from the first month until the month of the date
+func monthIndex(first time.Time, date time.Time) int {
+	return (date.Year()-first.Year())*12 + int(date.Month()) - int(first.Month())
+}
+
+// getPercent rounds the variation of the total to two decimal places, it is nil when the compared total is zero
+func getPercent(total money.Money, compared money.Money) *float64 {
+	if compared == 0 {
+		return nil
+	}
+	percent := math.Round(float64(total-compared)/float64(compared)*10000) / 100
+	return &percent
+}
+
+// getRatio rounds the ratio to four decimal places, it is nil when the whole is not positive
+func getRatio(part money.Money, whole money.Money) *float64 {
+	if whole <= 0 {
+		return nil
+	}
+	ratio := math.Round(float64(part)/float64(whole)*10000) / 10000
+	return &ratio
+}
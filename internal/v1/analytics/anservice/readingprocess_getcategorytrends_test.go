@@ -0,0 +1,171 @@
+package anservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/analytics/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	gainTotals    []repository.CategoryTotal
+	invoiceTotals []repository.CategoryTotal
+	err           error
+	invoiceErr    error
+	entity        string
+	params        repository.QueryParams
+}
+
+func (m *mockRepository) GetGainTotals(ctx context.Context, params repository.QueryParams) (*[]repository.CategoryTotal, error) {
+	m.entity = ENTITY_GAIN
+	m.params = params
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &m.gainTotals, nil
+}
+
+func (m *mockRepository) GetInvoiceTotals(ctx context.Context, params repository.QueryParams) (*[]repository.CategoryTotal, error) {
+	m.entity = ENTITY_INVOICE
+	m.params = params
+	if m.invoiceErr != nil {
+		return nil, m.invoiceErr
+	}
+	return &m.invoiceTotals, nil
+}
+
+type mockConverter struct {
+	err error
+}
+
+func (m *mockConverter) Convert(convertCtx erservice.ConvertContext) (money.Money, error) {
+	if convertCtx.From == convertCtx.To {
+		return convertCtx.Amount, nil
+	}
+	if m.err != nil {
+		return 0, m.err
+	}
+	return convertCtx.Amount * 2, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func buildInvoiceTotalsMock() []repository.CategoryTotal {
+	food := repository.Category{Id: 2, Category: "Alimentação"}
+	transport := repository.Category{Id: 3, Category: "Transporte"}
+	return []repository.CategoryTotal{
+		{Category: food, Currency: "BRL", PayDate: date(2023, 1, 10), Value: money.FromCents(30000)},
+		{Category: food, Currency: "BRL", PayDate: date(2023, 11, 5), Value: money.FromCents(40000)},
+		{Category: food, Currency: "BRL", PayDate: date(2023, 12, 5), Value: money.FromCents(30000)},
+		{Category: food, Currency: "USD", PayDate: date(2023, 12, 20), Value: money.FromCents(10000)},
+		{Category: food, Currency: "BRL", PayDate: date(2024, 1, 15), Value: money.FromCents(60000)},
+		{Category: transport, Currency: "BRL", PayDate: date(2023, 11, 10), Value: money.FromCents(10000)},
+		{Category: transport, Currency: "EUR", PayDate: date(2024, 1, 8), Value: money.FromCents(10000)},
+	}
+}
+
+func TestGetCategoryTrendsSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{invoiceTotals: buildInvoiceTotalsMock()}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{})
+
+	params := NewSearchParamsBuilder().
+		AddEntity(ENTITY_INVOICE).
+		AddMonth(1).
+		AddYear(2024).
+		AddMonths(2).
+		AddMovingAverageMonths(3).
+		Build()
+	response, err := _readingProcess.GetCategoryTrends(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.NoError(t, err)
+
+	monthOverMonthPercent := []float64{25, 20, -100}
+	yearOverYearPercent := float64(100)
+	assert.Equal(t, &CategoryTrendListResponse{
+		Entity:              ENTITY_INVOICE,
+		Currency:            "BRL",
+		StartMonth:          12,
+		StartYear:           2023,
+		EndMonth:            1,
+		EndYear:             2024,
+		MovingAverageMonths: 3,
+		Categories: []CategoryTrendResponse{
+			{
+				CategoryId: 2,
+				Category:   "Alimentação",
+				Total:      money.FromCents(110000),
+				Months: []MonthTrendResponse{
+					{Month: 12, Year: 2023, Total: money.FromCents(50000), PreviousMonthTotal: money.FromCents(40000), MonthOverMonthPercent: &monthOverMonthPercent[0], MovingAverage: money.FromCents(30000)},
+					{Month: 1, Year: 2024, Total: money.FromCents(60000), PreviousMonthTotal: money.FromCents(50000), MonthOverMonthPercent: &monthOverMonthPercent[1], PreviousYearTotal: money.FromCents(30000), YearOverYearPercent: &yearOverYearPercent, MovingAverage: money.FromCents(50000)},
+				},
+			},
+			{
+				CategoryId: 3,
+				Category:   "Transporte",
+				Total:      money.FromCents(20000),
+				Months: []MonthTrendResponse{
+					{Month: 12, Year: 2023, Total: money.FromCents(0), PreviousMonthTotal: money.FromCents(10000), MonthOverMonthPercent: &monthOverMonthPercent[2], MovingAverage: money.FromCents(3333)},
+					{Month: 1, Year: 2024, Total: money.FromCents(20000), MovingAverage: money.FromCents(10000)},
+				},
+			},
+		},
+	}, response)
+
+	expectedParams := repository.NewQueryParamsBuilder().
+		AddUserId("5832a502-bede-492d-8dc1-b13b32c30f29").
+		AddStart(time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)).
+		AddEnd(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+		Build()
+	assert.Equal(t, ENTITY_INVOICE, _mockRepository.entity)
+	assert.Equal(t, expectedParams, _mockRepository.params)
+}
+
+func TestGetCategoryTrendsOfGains(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{})
+
+	params := NewSearchParamsBuilder().
+		AddEntity(ENTITY_GAIN).
+		AddMonth(6).
+		AddYear(2024).
+		AddMonths(12).
+		AddMovingAverageMonths(6).
+		AddCurrency("USD").
+		Build()
+	response, err := _readingProcess.GetCategoryTrends(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, []CategoryTrendResponse{}, response.Categories)
+	assert.Equal(t, uint(7), response.StartMonth)
+	assert.Equal(t, uint(2023), response.StartYear)
+	assert.Equal(t, "USD", response.Currency)
+	assert.Equal(t, ENTITY_GAIN, _mockRepository.entity)
+}
+
+func TestGetCategoryTrendsConvertFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(
+		&mockRepository{invoiceTotals: buildInvoiceTotalsMock()},
+		&mockConverter{err: apperror.Unprocessable("There is no exchange rate from USD to BRL on 2023-12-20")})
+
+	params := NewSearchParamsBuilder().AddEntity(ENTITY_INVOICE).AddMonth(1).AddYear(2024).AddMonths(2).AddMovingAverageMonths(3).Build()
+	response, err := _readingProcess.GetCategoryTrends(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestGetCategoryTrendsFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	params := NewSearchParamsBuilder().AddEntity(ENTITY_GAIN).AddMonth(6).AddYear(2024).AddMonths(12).AddMovingAverageMonths(3).Build()
+	response, err := _readingProcess.GetCategoryTrends(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,112 @@
+package anservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/analytics/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildIncomeRepositoryMock() *mockRepository {
+	salary := repository.Category{Id: 1, Category: "Salário"}
+	dividends := repository.Category{Id: 5, Category: "Dividendos"}
+	rents := repository.Category{Id: 6, Category: "Aluguéis"}
+	return &mockRepository{
+		gainTotals: []repository.CategoryTotal{
+			{Category: salary, Currency: "BRL", PayDate: date(2024, 1, 5), Value: money.FromCents(500000)},
+			{Category: dividends, IsPassive: true, Currency: "USD", PayDate: date(2024, 1, 15), Value: money.FromCents(30000)},
+			{Category: dividends, IsPassive: true, Currency: "BRL", PayDate: date(2024, 2, 15), Value: money.FromCents(30000)},
+			{Category: rents, IsPassive: true, Currency: "BRL", PayDate: date(2024, 1, 10), Value: money.FromCents(25000)},
+		},
+		invoiceTotals: []repository.CategoryTotal{
+			{Category: repository.Category{Id: 2, Category: "Alimentação"}, Currency: "BRL", PayDate: date(2024, 1, 10), Value: money.FromCents(350000)},
+			{Category: repository.Category{Id: 3, Category: "Transporte"}, Currency: "EUR", PayDate: date(2024, 1, 20), Value: money.FromCents(25000)},
+		},
+	}
+}
+
+func TestGetPassiveIncomeSuccess(t *testing.T) {
+	_mockRepository := buildIncomeRepositoryMock()
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{})
+
+	params := NewSearchParamsBuilder().AddMonth(2).AddYear(2024).AddMonths(2).Build()
+	response, err := _readingProcess.GetPassiveIncome(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.NoError(t, err)
+
+	ratios := []float64{0.1453, 0.2125, 1, 0.187, 0.2875}
+	assert.Equal(t, &PassiveIncomeReportResponse{
+		Currency:      "BRL",
+		StartMonth:    1,
+		StartYear:     2024,
+		EndMonth:      2,
+		EndYear:       2024,
+		PassiveIncome: money.FromCents(115000),
+		ActiveIncome:  money.FromCents(500000),
+		Expenses:      money.FromCents(400000),
+		PassiveShare:  &ratios[3],
+		CoverageRatio: &ratios[4],
+		Months: []PassiveIncomeMonthResponse{
+			{Month: 1, Year: 2024, PassiveIncome: money.FromCents(85000), ActiveIncome: money.FromCents(500000), Expenses: money.FromCents(400000), PassiveShare: &ratios[0], CoverageRatio: &ratios[1]},
+			{Month: 2, Year: 2024, PassiveIncome: money.FromCents(30000), PassiveShare: &ratios[2]},
+		},
+		Categories: []CategoryIncomeResponse{
+			{CategoryId: 5, Category: "Dividendos", PassiveIncome: money.FromCents(90000)},
+			{CategoryId: 6, Category: "Aluguéis", PassiveIncome: money.FromCents(25000)},
+			{CategoryId: 1, Category: "Salário", ActiveIncome: money.FromCents(500000)},
+		},
+	}, response)
+
+	expectedParams := repository.NewQueryParamsBuilder().
+		AddUserId("5832a502-bede-492d-8dc1-b13b32c30f29").
+		AddStart(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+		AddEnd(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)).
+		Build()
+	assert.Equal(t, expectedParams, _mockRepository.params)
+}
+
+func TestGetPassiveIncomeWithoutRecords(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{}, &mockConverter{})
+
+	params := NewSearchParamsBuilder().AddMonth(2).AddYear(2024).AddMonths(1).AddCurrency("usd").Build()
+	response, err := _readingProcess.GetPassiveIncome(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", response.Currency)
+	assert.Nil(t, response.PassiveShare)
+	assert.Nil(t, response.CoverageRatio)
+	assert.Equal(t, []PassiveIncomeMonthResponse{{Month: 2, Year: 2024}}, response.Months)
+	assert.Equal(t, []CategoryIncomeResponse{}, response.Categories)
+}
+
+func TestGetPassiveIncomeConvertFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(
+		buildIncomeRepositoryMock(),
+		&mockConverter{err: apperror.Unprocessable("There is no exchange rate from USD to BRL on 2024-01-15")})
+
+	params := NewSearchParamsBuilder().AddMonth(2).AddYear(2024).AddMonths(2).Build()
+	response, err := _readingProcess.GetPassiveIncome(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestGetPassiveIncomeGetGainTotalsFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	params := NewSearchParamsBuilder().AddMonth(2).AddYear(2024).AddMonths(12).Build()
+	response, err := _readingProcess.GetPassiveIncome(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestGetPassiveIncomeGetInvoiceTotalsFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{invoiceErr: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	params := NewSearchParamsBuilder().AddMonth(2).AddYear(2024).AddMonths(12).Build()
+	response, err := _readingProcess.GetPassiveIncome(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,95 @@
+package anservice
+
+import (
+	"context"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const ENTITY_GAIN = "gain"
+const ENTITY_INVOICE = "invoice"
+
+const DEFAULT_MONTHS = 12
+const MAX_MONTHS = 36
+const DEFAULT_MOVING_AVERAGE_MONTHS = 3
+const MAX_MOVING_AVERAGE_MONTHS = 12
+
+type SearchContext struct {
+	Ctx       context.Context
+	Params    SearchParams
+	UserToken string
+}
+
+type SearchParams struct {
+	entity              string
+	month               uint
+	year                uint
+	months              uint
+	movingAverageMonths uint
+	currency            string
+}
+
+type CategoryTrendListResponse struct {
+	Entity              string                  `json:"entity"`
+	Currency            string                  `json:"currency"`
+	StartMonth          uint                    `json:"start_month"`
+	StartYear           uint                    `json:"start_year"`
+	EndMonth            uint                    `json:"end_month"`
+	EndYear             uint                    `json:"end_year"`
+	MovingAverageMonths uint                    `json:"moving_average_months"`
+	Categories          []CategoryTrendResponse `json:"categories"`
+}
+
+type CategoryTrendResponse struct {
+	CategoryId uint                 `json:"category_id"`
+	Category   string               `json:"category"`
+	Total      money.Money          `json:"total" swaggertype:"number"`
+	Months     []MonthTrendResponse `json:"months"`
+}
+
+// MonthTrendResponse compares the total of the month with the previous month and with the same month of the previous year,
+// the percentages are left out when the compared total is zero
+type MonthTrendResponse struct {
+	Month                 uint        `json:"month"`
+	Year                  uint        `json:"year"`
+	Total                 money.Money `json:"total" swaggertype:"number"`
+	PreviousMonthTotal    money.Money `json:"previous_month_total" swaggertype:"number"`
+	MonthOverMonthPercent *float64    `json:"month_over_month_percent,omitempty"`
+	PreviousYearTotal     money.Money `json:"previous_year_total" swaggertype:"number"`
+	YearOverYearPercent   *float64    `json:"year_over_year_percent,omitempty"`
+	MovingAverage         money.Money `json:"moving_average" swaggertype:"number"`
+}
+
+// PassiveIncomeReportResponse tracks how much of the expenses the passive income pays, the ratios are left out
+// when there is nothing to divide by
+type PassiveIncomeReportResponse struct {
+	Currency      string                       `json:"currency"`
+	StartMonth    uint                         `json:"start_month"`
+	StartYear     uint                         `json:"start_year"`
+	EndMonth      uint                         `json:"end_month"`
+	EndYear       uint                         `json:"end_year"`
+	PassiveIncome money.Money                  `json:"passive_income" swaggertype:"number"`
+	ActiveIncome  money.Money                  `json:"active_income" swaggertype:"number"`
+	Expenses      money.Money                  `json:"expenses" swaggertype:"number"`
+	PassiveShare  *float64                     `json:"passive_share,omitempty"`
+	CoverageRatio *float64                     `json:"coverage_ratio,omitempty"`
+	Months        []PassiveIncomeMonthResponse `json:"months"`
+	Categories    []CategoryIncomeResponse     `json:"categories"`
+}
+
+type PassiveIncomeMonthResponse struct {
+	Month         uint        `json:"month"`
+	Year          uint        `json:"year"`
+	PassiveIncome money.Money `json:"passive_income" swaggertype:"number"`
+	ActiveIncome  money.Money `json:"active_income" swaggertype:"number"`
+	Expenses      money.Money `json:"expenses" swaggertype:"number"`
+	PassiveShare  *float64    `json:"passive_share,omitempty"`
+	CoverageRatio *float64    `json:"coverage_ratio,omitempty"`
+}
+
+type CategoryIncomeResponse struct {
+	CategoryId    uint        `json:"category_id"`
+	Category      string      `json:"category"`
+	PassiveIncome money.Money `json:"passive_income" swaggertype:"number"`
+	ActiveIncome  money.Money `json:"active_income" swaggertype:"number"`
+}
@@ -0,0 +1,112 @@
+package analytics
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/analytics/anservice"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	GetCategoryTrends(c *gin.Context)
+	GetPassiveIncome(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	readingProcess anservice.ReadingProcess
+}
+
+func NewHandler(readingProcess anservice.ReadingProcess) Handler {
+	return &handler{readingProcess: readingProcess}
+}
+
+// GetCategoryTrends godoc
+// @Summary Obter a tendência mensal das categorias
+// @Description Este endpoint permite obter o total de cada categoria, mês a mês, nas receitas ou despesas dos últimos meses até o mês informado.
+// @Description Cada mês traz a variação em relação ao mês anterior e ao mesmo mês do ano anterior e a média móvel dos últimos meses. Os valores de todas as moedas são convertidos para a moeda informada na data de pagamento
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Param entity query string true "Tipo do registro (gain, invoice)"
+// @Param month query int true "Último mês da janela"
+// @Param year query int true "Ano do último mês da janela"
+// @Param months query int false "Quantidade de meses da janela, o padrão é 12 e o máximo é 36"
+// @Param moving_average query int false "Quantidade de meses da média móvel, o padrão é 3 e o máximo é 12"
+// @Param currency query string false "Moeda para a qual os valores são convertidos, o padrão é BRL"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} anservice.CategoryTrendListResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/analytics/category-trends [get]
+func (h *handler) GetCategoryTrends(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	params, err := validateAndGetTrendParams(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Analytics::ReadingProcess::GetCategoryTrends", "Get the monthly trends of the categories", nil)
+	searchCtx := anservice.SearchContext{
+		Ctx:       ctx,
+		Params:    *params,
+		UserToken: userToken,
+	}
+	trends, err := h.readingProcess.GetCategoryTrends(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, trends)
+}
+
+// @Summary Obter o relatório de renda passiva
+// @Description Este endpoint permite acompanhar, mês a mês, a renda passiva em relação à renda ativa e às despesas dos últimos meses até o mês informado.
+// @Description A cobertura é a renda passiva dividida pelas despesas, e as receitas são detalhadas por categoria. Os valores de todas as moedas são convertidos para a moeda informada na data de pagamento
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Param month query int true "Último mês da janela"
+// @Param year query int true "Ano do último mês da janela"
+// @Param months query int false "Quantidade de meses da janela, o padrão é 12 e o máximo é 36"
+// @Param currency query string false "Moeda para a qual os valores são convertidos, o padrão é BRL"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} anservice.PassiveIncomeReportResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/analytics/passive-income [get]
+func (h *handler) GetPassiveIncome(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	params, err := validateAndGetPassiveIncomeParams(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Analytics::ReadingProcess::GetPassiveIncome", "Get the passive income report", nil)
+	searchCtx := anservice.SearchContext{
+		Ctx:       ctx,
+		Params:    *params,
+		UserToken: userToken,
+	}
+	report, err := h.readingProcess.GetPassiveIncome(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,222 @@
+package analytics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/analytics/anservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type readingProcessMock struct {
+	err           error
+	trends        *anservice.CategoryTrendListResponse
+	passiveIncome *anservice.PassiveIncomeReportResponse
+	searchCtx     *anservice.SearchContext
+}
+
+func (rp *readingProcessMock) GetCategoryTrends(searchCtx anservice.SearchContext) (*anservice.CategoryTrendListResponse, error) {
+	rp.searchCtx = &searchCtx
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.trends, nil
+}
+
+func (rp *readingProcessMock) GetPassiveIncome(searchCtx anservice.SearchContext) (*anservice.PassiveIncomeReportResponse, error) {
+	rp.searchCtx = &searchCtx
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.passiveIncome, nil
+}
+
+func TestGetCategoryTrendsSuccess(t *testing.T) {
+	monthOverMonthPercent := 20.5
+	_readingProcessMock := &readingProcessMock{
+		trends: &anservice.CategoryTrendListResponse{
+			Entity:              "invoice",
+			Currency:            "BRL",
+			StartMonth:          1,
+			StartYear:           2024,
+			EndMonth:            1,
+			EndYear:             2024,
+			MovingAverageMonths: 3,
+			Categories: []anservice.CategoryTrendResponse{
+				{
+					CategoryId: 2,
+					Category:   "Alimentação",
+					Total:      money.FromCents(60250),
+					Months: []anservice.MonthTrendResponse{
+						{Month: 1, Year: 2024, Total: money.FromCents(60250), PreviousMonthTotal: money.FromCents(50000), MonthOverMonthPercent: &monthOverMonthPercent, MovingAverage: money.FromCents(55125)},
+					},
+				},
+			},
+		},
+	}
+	handler := NewHandler(_readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/analytics/category-trends", handler.GetCategoryTrends)
+
+	req, _ := http.NewRequest("GET", "/v1/analytics/category-trends?entity=invoice&month=1&year=2024&months=1", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"entity":"invoice","currency":"BRL","start_month":1,"start_year":2024,"end_month":1,"end_year":2024,"moving_average_months":3,"categories":[{"category_id":2,"category":"Alimentação","total":602.5,"months":[{"month":1,"year":2024,"total":602.5,"previous_month_total":500,"month_over_month_percent":20.5,"previous_year_total":0,"moving_average":551.25}]}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	expectedParams := anservice.NewSearchParamsBuilder().
+		AddEntity("invoice").
+		AddMonth(1).
+		AddYear(2024).
+		AddMonths(1).
+		AddMovingAverageMonths(anservice.DEFAULT_MOVING_AVERAGE_MONTHS).
+		Build()
+	assert.Equal(t, *expectedParams, _readingProcessMock.searchCtx.Params)
+}
+
+func TestGetCategoryTrendsInvalidParams(t *testing.T) {
+	tests := []struct {
+		query  string
+		detail string
+	}{
+		{query: "entity=transfer&month=1&year=2024", detail: "A param entity transfer is invalid"},
+		{query: "entity=gain&month=13&year=2024", detail: "A param month 13 is invalid"},
+		{query: "entity=gain&month=1", detail: "A param year 0 is invalid"},
+		{query: "entity=gain&month=1&year=2024&months=37", detail: "The param months must be between 1 and 36"},
+		{query: "entity=gain&month=1&year=2024&moving_average=0", detail: "The param moving_average must be between 1 and 12"},
+		{query: "entity=gain&month=1&year=2024&currency=REAL", detail: "A param currency REAL is invalid"},
+	}
+	for _, test := range tests {
+		_readingProcessMock := &readingProcessMock{}
+		handler := NewHandler(_readingProcessMock)
+		w := httptest.NewRecorder()
+		router := gin.Default()
+		router.Use(problem.Middleware)
+		apiRouter := router.Group("/v1")
+		apiRouter.GET("/analytics/category-trends", handler.GetCategoryTrends)
+
+		req, _ := http.NewRequest("GET", "/v1/analytics/category-trends?"+test.query, nil)
+		req.Header.Add(idpauth.AUTH_HEADER, userToken)
+		router.ServeHTTP(w, req)
+
+		bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"` + test.detail + `"}`
+		assert.Equal(t, bodyExpected, w.Body.String())
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Nil(t, _readingProcessMock.searchCtx)
+	}
+}
+
+func TestGetCategoryTrendsFail(t *testing.T) {
+	handler := NewHandler(&readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/analytics/category-trends", handler.GetCategoryTrends)
+
+	req, _ := http.NewRequest("GET", "/v1/analytics/category-trends?entity=gain&month=6&year=2024", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"An unexpected error has occurred, try again later"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetPassiveIncomeSuccess(t *testing.T) {
+	ratios := []float64{0.2, 0.2125}
+	_readingProcessMock := &readingProcessMock{
+		passiveIncome: &anservice.PassiveIncomeReportResponse{
+			Currency:      "BRL",
+			StartMonth:    1,
+			StartYear:     2024,
+			EndMonth:      1,
+			EndYear:       2024,
+			PassiveIncome: money.FromCents(85000),
+			ActiveIncome:  money.FromCents(340000),
+			Expenses:      money.FromCents(400000),
+			PassiveShare:  &ratios[0],
+			CoverageRatio: &ratios[1],
+			Months: []anservice.PassiveIncomeMonthResponse{
+				{Month: 1, Year: 2024, PassiveIncome: money.FromCents(85000), ActiveIncome: money.FromCents(340000), Expenses: money.FromCents(400000), PassiveShare: &ratios[0], CoverageRatio: &ratios[1]},
+			},
+			Categories: []anservice.CategoryIncomeResponse{
+				{CategoryId: 5, Category: "Dividendos", PassiveIncome: money.FromCents(85000)},
+				{CategoryId: 1, Category: "Salário", ActiveIncome: money.FromCents(340000)},
+			},
+		},
+	}
+	handler := NewHandler(_readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/analytics/passive-income", handler.GetPassiveIncome)
+
+	req, _ := http.NewRequest("GET", "/v1/analytics/passive-income?month=1&year=2024&months=1", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"currency":"BRL","start_month":1,"start_year":2024,"end_month":1,"end_year":2024,"passive_income":850,"active_income":3400,"expenses":4000,"passive_share":0.2,"coverage_ratio":0.2125,"months":[{"month":1,"year":2024,"passive_income":850,"active_income":3400,"expenses":4000,"passive_share":0.2,"coverage_ratio":0.2125}],"categories":[{"category_id":5,"category":"Dividendos","passive_income":850,"active_income":0},{"category_id":1,"category":"Salário","passive_income":0,"active_income":3400}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	expectedParams := anservice.NewSearchParamsBuilder().AddMonth(1).AddYear(2024).AddMonths(1).Build()
+	assert.Equal(t, *expectedParams, _readingProcessMock.searchCtx.Params)
+}
+
+func TestGetPassiveIncomeInvalidParams(t *testing.T) {
+	tests := []struct {
+		query  string
+		detail string
+	}{
+		{query: "year=2024", detail: "A param month 0 is invalid"},
+		{query: "month=1", detail: "A param year 0 is invalid"},
+		{query: "month=1&year=2024&months=0", detail: "The param months must be between 1 and 36"},
+		{query: "month=1&year=2024&currency=REAL", detail: "A param currency REAL is invalid"},
+	}
+	for _, test := range tests {
+		_readingProcessMock := &readingProcessMock{}
+		handler := NewHandler(_readingProcessMock)
+		w := httptest.NewRecorder()
+		router := gin.Default()
+		router.Use(problem.Middleware)
+		apiRouter := router.Group("/v1")
+		apiRouter.GET("/analytics/passive-income", handler.GetPassiveIncome)
+
+		req, _ := http.NewRequest("GET", "/v1/analytics/passive-income?"+test.query, nil)
+		req.Header.Add(idpauth.AUTH_HEADER, userToken)
+		router.ServeHTTP(w, req)
+
+		bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"` + test.detail + `"}`
+		assert.Equal(t, bodyExpected, w.Body.String())
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Nil(t, _readingProcessMock.searchCtx)
+	}
+}
+
+func TestGetPassiveIncomeFail(t *testing.T) {
+	handler := NewHandler(&readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/analytics/passive-income", handler.GetPassiveIncome)
+
+	req, _ := http.NewRequest("GET", "/v1/analytics/passive-income?month=6&year=2024", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
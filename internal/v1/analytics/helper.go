@@ -0,0 +1,74 @@
+package analytics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/analytics/anservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+func validateAndGetTrendParams(c *gin.Context) (*anservice.SearchParams, error) {
+	entity := c.Query("entity")
+	month, _ := strconv.ParseUint(c.Query("month"), 10, 32)
+	year, _ := strconv.ParseUint(c.Query("year"), 10, 32)
+	months, _ := strconv.ParseUint(c.DefaultQuery("months", strconv.Itoa(anservice.DEFAULT_MONTHS)), 10, 32)
+	movingAverageMonths, _ := strconv.ParseUint(c.DefaultQuery("moving_average", strconv.Itoa(anservice.DEFAULT_MOVING_AVERAGE_MONTHS)), 10, 32)
+	currency := strings.ToUpper(c.Query("currency"))
+
+	if entity != anservice.ENTITY_GAIN && entity != anservice.ENTITY_INVOICE {
+		return nil, apperror.Validation(fmt.Sprintf("A param entity %s is invalid", entity))
+	}
+	if month == uint64(0) || month > 12 {
+		return nil, apperror.Validation(fmt.Sprintf("A param month %d is invalid", month))
+	}
+	if year == uint64(0) {
+		return nil, apperror.Validation(fmt.Sprintf("A param year %d is invalid", year))
+	}
+	if months == uint64(0) || months > anservice.MAX_MONTHS {
+		return nil, apperror.Validation(fmt.Sprintf("The param months must be between 1 and %d", anservice.MAX_MONTHS))
+	}
+	if movingAverageMonths == uint64(0) || movingAverageMonths > anservice.MAX_MOVING_AVERAGE_MONTHS {
+		return nil, apperror.Validation(fmt.Sprintf("The param moving_average must be between 1 and %d", anservice.MAX_MOVING_AVERAGE_MONTHS))
+	}
+	if currency != "" && !validation.IsCurrency(currency) {
+		return nil, apperror.Validation(fmt.Sprintf("A param currency %s is invalid", currency))
+	}
+	return anservice.NewSearchParamsBuilder().
+		AddEntity(entity).
+		AddMonth(uint(month)).
+		AddYear(uint(year)).
+		AddMonths(uint(months)).
+		AddMovingAverageMonths(uint(movingAverageMonths)).
+		AddCurrency(currency).
+		Build(), nil
+}
+
+func validateAndGetPassiveIncomeParams(c *gin.Context) (*anservice.SearchParams, error) {
+	month, _ := strconv.ParseUint(c.Query("month"), 10, 32)
+	year, _ := strconv.ParseUint(c.Query("year"), 10, 32)
+	months, _ := strconv.ParseUint(c.DefaultQuery("months", strconv.Itoa(anservice.DEFAULT_MONTHS)), 10, 32)
+	currency := strings.ToUpper(c.Query("currency"))
+
+	if month == uint64(0) || month > 12 {
+		return nil, apperror.Validation(fmt.Sprintf("A param month %d is invalid", month))
+	}
+	if year == uint64(0) {
+		return nil, apperror.Validation(fmt.Sprintf("A param year %d is invalid", year))
+	}
+	if months == uint64(0) || months > anservice.MAX_MONTHS {
+		return nil, apperror.Validation(fmt.Sprintf("The param months must be between 1 and %d", anservice.MAX_MONTHS))
+	}
+	if currency != "" && !validation.IsCurrency(currency) {
+		return nil, apperror.Validation(fmt.Sprintf("A param currency %s is invalid", currency))
+	}
+	return anservice.NewSearchParamsBuilder().
+		AddMonth(uint(month)).
+		AddYear(uint(year)).
+		AddMonths(uint(months)).
+		AddCurrency(currency).
+		Build(), nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import "time"
+
+type QueryParamsBuilder struct {
+	userId string
+	start  time.Time
+	end    time.Time
+}
+
+func NewQueryParamsBuilder() *QueryParamsBuilder {
+	return &QueryParamsBuilder{}
+}
+func (builder *QueryParamsBuilder) AddUserId(userId string) *QueryParamsBuilder {
+	builder.userId = userId
+	return builder
+}
+
+// AddStart informs the first month of the window, any day of the month can be used
+func (builder *QueryParamsBuilder) AddStart(start time.Time) *QueryParamsBuilder {
+	builder.start = start
+	return builder
+}
+
+// AddEnd informs the last month of the window, any day of the month can be used
+func (builder *QueryParamsBuilder) AddEnd(end time.Time) *QueryParamsBuilder {
+	builder.end = end
+	return builder
+}
+func (builder *QueryParamsBuilder) Build() QueryParams {
+	return QueryParams{
+		userId: builder.userId,
+		start:  builder.start,
+		end:    builder.end,
+	}
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Repository interface {
+	GetGainTotals(ctx context.Context, params QueryParams) (*[]CategoryTotal, error)
+	GetInvoiceTotals(ctx context.Context, params QueryParams) (*[]CategoryTotal, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetGainTotals returns the gains of the window summed by category, kind of income, currency and month of payment,
+// so they can be converted with the rate of their month before being compared
+func (r *repository) GetGainTotals(ctx context.Context, params QueryParams) (*[]CategoryTotal, error) {
+	query := `
+		SELECT
+			gc.id,
+			gc.category,
+			g.is_passive,
+			g.currency,
+			YEAR(g.pay_in),
+			MONTH(g.pay_in),
+			SUM(g.value)
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON
+			gc.id = g.category_id
+		WHERE
+			g.user_id = ? AND g.deleted_at IS NULL AND g.pay_in >= ? AND g.pay_in < ?
+		GROUP BY gc.id, gc.category, g.is_passive, g.currency, YEAR(g.pay_in), MONTH(g.pay_in)
+		ORDER BY gc.id, YEAR(g.pay_in), MONTH(g.pay_in)`
+	return r.getTotals(ctx, query, params)
+}
+
+// GetInvoiceTotals returns the invoices of the window summed by category, currency and month of payment
+func (r *repository) GetInvoiceTotals(ctx context.Context, params QueryParams) (*[]CategoryTotal, error) {
+	query := `
+		SELECT
+			ic.id,
+			ic.category,
+			FALSE,
+			i.currency,
+			YEAR(i.pay_at),
+			MONTH(i.pay_at),
+			SUM(i.value)
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON
+			ic.id = i.category_id
+		WHERE
+			i.user_id = ? AND i.deleted_at IS NULL AND i.pay_at >= ? AND i.pay_at < ?
+		GROUP BY ic.id, ic.category, i.currency, YEAR(i.pay_at), MONTH(i.pay_at)
+		ORDER BY ic.id, YEAR(i.pay_at), MONTH(i.pay_at)`
+	return r.getTotals(ctx, query, params)
+}
+
+func (r *repository) getTotals(ctx context.Context, query string, params QueryParams) (*[]CategoryTotal, error) {
+	windowStart := firstDayOfMonth(params.start)
+	windowEnd := firstDayOfMonth(params.end).AddDate(0, 1, 0)
+	rows, err := r.db.QueryContext(ctx, query, params.userId, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totalList := []CategoryTotal{}
+	for rows.Next() {
+		var total CategoryTotal
+		var year int
+		var month int
+		err := rows.Scan(&total.Category.Id, &total.Category.Category, &total.IsPassive, &total.Currency, &year, &month, &total.Value)
+		if err != nil {
+			return nil, err
+		}
+		total.PayDate = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		totalList = append(totalList, total)
+	}
+	return &totalList, nil
+}
+
+func firstDayOfMonth(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getGainTotalsQuery = `
+		SELECT
+			gc.id,
+			gc.category,
+			g.is_passive,
+			g.currency,
+			YEAR(g.pay_in),
+			MONTH(g.pay_in),
+			SUM(g.value)
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON
+			gc.id = g.category_id
+		WHERE
+			g.user_id = ? AND g.deleted_at IS NULL AND g.pay_in >= ? AND g.pay_in < ?
+		GROUP BY gc.id, gc.category, g.is_passive, g.currency, YEAR(g.pay_in), MONTH(g.pay_in)
+		ORDER BY gc.id, YEAR(g.pay_in), MONTH(g.pay_in)`
+
+func buildQueryParamsMock() QueryParams {
+	return NewQueryParamsBuilder().
+		AddUserId("User1").
+		AddStart(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)).
+		AddEnd(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)).
+		Build()
+}
+
+func TestGetGainTotalsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category", "is_passive", "currency", "year", "month", "value"}).
+		AddRow(1, "Salário", false, "BRL", 2024, 1, "5000.00").
+		AddRow(5, "Dividendos", true, "USD", 2024, 2, "120.50")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainTotalsQuery).
+		WithArgs("User1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(rowsMock)
+
+	totalList, err := _repository.GetGainTotals(context.Background(), buildQueryParamsMock())
+	assert.NoError(t, err)
+	assert.Equal(t, []CategoryTotal{
+		{Category: Category{Id: 1, Category: "Salário"}, Currency: "BRL", PayDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: money.FromCents(500000)},
+		{Category: Category{Id: 5, Category: "Dividendos"}, IsPassive: true, Currency: "USD", PayDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Value: money.FromCents(12050)},
+	}, *totalList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainTotalsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category", "is_passive", "currency", "year", "month", "value"}).
+		AddRow(5, "Dividendos", true, "BRL", 2024, 1, "a lot")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainTotalsQuery).
+		WithArgs("User1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetGainTotals(context.Background(), buildQueryParamsMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainTotalsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainTotalsQuery).
+		WithArgs("User1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetGainTotals(context.Background(), buildQueryParamsMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getInvoiceTotalsQuery = `
+		SELECT
+			ic.id,
+			ic.category,
+			FALSE,
+			i.currency,
+			YEAR(i.pay_at),
+			MONTH(i.pay_at),
+			SUM(i.value)
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON
+			ic.id = i.category_id
+		WHERE
+			i.user_id = ? AND i.deleted_at IS NULL AND i.pay_at >= ? AND i.pay_at < ?
+		GROUP BY ic.id, ic.category, i.currency, YEAR(i.pay_at), MONTH(i.pay_at)
+		ORDER BY ic.id, YEAR(i.pay_at), MONTH(i.pay_at)`
+
+func TestGetInvoiceTotalsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category", "is_passive", "currency", "year", "month", "value"}).
+		AddRow(2, "Alimentação", false, "BRL", 2024, 1, "350.00").
+		AddRow(2, "Alimentação", false, "EUR", 2024, 2, "40.00")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceTotalsQuery).
+		WithArgs("User1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(rowsMock)
+
+	totalList, err := _repository.GetInvoiceTotals(context.Background(), buildQueryParamsMock())
+	assert.NoError(t, err)
+	assert.Equal(t, []CategoryTotal{
+		{Category: Category{Id: 2, Category: "Alimentação"}, Currency: "BRL", PayDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: money.FromCents(35000)},
+		{Category: Category{Id: 2, Category: "Alimentação"}, Currency: "EUR", PayDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Value: money.FromCents(4000)},
+	}, *totalList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceTotalsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceTotalsQuery).
+		WithArgs("User1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetInvoiceTotals(context.Background(), buildQueryParamsMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
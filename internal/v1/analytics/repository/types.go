@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type Category struct {
+	Id       uint
+	Category string
+}
+
+// CategoryTotal is the sum of the records of a category kept in a currency and paid in a month, whose first day
+// is the pay date. The gains are also split by the kind of income.
+type CategoryTotal struct {
+	Category  Category
+	IsPassive bool
+	Currency  string
+	PayDate   time.Time
+	Value     money.Money
+}
+
+type QueryParams struct {
+	userId string
+	start  time.Time
+	end    time.Time
+}
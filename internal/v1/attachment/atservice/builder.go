@@ -0,0 +1,56 @@
+package atservice
+
+import "time"
+
+type AttachmentResponseBuilder struct {
+	id          string
+	entity      string
+	entityId    string
+	fileName    string
+	contentType string
+	size        int64
+	createdAt   time.Time
+}
+
+func NewAttachmentResponseBuilder() *AttachmentResponseBuilder {
+	return &AttachmentResponseBuilder{}
+}
+func (builder *AttachmentResponseBuilder) AddId(id string) *AttachmentResponseBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *AttachmentResponseBuilder) AddEntity(entity string) *AttachmentResponseBuilder {
+	builder.entity = entity
+	return builder
+}
+func (builder *AttachmentResponseBuilder) AddEntityId(entityId string) *AttachmentResponseBuilder {
+	builder.entityId = entityId
+	return builder
+}
+func (builder *AttachmentResponseBuilder) AddFileName(fileName string) *AttachmentResponseBuilder {
+	builder.fileName = fileName
+	return builder
+}
+func (builder *AttachmentResponseBuilder) AddContentType(contentType string) *AttachmentResponseBuilder {
+	builder.contentType = contentType
+	return builder
+}
+func (builder *AttachmentResponseBuilder) AddSize(size int64) *AttachmentResponseBuilder {
+	builder.size = size
+	return builder
+}
+func (builder *AttachmentResponseBuilder) AddCreatedAt(createdAt time.Time) *AttachmentResponseBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *AttachmentResponseBuilder) Build() *AttachmentResponse {
+	return &AttachmentResponse{
+		Id:          builder.id,
+		Entity:      builder.entity,
+		EntityId:    builder.entityId,
+		FileName:    builder.fileName,
+		ContentType: builder.contentType,
+		Size:        builder.size,
+		CreatedAt:   builder.createdAt,
+	}
+}
@@ -0,0 +1,56 @@
+package atservice
+
+import (
+	"errors"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/blobstorage"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment/repository"
+)
+
+type ReadingProcess interface {
+	GetAll(searchCtx SearchContext) (*AttachmentListResponse, error)
+	Download(searchCtx SearchContext) (*AttachmentFile, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+	storage    blobstorage.Storage
+}
+
+func NewReadingProcess(repository repository.Repository, storage blobstorage.Storage) ReadingProcess {
+	return &readingProcess{repository: repository, storage: storage}
+}
+
+func (rp *readingProcess) GetAll(searchCtx SearchContext) (*AttachmentListResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	attachmentList, err := rp.repository.GetByEntity(searchCtx.Ctx, searchCtx.Entity, searchCtx.EntityId, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	attachmentResponseList := []AttachmentResponse{}
+	for _, attachment := range *attachmentList {
+		attachmentResponseList = append(attachmentResponseList, *toResponse(attachment))
+	}
+	return &AttachmentListResponse{Records: attachmentResponseList}, nil
+}
+
+func (rp *readingProcess) Download(searchCtx SearchContext) (*AttachmentFile, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	attachment, err := rp.repository.GetById(searchCtx.Ctx, searchCtx.Id, searchCtx.Entity, searchCtx.EntityId, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil {
+		return nil, apperror.NotFound("Attachment not found")
+	}
+	content, err := rp.storage.Get(searchCtx.Ctx, attachment.StorageKey)
+	if errors.Is(err, blobstorage.ErrNotFound) {
+		return nil, apperror.NotFound("Attachment file not found").Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &AttachmentFile{Attachment: *toResponse(*attachment), Content: content}, nil
+}
@@ -0,0 +1,50 @@
+package atservice
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildSearchContext() SearchContext {
+	return SearchContext{
+		Ctx:       context.TODO(),
+		UserToken: tokenMock,
+		Entity:    "invoice",
+		EntityId:  "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Id:        "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f",
+	}
+}
+
+func TestDownloadSuccess(t *testing.T) {
+	attachment := &repository.Attachment{Id: "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", FileName: "boleto.pdf", ContentType: "application/pdf", StorageKey: "key"}
+	_mockStorage := newMockStorage()
+	_mockStorage.blobs["key"] = []byte("%PDF-1.4")
+	_readingProcess := NewReadingProcess(&mockRepository{attachment: attachment}, _mockStorage)
+
+	file, err := _readingProcess.Download(buildSearchContext())
+	assert.NoError(t, err)
+	defer file.Content.Close()
+	content, _ := io.ReadAll(file.Content)
+	assert.Equal(t, "%PDF-1.4", string(content))
+	assert.Equal(t, "boleto.pdf", file.Attachment.FileName)
+}
+
+func TestDownloadNotFound(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{}, newMockStorage())
+
+	_, err := _readingProcess.Download(buildSearchContext())
+	assert.EqualError(t, err, "Attachment not found")
+}
+
+func TestDownloadFileNotFound(t *testing.T) {
+	attachment := &repository.Attachment{Id: "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", StorageKey: "missing"}
+	_readingProcess := NewReadingProcess(&mockRepository{attachment: attachment}, newMockStorage())
+
+	_, err := _readingProcess.Download(buildSearchContext())
+	assert.Equal(t, apperror.KIND_NOT_FOUND, apperror.KindOf(err))
+}
@@ -0,0 +1,37 @@
+package atservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{attachments: []repository.Attachment{
+		{Id: "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", Entity: "gain", FileName: "recibo.pdf", ContentType: "application/pdf", Size: 2048},
+	}}
+	_readingProcess := NewReadingProcess(_mockRepository, newMockStorage())
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock, Entity: "gain", EntityId: "cd1cc27b"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Records, 1)
+	assert.Equal(t, "recibo.pdf", response.Records[0].FileName)
+}
+
+func TestGetAllEmpty(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{}, newMockStorage())
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock, Entity: "gain", EntityId: "cd1cc27b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []AttachmentResponse{}, response.Records)
+}
+
+func TestGetAllFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, newMockStorage())
+
+	_, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock, Entity: "gain", EntityId: "cd1cc27b"})
+	assert.Error(t, err)
+}
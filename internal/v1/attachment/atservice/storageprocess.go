@@ -0,0 +1,166 @@
+package atservice
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/blobstorage"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment/repository"
+	uuid "github.com/satori/go.uuid"
+)
+
+type StorageProcess interface {
+	Upload(uploadCtx UploadContext) (*AttachmentResponse, error)
+	Delete(deleteCtx DeleteContext) error
+	Purge(ctx context.Context, deletedBefore time.Time) error
+}
+
+type storageProcess struct {
+	repository   repository.Repository
+	storage      blobstorage.Storage
+	generateUUID func() uuid.UUID
+}
+
+func NewStorageProcess(repository repository.Repository, storage blobstorage.Storage, generateUUID func() uuid.UUID) StorageProcess {
+	return &storageProcess{repository: repository, storage: storage, generateUUID: generateUUID}
+}
+
+// Upload stores the file in the blob storage and then registers it. The type of the file is
+// detected from its first bytes, so a renamed file is not accepted.
+func (sp *storageProcess) Upload(uploadCtx UploadContext) (*AttachmentResponse, error) {
+	user := idpauth.GetUser(uploadCtx.UserToken)
+	if uploadCtx.Size <= 0 {
+		return nil, apperror.Validation("The file is empty")
+	}
+	if uploadCtx.Size > MAX_FILE_SIZE {
+		return nil, apperror.TooLarge(fmt.Sprintf("The file must have at most %d MB", MAX_FILE_SIZE>>20))
+	}
+	err := sp.checkParent(uploadCtx.Ctx, uploadCtx.Entity, uploadCtx.EntityId, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReaderSize(uploadCtx.File, 512)
+	header, _ := reader.Peek(512)
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(header))
+	if !slices.Contains(AllowedContentTypes, contentType) {
+		return nil, apperror.UnsupportedMediaType(fmt.Sprintf("The file type %s is not accepted", contentType))
+	}
+
+	id := sp.generateUUID().String()
+	attachment := repository.NewAttachmentBuilder().
+		AddId(id).
+		AddCreatedAt(time.Now()).
+		AddUserId(user.Id).
+		AddEntity(uploadCtx.Entity).
+		AddEntityId(uploadCtx.EntityId).
+		AddFileName(sanitizeFileName(uploadCtx.FileName)).
+		AddContentType(contentType).
+		AddSize(uploadCtx.Size).
+		AddStorageKey(fmt.Sprintf("%s/%s/%s/%s", user.Id, uploadCtx.Entity, uploadCtx.EntityId, id)).
+		Build()
+
+	err = sp.storage.Put(uploadCtx.Ctx, attachment.StorageKey, reader, attachment.Size, attachment.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	attachmentSaved, err := sp.repository.Save(uploadCtx.Ctx, *attachment)
+	if err != nil {
+		sp.removeBlob(uploadCtx.Ctx, attachment.StorageKey)
+		return nil, err
+	}
+	return toResponse(*attachmentSaved), nil
+}
+
+func (sp *storageProcess) Delete(deleteCtx DeleteContext) error {
+	user := idpauth.GetUser(deleteCtx.UserToken)
+	attachment, err := sp.repository.GetById(deleteCtx.Ctx, deleteCtx.Id, deleteCtx.Entity, deleteCtx.EntityId, user.Id)
+	if err != nil {
+		return err
+	}
+	if attachment == nil {
+		return apperror.NotFound("Attachment not found")
+	}
+	err = sp.repository.Delete(deleteCtx.Ctx, attachment.Id, user.Id)
+	if err != nil {
+		return err
+	}
+	return sp.storage.Delete(deleteCtx.Ctx, attachment.StorageKey)
+}
+
+// Purge removes the attachments whose parent record was permanently removed, along with their files.
+// It runs after the purge of the trash, so the attachments follow their parent records.
+func (sp *storageProcess) Purge(ctx context.Context, deletedBefore time.Time) error {
+	attachmentList, err := sp.repository.GetOrphans(ctx)
+	if err != nil {
+		return err
+	}
+	for _, attachment := range *attachmentList {
+		err = sp.storage.Delete(ctx, attachment.StorageKey)
+		if err != nil {
+			return err
+		}
+		err = sp.repository.Delete(ctx, attachment.Id, attachment.UserId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sp *storageProcess) checkParent(ctx context.Context, entity string, entityId string, userId string) error {
+	exists, err := sp.repository.ParentExists(ctx, entity, entityId, userId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperror.NotFound(fmt.Sprintf("The %s %s was not found", entity, entityId))
+	}
+	return nil
+}
+
+// removeBlob undoes an upload whose record could not be stored, the failure is only logged
+// because the orphan file does not affect the records
+func (sp *storageProcess) removeBlob(ctx context.Context, key string) {
+	err := sp.storage.Delete(ctx, key)
+	if err != nil {
+		log.Println("Attachment blob removal failed:", err)
+	}
+}
+
+func sanitizeFileName(fileName string) string {
+	fileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return "file"
+	}
+	if len(fileName) > 255 {
+		extension := filepath.Ext(fileName)
+		if len(extension) > 16 {
+			extension = ""
+		}
+		fileName = fileName[:255-len(extension)] + extension
+	}
+	return fileName
+}
+
+func toResponse(attachment repository.Attachment) *AttachmentResponse {
+	return NewAttachmentResponseBuilder().
+		AddId(attachment.Id).
+		AddEntity(attachment.Entity).
+		AddEntityId(attachment.EntityId).
+		AddFileName(attachment.FileName).
+		AddContentType(attachment.ContentType).
+		AddSize(attachment.Size).
+		AddCreatedAt(attachment.CreatedAt).
+		Build()
+}
@@ -0,0 +1,53 @@
+package atservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildDeleteContext() DeleteContext {
+	return DeleteContext{
+		Ctx:       context.TODO(),
+		UserToken: tokenMock,
+		Entity:    "gain",
+		EntityId:  "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Id:        "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f",
+	}
+}
+
+func TestDeleteSuccess(t *testing.T) {
+	attachment := repository.NewAttachmentBuilder().
+		AddId("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f").
+		AddStorageKey("key").
+		Build()
+	_mockRepository := &mockRepository{attachment: attachment}
+	_mockStorage := newMockStorage()
+	_mockStorage.blobs["key"] = []byte("%PDF-1.4")
+	_storageProcess := NewStorageProcess(_mockRepository, _mockStorage, generateUUIDMock)
+
+	err := _storageProcess.Delete(buildDeleteContext())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f"}, _mockRepository.deleted)
+	assert.Empty(t, _mockStorage.blobs)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{}, newMockStorage(), generateUUIDMock)
+
+	err := _storageProcess.Delete(buildDeleteContext())
+	assert.Equal(t, apperror.KIND_NOT_FOUND, apperror.KindOf(err))
+}
+
+func TestDeleteGetByIdFail(t *testing.T) {
+	_mockRepository := &mockRepository{err: errors.New("An error has been ocurred")}
+	_storageProcess := NewStorageProcess(_mockRepository, newMockStorage(), generateUUIDMock)
+
+	err := _storageProcess.Delete(buildDeleteContext())
+	assert.Error(t, err)
+	assert.Empty(t, _mockRepository.deleted)
+}
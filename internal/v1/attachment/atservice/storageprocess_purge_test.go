@@ -0,0 +1,49 @@
+package atservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurgeSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{attachments: []repository.Attachment{
+		{Id: "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", UserId: "User1", StorageKey: "User1/gain/1/7d2f3c1e"},
+		{Id: "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", UserId: "User2", StorageKey: "User2/invoice/2/0a1b2c3d"},
+	}}
+	_mockStorage := newMockStorage()
+	_mockStorage.blobs["User1/gain/1/7d2f3c1e"] = []byte("%PDF-1.4")
+	_mockStorage.blobs["User2/invoice/2/0a1b2c3d"] = []byte("%PDF-1.4")
+	_mockStorage.blobs["User1/gain/3/kept"] = []byte("%PDF-1.4")
+	_storageProcess := NewStorageProcess(_mockRepository, _mockStorage, generateUUIDMock)
+
+	err := _storageProcess.Purge(context.TODO(), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"}, _mockRepository.deleted)
+	assert.Len(t, _mockStorage.blobs, 1)
+}
+
+func TestPurgeStorageFail(t *testing.T) {
+	_mockRepository := &mockRepository{attachments: []repository.Attachment{
+		{Id: "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", UserId: "User1", StorageKey: "User1/gain/1/7d2f3c1e"},
+	}}
+	_mockStorage := newMockStorage()
+	_mockStorage.err = errors.New("The bucket is unavailable")
+	_storageProcess := NewStorageProcess(_mockRepository, _mockStorage, generateUUIDMock)
+
+	err := _storageProcess.Purge(context.TODO(), time.Now())
+	assert.Error(t, err)
+	assert.Empty(t, _mockRepository.deleted)
+}
+
+func TestPurgeGetOrphansFail(t *testing.T) {
+	_mockRepository := &mockRepository{err: errors.New("An error has been ocurred")}
+	_storageProcess := NewStorageProcess(_mockRepository, newMockStorage(), generateUUIDMock)
+
+	err := _storageProcess.Purge(context.TODO(), time.Now())
+	assert.Error(t, err)
+}
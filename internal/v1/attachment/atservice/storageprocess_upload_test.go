@@ -0,0 +1,203 @@
+package atservice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/blobstorage"
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment/repository"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+const userIdMock = "5832a502-bede-492d-8dc1-b13b32c30f29"
+
+type mockRepository struct {
+	saved        []repository.Attachment
+	deleted      []string
+	attachment   *repository.Attachment
+	attachments  []repository.Attachment
+	parentExists bool
+	err          error
+	saveErr      error
+}
+
+func (r *mockRepository) Save(ctx context.Context, attachment repository.Attachment) (*repository.Attachment, error) {
+	if r.saveErr != nil {
+		return nil, r.saveErr
+	}
+	r.saved = append(r.saved, attachment)
+	return &attachment, nil
+}
+
+func (r *mockRepository) GetById(ctx context.Context, id string, entity string, entityId string, userId string) (*repository.Attachment, error) {
+	return r.attachment, r.err
+}
+
+func (r *mockRepository) GetByEntity(ctx context.Context, entity string, entityId string, userId string) (*[]repository.Attachment, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &r.attachments, nil
+}
+
+func (r *mockRepository) GetOrphans(ctx context.Context) (*[]repository.Attachment, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &r.attachments, nil
+}
+
+func (r *mockRepository) Delete(ctx context.Context, id string, userId string) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *mockRepository) ParentExists(ctx context.Context, entity string, entityId string, userId string) (bool, error) {
+	return r.parentExists, r.err
+}
+
+type mockStorage struct {
+	blobs map[string][]byte
+	err   error
+}
+
+func newMockStorage() *mockStorage {
+	return &mockStorage{blobs: map[string][]byte{}}
+}
+
+func (s *mockStorage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	if s.err != nil {
+		return s.err
+	}
+	data, _ := io.ReadAll(content)
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *mockStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, blobstorage.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *mockStorage) Delete(ctx context.Context, key string) error {
+	if s.err != nil {
+		return s.err
+	}
+	delete(s.blobs, key)
+	return nil
+}
+
+func buildUploadContext(content string) UploadContext {
+	return UploadContext{
+		Ctx:       context.TODO(),
+		UserToken: tokenMock,
+		Entity:    "invoice",
+		EntityId:  "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		FileName:  "C:\\Users\\boleto.pdf",
+		Size:      int64(len(content)),
+		File:      strings.NewReader(content),
+	}
+}
+
+func generateUUIDMock() uuid.UUID {
+	return uuid.FromStringOrNil("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f")
+}
+
+func TestUploadSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{parentExists: true}
+	_mockStorage := newMockStorage()
+	_storageProcess := NewStorageProcess(_mockRepository, _mockStorage, generateUUIDMock)
+
+	content := "%PDF-1.4 boleto"
+	response, err := _storageProcess.Upload(buildUploadContext(content))
+	assert.NoError(t, err)
+	assert.Equal(t, "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", response.Id)
+	assert.Equal(t, "boleto.pdf", response.FileName)
+	assert.Equal(t, "application/pdf", response.ContentType)
+	assert.Equal(t, int64(len(content)), response.Size)
+
+	key := userIdMock + "/invoice/cd1cc27b-28a1-47dc-ac76-70e8185e159d/7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f"
+	assert.Equal(t, key, _mockRepository.saved[0].StorageKey)
+	assert.Equal(t, content, string(_mockStorage.blobs[key]))
+}
+
+func TestUploadEmptyFile(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{parentExists: true}, newMockStorage(), generateUUIDMock)
+
+	_, err := _storageProcess.Upload(buildUploadContext(""))
+	assert.EqualError(t, err, "The file is empty")
+}
+
+func TestUploadTooLarge(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{parentExists: true}, newMockStorage(), generateUUIDMock)
+
+	uploadCtx := buildUploadContext("%PDF-1.4")
+	uploadCtx.Size = MAX_FILE_SIZE + 1
+	_, err := _storageProcess.Upload(uploadCtx)
+	var appErr *apperror.Error
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, appErr.Status)
+	assert.Equal(t, "The file must have at most 10 MB", appErr.Message)
+}
+
+func TestUploadParentNotFound(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{parentExists: false}, newMockStorage(), generateUUIDMock)
+
+	_, err := _storageProcess.Upload(buildUploadContext("%PDF-1.4"))
+	assert.Equal(t, apperror.KIND_NOT_FOUND, apperror.KindOf(err))
+	assert.EqualError(t, err, "The invoice cd1cc27b-28a1-47dc-ac76-70e8185e159d was not found")
+}
+
+func TestUploadUnsupportedType(t *testing.T) {
+	_mockStorage := newMockStorage()
+	_storageProcess := NewStorageProcess(&mockRepository{parentExists: true}, _mockStorage, generateUUIDMock)
+
+	_, err := _storageProcess.Upload(buildUploadContext("just a plain text"))
+	var appErr *apperror.Error
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusUnsupportedMediaType, appErr.Status)
+	assert.Equal(t, "The file type text/plain is not accepted", appErr.Message)
+	assert.Empty(t, _mockStorage.blobs)
+}
+
+func TestUploadStorageFail(t *testing.T) {
+	_mockRepository := &mockRepository{parentExists: true}
+	_mockStorage := newMockStorage()
+	_mockStorage.err = errors.New("The bucket is unavailable")
+	_storageProcess := NewStorageProcess(_mockRepository, _mockStorage, generateUUIDMock)
+
+	_, err := _storageProcess.Upload(buildUploadContext("%PDF-1.4"))
+	assert.EqualError(t, err, "The bucket is unavailable")
+	assert.Empty(t, _mockRepository.saved)
+}
+
+func TestUploadSaveFailRemovesBlob(t *testing.T) {
+	_mockRepository := &mockRepository{parentExists: true, saveErr: errors.New("An error has been ocurred")}
+	_mockStorage := newMockStorage()
+	_storageProcess := NewStorageProcess(_mockRepository, _mockStorage, generateUUIDMock)
+
+	_, err := _storageProcess.Upload(buildUploadContext("%PDF-1.4"))
+	assert.Error(t, err)
+	assert.Empty(t, _mockStorage.blobs)
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	assert.Equal(t, "boleto.pdf", sanitizeFileName("../../boleto.pdf"))
+	assert.Equal(t, "recibo.png", sanitizeFileName("C:\\fotos\\recibo.png"))
+	assert.Equal(t, "file", sanitizeFileName("  "))
+	long := sanitizeFileName(strings.Repeat("a", 300) + ".pdf")
+	assert.Len(t, long, 255)
+	assert.True(t, strings.HasSuffix(long, ".pdf"))
+}
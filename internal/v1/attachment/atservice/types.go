@@ -0,0 +1,59 @@
+package atservice
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// MAX_FILE_SIZE limits the size of each attached file to 10 MB
+const MAX_FILE_SIZE = 10 << 20
+
+// AllowedContentTypes are the types accepted for the attached files, detected from their content
+var AllowedContentTypes = []string{"application/pdf", "image/jpeg", "image/png", "image/webp"}
+
+type UploadContext struct {
+	Ctx       context.Context
+	UserToken string
+	Entity    string
+	EntityId  string
+	FileName  string
+	Size      int64
+	File      io.Reader
+}
+
+type SearchContext struct {
+	Ctx       context.Context
+	UserToken string
+	Entity    string
+	EntityId  string
+	Id        string
+}
+
+type DeleteContext struct {
+	Ctx       context.Context
+	UserToken string
+	Entity    string
+	EntityId  string
+	Id        string
+}
+
+type AttachmentResponse struct {
+	Id          string    `json:"id"`
+	Entity      string    `json:"entity"`
+	EntityId    string    `json:"entity_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type AttachmentListResponse struct {
+	Records []AttachmentResponse `json:"records"`
+}
+
+// AttachmentFile is the content of an attached file, which must be closed by the caller
+type AttachmentFile struct {
+	Attachment AttachmentResponse
+	Content    io.ReadCloser
+}
@@ -0,0 +1,230 @@
+package attachment
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment/atservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"go.elastic.co/apm"
+)
+
+// uploadOverhead is the room given to the multipart boundaries and headers over the size of the file
+const uploadOverhead = 64 << 10
+
+type Handler interface {
+	Upload(c *gin.Context)
+	GetAll(c *gin.Context)
+	Download(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	storageProcess atservice.StorageProcess
+	readingProcess atservice.ReadingProcess
+}
+
+func NewHandler(storageProcess atservice.StorageProcess, readingProcess atservice.ReadingProcess) Handler {
+	return &handler{storageProcess: storageProcess, readingProcess: readingProcess}
+}
+
+// @Summary Anexar um arquivo
+// @Description Este endpoint permite anexar um arquivo, como um recibo ou um boleto, a uma receita, despesa ou previsão.
+// @Description São aceitos arquivos PDF, JPEG, PNG e WEBP de até 10 MB
+// @Tags Attachment
+// @Accept multipart/form-data
+// @Produce json
+// @Param entity path string true "Tipo do registro (gain, gain-projection, invoice, invoice-projection)"
+// @Param id path string true "Id do registro"
+// @Param file formData file true "Arquivo que será anexado"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} atservice.AttachmentResponse
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 413 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 415 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/attachment/{entity}/{id} [post]
+func (h *handler) Upload(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	entity := c.Param("entity")
+
+	err := validateEntity(entity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, atservice.MAX_FILE_SIZE+uploadOverhead)
+	fileHeader, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.Error(apperror.TooLarge("The file must have at most 10 MB"))
+		return
+	}
+	if err != nil {
+		c.Error(validation.Errors{validation.Required("file")})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer file.Close()
+
+	span := tx.StartSpan("Attachment::StorageProcess::Upload", "Upload an attachment", nil)
+	uploadCtx := atservice.UploadContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Entity:    entity,
+		EntityId:  c.Param("id"),
+		FileName:  fileHeader.Filename,
+		Size:      fileHeader.Size,
+		File:      file,
+	}
+	attachment, err := h.storageProcess.Upload(uploadCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// @Summary Obter os anexos de um registro
+// @Description Este endpoint permite obter os arquivos anexados a uma receita, despesa ou previsão
+// @Tags Attachment
+// @Accept json
+// @Produce json
+// @Param entity path string true "Tipo do registro (gain, gain-projection, invoice, invoice-projection)"
+// @Param id path string true "Id do registro"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} atservice.AttachmentListResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/attachment/{entity}/{id} [get]
+func (h *handler) GetAll(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	entity := c.Param("entity")
+
+	err := validateEntity(entity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Attachment::ReadingProcess::GetAll", "Get the attachments of a record", nil)
+	searchCtx := atservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Entity:    entity,
+		EntityId:  c.Param("id"),
+	}
+	attachments, err := h.readingProcess.GetAll(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, attachments)
+}
+
+// @Summary Baixar um anexo
+// @Description Este endpoint permite baixar o arquivo anexado a uma receita, despesa ou previsão
+// @Tags Attachment
+// @Produce application/pdf
+// @Produce image/jpeg
+// @Produce image/png
+// @Produce image/webp
+// @Param entity path string true "Tipo do registro (gain, gain-projection, invoice, invoice-projection)"
+// @Param id path string true "Id do registro"
+// @Param attachment_id path string true "Id do anexo"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {file} file
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/attachment/{entity}/{id}/{attachment_id} [get]
+func (h *handler) Download(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	entity := c.Param("entity")
+
+	err := validateEntity(entity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Attachment::ReadingProcess::Download", "Download an attachment", nil)
+	searchCtx := atservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Entity:    entity,
+		EntityId:  c.Param("id"),
+		Id:        c.Param("attachment_id"),
+	}
+	file, err := h.readingProcess.Download(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	defer file.Content.Close()
+	span.End()
+	headers := map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": file.Attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	}
+	c.DataFromReader(http.StatusOK, file.Attachment.Size, file.Attachment.ContentType, file.Content, headers)
+}
+
+// @Summary Remover um anexo
+// @Description Este endpoint permite remover o arquivo anexado a uma receita, despesa ou previsão
+// @Tags Attachment
+// @Accept json
+// @Produce json
+// @Param entity path string true "Tipo do registro (gain, gain-projection, invoice, invoice-projection)"
+// @Param id path string true "Id do registro"
+// @Param attachment_id path string true "Id do anexo"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ResponseDefault{status=int,message=string}
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/attachment/{entity}/{id}/{attachment_id} [delete]
+func (h *handler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	entity := c.Param("entity")
+
+	err := validateEntity(entity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Attachment::StorageProcess::Delete", "Delete an attachment", nil)
+	deleteCtx := atservice.DeleteContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Entity:    entity,
+		EntityId:  c.Param("id"),
+		Id:        c.Param("attachment_id"),
+	}
+	err = h.storageProcess.Delete(deleteCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Attachment removed"})
+}
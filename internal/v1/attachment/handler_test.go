@@ -0,0 +1,216 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment/atservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type storageProcessMock struct {
+	err        error
+	attachment *atservice.AttachmentResponse
+	uploadCtx  atservice.UploadContext
+	content    string
+	deleteCtx  atservice.DeleteContext
+}
+
+func (sp *storageProcessMock) Upload(uploadCtx atservice.UploadContext) (*atservice.AttachmentResponse, error) {
+	sp.uploadCtx = uploadCtx
+	content, _ := io.ReadAll(uploadCtx.File)
+	sp.content = string(content)
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.attachment, nil
+}
+
+func (sp *storageProcessMock) Delete(deleteCtx atservice.DeleteContext) error {
+	sp.deleteCtx = deleteCtx
+	return sp.err
+}
+
+func (sp *storageProcessMock) Purge(ctx context.Context, deletedBefore time.Time) error {
+	return sp.err
+}
+
+type readingProcessMock struct {
+	err         error
+	attachments *atservice.AttachmentListResponse
+	file        *atservice.AttachmentFile
+}
+
+func (rp *readingProcessMock) GetAll(searchCtx atservice.SearchContext) (*atservice.AttachmentListResponse, error) {
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.attachments, nil
+}
+
+func (rp *readingProcessMock) Download(searchCtx atservice.SearchContext) (*atservice.AttachmentFile, error) {
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.file, nil
+}
+
+func newRouter(h Handler) *gin.Engine {
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/attachment/:entity/:id", h.Upload)
+	apiRouter.GET("/attachment/:entity/:id", h.GetAll)
+	apiRouter.GET("/attachment/:entity/:id/:attachment_id", h.Download)
+	apiRouter.DELETE("/attachment/:entity/:id/:attachment_id", h.Delete)
+	return router
+}
+
+func newUploadRequest(t *testing.T, path string, fileName string, content string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", fileName)
+	assert.NoError(t, err)
+	part.Write([]byte(content))
+	writer.Close()
+	req, _ := http.NewRequest("POST", path, body)
+	req.Header.Add("Content-Type", writer.FormDataContentType())
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	return req
+}
+
+func TestUploadSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{attachment: &atservice.AttachmentResponse{
+		Id:          "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f",
+		Entity:      "invoice",
+		EntityId:    "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		FileName:    "boleto.pdf",
+		ContentType: "application/pdf",
+		Size:        8,
+		CreatedAt:   time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+	}}
+	w := httptest.NewRecorder()
+	router := newRouter(NewHandler(_storageProcessMock, nil))
+
+	router.ServeHTTP(w, newUploadRequest(t, "/v1/attachment/invoice/cd1cc27b-28a1-47dc-ac76-70e8185e159d", "boleto.pdf", "%PDF-1.4"))
+	bodyExpected := `{"id":"7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f","entity":"invoice","entity_id":"cd1cc27b-28a1-47dc-ac76-70e8185e159d","file_name":"boleto.pdf","content_type":"application/pdf","size":8,"created_at":"2024-03-01T10:00:00Z"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "%PDF-1.4", _storageProcessMock.content)
+	assert.Equal(t, "boleto.pdf", _storageProcessMock.uploadCtx.FileName)
+	assert.Equal(t, int64(8), _storageProcessMock.uploadCtx.Size)
+	assert.Equal(t, "cd1cc27b-28a1-47dc-ac76-70e8185e159d", _storageProcessMock.uploadCtx.EntityId)
+}
+
+func TestUploadInvalidEntity(t *testing.T) {
+	w := httptest.NewRecorder()
+	router := newRouter(NewHandler(&storageProcessMock{}, nil))
+
+	router.ServeHTTP(w, newUploadRequest(t, "/v1/attachment/label/cd1cc27b", "boleto.pdf", "%PDF-1.4"))
+	bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"A param entity label is invalid"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUploadWithoutFile(t *testing.T) {
+	w := httptest.NewRecorder()
+	router := newRouter(NewHandler(&storageProcessMock{}, nil))
+
+	req, _ := http.NewRequest("POST", "/v1/attachment/gain/cd1cc27b", bytes.NewReader([]byte(`{}`)))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"file","code":"required","message":"The file must be informed"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestUploadTooLarge(t *testing.T) {
+	w := httptest.NewRecorder()
+	router := newRouter(NewHandler(&storageProcessMock{}, nil))
+
+	content := strings.Repeat("a", atservice.MAX_FILE_SIZE+uploadOverhead)
+	router.ServeHTTP(w, newUploadRequest(t, "/v1/attachment/gain/cd1cc27b", "boleto.pdf", content))
+	bodyExpected := `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"The file must have at most 10 MB"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestUploadUnsupportedType(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{err: apperror.UnsupportedMediaType("The file type text/plain is not accepted")}
+	w := httptest.NewRecorder()
+	router := newRouter(NewHandler(_storageProcessMock, nil))
+
+	router.ServeHTTP(w, newUploadRequest(t, "/v1/attachment/gain/cd1cc27b", "notes.txt", "just a plain text"))
+	bodyExpected := `{"type":"about:blank","title":"Unsupported Media Type","status":415,"detail":"The file type text/plain is not accepted"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
+
+func TestGetAllSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{attachments: &atservice.AttachmentListResponse{Records: []atservice.AttachmentResponse{}}}
+	w := httptest.NewRecorder()
+	router := newRouter(NewHandler(nil, _readingProcessMock))
+
+	req, _ := http.NewRequest("GET", "/v1/attachment/gain-projection/cd1cc27b", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, `{"records":[]}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDownloadSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{file: &atservice.AttachmentFile{
+		Attachment: atservice.AttachmentResponse{FileName: "boleto março.pdf", ContentType: "application/pdf", Size: 8},
+		Content:    io.NopCloser(strings.NewReader("%PDF-1.4")),
+	}}
+	w := httptest.NewRecorder()
+	router := newRouter(NewHandler(nil, _readingProcessMock))
+
+	req, _ := http.NewRequest("GET", "/v1/attachment/invoice/cd1cc27b/7d2f3c1e", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, "%PDF-1.4", w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename*=utf-8''boleto%20mar%C3%A7o.pdf", w.Header().Get("Content-Disposition"))
+}
+
+func TestDownloadNotFound(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{err: apperror.NotFound("Attachment not found")}
+	w := httptest.NewRecorder()
+	router := newRouter(NewHandler(nil, _readingProcessMock))
+
+	req, _ := http.NewRequest("GET", "/v1/attachment/invoice/cd1cc27b/7d2f3c1e", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+	bodyExpected := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Attachment not found"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{}
+	w := httptest.NewRecorder()
+	router := newRouter(NewHandler(_storageProcessMock, nil))
+
+	req, _ := http.NewRequest("DELETE", "/v1/attachment/gain/cd1cc27b/7d2f3c1e", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, `{"message":"Attachment removed","status":200}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "7d2f3c1e", _storageProcessMock.deleteCtx.Id)
+	assert.Equal(t, "gain", _storageProcessMock.deleteCtx.Entity)
+}
@@ -0,0 +1,16 @@
+package attachment
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+)
+
+func validateEntity(entity string) error {
+	if !slices.Contains(aservice.Entities, entity) {
+		return apperror.Validation(fmt.Sprintf("A param entity %s is invalid", entity))
+	}
+	return nil
+}
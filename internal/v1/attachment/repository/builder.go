@@ -0,0 +1,68 @@
+package repository
+
+import "time"
+
+type AttachmentBuilder struct {
+	id          string
+	createdAt   time.Time
+	userId      string
+	entity      string
+	entityId    string
+	fileName    string
+	contentType string
+	size        int64
+	storageKey  string
+}
+
+func NewAttachmentBuilder() *AttachmentBuilder {
+	return &AttachmentBuilder{}
+}
+func (builder *AttachmentBuilder) AddId(id string) *AttachmentBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *AttachmentBuilder) AddCreatedAt(createdAt time.Time) *AttachmentBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *AttachmentBuilder) AddUserId(userId string) *AttachmentBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *AttachmentBuilder) AddEntity(entity string) *AttachmentBuilder {
+	builder.entity = entity
+	return builder
+}
+func (builder *AttachmentBuilder) AddEntityId(entityId string) *AttachmentBuilder {
+	builder.entityId = entityId
+	return builder
+}
+func (builder *AttachmentBuilder) AddFileName(fileName string) *AttachmentBuilder {
+	builder.fileName = fileName
+	return builder
+}
+func (builder *AttachmentBuilder) AddContentType(contentType string) *AttachmentBuilder {
+	builder.contentType = contentType
+	return builder
+}
+func (builder *AttachmentBuilder) AddSize(size int64) *AttachmentBuilder {
+	builder.size = size
+	return builder
+}
+func (builder *AttachmentBuilder) AddStorageKey(storageKey string) *AttachmentBuilder {
+	builder.storageKey = storageKey
+	return builder
+}
+func (builder *AttachmentBuilder) Build() *Attachment {
+	return &Attachment{
+		Id:          builder.id,
+		CreatedAt:   builder.createdAt,
+		UserId:      builder.userId,
+		Entity:      builder.entity,
+		EntityId:    builder.entityId,
+		FileName:    builder.fileName,
+		ContentType: builder.contentType,
+		Size:        builder.size,
+		StorageKey:  builder.storageKey,
+	}
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ORPHANS_LIMIT is the number of orphan attachments removed on each purge
+const ORPHANS_LIMIT = 500
+
+// parentTables maps the entity of an attachment to the table of its parent record
+var parentTables = map[string]string{
+	"gain":               "gain",
+	"gain-projection":    "gain_projection",
+	"invoice":            "invoice",
+	"invoice-projection": "invoice_projection",
+}
+
+type Repository interface {
+	Save(ctx context.Context, attachment Attachment) (*Attachment, error)
+	GetById(ctx context.Context, id string, entity string, entityId string, userId string) (*Attachment, error)
+	GetByEntity(ctx context.Context, entity string, entityId string, userId string) (*[]Attachment, error)
+	GetOrphans(ctx context.Context) (*[]Attachment, error)
+	Delete(ctx context.Context, id string, userId string) error
+	ParentExists(ctx context.Context, entity string, entityId string, userId string) (bool, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Save(ctx context.Context, attachment Attachment) (*Attachment, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO attachment (id, created_at, user_id, entity, entity_id, file_name, content_type, size, storage_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		attachment.Id,
+		attachment.CreatedAt.Unix(),
+		attachment.UserId,
+		attachment.Entity,
+		attachment.EntityId,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *repository) scanAttachments(rows *sql.Rows) (*[]Attachment, error) {
+	attachmentList := []Attachment{}
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var attachment Attachment
+
+		err := rows.Scan(
+			&attachment.Id,
+			&createdAtTimestamp,
+			&attachment.UserId,
+			&attachment.Entity,
+			&attachment.EntityId,
+			&attachment.FileName,
+			&attachment.ContentType,
+			&attachment.Size,
+			&attachment.StorageKey)
+		if err != nil {
+			return nil, err
+		}
+		attachment.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+
+		attachmentList = append(attachmentList, attachment)
+	}
+	return &attachmentList, nil
+}
+
+func (r *repository) GetById(ctx context.Context, id string, entity string, entityId string, userId string) (*Attachment, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			entity,
+			entity_id,
+			file_name,
+			content_type,
+			size,
+			storage_key
+		FROM
+			attachment
+		WHERE
+			id = ? AND entity = ? AND entity_id = ? AND user_id = ?`
+	rows, err := r.db.QueryContext(ctx, query, id, entity, entityId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	attachmentList, err := r.scanAttachments(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(*attachmentList) == 0 {
+		return nil, nil
+	}
+	return &(*attachmentList)[0], nil
+}
+
+func (r *repository) GetByEntity(ctx context.Context, entity string, entityId string, userId string) (*[]Attachment, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			entity,
+			entity_id,
+			file_name,
+			content_type,
+			size,
+			storage_key
+		FROM
+			attachment
+		WHERE
+			entity = ? AND entity_id = ? AND user_id = ?
+		ORDER BY created_at ASC`
+	rows, err := r.db.QueryContext(ctx, query, entity, entityId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanAttachments(rows)
+}
+
+// GetOrphans returns the attachments whose parent record was permanently removed
+func (r *repository) GetOrphans(ctx context.Context) (*[]Attachment, error) {
+	query := `
+		SELECT
+			a.id,
+			a.created_at,
+			a.user_id,
+			a.entity,
+			a.entity_id,
+			a.file_name,
+			a.content_type,
+			a.size,
+			a.storage_key
+		FROM
+			attachment a
+		WHERE
+			(a.entity = 'gain' AND NOT EXISTS (SELECT 1 FROM gain p WHERE p.id = a.entity_id)) OR
+			(a.entity = 'gain-projection' AND NOT EXISTS (SELECT 1 FROM gain_projection p WHERE p.id = a.entity_id)) OR
+			(a.entity = 'invoice' AND NOT EXISTS (SELECT 1 FROM invoice p WHERE p.id = a.entity_id)) OR
+			(a.entity = 'invoice-projection' AND NOT EXISTS (SELECT 1 FROM invoice_projection p WHERE p.id = a.entity_id))
+		LIMIT ?`
+	rows, err := r.db.QueryContext(ctx, query, ORPHANS_LIMIT)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanAttachments(rows)
+}
+
+func (r *repository) Delete(ctx context.Context, id string, userId string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM attachment WHERE id = ? AND user_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ParentExists tells whether the record the attachment belongs to is registered and out of the trash
+func (r *repository) ParentExists(ctx context.Context, entity string, entityId string, userId string) (bool, error) {
+	table, ok := parentTables[entity]
+	if !ok {
+		return false, fmt.Errorf("unknown attachment entity %s", entity)
+	}
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE id = ? AND user_id = ? AND deleted_at IS NULL`, table)
+	var total uint
+	err := r.db.QueryRowContext(ctx, query, entityId, userId).Scan(&total)
+	if err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
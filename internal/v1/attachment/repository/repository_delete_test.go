@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`DELETE FROM attachment WHERE id = ? AND user_id = ?`).
+		ExpectExec().
+		WithArgs("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "User1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.Delete(context.Background(), "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "User1")
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDeleteFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`DELETE FROM attachment WHERE id = ? AND user_id = ?`).
+		ExpectExec().
+		WithArgs("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.Delete(context.Background(), "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
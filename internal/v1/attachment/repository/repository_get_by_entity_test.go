@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getByEntityQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			entity,
+			entity_id,
+			file_name,
+			content_type,
+			size,
+			storage_key
+		FROM
+			attachment
+		WHERE
+			entity = ? AND entity_id = ? AND user_id = ?
+		ORDER BY created_at ASC`
+
+func TestGetByEntitySuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(attachmentColumns).
+		AddRow("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", time.Now().Unix(), "User1", "gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+			"recibo.pdf", "application/pdf", 2048, "User1/gain/cd1cc27b-28a1-47dc-ac76-70e8185e159d/7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f").
+		AddRow("0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", time.Now().Unix(), "User1", "gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+			"comprovante.png", "image/png", 1024, "User1/gain/cd1cc27b-28a1-47dc-ac76-70e8185e159d/0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByEntityQuery).
+		WithArgs("gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1").
+		WillReturnRows(rowsMock)
+
+	attachmentList, err := _repository.GetByEntity(context.Background(), "gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.NoError(t, err)
+	assert.Len(t, *attachmentList, 2)
+	assert.Equal(t, "image/png", (*attachmentList)[1].ContentType)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByEntityScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(attachmentColumns).
+		AddRow("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", time.Now().Unix(), "User1", "gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+			"recibo.pdf", "application/pdf", "two kilobytes", "User1/gain/cd1cc27b-28a1-47dc-ac76-70e8185e159d/7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByEntityQuery).
+		WithArgs("gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetByEntity(context.Background(), "gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByEntityQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByEntityQuery).
+		WithArgs("gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetByEntity(context.Background(), "gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
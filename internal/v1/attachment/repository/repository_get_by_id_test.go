@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getByIdQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			entity,
+			entity_id,
+			file_name,
+			content_type,
+			size,
+			storage_key
+		FROM
+			attachment
+		WHERE
+			id = ? AND entity = ? AND entity_id = ? AND user_id = ?`
+
+var attachmentColumns = []string{"id", "created_at", "user_id", "entity", "entity_id", "file_name", "content_type", "size", "storage_key"}
+
+func TestGetByIdSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(attachmentColumns).AddRow(
+		"7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f",
+		time.Now().Unix(),
+		"User1",
+		"invoice",
+		"cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		"boleto.pdf",
+		"application/pdf",
+		2048,
+		"User1/invoice/cd1cc27b-28a1-47dc-ac76-70e8185e159d/7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f",
+	)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "invoice", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1").
+		WillReturnRows(rowsMock)
+
+	attachment, err := _repository.GetById(context.Background(), "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "invoice", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "boleto.pdf", attachment.FileName)
+	assert.Equal(t, int64(2048), attachment.Size)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "invoice", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1").
+		WillReturnRows(sqlMock.NewRows(attachmentColumns))
+
+	attachment, err := _repository.GetById(context.Background(), "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "invoice", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, attachment)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "invoice", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetById(context.Background(), "7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", "invoice", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
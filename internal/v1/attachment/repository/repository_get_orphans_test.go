@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getOrphansQuery = `
+		SELECT
+			a.id,
+			a.created_at,
+			a.user_id,
+			a.entity,
+			a.entity_id,
+			a.file_name,
+			a.content_type,
+			a.size,
+			a.storage_key
+		FROM
+			attachment a
+		WHERE
+			(a.entity = 'gain' AND NOT EXISTS (SELECT 1 FROM gain p WHERE p.id = a.entity_id)) OR
+			(a.entity = 'gain-projection' AND NOT EXISTS (SELECT 1 FROM gain_projection p WHERE p.id = a.entity_id)) OR
+			(a.entity = 'invoice' AND NOT EXISTS (SELECT 1 FROM invoice p WHERE p.id = a.entity_id)) OR
+			(a.entity = 'invoice-projection' AND NOT EXISTS (SELECT 1 FROM invoice_projection p WHERE p.id = a.entity_id))
+		LIMIT ?`
+
+func TestGetOrphansSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(attachmentColumns).
+		AddRow("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f", time.Now().Unix(), "User1", "invoice-projection", "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+			"boleto.pdf", "application/pdf", 2048, "User1/invoice-projection/cd1cc27b-28a1-47dc-ac76-70e8185e159d/7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getOrphansQuery).
+		WithArgs(ORPHANS_LIMIT).
+		WillReturnRows(rowsMock)
+
+	attachmentList, err := _repository.GetOrphans(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, *attachmentList, 1)
+	assert.Equal(t, "invoice-projection", (*attachmentList)[0].Entity)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetOrphansQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getOrphansQuery).
+		WithArgs(ORPHANS_LIMIT).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetOrphans(context.Background())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParentExistsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`SELECT COUNT(*) FROM invoice_projection WHERE id = ? AND user_id = ? AND deleted_at IS NULL`).
+		WithArgs("cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1").
+		WillReturnRows(sqlMock.NewRows([]string{"total"}).AddRow(1))
+	sqlMock.ExpectQuery(`SELECT COUNT(*) FROM gain WHERE id = ? AND user_id = ? AND deleted_at IS NULL`).
+		WithArgs("cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1").
+		WillReturnRows(sqlMock.NewRows([]string{"total"}).AddRow(0))
+
+	exists, err := _repository.ParentExists(context.Background(), "invoice-projection", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = _repository.ParentExists(context.Background(), "gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestParentExistsUnknownEntity(t *testing.T) {
+	dbMock, _, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	_, err = _repository.ParentExists(context.Background(), "label", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.EqualError(t, err, "unknown attachment entity label")
+}
+
+func TestParentExistsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`SELECT COUNT(*) FROM gain WHERE id = ? AND user_id = ? AND deleted_at IS NULL`).
+		WithArgs("cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.ParentExists(context.Background(), "gain", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveQuery = `
+		INSERT INTO attachment (id, created_at, user_id, entity, entity_id, file_name, content_type, size, storage_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func buildAttachmentMock() *Attachment {
+	return NewAttachmentBuilder().
+		AddId("7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f").
+		AddCreatedAt(time.Now()).
+		AddUserId("User1").
+		AddEntity("invoice").
+		AddEntityId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddFileName("boleto.pdf").
+		AddContentType("application/pdf").
+		AddSize(2048).
+		AddStorageKey("User1/invoice/cd1cc27b-28a1-47dc-ac76-70e8185e159d/7d2f3c1e-5b8a-4f0e-9c6d-2a1b3c4d5e6f").
+		Build()
+}
+
+func TestSaveSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	attachmentMock := buildAttachmentMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveQuery).
+		ExpectExec().
+		WithArgs(
+			attachmentMock.Id,
+			attachmentMock.CreatedAt.Unix(),
+			attachmentMock.UserId,
+			attachmentMock.Entity,
+			attachmentMock.EntityId,
+			attachmentMock.FileName,
+			attachmentMock.ContentType,
+			attachmentMock.Size,
+			attachmentMock.StorageKey).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	attachmentSaved, err := _repository.Save(context.Background(), *attachmentMock)
+	assert.NoError(t, err)
+	assert.Equal(t, attachmentMock.Id, attachmentSaved.Id)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	attachmentMock := buildAttachmentMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveQuery).
+		ExpectExec().
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.Save(context.Background(), *attachmentMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
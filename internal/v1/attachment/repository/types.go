@@ -0,0 +1,17 @@
+package repository
+
+import "time"
+
+// Attachment is a file, such as a receipt or a boleto, attached to a gain, an invoice or a projection.
+// The content is kept in the blob storage under the StorageKey.
+type Attachment struct {
+	Id          string
+	CreatedAt   time.Time
+	UserId      string
+	Entity      string
+	EntityId    string
+	FileName    string
+	ContentType string
+	Size        int64
+	StorageKey  string
+}
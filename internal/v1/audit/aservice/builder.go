@@ -0,0 +1,103 @@
+package aservice
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditResponseBuilder struct {
+	id        string
+	createdAt time.Time
+	actor     string
+	action    string
+	entity    string
+	entityId  string
+	requestId string
+	before    json.RawMessage
+	after     json.RawMessage
+}
+
+func NewAuditResponseBuilder() *AuditResponseBuilder {
+	return &AuditResponseBuilder{}
+}
+func (builder *AuditResponseBuilder) AddId(id string) *AuditResponseBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *AuditResponseBuilder) AddCreatedAt(createdAt time.Time) *AuditResponseBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *AuditResponseBuilder) AddActor(actor string) *AuditResponseBuilder {
+	builder.actor = actor
+	return builder
+}
+func (builder *AuditResponseBuilder) AddAction(action string) *AuditResponseBuilder {
+	builder.action = action
+	return builder
+}
+func (builder *AuditResponseBuilder) AddEntity(entity string) *AuditResponseBuilder {
+	builder.entity = entity
+	return builder
+}
+func (builder *AuditResponseBuilder) AddEntityId(entityId string) *AuditResponseBuilder {
+	builder.entityId = entityId
+	return builder
+}
+func (builder *AuditResponseBuilder) AddRequestId(requestId string) *AuditResponseBuilder {
+	builder.requestId = requestId
+	return builder
+}
+func (builder *AuditResponseBuilder) AddBefore(before string) *AuditResponseBuilder {
+	if before != "" {
+		builder.before = json.RawMessage(before)
+	}
+	return builder
+}
+func (builder *AuditResponseBuilder) AddAfter(after string) *AuditResponseBuilder {
+	if after != "" {
+		builder.after = json.RawMessage(after)
+	}
+	return builder
+}
+func (builder *AuditResponseBuilder) Build() *AuditResponse {
+	auditResponse := AuditResponse{}
+
+	auditResponse.Id = builder.id
+	auditResponse.CreatedAt = builder.createdAt
+	auditResponse.Actor = builder.actor
+	auditResponse.Action = builder.action
+	auditResponse.Entity = builder.entity
+	auditResponse.EntityId = builder.entityId
+	auditResponse.RequestId = builder.requestId
+	auditResponse.Before = builder.before
+	auditResponse.After = builder.after
+
+	return &auditResponse
+}
+
+type SearchParamsBuilder struct {
+	page     *uint
+	pagesize *uint
+}
+
+func NewSearchParamsBuilder() *SearchParamsBuilder {
+	return &SearchParamsBuilder{}
+}
+
+func (builder *SearchParamsBuilder) AddPage(page uint) *SearchParamsBuilder {
+	builder.page = &page
+	return builder
+}
+func (builder *SearchParamsBuilder) AddPageSize(pagesize uint) *SearchParamsBuilder {
+	builder.pagesize = &pagesize
+	return builder
+}
+func (builder *SearchParamsBuilder) Build() *SearchParams {
+	return &SearchParams{
+		paginate: &Paginate{
+			page:     builder.page,
+			pagesize: builder.pagesize,
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package aservice
+
+import (
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/repository"
+)
+
+type ReadingProcess interface {
+	GetHistory(searchCtx SearchContext) (*AuditHistoryResponse, error)
+	GetActivityPaginated(searchCtx SearchContext) (*AuditPaginateResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+}
+
+func NewReadingProcess(repository repository.Repository) ReadingProcess {
+	return &readingProcess{repository: repository}
+}
+
+func (rp *readingProcess) toResponse(auditLog repository.AuditLog) *AuditResponse {
+	return NewAuditResponseBuilder().
+		AddId(auditLog.Id).
+		AddCreatedAt(auditLog.CreatedAt).
+		AddActor(auditLog.UserId).
+		AddAction(auditLog.Action).
+		AddEntity(auditLog.Entity).
+		AddEntityId(auditLog.EntityId).
+		AddRequestId(auditLog.RequestId).
+		AddBefore(auditLog.Before).
+		AddAfter(auditLog.After).
+		Build()
+}
+
+func (rp *readingProcess) GetHistory(searchCtx SearchContext) (*AuditHistoryResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	queryParam := repository.NewQueryParamsBuilder().
+		AddEntity(searchCtx.Entity).
+		AddEntityId(searchCtx.Id).
+		AddUserId(user.Id).
+		Build()
+	auditLogList, err := rp.repository.GetByEntity(searchCtx.Ctx, queryParam)
+	if err != nil {
+		return nil, err
+	}
+
+	auditResponseList := []AuditResponse{}
+	for _, auditLog := range *auditLogList {
+		auditResponseList = append(auditResponseList, *rp.toResponse(auditLog))
+	}
+	return &AuditHistoryResponse{
+		Entity:   searchCtx.Entity,
+		EntityId: searchCtx.Id,
+		Records:  auditResponseList,
+	}, nil
+}
+
+func (rp *readingProcess) getOffset(actualPage uint, pagesize uint) uint {
+	return (actualPage - 1) * pagesize
+}
+
+func (rp *readingProcess) getTotalPages(totalRecords uint, pagesize uint) uint {
+	totalPages := totalRecords / pagesize
+	if (totalRecords % pagesize) > 0 {
+		totalPages++
+	}
+	return totalPages
+}
+
+func (rp *readingProcess) GetActivityPaginated(searchCtx SearchContext) (*AuditPaginateResponse, error) {
+	search := searchCtx.Params
+	user := idpauth.GetUser(searchCtx.UserToken)
+	offset := rp.getOffset(*search.paginate.page, *search.paginate.pagesize)
+	queryParam := repository.NewQueryParamsBuilder().
+		AddUserId(user.Id).
+		AddOffset(offset).
+		AddLimit(*search.paginate.pagesize).
+		Build()
+
+	totalRecords, err := rp.repository.GetTotalRecords(searchCtx.Ctx, queryParam)
+	if err != nil {
+		return nil, err
+	}
+	totalPages := rp.getTotalPages(*totalRecords, *search.paginate.pagesize)
+	auditLogList, err := rp.repository.GetAll(searchCtx.Ctx, queryParam)
+	if err != nil {
+		return nil, err
+	}
+
+	var auditResponseList []AuditResponse
+	for _, auditLog := range *auditLogList {
+		auditResponseList = append(auditResponseList, *rp.toResponse(auditLog))
+	}
+
+	return &AuditPaginateResponse{
+		CurrentPage:  *search.paginate.page,
+		PageLimit:    *search.paginate.pagesize,
+		TotalRecords: *totalRecords,
+		TotalPages:   totalPages,
+		Records:      auditResponseList,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package aservice
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/requestid"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/repository"
+	uuid "github.com/satori/go.uuid"
+)
+
+type StorageProcess interface {
+	Record(recordCtx RecordContext) error
+}
+
+type storageProcess struct {
+	repository   repository.Repository
+	generateUUID func() uuid.UUID
+}
+
+func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID) StorageProcess {
+	return &storageProcess{repository: repository, generateUUID: generateUUID}
+}
+
+func (sp *storageProcess) snapshot(data interface{}) (string, error) {
+	if data == nil {
+		return "", nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func (sp *storageProcess) Record(recordCtx RecordContext) error {
+	before, err := sp.snapshot(recordCtx.Before)
+	if err != nil {
+		return err
+	}
+	after, err := sp.snapshot(recordCtx.After)
+	if err != nil {
+		return err
+	}
+	auditLog := repository.NewAuditLogBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(time.Now()).
+		AddUserId(recordCtx.UserId).
+		AddAction(recordCtx.Action).
+		AddEntity(recordCtx.Entity).
+		AddEntityId(recordCtx.EntityId).
+		AddRequestId(requestid.FromContext(recordCtx.Ctx)).
+		AddBefore(before).
+		AddAfter(after).
+		Build()
+	_, err = sp.repository.Save(recordCtx.Ctx, *auditLog)
+	return err
+}
@@ -0,0 +1,193 @@
+package aservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/repository"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRepository struct {
+	saveCallsMock            []func(ctx context.Context, auditLog repository.AuditLog) (*repository.AuditLog, error)
+	getByEntityCallsMock     []func(ctx context.Context, params repository.QueryParams) (*[]repository.AuditLog, error)
+	getTotalRecordsCallsMock []func(ctx context.Context, params repository.QueryParams) (*uint, error)
+	getAllCallsMock          []func(ctx context.Context, params repository.QueryParams) (*[]repository.AuditLog, error)
+}
+
+func (r *mockRepository) AddSaveCall(
+	save func(ctx context.Context, auditLog repository.AuditLog) (*repository.AuditLog, error)) *mockRepository {
+	r.saveCallsMock = append(r.saveCallsMock, save)
+	return r
+}
+
+func (r *mockRepository) AddGetByEntityCall(
+	getByEntity func(ctx context.Context, params repository.QueryParams) (*[]repository.AuditLog, error)) *mockRepository {
+	r.getByEntityCallsMock = append(r.getByEntityCallsMock, getByEntity)
+	return r
+}
+
+func (r *mockRepository) AddGetTotalRecordsCalls(
+	getTotalRecords func(ctx context.Context, params repository.QueryParams) (*uint, error)) *mockRepository {
+	r.getTotalRecordsCallsMock = append(r.getTotalRecordsCallsMock, getTotalRecords)
+	return r
+}
+
+func (r *mockRepository) AddGetAllCalls(
+	getAll func(ctx context.Context, params repository.QueryParams) (*[]repository.AuditLog, error)) *mockRepository {
+	r.getAllCallsMock = append(r.getAllCallsMock, getAll)
+	return r
+}
+
+func (r *mockRepository) Save(ctx context.Context, auditLog repository.AuditLog) (*repository.AuditLog, error) {
+	if len(r.saveCallsMock) >= 1 {
+		save := r.saveCallsMock[0]
+		r.saveCallsMock = r.saveCallsMock[1:]
+		return save(ctx, auditLog)
+	}
+	return nil, nil
+}
+
+func (r *mockRepository) GetByEntity(ctx context.Context, params repository.QueryParams) (*[]repository.AuditLog, error) {
+	if len(r.getByEntityCallsMock) >= 1 {
+		getByEntity := r.getByEntityCallsMock[0]
+		r.getByEntityCallsMock = r.getByEntityCallsMock[1:]
+		return getByEntity(ctx, params)
+	}
+	return nil, nil
+}
+
+func (r *mockRepository) GetTotalRecords(ctx context.Context, params repository.QueryParams) (*uint, error) {
+	if len(r.getTotalRecordsCallsMock) >= 1 {
+		getTotalRecords := r.getTotalRecordsCallsMock[0]
+		r.getTotalRecordsCallsMock = r.getTotalRecordsCallsMock[1:]
+		return getTotalRecords(ctx, params)
+	}
+	return nil, nil
+}
+
+func (r *mockRepository) GetAll(ctx context.Context, params repository.QueryParams) (*[]repository.AuditLog, error) {
+	if len(r.getAllCallsMock) >= 1 {
+		getAll := r.getAllCallsMock[0]
+		r.getAllCallsMock = r.getAllCallsMock[1:]
+		return getAll(ctx, params)
+	}
+	return nil, nil
+}
+
+type snapshotMock struct {
+	Id    string  `json:"id"`
+	Value float64 `json:"value"`
+}
+
+func TestRecordSuccess(t *testing.T) {
+	var auditLogSaved repository.AuditLog
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, auditLog repository.AuditLog) (*repository.AuditLog, error) {
+		auditLogSaved = auditLog
+		return &auditLog, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("519fd73e-45e6-4471-8a66-5057486f5cc8")
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+
+	recordCtx := RecordContext{
+		Ctx:      ctx,
+		UserId:   "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Action:   ACTION_UPDATE,
+		Entity:   ENTITY_INVOICE,
+		EntityId: "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Before:   &snapshotMock{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Value: 500.5},
+		After:    &snapshotMock{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Value: 750.5},
+	}
+	err := _storageProcess.Record(recordCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", auditLogSaved.Id)
+	assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", auditLogSaved.UserId)
+	assert.Equal(t, "update", auditLogSaved.Action)
+	assert.Equal(t, "invoice", auditLogSaved.Entity)
+	assert.Equal(t, `{"id":"cd1cc27b-28a1-47dc-ac76-70e8185e159d","value":500.5}`, auditLogSaved.Before)
+	assert.Equal(t, `{"id":"cd1cc27b-28a1-47dc-ac76-70e8185e159d","value":750.5}`, auditLogSaved.After)
+}
+
+func TestRecordWithoutBeforeSuccess(t *testing.T) {
+	var auditLogSaved repository.AuditLog
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, auditLog repository.AuditLog) (*repository.AuditLog, error) {
+		auditLogSaved = auditLog
+		return &auditLog, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("519fd73e-45e6-4471-8a66-5057486f5cc8")
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+
+	recordCtx := RecordContext{
+		Ctx:      ctx,
+		UserId:   "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Action:   ACTION_CREATE,
+		Entity:   ENTITY_GAIN,
+		EntityId: "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		After:    &snapshotMock{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Value: 750.5},
+	}
+	err := _storageProcess.Record(recordCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, "", auditLogSaved.Before)
+	assert.Equal(t, `{"id":"cd1cc27b-28a1-47dc-ac76-70e8185e159d","value":750.5}`, auditLogSaved.After)
+}
+
+func TestRecordSnapshotFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("519fd73e-45e6-4471-8a66-5057486f5cc8")
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+
+	recordCtx := RecordContext{
+		Ctx:      ctx,
+		UserId:   "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Action:   ACTION_CREATE,
+		Entity:   ENTITY_GAIN,
+		EntityId: "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		After:    make(chan int),
+	}
+	err := _storageProcess.Record(recordCtx)
+	assert.Error(t, err)
+}
+
+func TestRecordSaveFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, auditLog repository.AuditLog) (*repository.AuditLog, error) {
+		return nil, errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("519fd73e-45e6-4471-8a66-5057486f5cc8")
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+
+	recordCtx := RecordContext{
+		Ctx:      ctx,
+		UserId:   "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Action:   ACTION_DELETE,
+		Entity:   ENTITY_GAIN,
+		EntityId: "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Before:   &snapshotMock{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Value: 750.5},
+	}
+	err := _storageProcess.Record(recordCtx)
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package aservice
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	ENTITY_GAIN               = "gain"
+	ENTITY_GAIN_PROJECTION    = "gain-projection"
+	ENTITY_INVOICE            = "invoice"
+	ENTITY_INVOICE_PROJECTION = "invoice-projection"
+)
+
+const (
+	ACTION_CREATE  = "create"
+	ACTION_UPDATE  = "update"
+	ACTION_DELETE  = "delete"
+	ACTION_REALIZE = "realize"
+	ACTION_RESTORE = "restore"
+	ACTION_REVERT  = "revert"
+)
+
+var Entities = []string{ENTITY_GAIN, ENTITY_GAIN_PROJECTION, ENTITY_INVOICE, ENTITY_INVOICE_PROJECTION}
+
+type RecordContext struct {
+	Ctx      context.Context
+	UserId   string
+	Action   string
+	Entity   string
+	EntityId string
+	Before   interface{}
+	After    interface{}
+}
+
+type SearchContext struct {
+	Ctx       context.Context
+	Params    SearchParams
+	UserToken string
+	Entity    string
+	Id        string
+}
+
+type AuditResponse struct {
+	Id        string          `json:"id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	Entity    string          `json:"entity"`
+	EntityId  string          `json:"entity_id"`
+	RequestId string          `json:"request_id,omitempty"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+}
+
+type AuditHistoryResponse struct {
+	Entity   string          `json:"entity"`
+	EntityId string          `json:"entity_id"`
+	Records  []AuditResponse `json:"records"`
+}
+
+type AuditPaginateResponse struct {
+	CurrentPage  uint            `json:"current_page"`
+	TotalPages   uint            `json:"total_pages"`
+	TotalRecords uint            `json:"total_records"`
+	PageLimit    uint            `json:"page_limit"`
+	Records      []AuditResponse `json:"records"`
+}
+
+type Paginate struct {
+	page     *uint
+	pagesize *uint
+}
+
+type SearchParams struct {
+	paginate *Paginate
+}
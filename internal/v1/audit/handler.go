@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	GetHistory(c *gin.Context)
+	GetActivity(c *gin.Context)
+}
+
+type handler struct {
+	readingProcess aservice.ReadingProcess
+}
+
+func NewHandler(readingProcess aservice.ReadingProcess) Handler {
+	return &handler{readingProcess: readingProcess}
+}
+
+// @Summary Obter o histórico de um registro
+// @Description Este endpoint permite obter o histórico de alterações de uma receita, despesa ou previsão
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Param entity path string true "Tipo do registro (gain, gain-projection, invoice, invoice-projection)"
+// @Param id path string true "Id do registro"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} aservice.AuditHistoryResponse
+// @Router /v1/audit/{entity}/{id} [get]
+func (h *handler) GetHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	entity := c.Param("entity")
+	id := c.Param("id")
+
+	err := validateEntity(entity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Audit::ReadingProcess::GetHistory", "Get the history of a record", nil)
+	searchCtx := aservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Entity:    entity,
+		Id:        id,
+	}
+	history, err := h.readingProcess.GetHistory(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, history)
+}
+
+// @Summary Obter o feed de atividades
+// @Description Este endpoint permite obter todas as alterações feitas pelo usuário, da mais recente para a mais antiga
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Param page_size query string false "O número de registros retornados pela busca"
+// @Param page query string false "A página que será buscada"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} aservice.AuditPaginateResponse
+// @Router /v1/audit [get]
+func (h *handler) GetActivity(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	searchParams, err := validateAndGetSearchParams(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Audit::ReadingProcess::GetActivityPaginated", "Get the user activity paginated", nil)
+	searchCtx := aservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Params:    *searchParams,
+	}
+	resultPaginated, err := h.readingProcess.GetActivityPaginated(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, resultPaginated)
+}
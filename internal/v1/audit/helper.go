@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+)
+
+func validateAndGetSearchParams(c *gin.Context) (*aservice.SearchParams, error) {
+	page, _ := strconv.ParseUint(c.Query("page"), 10, 32)
+	pagesize, _ := strconv.ParseUint(c.Query("page_size"), 10, 32)
+
+	if page == uint64(0) {
+		page = uint64(1)
+	}
+	if pagesize == uint64(0) {
+		pagesize = uint64(10)
+	}
+	return aservice.NewSearchParamsBuilder().
+		AddPage(uint(page)).
+		AddPageSize(uint(pagesize)).
+		Build(), nil
+}
+
+func validateEntity(entity string) error {
+	if !slices.Contains(aservice.Entities, entity) {
+		return apperror.Validation(fmt.Sprintf("A param entity %s is invalid", entity))
+	}
+	return nil
+}
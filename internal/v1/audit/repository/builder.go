@@ -0,0 +1,111 @@
+package repository
+
+import "time"
+
+type AuditLogBuilder struct {
+	id        string
+	createdAt time.Time
+	userId    string
+	action    string
+	entity    string
+	entityId  string
+	requestId string
+	before    string
+	after     string
+}
+
+func NewAuditLogBuilder() *AuditLogBuilder {
+	return &AuditLogBuilder{}
+}
+func (builder *AuditLogBuilder) AddId(id string) *AuditLogBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *AuditLogBuilder) AddCreatedAt(createdAt time.Time) *AuditLogBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *AuditLogBuilder) AddUserId(userId string) *AuditLogBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *AuditLogBuilder) AddAction(action string) *AuditLogBuilder {
+	builder.action = action
+	return builder
+}
+func (builder *AuditLogBuilder) AddEntity(entity string) *AuditLogBuilder {
+	builder.entity = entity
+	return builder
+}
+func (builder *AuditLogBuilder) AddEntityId(entityId string) *AuditLogBuilder {
+	builder.entityId = entityId
+	return builder
+}
+func (builder *AuditLogBuilder) AddRequestId(requestId string) *AuditLogBuilder {
+	builder.requestId = requestId
+	return builder
+}
+func (builder *AuditLogBuilder) AddBefore(before string) *AuditLogBuilder {
+	builder.before = before
+	return builder
+}
+func (builder *AuditLogBuilder) AddAfter(after string) *AuditLogBuilder {
+	builder.after = after
+	return builder
+}
+func (builder *AuditLogBuilder) Build() *AuditLog {
+	auditLog := AuditLog{}
+
+	auditLog.Id = builder.id
+	auditLog.CreatedAt = builder.createdAt
+	auditLog.UserId = builder.userId
+	auditLog.Action = builder.action
+	auditLog.Entity = builder.entity
+	auditLog.EntityId = builder.entityId
+	auditLog.RequestId = builder.requestId
+	auditLog.Before = builder.before
+	auditLog.After = builder.after
+
+	return &auditLog
+}
+
+type QueryParamsBuilder struct {
+	userId   string
+	entity   string
+	entityId string
+	limit    uint
+	offset   uint
+}
+
+func NewQueryParamsBuilder() *QueryParamsBuilder {
+	return &QueryParamsBuilder{}
+}
+func (builder *QueryParamsBuilder) AddUserId(userId string) *QueryParamsBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *QueryParamsBuilder) AddEntity(entity string) *QueryParamsBuilder {
+	builder.entity = entity
+	return builder
+}
+func (builder *QueryParamsBuilder) AddEntityId(entityId string) *QueryParamsBuilder {
+	builder.entityId = entityId
+	return builder
+}
+func (builder *QueryParamsBuilder) AddLimit(limit uint) *QueryParamsBuilder {
+	builder.limit = limit
+	return builder
+}
+func (builder *QueryParamsBuilder) AddOffset(offset uint) *QueryParamsBuilder {
+	builder.offset = offset
+	return builder
+}
+func (builder *QueryParamsBuilder) Build() QueryParams {
+	return QueryParams{
+		userId:   builder.userId,
+		entity:   builder.entity,
+		entityId: builder.entityId,
+		limit:    builder.limit,
+		offset:   builder.offset,
+	}
+}
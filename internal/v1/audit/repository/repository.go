@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+)
+
+type Repository interface {
+	Save(ctx context.Context, auditLog AuditLog) (*AuditLog, error)
+	GetByEntity(ctx context.Context, params QueryParams) (*[]AuditLog, error)
+	GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error)
+	GetAll(ctx context.Context, params QueryParams) (*[]AuditLog, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+func nullableJSON(data string) sql.NullString {
+	return sql.NullString{String: data, Valid: data != ""}
+}
+
+func (r *repository) Save(ctx context.Context, auditLog AuditLog) (*AuditLog, error) {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO audit_log (id, created_at, user_id, action, entity, entity_id, request_id, before_data, after_data) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		auditLog.Id,
+		auditLog.CreatedAt.Unix(),
+		auditLog.UserId,
+		auditLog.Action,
+		auditLog.Entity,
+		auditLog.EntityId,
+		auditLog.RequestId,
+		nullableJSON(auditLog.Before),
+		nullableJSON(auditLog.After),
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &auditLog, nil
+}
+
+func (r *repository) scanAuditLogs(rows *sql.Rows) (*[]AuditLog, error) {
+	var auditLogList []AuditLog
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var requestId sql.NullString
+		var before sql.NullString
+		var after sql.NullString
+		var auditLog AuditLog
+
+		err := rows.Scan(
+			&auditLog.Id,
+			&createdAtTimestamp,
+			&auditLog.UserId,
+			&auditLog.Action,
+			&auditLog.Entity,
+			&auditLog.EntityId,
+			&requestId,
+			&before,
+			&after)
+		if err != nil {
+			return nil, err
+		}
+		auditLog.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		auditLog.RequestId = requestId.String
+		auditLog.Before = before.String
+		auditLog.After = after.String
+
+		auditLogList = append(auditLogList, auditLog)
+	}
+	return &auditLogList, nil
+}
+
+func (r *repository) GetByEntity(ctx context.Context, params QueryParams) (*[]AuditLog, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			action,
+			entity,
+			entity_id,
+			request_id,
+			before_data,
+			after_data
+		FROM
+			audit_log
+		WHERE 
+			entity = ? AND entity_id = ? AND user_id = ?
+		ORDER BY created_at ASC`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, params.entity, params.entityId, params.userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanAuditLogs(rows)
+}
+
+func (r *repository) GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error) {
+	var totalRecords uint
+	query := `SELECT COUNT(*) as total_records FROM audit_log WHERE user_id = ?`
+	row := dbtx.Get(ctx, r.db).QueryRowContext(ctx, query, params.userId)
+	err := row.Scan(&totalRecords)
+	if err != nil {
+		return nil, err
+	}
+	return &totalRecords, nil
+}
+
+func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]AuditLog, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			action,
+			entity,
+			entity_id,
+			request_id,
+			before_data,
+			after_data
+		FROM
+			audit_log
+		WHERE 
+			user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, params.userId, params.limit, params.offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanAuditLogs(rows)
+}
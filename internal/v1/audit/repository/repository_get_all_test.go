@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	queryParams := NewQueryParamsBuilder().
+		AddUserId("User1").
+		AddLimit(10).
+		AddOffset(0).
+		Build()
+
+	now := time.Now()
+	rowsAuditLogMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"user_id",
+		"action",
+		"entity",
+		"entity_id",
+		"request_id",
+		"before_data",
+		"after_data",
+	}).AddRow(
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		now.Unix(),
+		"User1",
+		"delete",
+		"gain",
+		"cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		nil,
+		`{"value":500.5}`,
+		nil,
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			id,
+			created_at,
+			user_id,
+			action,
+			entity,
+			entity_id,
+			request_id,
+			before_data,
+			after_data
+		FROM
+			audit_log
+		WHERE 
+			user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`).
+		WithArgs(queryParams.userId, queryParams.limit, queryParams.offset).
+		WillReturnRows(rowsAuditLogMock)
+
+	auditLogList, err := _repository.GetAll(context.Background(), queryParams)
+	assert.NoError(t, err)
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", (*auditLogList)[0].Id)
+	assert.Equal(t, "", (*auditLogList)[0].RequestId)
+	assert.Equal(t, "", (*auditLogList)[0].After)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	queryParams := NewQueryParamsBuilder().
+		AddUserId("User1").
+		AddLimit(10).
+		AddOffset(0).
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			id,
+			created_at,
+			user_id,
+			action,
+			entity,
+			entity_id,
+			request_id,
+			before_data,
+			after_data
+		FROM
+			audit_log
+		WHERE 
+			user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`).
+		WithArgs(queryParams.userId, queryParams.limit, queryParams.offset).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAll(context.Background(), queryParams)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	queryParams := NewQueryParamsBuilder().
+		AddUserId("User1").
+		AddLimit(10).
+		AddOffset(0).
+		Build()
+
+	rowsAuditLogMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"user_id",
+		"action",
+		"entity",
+		"entity_id",
+		"request_id",
+		"before_data",
+		"after_data",
+	}).AddRow(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			id,
+			created_at,
+			user_id,
+			action,
+			entity,
+			entity_id,
+			request_id,
+			before_data,
+			after_data
+		FROM
+			audit_log
+		WHERE 
+			user_id = ?
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`).
+		WithArgs(queryParams.userId, queryParams.limit, queryParams.offset).
+		WillReturnRows(rowsAuditLogMock)
+
+	_, err = _repository.GetAll(context.Background(), queryParams)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByEntitySuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	queryParams := NewQueryParamsBuilder().
+		AddEntity("invoice").
+		AddEntityId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddUserId("User1").
+		Build()
+
+	now := time.Now()
+	rowsAuditLogMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"user_id",
+		"action",
+		"entity",
+		"entity_id",
+		"request_id",
+		"before_data",
+		"after_data",
+	}).AddRow(
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		now.Unix(),
+		"User1",
+		"create",
+		"invoice",
+		"cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		"a2a6039a-3e41-4401-9bc4-55b249d6f7d6",
+		nil,
+		`{"value":500.5}`,
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			id,
+			created_at,
+			user_id,
+			action,
+			entity,
+			entity_id,
+			request_id,
+			before_data,
+			after_data
+		FROM
+			audit_log
+		WHERE 
+			entity = ? AND entity_id = ? AND user_id = ?
+		ORDER BY created_at ASC`).
+		WithArgs(queryParams.entity, queryParams.entityId, queryParams.userId).
+		WillReturnRows(rowsAuditLogMock)
+
+	auditLogList, err := _repository.GetByEntity(context.Background(), queryParams)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(*auditLogList))
+	assert.Equal(t, "", (*auditLogList)[0].Before)
+	assert.Equal(t, `{"value":500.5}`, (*auditLogList)[0].After)
+	assert.Equal(t, now.Unix(), (*auditLogList)[0].CreatedAt.Unix())
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByEntityQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	queryParams := NewQueryParamsBuilder().
+		AddEntity("invoice").
+		AddEntityId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddUserId("User1").
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			id,
+			created_at,
+			user_id,
+			action,
+			entity,
+			entity_id,
+			request_id,
+			before_data,
+			after_data
+		FROM
+			audit_log
+		WHERE 
+			entity = ? AND entity_id = ? AND user_id = ?
+		ORDER BY created_at ASC`).
+		WithArgs(queryParams.entity, queryParams.entityId, queryParams.userId).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetByEntity(context.Background(), queryParams)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
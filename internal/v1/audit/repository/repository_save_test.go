@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAuditLogSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	auditLogMock := NewAuditLogBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(now).
+		AddUserId("User1").
+		AddAction("update").
+		AddEntity("invoice").
+		AddEntityId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddRequestId("a2a6039a-3e41-4401-9bc4-55b249d6f7d6").
+		AddBefore(`{"value":500.5}`).
+		AddAfter(`{"value":750.5}`).
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		INSERT INTO audit_log (id, created_at, user_id, action, entity, entity_id, request_id, before_data, after_data) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		ExpectExec().
+		WithArgs(
+			auditLogMock.Id,
+			auditLogMock.CreatedAt.Unix(),
+			auditLogMock.UserId,
+			auditLogMock.Action,
+			auditLogMock.Entity,
+			auditLogMock.EntityId,
+			auditLogMock.RequestId,
+			sql.NullString{String: auditLogMock.Before, Valid: true},
+			sql.NullString{String: auditLogMock.After, Valid: true}).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	auditLogSaved, err := _repository.Save(context.Background(), *auditLogMock)
+	assert.NoError(t, err)
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", auditLogSaved.Id)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveAuditLogWithoutBeforeSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	auditLogMock := NewAuditLogBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(now).
+		AddUserId("User1").
+		AddAction("create").
+		AddEntity("invoice").
+		AddEntityId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddAfter(`{"value":750.5}`).
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		INSERT INTO audit_log (id, created_at, user_id, action, entity, entity_id, request_id, before_data, after_data) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		ExpectExec().
+		WithArgs(
+			auditLogMock.Id,
+			auditLogMock.CreatedAt.Unix(),
+			auditLogMock.UserId,
+			auditLogMock.Action,
+			auditLogMock.Entity,
+			auditLogMock.EntityId,
+			"",
+			sql.NullString{},
+			sql.NullString{String: auditLogMock.After, Valid: true}).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	_, err = _repository.Save(context.Background(), *auditLogMock)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveAuditLogBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	auditLogMock := NewAuditLogBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(time.Now()).
+		AddUserId("User1").
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.Save(context.Background(), *auditLogMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveAuditLogExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	auditLogMock := NewAuditLogBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(now).
+		AddUserId("User1").
+		AddAction("delete").
+		AddEntity("gain").
+		AddEntityId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddBefore(`{"value":500.5}`).
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		INSERT INTO audit_log (id, created_at, user_id, action, entity, entity_id, request_id, before_data, after_data) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		ExpectExec().
+		WithArgs(
+			auditLogMock.Id,
+			auditLogMock.CreatedAt.Unix(),
+			auditLogMock.UserId,
+			auditLogMock.Action,
+			auditLogMock.Entity,
+			auditLogMock.EntityId,
+			"",
+			sql.NullString{String: auditLogMock.Before, Valid: true},
+			sql.NullString{}).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.Save(context.Background(), *auditLogMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import "time"
+
+type AuditLog struct {
+	Id        string
+	CreatedAt time.Time
+	UserId    string
+	Action    string
+	Entity    string
+	EntityId  string
+	RequestId string
+	Before    string
+	After     string
+}
+
+type QueryParams struct {
+	userId   string
+	entity   string
+	entityId string
+	limit    uint
+	offset   uint
+}
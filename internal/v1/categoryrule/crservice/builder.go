@@ -0,0 +1,90 @@
+package crservice
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type CategoryRuleResponseBuilder struct {
+	id            string
+	entity        string
+	matchType     string
+	pattern       string
+	minValue      *money.Money
+	maxValue      *money.Money
+	paymentTypeId *uint
+	categoryId    *uint
+	label         string
+	isPassive     *bool
+	priority      uint
+	createdAt     time.Time
+}
+
+func NewCategoryRuleResponseBuilder() *CategoryRuleResponseBuilder {
+	return &CategoryRuleResponseBuilder{}
+}
+func (builder *CategoryRuleResponseBuilder) AddId(id string) *CategoryRuleResponseBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddEntity(entity string) *CategoryRuleResponseBuilder {
+	builder.entity = entity
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddMatchType(matchType string) *CategoryRuleResponseBuilder {
+	builder.matchType = matchType
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddPattern(pattern string) *CategoryRuleResponseBuilder {
+	builder.pattern = pattern
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddMinValue(minValue *money.Money) *CategoryRuleResponseBuilder {
+	builder.minValue = minValue
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddMaxValue(maxValue *money.Money) *CategoryRuleResponseBuilder {
+	builder.maxValue = maxValue
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddPaymentTypeId(paymentTypeId *uint) *CategoryRuleResponseBuilder {
+	builder.paymentTypeId = paymentTypeId
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddCategoryId(categoryId *uint) *CategoryRuleResponseBuilder {
+	builder.categoryId = categoryId
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddLabel(label string) *CategoryRuleResponseBuilder {
+	builder.label = label
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddIsPassive(isPassive *bool) *CategoryRuleResponseBuilder {
+	builder.isPassive = isPassive
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddPriority(priority uint) *CategoryRuleResponseBuilder {
+	builder.priority = priority
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) AddCreatedAt(createdAt time.Time) *CategoryRuleResponseBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *CategoryRuleResponseBuilder) Build() *CategoryRuleResponse {
+	return &CategoryRuleResponse{
+		Id:            builder.id,
+		Entity:        builder.entity,
+		MatchType:     builder.matchType,
+		Pattern:       builder.pattern,
+		MinValue:      builder.minValue,
+		MaxValue:      builder.maxValue,
+		PaymentTypeId: builder.paymentTypeId,
+		CategoryId:    builder.categoryId,
+		Label:         builder.label,
+		IsPassive:     builder.isPassive,
+		Priority:      builder.priority,
+		CreatedAt:     builder.createdAt,
+	}
+}
@@ -0,0 +1,96 @@
+package crservice
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/repository"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Categorizer applies the rules registered by the user to the gains and invoices
+type Categorizer interface {
+	Categorize(categorizeCtx CategorizeContext) (*Categorization, error)
+	Label(labelCtx LabelContext) error
+}
+
+type categorizer struct {
+	repository   repository.Repository
+	generateUUID func() uuid.UUID
+}
+
+func NewCategorizer(repository repository.Repository, generateUUID func() uuid.UUID) Categorizer {
+	return &categorizer{repository: repository, generateUUID: generateUUID}
+}
+
+// Categorize evaluates the rules by priority, each action is taken from the first matching rule that sets it,
+// so a rule that only labels the record does not keep a later one from choosing its category.
+// It returns nil when no rule matches.
+func (ct *categorizer) Categorize(categorizeCtx CategorizeContext) (*Categorization, error) {
+	categoryRuleList, err := ct.repository.GetAll(categorizeCtx.Ctx, categorizeCtx.UserId, categorizeCtx.Entity)
+	if err != nil {
+		return nil, err
+	}
+	var categorization *Categorization
+	for _, categoryRule := range *categoryRuleList {
+		if !matches(categoryRule, categorizeCtx) {
+			continue
+		}
+		if categorization == nil {
+			categorization = &Categorization{}
+		}
+		if categorization.CategoryId == nil {
+			categorization.CategoryId = categoryRule.CategoryId
+		}
+		if categorization.Label == "" {
+			categorization.Label = categoryRule.Label
+		}
+		if categorization.IsPassive == nil {
+			categorization.IsPassive = categoryRule.IsPassive
+		}
+	}
+	return categorization, nil
+}
+
+// Label links the label chosen by the rules to the record once it is stored
+func (ct *categorizer) Label(labelCtx LabelContext) error {
+	return ct.repository.SaveLabel(labelCtx.Ctx, repository.RecordLabel{
+		Id:       ct.generateUUID().String(),
+		UserId:   labelCtx.UserId,
+		Entity:   labelCtx.Entity,
+		EntityId: labelCtx.EntityId,
+		Label:    labelCtx.Label,
+	})
+}
+
+// matches tells whether the record meets every condition of the rule. The pattern is met by the description or by
+// the merchant name of the record.
+func matches(categoryRule repository.CategoryRule, categorizeCtx CategorizeContext) bool {
+	if categoryRule.Pattern != "" {
+		matchesText := matchesPattern(categoryRule, categorizeCtx.Description) ||
+			(categorizeCtx.MerchantName != "" && matchesPattern(categoryRule, categorizeCtx.MerchantName))
+		if !matchesText {
+			return false
+		}
+	}
+	if categoryRule.MinValue != nil && categorizeCtx.Value < *categoryRule.MinValue {
+		return false
+	}
+	if categoryRule.MaxValue != nil && categorizeCtx.Value > *categoryRule.MaxValue {
+		return false
+	}
+	if categoryRule.PaymentTypeId != nil && categorizeCtx.PaymentTypeId != *categoryRule.PaymentTypeId {
+		return false
+	}
+	return true
+}
+
+// matchesPattern compares the pattern of the rule with the text ignoring the case, a regular expression that no
+// longer compiles does not match anything
+func matchesPattern(categoryRule repository.CategoryRule, text string) bool {
+	if categoryRule.MatchType == MATCH_TYPE_REGEX {
+		expression, err := regexp.Compile("(?i)" + categoryRule.Pattern)
+		return err == nil && expression.MatchString(text)
+	}
+	return strings.Contains(strings.ToLower(text), strings.ToLower(categoryRule.Pattern))
+}
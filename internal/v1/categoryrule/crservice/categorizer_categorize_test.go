@@ -0,0 +1,141 @@
+package crservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildRulesMock() []repository.CategoryRule {
+	fuelCategoryId := uint(3)
+	bakeryCategoryId := uint(2)
+	transportCategoryId := uint(7)
+	minValue := money.FromCents(100000)
+	creditPaymentTypeId := uint(3)
+	return []repository.CategoryRule{
+		*repository.NewCategoryRuleBuilder().AddPattern("posto").AddCategoryId(&fuelCategoryId).AddPriority(5).Build(),
+		*repository.NewCategoryRuleBuilder().AddMinValue(&minValue).AddLabel("grandes compras").AddPriority(6).Build(),
+		*repository.NewCategoryRuleBuilder().AddPattern("padaria").AddCategoryId(&bakeryCategoryId).Build(),
+		*repository.NewCategoryRuleBuilder().AddMatchType(MATCH_TYPE_REGEX).AddPattern("^(uber|99) ").
+			AddPaymentTypeId(&creditPaymentTypeId).AddCategoryId(&transportCategoryId).AddLabel("transporte").Build(),
+	}
+}
+
+func TestCategorizeMatch(t *testing.T) {
+	_mockRepository := &mockRepository{rules: buildRulesMock()}
+	_categorizer := NewCategorizer(_mockRepository, generateUUIDMock)
+
+	categorization, err := _categorizer.Categorize(CategorizeContext{
+		Ctx:         context.TODO(),
+		UserId:      "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Entity:      ENTITY_INVOICE,
+		Description: "PADARIA PAO QUENTE",
+		Value:       money.FromCents(1500),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), *categorization.CategoryId)
+	assert.Empty(t, categorization.Label)
+	assert.Nil(t, categorization.IsPassive)
+	assert.Equal(t, []string{ENTITY_INVOICE}, _mockRepository.entities)
+}
+
+func TestCategorizeFirstRuleWins(t *testing.T) {
+	_categorizer := NewCategorizer(&mockRepository{rules: buildRulesMock()}, generateUUIDMock)
+
+	categorization, err := _categorizer.Categorize(CategorizeContext{Ctx: context.TODO(), Entity: ENTITY_INVOICE, Description: "Padaria do Posto"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), *categorization.CategoryId)
+}
+
+func TestCategorizeCombinesActions(t *testing.T) {
+	_categorizer := NewCategorizer(&mockRepository{rules: buildRulesMock()}, generateUUIDMock)
+
+	categorization, err := _categorizer.Categorize(CategorizeContext{
+		Ctx:         context.TODO(),
+		Entity:      ENTITY_INVOICE,
+		Description: "Padaria Central",
+		Value:       money.FromCents(120000),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), *categorization.CategoryId)
+	assert.Equal(t, "grandes compras", categorization.Label)
+}
+
+func TestCategorizeRegexAndPaymentType(t *testing.T) {
+	_categorizer := NewCategorizer(&mockRepository{rules: buildRulesMock()}, generateUUIDMock)
+
+	categorization, err := _categorizer.Categorize(CategorizeContext{
+		Ctx:           context.TODO(),
+		Entity:        ENTITY_INVOICE,
+		Description:   "Uber *Trip",
+		Value:         money.FromCents(2350),
+		PaymentTypeId: 3,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), *categorization.CategoryId)
+	assert.Equal(t, "transporte", categorization.Label)
+
+	categorization, err = _categorizer.Categorize(CategorizeContext{
+		Ctx:           context.TODO(),
+		Entity:        ENTITY_INVOICE,
+		Description:   "Uber *Trip",
+		Value:         money.FromCents(2350),
+		PaymentTypeId: 4,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, categorization)
+}
+
+func TestCategorizeMatchOnMerchantName(t *testing.T) {
+	_categorizer := NewCategorizer(&mockRepository{rules: buildRulesMock()}, generateUUIDMock)
+
+	categorization, err := _categorizer.Categorize(CategorizeContext{
+		Ctx:          context.TODO(),
+		Entity:       ENTITY_INVOICE,
+		Description:  "Cafe da manha",
+		MerchantName: "PADARIA PAO QUENTE",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), *categorization.CategoryId)
+}
+
+func TestCategorizeNoMatch(t *testing.T) {
+	_categorizer := NewCategorizer(&mockRepository{rules: buildRulesMock()}, generateUUIDMock)
+
+	categorization, err := _categorizer.Categorize(CategorizeContext{Ctx: context.TODO(), Entity: ENTITY_INVOICE, Description: "Mercado"})
+	assert.NoError(t, err)
+	assert.Nil(t, categorization)
+}
+
+func TestCategorizeFail(t *testing.T) {
+	_categorizer := NewCategorizer(&mockRepository{err: errors.New("An error has been ocurred")}, generateUUIDMock)
+
+	categorization, err := _categorizer.Categorize(CategorizeContext{Ctx: context.TODO(), Entity: ENTITY_INVOICE, Description: "Mercado"})
+	assert.Error(t, err)
+	assert.Nil(t, categorization)
+}
+
+func TestLabel(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_categorizer := NewCategorizer(_mockRepository, generateUUIDMock)
+
+	err := _categorizer.Label(LabelContext{
+		Ctx:      context.TODO(),
+		UserId:   "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Entity:   ENTITY_INVOICE,
+		EntityId: "f6a3c2b1-2d4e-4f5a-9b8c-7d6e5f4a3b2c",
+		Label:    "transporte",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []repository.RecordLabel{{
+		Id:       "4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01",
+		UserId:   "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Entity:   ENTITY_INVOICE,
+		EntityId: "f6a3c2b1-2d4e-4f5a-9b8c-7d6e5f4a3b2c",
+		Label:    "transporte",
+	}}, _mockRepository.labels)
+}
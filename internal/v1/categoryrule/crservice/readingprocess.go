@@ -0,0 +1,32 @@
+package crservice
+
+import (
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/repository"
+)
+
+type ReadingProcess interface {
+	GetAll(searchCtx SearchContext) (*CategoryRuleListResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+}
+
+func NewReadingProcess(repository repository.Repository) ReadingProcess {
+	return &readingProcess{repository: repository}
+}
+
+func (rp *readingProcess) GetAll(searchCtx SearchContext) (*CategoryRuleListResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	categoryRuleList, err := rp.repository.GetAll(searchCtx.Ctx, user.Id, searchCtx.Entity)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryRuleResponseList := []CategoryRuleResponse{}
+	for _, categoryRule := range *categoryRuleList {
+		categoryRuleResponseList = append(categoryRuleResponseList, *toResponse(categoryRule))
+	}
+	return &CategoryRuleListResponse{Records: categoryRuleResponseList}, nil
+}
@@ -0,0 +1,40 @@
+package crservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllSuccess(t *testing.T) {
+	categoryId := uint(1)
+	_mockRepository := &mockRepository{rules: []repository.CategoryRule{
+		*repository.NewCategoryRuleBuilder().AddId("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01").AddEntity(ENTITY_GAIN).AddPattern("salario").AddCategoryId(&categoryId).Build(),
+	}}
+	_readingProcess := NewReadingProcess(_mockRepository)
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), Entity: ENTITY_GAIN, UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(response.Records))
+	assert.Equal(t, "salario", response.Records[0].Pattern)
+	assert.Equal(t, []string{ENTITY_GAIN}, _mockRepository.entities)
+}
+
+func TestGetAllEmpty(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{})
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, []CategoryRuleResponse{}, response.Records)
+}
+
+func TestGetAllFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")})
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,166 @@
+package crservice
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type StorageProcess interface {
+	Create(createCtx CreateContext) (*CategoryRuleResponse, error)
+	Delete(deleteCtx DeleteContext) error
+}
+
+type storageProcess struct {
+	repository       repository.Repository
+	generateUUID     func() uuid.UUID
+	referenceChecker validation.ReferenceChecker
+}
+
+func NewStorageProcess(
+	repository repository.Repository,
+	generateUUID func() uuid.UUID,
+	referenceChecker validation.ReferenceChecker) StorageProcess {
+	return &storageProcess{repository: repository, generateUUID: generateUUID, referenceChecker: referenceChecker}
+}
+
+func (sp *storageProcess) Create(createCtx CreateContext) (*CategoryRuleResponse, error) {
+	request := createCtx.Request
+	user := idpauth.GetUser(createCtx.UserToken)
+	request.Pattern = strings.TrimSpace(request.Pattern)
+	request.Label = strings.TrimSpace(request.Label)
+	if request.MatchType == "" {
+		request.MatchType = MATCH_TYPE_CONTAINS
+	}
+	err := validateRule(request)
+	if err != nil {
+		return nil, err
+	}
+	err = sp.checkReferences(createCtx.Ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	categoryRule := repository.NewCategoryRuleBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(time.Now()).
+		AddUserId(user.Id).
+		AddEntity(request.Entity).
+		AddMatchType(request.MatchType).
+		AddPattern(request.Pattern).
+		AddMinValue(request.MinValue).
+		AddMaxValue(request.MaxValue).
+		AddPaymentTypeId(request.PaymentTypeId).
+		AddCategoryId(request.CategoryId).
+		AddLabel(request.Label).
+		AddIsPassive(request.IsPassive).
+		AddPriority(request.Priority).
+		Build()
+	categoryRuleSaved, err := sp.repository.Save(createCtx.Ctx, *categoryRule)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(*categoryRuleSaved), nil
+}
+
+func (sp *storageProcess) Delete(deleteCtx DeleteContext) error {
+	user := idpauth.GetUser(deleteCtx.UserToken)
+	categoryRule, err := sp.repository.GetById(deleteCtx.Ctx, deleteCtx.Id, user.Id)
+	if err != nil {
+		return err
+	}
+	if categoryRule == nil {
+		return apperror.NotFound("Category rule not found")
+	}
+	return sp.repository.Delete(deleteCtx.Ctx, categoryRule.Id, user.Id)
+}
+
+// validateRule checks the combination of the fields, which the binding of each field can not tell
+func validateRule(request CreateRequest) error {
+	errs := validation.Errors{}
+	if request.Pattern == "" && request.MinValue == nil && request.MaxValue == nil && request.PaymentTypeId == nil {
+		errs = append(errs, validation.FieldError{
+			Field:   "pattern",
+			Code:    validation.CODE_REQUIRED,
+			Message: "The rule must have at least one condition: pattern, min_value, max_value or payment_type_id"})
+	}
+	if request.MatchType == MATCH_TYPE_REGEX && request.Pattern != "" {
+		_, err := regexp.Compile(request.Pattern)
+		if err != nil {
+			errs = append(errs, validation.FieldError{Field: "pattern", Code: validation.CODE_INVALID, Message: "The pattern is not a valid regular expression"})
+		}
+	}
+	if request.MinValue != nil && request.MaxValue != nil && *request.MaxValue < *request.MinValue {
+		errs = append(errs, validation.FieldError{Field: "max_value", Code: validation.CODE_TOO_SMALL, Message: "The max_value must be at least the min_value"})
+	}
+	if request.PaymentTypeId != nil && request.Entity != ENTITY_INVOICE {
+		errs = append(errs, validation.FieldError{Field: "payment_type_id", Code: validation.CODE_INVALID, Message: "The payment_type_id only applies to the invoice rules"})
+	}
+	if request.IsPassive != nil && request.Entity != ENTITY_GAIN {
+		errs = append(errs, validation.FieldError{Field: "is_passive", Code: validation.CODE_INVALID, Message: "The is_passive only applies to the gain rules"})
+	}
+	if request.CategoryId == nil && request.Label == "" && request.IsPassive == nil {
+		errs = append(errs, validation.FieldError{
+			Field:   "category_id",
+			Code:    validation.CODE_REQUIRED,
+			Message: "The rule must have at least one action: category_id, label or is_passive"})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// checkReferences verifies the category in the table of the entity the rule applies to, and the payment type
+func (sp *storageProcess) checkReferences(ctx context.Context, request CreateRequest) error {
+	errs := validation.Errors{}
+	if request.CategoryId != nil {
+		categoryExists := sp.referenceChecker.InvoiceCategoryExists
+		if request.Entity == ENTITY_GAIN {
+			categoryExists = sp.referenceChecker.GainCategoryExists
+		}
+		exists, err := categoryExists(ctx, *request.CategoryId)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			errs = append(errs, validation.NotFound("category_id"))
+		}
+	}
+	if request.PaymentTypeId != nil {
+		exists, err := sp.referenceChecker.PaymentTypeExists(ctx, *request.PaymentTypeId)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			errs = append(errs, validation.NotFound("payment_type_id"))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func toResponse(categoryRule repository.CategoryRule) *CategoryRuleResponse {
+	return NewCategoryRuleResponseBuilder().
+		AddId(categoryRule.Id).
+		AddEntity(categoryRule.Entity).
+		AddMatchType(categoryRule.MatchType).
+		AddPattern(categoryRule.Pattern).
+		AddMinValue(categoryRule.MinValue).
+		AddMaxValue(categoryRule.MaxValue).
+		AddPaymentTypeId(categoryRule.PaymentTypeId).
+		AddCategoryId(categoryRule.CategoryId).
+		AddLabel(categoryRule.Label).
+		AddIsPassive(categoryRule.IsPassive).
+		AddPriority(categoryRule.Priority).
+		AddCreatedAt(categoryRule.CreatedAt).
+		Build()
+}
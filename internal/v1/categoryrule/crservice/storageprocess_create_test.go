@@ -0,0 +1,236 @@
+package crservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	categoryRule *repository.CategoryRule
+	rules        []repository.CategoryRule
+	saved        []repository.CategoryRule
+	deleted      []string
+	entities     []string
+	labels       []repository.RecordLabel
+	err          error
+}
+
+func (r *mockRepository) Save(ctx context.Context, categoryRule repository.CategoryRule) (*repository.CategoryRule, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.saved = append(r.saved, categoryRule)
+	return &categoryRule, nil
+}
+
+func (r *mockRepository) GetById(ctx context.Context, id string, userId string) (*repository.CategoryRule, error) {
+	return r.categoryRule, r.err
+}
+
+func (r *mockRepository) GetAll(ctx context.Context, userId string, entity string) (*[]repository.CategoryRule, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.entities = append(r.entities, entity)
+	return &r.rules, nil
+}
+
+func (r *mockRepository) Delete(ctx context.Context, id string, userId string) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *mockRepository) SaveLabel(ctx context.Context, recordLabel repository.RecordLabel) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.labels = append(r.labels, recordLabel)
+	return nil
+}
+
+type mockReferenceChecker struct {
+	missingGainCategories    map[uint]bool
+	missingInvoiceCategories map[uint]bool
+	missingPaymentTypes      map[uint]bool
+	err                      error
+}
+
+func (rc *mockReferenceChecker) GainCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingGainCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) InvoiceCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingInvoiceCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) PaymentTypeExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingPaymentTypes[id], rc.err
+}
+
+func generateUUIDMock() uuid.UUID {
+	return uuid.FromStringOrNil("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01")
+}
+
+func buildCreateContext(entity string) CreateContext {
+	categoryId := uint(2)
+	return CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Entity: entity, Pattern: " Padaria ", CategoryId: &categoryId, Priority: 10},
+		UserToken: tokenMock,
+	}
+}
+
+func TestCreateSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, &mockReferenceChecker{})
+
+	response, err := _storageProcess.Create(buildCreateContext(ENTITY_INVOICE))
+	assert.NoError(t, err)
+	assert.Equal(t, "4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", response.Id)
+	assert.Equal(t, "Padaria", response.Pattern)
+	assert.Equal(t, MATCH_TYPE_CONTAINS, response.MatchType)
+	assert.Equal(t, uint(2), *response.CategoryId)
+	assert.Equal(t, uint(10), response.Priority)
+	assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", _mockRepository.saved[0].UserId)
+	assert.WithinDuration(t, time.Now(), response.CreatedAt, time.Minute)
+}
+
+func TestCreateGainCategoryNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	referenceChecker := &mockReferenceChecker{missingGainCategories: map[uint]bool{2: true}}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, referenceChecker)
+
+	response, err := _storageProcess.Create(buildCreateContext(ENTITY_GAIN))
+	assert.Equal(t, validation.Errors{validation.NotFound("category_id")}, err)
+	assert.Nil(t, response)
+	assert.Empty(t, _mockRepository.saved)
+
+	_, err = _storageProcess.Create(buildCreateContext(ENTITY_INVOICE))
+	assert.NoError(t, err)
+}
+
+func TestCreateReferenceCheckFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	referenceChecker := &mockReferenceChecker{err: errors.New("An error has been ocurred")}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, referenceChecker)
+
+	response, err := _storageProcess.Create(buildCreateContext(ENTITY_INVOICE))
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.Empty(t, _mockRepository.saved)
+}
+
+func TestCreateFail(t *testing.T) {
+	_mockRepository := &mockRepository{err: errors.New("An error has been ocurred")}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, &mockReferenceChecker{})
+
+	response, err := _storageProcess.Create(buildCreateContext(ENTITY_INVOICE))
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestCreateRuleWithValueRangeAndLabel(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, &mockReferenceChecker{})
+	minValue := money.FromCents(10000)
+	maxValue := money.FromCents(50000)
+	paymentTypeId := uint(3)
+	createCtx := CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Entity:        ENTITY_INVOICE,
+			MatchType:     MATCH_TYPE_REGEX,
+			Pattern:       "^(uber|99) ",
+			MinValue:      &minValue,
+			MaxValue:      &maxValue,
+			PaymentTypeId: &paymentTypeId,
+			Label:         " transporte ",
+		},
+		UserToken: tokenMock,
+	}
+
+	response, err := _storageProcess.Create(createCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, MATCH_TYPE_REGEX, response.MatchType)
+	assert.Equal(t, "transporte", response.Label)
+	assert.Nil(t, response.CategoryId)
+	assert.Equal(t, money.FromCents(50000), *_mockRepository.saved[0].MaxValue)
+	assert.Equal(t, uint(3), *_mockRepository.saved[0].PaymentTypeId)
+}
+
+func TestCreateInvalidRule(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, &mockReferenceChecker{})
+	minValue := money.FromCents(50000)
+	maxValue := money.FromCents(10000)
+	paymentTypeId := uint(3)
+	isPassive := true
+	createCtx := CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Entity:        ENTITY_GAIN,
+			MatchType:     MATCH_TYPE_REGEX,
+			Pattern:       "aluguel (",
+			MinValue:      &minValue,
+			MaxValue:      &maxValue,
+			PaymentTypeId: &paymentTypeId,
+			IsPassive:     &isPassive,
+		},
+		UserToken: tokenMock,
+	}
+
+	response, err := _storageProcess.Create(createCtx)
+	errs, ok := err.(validation.Errors)
+	assert.True(t, ok)
+	fields := []string{}
+	for _, fieldError := range errs {
+		fields = append(fields, fieldError.Field)
+	}
+	assert.Equal(t, []string{"pattern", "max_value", "payment_type_id"}, fields)
+	assert.Nil(t, response)
+	assert.Empty(t, _mockRepository.saved)
+}
+
+func TestCreateRuleWithoutConditionAndAction(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, &mockReferenceChecker{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Entity: ENTITY_INVOICE, Pattern: "  "},
+		UserToken: tokenMock,
+	})
+	errs, ok := err.(validation.Errors)
+	assert.True(t, ok)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "pattern", errs[0].Field)
+	assert.Equal(t, "category_id", errs[1].Field)
+	assert.Nil(t, response)
+}
+
+func TestCreatePaymentTypeNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	referenceChecker := &mockReferenceChecker{missingPaymentTypes: map[uint]bool{9: true}}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, referenceChecker)
+	createCtx := buildCreateContext(ENTITY_INVOICE)
+	paymentTypeId := uint(9)
+	createCtx.Request.PaymentTypeId = &paymentTypeId
+
+	response, err := _storageProcess.Create(createCtx)
+	assert.Equal(t, validation.Errors{validation.NotFound("payment_type_id")}, err)
+	assert.Nil(t, response)
+	assert.Empty(t, _mockRepository.saved)
+}
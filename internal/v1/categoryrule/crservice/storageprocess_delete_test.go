@@ -0,0 +1,43 @@
+package crservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildDeleteContext() DeleteContext {
+	return DeleteContext{Ctx: context.TODO(), Id: "4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", UserToken: tokenMock}
+}
+
+func TestDeleteSuccess(t *testing.T) {
+	categoryRule := repository.NewCategoryRuleBuilder().AddId("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01").Build()
+	_mockRepository := &mockRepository{categoryRule: categoryRule}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, &mockReferenceChecker{})
+
+	err := _storageProcess.Delete(buildDeleteContext())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01"}, _mockRepository.deleted)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, &mockReferenceChecker{})
+
+	err := _storageProcess.Delete(buildDeleteContext())
+	assert.Equal(t, apperror.KIND_NOT_FOUND, apperror.KindOf(err))
+	assert.Empty(t, _mockRepository.deleted)
+}
+
+func TestDeleteGetByIdFail(t *testing.T) {
+	_mockRepository := &mockRepository{err: errors.New("An error has been ocurred")}
+	_storageProcess := NewStorageProcess(_mockRepository, generateUUIDMock, &mockReferenceChecker{})
+
+	err := _storageProcess.Delete(buildDeleteContext())
+	assert.Error(t, err)
+	assert.Empty(t, _mockRepository.deleted)
+}
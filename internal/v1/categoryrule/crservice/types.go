@@ -0,0 +1,98 @@
+package crservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const (
+	ENTITY_GAIN    = "gain"
+	ENTITY_INVOICE = "invoice"
+)
+
+const (
+	MATCH_TYPE_CONTAINS = "contains"
+	MATCH_TYPE_REGEX    = "regex"
+)
+
+type CreateContext struct {
+	Ctx       context.Context
+	Request   CreateRequest
+	UserToken string
+}
+
+type SearchContext struct {
+	Ctx       context.Context
+	Entity    string
+	UserToken string
+}
+
+type DeleteContext struct {
+	Ctx       context.Context
+	Id        string
+	UserToken string
+}
+
+// CategorizeContext holds the record to be categorized. The merchant name is the one of the PIX the record came from,
+// the pattern of a rule is matched against it besides the description.
+type CategorizeContext struct {
+	Ctx           context.Context
+	UserId        string
+	Entity        string
+	Description   string
+	MerchantName  string
+	Value         money.Money
+	PaymentTypeId uint
+}
+
+type LabelContext struct {
+	Ctx      context.Context
+	UserId   string
+	Entity   string
+	EntityId string
+	Label    string
+}
+
+// CreateRequest needs at least one condition (pattern, min_value, max_value or payment_type_id)
+// and one action (category_id, label or is_passive). The payment type only applies to the invoices
+// and the is_passive only to the gains.
+type CreateRequest struct {
+	Entity        string       `json:"entity" binding:"required,oneof=gain invoice"`
+	MatchType     string       `json:"match_type" binding:"omitempty,oneof=contains regex"`
+	Pattern       string       `json:"pattern" binding:"max=255"`
+	MinValue      *money.Money `json:"min_value" binding:"omitempty,gte=0" swaggertype:"number"`
+	MaxValue      *money.Money `json:"max_value" binding:"omitempty,gte=0" swaggertype:"number"`
+	PaymentTypeId *uint        `json:"payment_type_id" binding:"omitempty,min=1"`
+	CategoryId    *uint        `json:"category_id" binding:"omitempty,min=1"`
+	Label         string       `json:"label" binding:"max=255"`
+	IsPassive     *bool        `json:"is_passive"`
+	Priority      uint         `json:"priority" binding:"max=1000"`
+}
+
+type CategoryRuleResponse struct {
+	Id            string       `json:"id"`
+	Entity        string       `json:"entity"`
+	MatchType     string       `json:"match_type"`
+	Pattern       string       `json:"pattern,omitempty"`
+	MinValue      *money.Money `json:"min_value,omitempty" swaggertype:"number"`
+	MaxValue      *money.Money `json:"max_value,omitempty" swaggertype:"number"`
+	PaymentTypeId *uint        `json:"payment_type_id,omitempty"`
+	CategoryId    *uint        `json:"category_id,omitempty"`
+	Label         string       `json:"label,omitempty"`
+	IsPassive     *bool        `json:"is_passive,omitempty"`
+	Priority      uint         `json:"priority"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type CategoryRuleListResponse struct {
+	Records []CategoryRuleResponse `json:"records"`
+}
+
+// Categorization holds the actions of the rules that matched a record, the nil ones were not set by any rule
+type Categorization struct {
+	CategoryId *uint
+	Label      string
+	IsPassive  *bool
+}
@@ -0,0 +1,138 @@
+package categoryrule
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	Create(c *gin.Context)
+	GetAll(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	storageProcess crservice.StorageProcess
+	readingProcess crservice.ReadingProcess
+}
+
+func NewHandler(storageProcess crservice.StorageProcess, readingProcess crservice.ReadingProcess) Handler {
+	return &handler{storageProcess: storageProcess, readingProcess: readingProcess}
+}
+
+// Create godoc
+// @Summary Criar uma regra de categorização
+// @Description Este endpoint permite criar uma regra que escolhe a categoria, o marcador e se a receita é passiva nas receitas ou despesas criadas, importadas ou a partir de um PIX.
+// @Description As condições são o padrão na descrição (contido ou expressão regular, sem diferenciar maiúsculas e minúsculas), a faixa de valor e o tipo de pagamento, e a regra se aplica quando todas as informadas são atendidas.
+// @Description As regras de maior prioridade são avaliadas primeiro e cada ação é tomada da primeira regra que a define
+// @Tags CategoryRule
+// @Accept json
+// @Produce json
+// @Param categoryRule body crservice.CreateRequest true "Modelo de criação da regra"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} crservice.CategoryRuleResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/category-rule [post]
+func (h *handler) Create(c *gin.Context) {
+	var request crservice.CreateRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("CategoryRule::StorageProcess::Create", "Create new category rule", nil)
+	createCtx := crservice.CreateContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Request:   request,
+	}
+	categoryRuleCreated, err := h.storageProcess.Create(createCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, categoryRuleCreated)
+}
+
+// @Summary Obter as regras de categorização
+// @Description Este endpoint permite obter as regras de categorização na ordem em que são avaliadas
+// @Tags CategoryRule
+// @Accept json
+// @Produce json
+// @Param entity query string false "Tipo do registro ao qual a regra se aplica (gain, invoice)"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} crservice.CategoryRuleListResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/category-rule [get]
+func (h *handler) GetAll(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	entity := c.Query("entity")
+
+	err := validateEntity(entity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("CategoryRule::ReadingProcess::GetAll", "Get all category rules", nil)
+	searchCtx := crservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Entity:    entity,
+	}
+	categoryRules, err := h.readingProcess.GetAll(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, categoryRules)
+}
+
+// @Summary Remover uma regra de categorização
+// @Description Este endpoint permite remover uma regra de categorização. As receitas e despesas já criadas mantêm a sua categoria
+// @Tags CategoryRule
+// @Accept json
+// @Produce json
+// @Param id path string true "Id da regra"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ResponseDefault{status=int,message=string}
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/category-rule/{id} [delete]
+func (h *handler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("CategoryRule::StorageProcess::Delete", "Delete a category rule", nil)
+	deleteCtx := crservice.DeleteContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Id:        c.Param("id"),
+	}
+	err := h.storageProcess.Delete(deleteCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Category rule removed"})
+}
@@ -0,0 +1,190 @@
+package categoryrule
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type storageProcessMock struct {
+	err          error
+	categoryRule *crservice.CategoryRuleResponse
+	deleted      string
+}
+
+func (sp *storageProcessMock) Create(createCtx crservice.CreateContext) (*crservice.CategoryRuleResponse, error) {
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.categoryRule, nil
+}
+
+func (sp *storageProcessMock) Delete(deleteCtx crservice.DeleteContext) error {
+	if sp.err != nil {
+		return sp.err
+	}
+	sp.deleted = deleteCtx.Id
+	return nil
+}
+
+type readingProcessMock struct {
+	err           error
+	categoryRules *crservice.CategoryRuleListResponse
+	entity        string
+}
+
+func (rp *readingProcessMock) GetAll(searchCtx crservice.SearchContext) (*crservice.CategoryRuleListResponse, error) {
+	rp.entity = searchCtx.Entity
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.categoryRules, nil
+}
+
+func TestCreateSuccess(t *testing.T) {
+	categoryId := uint(2)
+	_storageProcessMock := &storageProcessMock{
+		categoryRule: &crservice.CategoryRuleResponse{
+			Id:         "4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01",
+			Entity:     "invoice",
+			MatchType:  "contains",
+			Pattern:    "padaria",
+			CategoryId: &categoryId,
+			Priority:   10,
+			CreatedAt:  time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/category-rule", handler.Create)
+
+	body := []byte(`{"entity": "invoice", "pattern": "padaria", "category_id": 2, "priority": 10}`)
+	req, _ := http.NewRequest("POST", "/v1/category-rule", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"id":"4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01","entity":"invoice","match_type":"contains","pattern":"padaria","category_id":2,"priority":10,"created_at":"2024-03-01T10:00:00Z"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCreateInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/category-rule", handler.Create)
+
+	body := []byte(`{"entity": "transfer", "match_type": "glob", "min_value": -1, "category_id": 0}`)
+	req, _ := http.NewRequest("POST", "/v1/category-rule", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"entity","code":"invalid","message":"The entity is not valid"},{"field":"match_type","code":"invalid","message":"The match_type is not valid"},{"field":"min_value","code":"too_small","message":"The min_value must be at least 0"},{"field":"category_id","code":"too_small","message":"The category_id must be at least 1"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestGetAllSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{
+		categoryRules: &crservice.CategoryRuleListResponse{Records: []crservice.CategoryRuleResponse{}},
+	}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/category-rule", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/category-rule?entity=gain", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, `{"records":[]}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gain", _readingProcessMock.entity)
+}
+
+func TestGetAllInvalidEntity(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/category-rule", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/category-rule?entity=transfer", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"A param entity transfer is invalid"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetAllFail(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/category-rule", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/category-rule", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestDeleteSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.DELETE("/category-rule/:id", handler.Delete)
+
+	req, _ := http.NewRequest("DELETE", "/v1/category-rule/4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, `{"message":"Category rule removed","status":200}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", _storageProcessMock.deleted)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{err: apperror.NotFound("Category rule not found")}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.DELETE("/category-rule/:id", handler.Delete)
+
+	req, _ := http.NewRequest("DELETE", "/v1/category-rule/4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Category rule not found"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
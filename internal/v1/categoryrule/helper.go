@@ -0,0 +1,15 @@
+package categoryrule
+
+import (
+	"fmt"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+)
+
+func validateEntity(entity string) error {
+	if entity != "" && entity != crservice.ENTITY_GAIN && entity != crservice.ENTITY_INVOICE {
+		return apperror.Validation(fmt.Sprintf("A param entity %s is invalid", entity))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type CategoryRuleBuilder struct {
+	id            string
+	createdAt     time.Time
+	userId        string
+	entity        string
+	matchType     string
+	pattern       string
+	minValue      *money.Money
+	maxValue      *money.Money
+	paymentTypeId *uint
+	categoryId    *uint
+	label         string
+	isPassive     *bool
+	priority      uint
+}
+
+func NewCategoryRuleBuilder() *CategoryRuleBuilder {
+	return &CategoryRuleBuilder{}
+}
+func (builder *CategoryRuleBuilder) AddId(id string) *CategoryRuleBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddCreatedAt(createdAt time.Time) *CategoryRuleBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddUserId(userId string) *CategoryRuleBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddEntity(entity string) *CategoryRuleBuilder {
+	builder.entity = entity
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddMatchType(matchType string) *CategoryRuleBuilder {
+	builder.matchType = matchType
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddPattern(pattern string) *CategoryRuleBuilder {
+	builder.pattern = pattern
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddMinValue(minValue *money.Money) *CategoryRuleBuilder {
+	builder.minValue = minValue
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddMaxValue(maxValue *money.Money) *CategoryRuleBuilder {
+	builder.maxValue = maxValue
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddPaymentTypeId(paymentTypeId *uint) *CategoryRuleBuilder {
+	builder.paymentTypeId = paymentTypeId
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddCategoryId(categoryId *uint) *CategoryRuleBuilder {
+	builder.categoryId = categoryId
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddLabel(label string) *CategoryRuleBuilder {
+	builder.label = label
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddIsPassive(isPassive *bool) *CategoryRuleBuilder {
+	builder.isPassive = isPassive
+	return builder
+}
+func (builder *CategoryRuleBuilder) AddPriority(priority uint) *CategoryRuleBuilder {
+	builder.priority = priority
+	return builder
+}
+func (builder *CategoryRuleBuilder) Build() *CategoryRule {
+	categoryRule := CategoryRule{}
+
+	categoryRule.Id = builder.id
+	categoryRule.CreatedAt = builder.createdAt
+	categoryRule.UserId = builder.userId
+	categoryRule.Entity = builder.entity
+	categoryRule.MatchType = builder.matchType
+	categoryRule.Pattern = builder.pattern
+	categoryRule.MinValue = builder.minValue
+	categoryRule.MaxValue = builder.maxValue
+	categoryRule.PaymentTypeId = builder.paymentTypeId
+	categoryRule.CategoryId = builder.categoryId
+	categoryRule.Label = builder.label
+	categoryRule.IsPassive = builder.isPassive
+	categoryRule.Priority = builder.priority
+
+	return &categoryRule
+}
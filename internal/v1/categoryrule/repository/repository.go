@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type Repository interface {
+	Save(ctx context.Context, categoryRule CategoryRule) (*CategoryRule, error)
+	GetById(ctx context.Context, id string, userId string) (*CategoryRule, error)
+	GetAll(ctx context.Context, userId string, entity string) (*[]CategoryRule, error)
+	Delete(ctx context.Context, id string, userId string) error
+	SaveLabel(ctx context.Context, recordLabel RecordLabel) error
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Save(ctx context.Context, categoryRule CategoryRule) (*CategoryRule, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO category_rule (id, created_at, user_id, entity, match_type, pattern, min_value, max_value,
+			payment_type_id, category_id, label, is_passive, priority)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		categoryRule.Id,
+		categoryRule.CreatedAt.Unix(),
+		categoryRule.UserId,
+		categoryRule.Entity,
+		categoryRule.MatchType,
+		categoryRule.Pattern,
+		categoryRule.MinValue,
+		categoryRule.MaxValue,
+		categoryRule.PaymentTypeId,
+		categoryRule.CategoryId,
+		categoryRule.Label,
+		categoryRule.IsPassive,
+		categoryRule.Priority,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &categoryRule, nil
+}
+
+func (r *repository) scanCategoryRules(rows *sql.Rows) (*[]CategoryRule, error) {
+	categoryRuleList := []CategoryRule{}
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var minValue, maxValue money.NullMoney
+		var paymentTypeId, categoryId sql.NullInt64
+		var isPassive sql.NullBool
+		var categoryRule CategoryRule
+
+		err := rows.Scan(
+			&categoryRule.Id,
+			&createdAtTimestamp,
+			&categoryRule.UserId,
+			&categoryRule.Entity,
+			&categoryRule.MatchType,
+			&categoryRule.Pattern,
+			&minValue,
+			&maxValue,
+			&paymentTypeId,
+			&categoryId,
+			&categoryRule.Label,
+			&isPassive,
+			&categoryRule.Priority)
+		if err != nil {
+			return nil, err
+		}
+		categoryRule.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		if minValue.Valid {
+			categoryRule.MinValue = &minValue.Money
+		}
+		if maxValue.Valid {
+			categoryRule.MaxValue = &maxValue.Money
+		}
+		if paymentTypeId.Valid {
+			id := uint(paymentTypeId.Int64)
+			categoryRule.PaymentTypeId = &id
+		}
+		if categoryId.Valid {
+			id := uint(categoryId.Int64)
+			categoryRule.CategoryId = &id
+		}
+		if isPassive.Valid {
+			categoryRule.IsPassive = &isPassive.Bool
+		}
+
+		categoryRuleList = append(categoryRuleList, categoryRule)
+	}
+	return &categoryRuleList, nil
+}
+
+func (r *repository) GetById(ctx context.Context, id string, userId string) (*CategoryRule, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			entity,
+			match_type,
+			pattern,
+			min_value,
+			max_value,
+			payment_type_id,
+			category_id,
+			label,
+			is_passive,
+			priority
+		FROM
+			category_rule
+		WHERE
+			id = ? AND user_id = ?`
+	rows, err := r.db.QueryContext(ctx, query, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	categoryRuleList, err := r.scanCategoryRules(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(*categoryRuleList) == 0 {
+		return nil, nil
+	}
+	return &(*categoryRuleList)[0], nil
+}
+
+// GetAll returns the rules in the order they are evaluated, the blank entity returns the rules of every entity
+func (r *repository) GetAll(ctx context.Context, userId string, entity string) (*[]CategoryRule, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			entity,
+			match_type,
+			pattern,
+			min_value,
+			max_value,
+			payment_type_id,
+			category_id,
+			label,
+			is_passive,
+			priority
+		FROM
+			category_rule
+		WHERE
+			user_id = ? AND (? = '' OR entity = ?)
+		ORDER BY priority DESC, created_at ASC`
+	rows, err := r.db.QueryContext(ctx, query, userId, entity, entity)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanCategoryRules(rows)
+}
+
+func (r *repository) Delete(ctx context.Context, id string, userId string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM category_rule WHERE id = ? AND user_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SaveLabel registers the label of the user when it is new and links it to the record, a link that already exists is kept
+func (r *repository) SaveLabel(ctx context.Context, recordLabel RecordLabel) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT IGNORE INTO label (id, user_id, label) VALUES (?, ?, ?)`,
+		recordLabel.Id, recordLabel.UserId, recordLabel.Label)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT IGNORE INTO record_label (entity, entity_id, label_id)
+		SELECT ?, ?, id FROM label WHERE user_id = ? AND label = ?`,
+		recordLabel.Entity, recordLabel.EntityId, recordLabel.UserId, recordLabel.Label)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
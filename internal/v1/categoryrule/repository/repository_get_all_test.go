@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getAllQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			entity,
+			match_type,
+			pattern,
+			min_value,
+			max_value,
+			payment_type_id,
+			category_id,
+			label,
+			is_passive,
+			priority
+		FROM
+			category_rule
+		WHERE
+			user_id = ? AND (? = '' OR entity = ?)
+		ORDER BY priority DESC, created_at ASC`
+
+func TestGetAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(categoryRuleColumns).
+		AddRow("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", time.Now().Unix(), "User1", "invoice", "contains", "padaria", nil, "50.00", nil, 2, "Café da manhã", nil, 10).
+		AddRow("8f1d6c2b-7a3e-4b5c-9d0e-1f2a3b4c5d6e", time.Now().Unix(), "User1", "invoice", "regex", "^posto", "100.00", nil, 3, nil, "", nil, 0)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1", "invoice", "invoice").
+		WillReturnRows(rowsMock)
+
+	categoryRuleList, err := _repository.GetAll(context.Background(), "User1", "invoice")
+	assert.NoError(t, err)
+	assert.Len(t, *categoryRuleList, 2)
+	assert.Equal(t, "^posto", (*categoryRuleList)[1].Pattern)
+	assert.Equal(t, uint(3), *(*categoryRuleList)[1].PaymentTypeId)
+	assert.Nil(t, (*categoryRuleList)[1].CategoryId)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(categoryRuleColumns).
+		AddRow("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", time.Now().Unix(), "User1", "invoice", "contains", "padaria", nil, nil, nil, "two", "", nil, 10)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1", "", "").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetAll(context.Background(), "User1", "")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1", "gain", "gain").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAll(context.Background(), "User1", "gain")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
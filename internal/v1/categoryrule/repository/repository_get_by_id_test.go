@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var categoryRuleColumns = []string{"id", "created_at", "user_id", "entity", "match_type", "pattern", "min_value", "max_value",
+	"payment_type_id", "category_id", "label", "is_passive", "priority"}
+
+const getByIdQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			entity,
+			match_type,
+			pattern,
+			min_value,
+			max_value,
+			payment_type_id,
+			category_id,
+			label,
+			is_passive,
+			priority
+		FROM
+			category_rule
+		WHERE
+			id = ? AND user_id = ?`
+
+func TestGetByIdSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(categoryRuleColumns).
+		AddRow("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", time.Now().Unix(), "User1", "invoice", "contains", "padaria", nil, "50.00", nil, 2, "Café da manhã", nil, 10)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", "User1").
+		WillReturnRows(rowsMock)
+
+	categoryRule, err := _repository.GetById(context.Background(), "4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "padaria", categoryRule.Pattern)
+	assert.Equal(t, uint(2), *categoryRule.CategoryId)
+	assert.Nil(t, categoryRule.MinValue)
+	assert.Equal(t, money.FromCents(5000), *categoryRule.MaxValue)
+	assert.Nil(t, categoryRule.PaymentTypeId)
+	assert.Nil(t, categoryRule.IsPassive)
+	assert.Equal(t, "Café da manhã", categoryRule.Label)
+	assert.Equal(t, uint(10), categoryRule.Priority)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", "User1").
+		WillReturnRows(sqlMock.NewRows(categoryRuleColumns))
+
+	categoryRule, err := _repository.GetById(context.Background(), "4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, categoryRule)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetById(context.Background(), "4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveLabelQuery = `INSERT IGNORE INTO label (id, user_id, label) VALUES (?, ?, ?)`
+
+const saveRecordLabelQuery = `
+		INSERT IGNORE INTO record_label (entity, entity_id, label_id)
+		SELECT ?, ?, id FROM label WHERE user_id = ? AND label = ?`
+
+func buildRecordLabelMock() RecordLabel {
+	return RecordLabel{
+		Id:       "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d",
+		UserId:   "User1",
+		Entity:   "invoice",
+		EntityId: "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Label:    "Café da manhã",
+	}
+}
+
+func TestSaveLabelSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveLabelQuery).
+		WithArgs("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", "User1", "Café da manhã").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectExec(saveRecordLabelQuery).
+		WithArgs("invoice", "cd1cc27b-28a1-47dc-ac76-70e8185e159d", "User1", "Café da manhã").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.SaveLabel(context.Background(), buildRecordLabelMock())
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveLabelFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveLabelQuery).
+		WithArgs("9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", "User1", "Café da manhã").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(saveRecordLabelQuery).
+		WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.SaveLabel(context.Background(), buildRecordLabelMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
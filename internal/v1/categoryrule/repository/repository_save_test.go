@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveQuery = `
+		INSERT INTO category_rule (id, created_at, user_id, entity, match_type, pattern, min_value, max_value,
+			payment_type_id, category_id, label, is_passive, priority)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func buildCategoryRuleMock() *CategoryRule {
+	categoryId := uint(2)
+	maxValue := money.FromCents(5000)
+	return NewCategoryRuleBuilder().
+		AddId("4b7e2a10-93c5-4d8e-a1f2-6c3d9e8b7a01").
+		AddCreatedAt(time.Now()).
+		AddUserId("User1").
+		AddEntity("invoice").
+		AddMatchType("contains").
+		AddPattern("padaria").
+		AddMaxValue(&maxValue).
+		AddCategoryId(&categoryId).
+		AddLabel("Café da manhã").
+		AddPriority(10).
+		Build()
+}
+
+func TestSaveSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	categoryRuleMock := buildCategoryRuleMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveQuery).
+		ExpectExec().
+		WithArgs(
+			categoryRuleMock.Id,
+			categoryRuleMock.CreatedAt.Unix(),
+			categoryRuleMock.UserId,
+			categoryRuleMock.Entity,
+			categoryRuleMock.MatchType,
+			categoryRuleMock.Pattern,
+			nil,
+			"50.00",
+			nil,
+			int64(2),
+			categoryRuleMock.Label,
+			nil,
+			categoryRuleMock.Priority).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	categoryRuleSaved, err := _repository.Save(context.Background(), *categoryRuleMock)
+	assert.NoError(t, err)
+	assert.Equal(t, categoryRuleMock.Id, categoryRuleSaved.Id)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	categoryRuleMock := buildCategoryRuleMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveQuery).
+		ExpectExec().
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	categoryRuleSaved, err := _repository.Save(context.Background(), *categoryRuleMock)
+	assert.Error(t, err)
+	assert.Empty(t, categoryRuleSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	categoryRuleSaved, err := _repository.Save(context.Background(), *buildCategoryRuleMock())
+	assert.Error(t, err)
+	assert.Empty(t, categoryRuleSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+// CategoryRule applies its actions (category, label and is_passive) to the records of the entity that meet
+// every condition it informs: the pattern in the description, the value range and the payment type
+type CategoryRule struct {
+	Id            string
+	CreatedAt     time.Time
+	UserId        string
+	Entity        string
+	MatchType     string
+	Pattern       string
+	MinValue      *money.Money
+	MaxValue      *money.Money
+	PaymentTypeId *uint
+	CategoryId    *uint
+	Label         string
+	IsPassive     *bool
+	Priority      uint
+}
+
+// RecordLabel links a label of the user to a gain or an invoice
+type RecordLabel struct {
+	Id       string
+	UserId   string
+	Entity   string
+	EntityId string
+	Label    string
+}
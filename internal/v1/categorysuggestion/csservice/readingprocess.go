@@ -0,0 +1,31 @@
+package csservice
+
+import (
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+)
+
+type ReadingProcess interface {
+	GetSuggestions(searchCtx SearchContext) (*SuggestionListResponse, error)
+}
+
+type readingProcess struct {
+	suggester Suggester
+}
+
+func NewReadingProcess(suggester Suggester) ReadingProcess {
+	return &readingProcess{suggester: suggester}
+}
+
+func (rp *readingProcess) GetSuggestions(searchCtx SearchContext) (*SuggestionListResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	suggestions, err := rp.suggester.Suggest(SuggestContext{
+		Ctx:         searchCtx.Ctx,
+		UserId:      user.Id,
+		Entity:      searchCtx.Entity,
+		Description: searchCtx.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SuggestionListResponse{Suggestions: suggestions}, nil
+}
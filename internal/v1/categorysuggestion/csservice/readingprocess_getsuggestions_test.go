@@ -0,0 +1,40 @@
+package csservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSuggestionsSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{history: buildHistoryMock()}
+	_readingProcess := NewReadingProcess(NewSuggester(_mockRepository))
+
+	response, err := _readingProcess.GetSuggestions(SearchContext{
+		Ctx:         context.TODO(),
+		Entity:      ENTITY_INVOICE,
+		Description: "Posto Shell",
+		UserToken:   tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), response.Suggestions[0].CategoryId)
+	assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", _mockRepository.userId)
+}
+
+func TestGetSuggestionsEmpty(t *testing.T) {
+	_readingProcess := NewReadingProcess(NewSuggester(&mockRepository{}))
+
+	response, err := _readingProcess.GetSuggestions(SearchContext{Ctx: context.TODO(), Entity: ENTITY_GAIN, Description: "Salário", UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, []CategorySuggestionResponse{}, response.Suggestions)
+}
+
+func TestGetSuggestionsFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(NewSuggester(&mockRepository{err: errors.New("An error has been ocurred")}))
+
+	response, err := _readingProcess.GetSuggestions(SearchContext{Ctx: context.TODO(), Entity: ENTITY_GAIN, Description: "Salário", UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,138 @@
+package csservice
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/repository"
+)
+
+// Suggester suggests the category of a description from how the user categorized the similar ones before
+type Suggester interface {
+	Suggest(suggestCtx SuggestContext) ([]CategorySuggestionResponse, error)
+}
+
+type suggester struct {
+	repository repository.Repository
+}
+
+func NewSuggester(repository repository.Repository) Suggester {
+	return &suggester{repository: repository}
+}
+
+// Suggest ranks the categories with a naive Bayes classifier trained on the history of the user.
+// The confidence is the probability of each category given the words of the description,
+// and no category is suggested when none of its words appear in the history.
+func (s *suggester) Suggest(suggestCtx SuggestContext) ([]CategorySuggestionResponse, error) {
+	getHistory := s.repository.GetInvoiceHistory
+	if suggestCtx.Entity == ENTITY_GAIN {
+		getHistory = s.repository.GetGainHistory
+	}
+	recordList, err := getHistory(suggestCtx.Ctx, suggestCtx.UserId, HISTORY_SIZE)
+	if err != nil {
+		return nil, err
+	}
+	model := train(*recordList)
+	return model.classify(tokenize(suggestCtx.Description)), nil
+}
+
+type classifier struct {
+	records     int
+	categories  map[uint]string
+	documents   map[uint]int
+	tokens      map[uint]map[string]int
+	totalTokens map[uint]int
+	vocabulary  map[string]bool
+}
+
+func train(recordList []repository.CategorizedRecord) *classifier {
+	model := &classifier{
+		categories:  map[uint]string{},
+		documents:   map[uint]int{},
+		tokens:      map[uint]map[string]int{},
+		totalTokens: map[uint]int{},
+		vocabulary:  map[string]bool{},
+	}
+	for _, record := range recordList {
+		categoryId := record.Category.Id
+		model.records++
+		model.categories[categoryId] = record.Category.Category
+		model.documents[categoryId]++
+		if model.tokens[categoryId] == nil {
+			model.tokens[categoryId] = map[string]int{}
+		}
+		for _, token := range tokenize(record.Description) {
+			model.tokens[categoryId][token]++
+			model.totalTokens[categoryId]++
+			model.vocabulary[token] = true
+		}
+	}
+	return model
+}
+
+// classify scores each category with the log of its prior plus the log of the Laplace smoothed likelihood
+// of the known tokens, then turns the scores into probabilities
+func (model *classifier) classify(tokens []string) []CategorySuggestionResponse {
+	knownTokens := []string{}
+	for _, token := range tokens {
+		if model.vocabulary[token] {
+			knownTokens = append(knownTokens, token)
+		}
+	}
+	suggestions := []CategorySuggestionResponse{}
+	if len(knownTokens) == 0 {
+		return suggestions
+	}
+
+	scores := map[uint]float64{}
+	maxScore := math.Inf(-1)
+	for categoryId, documents := range model.documents {
+		score := math.Log(float64(documents) / float64(model.records))
+		for _, token := range knownTokens {
+			likelihood := float64(model.tokens[categoryId][token]+1) / float64(model.totalTokens[categoryId]+len(model.vocabulary))
+			score += math.Log(likelihood)
+		}
+		scores[categoryId] = score
+		maxScore = math.Max(maxScore, score)
+	}
+	var total float64
+	for categoryId, score := range scores {
+		scores[categoryId] = math.Exp(score - maxScore)
+		total += scores[categoryId]
+	}
+	for categoryId, score := range scores {
+		suggestions = append(suggestions, CategorySuggestionResponse{
+			CategoryId: categoryId,
+			Category:   model.categories[categoryId],
+			Confidence: math.Round(score/total*100) / 100,
+		})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Confidence != suggestions[j].Confidence {
+			return suggestions[i].Confidence > suggestions[j].Confidence
+		}
+		return suggestions[i].CategoryId < suggestions[j].CategoryId
+	})
+	if len(suggestions) > MAX_SUGGESTIONS {
+		suggestions = suggestions[:MAX_SUGGESTIONS]
+	}
+	return suggestions
+}
+
+// tokenize splits the description into lower case words, leaving out the numbers and the single letters
+// that usually are dates, installments and codes
+func tokenize(description string) []string {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := []string{}
+	for _, word := range words {
+		if len([]rune(word)) < 2 || strings.IndexFunc(word, unicode.IsLetter) == -1 {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
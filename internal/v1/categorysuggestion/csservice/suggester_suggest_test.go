@@ -0,0 +1,111 @@
+package csservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	history  []repository.CategorizedRecord
+	userId   string
+	limit    uint
+	entities []string
+	err      error
+}
+
+func (r *mockRepository) GetGainHistory(ctx context.Context, userId string, limit uint) (*[]repository.CategorizedRecord, error) {
+	r.entities = append(r.entities, ENTITY_GAIN)
+	return r.getHistory(userId, limit)
+}
+
+func (r *mockRepository) GetInvoiceHistory(ctx context.Context, userId string, limit uint) (*[]repository.CategorizedRecord, error) {
+	r.entities = append(r.entities, ENTITY_INVOICE)
+	return r.getHistory(userId, limit)
+}
+
+func (r *mockRepository) getHistory(userId string, limit uint) (*[]repository.CategorizedRecord, error) {
+	r.userId = userId
+	r.limit = limit
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &r.history, nil
+}
+
+func buildHistoryMock() []repository.CategorizedRecord {
+	food := repository.Category{Id: 2, Category: "Alimentação"}
+	transport := repository.Category{Id: 3, Category: "Transporte"}
+	return []repository.CategorizedRecord{
+		{Description: "Padaria Pão Quente", Category: food},
+		{Description: "PADARIA CENTRAL 12/03", Category: food},
+		{Description: "Mercado Extra", Category: food},
+		{Description: "Posto Ipiranga", Category: transport},
+		{Description: "Uber *Trip", Category: transport},
+		{Description: "Uber *Trip Help.Uber.com", Category: transport},
+	}
+}
+
+func TestSuggestSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{history: buildHistoryMock()}
+	_suggester := NewSuggester(_mockRepository)
+
+	suggestions, err := _suggester.Suggest(SuggestContext{
+		Ctx:         context.TODO(),
+		UserId:      "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Entity:      ENTITY_INVOICE,
+		Description: "Padaria do Bairro",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 2)
+	assert.Equal(t, uint(2), suggestions[0].CategoryId)
+	assert.Equal(t, "Alimentação", suggestions[0].Category)
+	assert.Greater(t, suggestions[0].Confidence, AUTO_CATEGORY_MIN_CONFIDENCE)
+	assert.InDelta(t, 1, suggestions[0].Confidence+suggestions[1].Confidence, 0.011)
+	assert.Equal(t, []string{ENTITY_INVOICE}, _mockRepository.entities)
+	assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", _mockRepository.userId)
+	assert.Equal(t, uint(HISTORY_SIZE), _mockRepository.limit)
+}
+
+func TestSuggestGain(t *testing.T) {
+	_mockRepository := &mockRepository{history: buildHistoryMock()}
+	_suggester := NewSuggester(_mockRepository)
+
+	suggestions, err := _suggester.Suggest(SuggestContext{Ctx: context.TODO(), Entity: ENTITY_GAIN, Description: "UBER TRIP"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), suggestions[0].CategoryId)
+	assert.Equal(t, []string{ENTITY_GAIN}, _mockRepository.entities)
+}
+
+func TestSuggestUnknownDescription(t *testing.T) {
+	_suggester := NewSuggester(&mockRepository{history: buildHistoryMock()})
+
+	suggestions, err := _suggester.Suggest(SuggestContext{Ctx: context.TODO(), Entity: ENTITY_INVOICE, Description: "Farmácia 24h"})
+	assert.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
+
+func TestSuggestWithoutHistory(t *testing.T) {
+	_suggester := NewSuggester(&mockRepository{})
+
+	suggestions, err := _suggester.Suggest(SuggestContext{Ctx: context.TODO(), Entity: ENTITY_INVOICE, Description: "Padaria"})
+	assert.NoError(t, err)
+	assert.Empty(t, suggestions)
+}
+
+func TestSuggestFail(t *testing.T) {
+	_suggester := NewSuggester(&mockRepository{err: errors.New("An error has been ocurred")})
+
+	suggestions, err := _suggester.Suggest(SuggestContext{Ctx: context.TODO(), Entity: ENTITY_INVOICE, Description: "Padaria"})
+	assert.Error(t, err)
+	assert.Nil(t, suggestions)
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"padaria", "pão", "quente", "3x"}, tokenize("PADARIA Pão-Quente 12/03 3x a"))
+}
@@ -0,0 +1,41 @@
+package csservice
+
+import "context"
+
+const (
+	ENTITY_GAIN    = "gain"
+	ENTITY_INVOICE = "invoice"
+)
+
+// HISTORY_SIZE is how many of the most recent records of the user the suggestions are learned from
+const HISTORY_SIZE = 1000
+
+// MAX_SUGGESTIONS is how many categories are suggested at most
+const MAX_SUGGESTIONS = 3
+
+// AUTO_CATEGORY_MIN_CONFIDENCE is the confidence the best suggestion needs to be chosen by the auto_category flag
+const AUTO_CATEGORY_MIN_CONFIDENCE = 0.5
+
+type SuggestContext struct {
+	Ctx         context.Context
+	UserId      string
+	Entity      string
+	Description string
+}
+
+type SearchContext struct {
+	Ctx         context.Context
+	Entity      string
+	Description string
+	UserToken   string
+}
+
+type CategorySuggestionResponse struct {
+	CategoryId uint    `json:"category_id"`
+	Category   string  `json:"category"`
+	Confidence float64 `json:"confidence"`
+}
+
+type SuggestionListResponse struct {
+	Suggestions []CategorySuggestionResponse `json:"suggestions"`
+}
@@ -0,0 +1,68 @@
+package categorysuggestion
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/csservice"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	GetSuggestions(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	readingProcess csservice.ReadingProcess
+}
+
+func NewHandler(readingProcess csservice.ReadingProcess) Handler {
+	return &handler{readingProcess: readingProcess}
+}
+
+// GetSuggestions godoc
+// @Summary Sugerir a categoria de uma descrição
+// @Description Este endpoint permite obter as categorias sugeridas para a descrição de uma receita ou despesa, aprendidas com as categorias que o usuário escolheu para as descrições parecidas.
+// @Description As sugestões vêm da maior para a menor confiança, e nenhuma é retornada quando as palavras da descrição não aparecem no histórico
+// @Tags CategorySuggestion
+// @Accept json
+// @Produce json
+// @Param entity query string true "Tipo do registro (gain, invoice)"
+// @Param description query string true "Descrição da receita ou despesa"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} csservice.SuggestionListResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/category-suggestion [get]
+func (h *handler) GetSuggestions(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	entity := c.Query("entity")
+	description := c.Query("description")
+
+	err := validateSearch(entity, description)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("CategorySuggestion::ReadingProcess::GetSuggestions", "Get the category suggestions of a description", nil)
+	searchCtx := csservice.SearchContext{
+		Ctx:         ctx,
+		Entity:      entity,
+		Description: description,
+		UserToken:   userToken,
+	}
+	suggestions, err := h.readingProcess.GetSuggestions(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, suggestions)
+}
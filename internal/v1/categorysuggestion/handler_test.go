@@ -0,0 +1,108 @@
+package categorysuggestion
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/csservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type readingProcessMock struct {
+	err         error
+	suggestions *csservice.SuggestionListResponse
+	searchCtx   csservice.SearchContext
+}
+
+func (rp *readingProcessMock) GetSuggestions(searchCtx csservice.SearchContext) (*csservice.SuggestionListResponse, error) {
+	rp.searchCtx = searchCtx
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.suggestions, nil
+}
+
+func TestGetSuggestionsSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{
+		suggestions: &csservice.SuggestionListResponse{
+			Suggestions: []csservice.CategorySuggestionResponse{
+				{CategoryId: 2, Category: "Alimentação", Confidence: 0.87},
+				{CategoryId: 3, Category: "Transporte", Confidence: 0.13},
+			},
+		},
+	}
+	handler := NewHandler(_readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/category-suggestion", handler.GetSuggestions)
+
+	req, _ := http.NewRequest("GET", "/v1/category-suggestion?entity=invoice&description=Padaria%20do%20Bairro", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"suggestions":[{"category_id":2,"category":"Alimentação","confidence":0.87},{"category_id":3,"category":"Transporte","confidence":0.13}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "invoice", _readingProcessMock.searchCtx.Entity)
+	assert.Equal(t, "Padaria do Bairro", _readingProcessMock.searchCtx.Description)
+}
+
+func TestGetSuggestionsInvalidEntity(t *testing.T) {
+	handler := NewHandler(&readingProcessMock{})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/category-suggestion", handler.GetSuggestions)
+
+	req, _ := http.NewRequest("GET", "/v1/category-suggestion?entity=transfer&description=Padaria", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"A param entity transfer is invalid"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetSuggestionsWithoutDescription(t *testing.T) {
+	handler := NewHandler(&readingProcessMock{})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/category-suggestion", handler.GetSuggestions)
+
+	req, _ := http.NewRequest("GET", "/v1/category-suggestion?entity=gain&description=%20", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"The param description must be informed"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetSuggestionsFail(t *testing.T) {
+	handler := NewHandler(&readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/category-suggestion", handler.GetSuggestions)
+
+	req, _ := http.NewRequest("GET", "/v1/category-suggestion?entity=gain&description=Salario", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"An unexpected error has occurred, try again later"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,19 @@
+package categorysuggestion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/csservice"
+)
+
+func validateSearch(entity string, description string) error {
+	if entity != csservice.ENTITY_GAIN && entity != csservice.ENTITY_INVOICE {
+		return apperror.Validation(fmt.Sprintf("A param entity %s is invalid", entity))
+	}
+	if strings.TrimSpace(description) == "" {
+		return apperror.Validation("The param description must be informed")
+	}
+	return nil
+}
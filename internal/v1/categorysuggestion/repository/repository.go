@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Repository interface {
+	GetGainHistory(ctx context.Context, userId string, limit uint) (*[]CategorizedRecord, error)
+	GetInvoiceHistory(ctx context.Context, userId string, limit uint) (*[]CategorizedRecord, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetGainHistory returns the description and the category of the most recent gains of the user
+func (r *repository) GetGainHistory(ctx context.Context, userId string, limit uint) (*[]CategorizedRecord, error) {
+	query := `
+		SELECT
+			g.description,
+			gc.id,
+			gc.category
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON
+			gc.id = g.category_id
+		WHERE
+			g.user_id = ? AND g.deleted_at IS NULL
+		ORDER BY g.created_at DESC
+		LIMIT ?`
+	return r.getHistory(ctx, query, userId, limit)
+}
+
+// GetInvoiceHistory returns the description and the category of the most recent invoices of the user
+func (r *repository) GetInvoiceHistory(ctx context.Context, userId string, limit uint) (*[]CategorizedRecord, error) {
+	query := `
+		SELECT
+			i.description,
+			ic.id,
+			ic.category
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON
+			ic.id = i.category_id
+		WHERE
+			i.user_id = ? AND i.deleted_at IS NULL
+		ORDER BY i.created_at DESC
+		LIMIT ?`
+	return r.getHistory(ctx, query, userId, limit)
+}
+
+func (r *repository) getHistory(ctx context.Context, query string, userId string, limit uint) (*[]CategorizedRecord, error) {
+	rows, err := r.db.QueryContext(ctx, query, userId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recordList := []CategorizedRecord{}
+	for rows.Next() {
+		var record CategorizedRecord
+		err := rows.Scan(&record.Description, &record.Category.Id, &record.Category.Category)
+		if err != nil {
+			return nil, err
+		}
+		recordList = append(recordList, record)
+	}
+	return &recordList, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getInvoiceHistoryQuery = `
+		SELECT
+			i.description,
+			ic.id,
+			ic.category
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON
+			ic.id = i.category_id
+		WHERE
+			i.user_id = ? AND i.deleted_at IS NULL
+		ORDER BY i.created_at DESC
+		LIMIT ?`
+
+func TestGetInvoiceHistorySuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"description", "id", "category"}).
+		AddRow("Padaria Pão Quente", 2, "Alimentação").
+		AddRow("Posto Ipiranga", 3, "Transporte")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceHistoryQuery).
+		WithArgs("User1", 1000).
+		WillReturnRows(rowsMock)
+
+	recordList, err := _repository.GetInvoiceHistory(context.Background(), "User1", 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, []CategorizedRecord{
+		{Description: "Padaria Pão Quente", Category: Category{Id: 2, Category: "Alimentação"}},
+		{Description: "Posto Ipiranga", Category: Category{Id: 3, Category: "Transporte"}},
+	}, *recordList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceHistoryScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"description", "id", "category"}).
+		AddRow("Padaria Pão Quente", "two", "Alimentação")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceHistoryQuery).
+		WithArgs("User1", 1000).
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetInvoiceHistory(context.Background(), "User1", 1000)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceHistoryQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceHistoryQuery).
+		WithArgs("User1", 1000).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetInvoiceHistory(context.Background(), "User1", 1000)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
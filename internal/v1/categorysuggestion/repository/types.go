@@ -0,0 +1,12 @@
+package repository
+
+// CategorizedRecord is a gain or invoice already registered by the user, from which the suggestions are learned
+type CategorizedRecord struct {
+	Description string
+	Category    Category
+}
+
+type Category struct {
+	Id       uint
+	Category string
+}
@@ -0,0 +1,71 @@
+package erservice
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type SearchParamsBuilder struct {
+	baseCurrency  string
+	quoteCurrency string
+}
+
+func NewSearchParamsBuilder() *SearchParamsBuilder {
+	return &SearchParamsBuilder{}
+}
+func (builder *SearchParamsBuilder) AddBaseCurrency(baseCurrency string) *SearchParamsBuilder {
+	builder.baseCurrency = baseCurrency
+	return builder
+}
+func (builder *SearchParamsBuilder) AddQuoteCurrency(quoteCurrency string) *SearchParamsBuilder {
+	builder.quoteCurrency = quoteCurrency
+	return builder
+}
+func (builder *SearchParamsBuilder) Build() *SearchParams {
+	return &SearchParams{
+		baseCurrency:  builder.baseCurrency,
+		quoteCurrency: builder.quoteCurrency,
+	}
+}
+
+type ExchangeRateResponseBuilder struct {
+	id            string
+	baseCurrency  string
+	quoteCurrency string
+	rateDate      time.Time
+	rate          money.Rate
+}
+
+func NewExchangeRateResponseBuilder() *ExchangeRateResponseBuilder {
+	return &ExchangeRateResponseBuilder{}
+}
+func (builder *ExchangeRateResponseBuilder) AddId(id string) *ExchangeRateResponseBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *ExchangeRateResponseBuilder) AddBaseCurrency(baseCurrency string) *ExchangeRateResponseBuilder {
+	builder.baseCurrency = baseCurrency
+	return builder
+}
+func (builder *ExchangeRateResponseBuilder) AddQuoteCurrency(quoteCurrency string) *ExchangeRateResponseBuilder {
+	builder.quoteCurrency = quoteCurrency
+	return builder
+}
+func (builder *ExchangeRateResponseBuilder) AddRateDate(rateDate time.Time) *ExchangeRateResponseBuilder {
+	builder.rateDate = rateDate
+	return builder
+}
+func (builder *ExchangeRateResponseBuilder) AddRate(rate money.Rate) *ExchangeRateResponseBuilder {
+	builder.rate = rate
+	return builder
+}
+func (builder *ExchangeRateResponseBuilder) Build() *ExchangeRateResponse {
+	return &ExchangeRateResponse{
+		Id:            builder.id,
+		BaseCurrency:  builder.baseCurrency,
+		QuoteCurrency: builder.quoteCurrency,
+		RateDate:      builder.rateDate,
+		Rate:          builder.rate,
+	}
+}
@@ -0,0 +1,58 @@
+package erservice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/repository"
+)
+
+// Converter translates amounts between currencies with the rates registered by the user
+type Converter interface {
+	Convert(convertCtx ConvertContext) (money.Money, error)
+}
+
+type converter struct {
+	repository repository.Repository
+}
+
+func NewConverter(repository repository.Repository) Converter {
+	return &converter{repository: repository}
+}
+
+// Convert uses the latest rate informed on or before the date of the transaction.
+// When only the rate of the opposite direction is registered, it is inverted.
+func (cv *converter) Convert(convertCtx ConvertContext) (money.Money, error) {
+	from := money.NormalizeCurrency(convertCtx.From)
+	to := money.NormalizeCurrency(convertCtx.To)
+	if from == to {
+		return convertCtx.Amount, nil
+	}
+	exchangeRate, err := cv.getLatest(convertCtx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	if exchangeRate != nil {
+		return convertCtx.Amount.Convert(exchangeRate.Rate), nil
+	}
+	exchangeRate, err = cv.getLatest(convertCtx, to, from)
+	if err != nil {
+		return 0, err
+	}
+	if exchangeRate != nil {
+		return convertCtx.Amount.Convert(exchangeRate.Rate.Invert()), nil
+	}
+	return 0, apperror.Unprocessable(fmt.Sprintf("There is no exchange rate from %s to %s on %s", from, to, convertCtx.Date.Format(time.DateOnly)))
+}
+
+func (cv *converter) getLatest(convertCtx ConvertContext, base string, quote string) (*repository.ExchangeRate, error) {
+	queryParams := repository.NewQueryParamsBuilder().
+		AddUserId(convertCtx.UserId).
+		AddBaseCurrency(base).
+		AddQuoteCurrency(quote).
+		AddDate(truncateDate(convertCtx.Date)).
+		Build()
+	return cv.repository.GetLatest(convertCtx.Ctx, queryParams)
+}
@@ -0,0 +1,78 @@
+package erservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertSameCurrency(t *testing.T) {
+	_converter := NewConverter(&mockRepository{})
+	amount, err := _converter.Convert(ConvertContext{Ctx: context.TODO(), UserId: "User1", Amount: money.FromCents(1000), From: "", To: "BRL", Date: time.Now()})
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromCents(1000), amount)
+}
+
+func TestConvertDirectRate(t *testing.T) {
+	date := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetLatestCall(func(ctx context.Context, params repository.QueryParams) (*repository.ExchangeRate, error) {
+		assert.Equal(t, repository.NewQueryParamsBuilder().
+			AddUserId("User1").
+			AddBaseCurrency("USD").
+			AddQuoteCurrency("BRL").
+			AddDate(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)).
+			Build(), params)
+		return &repository.ExchangeRate{Rate: money.Rate(495120000)}, nil
+	})
+
+	_converter := NewConverter(_mockRepository)
+	amount, err := _converter.Convert(ConvertContext{Ctx: context.TODO(), UserId: "User1", Amount: money.FromCents(10000), From: "USD", To: "BRL", Date: date})
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromCents(49512), amount)
+}
+
+func TestConvertInverseRate(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetLatestCall(func(ctx context.Context, params repository.QueryParams) (*repository.ExchangeRate, error) {
+		return nil, nil
+	})
+	_mockRepository.AddGetLatestCall(func(ctx context.Context, params repository.QueryParams) (*repository.ExchangeRate, error) {
+		assert.Equal(t, repository.NewQueryParamsBuilder().
+			AddUserId("User1").
+			AddBaseCurrency("USD").
+			AddQuoteCurrency("BRL").
+			AddDate(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)).
+			Build(), params)
+		return &repository.ExchangeRate{Rate: money.Rate(500000000)}, nil
+	})
+
+	_converter := NewConverter(_mockRepository)
+	amount, err := _converter.Convert(ConvertContext{Ctx: context.TODO(), UserId: "User1", Amount: money.FromCents(10000), From: "BRL", To: "USD", Date: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromCents(2000), amount)
+}
+
+func TestConvertWithoutRate(t *testing.T) {
+	_converter := NewConverter(&mockRepository{})
+	_, err := _converter.Convert(ConvertContext{Ctx: context.TODO(), UserId: "User1", Amount: money.FromCents(10000), From: "EUR", To: "BRL", Date: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)})
+	assert.Equal(t, apperror.KIND_VALIDATION, apperror.KindOf(err))
+	assert.Equal(t, "There is no exchange rate from EUR to BRL on 2024-03-04", err.Error())
+}
+
+func TestConvertGetLatestFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetLatestCall(func(ctx context.Context, params repository.QueryParams) (*repository.ExchangeRate, error) {
+		return nil, errors.New("An error has been ocurred")
+	})
+
+	_converter := NewConverter(_mockRepository)
+	_, err := _converter.Convert(ConvertContext{Ctx: context.TODO(), UserId: "User1", Amount: money.FromCents(10000), From: "USD", To: "BRL", Date: time.Now()})
+	assert.Error(t, err)
+}
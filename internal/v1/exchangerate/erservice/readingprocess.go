@@ -0,0 +1,37 @@
+package erservice
+
+import (
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/repository"
+)
+
+type ReadingProcess interface {
+	GetAll(searchCtx SearchContext) (*ExchangeRateListResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+}
+
+func NewReadingProcess(repository repository.Repository) ReadingProcess {
+	return &readingProcess{repository: repository}
+}
+
+func (rp *readingProcess) GetAll(searchCtx SearchContext) (*ExchangeRateListResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	queryParams := repository.NewQueryParamsBuilder().
+		AddUserId(user.Id).
+		AddBaseCurrency(searchCtx.Params.baseCurrency).
+		AddQuoteCurrency(searchCtx.Params.quoteCurrency).
+		Build()
+	exchangeRateList, err := rp.repository.GetAll(searchCtx.Ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeRateResponseList := []ExchangeRateResponse{}
+	for _, exchangeRate := range *exchangeRateList {
+		exchangeRateResponseList = append(exchangeRateResponseList, *toResponse(exchangeRate))
+	}
+	return &ExchangeRateListResponse{Records: exchangeRateResponseList}, nil
+}
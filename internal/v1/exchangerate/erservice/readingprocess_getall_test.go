@@ -0,0 +1,60 @@
+package erservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetAllCall(func(ctx context.Context, params repository.QueryParams) (*[]repository.ExchangeRate, error) {
+		assert.Equal(t, repository.NewQueryParamsBuilder().
+			AddUserId("5832a502-bede-492d-8dc1-b13b32c30f29").
+			AddBaseCurrency("USD").
+			Build(), params)
+		return &[]repository.ExchangeRate{*repository.NewExchangeRateBuilder().
+			AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+			AddBaseCurrency("USD").
+			AddQuoteCurrency("BRL").
+			AddRateDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).
+			AddRate(money.Rate(495120000)).
+			Build()}, nil
+	})
+
+	_readingProcess := NewReadingProcess(_mockRepository)
+	params := NewSearchParamsBuilder().AddBaseCurrency("USD").Build()
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(response.Records))
+	assert.Equal(t, "BRL", response.Records[0].QuoteCurrency)
+}
+
+func TestGetAllEmpty(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetAllCall(func(ctx context.Context, params repository.QueryParams) (*[]repository.ExchangeRate, error) {
+		return &[]repository.ExchangeRate{}, nil
+	})
+
+	_readingProcess := NewReadingProcess(_mockRepository)
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), Params: *NewSearchParamsBuilder().Build(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, []ExchangeRateResponse{}, response.Records)
+}
+
+func TestGetAllFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetAllCall(func(ctx context.Context, params repository.QueryParams) (*[]repository.ExchangeRate, error) {
+		return nil, errors.New("An error has been ocurred")
+	})
+
+	_readingProcess := NewReadingProcess(_mockRepository)
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), Params: *NewSearchParamsBuilder().Build(), UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,142 @@
+package erservice
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type StorageProcess interface {
+	Create(createCtx CreateContext) (*ExchangeRateResponse, error)
+	Import(importCtx ImportContext) (*ImportResponse, error)
+}
+
+type storageProcess struct {
+	repository   repository.Repository
+	generateUUID func() uuid.UUID
+}
+
+func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID) StorageProcess {
+	return &storageProcess{repository: repository, generateUUID: generateUUID}
+}
+
+func (sp *storageProcess) Create(createCtx CreateContext) (*ExchangeRateResponse, error) {
+	request := createCtx.Request
+	user := idpauth.GetUser(createCtx.UserToken)
+	exchangeRate := repository.NewExchangeRateBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(time.Now()).
+		AddUserId(user.Id).
+		AddBaseCurrency(request.BaseCurrency).
+		AddQuoteCurrency(request.QuoteCurrency).
+		AddRateDate(truncateDate(request.RateDate)).
+		AddRate(request.Rate).
+		Build()
+	exchangeRateSaved, err := sp.repository.Save(createCtx.Ctx, *exchangeRate)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(*exchangeRateSaved), nil
+}
+
+// Import loads a CSV file with the columns date, base_currency, quote_currency and rate,
+// such as "2024-03-01,USD,BRL,4.9512". The header line is optional.
+// The file is only stored when every line is valid.
+func (sp *storageProcess) Import(importCtx ImportContext) (*ImportResponse, error) {
+	user := idpauth.GetUser(importCtx.UserToken)
+	reader := csv.NewReader(importCtx.File)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, apperror.Validation("The file is not a valid CSV").Wrap(err)
+	}
+	if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "date") {
+		records = records[1:]
+	}
+	if len(records) == 0 {
+		return nil, apperror.Validation("The file has no exchange rates")
+	}
+	if len(records) > MAX_IMPORT_LINES {
+		return nil, apperror.Validation(fmt.Sprintf("The file must have at most %d exchange rates", MAX_IMPORT_LINES))
+	}
+
+	createdAt := time.Now()
+	errs := validation.Errors{}
+	exchangeRates := []repository.ExchangeRate{}
+	for index, record := range records {
+		exchangeRate, err := parseRecord(record)
+		if err != nil {
+			errs = append(errs, validation.FieldError{
+				Field:   "file",
+				Code:    validation.CODE_INVALID,
+				Message: fmt.Sprintf("The line %d %s", index+1, err.Error()),
+			})
+			continue
+		}
+		exchangeRate.Id = sp.generateUUID().String()
+		exchangeRate.CreatedAt = createdAt
+		exchangeRate.UserId = user.Id
+		exchangeRates = append(exchangeRates, *exchangeRate)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	err = sp.repository.SaveAll(importCtx.Ctx, exchangeRates)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportResponse{Imported: uint(len(exchangeRates))}, nil
+}
+
+func parseRecord(record []string) (*repository.ExchangeRate, error) {
+	if len(record) != 4 {
+		return nil, errors.New("must have the columns date, base_currency, quote_currency and rate")
+	}
+	rateDate, err := time.Parse(time.DateOnly, strings.TrimSpace(record[0]))
+	if err != nil {
+		return nil, errors.New("has a date that is not in the format YYYY-MM-DD")
+	}
+	baseCurrency := strings.ToUpper(strings.TrimSpace(record[1]))
+	quoteCurrency := strings.ToUpper(strings.TrimSpace(record[2]))
+	if !validation.IsCurrency(baseCurrency) || !validation.IsCurrency(quoteCurrency) {
+		return nil, errors.New("has a currency that is not a valid ISO 4217 code")
+	}
+	if baseCurrency == quoteCurrency {
+		return nil, errors.New("has the same base and quote currency")
+	}
+	rate, err := money.ParseRate(record[3])
+	if err != nil || rate <= 0 {
+		return nil, errors.New("has a rate that is not a positive number")
+	}
+	return repository.NewExchangeRateBuilder().
+		AddBaseCurrency(baseCurrency).
+		AddQuoteCurrency(quoteCurrency).
+		AddRateDate(rateDate).
+		AddRate(rate).
+		Build(), nil
+}
+
+// truncateDate keeps only the day, since a rate is valid for the whole date
+func truncateDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toResponse(exchangeRate repository.ExchangeRate) *ExchangeRateResponse {
+	return NewExchangeRateResponseBuilder().
+		AddId(exchangeRate.Id).
+		AddBaseCurrency(exchangeRate.BaseCurrency).
+		AddQuoteCurrency(exchangeRate.QuoteCurrency).
+		AddRateDate(exchangeRate.RateDate).
+		AddRate(exchangeRate.Rate).
+		Build()
+}
@@ -0,0 +1,120 @@
+package erservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/repository"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	saveCallsMock      []func(ctx context.Context, exchangeRate repository.ExchangeRate) (*repository.ExchangeRate, error)
+	saveAllCallsMock   []func(ctx context.Context, exchangeRates []repository.ExchangeRate) error
+	getAllCallsMock    []func(ctx context.Context, params repository.QueryParams) (*[]repository.ExchangeRate, error)
+	getLatestCallsMock []func(ctx context.Context, params repository.QueryParams) (*repository.ExchangeRate, error)
+}
+
+func (r *mockRepository) AddSaveCall(
+	save func(ctx context.Context, exchangeRate repository.ExchangeRate) (*repository.ExchangeRate, error)) *mockRepository {
+	r.saveCallsMock = append(r.saveCallsMock, save)
+	return r
+}
+
+func (r *mockRepository) AddSaveAllCall(
+	saveAll func(ctx context.Context, exchangeRates []repository.ExchangeRate) error) *mockRepository {
+	r.saveAllCallsMock = append(r.saveAllCallsMock, saveAll)
+	return r
+}
+
+func (r *mockRepository) AddGetAllCall(
+	getAll func(ctx context.Context, params repository.QueryParams) (*[]repository.ExchangeRate, error)) *mockRepository {
+	r.getAllCallsMock = append(r.getAllCallsMock, getAll)
+	return r
+}
+
+func (r *mockRepository) AddGetLatestCall(
+	getLatest func(ctx context.Context, params repository.QueryParams) (*repository.ExchangeRate, error)) *mockRepository {
+	r.getLatestCallsMock = append(r.getLatestCallsMock, getLatest)
+	return r
+}
+
+func (r *mockRepository) Save(ctx context.Context, exchangeRate repository.ExchangeRate) (*repository.ExchangeRate, error) {
+	if len(r.saveCallsMock) >= 1 {
+		save := r.saveCallsMock[0]
+		r.saveCallsMock = r.saveCallsMock[1:]
+		return save(ctx, exchangeRate)
+	}
+	return nil, nil
+}
+
+func (r *mockRepository) SaveAll(ctx context.Context, exchangeRates []repository.ExchangeRate) error {
+	if len(r.saveAllCallsMock) >= 1 {
+		saveAll := r.saveAllCallsMock[0]
+		r.saveAllCallsMock = r.saveAllCallsMock[1:]
+		return saveAll(ctx, exchangeRates)
+	}
+	return nil
+}
+
+func (r *mockRepository) GetAll(ctx context.Context, params repository.QueryParams) (*[]repository.ExchangeRate, error) {
+	if len(r.getAllCallsMock) >= 1 {
+		getAll := r.getAllCallsMock[0]
+		r.getAllCallsMock = r.getAllCallsMock[1:]
+		return getAll(ctx, params)
+	}
+	return nil, nil
+}
+
+func (r *mockRepository) GetLatest(ctx context.Context, params repository.QueryParams) (*repository.ExchangeRate, error) {
+	if len(r.getLatestCallsMock) >= 1 {
+		getLatest := r.getLatestCallsMock[0]
+		r.getLatestCallsMock = r.getLatestCallsMock[1:]
+		return getLatest(ctx, params)
+	}
+	return nil, nil
+}
+
+func uuidMock() uuid.UUID {
+	return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+}
+
+func TestCreateSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, exchangeRate repository.ExchangeRate) (*repository.ExchangeRate, error) {
+		assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", exchangeRate.UserId)
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), exchangeRate.RateDate)
+		return &exchangeRate, nil
+	})
+
+	request := CreateRequest{
+		BaseCurrency:  "USD",
+		QuoteCurrency: "BRL",
+		RateDate:      time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC),
+		Rate:          money.Rate(495120000),
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	response, err := _storageProcess.Create(CreateContext{Ctx: context.TODO(), Request: request, UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, "cd1cc27b-28a1-47dc-ac76-70e8185e159d", response.Id)
+	assert.Equal(t, money.Rate(495120000), response.Rate)
+}
+
+func TestCreateSaveFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, exchangeRate repository.ExchangeRate) (*repository.ExchangeRate, error) {
+		return nil, errors.New("An error has been ocurred")
+	})
+
+	request := CreateRequest{BaseCurrency: "USD", QuoteCurrency: "BRL", RateDate: time.Now(), Rate: money.Rate(495120000)}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	response, err := _storageProcess.Create(CreateContext{Ctx: context.TODO(), Request: request, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
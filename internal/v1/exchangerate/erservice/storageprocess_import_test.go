@@ -0,0 +1,74 @@
+package erservice
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportSuccess(t *testing.T) {
+	var saved []repository.ExchangeRate
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveAllCall(func(ctx context.Context, exchangeRates []repository.ExchangeRate) error {
+		saved = exchangeRates
+		return nil
+	})
+
+	file := strings.NewReader("date,base_currency,quote_currency,rate\n2024-03-01,USD,BRL,4.9512\n2024-03-01, eur, brl, 5.3871\n")
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	response, err := _storageProcess.Import(ImportContext{Ctx: context.TODO(), File: file, UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), response.Imported)
+	assert.Equal(t, 2, len(saved))
+	assert.Equal(t, "EUR", saved[1].BaseCurrency)
+	assert.Equal(t, "BRL", saved[1].QuoteCurrency)
+	assert.Equal(t, money.Rate(538710000), saved[1].Rate)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), saved[1].RateDate)
+	assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", saved[0].UserId)
+}
+
+func TestImportInvalidLines(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveAllCall(func(ctx context.Context, exchangeRates []repository.ExchangeRate) error {
+		t.Fatal("the file must not be stored")
+		return nil
+	})
+
+	file := strings.NewReader("01/03/2024,USD,BRL,4.9512\n2024-03-01,USD,XYZ,4.9512\n2024-03-01,USD,BRL,-1\n2024-03-01,USD,BRL\n2024-03-01,USD,BRL,4.95\n")
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	response, err := _storageProcess.Import(ImportContext{Ctx: context.TODO(), File: file, UserToken: tokenMock})
+	assert.Nil(t, response)
+	var errs validation.Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Equal(t, 4, len(errs))
+	assert.Equal(t, "The line 1 has a date that is not in the format YYYY-MM-DD", errs[0].Message)
+	assert.Equal(t, "The line 2 has a currency that is not a valid ISO 4217 code", errs[1].Message)
+	assert.Equal(t, "The line 3 has a rate that is not a positive number", errs[2].Message)
+	assert.Equal(t, "The line 4 must have the columns date, base_currency, quote_currency and rate", errs[3].Message)
+}
+
+func TestImportEmptyFile(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{}, uuidMock)
+	_, err := _storageProcess.Import(ImportContext{Ctx: context.TODO(), File: strings.NewReader("date,base_currency,quote_currency,rate\n"), UserToken: tokenMock})
+	assert.Equal(t, apperror.KIND_VALIDATION, apperror.KindOf(err))
+}
+
+func TestImportSaveAllFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveAllCall(func(ctx context.Context, exchangeRates []repository.ExchangeRate) error {
+		return errors.New("An error has been ocurred")
+	})
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_, err := _storageProcess.Import(ImportContext{Ctx: context.TODO(), File: strings.NewReader("2024-03-01,USD,BRL,4.9512"), UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Equal(t, apperror.Kind(""), apperror.KindOf(err))
+}
@@ -0,0 +1,67 @@
+package erservice
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+// MAX_IMPORT_LINES limits the size of the files loaded at once
+const MAX_IMPORT_LINES = 5000
+
+type CreateContext struct {
+	Ctx       context.Context
+	Request   CreateRequest
+	UserToken string
+}
+
+type ImportContext struct {
+	Ctx       context.Context
+	File      io.Reader
+	UserToken string
+}
+
+type SearchContext struct {
+	Ctx       context.Context
+	Params    SearchParams
+	UserToken string
+}
+
+type ConvertContext struct {
+	Ctx    context.Context
+	UserId string
+	Amount money.Money
+	From   string
+	To     string
+	Date   time.Time
+}
+
+type CreateRequest struct {
+	BaseCurrency  string     `json:"base_currency" binding:"required,iso4217"`
+	QuoteCurrency string     `json:"quote_currency" binding:"required,iso4217,nefield=BaseCurrency"`
+	RateDate      time.Time  `json:"rate_date" binding:"required"`
+	Rate          money.Rate `json:"rate" binding:"gt=0" swaggertype:"number"`
+}
+
+type ExchangeRateResponse struct {
+	Id            string     `json:"id"`
+	BaseCurrency  string     `json:"base_currency"`
+	QuoteCurrency string     `json:"quote_currency"`
+	RateDate      time.Time  `json:"rate_date"`
+	Rate          money.Rate `json:"rate" swaggertype:"number"`
+}
+
+type ExchangeRateListResponse struct {
+	Records []ExchangeRateResponse `json:"records"`
+}
+
+type ImportResponse struct {
+	Imported uint `json:"imported"`
+}
+
+type SearchParams struct {
+	baseCurrency  string
+	quoteCurrency string
+}
@@ -0,0 +1,150 @@
+package exchangerate
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	Create(c *gin.Context)
+	Import(c *gin.Context)
+	GetAll(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	storageProcess erservice.StorageProcess
+	readingProcess erservice.ReadingProcess
+}
+
+func NewHandler(storageProcess erservice.StorageProcess, readingProcess erservice.ReadingProcess) Handler {
+	return &handler{storageProcess: storageProcess, readingProcess: readingProcess}
+}
+
+// Create godoc
+// @Summary Informar uma cotação
+// @Description Este endpoint permite informar manualmente quanto vale uma unidade da moeda base na moeda de cotação em uma data. Uma cotação já informada para as mesmas moedas e data é substituída
+// @Tags ExchangeRate
+// @Accept json
+// @Produce json
+// @Param exchangeRate body erservice.CreateRequest true "Modelo de criação da cotação"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} erservice.ExchangeRateResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/exchange-rate [post]
+func (h *handler) Create(c *gin.Context) {
+	var request erservice.CreateRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("ExchangeRate::StorageProcess::Create", "Create new exchange rate", nil)
+	createCtx := erservice.CreateContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Request:   request,
+	}
+	exchangeRateCreated, err := h.storageProcess.Create(createCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, exchangeRateCreated)
+}
+
+// Import godoc
+// @Summary Carregar cotações de um arquivo
+// @Description Este endpoint permite carregar cotações de um arquivo CSV com as colunas date, base_currency, quote_currency e rate, como "2024-03-01,USD,BRL,4.9512". O arquivo só é carregado se todas as linhas forem válidas
+// @Tags ExchangeRate
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Arquivo CSV com as cotações"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} erservice.ImportResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/exchange-rate/import [post]
+func (h *handler) Import(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.Error(validation.Errors{validation.Required("file")})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer file.Close()
+
+	span := tx.StartSpan("ExchangeRate::StorageProcess::Import", "Import exchange rates from a file", nil)
+	importCtx := erservice.ImportContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		File:      file,
+	}
+	imported, err := h.storageProcess.Import(importCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, imported)
+}
+
+// @Summary Obter as cotações
+// @Description Este endpoint permite obter as cotações informadas, da data mais recente para a mais antiga
+// @Tags ExchangeRate
+// @Accept json
+// @Produce json
+// @Param base_currency query string false "Código ISO 4217 da moeda base"
+// @Param quote_currency query string false "Código ISO 4217 da moeda de cotação"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} erservice.ExchangeRateListResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/exchange-rate [get]
+func (h *handler) GetAll(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	searchParams, err := validateAndGetSearchParams(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("ExchangeRate::ReadingProcess::GetAll", "Get all exchange rates", nil)
+	searchCtx := erservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Params:    *searchParams,
+	}
+	exchangeRates, err := h.readingProcess.GetAll(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, exchangeRates)
+}
@@ -0,0 +1,220 @@
+package exchangerate
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type storageProcessMock struct {
+	err          error
+	exchangeRate *erservice.ExchangeRateResponse
+	imported     *erservice.ImportResponse
+	file         string
+}
+
+func (sp *storageProcessMock) Create(createCtx erservice.CreateContext) (*erservice.ExchangeRateResponse, error) {
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.exchangeRate, nil
+}
+
+func (sp *storageProcessMock) Import(importCtx erservice.ImportContext) (*erservice.ImportResponse, error) {
+	content, _ := io.ReadAll(importCtx.File)
+	sp.file = string(content)
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.imported, nil
+}
+
+type readingProcessMock struct {
+	err           error
+	exchangeRates *erservice.ExchangeRateListResponse
+}
+
+func (rp *readingProcessMock) GetAll(searchCtx erservice.SearchContext) (*erservice.ExchangeRateListResponse, error) {
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.exchangeRates, nil
+}
+
+func TestCreateSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{
+		exchangeRate: &erservice.ExchangeRateResponse{
+			Id:            "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+			BaseCurrency:  "USD",
+			QuoteCurrency: "BRL",
+			RateDate:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			Rate:          money.Rate(495120000),
+		},
+	}
+
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/exchange-rate", handler.Create)
+
+	body := []byte(`{"base_currency": "USD", "quote_currency": "BRL", "rate_date": "2024-03-01T00:00:00Z", "rate": 4.9512}`)
+	req, _ := http.NewRequest("POST", "/v1/exchange-rate", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+
+	router.ServeHTTP(w, req)
+	bodyExpected := `{"id":"cd1cc27b-28a1-47dc-ac76-70e8185e159d","base_currency":"USD","quote_currency":"BRL","rate_date":"2024-03-01T00:00:00Z","rate":4.9512}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCreateInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/exchange-rate", handler.Create)
+
+	body := []byte(`{"base_currency": "BRL", "quote_currency": "BRL", "rate_date": "2024-03-01T00:00:00Z", "rate": 0}`)
+	req, _ := http.NewRequest("POST", "/v1/exchange-rate", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+
+	router.ServeHTTP(w, req)
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"quote_currency","code":"invalid","message":"The quote_currency is not valid"},{"field":"rate","code":"too_small","message":"The rate must be greater than 0"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func newImportRequest(t *testing.T, content string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "rates.csv")
+	assert.NoError(t, err)
+	part.Write([]byte(content))
+	writer.Close()
+	req, _ := http.NewRequest("POST", "/v1/exchange-rate/import", body)
+	req.Header.Add("Content-Type", writer.FormDataContentType())
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	return req
+}
+
+func TestImportSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{imported: &erservice.ImportResponse{Imported: 2}}
+
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/exchange-rate/import", handler.Import)
+
+	content := "2024-03-01,USD,BRL,4.9512\n2024-03-01,EUR,BRL,5.3871\n"
+	router.ServeHTTP(w, newImportRequest(t, content))
+	assert.Equal(t, `{"imported":2}`, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, content, _storageProcessMock.file)
+}
+
+func TestImportWithoutFile(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/exchange-rate/import", handler.Import)
+
+	req, _ := http.NewRequest("POST", "/v1/exchange-rate/import", bytes.NewReader([]byte(`{}`)))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+
+	router.ServeHTTP(w, req)
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"file","code":"required","message":"The file must be informed"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestImportInvalidLines(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{
+		err: validation.Errors{{Field: "file", Code: validation.CODE_INVALID, Message: "The line 1 has a rate that is not a positive number"}},
+	}
+
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/exchange-rate/import", handler.Import)
+
+	router.ServeHTTP(w, newImportRequest(t, "2024-03-01,USD,BRL,0\n"))
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"file","code":"invalid","message":"The line 1 has a rate that is not a positive number"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestGetAllSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{
+		exchangeRates: &erservice.ExchangeRateListResponse{Records: []erservice.ExchangeRateResponse{}},
+	}
+
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/exchange-rate", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/exchange-rate?base_currency=USD", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+
+	router.ServeHTTP(w, req)
+	assert.Equal(t, `{"records":[]}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetAllInvalidCurrency(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/exchange-rate", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/exchange-rate?quote_currency=REAL", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+
+	router.ServeHTTP(w, req)
+	bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"A param quote_currency REAL is invalid"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetAllFail(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/exchange-rate", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/exchange-rate", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,26 @@
+package exchangerate
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+func validateAndGetSearchParams(c *gin.Context) (*erservice.SearchParams, error) {
+	baseCurrency := c.Query("base_currency")
+	quoteCurrency := c.Query("quote_currency")
+
+	if baseCurrency != "" && !validation.IsCurrency(baseCurrency) {
+		return nil, apperror.Validation(fmt.Sprintf("A param base_currency %s is invalid", baseCurrency))
+	}
+	if quoteCurrency != "" && !validation.IsCurrency(quoteCurrency) {
+		return nil, apperror.Validation(fmt.Sprintf("A param quote_currency %s is invalid", quoteCurrency))
+	}
+	return erservice.NewSearchParamsBuilder().
+		AddBaseCurrency(baseCurrency).
+		AddQuoteCurrency(quoteCurrency).
+		Build(), nil
+}
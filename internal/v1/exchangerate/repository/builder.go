@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type QueryParamsBuilder struct {
+	userId        string
+	baseCurrency  string
+	quoteCurrency string
+	date          time.Time
+}
+
+func NewQueryParamsBuilder() *QueryParamsBuilder {
+	return &QueryParamsBuilder{}
+}
+func (builder *QueryParamsBuilder) AddUserId(userId string) *QueryParamsBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *QueryParamsBuilder) AddBaseCurrency(baseCurrency string) *QueryParamsBuilder {
+	builder.baseCurrency = baseCurrency
+	return builder
+}
+func (builder *QueryParamsBuilder) AddQuoteCurrency(quoteCurrency string) *QueryParamsBuilder {
+	builder.quoteCurrency = quoteCurrency
+	return builder
+}
+func (builder *QueryParamsBuilder) AddDate(date time.Time) *QueryParamsBuilder {
+	builder.date = date
+	return builder
+}
+func (builder *QueryParamsBuilder) Build() QueryParams {
+	return QueryParams{
+		userId:        builder.userId,
+		baseCurrency:  builder.baseCurrency,
+		quoteCurrency: builder.quoteCurrency,
+		date:          builder.date,
+	}
+}
+
+type ExchangeRateBuilder struct {
+	id            string
+	createdAt     time.Time
+	userId        string
+	baseCurrency  string
+	quoteCurrency string
+	rateDate      time.Time
+	rate          money.Rate
+}
+
+func NewExchangeRateBuilder() *ExchangeRateBuilder {
+	return &ExchangeRateBuilder{}
+}
+func (builder *ExchangeRateBuilder) AddId(id string) *ExchangeRateBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *ExchangeRateBuilder) AddCreatedAt(createdAt time.Time) *ExchangeRateBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *ExchangeRateBuilder) AddUserId(userId string) *ExchangeRateBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *ExchangeRateBuilder) AddBaseCurrency(baseCurrency string) *ExchangeRateBuilder {
+	builder.baseCurrency = baseCurrency
+	return builder
+}
+func (builder *ExchangeRateBuilder) AddQuoteCurrency(quoteCurrency string) *ExchangeRateBuilder {
+	builder.quoteCurrency = quoteCurrency
+	return builder
+}
+func (builder *ExchangeRateBuilder) AddRateDate(rateDate time.Time) *ExchangeRateBuilder {
+	builder.rateDate = rateDate
+	return builder
+}
+func (builder *ExchangeRateBuilder) AddRate(rate money.Rate) *ExchangeRateBuilder {
+	builder.rate = rate
+	return builder
+}
+func (builder *ExchangeRateBuilder) Build() *ExchangeRate {
+	exchangeRate := ExchangeRate{}
+
+	exchangeRate.Id = builder.id
+	exchangeRate.CreatedAt = builder.createdAt
+	exchangeRate.UserId = builder.userId
+	exchangeRate.BaseCurrency = builder.baseCurrency
+	exchangeRate.QuoteCurrency = builder.quoteCurrency
+	exchangeRate.RateDate = builder.rateDate
+	exchangeRate.Rate = builder.rate
+
+	return &exchangeRate
+}
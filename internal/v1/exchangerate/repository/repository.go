@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Repository interface {
+	Save(ctx context.Context, exchangeRate ExchangeRate) (*ExchangeRate, error)
+	SaveAll(ctx context.Context, exchangeRates []ExchangeRate) error
+	GetAll(ctx context.Context, params QueryParams) (*[]ExchangeRate, error)
+	GetLatest(ctx context.Context, params QueryParams) (*ExchangeRate, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+const upsertQuery = `
+		INSERT INTO exchange_rate (id, created_at, user_id, base_currency, quote_currency, rate_date, rate) 
+		VALUES (?, ?, ?, ?, ?, ?, ?) 
+		ON DUPLICATE KEY UPDATE rate = VALUES(rate)`
+
+// Save stores the rate, replacing the one already informed for the same currencies and date
+func (r *repository) Save(ctx context.Context, exchangeRate ExchangeRate) (*ExchangeRate, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := tx.PrepareContext(ctx, upsertQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		exchangeRate.Id,
+		exchangeRate.CreatedAt.Unix(),
+		exchangeRate.UserId,
+		exchangeRate.BaseCurrency,
+		exchangeRate.QuoteCurrency,
+		exchangeRate.RateDate,
+		exchangeRate.Rate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &exchangeRate, nil
+}
+
+// SaveAll stores every rate in a single transaction, so a file is either fully loaded or not loaded at all
+func (r *repository) SaveAll(ctx context.Context, exchangeRates []ExchangeRate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, upsertQuery)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for _, exchangeRate := range exchangeRates {
+		_, err = stmt.Exec(
+			exchangeRate.Id,
+			exchangeRate.CreatedAt.Unix(),
+			exchangeRate.UserId,
+			exchangeRate.BaseCurrency,
+			exchangeRate.QuoteCurrency,
+			exchangeRate.RateDate,
+			exchangeRate.Rate,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) scanExchangeRates(rows *sql.Rows) (*[]ExchangeRate, error) {
+	var exchangeRateList []ExchangeRate
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var exchangeRate ExchangeRate
+
+		err := rows.Scan(
+			&exchangeRate.Id,
+			&createdAtTimestamp,
+			&exchangeRate.UserId,
+			&exchangeRate.BaseCurrency,
+			&exchangeRate.QuoteCurrency,
+			&exchangeRate.RateDate,
+			&exchangeRate.Rate)
+		if err != nil {
+			return nil, err
+		}
+		exchangeRate.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+
+		exchangeRateList = append(exchangeRateList, exchangeRate)
+	}
+	return &exchangeRateList, nil
+}
+
+func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]ExchangeRate, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			base_currency,
+			quote_currency,
+			rate_date,
+			rate
+		FROM
+			exchange_rate
+		WHERE 
+			user_id = ? AND (? = '' OR base_currency = ?) AND (? = '' OR quote_currency = ?)
+		ORDER BY rate_date DESC, base_currency ASC, quote_currency ASC`
+	rows, err := r.db.QueryContext(ctx, query,
+		params.userId, params.baseCurrency, params.baseCurrency, params.quoteCurrency, params.quoteCurrency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanExchangeRates(rows)
+}
+
+// GetLatest returns the most recent rate informed on or before the date of the params
+func (r *repository) GetLatest(ctx context.Context, params QueryParams) (*ExchangeRate, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			base_currency,
+			quote_currency,
+			rate_date,
+			rate
+		FROM
+			exchange_rate
+		WHERE 
+			user_id = ? AND base_currency = ? AND quote_currency = ? AND rate_date <= ?
+		ORDER BY rate_date DESC
+		LIMIT 1`
+	rows, err := r.db.QueryContext(ctx, query, params.userId, params.baseCurrency, params.quoteCurrency, params.date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	exchangeRateList, err := r.scanExchangeRates(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(*exchangeRateList) == 0 {
+		return nil, nil
+	}
+	return &(*exchangeRateList)[0], nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getAllQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			base_currency,
+			quote_currency,
+			rate_date,
+			rate
+		FROM
+			exchange_rate
+		WHERE 
+			user_id = ? AND (? = '' OR base_currency = ?) AND (? = '' OR quote_currency = ?)
+		ORDER BY rate_date DESC, base_currency ASC, quote_currency ASC`
+
+func TestGetAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rateDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"user_id",
+		"base_currency",
+		"quote_currency",
+		"rate_date",
+		"rate",
+	}).AddRow(
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		time.Now().Unix(),
+		"User1",
+		"USD",
+		"BRL",
+		rateDate,
+		"4.95120000",
+	)
+
+	queryParams := NewQueryParamsBuilder().
+		AddUserId("User1").
+		AddBaseCurrency("USD").
+		Build()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1", "USD", "USD", "", "").
+		WillReturnRows(rowsMock)
+
+	exchangeRates, err := _repository.GetAll(context.Background(), queryParams)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(*exchangeRates))
+	assert.Equal(t, money.Rate(495120000), (*exchangeRates)[0].Rate)
+	assert.Equal(t, rateDate, (*exchangeRates)[0].RateDate)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1", "", "", "", "").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAll(context.Background(), NewQueryParamsBuilder().AddUserId("User1").Build())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"user_id",
+		"base_currency",
+		"quote_currency",
+		"rate_date",
+		"rate",
+	}).AddRow(nil, nil, nil, nil, nil, nil, "invalid")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1", "", "", "", "").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetAll(context.Background(), NewQueryParamsBuilder().AddUserId("User1").Build())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getLatestQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			base_currency,
+			quote_currency,
+			rate_date,
+			rate
+		FROM
+			exchange_rate
+		WHERE 
+			user_id = ? AND base_currency = ? AND quote_currency = ? AND rate_date <= ?
+		ORDER BY rate_date DESC
+		LIMIT 1`
+
+func TestGetLatestSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	date := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"user_id",
+		"base_currency",
+		"quote_currency",
+		"rate_date",
+		"rate",
+	}).AddRow(
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		time.Now().Unix(),
+		"User1",
+		"USD",
+		"BRL",
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"4.95120000",
+	)
+	queryParams := NewQueryParamsBuilder().
+		AddUserId("User1").
+		AddBaseCurrency("USD").
+		AddQuoteCurrency("BRL").
+		AddDate(date).
+		Build()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getLatestQuery).
+		WithArgs("User1", "USD", "BRL", date).
+		WillReturnRows(rowsMock)
+
+	exchangeRate, err := _repository.GetLatest(context.Background(), queryParams)
+	assert.NoError(t, err)
+	assert.Equal(t, money.Rate(495120000), exchangeRate.Rate)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetLatestNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	date := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows([]string{"id", "created_at", "user_id", "base_currency", "quote_currency", "rate_date", "rate"})
+	queryParams := NewQueryParamsBuilder().
+		AddUserId("User1").
+		AddBaseCurrency("USD").
+		AddQuoteCurrency("BRL").
+		AddDate(date).
+		Build()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getLatestQuery).
+		WithArgs("User1", "USD", "BRL", date).
+		WillReturnRows(rowsMock)
+
+	exchangeRate, err := _repository.GetLatest(context.Background(), queryParams)
+	assert.NoError(t, err)
+	assert.Nil(t, exchangeRate)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetLatestQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	date := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	queryParams := NewQueryParamsBuilder().
+		AddUserId("User1").
+		AddBaseCurrency("USD").
+		AddQuoteCurrency("BRL").
+		AddDate(date).
+		Build()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getLatestQuery).
+		WithArgs("User1", "USD", "BRL", date).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetLatest(context.Background(), queryParams)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAllExchangeRatesSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	first := buildExchangeRateMock()
+	second := buildExchangeRateMock()
+	second.Id = "cd1cc27b-28a1-47dc-ac76-70e8185e159d"
+	second.BaseCurrency = "EUR"
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	prepare := sqlMock.ExpectPrepare(upsertQuery)
+	for _, exchangeRate := range []*ExchangeRate{first, second} {
+		prepare.ExpectExec().
+			WithArgs(
+				exchangeRate.Id,
+				exchangeRate.CreatedAt.Unix(),
+				exchangeRate.UserId,
+				exchangeRate.BaseCurrency,
+				exchangeRate.QuoteCurrency,
+				exchangeRate.RateDate,
+				"4.9512").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	sqlMock.ExpectCommit()
+
+	err = _repository.SaveAll(context.Background(), []ExchangeRate{*first, *second})
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveAllExchangeRatesExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	exchangeRateMock := buildExchangeRateMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(upsertQuery).
+		ExpectExec().
+		WithArgs(
+			exchangeRateMock.Id,
+			exchangeRateMock.CreatedAt.Unix(),
+			exchangeRateMock.UserId,
+			exchangeRateMock.BaseCurrency,
+			exchangeRateMock.QuoteCurrency,
+			exchangeRateMock.RateDate,
+			"4.9512").
+		WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.SaveAll(context.Background(), []ExchangeRate{*exchangeRateMock})
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildExchangeRateMock() *ExchangeRate {
+	return NewExchangeRateBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(time.Now()).
+		AddUserId("User1").
+		AddBaseCurrency("USD").
+		AddQuoteCurrency("BRL").
+		AddRateDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).
+		AddRate(money.Rate(495120000)).
+		Build()
+}
+
+func TestSaveExchangeRateSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	exchangeRateMock := buildExchangeRateMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		INSERT INTO exchange_rate (id, created_at, user_id, base_currency, quote_currency, rate_date, rate) 
+		VALUES (?, ?, ?, ?, ?, ?, ?) 
+		ON DUPLICATE KEY UPDATE rate = VALUES(rate)`).
+		ExpectExec().
+		WithArgs(
+			exchangeRateMock.Id,
+			exchangeRateMock.CreatedAt.Unix(),
+			exchangeRateMock.UserId,
+			exchangeRateMock.BaseCurrency,
+			exchangeRateMock.QuoteCurrency,
+			exchangeRateMock.RateDate,
+			"4.9512").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	exchangeRateSaved, err := _repository.Save(context.Background(), *exchangeRateMock)
+	assert.NoError(t, err)
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", exchangeRateSaved.Id)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveExchangeRateBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.Save(context.Background(), *buildExchangeRateMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveExchangeRateExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	exchangeRateMock := buildExchangeRateMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(upsertQuery).
+		ExpectExec().
+		WithArgs(
+			exchangeRateMock.Id,
+			exchangeRateMock.CreatedAt.Unix(),
+			exchangeRateMock.UserId,
+			exchangeRateMock.BaseCurrency,
+			exchangeRateMock.QuoteCurrency,
+			exchangeRateMock.RateDate,
+			"4.9512").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.Save(context.Background(), *exchangeRateMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+// ExchangeRate tells how many units of the quote currency are worth one unit of the base currency on a date
+type ExchangeRate struct {
+	Id            string
+	CreatedAt     time.Time
+	UserId        string
+	BaseCurrency  string
+	QuoteCurrency string
+	RateDate      time.Time
+	Rate          money.Rate
+}
+
+type QueryParams struct {
+	userId        string
+	baseCurrency  string
+	quoteCurrency string
+	date          time.Time
+}
@@ -0,0 +1,38 @@
+package fcservice
+
+import "time"
+
+type SearchParamsBuilder struct {
+	from        time.Time
+	to          time.Time
+	granularity string
+	currency    string
+}
+
+func NewSearchParamsBuilder() *SearchParamsBuilder {
+	return &SearchParamsBuilder{}
+}
+func (builder *SearchParamsBuilder) AddFrom(from time.Time) *SearchParamsBuilder {
+	builder.from = from
+	return builder
+}
+func (builder *SearchParamsBuilder) AddTo(to time.Time) *SearchParamsBuilder {
+	builder.to = to
+	return builder
+}
+func (builder *SearchParamsBuilder) AddGranularity(granularity string) *SearchParamsBuilder {
+	builder.granularity = granularity
+	return builder
+}
+func (builder *SearchParamsBuilder) AddCurrency(currency string) *SearchParamsBuilder {
+	builder.currency = currency
+	return builder
+}
+func (builder *SearchParamsBuilder) Build() *SearchParams {
+	return &SearchParams{
+		from:        builder.from,
+		to:          builder.to,
+		granularity: builder.granularity,
+		currency:    builder.currency,
+	}
+}
@@ -0,0 +1,173 @@
+package fcservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/forecast/repository"
+)
+
+type ReadingProcess interface {
+	GetForecast(searchCtx SearchContext) (*ForecastResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+	converter  erservice.Converter
+}
+
+func NewReadingProcess(repository repository.Repository, converter erservice.Converter) ReadingProcess {
+	return &readingProcess{repository: repository, converter: converter}
+}
+
+// GetForecast starts from the balance of everything dated before the interval and moves it, period by period,
+// with the realized records and the pending projections dated in the interval
+func (rp *readingProcess) GetForecast(searchCtx SearchContext) (*ForecastResponse, error) {
+	search := searchCtx.Params
+	user := idpauth.GetUser(searchCtx.UserToken)
+	from := truncateDate(search.from)
+	to := truncateDate(search.to)
+	currency := money.NormalizeCurrency(search.currency)
+
+	periods, err := buildPeriods(from, to, search.granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	balanceList, err := rp.repository.GetBalanceBefore(searchCtx.Ctx, user.Id, from)
+	if err != nil {
+		return nil, err
+	}
+	openingBalance := money.Money(0)
+	for _, balance := range *balanceList {
+		value, err := rp.convert(searchCtx.Ctx, user.Id, balance.Value, balance.Currency, currency, from)
+		if err != nil {
+			return nil, err
+		}
+		openingBalance += value
+	}
+
+	entryList, err := rp.repository.GetEntries(searchCtx.Ctx, user.Id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	entries := *entryList
+
+	forecast := &ForecastResponse{
+		From:           from,
+		To:             to,
+		Granularity:    search.granularity,
+		Currency:       currency,
+		OpeningBalance: openingBalance,
+		LowestBalance:  openingBalance,
+		Periods:        periods,
+	}
+	balance := openingBalance
+	if balance < 0 {
+		forecast.FirstNegativeAt = &from
+	}
+	next := 0
+	for index := range forecast.Periods {
+		period := &forecast.Periods[index]
+		period.LowestBalance = balance
+		startsWithEntry := next < len(entries) && truncateDate(entries[next].Date).Equal(period.Start)
+		for next < len(entries) && !truncateDate(entries[next].Date).After(period.End) {
+			entry := entries[next]
+			date := truncateDate(entry.Date)
+			value, err := rp.convert(searchCtx.Ctx, user.Id, entry.Value, entry.Currency, currency, date)
+			if err != nil {
+				return nil, err
+			}
+			balance += addToPeriod(period, entry, value)
+			next++
+			if next < len(entries) && truncateDate(entries[next].Date).Equal(date) {
+				continue
+			}
+			if startsWithEntry || balance < period.LowestBalance {
+				period.LowestBalance = balance
+				startsWithEntry = false
+			}
+			if balance < 0 && forecast.FirstNegativeAt == nil {
+				forecast.FirstNegativeAt = &date
+			}
+		}
+		period.Balance = balance
+		period.IsNegative = period.LowestBalance < 0
+		if period.IsNegative {
+			forecast.HasNegativeBalance = true
+		}
+		if period.LowestBalance < forecast.LowestBalance {
+			forecast.LowestBalance = period.LowestBalance
+		}
+	}
+	forecast.ClosingBalance = balance
+	return forecast, nil
+}
+
+func (rp *readingProcess) convert(ctx context.Context, userId string, amount money.Money, from string, to string, date time.Time) (money.Money, error) {
+	return rp.converter.Convert(erservice.ConvertContext{
+		Ctx:    ctx,
+		UserId: userId,
+		Amount: amount,
+		From:   from,
+		To:     to,
+		Date:   date,
+	})
+}
+
+// addToPeriod sums the entry in the totals of the period and returns how much it moves the balance
+func addToPeriod(period *PeriodResponse, entry repository.Entry, value money.Money) money.Money {
+	switch {
+	case entry.Entity == repository.ENTITY_GAIN && entry.Projected:
+		period.ExpectedGains += value
+	case entry.Entity == repository.ENTITY_GAIN:
+		period.RealizedGains += value
+	case entry.Projected:
+		period.ExpectedInvoices += value
+	default:
+		period.RealizedInvoices += value
+	}
+	if entry.Entity == repository.ENTITY_GAIN {
+		return value
+	}
+	return -value
+}
+
+// buildPeriods splits the interval in days, in weeks starting on monday or in calendar months,
+// the first and the last periods are cut at the edges of the interval
+func buildPeriods(from time.Time, to time.Time, granularity string) ([]PeriodResponse, error) {
+	periods := []PeriodResponse{}
+	for start := from; !start.After(to); {
+		if len(periods) == MAX_PERIODS {
+			return nil, apperror.Validation(fmt.Sprintf("The forecast must have at most %d periods", MAX_PERIODS))
+		}
+		end := getPeriodEnd(start, granularity)
+		if end.After(to) {
+			end = to
+		}
+		periods = append(periods, PeriodResponse{Start: start, End: end})
+		start = end.AddDate(0, 0, 1)
+	}
+	return periods, nil
+}
+
+func getPeriodEnd(start time.Time, granularity string) time.Time {
+	switch granularity {
+	case GRANULARITY_WEEK:
+		daysSinceMonday := (int(start.Weekday()) + 6) % 7
+		return start.AddDate(0, 0, 6-daysSinceMonday)
+	case GRANULARITY_MONTH:
+		return time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	default:
+		return start
+	}
+}
+
+func truncateDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
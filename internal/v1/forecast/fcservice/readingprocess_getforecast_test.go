@@ -0,0 +1,206 @@
+package fcservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/forecast/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	balances      []repository.CurrencyBalance
+	entries       []repository.Entry
+	balanceErr    error
+	entriesErr    error
+	userId        string
+	balanceBefore time.Time
+	entriesFrom   time.Time
+	entriesTo     time.Time
+}
+
+func (m *mockRepository) GetBalanceBefore(ctx context.Context, userId string, date time.Time) (*[]repository.CurrencyBalance, error) {
+	m.userId = userId
+	m.balanceBefore = date
+	if m.balanceErr != nil {
+		return nil, m.balanceErr
+	}
+	return &m.balances, nil
+}
+
+func (m *mockRepository) GetEntries(ctx context.Context, userId string, from time.Time, to time.Time) (*[]repository.Entry, error) {
+	m.entriesFrom = from
+	m.entriesTo = to
+	if m.entriesErr != nil {
+		return nil, m.entriesErr
+	}
+	return &m.entries, nil
+}
+
+// mockConverter doubles the amounts in USD and keeps the other ones
+type mockConverter struct {
+	err error
+}
+
+func (m *mockConverter) Convert(convertCtx erservice.ConvertContext) (money.Money, error) {
+	if m.err != nil && convertCtx.From != convertCtx.To {
+		return 0, m.err
+	}
+	if convertCtx.From == "USD" && convertCtx.To != "USD" {
+		return convertCtx.Amount * 2, nil
+	}
+	return convertCtx.Amount, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func buildEntriesMock() []repository.Entry {
+	return []repository.Entry{
+		{Entity: repository.ENTITY_INVOICE, Date: date(2024, 3, 5), Value: money.FromCents(80000), Currency: "BRL"},
+		{Entity: repository.ENTITY_GAIN, Projected: true, Date: date(2024, 3, 5), Value: money.FromCents(20000), Currency: "BRL"},
+		{Entity: repository.ENTITY_GAIN, Date: date(2024, 3, 20), Value: money.FromCents(50000), Currency: "BRL"},
+		{Entity: repository.ENTITY_INVOICE, Projected: true, Date: date(2024, 4, 10), Value: money.FromCents(10000), Currency: "USD"},
+	}
+}
+
+func TestGetForecastByMonth(t *testing.T) {
+	_mockRepository := &mockRepository{
+		balances: []repository.CurrencyBalance{
+			{Currency: "BRL", Value: money.FromCents(50000)},
+			{Currency: "USD", Value: money.FromCents(5000)},
+		},
+		entries: buildEntriesMock(),
+	}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{})
+
+	params := NewSearchParamsBuilder().
+		AddFrom(date(2024, 3, 1)).
+		AddTo(date(2024, 5, 15)).
+		AddGranularity(GRANULARITY_MONTH).
+		Build()
+	response, err := _readingProcess.GetForecast(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, &ForecastResponse{
+		From:               date(2024, 3, 1),
+		To:                 date(2024, 5, 15),
+		Granularity:        GRANULARITY_MONTH,
+		Currency:           "BRL",
+		OpeningBalance:     money.FromCents(60000),
+		ClosingBalance:     money.FromCents(30000),
+		LowestBalance:      money.FromCents(0),
+		HasNegativeBalance: false,
+		Periods: []PeriodResponse{
+			{Start: date(2024, 3, 1), End: date(2024, 3, 31), RealizedGains: money.FromCents(50000), RealizedInvoices: money.FromCents(80000), ExpectedGains: money.FromCents(20000), Balance: money.FromCents(50000), LowestBalance: money.FromCents(0)},
+			{Start: date(2024, 4, 1), End: date(2024, 4, 30), ExpectedInvoices: money.FromCents(20000), Balance: money.FromCents(30000), LowestBalance: money.FromCents(30000)},
+			{Start: date(2024, 5, 1), End: date(2024, 5, 15), Balance: money.FromCents(30000), LowestBalance: money.FromCents(30000)},
+		},
+	}, response)
+	assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", _mockRepository.userId)
+	assert.Equal(t, date(2024, 3, 1), _mockRepository.balanceBefore)
+	assert.Equal(t, date(2024, 5, 15), _mockRepository.entriesTo)
+}
+
+func TestGetForecastFlagsNegativePeriods(t *testing.T) {
+	_mockRepository := &mockRepository{
+		balances: []repository.CurrencyBalance{{Currency: "BRL", Value: money.FromCents(10000)}},
+		entries:  buildEntriesMock(),
+	}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{})
+
+	params := NewSearchParamsBuilder().
+		AddFrom(date(2024, 3, 4)).
+		AddTo(date(2024, 3, 24)).
+		AddGranularity(GRANULARITY_WEEK).
+		AddCurrency("BRL").
+		Build()
+	response, err := _readingProcess.GetForecast(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.NoError(t, err)
+	firstNegativeAt := date(2024, 3, 5)
+	assert.True(t, response.HasNegativeBalance)
+	assert.Equal(t, &firstNegativeAt, response.FirstNegativeAt)
+	assert.Equal(t, money.FromCents(-50000), response.LowestBalance)
+	assert.Equal(t, []PeriodResponse{
+		{Start: date(2024, 3, 4), End: date(2024, 3, 10), RealizedInvoices: money.FromCents(80000), ExpectedGains: money.FromCents(20000), Balance: money.FromCents(-50000), LowestBalance: money.FromCents(-50000), IsNegative: true},
+		{Start: date(2024, 3, 11), End: date(2024, 3, 17), Balance: money.FromCents(-50000), LowestBalance: money.FromCents(-50000), IsNegative: true},
+		{Start: date(2024, 3, 18), End: date(2024, 3, 24), RealizedGains: money.FromCents(50000), Balance: money.FromCents(0), LowestBalance: money.FromCents(-50000), IsNegative: true},
+	}, response.Periods)
+}
+
+func TestGetForecastByDayStartingWithEntry(t *testing.T) {
+	_mockRepository := &mockRepository{
+		balances: []repository.CurrencyBalance{{Currency: "BRL", Value: money.FromCents(-10000)}},
+		entries: []repository.Entry{
+			{Entity: repository.ENTITY_GAIN, Projected: true, Date: date(2024, 3, 1), Value: money.FromCents(30000), Currency: "BRL"},
+		},
+	}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{})
+
+	params := NewSearchParamsBuilder().
+		AddFrom(date(2024, 3, 1)).
+		AddTo(date(2024, 3, 2)).
+		AddGranularity(GRANULARITY_DAY).
+		Build()
+	response, err := _readingProcess.GetForecast(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.NoError(t, err)
+	firstNegativeAt := date(2024, 3, 1)
+	assert.False(t, response.HasNegativeBalance)
+	assert.Equal(t, &firstNegativeAt, response.FirstNegativeAt)
+	assert.Equal(t, []PeriodResponse{
+		{Start: date(2024, 3, 1), End: date(2024, 3, 1), ExpectedGains: money.FromCents(30000), Balance: money.FromCents(20000), LowestBalance: money.FromCents(20000)},
+		{Start: date(2024, 3, 2), End: date(2024, 3, 2), Balance: money.FromCents(20000), LowestBalance: money.FromCents(20000)},
+	}, response.Periods)
+}
+
+func TestGetForecastTooManyPeriods(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{})
+
+	params := NewSearchParamsBuilder().
+		AddFrom(date(2024, 1, 1)).
+		AddTo(date(2025, 1, 1)).
+		AddGranularity(GRANULARITY_DAY).
+		Build()
+	response, err := _readingProcess.GetForecast(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.Equal(t, apperror.Validation("The forecast must have at most 366 periods"), err)
+	assert.Nil(t, response)
+	assert.Equal(t, "", _mockRepository.userId)
+}
+
+func TestGetForecastConvertFail(t *testing.T) {
+	_mockRepository := &mockRepository{
+		balances: []repository.CurrencyBalance{{Currency: "USD", Value: money.FromCents(5000)}},
+	}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{err: apperror.Unprocessable("There is no exchange rate from USD to BRL on 2024-03-01")})
+
+	params := NewSearchParamsBuilder().AddFrom(date(2024, 3, 1)).AddTo(date(2024, 3, 31)).AddGranularity(GRANULARITY_MONTH).Build()
+	response, err := _readingProcess.GetForecast(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestGetForecastGetBalanceFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{balanceErr: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	params := NewSearchParamsBuilder().AddFrom(date(2024, 3, 1)).AddTo(date(2024, 3, 31)).AddGranularity(GRANULARITY_MONTH).Build()
+	response, err := _readingProcess.GetForecast(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestGetForecastGetEntriesFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{entriesErr: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	params := NewSearchParamsBuilder().AddFrom(date(2024, 3, 1)).AddTo(date(2024, 3, 31)).AddGranularity(GRANULARITY_MONTH).Build()
+	response, err := _readingProcess.GetForecast(SearchContext{Ctx: context.TODO(), Params: *params, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
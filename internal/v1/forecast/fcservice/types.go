@@ -0,0 +1,55 @@
+package fcservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const GRANULARITY_DAY = "day"
+const GRANULARITY_WEEK = "week"
+const GRANULARITY_MONTH = "month"
+
+// MAX_PERIODS limits the size of the forecast built at once
+const MAX_PERIODS = 366
+
+type SearchContext struct {
+	Ctx       context.Context
+	Params    SearchParams
+	UserToken string
+}
+
+type SearchParams struct {
+	from        time.Time
+	to          time.Time
+	granularity string
+	currency    string
+}
+
+type ForecastResponse struct {
+	From               time.Time        `json:"from"`
+	To                 time.Time        `json:"to"`
+	Granularity        string           `json:"granularity"`
+	Currency           string           `json:"currency"`
+	OpeningBalance     money.Money      `json:"opening_balance" swaggertype:"number"`
+	ClosingBalance     money.Money      `json:"closing_balance" swaggertype:"number"`
+	LowestBalance      money.Money      `json:"lowest_balance" swaggertype:"number"`
+	HasNegativeBalance bool             `json:"has_negative_balance"`
+	FirstNegativeAt    *time.Time       `json:"first_negative_at,omitempty"`
+	Periods            []PeriodResponse `json:"periods"`
+}
+
+// PeriodResponse holds the movements of the period and the balance at its end.
+// The period is negative when the balance is below zero at the end of any of its days.
+type PeriodResponse struct {
+	Start            time.Time   `json:"start"`
+	End              time.Time   `json:"end"`
+	RealizedGains    money.Money `json:"realized_gains" swaggertype:"number"`
+	RealizedInvoices money.Money `json:"realized_invoices" swaggertype:"number"`
+	ExpectedGains    money.Money `json:"expected_gains" swaggertype:"number"`
+	ExpectedInvoices money.Money `json:"expected_invoices" swaggertype:"number"`
+	Balance          money.Money `json:"balance" swaggertype:"number"`
+	LowestBalance    money.Money `json:"lowest_balance" swaggertype:"number"`
+	IsNegative       bool        `json:"is_negative"`
+}
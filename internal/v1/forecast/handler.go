@@ -0,0 +1,68 @@
+package forecast
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/forecast/fcservice"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	GetForecast(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	readingProcess fcservice.ReadingProcess
+}
+
+func NewHandler(readingProcess fcservice.ReadingProcess) Handler {
+	return &handler{readingProcess: readingProcess}
+}
+
+// GetForecast godoc
+// @Summary Obter a previsão do fluxo de caixa
+// @Description Este endpoint permite obter a previsão do saldo no intervalo, partindo do saldo de tudo o que é anterior ao início e somando, em cada período, as receitas e despesas realizadas e as projeções ainda não realizadas.
+// @Description Os valores são convertidos para a moeda informada, e os períodos em que o saldo fica negativo ao fim de algum dia são sinalizados
+// @Tags Forecast
+// @Accept json
+// @Produce json
+// @Param from query string true "Data inicial (AAAA-MM-DD)"
+// @Param to query string true "Data final (AAAA-MM-DD)"
+// @Param granularity query string false "Tamanho dos períodos (day, week, month), o padrão é month"
+// @Param currency query string false "Moeda da previsão, o padrão é BRL"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} fcservice.ForecastResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/forecast [get]
+func (h *handler) GetForecast(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	params, err := validateAndGetSearchParams(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Forecast::ReadingProcess::GetForecast", "Get the cash flow forecast", nil)
+	searchCtx := fcservice.SearchContext{
+		Ctx:       ctx,
+		Params:    *params,
+		UserToken: userToken,
+	}
+	forecast, err := h.readingProcess.GetForecast(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, forecast)
+}
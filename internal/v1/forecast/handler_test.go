@@ -0,0 +1,128 @@
+package forecast
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/forecast/fcservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type readingProcessMock struct {
+	err       error
+	forecast  *fcservice.ForecastResponse
+	searchCtx *fcservice.SearchContext
+}
+
+func (rp *readingProcessMock) GetForecast(searchCtx fcservice.SearchContext) (*fcservice.ForecastResponse, error) {
+	rp.searchCtx = &searchCtx
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.forecast, nil
+}
+
+func TestGetForecastSuccess(t *testing.T) {
+	firstNegativeAt := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	_readingProcessMock := &readingProcessMock{
+		forecast: &fcservice.ForecastResponse{
+			From:               time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			To:                 time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			Granularity:        "month",
+			Currency:           "BRL",
+			OpeningBalance:     money.FromCents(10000),
+			ClosingBalance:     money.FromCents(-5050),
+			LowestBalance:      money.FromCents(-70050),
+			HasNegativeBalance: true,
+			FirstNegativeAt:    &firstNegativeAt,
+			Periods: []fcservice.PeriodResponse{
+				{
+					Start:            time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+					End:              time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+					RealizedInvoices: money.FromCents(80050),
+					ExpectedGains:    money.FromCents(65000),
+					Balance:          money.FromCents(-5050),
+					LowestBalance:    money.FromCents(-70050),
+					IsNegative:       true,
+				},
+			},
+		},
+	}
+	handler := NewHandler(_readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/forecast", handler.GetForecast)
+
+	req, _ := http.NewRequest("GET", "/v1/forecast?from=2024-03-01&to=2024-03-31", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"from":"2024-03-01T00:00:00Z","to":"2024-03-31T00:00:00Z","granularity":"month","currency":"BRL","opening_balance":100,"closing_balance":-50.5,"lowest_balance":-700.5,"has_negative_balance":true,"first_negative_at":"2024-03-05T00:00:00Z","periods":[{"start":"2024-03-01T00:00:00Z","end":"2024-03-31T00:00:00Z","realized_gains":0,"realized_invoices":800.5,"expected_gains":650,"expected_invoices":0,"balance":-50.5,"lowest_balance":-700.5,"is_negative":true}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	expectedParams := fcservice.NewSearchParamsBuilder().
+		AddFrom(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).
+		AddTo(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)).
+		AddGranularity("month").
+		Build()
+	assert.Equal(t, *expectedParams, _readingProcessMock.searchCtx.Params)
+}
+
+func TestGetForecastInvalidParams(t *testing.T) {
+	tests := []struct {
+		query  string
+		detail string
+	}{
+		{query: "to=2024-03-31", detail: "A param from  is invalid"},
+		{query: "from=2024-03-01&to=31/03/2024", detail: "A param to 31/03/2024 is invalid"},
+		{query: "from=2024-03-01&to=2024-02-29", detail: "The param to must not be before the param from"},
+		{query: "from=2024-03-01&to=2024-03-31&granularity=year", detail: "A param granularity year is invalid"},
+		{query: "from=2024-03-01&to=2024-03-31&currency=REAL", detail: "A param currency REAL is invalid"},
+	}
+	for _, test := range tests {
+		_readingProcessMock := &readingProcessMock{}
+		handler := NewHandler(_readingProcessMock)
+		w := httptest.NewRecorder()
+		router := gin.Default()
+		router.Use(problem.Middleware)
+		apiRouter := router.Group("/v1")
+		apiRouter.GET("/forecast", handler.GetForecast)
+
+		req, _ := http.NewRequest("GET", "/v1/forecast?"+test.query, nil)
+		req.Header.Add(idpauth.AUTH_HEADER, userToken)
+		router.ServeHTTP(w, req)
+
+		bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"` + test.detail + `"}`
+		assert.Equal(t, bodyExpected, w.Body.String())
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Nil(t, _readingProcessMock.searchCtx)
+	}
+}
+
+func TestGetForecastFail(t *testing.T) {
+	handler := NewHandler(&readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/forecast", handler.GetForecast)
+
+	req, _ := http.NewRequest("GET", "/v1/forecast?from=2024-03-01&to=2024-05-31&granularity=week", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"An unexpected error has occurred, try again later"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
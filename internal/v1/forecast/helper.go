@@ -0,0 +1,41 @@
+package forecast
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/forecast/fcservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+func validateAndGetSearchParams(c *gin.Context) (*fcservice.SearchParams, error) {
+	granularity := c.DefaultQuery("granularity", fcservice.GRANULARITY_MONTH)
+	currency := strings.ToUpper(c.Query("currency"))
+
+	from, err := time.Parse(time.DateOnly, c.Query("from"))
+	if err != nil {
+		return nil, apperror.Validation(fmt.Sprintf("A param from %s is invalid", c.Query("from")))
+	}
+	to, err := time.Parse(time.DateOnly, c.Query("to"))
+	if err != nil {
+		return nil, apperror.Validation(fmt.Sprintf("A param to %s is invalid", c.Query("to")))
+	}
+	if to.Before(from) {
+		return nil, apperror.Validation("The param to must not be before the param from")
+	}
+	if granularity != fcservice.GRANULARITY_DAY && granularity != fcservice.GRANULARITY_WEEK && granularity != fcservice.GRANULARITY_MONTH {
+		return nil, apperror.Validation(fmt.Sprintf("A param granularity %s is invalid", granularity))
+	}
+	if currency != "" && !validation.IsCurrency(currency) {
+		return nil, apperror.Validation(fmt.Sprintf("A param currency %s is invalid", currency))
+	}
+	return fcservice.NewSearchParamsBuilder().
+		AddFrom(from).
+		AddTo(to).
+		AddGranularity(granularity).
+		AddCurrency(currency).
+		Build(), nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type Repository interface {
+	GetBalanceBefore(ctx context.Context, userId string, date time.Time) (*[]CurrencyBalance, error)
+	GetEntries(ctx context.Context, userId string, from time.Time, to time.Time) (*[]Entry, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetBalanceBefore returns, by currency, the balance of the realized records and of the pending projections dated before the date
+func (r *repository) GetBalanceBefore(ctx context.Context, userId string, date time.Time) (*[]CurrencyBalance, error) {
+	query := `
+		SELECT
+			entry.currency,
+			SUM(entry.value)
+		FROM
+			(
+				SELECT currency, value FROM gain
+				WHERE user_id = ? AND deleted_at IS NULL AND pay_in < ?
+				UNION ALL
+				SELECT currency, -value FROM invoice
+				WHERE user_id = ? AND deleted_at IS NULL AND pay_at < ?
+				UNION ALL
+				SELECT currency, value FROM gain_projection
+				WHERE user_id = ? AND deleted_at IS NULL AND is_already_done = false AND pay_in < ?
+				UNION ALL
+				SELECT currency, -value FROM invoice_projection
+				WHERE user_id = ? AND deleted_at IS NULL AND is_already_done = false AND pay_in < ?
+			) AS entry
+		GROUP BY entry.currency
+		ORDER BY entry.currency`
+	rows, err := r.db.QueryContext(ctx, query, userId, date, userId, date, userId, date, userId, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balanceList := []CurrencyBalance{}
+	for rows.Next() {
+		var balance CurrencyBalance
+		err := rows.Scan(&balance.Currency, &balance.Value)
+		if err != nil {
+			return nil, err
+		}
+		balanceList = append(balanceList, balance)
+	}
+	return &balanceList, nil
+}
+
+// GetEntries returns the realized records and the pending projections dated in the interval, in the order of the date
+func (r *repository) GetEntries(ctx context.Context, userId string, from time.Time, to time.Time) (*[]Entry, error) {
+	query := `
+		SELECT 'gain' AS entity, false AS projected, pay_in AS date, value, currency FROM gain
+		WHERE user_id = ? AND deleted_at IS NULL AND pay_in BETWEEN ? AND ?
+		UNION ALL
+		SELECT 'invoice', false, pay_at, value, currency FROM invoice
+		WHERE user_id = ? AND deleted_at IS NULL AND pay_at BETWEEN ? AND ?
+		UNION ALL
+		SELECT 'gain', true, pay_in, value, currency FROM gain_projection
+		WHERE user_id = ? AND deleted_at IS NULL AND is_already_done = false AND pay_in BETWEEN ? AND ?
+		UNION ALL
+		SELECT 'invoice', true, pay_in, value, currency FROM invoice_projection
+		WHERE user_id = ? AND deleted_at IS NULL AND is_already_done = false AND pay_in BETWEEN ? AND ?
+		ORDER BY date`
+	rows, err := r.db.QueryContext(ctx, query,
+		userId, from, to,
+		userId, from, to,
+		userId, from, to,
+		userId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entryList := []Entry{}
+	for rows.Next() {
+		var entry Entry
+		err := rows.Scan(&entry.Entity, &entry.Projected, &entry.Date, &entry.Value, &entry.Currency)
+		if err != nil {
+			return nil, err
+		}
+		entryList = append(entryList, entry)
+	}
+	return &entryList, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getBalanceBeforeQuery = `
+		SELECT
+			entry.currency,
+			SUM(entry.value)
+		FROM
+			(
+				SELECT currency, value FROM gain
+				WHERE user_id = ? AND deleted_at IS NULL AND pay_in < ?
+				UNION ALL
+				SELECT currency, -value FROM invoice
+				WHERE user_id = ? AND deleted_at IS NULL AND pay_at < ?
+				UNION ALL
+				SELECT currency, value FROM gain_projection
+				WHERE user_id = ? AND deleted_at IS NULL AND is_already_done = false AND pay_in < ?
+				UNION ALL
+				SELECT currency, -value FROM invoice_projection
+				WHERE user_id = ? AND deleted_at IS NULL AND is_already_done = false AND pay_in < ?
+			) AS entry
+		GROUP BY entry.currency
+		ORDER BY entry.currency`
+
+func TestGetBalanceBeforeSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows([]string{"currency", "SUM(entry.value)"}).
+		AddRow("BRL", "1520.75").
+		AddRow("USD", "-30.00")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getBalanceBeforeQuery).
+		WithArgs("User1", date, "User1", date, "User1", date, "User1", date).
+		WillReturnRows(rowsMock)
+
+	balanceList, err := _repository.GetBalanceBefore(context.Background(), "User1", date)
+	assert.NoError(t, err)
+	assert.Equal(t, []CurrencyBalance{
+		{Currency: "BRL", Value: money.FromCents(152075)},
+		{Currency: "USD", Value: money.FromCents(-3000)},
+	}, *balanceList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetBalanceBeforeScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows([]string{"currency", "SUM(entry.value)"}).
+		AddRow("BRL", "a lot")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getBalanceBeforeQuery).
+		WithArgs("User1", date, "User1", date, "User1", date, "User1", date).
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetBalanceBefore(context.Background(), "User1", date)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetBalanceBeforeQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getBalanceBeforeQuery).
+		WithArgs("User1", date, "User1", date, "User1", date, "User1", date).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetBalanceBefore(context.Background(), "User1", date)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
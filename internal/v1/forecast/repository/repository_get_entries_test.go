@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getEntriesQuery = `
+		SELECT 'gain' AS entity, false AS projected, pay_in AS date, value, currency FROM gain
+		WHERE user_id = ? AND deleted_at IS NULL AND pay_in BETWEEN ? AND ?
+		UNION ALL
+		SELECT 'invoice', false, pay_at, value, currency FROM invoice
+		WHERE user_id = ? AND deleted_at IS NULL AND pay_at BETWEEN ? AND ?
+		UNION ALL
+		SELECT 'gain', true, pay_in, value, currency FROM gain_projection
+		WHERE user_id = ? AND deleted_at IS NULL AND is_already_done = false AND pay_in BETWEEN ? AND ?
+		UNION ALL
+		SELECT 'invoice', true, pay_in, value, currency FROM invoice_projection
+		WHERE user_id = ? AND deleted_at IS NULL AND is_already_done = false AND pay_in BETWEEN ? AND ?
+		ORDER BY date`
+
+func TestGetEntriesSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows([]string{"entity", "projected", "date", "value", "currency"}).
+		AddRow("invoice", false, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), "750.50", "BRL").
+		AddRow("gain", true, time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC), "3000.00", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getEntriesQuery).
+		WithArgs("User1", from, to, "User1", from, to, "User1", from, to, "User1", from, to).
+		WillReturnRows(rowsMock)
+
+	entryList, err := _repository.GetEntries(context.Background(), "User1", from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Entity: ENTITY_INVOICE, Projected: false, Date: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), Value: money.FromCents(75050), Currency: "BRL"},
+		{Entity: ENTITY_GAIN, Projected: true, Date: time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC), Value: money.FromCents(300000), Currency: "BRL"},
+	}, *entryList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetEntriesScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows([]string{"entity", "projected", "date", "value", "currency"}).
+		AddRow("invoice", false, "yesterday", "750.50", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getEntriesQuery).
+		WithArgs("User1", from, to, "User1", from, to, "User1", from, to, "User1", from, to).
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetEntries(context.Background(), "User1", from, to)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetEntriesQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getEntriesQuery).
+		WithArgs("User1", from, to, "User1", from, to, "User1", from, to, "User1", from, to).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetEntries(context.Background(), "User1", from, to)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
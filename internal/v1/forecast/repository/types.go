@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const ENTITY_GAIN = "gain"
+const ENTITY_INVOICE = "invoice"
+
+// CurrencyBalance is the sum of the gains minus the invoices kept in a currency
+type CurrencyBalance struct {
+	Currency string
+	Value    money.Money
+}
+
+// Entry is a gain or an invoice that moves the balance on its date, either realized or still projected
+type Entry struct {
+	Entity    string
+	Projected bool
+	Date      time.Time
+	Value     money.Money
+	Currency  string
+}
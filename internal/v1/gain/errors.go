@@ -1,9 +0,0 @@
-package gain
-
-type InvalidArgs struct {
-	message string
-}
-
-func (invalidArgs *InvalidArgs) Error() string {
-	return invalidArgs.message
-}
@@ -0,0 +1,175 @@
+package gservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+// gainBatch keeps the per-item results of a batch operation and decides how the
+// valid items are persisted according to the batch mode
+type gainBatch struct {
+	mode    string
+	results []GainBatchItemResult
+	ids     map[string]bool
+}
+
+func newGainBatch(mode string, size int) *gainBatch {
+	if mode == "" {
+		mode = BATCH_MODE_ATOMIC
+	}
+	results := make([]GainBatchItemResult, size)
+	for index := range results {
+		results[index].Index = index
+	}
+	return &gainBatch{mode: mode, results: results, ids: map[string]bool{}}
+}
+
+func (batch *gainBatch) checkId(id string) *BatchItemError {
+	if id == "" {
+		return &BatchItemError{Code: BATCH_ERROR_INVALID_ID, Message: "The id must be informed"}
+	}
+	if batch.ids[id] {
+		return &BatchItemError{Code: BATCH_ERROR_DUPLICATED_ID, Message: "The id is repeated in the batch"}
+	}
+	batch.ids[id] = true
+	return nil
+}
+
+// checkVersion compares the version informed by the client with the stored one of the gain
+func checkVersion(informed uint, stored uint) *BatchItemError {
+	if informed == 0 {
+		return &BatchItemError{Code: BATCH_ERROR_INVALID_VERSION, Message: "The version must be informed"}
+	}
+	if informed != stored {
+		return versionConflict()
+	}
+	return nil
+}
+
+func versionConflict() *BatchItemError {
+	return &BatchItemError{Code: BATCH_ERROR_VERSION_CONFLICT, Message: "The gain was modified by another request"}
+}
+
+func (batch *gainBatch) fail(index int, itemErr *BatchItemError) {
+	batch.results[index].Status = BATCH_STATUS_FAILED
+	batch.results[index].Error = itemErr
+}
+
+func (batch *gainBatch) succeed(index int, status string, gain *GainResponse) {
+	batch.results[index].Status = status
+	batch.results[index].Gain = gain
+}
+
+func (batch *gainBatch) hasFailures() bool {
+	for _, result := range batch.results {
+		if result.Status == BATCH_STATUS_FAILED {
+			return true
+		}
+	}
+	return false
+}
+
+// persist stores the pending items. In the atomic mode nothing is stored when any item
+// is invalid and the valid ones are flagged as skipped; otherwise every pending item is
+// stored in one transaction, which is undone when an item was changed after the version
+// informed by the client. In the partial mode each item is stored on its own and a
+// storage error only fails that item, the cause is logged and not exposed to the client.
+func (batch *gainBatch) persist(pending []int, persistAll func() error, persistOne func(position int) error) error {
+	if len(pending) == 0 {
+		return nil
+	}
+	if batch.mode == BATCH_MODE_ATOMIC {
+		if batch.hasFailures() {
+			batch.skip(pending)
+			return nil
+		}
+		err := persistAll()
+		var conflict *repository.BatchConflictError
+		if errors.As(err, &conflict) {
+			batch.fail(pending[conflict.Position], versionConflict())
+			batch.skip(pending)
+			return nil
+		}
+		return err
+	}
+	for position, index := range pending {
+		err := persistOne(position)
+		var conflict *repository.BatchConflictError
+		if errors.Is(err, ErrVersionConflict) || errors.As(err, &conflict) {
+			batch.fail(index, versionConflict())
+			continue
+		}
+		if err != nil {
+			log.Println("Batch item storage failed:", err)
+			batch.fail(index, &BatchItemError{Code: BATCH_ERROR_STORAGE, Message: "The item could not be saved"})
+		}
+	}
+	return nil
+}
+
+// skip flags the pending items that were not failed as skipped
+func (batch *gainBatch) skip(pending []int) {
+	for _, index := range pending {
+		if batch.results[index].Status == "" {
+			batch.results[index].Status = BATCH_STATUS_SKIPPED
+		}
+	}
+}
+
+func (batch *gainBatch) response() *GainBatchResponse {
+	response := &GainBatchResponse{Mode: batch.mode, Results: batch.results}
+	for _, result := range batch.results {
+		switch result.Status {
+		case BATCH_STATUS_CREATED, BATCH_STATUS_UPDATED, BATCH_STATUS_DELETED:
+			response.Succeeded++
+		case BATCH_STATUS_FAILED:
+			response.Failed++
+		}
+	}
+	return response
+}
+
+func validateGainValues(payIn time.Time, description string, value money.Money, currency string, categoryId uint) *BatchItemError {
+	if payIn.IsZero() {
+		return &BatchItemError{Code: BATCH_ERROR_INVALID_PAY_IN, Message: "The pay_in must be informed"}
+	}
+	if strings.TrimSpace(description) == "" {
+		return &BatchItemError{Code: BATCH_ERROR_INVALID_DESCRIPTION, Message: "The description must be informed"}
+	}
+	if utf8.RuneCountInString(description) > 255 {
+		return &BatchItemError{Code: BATCH_ERROR_INVALID_DESCRIPTION, Message: "The description must have at most 255 characters"}
+	}
+	if value <= 0 {
+		return &BatchItemError{Code: BATCH_ERROR_INVALID_VALUE, Message: "The value must be greater than zero"}
+	}
+	if currency != "" && !validation.IsCurrency(currency) {
+		return &BatchItemError{Code: BATCH_ERROR_INVALID_CURRENCY, Message: "The currency must be an ISO 4217 code"}
+	}
+	if categoryId == 0 {
+		return &BatchItemError{Code: BATCH_ERROR_INVALID_CATEGORY, Message: "The category_id must be informed"}
+	}
+	return nil
+}
+
+// validateItem checks the values of a batch item, the same rules of the binding tags of a single gain, and that
+// the category it references is registered
+func (sp *storageProcess) validateItem(ctx context.Context, payIn time.Time, description string, value money.Money, currency string, categoryId uint) (*BatchItemError, error) {
+	itemErr := validateGainValues(payIn, description, value, currency, categoryId)
+	if itemErr != nil {
+		return itemErr, nil
+	}
+	err := sp.checkReferences(ctx, categoryId)
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return nil, err
+	}
+	return &BatchItemError{Code: BATCH_ERROR_INVALID_CATEGORY, Message: errs.Error()}, nil
+}
@@ -1,10 +1,15 @@
 package gservice
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type SearchParamsBuilder struct {
 	month    *uint
 	year     *uint
+	currency string
 	page     *uint
 	pagesize *uint
 }
@@ -21,6 +26,10 @@ func (builder *SearchParamsBuilder) AddYear(year uint) *SearchParamsBuilder {
 	builder.year = &year
 	return builder
 }
+func (builder *SearchParamsBuilder) AddCurrency(currency string) *SearchParamsBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *SearchParamsBuilder) AddPage(page uint) *SearchParamsBuilder {
 	builder.page = &page
 	return builder
@@ -31,8 +40,9 @@ func (builder *SearchParamsBuilder) AddPageSize(pagesize uint) *SearchParamsBuil
 }
 func (builder *SearchParamsBuilder) Build() *SearchParams {
 	return &SearchParams{
-		month: builder.month,
-		year:  builder.year,
+		month:    builder.month,
+		year:     builder.year,
+		currency: builder.currency,
 		paginate: &Paginate{
 			page:     builder.page,
 			pagesize: builder.pagesize,
@@ -44,10 +54,13 @@ type GainResponseBuilder struct {
 	id               string
 	payIn            time.Time
 	description      string
-	value            float64
+	value            money.Money
+	currency         string
 	isPassive        bool
 	gainProjectionId string
 	category         CategoryResponse
+	deletedAt        *time.Time
+	version          uint
 }
 
 func NewGainResponseBuilder() *GainResponseBuilder {
@@ -65,10 +78,14 @@ func (builder *GainResponseBuilder) AddDescription(description string) *GainResp
 	builder.description = description
 	return builder
 }
-func (builder *GainResponseBuilder) AddValue(value float64) *GainResponseBuilder {
+func (builder *GainResponseBuilder) AddValue(value money.Money) *GainResponseBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *GainResponseBuilder) AddCurrency(currency string) *GainResponseBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *GainResponseBuilder) AddIsPassive(isPassive bool) *GainResponseBuilder {
 	builder.isPassive = isPassive
 	return builder
@@ -81,16 +98,27 @@ func (builder *GainResponseBuilder) AddCategory(category CategoryResponse) *Gain
 	builder.category = category
 	return builder
 }
+func (builder *GainResponseBuilder) AddDeletedAt(deletedAt time.Time) *GainResponseBuilder {
+	builder.deletedAt = &deletedAt
+	return builder
+}
+func (builder *GainResponseBuilder) AddVersion(version uint) *GainResponseBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *GainResponseBuilder) Build() *GainResponse {
 	gainResponse := GainResponse{}
 
 	gainResponse.Id = builder.id
 	gainResponse.Description = builder.description
 	gainResponse.Value = builder.value
+	gainResponse.Currency = builder.currency
 	gainResponse.PayIn = builder.payIn
 	gainResponse.IsPassive = builder.isPassive
 	gainResponse.GainProjectionId = builder.gainProjectionId
 	gainResponse.Category = builder.category
+	gainResponse.DeletedAt = builder.deletedAt
+	gainResponse.Version = builder.version
 
 	return &gainResponse
 }
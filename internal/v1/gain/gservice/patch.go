@@ -0,0 +1,48 @@
+package gservice
+
+import (
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+// apply copies only the fields informed in the request over the stored gain
+func (request PatchRequest) apply(gain *repository.Gain) {
+	if request.PayIn != nil {
+		gain.PayIn = *request.PayIn
+	}
+	if request.Description != nil {
+		gain.Description = *request.Description
+	}
+	if request.Value != nil {
+		gain.Value = *request.Value
+	}
+	if request.Currency != nil {
+		gain.Currency = money.NormalizeCurrency(*request.Currency)
+	}
+	if request.IsPassive != nil {
+		gain.IsPassive = *request.IsPassive
+	}
+	if request.CategoryId != nil {
+		gain.Category = repository.GainCategory{Id: *request.CategoryId}
+	}
+}
+
+func validatePatchedGain(gain repository.Gain) error {
+	errs := validation.Errors{}
+	if strings.TrimSpace(gain.Description) == "" {
+		errs = append(errs, validation.Required("description"))
+	}
+	if gain.Value <= 0 {
+		errs = append(errs, validation.FieldError{Field: "value", Code: validation.CODE_TOO_SMALL, Message: "The value must be greater than 0"})
+	}
+	if gain.Category.Id == 0 {
+		errs = append(errs, validation.Required("category_id"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
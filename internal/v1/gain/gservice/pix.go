@@ -0,0 +1,59 @@
+package gservice
+
+import (
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/pix"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+// CreateFromPix registers the gain received through a PIX copia e cola, whose merchant is the payer
+func (sp *storageProcess) CreateFromPix(createFromPixCtx CreateFromPixContext) (*GainResponse, error) {
+	request := createFromPixCtx.Request
+	payment, err := pix.Parse(request.Code)
+	if err != nil {
+		return nil, validation.Errors{{Field: "code", Code: validation.CODE_INVALID, Message: "The code is not valid, " + err.Error()}}
+	}
+	createRequest := CreateRequest{
+		PayIn:        request.PayIn,
+		Description:  strings.TrimSpace(request.Description),
+		Value:        request.Value,
+		Currency:     money.CURRENCY_DEFAULT,
+		IsPassive:    request.IsPassive,
+		CategoryId:   request.CategoryId,
+		AutoCategory: request.AutoCategory,
+	}
+	if createRequest.Description == "" {
+		createRequest.Description = payment.MerchantName
+	}
+	if createRequest.Value == 0 {
+		createRequest.Value = payment.Amount
+	}
+	err = validatePixRequest(createRequest)
+	if err != nil {
+		return nil, err
+	}
+	return sp.Create(CreateContext{
+		Ctx:          createFromPixCtx.Ctx,
+		Request:      createRequest,
+		MerchantName: payment.MerchantName,
+		UserToken:    createFromPixCtx.UserToken,
+	})
+}
+
+// validatePixRequest requires the fields that neither the PIX nor the request informed,
+// the category is left to the category rules applied by Create
+func validatePixRequest(request CreateRequest) error {
+	errs := validation.Errors{}
+	if request.Description == "" {
+		errs = append(errs, validation.Required("description"))
+	}
+	if request.Value <= 0 {
+		errs = append(errs, validation.Required("value"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
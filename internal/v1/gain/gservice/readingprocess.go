@@ -1,21 +1,25 @@
 package gservice
 
 import (
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
 )
 
 type ReadingProcess interface {
 	GetById(searchCtx SearchContext) (*GainResponse, error)
 	GetAllPaginated(searchCtx SearchContext) (*GainPaginateResponse, error)
+	GetTrash(searchCtx SearchContext) (*GainTrashResponse, error)
 }
 
 type readingProcess struct {
 	repository repository.Repository
+	converter  erservice.Converter
 }
 
-func NewReadingProcess(repository repository.Repository) ReadingProcess {
-	return &readingProcess{repository: repository}
+func NewReadingProcess(repository repository.Repository, converter erservice.Converter) ReadingProcess {
+	return &readingProcess{repository: repository, converter: converter}
 }
 
 func (rp *readingProcess) GetById(searchCtx SearchContext) (*GainResponse, error) {
@@ -25,7 +29,7 @@ func (rp *readingProcess) GetById(searchCtx SearchContext) (*GainResponse, error
 		return nil, err
 	}
 	if gain == nil {
-		return nil, nil
+		return nil, apperror.NotFound("Gain not found")
 	}
 
 	return NewGainResponseBuilder().
@@ -33,9 +37,11 @@ func (rp *readingProcess) GetById(searchCtx SearchContext) (*GainResponse, error
 		AddPayIn(gain.PayIn).
 		AddDescription(gain.Description).
 		AddValue(gain.Value).
+		AddCurrency(gain.Currency).
 		AddIsPassive(gain.IsPassive).
 		AddGainProjectionId(gain.GainProjectionId).
 		AddCategory(CategoryResponse{Id: gain.Category.Id, Category: gain.Category.Category}).
+		AddVersion(gain.Version).
 		Build(), nil
 }
 
@@ -86,15 +92,57 @@ func (rp *readingProcess) GetAllPaginated(searchCtx SearchContext) (*GainPaginat
 			AddIsPassive(gain.IsPassive).
 			AddPayIn(gain.PayIn).
 			AddValue(gain.Value).
+			AddCurrency(gain.Currency).
 			Build()
+		if search.currency != "" {
+			convertedValue, err := rp.converter.Convert(erservice.ConvertContext{
+				Ctx:    searchCtx.Ctx,
+				UserId: user.Id,
+				Amount: gain.Value,
+				From:   gain.Currency,
+				To:     search.currency,
+				Date:   gain.PayIn,
+			})
+			if err != nil {
+				return nil, err
+			}
+			GainResponse.ConvertedValue = &convertedValue
+		}
 		gainResponseList = append(gainResponseList, *GainResponse)
 	}
 
 	return &GainPaginateResponse{
-		CurrentPage:  *search.paginate.page,
-		PageLimit:    *search.paginate.pagesize,
-		TotalRecords: *totalRecords,
-		TotalPages:   totalPages,
-		Records:      gainResponseList,
+		CurrentPage:       *search.paginate.page,
+		PageLimit:         *search.paginate.pagesize,
+		TotalRecords:      *totalRecords,
+		TotalPages:        totalPages,
+		ConvertedCurrency: search.currency,
+		Records:           gainResponseList,
 	}, nil
 }
+
+func (rp *readingProcess) GetTrash(searchCtx SearchContext) (*GainTrashResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	gainList, err := rp.repository.GetTrash(searchCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	gainResponseList := []GainResponse{}
+	for _, gain := range *gainList {
+		gainResponse := NewGainResponseBuilder().
+			AddId(gain.Id).
+			AddPayIn(gain.PayIn).
+			AddDescription(gain.Description).
+			AddValue(gain.Value).
+			AddCurrency(gain.Currency).
+			AddIsPassive(gain.IsPassive).
+			AddGainProjectionId(gain.GainProjectionId).
+			AddCategory(CategoryResponse{Id: gain.Category.Id, Category: gain.Category.Category}).
+			AddDeletedAt(gain.DeletedAt).
+			Build()
+		gainResponseList = append(gainResponseList, *gainResponse)
+	}
+
+	return &GainTrashResponse{Records: gainResponseList}, nil
+}
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,7 +21,7 @@ func TestGetAllPaginatedSuccess(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
@@ -32,7 +34,7 @@ func TestGetAllPaginatedSuccess(t *testing.T) {
 	})
 
 	ctx := context.TODO()
-	_readingProcess := NewReadingProcess(_mockRepository)
+	_readingProcess := NewReadingProcess(_mockRepository, nil)
 
 	searchParams := NewSearchParamsBuilder().
 		AddMonth(10).
@@ -60,7 +62,7 @@ func TestGetAllPaginatedGetTotalRecordsFail(t *testing.T) {
 	})
 
 	ctx := context.TODO()
-	_readingProcess := NewReadingProcess(_mockRepository)
+	_readingProcess := NewReadingProcess(_mockRepository, nil)
 
 	searchParams := NewSearchParamsBuilder().
 		AddMonth(10).
@@ -90,7 +92,7 @@ func TestGetAllPaginatedGetAllFail(t *testing.T) {
 	})
 
 	ctx := context.TODO()
-	_readingProcess := NewReadingProcess(_mockRepository)
+	_readingProcess := NewReadingProcess(_mockRepository, nil)
 
 	searchParams := NewSearchParamsBuilder().
 		AddMonth(10).
@@ -108,3 +110,62 @@ func TestGetAllPaginatedGetAllFail(t *testing.T) {
 	_, err := _readingProcess.GetAllPaginated(searchCtx)
 	assert.Error(t, err)
 }
+
+type mockConverter struct {
+	err error
+}
+
+func (cv *mockConverter) Convert(convertCtx erservice.ConvertContext) (money.Money, error) {
+	if cv.err != nil {
+		return 0, cv.err
+	}
+	return convertCtx.Amount * 5, nil
+}
+
+func getAllPaginatedConverted(converter erservice.Converter) (*GainPaginateResponse, error) {
+	payIn := time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC)
+	gainMock := repository.NewGainBuilder().
+		AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddPayIn(payIn).
+		AddIsPassive(true).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(10000)).
+		AddCurrency("USD").
+		AddUserId("User1").
+		Build()
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetTotalRecordsCalls(func(ctx context.Context, params repository.QueryParams) (*uint, error) {
+		totalRecords := uint(1)
+		return &totalRecords, nil
+	})
+	_mockRepository.AddGetAllCalls(func(ctx context.Context, params repository.QueryParams) (*[]repository.Gain, error) {
+		return &[]repository.Gain{*gainMock}, nil
+	})
+
+	_readingProcess := NewReadingProcess(_mockRepository, converter)
+	searchParams := NewSearchParamsBuilder().
+		AddMonth(10).
+		AddYear(2023).
+		AddCurrency("BRL").
+		AddPage(1).
+		AddPageSize(10).
+		Build()
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	return _readingProcess.GetAllPaginated(SearchContext{Params: *searchParams, UserToken: token, Ctx: context.TODO()})
+}
+
+func TestGetAllPaginatedConvertedSuccess(t *testing.T) {
+	response, err := getAllPaginatedConverted(&mockConverter{})
+	assert.NoError(t, err)
+	assert.Equal(t, "BRL", response.ConvertedCurrency)
+	assert.Equal(t, "USD", response.Records[0].Currency)
+	assert.Equal(t, money.FromCents(10000), response.Records[0].Value)
+	assert.Equal(t, money.FromCents(50000), *response.Records[0].ConvertedValue)
+}
+
+func TestGetAllPaginatedConvertFail(t *testing.T) {
+	response, err := getAllPaginatedConverted(&mockConverter{err: errors.New("There is no exchange rate")})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
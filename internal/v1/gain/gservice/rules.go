@@ -0,0 +1,145 @@
+package gservice
+
+import (
+	"context"
+
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
+)
+
+// applyRules fills the category and the passive flag of the request from the category rules of the user,
+// the category informed in the request takes precedence. It returns the label chosen by the rules.
+func (sp *storageProcess) applyRules(ctx context.Context, userId string, request *CreateRequest, merchantName string) (string, error) {
+	categorization, err := sp.categorizer.Categorize(crservice.CategorizeContext{
+		Ctx:          ctx,
+		UserId:       userId,
+		Entity:       crservice.ENTITY_GAIN,
+		Description:  request.Description,
+		MerchantName: merchantName,
+		Value:        request.Value,
+	})
+	if err != nil || categorization == nil {
+		return "", err
+	}
+	if request.CategoryId == 0 && categorization.CategoryId != nil {
+		request.CategoryId = *categorization.CategoryId
+	}
+	if !request.IsPassive && categorization.IsPassive != nil {
+		request.IsPassive = *categorization.IsPassive
+	}
+	return categorization.Label, nil
+}
+
+func (sp *storageProcess) label(ctx context.Context, userId string, gainId string, label string) error {
+	if label == "" {
+		return nil
+	}
+	return sp.categorizer.Label(crservice.LabelContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Entity:   crservice.ENTITY_GAIN,
+		EntityId: gainId,
+		Label:    label,
+	})
+}
+
+// ApplyRules runs the category rules over the gains of the month, in which the rules take precedence over
+// the stored category. The changed gains are edited in a single transaction unless it is a dry run.
+func (sp *storageProcess) ApplyRules(applyRulesCtx ApplyRulesContext) (*ApplyRulesResponse, error) {
+	request := applyRulesCtx.Request
+	user := idpauth.GetUser(applyRulesCtx.UserToken)
+	queryParams := repository.NewQueryParamsBuilder().
+		AddUserId(user.Id).
+		AddMonth(request.Month).
+		AddYear(request.Year).
+		Build()
+	totalRecords, err := sp.repository.GetTotalRecords(applyRulesCtx.Ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	gainList, err := sp.repository.GetAll(applyRulesCtx.Ctx, repository.NewQueryParamsBuilder().
+		AddUserId(user.Id).
+		AddMonth(request.Month).
+		AddYear(request.Year).
+		AddLimit(*totalRecords).
+		Build())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApplyRulesResponse{DryRun: request.DryRun, Results: []ApplyRulesResult{}}
+	originalGains := []repository.Gain{}
+	changedGains := []repository.Gain{}
+	for _, gain := range *gainList {
+		categorization, err := sp.categorizer.Categorize(crservice.CategorizeContext{
+			Ctx:         applyRulesCtx.Ctx,
+			UserId:      user.Id,
+			Entity:      crservice.ENTITY_GAIN,
+			Description: gain.Description,
+			Value:       gain.Value,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if categorization == nil {
+			continue
+		}
+		result := ApplyRulesResult{
+			Id:               gain.Id,
+			Description:      gain.Description,
+			CategoryIdBefore: gain.Category.Id,
+			CategoryIdAfter:  gain.Category.Id,
+			IsPassiveBefore:  gain.IsPassive,
+			IsPassiveAfter:   gain.IsPassive,
+			Label:            categorization.Label,
+		}
+		if categorization.CategoryId != nil {
+			result.CategoryIdAfter = *categorization.CategoryId
+		}
+		if categorization.IsPassive != nil {
+			result.IsPassiveAfter = *categorization.IsPassive
+		}
+		result.Changed = result.CategoryIdAfter != result.CategoryIdBefore || result.IsPassiveAfter != result.IsPassiveBefore
+		response.Matched++
+		if result.Changed {
+			response.Changed++
+			changedGain := gain
+			changedGain.UserId = user.Id
+			changedGain.Category = repository.GainCategory{Id: result.CategoryIdAfter}
+			changedGain.IsPassive = result.IsPassiveAfter
+			originalGains = append(originalGains, gain)
+			changedGains = append(changedGains, changedGain)
+		}
+		response.Results = append(response.Results, result)
+	}
+	if request.DryRun {
+		return response, nil
+	}
+
+	err = sp.transactor.Within(applyRulesCtx.Ctx, func(ctx context.Context) error {
+		if len(changedGains) > 0 {
+			err := sp.repository.EditAll(ctx, changedGains)
+			if err != nil {
+				return err
+			}
+		}
+		for index := range changedGains {
+			_, err := sp.recordUpdate(ctx, user.Id, sp.buildResponse(&originalGains[index]))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range response.Results {
+		err = sp.label(applyRulesCtx.Ctx, user.Id, result.Id, result.Label)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
@@ -1,64 +1,105 @@
 package gservice
 
 import (
+	"context"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/csservice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	uuid "github.com/satori/go.uuid"
 )
 
 type StorageProcess interface {
 	Create(createCtx CreateContext) (*GainResponse, error)
+	CreateFromPix(createFromPixCtx CreateFromPixContext) (*GainResponse, error)
 	Update(updateCtx UpdateContext) (*GainResponse, error)
-	Delete(searchCtx SearchContext) error
+	Patch(patchCtx PatchContext) (*GainResponse, error)
+	Delete(deleteCtx DeleteContext) error
+	Restore(searchCtx SearchContext) (*GainResponse, error)
+	CreateBatch(createBatchCtx CreateBatchContext) (*GainBatchResponse, error)
+	UpdateBatch(updateBatchCtx UpdateBatchContext) (*GainBatchResponse, error)
+	DeleteBatch(deleteBatchCtx DeleteBatchContext) (*GainBatchResponse, error)
+	ApplyRules(applyRulesCtx ApplyRulesContext) (*ApplyRulesResponse, error)
 }
 
 type storageProcess struct {
-	repository   repository.Repository
-	generateUUID func() uuid.UUID
+	repository       repository.Repository
+	generateUUID     func() uuid.UUID
+	auditProcess     aservice.StorageProcess
+	referenceChecker validation.ReferenceChecker
+	categorizer      crservice.Categorizer
+	suggester        csservice.Suggester
+	transactor       dbtx.Transactor
 }
 
-func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID) StorageProcess {
-	return &storageProcess{repository: repository, generateUUID: generateUUID}
+func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID, auditProcess aservice.StorageProcess, referenceChecker validation.ReferenceChecker, categorizer crservice.Categorizer, suggester csservice.Suggester, transactor dbtx.Transactor) StorageProcess {
+	return &storageProcess{repository: repository, generateUUID: generateUUID, auditProcess: auditProcess, referenceChecker: referenceChecker, categorizer: categorizer, suggester: suggester, transactor: transactor}
 }
 
 func (sp *storageProcess) Create(createCtx CreateContext) (*GainResponse, error) {
 	request := createCtx.Request
 	user := idpauth.GetUser(createCtx.UserToken)
-	createdAt := time.Now()
-	gainBuilder := repository.NewGainBuilder().
-		AddId(sp.generateUUID().String()).
-		AddCreatedAt(createdAt).
-		AddPayIn(request.PayIn).
-		AddIsPassive(request.IsPassive).
-		AddCategory(repository.GainCategory{Id: request.CategoryId}).
-		AddDescription(request.Description).
-		AddValue(request.Value).
-		AddUserId(user.Id)
-	if request.PayIn.IsZero() {
-		gainBuilder.AddPayIn(createdAt)
+	label, err := sp.applyRules(createCtx.Ctx, user.Id, &request, createCtx.MerchantName)
+	if err != nil {
+		return nil, err
 	}
-
-	gain := gainBuilder.Build()
-	gainSaved, err := sp.repository.Save(createCtx.Ctx, *gain)
+	err = sp.suggestCategory(createCtx.Ctx, user.Id, &request)
 	if err != nil {
 		return nil, err
 	}
-
-	gainSaved, err = sp.repository.GetById(createCtx.Ctx, gainSaved.Id, user.Id)
+	if request.CategoryId == 0 {
+		return nil, validation.Errors{validation.Required("category_id")}
+	}
+	err = sp.checkReferences(createCtx.Ctx, request.CategoryId)
 	if err != nil {
 		return nil, err
 	}
+	gain := sp.buildGain(request, time.Now(), user.Id)
+	var gainResponse *GainResponse
+	err = sp.transactor.Within(createCtx.Ctx, func(ctx context.Context) error {
+		gainSaved, err := sp.repository.Save(ctx, *gain)
+		if err != nil {
+			return err
+		}
 
-	return NewGainResponseBuilder().
-		AddId(gain.Id).
-		AddPayIn(gain.PayIn).
-		AddDescription(gain.Description).
-		AddValue(gain.Value).
-		AddIsPassive(gain.IsPassive).
-		AddCategory(CategoryResponse{Id: gainSaved.Category.Id, Category: gainSaved.Category.Category}).
-		Build(), nil
+		gainSaved, err = sp.repository.GetById(ctx, gainSaved.Id, user.Id)
+		if err != nil {
+			return err
+		}
+
+		gainResponse = NewGainResponseBuilder().
+			AddId(gain.Id).
+			AddPayIn(gain.PayIn).
+			AddDescription(gain.Description).
+			AddValue(gain.Value).
+			AddCurrency(gain.Currency).
+			AddIsPassive(gain.IsPassive).
+			AddCategory(CategoryResponse{Id: gainSaved.Category.Id, Category: gainSaved.Category.Category}).
+			Build()
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_CREATE,
+			Entity:   aservice.ENTITY_GAIN,
+			EntityId: gainResponse.Id,
+			After:    gainResponse,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = sp.label(createCtx.Ctx, user.Id, gainResponse.Id, label)
+	if err != nil {
+		return nil, err
+	}
+	return gainResponse, nil
 }
 
 func (sp *storageProcess) Update(updateCtx UpdateContext) (*GainResponse, error) {
@@ -70,36 +111,498 @@ func (sp *storageProcess) Update(updateCtx UpdateContext) (*GainResponse, error)
 		AddIsPassive(request.IsPassive).
 		AddCategory(repository.GainCategory{Id: request.CategoryId}).
 		AddDescription(request.Description).
-		AddValue(request.Value)
+		AddValue(request.Value).
+		AddCurrency(money.NormalizeCurrency(request.Currency))
 	gainExists, err := sp.repository.GetById(updateCtx.Ctx, updateCtx.Id, user.Id)
 	if err != nil {
 		return nil, err
 	}
 	if gainExists == nil {
-		return nil, nil
+		return nil, apperror.NotFound("Gain not found")
+	}
+	err = sp.checkReferences(updateCtx.Ctx, request.CategoryId)
+	if err != nil {
+		return nil, err
 	}
 	gainBuilder.AddUserId(user.Id)
-	gainUpdated, err := sp.repository.Edit(updateCtx.Ctx, *gainBuilder.Build())
+	gainBuilder.AddVersion(updateCtx.Version)
+	var gainResponse *GainResponse
+	err = sp.transactor.Within(updateCtx.Ctx, func(ctx context.Context) error {
+		gainUpdated, err := sp.repository.Edit(ctx, *gainBuilder.Build())
+		if err != nil {
+			return err
+		}
+
+		gainUpdated, err = sp.repository.GetById(ctx, gainUpdated.Id, user.Id)
+		if err != nil {
+			return err
+		}
+
+		gainResponse = NewGainResponseBuilder().
+			AddId(gainUpdated.Id).
+			AddPayIn(gainUpdated.PayIn).
+			AddDescription(gainUpdated.Description).
+			AddValue(gainUpdated.Value).
+			AddCurrency(gainUpdated.Currency).
+			AddIsPassive(gainUpdated.IsPassive).
+			AddCategory(CategoryResponse{Id: gainUpdated.Category.Id, Category: gainUpdated.Category.Category}).
+			AddVersion(gainUpdated.Version).
+			Build()
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_GAIN,
+			EntityId: gainResponse.Id,
+			Before:   sp.buildResponse(gainExists),
+			After:    gainResponse,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
+	return gainResponse, nil
+}
 
-	gainUpdated, err = sp.repository.GetById(updateCtx.Ctx, gainUpdated.Id, user.Id)
+func (sp *storageProcess) Patch(patchCtx PatchContext) (*GainResponse, error) {
+	user := idpauth.GetUser(patchCtx.UserToken)
+	gainExists, err := sp.repository.GetById(patchCtx.Ctx, patchCtx.Id, user.Id)
 	if err != nil {
 		return nil, err
 	}
+	if gainExists == nil {
+		return nil, apperror.NotFound("Gain not found")
+	}
+	gain := *gainExists
+	patchCtx.Request.apply(&gain)
+	err = validatePatchedGain(gain)
+	if err != nil {
+		return nil, err
+	}
+	err = sp.checkReferences(patchCtx.Ctx, gain.Category.Id)
+	if err != nil {
+		return nil, err
+	}
+	gain.UserId = user.Id
+	gain.Version = patchCtx.Version
+	var gainResponse *GainResponse
+	err = sp.transactor.Within(patchCtx.Ctx, func(ctx context.Context) error {
+		gainUpdated, err := sp.repository.Edit(ctx, gain)
+		if err != nil {
+			return err
+		}
 
-	return NewGainResponseBuilder().
-		AddId(gainUpdated.Id).
-		AddPayIn(gainUpdated.PayIn).
-		AddDescription(gainUpdated.Description).
-		AddValue(gainUpdated.Value).
-		AddIsPassive(gainUpdated.IsPassive).
-		AddCategory(CategoryResponse{Id: gainUpdated.Category.Id, Category: gainUpdated.Category.Category}).
-		Build(), nil
+		gainUpdated, err = sp.repository.GetById(ctx, gainUpdated.Id, user.Id)
+		if err != nil {
+			return err
+		}
+
+		gainResponse = sp.buildResponse(gainUpdated)
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_GAIN,
+			EntityId: gainResponse.Id,
+			Before:   sp.buildResponse(gainExists),
+			After:    gainResponse,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gainResponse, nil
 }
 
-func (sp *storageProcess) Delete(searchCtx SearchContext) error {
+func (sp *storageProcess) Delete(deleteCtx DeleteContext) error {
+	user := idpauth.GetUser(deleteCtx.UserToken)
+	gainExists, err := sp.repository.GetById(deleteCtx.Ctx, deleteCtx.Id, user.Id)
+	if err != nil {
+		return err
+	}
+	if gainExists == nil {
+		return apperror.NotFound("Gain not found")
+	}
+	return sp.transactor.Within(deleteCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.Remove(ctx, deleteCtx.Id, user.Id, deleteCtx.Version)
+		if err != nil {
+			return err
+		}
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_DELETE,
+			Entity:   aservice.ENTITY_GAIN,
+			EntityId: gainExists.Id,
+			Before:   sp.buildResponse(gainExists),
+		})
+	})
+}
+
+func (sp *storageProcess) Restore(searchCtx SearchContext) (*GainResponse, error) {
 	user := idpauth.GetUser(searchCtx.UserToken)
-	return sp.repository.Remove(searchCtx.Ctx, searchCtx.Id, user.Id)
+	var gainResponse *GainResponse
+	err := sp.transactor.Within(searchCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.Restore(ctx, searchCtx.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		gainRestored, err := sp.repository.GetById(ctx, searchCtx.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		if gainRestored == nil {
+			return apperror.NotFound("Gain not found")
+		}
+
+		gainResponse = sp.buildResponse(gainRestored)
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_RESTORE,
+			Entity:   aservice.ENTITY_GAIN,
+			EntityId: gainResponse.Id,
+			After:    gainResponse,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gainResponse, nil
+}
+
+func (sp *storageProcess) CreateBatch(createBatchCtx CreateBatchContext) (*GainBatchResponse, error) {
+	request := createBatchCtx.Request
+	user := idpauth.GetUser(createBatchCtx.UserToken)
+	createdAt := time.Now()
+	batch := newGainBatch(request.Mode, len(request.Items))
+
+	pending := []int{}
+	gains := []repository.Gain{}
+	labels := make([]string, len(request.Items))
+	for index, item := range request.Items {
+		label, err := sp.applyRules(createBatchCtx.Ctx, user.Id, &item, "")
+		if err != nil {
+			return nil, err
+		}
+		labels[index] = label
+		err = sp.suggestCategory(createBatchCtx.Ctx, user.Id, &item)
+		if err != nil {
+			return nil, err
+		}
+		itemErr, err := sp.validateItem(createBatchCtx.Ctx, item.PayIn, item.Description, item.Value, item.Currency, item.CategoryId)
+		if err != nil {
+			return nil, err
+		}
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		gain := sp.buildGain(item, createdAt, user.Id)
+		batch.results[index].Id = gain.Id
+		pending = append(pending, index)
+		gains = append(gains, *gain)
+	}
+	gainsCreated := make([]*GainResponse, len(gains))
+	err := batch.persist(pending,
+		func() error {
+			return sp.transactor.Within(createBatchCtx.Ctx, func(ctx context.Context) error {
+				err := sp.repository.SaveAll(ctx, gains)
+				if err != nil {
+					return err
+				}
+				for position := range gains {
+					gainsCreated[position], err = sp.recordCreation(ctx, user.Id, gains[position].Id)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		},
+		func(position int) error {
+			return sp.transactor.Within(createBatchCtx.Ctx, func(ctx context.Context) error {
+				_, err := sp.repository.Save(ctx, gains[position])
+				if err != nil {
+					return err
+				}
+				gainsCreated[position], err = sp.recordCreation(ctx, user.Id, gains[position].Id)
+				return err
+			})
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for position, index := range pending {
+		if batch.results[index].Status != "" {
+			continue
+		}
+		err = sp.label(createBatchCtx.Ctx, user.Id, gainsCreated[position].Id, labels[index])
+		if err != nil {
+			return nil, err
+		}
+		batch.succeed(index, BATCH_STATUS_CREATED, gainsCreated[position])
+	}
+	return batch.response(), nil
+}
+
+func (sp *storageProcess) UpdateBatch(updateBatchCtx UpdateBatchContext) (*GainBatchResponse, error) {
+	request := updateBatchCtx.Request
+	user := idpauth.GetUser(updateBatchCtx.UserToken)
+	batch := newGainBatch(request.Mode, len(request.Items))
+
+	pending := []int{}
+	gains := []repository.Gain{}
+	gainsBefore := []*GainResponse{}
+	for index, item := range request.Items {
+		batch.results[index].Id = item.Id
+		itemErr := batch.checkId(item.Id)
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		itemErr, err := sp.validateItem(updateBatchCtx.Ctx, item.PayIn, item.Description, item.Value, item.Currency, item.CategoryId)
+		if err != nil {
+			return nil, err
+		}
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		gainExists, err := sp.repository.GetById(updateBatchCtx.Ctx, item.Id, user.Id)
+		if err != nil {
+			return nil, err
+		}
+		if gainExists == nil {
+			batch.fail(index, &BatchItemError{Code: BATCH_ERROR_NOT_FOUND, Message: "Gain not found"})
+			continue
+		}
+		itemErr = checkVersion(item.Version, gainExists.Version)
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		gain := repository.NewGainBuilder().
+			AddId(item.Id).
+			AddPayIn(item.PayIn).
+			AddIsPassive(item.IsPassive).
+			AddCategory(repository.GainCategory{Id: item.CategoryId}).
+			AddDescription(item.Description).
+			AddValue(item.Value).
+			AddCurrency(money.NormalizeCurrency(item.Currency)).
+			AddUserId(user.Id).
+			AddVersion(item.Version).
+			Build()
+		pending = append(pending, index)
+		gains = append(gains, *gain)
+		gainsBefore = append(gainsBefore, sp.buildResponse(gainExists))
+	}
+	gainsUpdated := make([]*GainResponse, len(gains))
+	err := batch.persist(pending,
+		func() error {
+			return sp.transactor.Within(updateBatchCtx.Ctx, func(ctx context.Context) error {
+				err := sp.repository.EditAll(ctx, gains)
+				if err != nil {
+					return err
+				}
+				for position := range gains {
+					gainsUpdated[position], err = sp.recordUpdate(ctx, user.Id, gainsBefore[position])
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		},
+		func(position int) error {
+			return sp.transactor.Within(updateBatchCtx.Ctx, func(ctx context.Context) error {
+				_, err := sp.repository.Edit(ctx, gains[position])
+				if err != nil {
+					return err
+				}
+				gainsUpdated[position], err = sp.recordUpdate(ctx, user.Id, gainsBefore[position])
+				return err
+			})
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for position, index := range pending {
+		if batch.results[index].Status != "" {
+			continue
+		}
+		batch.succeed(index, BATCH_STATUS_UPDATED, gainsUpdated[position])
+	}
+	return batch.response(), nil
+}
+
+func (sp *storageProcess) DeleteBatch(deleteBatchCtx DeleteBatchContext) (*GainBatchResponse, error) {
+	request := deleteBatchCtx.Request
+	user := idpauth.GetUser(deleteBatchCtx.UserToken)
+	batch := newGainBatch(request.Mode, len(request.Items))
+
+	pending := []int{}
+	ids := []string{}
+	versions := []uint{}
+	gainsBefore := []*GainResponse{}
+	for index, item := range request.Items {
+		batch.results[index].Id = item.Id
+		itemErr := batch.checkId(item.Id)
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		gainExists, err := sp.repository.GetById(deleteBatchCtx.Ctx, item.Id, user.Id)
+		if err != nil {
+			return nil, err
+		}
+		if gainExists == nil {
+			batch.fail(index, &BatchItemError{Code: BATCH_ERROR_NOT_FOUND, Message: "Gain not found"})
+			continue
+		}
+		itemErr = checkVersion(item.Version, gainExists.Version)
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		pending = append(pending, index)
+		ids = append(ids, item.Id)
+		versions = append(versions, item.Version)
+		gainsBefore = append(gainsBefore, sp.buildResponse(gainExists))
+	}
+	err := batch.persist(pending,
+		func() error {
+			return sp.transactor.Within(deleteBatchCtx.Ctx, func(ctx context.Context) error {
+				err := sp.repository.RemoveAll(ctx, ids, versions, user.Id)
+				if err != nil {
+					return err
+				}
+				for position := range ids {
+					err = sp.recordDeletion(ctx, user.Id, gainsBefore[position])
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		},
+		func(position int) error {
+			return sp.transactor.Within(deleteBatchCtx.Ctx, func(ctx context.Context) error {
+				err := sp.repository.Remove(ctx, ids[position], user.Id, versions[position])
+				if err != nil {
+					return err
+				}
+				return sp.recordDeletion(ctx, user.Id, gainsBefore[position])
+			})
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range pending {
+		if batch.results[index].Status != "" {
+			continue
+		}
+		batch.succeed(index, BATCH_STATUS_DELETED, nil)
+	}
+	return batch.response(), nil
+}
+
+// recordCreation reads the gain stored in the transaction of the context and records its creation
+func (sp *storageProcess) recordCreation(ctx context.Context, userId string, id string) (*GainResponse, error) {
+	gainSaved, err := sp.repository.GetById(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	gainResponse := sp.buildResponse(gainSaved)
+	err = sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_CREATE,
+		Entity:   aservice.ENTITY_GAIN,
+		EntityId: gainResponse.Id,
+		After:    gainResponse,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gainResponse, nil
+}
+
+// recordUpdate reads the gain edited in the transaction of the context and records its update
+func (sp *storageProcess) recordUpdate(ctx context.Context, userId string, gainBefore *GainResponse) (*GainResponse, error) {
+	gainUpdated, err := sp.repository.GetById(ctx, gainBefore.Id, userId)
+	if err != nil {
+		return nil, err
+	}
+	gainResponse := sp.buildResponse(gainUpdated)
+	err = sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_UPDATE,
+		Entity:   aservice.ENTITY_GAIN,
+		EntityId: gainResponse.Id,
+		Before:   gainBefore,
+		After:    gainResponse,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gainResponse, nil
+}
+
+func (sp *storageProcess) recordDeletion(ctx context.Context, userId string, gainBefore *GainResponse) error {
+	return sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_DELETE,
+		Entity:   aservice.ENTITY_GAIN,
+		EntityId: gainBefore.Id,
+		Before:   gainBefore,
+	})
+}
+
+func (sp *storageProcess) buildGain(request CreateRequest, createdAt time.Time, userId string) *repository.Gain {
+	gainBuilder := repository.NewGainBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(createdAt).
+		AddPayIn(request.PayIn).
+		AddIsPassive(request.IsPassive).
+		AddCategory(repository.GainCategory{Id: request.CategoryId}).
+		AddDescription(request.Description).
+		AddValue(request.Value).
+		AddCurrency(money.NormalizeCurrency(request.Currency)).
+		AddUserId(userId)
+	if request.PayIn.IsZero() {
+		gainBuilder.AddPayIn(createdAt)
+	}
+	return gainBuilder.Build()
+}
+
+// checkReferences reports as a validation error the category that is not registered
+func (sp *storageProcess) checkReferences(ctx context.Context, categoryId uint) error {
+	categoryExists, err := sp.referenceChecker.GainCategoryExists(ctx, categoryId)
+	if err != nil {
+		return err
+	}
+	if !categoryExists {
+		return validation.Errors{validation.NotFound("category_id")}
+	}
+	return nil
+}
+
+func (sp *storageProcess) buildResponse(gain *repository.Gain) *GainResponse {
+	return NewGainResponseBuilder().
+		AddId(gain.Id).
+		AddPayIn(gain.PayIn).
+		AddDescription(gain.Description).
+		AddValue(gain.Value).
+		AddCurrency(gain.Currency).
+		AddIsPassive(gain.IsPassive).
+		AddGainProjectionId(gain.GainProjectionId).
+		AddCategory(CategoryResponse{Id: gain.Category.Id, Category: gain.Category.Category}).
+		AddVersion(gain.Version).
+		Build()
 }
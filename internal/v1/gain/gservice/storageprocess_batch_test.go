@@ -0,0 +1,508 @@
+package gservice
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildGainMock(id string) *repository.Gain {
+	return repository.NewGainBuilder().
+		AddId(id).
+		AddCreatedAt(time.Now()).
+		AddPayIn(time.Now()).
+		AddIsPassive(true).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		AddVersion(1).
+		Build()
+}
+
+func TestCreateBatchAtomicSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	var gainsSaved []repository.Gain
+	_mockRepository.AddSaveAllCall(func(ctx context.Context, gains []repository.Gain) error {
+		gainsSaved = gains
+		return nil
+	})
+	getByIdMock := func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return buildGainMock(id), nil
+	}
+	_mockRepository.AddGetByIdCall(getByIdMock).AddGetByIdCall(getByIdMock)
+
+	var actionsRecorded []string
+	recordMock := func(recordCtx aservice.RecordContext) error {
+		actionsRecorded = append(actionsRecorded, recordCtx.Action)
+		return nil
+	}
+	_mockAuditProcess := &mockAuditProcess{}
+	_mockAuditProcess.AddRecordCall(recordMock).AddRecordCall(recordMock)
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	createBatchCtx := CreateBatchContext{
+		Ctx: context.TODO(),
+		Request: CreateBatchRequest{
+			Items: []CreateRequest{
+				{PayIn: time.Now(), Description: "Salário", Value: money.FromCents(500000), CategoryId: 2},
+				{PayIn: time.Now(), Description: "Aluguel", Value: money.FromCents(150000), CategoryId: 3, IsPassive: true},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.CreateBatch(createBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, BATCH_MODE_ATOMIC, response.Mode)
+	assert.Equal(t, uint(2), response.Succeeded)
+	assert.Equal(t, uint(0), response.Failed)
+	assert.Equal(t, BATCH_STATUS_CREATED, response.Results[0].Status)
+	assert.Equal(t, BATCH_STATUS_CREATED, response.Results[1].Status)
+	assert.NotNil(t, response.Results[1].Gain)
+	assert.Equal(t, 2, len(gainsSaved))
+	assert.Equal(t, "Aluguel", gainsSaved[1].Description)
+	assert.False(t, gainsSaved[1].PayIn.IsZero())
+	assert.Equal(t, []string{aservice.ACTION_CREATE, aservice.ACTION_CREATE}, actionsRecorded)
+}
+
+func TestCreateBatchAtomicWithInvalidItem(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveAllCall(func(ctx context.Context, gains []repository.Gain) error {
+		t.Error("SaveAll should not be called when an item is invalid")
+		return nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	createBatchCtx := CreateBatchContext{
+		Ctx: context.TODO(),
+		Request: CreateBatchRequest{
+			Mode: BATCH_MODE_ATOMIC,
+			Items: []CreateRequest{
+				{PayIn: time.Now(), Description: "Salário", Value: money.FromCents(500000), CategoryId: 2},
+				{PayIn: time.Now(), Description: "Aluguel", Value: money.FromCents(-1000), CategoryId: 3},
+				{PayIn: time.Now(), Description: "", Value: money.FromCents(1000), CategoryId: 3},
+				{PayIn: time.Now(), Description: "Dividendos", Value: money.FromCents(1000)},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.CreateBatch(createBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), response.Succeeded)
+	assert.Equal(t, uint(3), response.Failed)
+	assert.Equal(t, BATCH_STATUS_SKIPPED, response.Results[0].Status)
+	assert.Equal(t, BATCH_STATUS_FAILED, response.Results[1].Status)
+	assert.Equal(t, BATCH_ERROR_INVALID_VALUE, response.Results[1].Error.Code)
+	assert.Equal(t, BATCH_ERROR_INVALID_DESCRIPTION, response.Results[2].Error.Code)
+	assert.Equal(t, BATCH_ERROR_INVALID_CATEGORY, response.Results[3].Error.Code)
+}
+
+func TestCreateBatchAtomicSaveAllFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveAllCall(func(ctx context.Context, gains []repository.Gain) error {
+		return errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	createBatchCtx := CreateBatchContext{
+		Ctx: context.TODO(),
+		Request: CreateBatchRequest{
+			Items: []CreateRequest{{PayIn: time.Now(), Description: "Salário", Value: money.FromCents(500000), CategoryId: 2}},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	_, err := _storageProcess.CreateBatch(createBatchCtx)
+	assert.Error(t, err)
+}
+
+func TestCreateBatchPartialWithStorageError(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, gain repository.Gain) (*repository.Gain, error) {
+		return &gain, nil
+	}).AddSaveCall(func(ctx context.Context, gain repository.Gain) (*repository.Gain, error) {
+		return nil, errors.New("An error has been ocurred")
+	})
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return buildGainMock(id), nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	createBatchCtx := CreateBatchContext{
+		Ctx: context.TODO(),
+		Request: CreateBatchRequest{
+			Mode: BATCH_MODE_PARTIAL,
+			Items: []CreateRequest{
+				{PayIn: time.Now(), Description: "Salário", Value: money.FromCents(500000), CategoryId: 2},
+				{PayIn: time.Now(), Description: "Aluguel", Value: money.FromCents(150000), CategoryId: 3},
+				{PayIn: time.Now(), Description: "Aluguel", Value: money.FromCents(0), CategoryId: 3},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.CreateBatch(createBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, BATCH_MODE_PARTIAL, response.Mode)
+	assert.Equal(t, uint(1), response.Succeeded)
+	assert.Equal(t, uint(2), response.Failed)
+	assert.Equal(t, BATCH_STATUS_CREATED, response.Results[0].Status)
+	assert.Equal(t, BATCH_ERROR_STORAGE, response.Results[1].Error.Code)
+	assert.Equal(t, "The item could not be saved", response.Results[1].Error.Message)
+	assert.Equal(t, BATCH_ERROR_INVALID_VALUE, response.Results[2].Error.Code)
+}
+
+func TestUpdateBatchPartial(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return buildGainMock(id), nil
+	}).AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return nil, nil
+	}).AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		gain := buildGainMock(id)
+		gain.Description = "Description editada"
+		return gain, nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, gain repository.Gain) (*repository.Gain, error) {
+		return &gain, nil
+	})
+
+	var recordsCtx []aservice.RecordContext
+	_mockAuditProcess := &mockAuditProcess{}
+	_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+		recordsCtx = append(recordsCtx, recordCtx)
+		return nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	item := UpdateRequest{PayIn: time.Now(), Description: "Description editada", Value: money.FromCents(75050), CategoryId: 2}
+	updateBatchCtx := UpdateBatchContext{
+		Ctx: context.TODO(),
+		Request: UpdateBatchRequest{
+			Mode: BATCH_MODE_PARTIAL,
+			Items: []UpdateBatchItem{
+				{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Version: 1, UpdateRequest: item},
+				{Id: "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a", Version: 1, UpdateRequest: item},
+				{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Version: 1, UpdateRequest: item},
+				{Id: "", Version: 1, UpdateRequest: item},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.UpdateBatch(updateBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), response.Succeeded)
+	assert.Equal(t, uint(3), response.Failed)
+	assert.Equal(t, BATCH_STATUS_UPDATED, response.Results[0].Status)
+	assert.Equal(t, "Description editada", response.Results[0].Gain.Description)
+	assert.Equal(t, BATCH_ERROR_NOT_FOUND, response.Results[1].Error.Code)
+	assert.Equal(t, BATCH_ERROR_DUPLICATED_ID, response.Results[2].Error.Code)
+	assert.Equal(t, BATCH_ERROR_INVALID_ID, response.Results[3].Error.Code)
+	assert.Equal(t, 1, len(recordsCtx))
+	assert.Equal(t, aservice.ACTION_UPDATE, recordsCtx[0].Action)
+	assert.Equal(t, "Description teste", recordsCtx[0].Before.(*GainResponse).Description)
+}
+
+func TestUpdateBatchAtomicSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	getByIdMock := func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return buildGainMock(id), nil
+	}
+	_mockRepository.AddGetByIdCall(getByIdMock).AddGetByIdCall(getByIdMock)
+	var gainsEdited []repository.Gain
+	_mockRepository.AddEditAllCall(func(ctx context.Context, gains []repository.Gain) error {
+		gainsEdited = gains
+		return nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	updateBatchCtx := UpdateBatchContext{
+		Ctx: context.TODO(),
+		Request: UpdateBatchRequest{
+			Items: []UpdateBatchItem{
+				{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Version: 1, UpdateRequest: UpdateRequest{PayIn: time.Now(), Description: "Salário", Value: money.FromCents(500000), CategoryId: 2}},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.UpdateBatch(updateBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), response.Succeeded)
+	assert.Equal(t, 1, len(gainsEdited))
+	assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", gainsEdited[0].UserId)
+	assert.Equal(t, money.FromCents(500000), gainsEdited[0].Value)
+	assert.Equal(t, uint(1), gainsEdited[0].Version)
+}
+
+func TestDeleteBatchAtomicSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	getByIdMock := func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return buildGainMock(id), nil
+	}
+	_mockRepository.AddGetByIdCall(getByIdMock).AddGetByIdCall(getByIdMock)
+	var idsRemoved []string
+	var versionsRemoved []uint
+	_mockRepository.AddRemoveAllCall(func(ctx context.Context, ids []string, versions []uint, userId string) error {
+		idsRemoved = ids
+		versionsRemoved = versions
+		return nil
+	})
+
+	var actionsRecorded []string
+	recordMock := func(recordCtx aservice.RecordContext) error {
+		actionsRecorded = append(actionsRecorded, recordCtx.Action)
+		return nil
+	}
+	_mockAuditProcess := &mockAuditProcess{}
+	_mockAuditProcess.AddRecordCall(recordMock).AddRecordCall(recordMock)
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	deleteBatchCtx := DeleteBatchContext{
+		Ctx: context.TODO(),
+		Request: DeleteBatchRequest{
+			Items: []DeleteBatchItem{
+				{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Version: 1},
+				{Id: "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a", Version: 1},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.DeleteBatch(deleteBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), response.Succeeded)
+	assert.Equal(t, BATCH_STATUS_DELETED, response.Results[1].Status)
+	assert.Equal(t, []string{"cd1cc27b-28a1-47dc-ac76-70e8185e159d", "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a"}, idsRemoved)
+	assert.Equal(t, []uint{1, 1}, versionsRemoved)
+	assert.Equal(t, []string{aservice.ACTION_DELETE, aservice.ACTION_DELETE}, actionsRecorded)
+}
+
+func TestDeleteBatchAtomicNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return buildGainMock(id), nil
+	}).AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return nil, nil
+	})
+	_mockRepository.AddRemoveAllCall(func(ctx context.Context, ids []string, versions []uint, userId string) error {
+		t.Error("RemoveAll should not be called when an id is not found")
+		return nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	deleteBatchCtx := DeleteBatchContext{
+		Ctx: context.TODO(),
+		Request: DeleteBatchRequest{
+			Items: []DeleteBatchItem{
+				{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Version: 1},
+				{Id: "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a", Version: 1},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.DeleteBatch(deleteBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), response.Succeeded)
+	assert.Equal(t, uint(1), response.Failed)
+	assert.Equal(t, BATCH_STATUS_SKIPPED, response.Results[0].Status)
+	assert.Equal(t, BATCH_ERROR_NOT_FOUND, response.Results[1].Error.Code)
+}
+
+func TestDeleteBatchGetByIdFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return nil, errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	deleteBatchCtx := DeleteBatchContext{
+		Ctx: context.TODO(),
+		Request: DeleteBatchRequest{
+			Items: []DeleteBatchItem{{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Version: 1}},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	_, err := _storageProcess.DeleteBatch(deleteBatchCtx)
+	assert.Error(t, err)
+}
+
+func TestCreateBatchPartialWithCategoryNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, gain repository.Gain) (*repository.Gain, error) {
+		return &gain, nil
+	})
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return buildGainMock(id), nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{missingCategories: map[uint]bool{9: true}}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	createBatchCtx := CreateBatchContext{
+		Ctx: context.TODO(),
+		Request: CreateBatchRequest{
+			Mode: BATCH_MODE_PARTIAL,
+			Items: []CreateRequest{
+				{PayIn: time.Now(), Description: "Salário", Value: money.FromCents(500000), CategoryId: 2},
+				{PayIn: time.Now(), Description: "Aluguel", Value: money.FromCents(150000), CategoryId: 9},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.CreateBatch(createBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), response.Succeeded)
+	assert.Equal(t, uint(1), response.Failed)
+	assert.Equal(t, BATCH_STATUS_CREATED, response.Results[0].Status)
+	assert.Equal(t, BATCH_ERROR_INVALID_CATEGORY, response.Results[1].Error.Code)
+	assert.Equal(t, "The category_id does not exist", response.Results[1].Error.Message)
+}
+
+func TestUpdateBatchAtomicVersionConflict(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	getByIdMock := func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return buildGainMock(id), nil
+	}
+	_mockRepository.AddGetByIdCall(getByIdMock).AddGetByIdCall(getByIdMock)
+	_mockRepository.AddEditAllCall(func(ctx context.Context, gains []repository.Gain) error {
+		return &repository.BatchConflictError{Position: 1}
+	})
+	_mockAuditProcess := &mockAuditProcess{}
+	_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+		t.Error("Record should not be called when the batch is not stored")
+		return nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	item := UpdateRequest{PayIn: time.Now(), Description: "Salário", Value: money.FromCents(500000), CategoryId: 2}
+	updateBatchCtx := UpdateBatchContext{
+		Ctx: context.TODO(),
+		Request: UpdateBatchRequest{
+			Items: []UpdateBatchItem{
+				{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Version: 1, UpdateRequest: item},
+				{Id: "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a", Version: 1, UpdateRequest: item},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.UpdateBatch(updateBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), response.Succeeded)
+	assert.Equal(t, uint(1), response.Failed)
+	assert.Equal(t, BATCH_STATUS_SKIPPED, response.Results[0].Status)
+	assert.Equal(t, BATCH_ERROR_VERSION_CONFLICT, response.Results[1].Error.Code)
+}
+
+func TestDeleteBatchPartialVersionConflict(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	getByIdMock := func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return buildGainMock(id), nil
+	}
+	_mockRepository.AddGetByIdCall(getByIdMock).AddGetByIdCall(getByIdMock).AddGetByIdCall(getByIdMock)
+	_mockRepository.AddRemoveCall(func(ctx context.Context, id string, userId string, version uint) error {
+		return repository.ErrVersionConflict
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	deleteBatchCtx := DeleteBatchContext{
+		Ctx: context.TODO(),
+		Request: DeleteBatchRequest{
+			Mode: BATCH_MODE_PARTIAL,
+			Items: []DeleteBatchItem{
+				{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Version: 1},
+				{Id: "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a", Version: 2},
+				{Id: "9a0e8f1c-3b5d-4e7a-8c2f-6d1b0a9e8c7f"},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.DeleteBatch(deleteBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), response.Succeeded)
+	assert.Equal(t, uint(3), response.Failed)
+	assert.Equal(t, BATCH_ERROR_VERSION_CONFLICT, response.Results[0].Error.Code)
+	assert.Equal(t, BATCH_ERROR_VERSION_CONFLICT, response.Results[1].Error.Code)
+	assert.Equal(t, BATCH_ERROR_INVALID_VERSION, response.Results[2].Error.Code)
+}
+
+func TestCreateBatchWithInvalidItemFields(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveAllCall(func(ctx context.Context, gains []repository.Gain) error {
+		t.Error("SaveAll should not be called when an item is invalid")
+		return nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	createBatchCtx := CreateBatchContext{
+		Ctx: context.TODO(),
+		Request: CreateBatchRequest{
+			Items: []CreateRequest{
+				{Description: "Salário", Value: money.FromCents(500000), CategoryId: 2},
+				{PayIn: time.Now(), Description: strings.Repeat("a", 256), Value: money.FromCents(500000), CategoryId: 2},
+				{PayIn: time.Now(), Description: "Salário", Value: money.FromCents(500000), Currency: "XYZ", CategoryId: 2},
+			},
+		},
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+	response, err := _storageProcess.CreateBatch(createBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), response.Failed)
+	assert.Equal(t, BATCH_ERROR_INVALID_PAY_IN, response.Results[0].Error.Code)
+	assert.Equal(t, BATCH_ERROR_INVALID_DESCRIPTION, response.Results[1].Error.Code)
+	assert.Equal(t, BATCH_ERROR_INVALID_CURRENCY, response.Results[2].Error.Code)
+}
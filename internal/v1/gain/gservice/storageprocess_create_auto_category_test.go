@@ -0,0 +1,86 @@
+package gservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/csservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildAutoCategoryRepositoryMock(saved *repository.Gain) *mockRepository {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, gain repository.Gain) (*repository.Gain, error) {
+		*saved = gain
+		return &gain, nil
+	})
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return saved, nil
+	})
+	return _mockRepository
+}
+
+func TestCreateWithAutoCategory(t *testing.T) {
+	saved := &repository.Gain{}
+	_mockSuggester := &mockSuggester{suggestions: []csservice.CategorySuggestionResponse{{CategoryId: 2, Confidence: 0.8}, {CategoryId: 5, Confidence: 0.2}}}
+	_storageProcess := NewStorageProcess(buildAutoCategoryRepositoryMock(saved), pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, _mockSuggester, &mockTransactor{})
+
+	_, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Salário empresa", Value: money.FromCents(5000), AutoCategory: true},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), saved.Category.Id)
+	assert.Equal(t, csservice.SuggestContext{
+		Ctx:         context.TODO(),
+		UserId:      "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Entity:      csservice.ENTITY_GAIN,
+		Description: "Salário empresa",
+	}, *_mockSuggester.suggestCtx)
+}
+
+func TestCreateWithAutoCategoryNotConfident(t *testing.T) {
+	_mockSuggester := &mockSuggester{suggestions: []csservice.CategorySuggestionResponse{{CategoryId: 2, Confidence: 0.4}, {CategoryId: 5, Confidence: 0.35}}}
+	_storageProcess := NewStorageProcess(&mockRepository{}, pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, _mockSuggester, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Salário empresa", Value: money.FromCents(5000), AutoCategory: true},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.Equal(t, validation.Errors{validation.Required("category_id")}, err)
+	assert.Nil(t, response)
+}
+
+func TestCreateInformedCategoryIgnoresAutoCategory(t *testing.T) {
+	saved := &repository.Gain{}
+	_mockSuggester := &mockSuggester{suggestions: []csservice.CategorySuggestionResponse{{CategoryId: 2, Confidence: 0.9}}}
+	_storageProcess := NewStorageProcess(buildAutoCategoryRepositoryMock(saved), pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, _mockSuggester, &mockTransactor{})
+
+	_, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Salário empresa", Value: money.FromCents(5000), CategoryId: 7, AutoCategory: true},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), saved.Category.Id)
+	assert.Nil(t, _mockSuggester.suggestCtx)
+}
+
+func TestCreateWithAutoCategoryFail(t *testing.T) {
+	_mockSuggester := &mockSuggester{err: errors.New("An error has been ocurred")}
+	_storageProcess := NewStorageProcess(&mockRepository{}, pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, _mockSuggester, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Salário empresa", Value: money.FromCents(5000), AutoCategory: true},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -6,7 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/csservice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,9 +20,14 @@ type mockRepository struct {
 	saveCallsMock            []func(ctx context.Context, Gain repository.Gain) (*repository.Gain, error)
 	getByIdCallsMock         []func(ctx context.Context, id string, userId string) (*repository.Gain, error)
 	editCallsMock            []func(ctx context.Context, Gain repository.Gain) (*repository.Gain, error)
-	removeCallsMock          []func(ctx context.Context, id string, userId string) error
+	removeCallsMock          []func(ctx context.Context, id string, userId string, version uint) error
 	getTotalRecordsCallsMock []func(ctx context.Context, params repository.QueryParams) (*uint, error)
 	getAllCallsMock          []func(ctx context.Context, params repository.QueryParams) (*[]repository.Gain, error)
+	getTrashCallsMock        []func(ctx context.Context, userId string) (*[]repository.Gain, error)
+	restoreCallsMock         []func(ctx context.Context, id string, userId string) error
+	saveAllCallsMock         []func(ctx context.Context, gains []repository.Gain) error
+	editAllCallsMock         []func(ctx context.Context, gains []repository.Gain) error
+	removeAllCallsMock       []func(ctx context.Context, ids []string, versions []uint, userId string) error
 }
 
 func (r *mockRepository) AddSaveCall(
@@ -39,7 +49,7 @@ func (r *mockRepository) AddEditCall(
 }
 
 func (r *mockRepository) AddRemoveCall(
-	remove func(ctx context.Context, id string, userId string) error) *mockRepository {
+	remove func(ctx context.Context, id string, userId string, version uint) error) *mockRepository {
 	r.removeCallsMock = append(r.removeCallsMock, remove)
 	return r
 }
@@ -83,11 +93,11 @@ func (r *mockRepository) GetById(ctx context.Context, id string, userId string)
 	return nil, nil
 }
 
-func (r *mockRepository) Remove(ctx context.Context, id string, userId string) error {
+func (r *mockRepository) Remove(ctx context.Context, id string, userId string, version uint) error {
 	if len(r.removeCallsMock) >= 1 {
 		remove := r.removeCallsMock[0]
 		r.removeCallsMock = r.removeCallsMock[1:]
-		return remove(ctx, id, userId)
+		return remove(ctx, id, userId, version)
 	}
 	return nil
 }
@@ -110,6 +120,115 @@ func (r *mockRepository) GetAll(ctx context.Context, params repository.QueryPara
 	return nil, nil
 }
 
+func (r *mockRepository) AddGetTrashCall(
+	getTrash func(ctx context.Context, userId string) (*[]repository.Gain, error)) *mockRepository {
+	r.getTrashCallsMock = append(r.getTrashCallsMock, getTrash)
+	return r
+}
+
+func (r *mockRepository) AddRestoreCall(
+	restore func(ctx context.Context, id string, userId string) error) *mockRepository {
+	r.restoreCallsMock = append(r.restoreCallsMock, restore)
+	return r
+}
+
+func (r *mockRepository) GetTrash(ctx context.Context, userId string) (*[]repository.Gain, error) {
+	if len(r.getTrashCallsMock) >= 1 {
+		getTrash := r.getTrashCallsMock[0]
+		r.getTrashCallsMock = r.getTrashCallsMock[1:]
+		return getTrash(ctx, userId)
+	}
+	return nil, nil
+}
+
+func (r *mockRepository) Restore(ctx context.Context, id string, userId string) error {
+	if len(r.restoreCallsMock) >= 1 {
+		restore := r.restoreCallsMock[0]
+		r.restoreCallsMock = r.restoreCallsMock[1:]
+		return restore(ctx, id, userId)
+	}
+	return nil
+}
+
+func (r *mockRepository) Purge(ctx context.Context, deletedBefore time.Time) error {
+	return nil
+}
+
+type mockAuditProcess struct {
+	recordCallsMock []func(recordCtx aservice.RecordContext) error
+}
+
+func (a *mockAuditProcess) AddRecordCall(record func(recordCtx aservice.RecordContext) error) *mockAuditProcess {
+	a.recordCallsMock = append(a.recordCallsMock, record)
+	return a
+}
+
+func (a *mockAuditProcess) Record(recordCtx aservice.RecordContext) error {
+	if len(a.recordCallsMock) >= 1 {
+		record := a.recordCallsMock[0]
+		a.recordCallsMock = a.recordCallsMock[1:]
+		return record(recordCtx)
+	}
+	return nil
+}
+
+type mockCategorizer struct {
+	categorization *crservice.Categorization
+	description    string
+	merchantName   string
+	entity         string
+	value          money.Money
+	paymentTypeId  uint
+	labels         []crservice.LabelContext
+	err            error
+}
+
+func (ct *mockCategorizer) Categorize(categorizeCtx crservice.CategorizeContext) (*crservice.Categorization, error) {
+	ct.description = categorizeCtx.Description
+	ct.merchantName = categorizeCtx.MerchantName
+	ct.entity = categorizeCtx.Entity
+	ct.value = categorizeCtx.Value
+	ct.paymentTypeId = categorizeCtx.PaymentTypeId
+	return ct.categorization, ct.err
+}
+
+func (ct *mockCategorizer) Label(labelCtx crservice.LabelContext) error {
+	if ct.err != nil {
+		return ct.err
+	}
+	ct.labels = append(ct.labels, labelCtx)
+	return nil
+}
+
+type mockSuggester struct {
+	suggestions []csservice.CategorySuggestionResponse
+	suggestCtx  *csservice.SuggestContext
+	err         error
+}
+
+func (s *mockSuggester) Suggest(suggestCtx csservice.SuggestContext) ([]csservice.CategorySuggestionResponse, error) {
+	s.suggestCtx = &suggestCtx
+	return s.suggestions, s.err
+}
+
+type mockReferenceChecker struct {
+	missingCategories   map[uint]bool
+	missingPaymentTypes map[uint]bool
+	err                 error
+}
+
+func (rc *mockReferenceChecker) GainCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) InvoiceCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) PaymentTypeExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingPaymentTypes[id], rc.err
+}
+
 func TestCreateSuccess(t *testing.T) {
 
 	createdAt := time.Now()
@@ -120,11 +239,12 @@ func TestCreateSuccess(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
 	_mockRepository.AddSaveCall(func(ctx context.Context, Gain repository.Gain) (*repository.Gain, error) {
+		assert.Equal(t, money.CURRENCY_DEFAULT, Gain.Currency)
 		return gainMock, nil
 	})
 	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
@@ -138,13 +258,13 @@ func TestCreateSuccess(t *testing.T) {
 	request := CreateRequest{
 		PayIn:       time.Now(),
 		Description: "Description teste",
-		Value:       750.50,
+		Value:       money.FromCents(75050),
 		IsPassive:   false,
 		CategoryId:  2,
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
 	createCtx := CreateContext{
@@ -155,6 +275,7 @@ func TestCreateSuccess(t *testing.T) {
 	response, err := _storageProcess.Create(createCtx)
 	assert.NoError(t, err)
 	assert.Equal(t, "cd1cc27b-28a1-47dc-ac76-70e8185e159d", response.Id)
+	assert.Equal(t, "BRL", response.Currency)
 }
 
 func TestCreateWithoutPayInSuccess(t *testing.T) {
@@ -167,7 +288,7 @@ func TestCreateWithoutPayInSuccess(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
@@ -184,13 +305,13 @@ func TestCreateWithoutPayInSuccess(t *testing.T) {
 
 	request := CreateRequest{
 		Description: "Description teste",
-		Value:       750.50,
+		Value:       money.FromCents(75050),
 		IsPassive:   false,
 		CategoryId:  2,
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
 	createCtx := CreateContext{
@@ -217,13 +338,13 @@ func TestCreateSaveFail(t *testing.T) {
 	request := CreateRequest{
 		PayIn:       time.Now(),
 		Description: "Description teste",
-		Value:       750.50,
+		Value:       money.FromCents(75050),
 		IsPassive:   false,
 		CategoryId:  2,
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
 	createCtx := CreateContext{
@@ -246,7 +367,7 @@ func TestCreateGetByIdFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
@@ -264,13 +385,13 @@ func TestCreateGetByIdFail(t *testing.T) {
 	request := CreateRequest{
 		PayIn:       time.Now(),
 		Description: "Description teste",
-		Value:       750.50,
+		Value:       money.FromCents(75050),
 		IsPassive:   false,
 		CategoryId:  2,
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
 	createCtx := CreateContext{
@@ -281,3 +402,169 @@ func TestCreateGetByIdFail(t *testing.T) {
 	_, err := _storageProcess.Create(createCtx)
 	assert.Error(t, err)
 }
+
+func TestCreateAuditFail(t *testing.T) {
+	createdAt := time.Now()
+	gainMock := repository.NewGainBuilder().
+		AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddCreatedAt(createdAt).
+		AddPayIn(createdAt).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		Build()
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, Gain repository.Gain) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	_mockAuditProcess := &mockAuditProcess{}
+	_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+		return errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+	}
+
+	request := CreateRequest{
+		PayIn:       time.Now(),
+		Description: "Description teste",
+		Value:       money.FromCents(75050),
+		CategoryId:  2,
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createCtx := CreateContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	_, err := _storageProcess.Create(createCtx)
+	assert.Error(t, err)
+}
+
+func (r *mockRepository) AddSaveAllCall(
+	saveAll func(ctx context.Context, gains []repository.Gain) error) *mockRepository {
+	r.saveAllCallsMock = append(r.saveAllCallsMock, saveAll)
+	return r
+}
+
+func (r *mockRepository) AddEditAllCall(
+	editAll func(ctx context.Context, gains []repository.Gain) error) *mockRepository {
+	r.editAllCallsMock = append(r.editAllCallsMock, editAll)
+	return r
+}
+
+func (r *mockRepository) AddRemoveAllCall(
+	removeAll func(ctx context.Context, ids []string, versions []uint, userId string) error) *mockRepository {
+	r.removeAllCallsMock = append(r.removeAllCallsMock, removeAll)
+	return r
+}
+
+func (r *mockRepository) SaveAll(ctx context.Context, gains []repository.Gain) error {
+	if len(r.saveAllCallsMock) >= 1 {
+		saveAll := r.saveAllCallsMock[0]
+		r.saveAllCallsMock = r.saveAllCallsMock[1:]
+		return saveAll(ctx, gains)
+	}
+	return nil
+}
+
+func (r *mockRepository) EditAll(ctx context.Context, gains []repository.Gain) error {
+	if len(r.editAllCallsMock) >= 1 {
+		editAll := r.editAllCallsMock[0]
+		r.editAllCallsMock = r.editAllCallsMock[1:]
+		return editAll(ctx, gains)
+	}
+	return nil
+}
+
+func (r *mockRepository) RemoveAll(ctx context.Context, ids []string, versions []uint, userId string) error {
+	if len(r.removeAllCallsMock) >= 1 {
+		removeAll := r.removeAllCallsMock[0]
+		r.removeAllCallsMock = r.removeAllCallsMock[1:]
+		return removeAll(ctx, ids, versions, userId)
+	}
+	return nil
+}
+
+func TestCreateCategoryNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, gain repository.Gain) (*repository.Gain, error) {
+		t.Error("Save should not be called when a reference is not valid")
+		return nil, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+	}
+
+	request := CreateRequest{
+		PayIn:       time.Now(),
+		Description: "Description teste",
+		Value:       money.FromCents(75050),
+		IsPassive:   false,
+		CategoryId:  2,
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{missingCategories: map[uint]bool{2: true}}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createCtx := CreateContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	response, err := _storageProcess.Create(createCtx)
+	var errs validation.Errors
+	assert.ErrorAs(t, err, &errs)
+	assert.Equal(t, validation.Errors{validation.NotFound("category_id")}, errs)
+	assert.Empty(t, response)
+}
+
+func TestCreateReferenceCheckFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, gain repository.Gain) (*repository.Gain, error) {
+		t.Error("Save should not be called when a reference is not valid")
+		return nil, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+	}
+
+	request := CreateRequest{
+		PayIn:       time.Now(),
+		Description: "Description teste",
+		Value:       money.FromCents(75050),
+		IsPassive:   false,
+		CategoryId:  2,
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{err: errors.New("An error has been ocurred")}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createCtx := CreateContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	response, err := _storageProcess.Create(createCtx)
+	assert.EqualError(t, err, "An error has been ocurred")
+	assert.Empty(t, response)
+}
+
+type mockTransactor struct{}
+
+func (t *mockTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -4,14 +4,24 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestDeleteSuccess(t *testing.T) {
 	_mockRepository := &mockRepository{}
-	_mockRepository.AddRemoveCall(func(ctx context.Context, id string, userId string) error {
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return repository.NewGainBuilder().AddId(id).AddVersion(3).Build(), nil
+	})
+	var versionFound uint
+	_mockRepository.AddRemoveCall(func(ctx context.Context, id string, userId string, version uint) error {
+		versionFound = version
 		return nil
 	})
 
@@ -20,21 +30,26 @@ func TestDeleteSuccess(t *testing.T) {
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
-	searchCtx := SearchContext{
+	deleteCtx := DeleteContext{
 		Ctx:       ctx,
 		Id:        "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Version:   3,
 		UserToken: token,
 	}
-	err := _storageProcess.Delete(searchCtx)
+	err := _storageProcess.Delete(deleteCtx)
 	assert.NoError(t, err)
+	assert.Equal(t, uint(3), versionFound)
 }
 
 func TestDeleteFail(t *testing.T) {
 	_mockRepository := &mockRepository{}
-	_mockRepository.AddRemoveCall(func(ctx context.Context, id string, userId string) error {
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return repository.NewGainBuilder().AddId(id).AddVersion(3).Build(), nil
+	})
+	_mockRepository.AddRemoveCall(func(ctx context.Context, id string, userId string, version uint) error {
 		return errors.New("An error has been ocurred")
 	})
 
@@ -43,14 +58,111 @@ func TestDeleteFail(t *testing.T) {
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
-	searchCtx := SearchContext{
+	deleteCtx := DeleteContext{
 		Ctx:       ctx,
 		Id:        "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Version:   3,
 		UserToken: token,
 	}
-	err := _storageProcess.Delete(searchCtx)
+	err := _storageProcess.Delete(deleteCtx)
 	assert.Error(t, err)
 }
+
+func TestDeleteRecordsAudit(t *testing.T) {
+	gainMock := repository.NewGainBuilder().
+		AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddPayIn(time.Now()).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		Build()
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	_mockRepository.AddRemoveCall(func(ctx context.Context, id string, userId string, version uint) error {
+		return nil
+	})
+	var recordCtxFound aservice.RecordContext
+	_mockAuditProcess := &mockAuditProcess{}
+	_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+		recordCtxFound = recordCtx
+		return nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	deleteCtx := DeleteContext{
+		Ctx:       ctx,
+		Id:        "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Version:   3,
+		UserToken: token,
+	}
+	err := _storageProcess.Delete(deleteCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, aservice.ACTION_DELETE, recordCtxFound.Action)
+	assert.Equal(t, aservice.ENTITY_GAIN, recordCtxFound.Entity)
+	assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", recordCtxFound.UserId)
+	assert.Equal(t, money.FromCents(75050), recordCtxFound.Before.(*GainResponse).Value)
+	assert.Nil(t, recordCtxFound.After)
+}
+
+func TestDeleteVersionConflict(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Gain, error) {
+		return repository.NewGainBuilder().AddId(id).AddVersion(3).Build(), nil
+	})
+	_mockRepository.AddRemoveCall(func(ctx context.Context, id string, userId string, version uint) error {
+		return ErrVersionConflict
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	deleteCtx := DeleteContext{
+		Ctx:       ctx,
+		Id:        "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Version:   3,
+		UserToken: token,
+	}
+	err := _storageProcess.Delete(deleteCtx)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddRemoveCall(func(ctx context.Context, id string, userId string, version uint) error {
+		return errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	deleteCtx := DeleteContext{
+		Ctx:       ctx,
+		Id:        "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		Version:   3,
+		UserToken: token,
+	}
+	err := _storageProcess.Delete(deleteCtx)
+	assert.Equal(t, apperror.KIND_NOT_FOUND, apperror.KindOf(err))
+}
@@ -0,0 +1,29 @@
+package gservice
+
+import (
+	"context"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/csservice"
+)
+
+// suggestCategory chooses the category learned from the history of the user when the request asks for the
+// auto category and neither the request nor the category rules informed it.
+// The category is left blank when the best suggestion is not confident enough.
+func (sp *storageProcess) suggestCategory(ctx context.Context, userId string, request *CreateRequest) error {
+	if !request.AutoCategory || request.CategoryId != 0 {
+		return nil
+	}
+	suggestions, err := sp.suggester.Suggest(csservice.SuggestContext{
+		Ctx:         ctx,
+		UserId:      userId,
+		Entity:      csservice.ENTITY_GAIN,
+		Description: request.Description,
+	})
+	if err != nil {
+		return err
+	}
+	if len(suggestions) > 0 && suggestions[0].Confidence >= csservice.AUTO_CATEGORY_MIN_CONFIDENCE {
+		request.CategoryId = suggestions[0].CategoryId
+	}
+	return nil
+}
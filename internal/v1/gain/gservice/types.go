@@ -3,12 +3,48 @@ package gservice
 import (
 	"context"
 	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gain/repository"
 )
 
+// ErrVersionConflict is returned when the version informed by the client is not the current one of the record
+var ErrVersionConflict = repository.ErrVersionConflict
+
+const (
+	BATCH_MODE_ATOMIC  = "atomic"
+	BATCH_MODE_PARTIAL = "partial"
+)
+
+const (
+	BATCH_STATUS_CREATED = "created"
+	BATCH_STATUS_UPDATED = "updated"
+	BATCH_STATUS_DELETED = "deleted"
+	BATCH_STATUS_FAILED  = "failed"
+	BATCH_STATUS_SKIPPED = "skipped"
+)
+
+const (
+	BATCH_ERROR_INVALID_ID          = "invalid_id"
+	BATCH_ERROR_DUPLICATED_ID       = "duplicated_id"
+	BATCH_ERROR_INVALID_PAY_IN      = "invalid_pay_in"
+	BATCH_ERROR_INVALID_DESCRIPTION = "invalid_description"
+	BATCH_ERROR_INVALID_VALUE       = "invalid_value"
+	BATCH_ERROR_INVALID_CURRENCY    = "invalid_currency"
+	BATCH_ERROR_INVALID_CATEGORY    = "invalid_category"
+	BATCH_ERROR_INVALID_VERSION     = "invalid_version"
+	BATCH_ERROR_NOT_FOUND           = "not_found"
+	BATCH_ERROR_VERSION_CONFLICT    = "version_conflict"
+	BATCH_ERROR_STORAGE             = "storage_error"
+)
+
+// CreateContext carries the merchant name of the PIX the gain came from, matched by the category rules
+// whatever description is informed
 type CreateContext struct {
-	Ctx       context.Context
-	Request   CreateRequest
-	UserToken string
+	Ctx          context.Context
+	Request      CreateRequest
+	MerchantName string
+	UserToken    string
 }
 
 type UpdateContext struct {
@@ -16,6 +52,46 @@ type UpdateContext struct {
 	Request   UpdateRequest
 	UserToken string
 	Id        string
+	Version   uint
+}
+
+type PatchContext struct {
+	Ctx       context.Context
+	Request   PatchRequest
+	UserToken string
+	Id        string
+	Version   uint
+}
+
+type DeleteContext struct {
+	Ctx       context.Context
+	UserToken string
+	Id        string
+	Version   uint
+}
+
+type CreateBatchContext struct {
+	Ctx       context.Context
+	Request   CreateBatchRequest
+	UserToken string
+}
+
+type UpdateBatchContext struct {
+	Ctx       context.Context
+	Request   UpdateBatchRequest
+	UserToken string
+}
+
+type DeleteBatchContext struct {
+	Ctx       context.Context
+	Request   DeleteBatchRequest
+	UserToken string
+}
+
+type CreateFromPixContext struct {
+	Ctx       context.Context
+	Request   CreateFromPixRequest
+	UserToken string
 }
 
 type SearchContext struct {
@@ -25,25 +101,80 @@ type SearchContext struct {
 	Id        string
 }
 
+// CreateRequest leaves the category to the category rules when it is not informed,
+// and to the suggestion learned from the history of the user when the auto_category is set
 type CreateRequest struct {
-	PayIn       time.Time `json:"pay_in"`
-	Description string    `json:"description"`
-	Value       float64   `json:"value"`
-	IsPassive   bool      `json:"is_passive"`
-	CategoryId  uint      `json:"category_id"`
+	PayIn        time.Time   `json:"pay_in" binding:"required"`
+	Description  string      `json:"description" binding:"notblank,max=255"`
+	Value        money.Money `json:"value" binding:"gt=0" swaggertype:"number"`
+	Currency     string      `json:"currency" binding:"omitempty,iso4217"`
+	IsPassive    bool        `json:"is_passive"`
+	CategoryId   uint        `json:"category_id"`
+	AutoCategory bool        `json:"auto_category"`
+}
+
+// CreateFromPixRequest takes the value and the description from the PIX, the informed ones take precedence.
+// The category is chosen by the category rules of the user when it is not informed, as in the creation of any gain.
+type CreateFromPixRequest struct {
+	Code         string      `json:"code" binding:"notblank,max=512"`
+	PayIn        time.Time   `json:"pay_in"`
+	Description  string      `json:"description" binding:"max=255"`
+	Value        money.Money `json:"value" binding:"gte=0" swaggertype:"number"`
+	IsPassive    bool        `json:"is_passive"`
+	CategoryId   uint        `json:"category_id"`
+	AutoCategory bool        `json:"auto_category"`
 }
 
 type UpdateRequest struct {
-	PayIn       time.Time `json:"pay_in"`
-	Description string    `json:"description"`
-	Value       float64   `json:"value"`
-	IsPassive   bool      `json:"is_passive"`
-	CategoryId  uint      `json:"category_id"`
+	PayIn       time.Time   `json:"pay_in" binding:"required"`
+	Description string      `json:"description" binding:"notblank,max=255"`
+	Value       money.Money `json:"value" binding:"gt=0" swaggertype:"number"`
+	Currency    string      `json:"currency" binding:"omitempty,iso4217"`
+	IsPassive   bool        `json:"is_passive"`
+	CategoryId  uint        `json:"category_id" binding:"required"`
+}
+
+// PatchRequest holds only the fields that must be changed, the omitted ones keep the stored value
+type PatchRequest struct {
+	PayIn       *time.Time   `json:"pay_in"`
+	Description *string      `json:"description" binding:"omitempty,notblank,max=255"`
+	Value       *money.Money `json:"value" binding:"omitempty,gt=0" swaggertype:"number"`
+	Currency    *string      `json:"currency" binding:"omitempty,iso4217"`
+	IsPassive   *bool        `json:"is_passive"`
+	CategoryId  *uint        `json:"category_id" binding:"omitempty,min=1"`
+}
+
+type CreateBatchRequest struct {
+	Mode  string          `json:"mode"`
+	Items []CreateRequest `json:"items"`
+}
+
+type UpdateBatchRequest struct {
+	Mode  string            `json:"mode"`
+	Items []UpdateBatchItem `json:"items"`
+}
+
+// UpdateBatchItem carries the version of the gain read by the client, the one returned in the ETag
+type UpdateBatchItem struct {
+	Id      string `json:"id"`
+	Version uint   `json:"version"`
+	UpdateRequest
+}
+
+type DeleteBatchRequest struct {
+	Mode  string            `json:"mode"`
+	Items []DeleteBatchItem `json:"items"`
+}
+
+// DeleteBatchItem carries the version of the gain read by the client, the one returned in the ETag
+type DeleteBatchItem struct {
+	Id      string `json:"id"`
+	Version uint   `json:"version"`
 }
 
 type CreateGainRequest struct {
-	Value float64   `json:"value"`
-	PayIn time.Time `json:"pay_in"`
+	Value money.Money `json:"value" swaggertype:"number"`
+	PayIn time.Time   `json:"pay_in"`
 }
 
 type CategoryResponse struct {
@@ -56,9 +187,37 @@ type GainResponse struct {
 	GainProjectionId string           `json:"gain_projection_id,omitempty"`
 	PayIn            time.Time        `json:"pay_in"`
 	Description      string           `json:"description"`
-	Value            float64          `json:"value"`
+	Value            money.Money      `json:"value" swaggertype:"number"`
+	Currency         string           `json:"currency"`
+	ConvertedValue   *money.Money     `json:"converted_value,omitempty" swaggertype:"number"`
 	IsPassive        bool             `json:"is_passive"`
 	Category         CategoryResponse `json:"category"`
+	DeletedAt        *time.Time       `json:"deleted_at,omitempty"`
+	Version          uint             `json:"-"`
+}
+
+type GainTrashResponse struct {
+	Records []GainResponse `json:"records"`
+}
+
+type BatchItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type GainBatchItemResult struct {
+	Index  int             `json:"index"`
+	Id     string          `json:"id,omitempty"`
+	Status string          `json:"status"`
+	Error  *BatchItemError `json:"error,omitempty"`
+	Gain   *GainResponse   `json:"gain,omitempty"`
+}
+
+type GainBatchResponse struct {
+	Mode      string                `json:"mode"`
+	Succeeded uint                  `json:"succeeded"`
+	Failed    uint                  `json:"failed"`
+	Results   []GainBatchItemResult `json:"results"`
 }
 
 type GainStat struct {
@@ -68,11 +227,12 @@ type GainStat struct {
 }
 
 type GainPaginateResponse struct {
-	CurrentPage  uint           `json:"current_page"`
-	TotalPages   uint           `json:"total_pages"`
-	TotalRecords uint           `json:"total_records"`
-	PageLimit    uint           `json:"page_limit"`
-	Records      []GainResponse `json:"records"`
+	CurrentPage       uint           `json:"current_page"`
+	TotalPages        uint           `json:"total_pages"`
+	TotalRecords      uint           `json:"total_records"`
+	PageLimit         uint           `json:"page_limit"`
+	ConvertedCurrency string         `json:"converted_currency,omitempty"`
+	Records           []GainResponse `json:"records"`
 }
 
 type Paginate struct {
@@ -83,5 +243,37 @@ type Paginate struct {
 type SearchParams struct {
 	month    *uint
 	year     *uint
+	currency string
 	paginate *Paginate
 }
+
+type ApplyRulesContext struct {
+	Ctx       context.Context
+	Request   ApplyRulesRequest
+	UserToken string
+}
+
+// ApplyRulesRequest selects the month whose gains are categorized again, the dry run only previews the changes
+type ApplyRulesRequest struct {
+	Month  uint `json:"month" binding:"required,min=1,max=12"`
+	Year   uint `json:"year" binding:"required,min=1900"`
+	DryRun bool `json:"dry_run"`
+}
+
+type ApplyRulesResult struct {
+	Id               string `json:"id"`
+	Description      string `json:"description"`
+	CategoryIdBefore uint   `json:"category_id_before"`
+	CategoryIdAfter  uint   `json:"category_id_after"`
+	IsPassiveBefore  bool   `json:"is_passive_before"`
+	IsPassiveAfter   bool   `json:"is_passive_after"`
+	Label            string `json:"label,omitempty"`
+	Changed          bool   `json:"changed"`
+}
+
+type ApplyRulesResponse struct {
+	DryRun  bool               `json:"dry_run"`
+	Matched uint               `json:"matched"`
+	Changed uint               `json:"changed"`
+	Results []ApplyRulesResult `json:"results"`
+}
@@ -1,21 +1,33 @@
 package gain
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/etag"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
 	"github.com/ruanlas/wallet-core-api/internal/tracing"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gain/gservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	"go.elastic.co/apm"
 )
 
 type Handler interface {
 	Create(c *gin.Context)
+	CreateFromPix(c *gin.Context)
 	GetById(c *gin.Context)
 	Update(c *gin.Context)
+	Patch(c *gin.Context)
 	Delete(c *gin.Context)
 	GetAll(c *gin.Context)
+	GetTrash(c *gin.Context)
+	Restore(c *gin.Context)
+	CreateBatch(c *gin.Context)
+	UpdateBatch(c *gin.Context)
+	DeleteBatch(c *gin.Context)
+	ApplyRules(c *gin.Context)
 }
 
 type ResponseDefault interface {
@@ -32,7 +44,9 @@ func NewHandler(storageProcess gservice.StorageProcess, readingProcess gservice.
 
 // Create godoc
 // @Summary Criar uma Receita
-// @Description Este endpoint permite criar uma receita
+// @Description Este endpoint permite criar uma receita.
+// @Description A categoria é escolhida pelas regras de categorização quando não informada.
+// @Description Com auto_category, a categoria ainda não escolhida é sugerida a partir das receitas já categorizadas do usuário quando a confiança da sugestão é suficiente
 // @Tags Gain
 // @Accept json
 // @Produce json
@@ -47,9 +61,9 @@ func (h *handler) Create(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	// apm.TransactionFromContext(ctx)
 
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("Gain::StorageProcess::Create", "Create new gain", nil)
@@ -60,7 +74,45 @@ func (h *handler) Create(c *gin.Context) {
 	}
 	gainCreated, err := h.storageProcess.Create(createCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, gainCreated)
+}
+
+// @Summary Criar uma Receita a partir de um PIX
+// @Description Este endpoint permite criar uma receita a partir de um PIX copia e cola ou do conteúdo do seu QR code.
+// @Description O valor e a descrição são obtidos do PIX quando não informados, e a categoria é escolhida pelas regras de categorização quando não informada
+// @Tags Gain
+// @Accept json
+// @Produce json
+// @Param gain body gservice.CreateFromPixRequest true "Modelo de criação da receita a partir do PIX"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} gservice.GainResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/gain/pix [post]
+func (h *handler) CreateFromPix(c *gin.Context) {
+	var request gservice.CreateFromPixRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Gain::StorageProcess::CreateFromPix", "Create new gain from a PIX", nil)
+	createFromPixCtx := gservice.CreateFromPixContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Request:   request,
+	}
+	gainCreated, err := h.storageProcess.CreateFromPix(createFromPixCtx)
+	if err != nil {
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
@@ -76,6 +128,7 @@ func (h *handler) Create(c *gin.Context) {
 // @Param id path string true "Id da receita"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
 // @Success 200 {object} gservice.GainResponse
+// @Header 200 {string} ETag "Versão atual do registro, deve ser enviada no If-Match da edição e remoção"
 // @Router /v1/gain/{id} [get]
 func (h *handler) GetById(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -92,15 +145,12 @@ func (h *handler) GetById(c *gin.Context) {
 	}
 	Gain, err := h.readingProcess.GetById(searchCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
-	if Gain == nil {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Object not found"})
-		return
-	}
 	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(Gain.Version))
 	c.JSON(http.StatusOK, Gain)
 }
 
@@ -112,7 +162,10 @@ func (h *handler) GetById(c *gin.Context) {
 // @Produce json
 // @Param gain body gservice.UpdateRequest true "Modelo de edição da receita"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
 // @Success 200 {object} gservice.GainResponse
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
 // @Router /v1/gain/{id} [put]
 func (h *handler) Update(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -121,9 +174,19 @@ func (h *handler) Update(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	id := c.Param("id")
 	var request gservice.UpdateRequest
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("Gain::StorageProcess::Update", "Create new gain", nil)
@@ -131,19 +194,80 @@ func (h *handler) Update(c *gin.Context) {
 		Ctx:       ctx,
 		Request:   request,
 		Id:        id,
+		Version:   version,
 		UserToken: userToken,
 	}
 	gainUpdated, err := h.storageProcess.Update(updateCtx)
+	if errors.Is(err, gservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The gain was modified by another request"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
-	if gainUpdated == nil {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Gain not found"})
+	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(gainUpdated.Version))
+	c.JSON(http.StatusOK, gainUpdated)
+}
+
+// @Summary Editar parcialmente uma Receita
+// @Description Este endpoint permite editar somente os campos informados de uma receita.
+// @Description Os campos omitidos mantêm o valor atual e o registro resultante é validado antes de ser salvo.
+// @Tags Gain
+// @Accept json
+// @Produce json
+// @Param gain body gservice.PatchRequest true "Modelo de edição parcial da receita"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
+// @Success 200 {object} gservice.GainResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/gain/{id} [patch]
+func (h *handler) Patch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	id := c.Param("id")
+	var request gservice.PatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Gain::StorageProcess::Patch", "Patch a gain", nil)
+	patchCtx := gservice.PatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		Id:        id,
+		Version:   version,
+		UserToken: userToken,
+	}
+	gainUpdated, err := h.storageProcess.Patch(patchCtx)
+	if errors.Is(err, gservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The gain was modified by another request"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
 		return
 	}
 	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(gainUpdated.Version))
 	c.JSON(http.StatusOK, gainUpdated)
 }
 
@@ -154,7 +278,11 @@ func (h *handler) Update(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Id da receita"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
 // @Success 200 {object} ResponseDefault{status=int,message=string}
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
 // @Router /v1/gain/{id} [delete]
 func (h *handler) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -162,15 +290,30 @@ func (h *handler) Delete(c *gin.Context) {
 
 	id := c.Param("id")
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	span := tx.StartSpan("Gain::StorageProcess::Delete", "Delete a gain", nil)
-	searchCtx := gservice.SearchContext{
+	deleteCtx := gservice.DeleteContext{
 		Ctx:       ctx,
 		Id:        id,
+		Version:   version,
 		UserToken: userToken,
 	}
-	err := h.storageProcess.Delete(searchCtx)
+	err = h.storageProcess.Delete(deleteCtx)
+	if errors.Is(err, gservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The gain was modified by another request"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
@@ -178,6 +321,184 @@ func (h *handler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Gain removed"})
 }
 
+// @Summary Criar Receitas em lote
+// @Description Este endpoint permite criar várias receitas de uma vez.
+// @Description No modo atomic (padrão) nenhuma receita é criada caso algum item seja inválido, no modo partial os itens válidos são criados.
+// @Tags Gain
+// @Accept json
+// @Produce json
+// @Param gain body gservice.CreateBatchRequest true "Modelo de criação das receitas em lote"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} gservice.GainBatchResponse
+// @Success 207 {object} gservice.GainBatchResponse
+// @Failure 422 {object} gservice.GainBatchResponse
+// @Router /v1/gain/batch [post]
+func (h *handler) CreateBatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request gservice.CreateBatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	err = validateBatch(request.Mode, len(request.Items))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Gain::StorageProcess::CreateBatch", "Create a batch of gains", nil)
+	createBatchCtx := gservice.CreateBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.CreateBatch(createBatchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(getBatchStatusCode(response, http.StatusCreated), response)
+}
+
+// @Summary Editar Receitas em lote
+// @Description Este endpoint permite editar várias receitas de uma vez.
+// @Description No modo atomic (padrão) nenhuma receita é editada caso algum item seja inválido, no modo partial os itens válidos são editados.
+// @Description Cada item deve informar a version obtida no ETag da consulta, o item alterado por outra requisição falha com version_conflict.
+// @Tags Gain
+// @Accept json
+// @Produce json
+// @Param gain body gservice.UpdateBatchRequest true "Modelo de edição das receitas em lote"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} gservice.GainBatchResponse
+// @Success 207 {object} gservice.GainBatchResponse
+// @Failure 422 {object} gservice.GainBatchResponse
+// @Router /v1/gain/batch [put]
+func (h *handler) UpdateBatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request gservice.UpdateBatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	err = validateBatch(request.Mode, len(request.Items))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Gain::StorageProcess::UpdateBatch", "Update a batch of gains", nil)
+	updateBatchCtx := gservice.UpdateBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.UpdateBatch(updateBatchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(getBatchStatusCode(response, http.StatusOK), response)
+}
+
+// @Summary Remover Receitas em lote
+// @Description Este endpoint permite remover várias receitas de uma vez.
+// @Description No modo atomic (padrão) nenhuma receita é removida caso algum item seja inválido, no modo partial os itens válidos são removidos.
+// @Description Cada item deve informar a version obtida no ETag da consulta, o item alterado por outra requisição falha com version_conflict.
+// @Tags Gain
+// @Accept json
+// @Produce json
+// @Param gain body gservice.DeleteBatchRequest true "Modelo de remoção das receitas em lote"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} gservice.GainBatchResponse
+// @Success 207 {object} gservice.GainBatchResponse
+// @Failure 422 {object} gservice.GainBatchResponse
+// @Router /v1/gain/batch [delete]
+func (h *handler) DeleteBatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request gservice.DeleteBatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	err = validateBatch(request.Mode, len(request.Items))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Gain::StorageProcess::DeleteBatch", "Delete a batch of gains", nil)
+	deleteBatchCtx := gservice.DeleteBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.DeleteBatch(deleteBatchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(getBatchStatusCode(response, http.StatusOK), response)
+}
+
+// @Summary Reaplicar as regras de categorização nas Receitas
+// @Description Este endpoint permite aplicar as regras de categorização nas receitas já registradas no mês, escolhendo a categoria, o marcador e se a receita é passiva conforme as regras atuais.
+// @Description Com o dry_run as alterações são apenas simuladas, sem alterar as receitas
+// @Tags Gain
+// @Accept json
+// @Produce json
+// @Param applyRules body gservice.ApplyRulesRequest true "Modelo da reaplicação das regras"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} gservice.ApplyRulesResponse
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /v1/gain/apply-rules [post]
+func (h *handler) ApplyRules(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request gservice.ApplyRulesRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Gain::StorageProcess::ApplyRules", "Apply the category rules to the gains of the month", nil)
+	applyRulesCtx := gservice.ApplyRulesContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.ApplyRules(applyRulesCtx)
+	if errors.Is(err, gservice.ErrVersionConflict) {
+		c.Error(apperror.Conflict("A gain of the month was modified by another request, try again"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, response)
+}
+
 // @Summary Obter uma listagem de Receitas
 // @Description Este endpoint permite obter uma listagem de receitas
 // @Tags Gain
@@ -187,6 +508,7 @@ func (h *handler) Delete(c *gin.Context) {
 // @Param page query string false "A página que será buscada"
 // @Param month query string true "O mês que será filtrado a busca"
 // @Param year query string true "O ano que será filtrado a busca"
+// @Param currency query string false "A moeda (ISO 4217) para a qual os valores serão convertidos"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
 // @Success 200 {object} gservice.GainPaginateResponse
 // @Router /v1/gain [get]
@@ -197,7 +519,7 @@ func (h *handler) GetAll(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	searchParams, err := validateAndGetSearchParams(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("Gain::ReadingProcess::GetAllPaginated", "Get a gain paginated", nil)
@@ -208,10 +530,69 @@ func (h *handler) GetAll(c *gin.Context) {
 	}
 	resultPaginated, err := h.readingProcess.GetAllPaginated(searchCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
 	span.End()
 	c.JSON(http.StatusOK, resultPaginated)
 }
+
+// @Summary Obter a lixeira de Receitas
+// @Description Este endpoint permite obter as receitas removidas que ainda podem ser restauradas
+// @Tags Gain
+// @Accept json
+// @Produce json
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} gservice.GainTrashResponse
+// @Router /v1/gain/trash [get]
+func (h *handler) GetTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	span := tx.StartSpan("Gain::ReadingProcess::GetTrash", "Get the trashed gain", nil)
+	searchCtx := gservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+	}
+	trash, err := h.readingProcess.GetTrash(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, trash)
+}
+
+// @Summary Restaurar uma Receita
+// @Description Este endpoint permite restaurar uma receita que está na lixeira
+// @Tags Gain
+// @Accept json
+// @Produce json
+// @Param id path string true "Id da receita"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} gservice.GainResponse
+// @Router /v1/gain/{id}/restore [post]
+func (h *handler) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	id := c.Param("id")
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	span := tx.StartSpan("Gain::StorageProcess::Restore", "Restore a gain", nil)
+	searchCtx := gservice.SearchContext{
+		Ctx:       ctx,
+		Id:        id,
+		UserToken: userToken,
+	}
+	gainRestored, err := h.storageProcess.Restore(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, gainRestored)
+}
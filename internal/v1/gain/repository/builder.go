@@ -1,6 +1,10 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type QueryParamsBuilder struct {
 	userId string
@@ -48,11 +52,13 @@ type GainBuilder struct {
 	createdAt        time.Time
 	payIn            time.Time
 	description      string
-	value            float64
+	value            money.Money
+	currency         string
 	isPassive        bool
 	userId           string
 	category         GainCategory
 	gainProjectionId string
+	version          uint
 }
 
 func NewGainBuilder() *GainBuilder {
@@ -74,10 +80,14 @@ func (builder *GainBuilder) AddDescription(description string) *GainBuilder {
 	builder.description = description
 	return builder
 }
-func (builder *GainBuilder) AddValue(value float64) *GainBuilder {
+func (builder *GainBuilder) AddValue(value money.Money) *GainBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *GainBuilder) AddCurrency(currency string) *GainBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *GainBuilder) AddIsPassive(isPassive bool) *GainBuilder {
 	builder.isPassive = isPassive
 	return builder
@@ -94,6 +104,10 @@ func (builder *GainBuilder) AddCategory(category GainCategory) *GainBuilder {
 	builder.category = category
 	return builder
 }
+func (builder *GainBuilder) AddVersion(version uint) *GainBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *GainBuilder) Build() *Gain {
 	gain := Gain{}
 
@@ -102,10 +116,12 @@ func (builder *GainBuilder) Build() *Gain {
 	gain.PayIn = builder.payIn
 	gain.Description = builder.description
 	gain.Value = builder.value
+	gain.Currency = builder.currency
 	gain.IsPassive = builder.isPassive
 	gain.GainProjectionId = builder.gainProjectionId
 	gain.UserId = builder.userId
 	gain.Category = builder.category
+	gain.Version = builder.version
 
 	return &gain
 }
@@ -3,16 +3,44 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 )
 
 type Repository interface {
 	Save(ctx context.Context, gain Gain) (*Gain, error)
 	GetById(ctx context.Context, id string, userId string) (*Gain, error)
 	Edit(ctx context.Context, gain Gain) (*Gain, error)
-	Remove(ctx context.Context, id string, userId string) error
+	Remove(ctx context.Context, id string, userId string, version uint) error
+	SaveAll(ctx context.Context, gains []Gain) error
+	EditAll(ctx context.Context, gains []Gain) error
+	RemoveAll(ctx context.Context, ids []string, versions []uint, userId string) error
 	GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error)
 	GetAll(ctx context.Context, params QueryParams) (*[]Gain, error)
+	GetTrash(ctx context.Context, userId string) (*[]Gain, error)
+	Restore(ctx context.Context, id string, userId string) error
+	Purge(ctx context.Context, deletedBefore time.Time) error
+}
+
+// ErrVersionConflict is returned when the record was changed after the version informed by the caller
+var ErrVersionConflict = errors.New("the record was modified by another request")
+
+// BatchConflictError tells which item of a batch was changed after the version informed by the caller,
+// it matches ErrVersionConflict
+type BatchConflictError struct {
+	Position int
+}
+
+func (e *BatchConflictError) Error() string {
+	return fmt.Sprintf("the record at the position %d of the batch was modified by another request", e.Position)
+}
+
+func (e *BatchConflictError) Unwrap() error {
+	return ErrVersionConflict
 }
 
 type repository struct {
@@ -24,13 +52,13 @@ func New(db *sql.DB) Repository {
 }
 
 func (r *repository) Save(ctx context.Context, gain Gain) (*Gain, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +69,7 @@ func (r *repository) Save(ctx context.Context, gain Gain) (*Gain, error) {
 		gain.PayIn,
 		gain.Description,
 		gain.Value,
+		gain.Currency,
 		gain.IsPassive,
 		gain.UserId,
 		gain.Category.Id,
@@ -56,30 +85,32 @@ func (r *repository) Save(ctx context.Context, gain Gain) (*Gain, error) {
 }
 
 func (r *repository) GetById(ctx context.Context, id string, userId string) (*Gain, error) {
-	results, err := r.db.QueryContext(ctx, `
+	results, err := dbtx.Get(ctx, r.db).QueryContext(ctx, `
 		SELECT
 			g.id,
 			g.created_at,
 			g.pay_in,
 			g.description,
 			g.value,
+			g.currency,
 			g.is_passive,
 			g.user_id,
 			gc.id,
 			gc.category,
-			g.gain_projection_id
+			g.gain_projection_id,
+			g.version
 		FROM
 			gain g
 		INNER JOIN gain_category gc ON 
 			gc.id = g.category_id
-		WHERE g.id = ? AND g.user_id = ?`, id, userId)
+		WHERE g.id = ? AND g.user_id = ? AND g.deleted_at IS NULL`, id, userId)
 	if err != nil {
 		return nil, err
 	}
 	defer results.Close()
 	gain := &Gain{Category: GainCategory{}}
 	if results.Next() {
-		var value sql.NullFloat64
+		var value money.NullMoney
 		var categoryId sql.NullInt64
 		var createdAtTimestamp sql.NullInt64
 		var gainProjectionId sql.NullString
@@ -89,18 +120,20 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*Ga
 			&gain.PayIn,
 			&gain.Description,
 			&value,
+			&gain.Currency,
 			&gain.IsPassive,
 			&gain.UserId,
 			&categoryId,
 			&gain.Category.Category,
 			&gainProjectionId,
+			&gain.Version,
 		)
 		if err != nil {
 			return nil, err
 		}
 		gain.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
 		gain.Category.Id = uint(categoryId.Int64)
-		gain.Value = value.Float64
+		gain.Value = value.Money
 		gain.GainProjectionId = gainProjectionId.String
 	} else {
 		return nil, nil
@@ -109,29 +142,39 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*Ga
 }
 
 func (r *repository) Edit(ctx context.Context, gain Gain) (*Gain, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		UPDATE gain SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ? 
-		WHERE id = ? AND user_id = ?`)
+		UPDATE gain SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`)
 	if err != nil {
 		return nil, err
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(
+	result, err := stmt.Exec(
 		gain.PayIn,
 		gain.Description,
 		gain.Value,
+		gain.Currency,
 		gain.IsPassive,
 		gain.Category.Id,
 		gain.Id,
 		gain.UserId,
+		gain.Version,
 	)
 	if err != nil {
 		return nil, err
 	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return nil, ErrVersionConflict
+	}
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -139,20 +182,151 @@ func (r *repository) Edit(ctx context.Context, gain Gain) (*Gain, error) {
 	return &gain, nil
 }
 
-func (r *repository) Remove(ctx context.Context, id string, userId string) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+func (r *repository) Remove(ctx context.Context, id string, userId string, version uint) error {
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return err
 	}
-	stmt, err := tx.PrepareContext(ctx, `DELETE FROM gain WHERE id = ? AND user_id = ?`)
+	stmt, err := tx.PrepareContext(ctx, `UPDATE gain SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(id, userId)
+	result, err := stmt.Exec(time.Now().Unix(), id, userId, version)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return ErrVersionConflict
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SaveAll saves every gain in a single transaction, so either all of them are stored or none
+func (r *repository) SaveAll(ctx context.Context, gains []Gain) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for _, gain := range gains {
+		_, err = stmt.Exec(
+			gain.Id,
+			gain.CreatedAt.Unix(),
+			gain.PayIn,
+			gain.Description,
+			gain.Value,
+			gain.Currency,
+			gain.IsPassive,
+			gain.UserId,
+			gain.Category.Id,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// EditAll edits every gain in a single transaction, so either all of them are changed or none.
+// A gain whose version is not the current one aborts the batch with a BatchConflictError
+func (r *repository) EditAll(ctx context.Context, gains []Gain) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		UPDATE gain SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for position, gain := range gains {
+		result, err := stmt.Exec(
+			gain.PayIn,
+			gain.Description,
+			gain.Value,
+			gain.Currency,
+			gain.IsPassive,
+			gain.Category.Id,
+			gain.Id,
+			gain.UserId,
+			gain.Version,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if rowsAffected == 0 {
+			tx.Rollback()
+			return &BatchConflictError{Position: position}
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoveAll moves every gain to the trash in a single transaction, so either all of them are removed or none.
+// A gain whose version is not the current one aborts the batch with a BatchConflictError
+func (r *repository) RemoveAll(ctx context.Context, ids []string, versions []uint, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE gain SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	deletedAt := time.Now().Unix()
+	for position, id := range ids {
+		result, err := stmt.Exec(deletedAt, id, userId, versions[position])
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if rowsAffected == 0 {
+			tx.Rollback()
+			return &BatchConflictError{Position: position}
+		}
+	}
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -162,8 +336,8 @@ func (r *repository) Remove(ctx context.Context, id string, userId string) error
 
 func (r *repository) GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error) {
 	var totalRecords uint
-	query := `SELECT COUNT(*) as total_records FROM gain WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ?`
-	row := r.db.QueryRowContext(ctx, query, params.month, params.year, params.userId)
+	query := `SELECT COUNT(*) as total_records FROM gain WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ? AND deleted_at IS NULL`
+	row := dbtx.Get(ctx, r.db).QueryRowContext(ctx, query, params.month, params.year, params.userId)
 	err := row.Scan(&totalRecords)
 	if err != nil {
 		return nil, err
@@ -179,8 +353,10 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Gain, e
 			g.pay_in,
 			g.description,
 			g.value,
+			g.currency,
 			g.is_passive,
 			g.user_id,
+			g.version,
 			gc.id,
 			gc.category
 		FROM
@@ -188,9 +364,9 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Gain, e
 		INNER JOIN gain_category gc ON 
 			gc.id = g.category_id
 		WHERE 
-			MONTH(g.pay_in) = ? AND YEAR(g.pay_in) = ? AND g.user_id = ?
+			MONTH(g.pay_in) = ? AND YEAR(g.pay_in) = ? AND g.user_id = ? AND g.deleted_at IS NULL
 		LIMIT ? OFFSET ?`
-	rows, err := r.db.QueryContext(ctx, query, params.month, params.year, params.userId, params.limit, params.offset)
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, params.month, params.year, params.userId, params.limit, params.offset)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +374,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Gain, e
 
 	var gainList []Gain
 	for rows.Next() {
-		var value sql.NullFloat64
+		var value money.NullMoney
 		var categoryId sql.NullInt64
 		var createdAtTimestamp sql.NullInt64
 		var g Gain
@@ -210,15 +386,80 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Gain, e
 			&g.PayIn,
 			&g.Description,
 			&value,
+			&g.Currency,
 			&g.IsPassive,
 			&g.UserId,
+			&g.Version,
 			&categoryId,
 			&category.Category)
 		if err != nil {
 			return nil, err
 		}
 		g.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
-		g.Value = value.Float64
+		g.Value = value.Money
+		category.Id = uint(categoryId.Int64)
+		g.Category = category
+
+		gainList = append(gainList, g)
+	}
+
+	return &gainList, nil
+}
+
+func (r *repository) GetTrash(ctx context.Context, userId string) (*[]Gain, error) {
+	query := `
+		SELECT
+			g.id,
+			g.created_at,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency,
+			g.is_passive,
+			g.user_id,
+			gc.id,
+			gc.category,
+			g.deleted_at
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON 
+			gc.id = g.category_id
+		WHERE 
+			g.user_id = ? AND g.deleted_at IS NOT NULL
+		ORDER BY g.deleted_at DESC`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var gainList []Gain
+	for rows.Next() {
+		var value money.NullMoney
+		var categoryId sql.NullInt64
+		var createdAtTimestamp sql.NullInt64
+		var deletedAtTimestamp sql.NullInt64
+		var g Gain
+		var category GainCategory
+
+		err := rows.Scan(
+			&g.Id,
+			&createdAtTimestamp,
+			&g.PayIn,
+			&g.Description,
+			&value,
+			&g.Currency,
+			&g.IsPassive,
+			&g.UserId,
+			&categoryId,
+			&category.Category,
+			&deletedAtTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		g.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		g.DeletedAt = time.Unix(deletedAtTimestamp.Int64, 0)
+		g.Value = value.Money
 		category.Id = uint(categoryId.Int64)
 		g.Category = category
 
@@ -227,3 +468,57 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Gain, e
 
 	return &gainList, nil
 }
+
+func (r *repository) Restore(ctx context.Context, id string, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE gain SET deleted_at = NULL, version = version + 1 WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) Purge(ctx context.Context, deletedBefore time.Time) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	// The dividend of an asset is the gain itself, so its link goes along with it
+	dividendStmt, err := tx.PrepareContext(ctx, `
+		DELETE FROM asset_dividend
+		WHERE gain_id IN (SELECT id FROM gain WHERE deleted_at IS NOT NULL AND deleted_at < ?)`)
+	if err != nil {
+		return err
+	}
+	defer dividendStmt.Close()
+	_, err = dividendStmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM gain WHERE deleted_at IS NOT NULL AND deleted_at < ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const editAllUpdateMock = `
+		UPDATE gain SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`
+
+func TestEditAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildGainsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	stmt := sqlMock.ExpectPrepare(editAllUpdateMock)
+	for _, gain := range gainsMock {
+		stmt.ExpectExec().
+			WithArgs(
+				gain.PayIn,
+				gain.Description,
+				gain.Value,
+				gain.Currency,
+				gain.IsPassive,
+				gain.Category.Id,
+				gain.Id,
+				gain.UserId,
+				gain.Version).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	sqlMock.ExpectCommit()
+
+	err = _repository.EditAll(context.Background(), gainsMock)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEditAllExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildGainsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(editAllUpdateMock).
+		ExpectExec().
+		WithArgs(
+			gainsMock[0].PayIn,
+			gainsMock[0].Description,
+			gainsMock[0].Value,
+			gainsMock[0].Currency,
+			gainsMock[0].IsPassive,
+			gainsMock[0].Category.Id,
+			gainsMock[0].Id,
+			gainsMock[0].UserId,
+			gainsMock[0].Version).
+		WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.EditAll(context.Background(), gainsMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEditAllCommitFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildGainsMock()[:1]
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(editAllUpdateMock).
+		ExpectExec().
+		WithArgs(
+			gainsMock[0].PayIn,
+			gainsMock[0].Description,
+			gainsMock[0].Value,
+			gainsMock[0].Currency,
+			gainsMock[0].IsPassive,
+			gainsMock[0].Category.Id,
+			gainsMock[0].Id,
+			gainsMock[0].UserId,
+			gainsMock[0].Version).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit().WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.EditAll(context.Background(), gainsMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEditAllVersionConflict(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildGainsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	stmt := sqlMock.ExpectPrepare(editAllUpdateMock)
+	for index, gain := range gainsMock {
+		stmt.ExpectExec().
+			WithArgs(
+				gain.PayIn,
+				gain.Description,
+				gain.Value,
+				gain.Currency,
+				gain.IsPassive,
+				gain.Category.Id,
+				gain.Id,
+				gain.UserId,
+				gain.Version).
+			WillReturnResult(sqlmock.NewResult(0, int64(1-index)))
+	}
+	sqlMock.ExpectRollback()
+
+	err = _repository.EditAll(context.Background(), gainsMock)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	var conflict *BatchConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 1, conflict.Position)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,24 +25,28 @@ func TestEditGainSuccess(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE gain SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE gain SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			gainMock.PayIn,
 			gainMock.Description,
 			gainMock.Value,
+			gainMock.Currency,
 			gainMock.IsPassive,
 			gainMock.Category.Id,
 			gainMock.Id,
-			gainMock.UserId).
+			gainMock.UserId,
+			gainMock.Version).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit()
 
@@ -69,8 +74,10 @@ func TestEditGainBeginFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
@@ -100,16 +107,18 @@ func TestEditGainPrepareFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE gain SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE gain SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Edit(context.Background(), *gainMock)
@@ -135,25 +144,29 @@ func TestEditGainExecFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE gain SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE gain SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			gainMock.PayIn,
 			gainMock.Description,
 			gainMock.Value,
+			gainMock.Currency,
 			gainMock.IsPassive,
 			gainMock.Category.Id,
 			gainMock.Id,
-			gainMock.UserId).
+			gainMock.UserId,
+			gainMock.Version).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Edit(context.Background(), *gainMock)
@@ -179,25 +192,29 @@ func TestEditGainCommitFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE gain SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE gain SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			gainMock.PayIn,
 			gainMock.Description,
 			gainMock.Value,
+			gainMock.Currency,
 			gainMock.IsPassive,
 			gainMock.Category.Id,
 			gainMock.Id,
-			gainMock.UserId).
+			gainMock.UserId,
+			gainMock.Version).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit().WillReturnError(errors.New("An error has been ocurred"))
 
@@ -208,3 +225,52 @@ func TestEditGainCommitFail(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestEditGainVersionConflict(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	gainMock := NewGainBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(now).
+		AddPayIn(now).
+		AddIsPassive(true).
+		AddCategory(GainCategory{Id: 1}).
+		AddDescription("Description de teste").
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddUserId("User1").
+		AddVersion(3).
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		UPDATE gain SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
+		ExpectExec().
+		WithArgs(
+			gainMock.PayIn,
+			gainMock.Description,
+			gainMock.Value,
+			gainMock.Currency,
+			gainMock.IsPassive,
+			gainMock.Category.Id,
+			gainMock.Id,
+			gainMock.UserId,
+			gainMock.Version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	_, err = _repository.Edit(context.Background(), *gainMock)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,8 +34,10 @@ func TestGetAllSuccess(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1, Category: "Salário"}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(1).
 		Build()
 
 	rowsGainMock := sqlMock.NewRows([]string{
@@ -43,8 +46,10 @@ func TestGetAllSuccess(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"user_id",
+		"version",
 		"category_id",
 		"category",
 	}).AddRow(
@@ -53,8 +58,10 @@ func TestGetAllSuccess(t *testing.T) {
 		gainPMock.PayIn,
 		gainPMock.Description,
 		gainPMock.Value,
+		gainPMock.Currency,
 		gainPMock.IsPassive,
 		gainPMock.UserId,
+		gainPMock.Version,
 		gainPMock.Category.Id,
 		gainPMock.Category.Category,
 	)
@@ -68,8 +75,10 @@ func TestGetAllSuccess(t *testing.T) {
 			g.pay_in,
 			g.description,
 			g.value,
+			g.currency,
 			g.is_passive,
 			g.user_id,
+			g.version,
 			gc.id,
 			gc.category
 		FROM
@@ -77,7 +86,7 @@ func TestGetAllSuccess(t *testing.T) {
 		INNER JOIN gain_category gc ON 
 			gc.id = g.category_id
 		WHERE 
-			MONTH(g.pay_in) = ? AND YEAR(g.pay_in) = ? AND g.user_id = ?
+			MONTH(g.pay_in) = ? AND YEAR(g.pay_in) = ? AND g.user_id = ? AND g.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnRows(rowsGainMock)
@@ -85,6 +94,7 @@ func TestGetAllSuccess(t *testing.T) {
 	listGain, err := _repository.GetAll(context.Background(), queryParams)
 	assert.NoError(t, err)
 	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", (*listGain)[0].Id)
+	assert.Equal(t, uint(1), (*listGain)[0].Version)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -115,8 +125,10 @@ func TestGetAllQueryFail(t *testing.T) {
 			g.pay_in,
 			g.description,
 			g.value,
+			g.currency,
 			g.is_passive,
 			g.user_id,
+			g.version,
 			gc.id,
 			gc.category
 		FROM
@@ -124,7 +136,7 @@ func TestGetAllQueryFail(t *testing.T) {
 		INNER JOIN gain_category gc ON 
 			gc.id = g.category_id
 		WHERE 
-			MONTH(g.pay_in) = ? AND YEAR(g.pay_in) = ? AND g.user_id = ?
+			MONTH(g.pay_in) = ? AND YEAR(g.pay_in) = ? AND g.user_id = ? AND g.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnError(errors.New("An error has been ocurred"))
@@ -158,8 +170,10 @@ func TestGetAllScanFail(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"user_id",
+		"version",
 		"category_id",
 		"category",
 	}).AddRow(
@@ -172,6 +186,8 @@ func TestGetAllScanFail(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 	).RowError(1, errors.New("An error has been ocurred"))
 
 	_repository := New(dbMock)
@@ -183,8 +199,10 @@ func TestGetAllScanFail(t *testing.T) {
 			g.pay_in,
 			g.description,
 			g.value,
+			g.currency,
 			g.is_passive,
 			g.user_id,
+			g.version,
 			gc.id,
 			gc.category
 		FROM
@@ -192,7 +210,7 @@ func TestGetAllScanFail(t *testing.T) {
 		INNER JOIN gain_category gc ON 
 			gc.id = g.category_id
 		WHERE 
-			MONTH(g.pay_in) = ? AND YEAR(g.pay_in) = ? AND g.user_id = ?
+			MONTH(g.pay_in) = ? AND YEAR(g.pay_in) = ? AND g.user_id = ? AND g.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnRows(rowsGainMock)
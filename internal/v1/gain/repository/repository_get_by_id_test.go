@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,9 +26,11 @@ func TestGetGainByIdSuccess(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1, Category: "Salário"}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddGainProjectionId("7172a75e-f41e-47df-a514-12580f34bd09").
+		AddVersion(3).
 		Build()
 
 	rowsGainMock := sqlMock.NewRows([]string{
@@ -36,22 +39,26 @@ func TestGetGainByIdSuccess(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"user_id",
 		"category_id",
 		"category",
 		"gain_projection_id",
+		"version",
 	}).AddRow(
 		gainMock.Id,
 		gainMock.CreatedAt.Unix(),
 		gainMock.PayIn,
 		gainMock.Description,
 		gainMock.Value,
+		gainMock.Currency,
 		gainMock.IsPassive,
 		gainMock.UserId,
 		gainMock.Category.Id,
 		gainMock.Category.Category,
 		gainMock.GainProjectionId,
+		gainMock.Version,
 	)
 
 	_repository := New(dbMock)
@@ -63,22 +70,26 @@ func TestGetGainByIdSuccess(t *testing.T) {
 			g.pay_in,
 			g.description,
 			g.value,
+			g.currency,
 			g.is_passive,
 			g.user_id,
 			gc.id,
 			gc.category,
-			g.gain_projection_id
+			g.gain_projection_id,
+			g.version
 		FROM
 			gain g
 		INNER JOIN gain_category gc ON 
 			gc.id = g.category_id
-		WHERE g.id = ? AND g.user_id = ?`).
+		WHERE g.id = ? AND g.user_id = ? AND g.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsGainMock)
 
 	gainPSaved, err := _repository.GetById(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
 	assert.NoError(t, err)
 	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", gainPSaved.Id)
+	assert.Equal(t, uint(3), gainPSaved.Version)
+	assert.Equal(t, "BRL", gainPSaved.Currency)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -101,16 +112,18 @@ func TestGetGainByIdQueryFail(t *testing.T) {
 			g.pay_in,
 			g.description,
 			g.value,
+			g.currency,
 			g.is_passive,
 			g.user_id,
 			gc.id,
 			gc.category,
-			g.gain_projection_id
+			g.gain_projection_id,
+			g.version
 		FROM
 			gain g
 		INNER JOIN gain_category gc ON 
 			gc.id = g.category_id
-		WHERE g.id = ? AND g.user_id = ?`).
+		WHERE g.id = ? AND g.user_id = ? AND g.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnError(errors.New("An error has been ocurred"))
 
@@ -135,11 +148,13 @@ func TestGetGainByIdRowEmpty(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"user_id",
 		"category_id",
 		"category",
 		"gain_projection_id",
+		"version",
 	})
 
 	_repository := New(dbMock)
@@ -151,16 +166,18 @@ func TestGetGainByIdRowEmpty(t *testing.T) {
 			g.pay_in,
 			g.description,
 			g.value,
+			g.currency,
 			g.is_passive,
 			g.user_id,
 			gc.id,
 			gc.category,
-			g.gain_projection_id
+			g.gain_projection_id,
+			g.version
 		FROM
 			gain g
 		INNER JOIN gain_category gc ON 
 			gc.id = g.category_id
-		WHERE g.id = ? AND g.user_id = ?`).
+		WHERE g.id = ? AND g.user_id = ? AND g.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsGainMock)
 
@@ -186,13 +203,15 @@ func TestGetGainByIdRowScanFail(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"user_id",
 		"category_id",
 		"category",
 		"gain_projection_id",
+		"version",
 	}).AddRow(
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).
 		RowError(1, errors.New("An error has been ocurred"))
 
 	_repository := New(dbMock)
@@ -204,16 +223,18 @@ func TestGetGainByIdRowScanFail(t *testing.T) {
 			g.pay_in,
 			g.description,
 			g.value,
+			g.currency,
 			g.is_passive,
 			g.user_id,
 			gc.id,
 			gc.category,
-			g.gain_projection_id
+			g.gain_projection_id,
+			g.version
 		FROM
 			gain g
 		INNER JOIN gain_category gc ON 
 			gc.id = g.category_id
-		WHERE g.id = ? AND g.user_id = ?`).
+		WHERE g.id = ? AND g.user_id = ? AND g.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsGainMock)
 
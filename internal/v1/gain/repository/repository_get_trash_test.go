@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrashSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"pay_in",
+		"description",
+		"value",
+		"currency",
+		"is_passive",
+		"user_id",
+		"category_id",
+		"category",
+		"deleted_at",
+	}).AddRow(
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		now.Unix(),
+		now,
+		"Description de teste",
+		500.50,
+		"USD",
+		true,
+		"User1",
+		1,
+		"Categoria",
+		now.Unix(),
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			g.id,
+			g.created_at,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency,
+			g.is_passive,
+			g.user_id,
+			gc.id,
+			gc.category,
+			g.deleted_at
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON 
+			gc.id = g.category_id
+		WHERE 
+			g.user_id = ? AND g.deleted_at IS NOT NULL
+		ORDER BY g.deleted_at DESC`).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	trash, err := _repository.GetTrash(context.Background(), "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(*trash))
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", (*trash)[0].Id)
+	assert.Equal(t, now.Unix(), (*trash)[0].DeletedAt.Unix())
+	assert.Equal(t, "USD", (*trash)[0].Currency)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetTrashQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			g.id,
+			g.created_at,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency,
+			g.is_passive,
+			g.user_id,
+			gc.id,
+			gc.category,
+			g.deleted_at
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON 
+			gc.id = g.category_id
+		WHERE 
+			g.user_id = ? AND g.deleted_at IS NOT NULL
+		ORDER BY g.deleted_at DESC`).
+		WithArgs("User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetTrash(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
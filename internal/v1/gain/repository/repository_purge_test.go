@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurgeGainSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+	deletedBefore := time.Now().AddDate(0, 0, -30)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		DELETE FROM asset_dividend
+		WHERE gain_id IN (SELECT id FROM gain WHERE deleted_at IS NOT NULL AND deleted_at < ?)`).
+		ExpectExec().
+		WithArgs(deletedBefore.Unix()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectPrepare(`DELETE FROM gain WHERE deleted_at IS NOT NULL AND deleted_at < ?`).
+		ExpectExec().
+		WithArgs(deletedBefore.Unix()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	sqlMock.ExpectCommit()
+
+	err = _repository.Purge(context.Background(), deletedBefore)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPurgeGainExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+	deletedBefore := time.Now().AddDate(0, 0, -30)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		DELETE FROM asset_dividend
+		WHERE gain_id IN (SELECT id FROM gain WHERE deleted_at IS NOT NULL AND deleted_at < ?)`).
+		ExpectExec().
+		WithArgs(deletedBefore.Unix()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectPrepare(`DELETE FROM gain WHERE deleted_at IS NOT NULL AND deleted_at < ?`).
+		ExpectExec().
+		WithArgs(deletedBefore.Unix()).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.Purge(context.Background(), deletedBefore)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -19,13 +19,13 @@ func TestRemoveGainSuccess(t *testing.T) {
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
-	sqlMock.ExpectPrepare(`DELETE FROM gain WHERE id = ? AND user_id = ?`).
+	sqlMock.ExpectPrepare(`UPDATE gain SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`).
 		ExpectExec().
-		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WithArgs(sqlmock.AnyArg(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit()
 
-	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3)
 	assert.NoError(t, err)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
@@ -44,7 +44,7 @@ func TestRemoveGainBeginFail(t *testing.T) {
 
 	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
 
-	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3)
 	assert.Error(t, err)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
@@ -62,10 +62,10 @@ func TestRemoveGainPrepareFail(t *testing.T) {
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
-	sqlMock.ExpectPrepare(`DELETE FROM gain WHERE id = ? AND user_id = ?`).
+	sqlMock.ExpectPrepare(`UPDATE gain SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
-	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3)
 	assert.Error(t, err)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
@@ -83,12 +83,12 @@ func TestRemoveGainExecFail(t *testing.T) {
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
-	sqlMock.ExpectPrepare(`DELETE FROM gain WHERE id = ? AND user_id = ?`).
+	sqlMock.ExpectPrepare(`UPDATE gain SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`).
 		ExpectExec().
-		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WithArgs(sqlmock.AnyArg(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3).
 		WillReturnError(errors.New("An error has been ocurred"))
 
-	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3)
 	assert.Error(t, err)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
@@ -106,17 +106,41 @@ func TestRemoveGainCommitFail(t *testing.T) {
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
-	sqlMock.ExpectPrepare(`DELETE FROM gain WHERE id = ? AND user_id = ?`).
+	sqlMock.ExpectPrepare(`UPDATE gain SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`).
 		ExpectExec().
-		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WithArgs(sqlmock.AnyArg(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit().
 		WillReturnError(errors.New("An error has been ocurred"))
 
-	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3)
 	assert.Error(t, err)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestRemoveGainVersionConflict(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`UPDATE gain SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`).
+		ExpectExec().
+		WithArgs(sqlmock.AnyArg(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	err = _repository.Remove(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", 3)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveAllInsertMock = `
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func buildGainsMock() []Gain {
+	now := time.Now()
+	return []Gain{
+		*NewGainBuilder().
+			AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+			AddCreatedAt(now).
+			AddPayIn(now).
+			AddIsPassive(true).
+			AddCategory(GainCategory{Id: 1}).
+			AddDescription("Description de teste").
+			AddValue(money.FromCents(50050)).
+			AddCurrency("BRL").
+			AddUserId("User1").
+			Build(),
+		*NewGainBuilder().
+			AddId("2b4a2b1e-0c1b-4f6e-9f65-3bd0a5cfa7a9").
+			AddCreatedAt(now).
+			AddPayIn(now).
+			AddIsPassive(false).
+			AddCategory(GainCategory{Id: 2}).
+			AddDescription("Description de teste 2").
+			AddValue(money.FromCents(10000)).
+			AddCurrency("BRL").
+			AddUserId("User1").
+			Build(),
+	}
+}
+
+func TestSaveAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildGainsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	stmt := sqlMock.ExpectPrepare(saveAllInsertMock)
+	for _, gain := range gainsMock {
+		stmt.ExpectExec().
+			WithArgs(
+				gain.Id,
+				gain.CreatedAt.Unix(),
+				gain.PayIn,
+				gain.Description,
+				gain.Value,
+				gain.Currency,
+				gain.IsPassive,
+				gain.UserId,
+				gain.Category.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	sqlMock.ExpectCommit()
+
+	err = _repository.SaveAll(context.Background(), gainsMock)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveAllPrepareFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveAllInsertMock).WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.SaveAll(context.Background(), buildGainsMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveAllExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildGainsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	stmt := sqlMock.ExpectPrepare(saveAllInsertMock)
+	stmt.ExpectExec().
+		WithArgs(
+			gainsMock[0].Id,
+			gainsMock[0].CreatedAt.Unix(),
+			gainsMock[0].PayIn,
+			gainsMock[0].Description,
+			gainsMock[0].Value,
+			gainsMock[0].Currency,
+			gainsMock[0].IsPassive,
+			gainsMock[0].UserId,
+			gainsMock[0].Category.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	stmt.ExpectExec().
+		WithArgs(
+			gainsMock[1].Id,
+			gainsMock[1].CreatedAt.Unix(),
+			gainsMock[1].PayIn,
+			gainsMock[1].Description,
+			gainsMock[1].Value,
+			gainsMock[1].Currency,
+			gainsMock[1].IsPassive,
+			gainsMock[1].UserId,
+			gainsMock[1].Category.Id).
+		WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.SaveAll(context.Background(), gainsMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,7 +26,8 @@ func TestSaveGainSuccess(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -33,8 +35,8 @@ func TestSaveGainSuccess(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			gainMock.Id,
@@ -42,6 +44,7 @@ func TestSaveGainSuccess(t *testing.T) {
 			gainMock.PayIn,
 			gainMock.Description,
 			gainMock.Value,
+			gainMock.Currency,
 			gainMock.IsPassive,
 			gainMock.UserId,
 			gainMock.Category.Id).
@@ -72,7 +75,8 @@ func TestSaveGainBeginFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -103,7 +107,8 @@ func TestSaveGainPrepareFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -111,8 +116,8 @@ func TestSaveGainPrepareFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Save(context.Background(), *gainMock)
@@ -138,7 +143,8 @@ func TestSaveGainExecFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -146,8 +152,8 @@ func TestSaveGainExecFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			gainMock.Id,
@@ -155,6 +161,7 @@ func TestSaveGainExecFail(t *testing.T) {
 			gainMock.PayIn,
 			gainMock.Description,
 			gainMock.Value,
+			gainMock.Currency,
 			gainMock.IsPassive,
 			gainMock.UserId,
 			gainMock.Category.Id).
@@ -183,7 +190,8 @@ func TestSaveGainCommitFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -191,8 +199,8 @@ func TestSaveGainCommitFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			gainMock.Id,
@@ -200,6 +208,7 @@ func TestSaveGainCommitFail(t *testing.T) {
 			gainMock.PayIn,
 			gainMock.Description,
 			gainMock.Value,
+			gainMock.Currency,
 			gainMock.IsPassive,
 			gainMock.UserId,
 			gainMock.Category.Id).
@@ -1,16 +1,23 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type Gain struct {
 	Id               string
 	CreatedAt        time.Time
 	PayIn            time.Time
 	Description      string
-	Value            float64
+	Value            money.Money
+	Currency         string
 	IsPassive        bool
 	GainProjectionId string
 	UserId           string
+	DeletedAt        time.Time
+	Version          uint
 	Category         GainCategory
 }
 
@@ -1,9 +0,0 @@
-package gainprojection
-
-type InvalidArgs struct {
-	message string
-}
-
-func (invalidArgs *InvalidArgs) Error() string {
-	return invalidArgs.message
-}
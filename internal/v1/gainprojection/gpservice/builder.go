@@ -1,15 +1,22 @@
 package gpservice
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type GainProjectionResponseBuilder struct {
 	id          string
 	payIn       time.Time
 	description string
-	value       float64
+	value       money.Money
+	currency    string
 	isPassive   bool
 	recurrence  uint
 	category    CategoryResponse
+	deletedAt   *time.Time
+	version     uint
 }
 
 func NewGainProjectionResponseBuilder() *GainProjectionResponseBuilder {
@@ -27,10 +34,14 @@ func (builder *GainProjectionResponseBuilder) AddDescription(description string)
 	builder.description = description
 	return builder
 }
-func (builder *GainProjectionResponseBuilder) AddValue(value float64) *GainProjectionResponseBuilder {
+func (builder *GainProjectionResponseBuilder) AddValue(value money.Money) *GainProjectionResponseBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *GainProjectionResponseBuilder) AddCurrency(currency string) *GainProjectionResponseBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *GainProjectionResponseBuilder) AddIsPassive(isPassive bool) *GainProjectionResponseBuilder {
 	builder.isPassive = isPassive
 	return builder
@@ -43,16 +54,27 @@ func (builder *GainProjectionResponseBuilder) AddCategory(category CategoryRespo
 	builder.category = category
 	return builder
 }
+func (builder *GainProjectionResponseBuilder) AddDeletedAt(deletedAt time.Time) *GainProjectionResponseBuilder {
+	builder.deletedAt = &deletedAt
+	return builder
+}
+func (builder *GainProjectionResponseBuilder) AddVersion(version uint) *GainProjectionResponseBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *GainProjectionResponseBuilder) Build() *GainProjectionResponse {
 	gainProjectionResponse := GainProjectionResponse{}
 
 	gainProjectionResponse.Id = builder.id
 	gainProjectionResponse.Description = builder.description
 	gainProjectionResponse.Value = builder.value
+	gainProjectionResponse.Currency = builder.currency
 	gainProjectionResponse.PayIn = builder.payIn
 	gainProjectionResponse.IsPassive = builder.isPassive
 	gainProjectionResponse.Recurrence = builder.recurrence
 	gainProjectionResponse.Category = builder.category
+	gainProjectionResponse.DeletedAt = builder.deletedAt
+	gainProjectionResponse.Version = builder.version
 
 	return &gainProjectionResponse
 }
@@ -99,7 +121,8 @@ type GainResponseBuilder struct {
 	id               string
 	payIn            time.Time
 	description      string
-	value            float64
+	value            money.Money
+	currency         string
 	isPassive        bool
 	gainProjectionId string
 	category         CategoryResponse
@@ -120,10 +143,14 @@ func (builder *GainResponseBuilder) AddDescription(description string) *GainResp
 	builder.description = description
 	return builder
 }
-func (builder *GainResponseBuilder) AddValue(value float64) *GainResponseBuilder {
+func (builder *GainResponseBuilder) AddValue(value money.Money) *GainResponseBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *GainResponseBuilder) AddCurrency(currency string) *GainResponseBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *GainResponseBuilder) AddIsPassive(isPassive bool) *GainResponseBuilder {
 	builder.isPassive = isPassive
 	return builder
@@ -142,6 +169,7 @@ func (builder *GainResponseBuilder) Build() *GainResponse {
 	gainResponse.Id = builder.id
 	gainResponse.Description = builder.description
 	gainResponse.Value = builder.value
+	gainResponse.Currency = builder.currency
 	gainResponse.PayIn = builder.payIn
 	gainResponse.IsPassive = builder.isPassive
 	gainResponse.GainProjectionId = builder.gainProjectionId
@@ -0,0 +1,48 @@
+package gpservice
+
+import (
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+// apply copies only the fields informed in the request over the stored gain projection
+func (request PatchRequest) apply(gainProjection *repository.GainProjection) {
+	if request.PayIn != nil {
+		gainProjection.PayIn = *request.PayIn
+	}
+	if request.Description != nil {
+		gainProjection.Description = *request.Description
+	}
+	if request.Value != nil {
+		gainProjection.Value = *request.Value
+	}
+	if request.Currency != nil {
+		gainProjection.Currency = money.NormalizeCurrency(*request.Currency)
+	}
+	if request.IsPassive != nil {
+		gainProjection.IsPassive = *request.IsPassive
+	}
+	if request.CategoryId != nil {
+		gainProjection.Category = repository.GainCategory{Id: *request.CategoryId}
+	}
+}
+
+func validatePatchedGainProjection(gainProjection repository.GainProjection) error {
+	errs := validation.Errors{}
+	if strings.TrimSpace(gainProjection.Description) == "" {
+		errs = append(errs, validation.Required("description"))
+	}
+	if gainProjection.Value <= 0 {
+		errs = append(errs, validation.FieldError{Field: "value", Code: validation.CODE_TOO_SMALL, Message: "The value must be greater than 0"})
+	}
+	if gainProjection.Category.Id == 0 {
+		errs = append(errs, validation.Required("category_id"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package gpservice
 
 import (
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
 )
@@ -8,6 +9,7 @@ import (
 type ReadingProcess interface {
 	GetById(searchCtx SearchContext) (*GainProjectionResponse, error)
 	GetAllPaginated(searchCtx SearchContext) (*GainProjectionPaginateResponse, error)
+	GetTrash(searchCtx SearchContext) (*GainProjectionTrashResponse, error)
 }
 
 type readingProcess struct {
@@ -25,7 +27,7 @@ func (rp *readingProcess) GetById(searchCtx SearchContext) (*GainProjectionRespo
 		return nil, err
 	}
 	if gainProjection == nil {
-		return nil, nil
+		return nil, apperror.NotFound("Gain projection not found")
 	}
 
 	return NewGainProjectionResponseBuilder().
@@ -33,8 +35,10 @@ func (rp *readingProcess) GetById(searchCtx SearchContext) (*GainProjectionRespo
 		AddPayIn(gainProjection.PayIn).
 		AddDescription(gainProjection.Description).
 		AddValue(gainProjection.Value).
+		AddCurrency(gainProjection.Currency).
 		AddIsPassive(gainProjection.IsPassive).
 		AddCategory(CategoryResponse{Id: gainProjection.Category.Id, Category: gainProjection.Category.Category}).
+		AddVersion(gainProjection.Version).
 		Build(), nil
 }
 
@@ -85,6 +89,7 @@ func (rp *readingProcess) GetAllPaginated(searchCtx SearchContext) (*GainProject
 			AddIsPassive(gainProjection.IsPassive).
 			AddPayIn(gainProjection.PayIn).
 			AddValue(gainProjection.Value).
+			AddCurrency(gainProjection.Currency).
 			Build()
 		gainProjectionResponseList = append(gainProjectionResponseList, *gainProjectionResponse)
 	}
@@ -97,3 +102,28 @@ func (rp *readingProcess) GetAllPaginated(searchCtx SearchContext) (*GainProject
 		Records:      gainProjectionResponseList,
 	}, nil
 }
+
+func (rp *readingProcess) GetTrash(searchCtx SearchContext) (*GainProjectionTrashResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	gainProjectionList, err := rp.repository.GetTrash(searchCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	gainProjectionResponseList := []GainProjectionResponse{}
+	for _, gainProjection := range *gainProjectionList {
+		gainProjectionResponse := NewGainProjectionResponseBuilder().
+			AddId(gainProjection.Id).
+			AddPayIn(gainProjection.PayIn).
+			AddDescription(gainProjection.Description).
+			AddValue(gainProjection.Value).
+			AddCurrency(gainProjection.Currency).
+			AddIsPassive(gainProjection.IsPassive).
+			AddCategory(CategoryResponse{Id: gainProjection.Category.Id, Category: gainProjection.Category.Category}).
+			AddDeletedAt(gainProjection.DeletedAt).
+			Build()
+		gainProjectionResponseList = append(gainProjectionResponseList, *gainProjectionResponse)
+	}
+
+	return &GainProjectionTrashResponse{Records: gainProjectionResponseList}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,7 +21,7 @@ func TestGetAllPaginatedSuccess(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
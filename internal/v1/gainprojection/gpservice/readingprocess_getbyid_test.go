@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,7 +22,7 @@ func TestGetByIdSuccess(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
@@ -58,7 +60,7 @@ func TestGetByIdNotFound(t *testing.T) {
 		Ctx:       ctx,
 	}
 	gainProject, err := _readingProcess.GetById(searchCtx)
-	assert.NoError(t, err)
+	assert.Equal(t, apperror.NotFound("Gain projection not found"), err)
 	assert.Empty(t, gainProject)
 }
 
@@ -2,32 +2,49 @@ package gpservice
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	uuid "github.com/satori/go.uuid"
 )
 
 type StorageProcess interface {
 	Create(createCtx CreateContext) (*GainProjectionResponse, error)
 	Update(updateCtx UpdateContext) (*GainProjectionResponse, error)
-	Delete(searchCtx SearchContext) error
+	Patch(patchCtx PatchContext) (*GainProjectionResponse, error)
+	Delete(deleteCtx DeleteContext) error
+	Restore(searchCtx SearchContext) (*GainProjectionResponse, error)
 	CreateGain(createGainCtx CreateGainContext) (*GainStat, error)
+	CreateGainBatch(createGainBatchCtx CreateGainBatchContext) (*GainBatchResponse, error)
+	RevertGain(revertGainCtx RevertGainContext) (*RevertGainStat, error)
 }
 
 type storageProcess struct {
-	repository   repository.Repository
-	generateUUID func() uuid.UUID
+	repository       repository.Repository
+	generateUUID     func() uuid.UUID
+	auditProcess     aservice.StorageProcess
+	referenceChecker validation.ReferenceChecker
+	transactor       dbtx.Transactor
 }
 
-func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID) StorageProcess {
-	return &storageProcess{repository: repository, generateUUID: generateUUID}
+func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID, auditProcess aservice.StorageProcess, referenceChecker validation.ReferenceChecker, transactor dbtx.Transactor) StorageProcess {
+	return &storageProcess{repository: repository, generateUUID: generateUUID, auditProcess: auditProcess, referenceChecker: referenceChecker, transactor: transactor}
 }
 
 func (sp *storageProcess) Create(createCtx CreateContext) (*GainProjectionResponse, error) {
 	request := createCtx.Request
 	user := idpauth.GetUser(createCtx.UserToken)
+	err := sp.checkReferences(createCtx.Ctx, request.CategoryId)
+	if err != nil {
+		return nil, err
+	}
 	createdAt := time.Now()
 	gainProjection := repository.NewGainProjectionBuilder().
 		AddId(sp.generateUUID().String()).
@@ -38,32 +55,50 @@ func (sp *storageProcess) Create(createCtx CreateContext) (*GainProjectionRespon
 		AddCategory(repository.GainCategory{Id: request.CategoryId}).
 		AddDescription(request.Description).
 		AddValue(request.Value).
+		AddCurrency(money.NormalizeCurrency(request.Currency)).
 		AddUserId(user.Id).
 		Build()
 
-	gainProjectionSaved, err := sp.repository.Save(createCtx.Ctx, *gainProjection)
-	if err != nil {
-		return nil, err
-	}
-
-	if request.Recurrence > 1 {
-		err = sp.createRecurrence(createCtx.Ctx, request, createdAt, user.Id)
+	var gainProjectionSaved *repository.GainProjection
+	err = sp.transactor.Within(createCtx.Ctx, func(ctx context.Context) error {
+		_, err := sp.repository.Save(ctx, *gainProjection)
 		if err != nil {
-			return nil, err
+			return err
 		}
-	} else {
-		request.Recurrence = 1
-	}
-	gainProjectionSaved, err = sp.repository.GetById(createCtx.Ctx, gainProjectionSaved.Id, user.Id)
+		err = sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_CREATE,
+			Entity:   aservice.ENTITY_GAIN_PROJECTION,
+			EntityId: gainProjection.Id,
+			After:    sp.buildResponse(gainProjection),
+		})
+		if err != nil {
+			return err
+		}
+
+		if request.Recurrence > 1 {
+			err = sp.createRecurrence(ctx, request, createdAt, user.Id)
+			if err != nil {
+				return err
+			}
+		}
+		gainProjectionSaved, err = sp.repository.GetById(ctx, gainProjection.Id, user.Id)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	if request.Recurrence <= 1 {
+		request.Recurrence = 1
+	}
 
 	return NewGainProjectionResponseBuilder().
 		AddId(gainProjection.Id).
 		AddPayIn(gainProjection.PayIn).
 		AddDescription(gainProjection.Description).
 		AddValue(gainProjection.Value).
+		AddCurrency(gainProjection.Currency).
 		AddIsPassive(gainProjection.IsPassive).
 		AddCategory(CategoryResponse{Id: gainProjectionSaved.Category.Id, Category: gainProjectionSaved.Category.Category}).
 		AddRecurrence(request.Recurrence).
@@ -81,6 +116,7 @@ func (sp *storageProcess) createRecurrence(ctx context.Context, request CreateRe
 			AddCategory(repository.GainCategory{Id: request.CategoryId}).
 			AddDescription(request.Description).
 			AddValue(request.Value).
+			AddCurrency(money.NormalizeCurrency(request.Currency)).
 			AddUserId(userId).
 			Build()
 
@@ -88,6 +124,17 @@ func (sp *storageProcess) createRecurrence(ctx context.Context, request CreateRe
 		if err != nil {
 			return err
 		}
+		err = sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   userId,
+			Action:   aservice.ACTION_CREATE,
+			Entity:   aservice.ENTITY_GAIN_PROJECTION,
+			EntityId: gainProjection.Id,
+			After:    sp.buildResponse(gainProjection),
+		})
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -101,39 +148,133 @@ func (sp *storageProcess) Update(updateCtx UpdateContext) (*GainProjectionRespon
 		AddIsPassive(request.IsPassive).
 		AddCategory(repository.GainCategory{Id: request.CategoryId}).
 		AddDescription(request.Description).
-		AddValue(request.Value)
+		AddValue(request.Value).
+		AddCurrency(money.NormalizeCurrency(request.Currency))
 	gainProjectionExists, err := sp.repository.GetById(updateCtx.Ctx, updateCtx.Id, user.Id)
 	if err != nil {
 		return nil, err
 	}
 	if gainProjectionExists == nil {
-		return nil, nil
+		return nil, apperror.NotFound("Gain projection not found")
+	}
+	err = sp.checkReferences(updateCtx.Ctx, request.CategoryId)
+	if err != nil {
+		return nil, err
 	}
 	gainProjectionBuilder.AddIsAlreadyDone(gainProjectionExists.IsAlreadyDone)
 	gainProjectionBuilder.AddUserId(user.Id)
-	gainProjectionUpdated, err := sp.repository.Edit(updateCtx.Ctx, *gainProjectionBuilder.Build())
+	gainProjectionBuilder.AddVersion(updateCtx.Version)
+	var gainProjectionResponse *GainProjectionResponse
+	err = sp.transactor.Within(updateCtx.Ctx, func(ctx context.Context) error {
+		gainProjectionUpdated, err := sp.repository.Edit(ctx, *gainProjectionBuilder.Build())
+		if err != nil {
+			return err
+		}
+
+		gainProjectionUpdated, err = sp.repository.GetById(ctx, gainProjectionUpdated.Id, user.Id)
+		if err != nil {
+			return err
+		}
+
+		gainProjectionResponse = NewGainProjectionResponseBuilder().
+			AddId(gainProjectionUpdated.Id).
+			AddPayIn(gainProjectionUpdated.PayIn).
+			AddDescription(gainProjectionUpdated.Description).
+			AddValue(gainProjectionUpdated.Value).
+			AddCurrency(gainProjectionUpdated.Currency).
+			AddIsPassive(gainProjectionUpdated.IsPassive).
+			AddCategory(CategoryResponse{Id: gainProjectionUpdated.Category.Id, Category: gainProjectionUpdated.Category.Category}).
+			AddVersion(gainProjectionUpdated.Version).
+			Build()
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_GAIN_PROJECTION,
+			EntityId: gainProjectionResponse.Id,
+			Before:   sp.buildResponse(gainProjectionExists),
+			After:    gainProjectionResponse,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
+	return gainProjectionResponse, nil
+}
 
-	gainProjectionUpdated, err = sp.repository.GetById(updateCtx.Ctx, gainProjectionUpdated.Id, user.Id)
+func (sp *storageProcess) Patch(patchCtx PatchContext) (*GainProjectionResponse, error) {
+	user := idpauth.GetUser(patchCtx.UserToken)
+	gainProjectionExists, err := sp.repository.GetById(patchCtx.Ctx, patchCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if gainProjectionExists == nil {
+		return nil, apperror.NotFound("Gain projection not found")
+	}
+	gainProjection := *gainProjectionExists
+	patchCtx.Request.apply(&gainProjection)
+	err = validatePatchedGainProjection(gainProjection)
 	if err != nil {
 		return nil, err
 	}
+	err = sp.checkReferences(patchCtx.Ctx, gainProjection.Category.Id)
+	if err != nil {
+		return nil, err
+	}
+	gainProjection.UserId = user.Id
+	gainProjection.Version = patchCtx.Version
+	var gainProjectionResponse *GainProjectionResponse
+	err = sp.transactor.Within(patchCtx.Ctx, func(ctx context.Context) error {
+		gainProjectionUpdated, err := sp.repository.Edit(ctx, gainProjection)
+		if err != nil {
+			return err
+		}
 
-	return NewGainProjectionResponseBuilder().
-		AddId(gainProjectionUpdated.Id).
-		AddPayIn(gainProjectionUpdated.PayIn).
-		AddDescription(gainProjectionUpdated.Description).
-		AddValue(gainProjectionUpdated.Value).
-		AddIsPassive(gainProjectionUpdated.IsPassive).
-		AddCategory(CategoryResponse{Id: gainProjectionUpdated.Category.Id, Category: gainProjectionUpdated.Category.Category}).
-		Build(), nil
+		gainProjectionUpdated, err = sp.repository.GetById(ctx, gainProjectionUpdated.Id, user.Id)
+		if err != nil {
+			return err
+		}
+
+		gainProjectionResponse = sp.buildResponse(gainProjectionUpdated)
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_GAIN_PROJECTION,
+			EntityId: gainProjectionResponse.Id,
+			Before:   sp.buildResponse(gainProjectionExists),
+			After:    gainProjectionResponse,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gainProjectionResponse, nil
 }
 
-func (sp *storageProcess) Delete(searchCtx SearchContext) error {
-	user := idpauth.GetUser(searchCtx.UserToken)
-	return sp.repository.Remove(searchCtx.Ctx, searchCtx.Id, user.Id)
+func (sp *storageProcess) Delete(deleteCtx DeleteContext) error {
+	user := idpauth.GetUser(deleteCtx.UserToken)
+	gainProjectionExists, err := sp.repository.GetById(deleteCtx.Ctx, deleteCtx.Id, user.Id)
+	if err != nil {
+		return err
+	}
+	if gainProjectionExists == nil {
+		return apperror.NotFound("Gain projection not found")
+	}
+	return sp.transactor.Within(deleteCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.Remove(ctx, deleteCtx.Id, user.Id, deleteCtx.Version)
+		if err != nil {
+			return err
+		}
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_DELETE,
+			Entity:   aservice.ENTITY_GAIN_PROJECTION,
+			EntityId: gainProjectionExists.Id,
+			Before:   sp.buildResponse(gainProjectionExists),
+		})
+	})
 }
 
 func (sp *storageProcess) CreateGain(createGainCtx CreateGainContext) (*GainStat, error) {
@@ -149,6 +290,119 @@ func (sp *storageProcess) CreateGain(createGainCtx CreateGainContext) (*GainStat
 	if gainProjection.IsAlreadyDone == true {
 		return &GainStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: true}, nil
 	}
+	var gainResponse *GainResponse
+	err = sp.transactor.Within(createGainCtx.Ctx, func(ctx context.Context) error {
+		gain, err := sp.repository.SaveGain(ctx, *sp.buildGain(gainProjection, request))
+		if err != nil {
+			return err
+		}
+		gainProjectionBefore := sp.buildResponse(gainProjection)
+		gainProjection.IsAlreadyDone = true
+		_, err = sp.repository.Edit(ctx, *gainProjection)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return apperror.Conflict("The gain projection was changed by another request")
+		}
+		if err != nil {
+			return err
+		}
+
+		gainResponse = sp.buildGainResponse(gain)
+		return sp.recordRealization(ctx, user.Id, gainProjectionBefore, gainResponse)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GainStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: false, Gain: gainResponse}, nil
+}
+
+func (sp *storageProcess) CreateGainBatch(createGainBatchCtx CreateGainBatchContext) (*GainBatchResponse, error) {
+	request := createGainBatchCtx.Request
+	user := idpauth.GetUser(createGainBatchCtx.UserToken)
+
+	projectionIds := []string{}
+	overrides := map[string]CreateGainRequest{}
+	for _, item := range request.Items {
+		if _, exists := overrides[item.Id]; !exists {
+			projectionIds = append(projectionIds, item.Id)
+		}
+		overrides[item.Id] = CreateGainRequest{Value: item.Value, PayIn: item.PayIn}
+	}
+	gainProjections := map[string]*repository.GainProjection{}
+	if request.Filter != nil {
+		queryParams := repository.NewQueryParamsBuilder().
+			AddMonth(request.Filter.Month).
+			AddYear(request.Filter.Year).
+			AddCategoryId(request.Filter.CategoryId).
+			AddUserId(user.Id).
+			Build()
+		pendingList, err := sp.repository.GetAllPending(createGainBatchCtx.Ctx, queryParams)
+		if err != nil {
+			return nil, err
+		}
+		for i := range *pendingList {
+			gainProjection := &(*pendingList)[i]
+			if _, exists := overrides[gainProjection.Id]; !exists {
+				projectionIds = append(projectionIds, gainProjection.Id)
+			}
+			gainProjections[gainProjection.Id] = gainProjection
+		}
+	}
+
+	results := []GainBatchItemResult{}
+	gains := []repository.Gain{}
+	gainProjectionsBefore := []*GainProjectionResponse{}
+	for _, projectionId := range projectionIds {
+		gainProjection, found := gainProjections[projectionId]
+		if !found {
+			var err error
+			gainProjection, err = sp.repository.GetById(createGainBatchCtx.Ctx, projectionId, user.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if gainProjection == nil {
+			results = append(results, GainBatchItemResult{ProjectionId: projectionId, GainStat: GainStat{ProjectionIsFound: false}})
+			continue
+		}
+		if gainProjection.IsAlreadyDone {
+			results = append(results, GainBatchItemResult{ProjectionId: projectionId, GainStat: GainStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: true}})
+			continue
+		}
+		gain := sp.buildGain(gainProjection, overrides[projectionId])
+		gains = append(gains, *gain)
+		gainProjectionsBefore = append(gainProjectionsBefore, sp.buildResponse(gainProjection))
+		results = append(results, GainBatchItemResult{
+			ProjectionId: projectionId,
+			GainStat:     GainStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: false, Gain: sp.buildGainResponse(gain)},
+		})
+	}
+	if len(gains) == 0 {
+		return &GainBatchResponse{TotalCreated: 0, Results: results}, nil
+	}
+
+	err := sp.transactor.Within(createGainBatchCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.RealizeGains(ctx, gains)
+		if errors.Is(err, repository.ErrAlreadyDone) {
+			return apperror.Conflict("A gain projection of the batch is already done")
+		}
+		if err != nil {
+			return err
+		}
+		for i := range gains {
+			err = sp.recordRealization(ctx, user.Id, gainProjectionsBefore[i], sp.buildGainResponse(&gains[i]))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GainBatchResponse{TotalCreated: uint(len(gains)), Results: results}, nil
+}
+
+func (sp *storageProcess) buildGain(gainProjection *repository.GainProjection, request CreateGainRequest) *repository.Gain {
 	gainBuilder := repository.NewGainBuilder().
 		AddId(sp.generateUUID().String()).
 		AddCategory(gainProjection.Category).
@@ -158,6 +412,7 @@ func (sp *storageProcess) CreateGain(createGainCtx CreateGainContext) (*GainStat
 		AddIsPassive(gainProjection.IsPassive).
 		AddUserId(gainProjection.UserId).
 		AddValue(gainProjection.Value).
+		AddCurrency(gainProjection.Currency).
 		AddPayIn(gainProjection.PayIn)
 	if request.Value != 0 {
 		gainBuilder.AddValue(request.Value)
@@ -165,24 +420,195 @@ func (sp *storageProcess) CreateGain(createGainCtx CreateGainContext) (*GainStat
 	if !request.PayIn.IsZero() {
 		gainBuilder.AddPayIn(request.PayIn)
 	}
-	gain, err := sp.repository.SaveGain(createGainCtx.Ctx, *gainBuilder.Build())
+	return gainBuilder.Build()
+}
+
+func (sp *storageProcess) recordRealization(ctx context.Context, userId string, gainProjectionBefore *GainProjectionResponse, gainResponse *GainResponse) error {
+	err := sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_CREATE,
+		Entity:   aservice.ENTITY_GAIN,
+		EntityId: gainResponse.Id,
+		After:    gainResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_REALIZE,
+		Entity:   aservice.ENTITY_GAIN_PROJECTION,
+		EntityId: gainProjectionBefore.Id,
+		Before:   gainProjectionBefore,
+		After:    gainResponse,
+	})
+}
+
+func (sp *storageProcess) RevertGain(revertGainCtx RevertGainContext) (*RevertGainStat, error) {
+	request := revertGainCtx.Request
+	user := idpauth.GetUser(revertGainCtx.UserToken)
+	gainProjection, err := sp.repository.GetById(revertGainCtx.Ctx, revertGainCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if gainProjection == nil {
+		return &RevertGainStat{ProjectionIsFound: false}, nil
+	}
+	if !gainProjection.IsAlreadyDone {
+		return &RevertGainStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: false}, nil
+	}
+	gain, err := sp.repository.GetGainByProjectionId(revertGainCtx.Ctx, gainProjection.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if gain != nil && gain.DeletedAt.IsZero() {
+		gainIsModified := sp.isGainModified(gain)
+		if gainIsModified && !request.KeepGain && !request.Force {
+			return &RevertGainStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: true, GainIsModified: true}, nil
+		}
+	}
+
+	gainProjectionBefore := sp.buildResponse(gainProjection)
+	gainProjection.IsAlreadyDone = false
+	gainProjectionResponse := sp.buildResponse(gainProjection)
+	err = sp.transactor.Within(revertGainCtx.Ctx, func(ctx context.Context) error {
+		if gain != nil {
+			err := sp.detachGain(ctx, user.Id, gain, request.KeepGain)
+			if err != nil {
+				return err
+			}
+		}
+		_, err := sp.repository.Edit(ctx, *gainProjection)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return apperror.Conflict("The gain projection was changed by another request")
+		}
+		if err != nil {
+			return err
+		}
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_REVERT,
+			Entity:   aservice.ENTITY_GAIN_PROJECTION,
+			EntityId: gainProjection.Id,
+			Before:   gainProjectionBefore,
+			After:    gainProjectionResponse,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
-	gainProjection.IsAlreadyDone = true
-	_, err = sp.repository.Edit(createGainCtx.Ctx, *gainProjection)
+	return &RevertGainStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: true, GainProjection: gainProjectionResponse}, nil
+}
+
+// detachGain removes the realized gain to the trash or, when asked to keep it, only drops its link to the projection.
+// A gain already in the trash only has its link dropped, so restoring it later does not realize the projection twice
+func (sp *storageProcess) detachGain(ctx context.Context, userId string, gain *repository.Gain, keepGain bool) error {
+	gainBefore := sp.buildGainResponse(gain)
+	if keepGain || !gain.DeletedAt.IsZero() {
+		err := sp.repository.UnlinkGain(ctx, gain.Id, userId)
+		if err != nil {
+			return err
+		}
+		gain.GainProjectionId = ""
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   userId,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_GAIN,
+			EntityId: gain.Id,
+			Before:   gainBefore,
+			After:    sp.buildGainResponse(gain),
+		})
+	}
+	err := sp.repository.RemoveGain(ctx, gain.Id, userId)
+	if err != nil {
+		return err
+	}
+	return sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_DELETE,
+		Entity:   aservice.ENTITY_GAIN,
+		EntityId: gain.Id,
+		Before:   gainBefore,
+	})
+}
+
+// isGainModified reports whether the realized gain was edited after being created from the projection. The gain
+// is created with the version 1 and each edit increments it, so the values informed when realizing it are not edits
+func (sp *storageProcess) isGainModified(gain *repository.Gain) bool {
+	return gain.Version > 1
+}
+
+func (sp *storageProcess) Restore(searchCtx SearchContext) (*GainProjectionResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	var gainProjectionResponse *GainProjectionResponse
+	err := sp.transactor.Within(searchCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.Restore(ctx, searchCtx.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		gainProjectionRestored, err := sp.repository.GetById(ctx, searchCtx.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		if gainProjectionRestored == nil {
+			return apperror.NotFound("Gain projection not found")
+		}
+
+		gainProjectionResponse = sp.buildResponse(gainProjectionRestored)
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_RESTORE,
+			Entity:   aservice.ENTITY_GAIN_PROJECTION,
+			EntityId: gainProjectionResponse.Id,
+			After:    gainProjectionResponse,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
+	return gainProjectionResponse, nil
+}
+
+// checkReferences reports as a validation error the category that is not registered
+func (sp *storageProcess) checkReferences(ctx context.Context, categoryId uint) error {
+	categoryExists, err := sp.referenceChecker.GainCategoryExists(ctx, categoryId)
+	if err != nil {
+		return err
+	}
+	if !categoryExists {
+		return validation.Errors{validation.NotFound("category_id")}
+	}
+	return nil
+}
 
-	gainResponse := NewGainResponseBuilder().
+func (sp *storageProcess) buildResponse(gainProjection *repository.GainProjection) *GainProjectionResponse {
+	return NewGainProjectionResponseBuilder().
+		AddId(gainProjection.Id).
+		AddPayIn(gainProjection.PayIn).
+		AddDescription(gainProjection.Description).
+		AddValue(gainProjection.Value).
+		AddCurrency(gainProjection.Currency).
+		AddIsPassive(gainProjection.IsPassive).
+		AddCategory(CategoryResponse{Id: gainProjection.Category.Id, Category: gainProjection.Category.Category}).
+		AddVersion(gainProjection.Version).
+		Build()
+}
+
+func (sp *storageProcess) buildGainResponse(gain *repository.Gain) *GainResponse {
+	return NewGainResponseBuilder().
 		AddId(gain.Id).
 		AddGainProjectionId(gain.GainProjectionId).
 		AddPayIn(gain.PayIn).
 		AddDescription(gain.Description).
 		AddValue(gain.Value).
+		AddCurrency(gain.Currency).
 		AddIsPassive(gain.IsPassive).
 		AddCategory(CategoryResponse{Id: gain.Category.Id, Category: gain.Category.Category}).
 		Build()
-	return &GainStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: false, Gain: gainResponse}, nil
 }
@@ -0,0 +1,267 @@
+package gpservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateGainBatchSuccess(t *testing.T) {
+
+	createdAt := time.Now()
+	gainProjectionAMock := repository.NewGainProjectionBuilder().
+		AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddCreatedAt(createdAt).
+		AddPayIn(createdAt).
+		AddIsPassive(true).
+		AddIsAlreadyDone(false).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		Build()
+	gainProjectionCMock := repository.NewGainProjectionBuilder().
+		AddId("b0e7b1a8-3f38-4b33-9b8e-52f5d0a3c6f4").
+		AddCreatedAt(createdAt).
+		AddPayIn(createdAt).
+		AddIsPassive(false).
+		AddIsAlreadyDone(false).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste 2").
+		AddValue(money.FromCents(10000)).
+		AddUserId("User1").
+		Build()
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetAllPendingCall(func(ctx context.Context, params repository.QueryParams) (*[]repository.GainProjection, error) {
+		return &[]repository.GainProjection{*gainProjectionAMock, *gainProjectionCMock}, nil
+	})
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		assert.Equal(t, "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a", id)
+		return nil, nil
+	})
+	var gainsRealized []repository.Gain
+	_mockRepository.AddRealizeGainsCall(func(ctx context.Context, gains []repository.Gain) error {
+		gainsRealized = gains
+		return nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	var actionsRecorded []string
+	recordMock := func(recordCtx aservice.RecordContext) error {
+		actionsRecorded = append(actionsRecorded, recordCtx.Action)
+		return nil
+	}
+	_mockAuditProcess := &mockAuditProcess{}
+	_mockAuditProcess.AddRecordCall(recordMock).AddRecordCall(recordMock).AddRecordCall(recordMock).AddRecordCall(recordMock)
+
+	request := CreateGainBatchRequest{
+		Items: []CreateGainBatchItem{
+			{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Value: money.FromCents(80000)},
+			{Id: "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a"},
+		},
+		Filter: &CreateGainBatchFilter{Month: 10, Year: 2024, CategoryId: 2},
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createGainBatchCtx := CreateGainBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	response, err := _storageProcess.CreateGainBatch(createGainBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), response.TotalCreated)
+	assert.Equal(t, 3, len(response.Results))
+
+	assert.Equal(t, "cd1cc27b-28a1-47dc-ac76-70e8185e159d", response.Results[0].ProjectionId)
+	assert.True(t, response.Results[0].ProjectionIsFound)
+	assert.Equal(t, money.FromCents(80000), response.Results[0].Gain.Value)
+
+	assert.Equal(t, "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a", response.Results[1].ProjectionId)
+	assert.False(t, response.Results[1].ProjectionIsFound)
+	assert.Nil(t, response.Results[1].Gain)
+
+	assert.Equal(t, "b0e7b1a8-3f38-4b33-9b8e-52f5d0a3c6f4", response.Results[2].ProjectionId)
+	assert.True(t, response.Results[2].ProjectionIsFound)
+	assert.Equal(t, money.FromCents(10000), response.Results[2].Gain.Value)
+
+	assert.Equal(t, 2, len(gainsRealized))
+	assert.Equal(t, "cd1cc27b-28a1-47dc-ac76-70e8185e159d", gainsRealized[0].GainProjectionId)
+	assert.Equal(t, "b0e7b1a8-3f38-4b33-9b8e-52f5d0a3c6f4", gainsRealized[1].GainProjectionId)
+	assert.Equal(t, []string{aservice.ACTION_CREATE, aservice.ACTION_REALIZE, aservice.ACTION_CREATE, aservice.ACTION_REALIZE}, actionsRecorded)
+}
+
+func TestCreateGainBatchAlreadyDone(t *testing.T) {
+
+	createdAt := time.Now()
+	gainProjectMock := repository.NewGainProjectionBuilder().
+		AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddCreatedAt(createdAt).
+		AddPayIn(createdAt).
+		AddIsPassive(true).
+		AddIsAlreadyDone(true).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		Build()
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddRealizeGainsCall(func(ctx context.Context, gains []repository.Gain) error {
+		t.Error("RealizeGains should not be called when there is nothing to realize")
+		return nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	request := CreateGainBatchRequest{
+		Items: []CreateGainBatchItem{{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d"}},
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createGainBatchCtx := CreateGainBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	response, err := _storageProcess.CreateGainBatch(createGainBatchCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), response.TotalCreated)
+	assert.Equal(t, 1, len(response.Results))
+	assert.True(t, response.Results[0].ProjectionIsFound)
+	assert.True(t, response.Results[0].ProjectionIsAlreadyDone)
+}
+
+func TestCreateGainBatchGetAllPendingFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetAllPendingCall(func(ctx context.Context, params repository.QueryParams) (*[]repository.GainProjection, error) {
+		return nil, errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	request := CreateGainBatchRequest{
+		Filter: &CreateGainBatchFilter{Month: 10, Year: 2024},
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createGainBatchCtx := CreateGainBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	_, err := _storageProcess.CreateGainBatch(createGainBatchCtx)
+	assert.Error(t, err)
+}
+
+func TestCreateGainBatchRealizeGainsFail(t *testing.T) {
+
+	createdAt := time.Now()
+	gainProjectMock := repository.NewGainProjectionBuilder().
+		AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddCreatedAt(createdAt).
+		AddPayIn(createdAt).
+		AddIsPassive(true).
+		AddIsAlreadyDone(false).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		Build()
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetAllPendingCall(func(ctx context.Context, params repository.QueryParams) (*[]repository.GainProjection, error) {
+		return &[]repository.GainProjection{*gainProjectMock}, nil
+	})
+	_mockRepository.AddRealizeGainsCall(func(ctx context.Context, gains []repository.Gain) error {
+		return errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	request := CreateGainBatchRequest{
+		Filter: &CreateGainBatchFilter{Month: 10, Year: 2024},
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createGainBatchCtx := CreateGainBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	_, err := _storageProcess.CreateGainBatch(createGainBatchCtx)
+	assert.Error(t, err)
+}
+
+func TestCreateGainBatchRealizedConcurrently(t *testing.T) {
+
+	createdAt := time.Now()
+	gainProjectMock := repository.NewGainProjectionBuilder().
+		AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddCreatedAt(createdAt).
+		AddPayIn(createdAt).
+		AddIsPassive(true).
+		AddIsAlreadyDone(false).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		Build()
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetAllPendingCall(func(ctx context.Context, params repository.QueryParams) (*[]repository.GainProjection, error) {
+		return &[]repository.GainProjection{*gainProjectMock}, nil
+	})
+	_mockRepository.AddRealizeGainsCall(func(ctx context.Context, gains []repository.Gain) error {
+		return repository.ErrAlreadyDone
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	request := CreateGainBatchRequest{
+		Filter: &CreateGainBatchFilter{Month: 10, Year: 2024},
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createGainBatchCtx := CreateGainBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	_, err := _storageProcess.CreateGainBatch(createGainBatchCtx)
+	assert.Equal(t, apperror.KIND_CONFLICT, apperror.KindOf(err))
+}
@@ -6,19 +6,29 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 type mockRepository struct {
-	saveCallsMock            []func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error)
-	getByIdCallsMock         []func(ctx context.Context, id string, userId string) (*repository.GainProjection, error)
-	editCallsMock            []func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error)
-	removeCallsMock          []func(ctx context.Context, id string, userId string) error
-	getTotalRecordsCallsMock []func(ctx context.Context, params repository.QueryParams) (*uint, error)
-	getAllCallsMock          []func(ctx context.Context, params repository.QueryParams) (*[]repository.GainProjection, error)
-	saveGainCallsMock        []func(ctx context.Context, gain repository.Gain) (*repository.Gain, error)
+	saveCallsMock                  []func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error)
+	getByIdCallsMock               []func(ctx context.Context, id string, userId string) (*repository.GainProjection, error)
+	editCallsMock                  []func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error)
+	removeCallsMock                []func(ctx context.Context, id string, userId string, version uint) error
+	getTotalRecordsCallsMock       []func(ctx context.Context, params repository.QueryParams) (*uint, error)
+	getAllCallsMock                []func(ctx context.Context, params repository.QueryParams) (*[]repository.GainProjection, error)
+	saveGainCallsMock              []func(ctx context.Context, gain repository.Gain) (*repository.Gain, error)
+	getTrashCallsMock              []func(ctx context.Context, userId string) (*[]repository.GainProjection, error)
+	restoreCallsMock               []func(ctx context.Context, id string, userId string) error
+	getGainByProjectionIdCallsMock []func(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error)
+	removeGainCallsMock            []func(ctx context.Context, id string, userId string) error
+	unlinkGainCallsMock            []func(ctx context.Context, id string, userId string) error
+	getAllPendingCallsMock         []func(ctx context.Context, params repository.QueryParams) (*[]repository.GainProjection, error)
+	realizeGainsCallsMock          []func(ctx context.Context, gains []repository.Gain) error
 }
 
 func (r *mockRepository) AddSaveCall(
@@ -40,7 +50,7 @@ func (r *mockRepository) AddEditCall(
 }
 
 func (r *mockRepository) AddRemoveCall(
-	remove func(ctx context.Context, id string, userId string) error) *mockRepository {
+	remove func(ctx context.Context, id string, userId string, version uint) error) *mockRepository {
 	r.removeCallsMock = append(r.removeCallsMock, remove)
 	return r
 }
@@ -90,11 +100,11 @@ func (r *mockRepository) GetById(ctx context.Context, id string, userId string)
 	return nil, nil
 }
 
-func (r *mockRepository) Remove(ctx context.Context, id string, userId string) error {
+func (r *mockRepository) Remove(ctx context.Context, id string, userId string, version uint) error {
 	if len(r.removeCallsMock) >= 1 {
 		remove := r.removeCallsMock[0]
 		r.removeCallsMock = r.removeCallsMock[1:]
-		return remove(ctx, id, userId)
+		return remove(ctx, id, userId, version)
 	}
 	return nil
 }
@@ -126,6 +136,121 @@ func (r *mockRepository) SaveGain(ctx context.Context, gain repository.Gain) (*r
 	return nil, nil
 }
 
+func (r *mockRepository) AddGetGainByProjectionIdCall(
+	getGainByProjectionId func(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error)) *mockRepository {
+	r.getGainByProjectionIdCallsMock = append(r.getGainByProjectionIdCallsMock, getGainByProjectionId)
+	return r
+}
+
+func (r *mockRepository) AddRemoveGainCall(
+	removeGain func(ctx context.Context, id string, userId string) error) *mockRepository {
+	r.removeGainCallsMock = append(r.removeGainCallsMock, removeGain)
+	return r
+}
+
+func (r *mockRepository) AddUnlinkGainCall(
+	unlinkGain func(ctx context.Context, id string, userId string) error) *mockRepository {
+	r.unlinkGainCallsMock = append(r.unlinkGainCallsMock, unlinkGain)
+	return r
+}
+
+func (r *mockRepository) GetGainByProjectionId(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error) {
+	if len(r.getGainByProjectionIdCallsMock) >= 1 {
+		getGainByProjectionId := r.getGainByProjectionIdCallsMock[0]
+		r.getGainByProjectionIdCallsMock = r.getGainByProjectionIdCallsMock[1:]
+		return getGainByProjectionId(ctx, gainProjectionId, userId)
+	}
+	return nil, nil
+}
+
+func (r *mockRepository) RemoveGain(ctx context.Context, id string, userId string) error {
+	if len(r.removeGainCallsMock) >= 1 {
+		removeGain := r.removeGainCallsMock[0]
+		r.removeGainCallsMock = r.removeGainCallsMock[1:]
+		return removeGain(ctx, id, userId)
+	}
+	return nil
+}
+
+func (r *mockRepository) UnlinkGain(ctx context.Context, id string, userId string) error {
+	if len(r.unlinkGainCallsMock) >= 1 {
+		unlinkGain := r.unlinkGainCallsMock[0]
+		r.unlinkGainCallsMock = r.unlinkGainCallsMock[1:]
+		return unlinkGain(ctx, id, userId)
+	}
+	return nil
+}
+
+func (r *mockRepository) AddGetTrashCall(
+	getTrash func(ctx context.Context, userId string) (*[]repository.GainProjection, error)) *mockRepository {
+	r.getTrashCallsMock = append(r.getTrashCallsMock, getTrash)
+	return r
+}
+
+func (r *mockRepository) AddRestoreCall(
+	restore func(ctx context.Context, id string, userId string) error) *mockRepository {
+	r.restoreCallsMock = append(r.restoreCallsMock, restore)
+	return r
+}
+
+func (r *mockRepository) GetTrash(ctx context.Context, userId string) (*[]repository.GainProjection, error) {
+	if len(r.getTrashCallsMock) >= 1 {
+		getTrash := r.getTrashCallsMock[0]
+		r.getTrashCallsMock = r.getTrashCallsMock[1:]
+		return getTrash(ctx, userId)
+	}
+	return nil, nil
+}
+
+func (r *mockRepository) Restore(ctx context.Context, id string, userId string) error {
+	if len(r.restoreCallsMock) >= 1 {
+		restore := r.restoreCallsMock[0]
+		r.restoreCallsMock = r.restoreCallsMock[1:]
+		return restore(ctx, id, userId)
+	}
+	return nil
+}
+
+func (r *mockRepository) Purge(ctx context.Context, deletedBefore time.Time) error {
+	return nil
+}
+
+type mockAuditProcess struct {
+	recordCallsMock []func(recordCtx aservice.RecordContext) error
+}
+
+func (a *mockAuditProcess) AddRecordCall(record func(recordCtx aservice.RecordContext) error) *mockAuditProcess {
+	a.recordCallsMock = append(a.recordCallsMock, record)
+	return a
+}
+
+func (a *mockAuditProcess) Record(recordCtx aservice.RecordContext) error {
+	if len(a.recordCallsMock) >= 1 {
+		record := a.recordCallsMock[0]
+		a.recordCallsMock = a.recordCallsMock[1:]
+		return record(recordCtx)
+	}
+	return nil
+}
+
+type mockReferenceChecker struct {
+	missingCategories   map[uint]bool
+	missingPaymentTypes map[uint]bool
+	err                 error
+}
+
+func (rc *mockReferenceChecker) GainCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) InvoiceCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) PaymentTypeExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingPaymentTypes[id], rc.err
+}
+
 func TestCreateSuccessWithoutRecurrence(t *testing.T) {
 
 	createdAt := time.Now()
@@ -137,7 +262,7 @@ func TestCreateSuccessWithoutRecurrence(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
@@ -155,13 +280,13 @@ func TestCreateSuccessWithoutRecurrence(t *testing.T) {
 	request := CreateRequest{
 		PayIn:       time.Now(),
 		Description: "Description teste",
-		Value:       750.50,
+		Value:       money.FromCents(75050),
 		IsPassive:   false,
 		CategoryId:  2,
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
 	createCtx := CreateContext{
@@ -186,7 +311,7 @@ func TestCreateSuccessWithRecurrence(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
@@ -207,14 +332,14 @@ func TestCreateSuccessWithRecurrence(t *testing.T) {
 	request := CreateRequest{
 		PayIn:       time.Now(),
 		Description: "Description teste",
-		Value:       750.50,
+		Value:       money.FromCents(75050),
 		IsPassive:   false,
 		Recurrence:  2,
 		CategoryId:  2,
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
 	createCtx := CreateContext{
@@ -242,13 +367,13 @@ func TestCreateWithoutRecurrenceSaveFail(t *testing.T) {
 	request := CreateRequest{
 		PayIn:       time.Now(),
 		Description: "Description teste",
-		Value:       750.50,
+		Value:       money.FromCents(75050),
 		IsPassive:   false,
 		CategoryId:  2,
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
 	createCtx := CreateContext{
@@ -272,7 +397,7 @@ func TestCreateWithoutRecurrenceGetByIdFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
@@ -290,13 +415,13 @@ func TestCreateWithoutRecurrenceGetByIdFail(t *testing.T) {
 	request := CreateRequest{
 		PayIn:       time.Now(),
 		Description: "Description teste",
-		Value:       750.50,
+		Value:       money.FromCents(75050),
 		IsPassive:   false,
 		CategoryId:  2,
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
 	createCtx := CreateContext{
@@ -319,7 +444,7 @@ func TestCreateWithRecurrenceSaveFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
 		AddDescription("Description teste").
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
@@ -337,14 +462,14 @@ func TestCreateWithRecurrenceSaveFail(t *testing.T) {
 	request := CreateRequest{
 		PayIn:       time.Now(),
 		Description: "Description teste",
-		Value:       750.50,
+		Value:       money.FromCents(75050),
 		IsPassive:   false,
 		Recurrence:  2,
 		CategoryId:  2,
 	}
 	ctx := context.TODO()
 
-	_storageProcess := NewStorageProcess(_mockRepository, uuidMock)
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
 
 	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
 	createCtx := CreateContext{
@@ -355,3 +480,107 @@ func TestCreateWithRecurrenceSaveFail(t *testing.T) {
 	_, err := _storageProcess.Create(createCtx)
 	assert.Error(t, err)
 }
+
+func (r *mockRepository) AddGetAllPendingCall(
+	getAllPending func(ctx context.Context, params repository.QueryParams) (*[]repository.GainProjection, error)) *mockRepository {
+	r.getAllPendingCallsMock = append(r.getAllPendingCallsMock, getAllPending)
+	return r
+}
+
+func (r *mockRepository) AddRealizeGainsCall(
+	realizeGains func(ctx context.Context, gains []repository.Gain) error) *mockRepository {
+	r.realizeGainsCallsMock = append(r.realizeGainsCallsMock, realizeGains)
+	return r
+}
+
+func (r *mockRepository) GetAllPending(ctx context.Context, params repository.QueryParams) (*[]repository.GainProjection, error) {
+	if len(r.getAllPendingCallsMock) >= 1 {
+		getAllPending := r.getAllPendingCallsMock[0]
+		r.getAllPendingCallsMock = r.getAllPendingCallsMock[1:]
+		return getAllPending(ctx, params)
+	}
+	return nil, nil
+}
+
+func (r *mockRepository) RealizeGains(ctx context.Context, gains []repository.Gain) error {
+	if len(r.realizeGainsCallsMock) >= 1 {
+		realizeGains := r.realizeGainsCallsMock[0]
+		r.realizeGainsCallsMock = r.realizeGainsCallsMock[1:]
+		return realizeGains(ctx, gains)
+	}
+	return nil
+}
+
+func TestCreateCategoryNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		t.Error("Save should not be called when a reference is not valid")
+		return nil, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+	}
+
+	request := CreateRequest{
+		PayIn:       time.Now(),
+		Description: "Description teste",
+		Value:       money.FromCents(75050),
+		IsPassive:   false,
+		CategoryId:  2,
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{missingCategories: map[uint]bool{2: true}}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createCtx := CreateContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	response, err := _storageProcess.Create(createCtx)
+	var errs validation.Errors
+	assert.ErrorAs(t, err, &errs)
+	assert.Equal(t, validation.Errors{validation.NotFound("category_id")}, errs)
+	assert.Empty(t, response)
+}
+
+func TestCreateReferenceCheckFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		t.Error("Save should not be called when a reference is not valid")
+		return nil, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+	}
+
+	request := CreateRequest{
+		PayIn:       time.Now(),
+		Description: "Description teste",
+		Value:       money.FromCents(75050),
+		IsPassive:   false,
+		CategoryId:  2,
+	}
+	ctx := context.TODO()
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{err: errors.New("An error has been ocurred")}, &mockTransactor{})
+
+	token := "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+	createCtx := CreateContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: token,
+	}
+	response, err := _storageProcess.Create(createCtx)
+	assert.EqualError(t, err, "An error has been ocurred")
+	assert.Empty(t, response)
+}
+
+type mockTransactor struct{}
+
+func (t *mockTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
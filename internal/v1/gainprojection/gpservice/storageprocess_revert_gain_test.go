@@ -0,0 +1,380 @@
+package gpservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildRevertGainMocks(payIn time.Time) (*repository.GainProjection, *repository.Gain) {
+	gainProjectMock := repository.NewGainProjectionBuilder().
+		AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddPayIn(payIn).
+		AddIsAlreadyDone(true).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		Build()
+	gainMock := repository.NewGainBuilder().
+		AddId("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628").
+		AddPayIn(payIn).
+		AddCategory(repository.GainCategory{Id: 2, Category: "Salário"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		AddGainProjectionId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddVersion(1).
+		Build()
+	return gainProjectMock, gainMock
+}
+
+func newRevertGainCtx(request RevertGainRequest) RevertGainContext {
+	return RevertGainContext{
+		Ctx:       context.TODO(),
+		Request:   request,
+		Id:        "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+}
+
+func TestRevertGainSuccess(t *testing.T) {
+	gainProjectMock, gainMock := buildRevertGainMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddGetGainByProjectionIdCall(func(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	var gainRemovedId string
+	_mockRepository.AddRemoveGainCall(func(ctx context.Context, id string, userId string) error {
+		gainRemovedId = id
+		return nil
+	})
+	var gainProjectionEdited repository.GainProjection
+	_mockRepository.AddEditCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		gainProjectionEdited = gainProjection
+		return &gainProjection, nil
+	})
+	var recordCtxList []aservice.RecordContext
+	_mockAuditProcess := &mockAuditProcess{}
+	for i := 0; i < 2; i++ {
+		_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+			recordCtxList = append(recordCtxList, recordCtx)
+			return nil
+		})
+	}
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, true, stat.ProjectionIsFound)
+	assert.Equal(t, true, stat.ProjectionIsAlreadyDone)
+	assert.Equal(t, false, stat.GainIsModified)
+	assert.Equal(t, "cd1cc27b-28a1-47dc-ac76-70e8185e159d", stat.GainProjection.Id)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", gainRemovedId)
+	assert.Equal(t, false, gainProjectionEdited.IsAlreadyDone)
+	assert.Equal(t, 2, len(recordCtxList))
+	assert.Equal(t, aservice.ACTION_DELETE, recordCtxList[0].Action)
+	assert.Equal(t, aservice.ENTITY_GAIN, recordCtxList[0].Entity)
+	assert.Equal(t, aservice.ACTION_REVERT, recordCtxList[1].Action)
+	assert.Equal(t, aservice.ENTITY_GAIN_PROJECTION, recordCtxList[1].Entity)
+}
+
+func TestRevertGainKeepGain(t *testing.T) {
+	gainProjectMock, gainMock := buildRevertGainMocks(time.Now())
+	gainMock.Value = 800
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddGetGainByProjectionIdCall(func(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	var gainUnlinkedId string
+	_mockRepository.AddUnlinkGainCall(func(ctx context.Context, id string, userId string) error {
+		gainUnlinkedId = id
+		return nil
+	})
+	_mockRepository.AddRemoveGainCall(func(ctx context.Context, id string, userId string) error {
+		return errors.New("The gain should not be removed")
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		return &gainProjection, nil
+	})
+	var recordCtxList []aservice.RecordContext
+	_mockAuditProcess := &mockAuditProcess{}
+	for i := 0; i < 2; i++ {
+		_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+			recordCtxList = append(recordCtxList, recordCtx)
+			return nil
+		})
+	}
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{KeepGain: true}))
+	assert.NoError(t, err)
+	assert.NotNil(t, stat.GainProjection)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", gainUnlinkedId)
+	assert.Equal(t, aservice.ACTION_UPDATE, recordCtxList[0].Action)
+	assert.Equal(t, "", recordCtxList[0].After.(*GainResponse).GainProjectionId)
+}
+
+func TestRevertGainModified(t *testing.T) {
+	gainProjectMock, gainMock := buildRevertGainMocks(time.Now())
+	gainMock.Version = 2
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddGetGainByProjectionIdCall(func(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	_mockRepository.AddRemoveGainCall(func(ctx context.Context, id string, userId string) error {
+		return errors.New("The gain should not be removed")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, true, stat.GainIsModified)
+	assert.Nil(t, stat.GainProjection)
+}
+
+func TestRevertGainModifiedForce(t *testing.T) {
+	gainProjectMock, gainMock := buildRevertGainMocks(time.Now())
+	gainMock.Version = 2
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddGetGainByProjectionIdCall(func(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	var gainRemovedId string
+	_mockRepository.AddRemoveGainCall(func(ctx context.Context, id string, userId string) error {
+		gainRemovedId = id
+		return nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		return &gainProjection, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{Force: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, false, stat.GainIsModified)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", gainRemovedId)
+}
+
+func TestRevertGainOverriddenOnRealization(t *testing.T) {
+	gainProjectMock, gainMock := buildRevertGainMocks(time.Now())
+	gainMock.Value = money.FromCents(80000)
+	gainMock.PayIn = gainMock.PayIn.AddDate(0, 0, 2)
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddGetGainByProjectionIdCall(func(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	var gainRemovedId string
+	_mockRepository.AddRemoveGainCall(func(ctx context.Context, id string, userId string) error {
+		gainRemovedId = id
+		return nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		return &gainProjection, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, false, stat.GainIsModified)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", gainRemovedId)
+}
+
+func TestRevertGainWithoutLinkedGain(t *testing.T) {
+	gainProjectMock, _ := buildRevertGainMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		return &gainProjection, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.NoError(t, err)
+	assert.NotNil(t, stat.GainProjection)
+}
+
+func TestRevertGainNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return nil, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, false, stat.ProjectionIsFound)
+}
+
+func TestRevertGainNotRealized(t *testing.T) {
+	gainProjectMock, _ := buildRevertGainMocks(time.Now())
+	gainProjectMock.IsAlreadyDone = false
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, true, stat.ProjectionIsFound)
+	assert.Equal(t, false, stat.ProjectionIsAlreadyDone)
+}
+
+func TestRevertGainRemoveGainFail(t *testing.T) {
+	gainProjectMock, gainMock := buildRevertGainMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddGetGainByProjectionIdCall(func(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	_mockRepository.AddRemoveGainCall(func(ctx context.Context, id string, userId string) error {
+		return errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	_, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.Error(t, err)
+}
+
+func TestRevertGainEditFail(t *testing.T) {
+	gainProjectMock, _ := buildRevertGainMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		return nil, errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	_, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.Error(t, err)
+}
+
+func TestRevertGainTrashedGain(t *testing.T) {
+	gainProjectMock, gainMock := buildRevertGainMocks(time.Now())
+	gainMock.Version = 2
+	gainMock.DeletedAt = time.Now()
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddGetGainByProjectionIdCall(func(ctx context.Context, gainProjectionId string, userId string) (*repository.Gain, error) {
+		return gainMock, nil
+	})
+	var gainUnlinkedId string
+	_mockRepository.AddUnlinkGainCall(func(ctx context.Context, id string, userId string) error {
+		gainUnlinkedId = id
+		return nil
+	})
+	_mockRepository.AddRemoveGainCall(func(ctx context.Context, id string, userId string) error {
+		return errors.New("The gain should not be removed")
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		return &gainProjection, nil
+	})
+	_mockAuditProcess := &mockAuditProcess{}
+	for i := 0; i < 2; i++ {
+		_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+			return nil
+		})
+	}
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, false, stat.GainIsModified)
+	assert.NotNil(t, stat.GainProjection)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", gainUnlinkedId)
+}
+
+func TestRevertGainEditConflict(t *testing.T) {
+	gainProjectMock, _ := buildRevertGainMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.GainProjection, error) {
+		return gainProjectMock, nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, gainProjection repository.GainProjection) (*repository.GainProjection, error) {
+		return nil, repository.ErrVersionConflict
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	_, err := _storageProcess.RevertGain(newRevertGainCtx(RevertGainRequest{}))
+	assert.Equal(t, apperror.KIND_CONFLICT, apperror.KindOf(err))
+}
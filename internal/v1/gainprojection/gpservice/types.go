@@ -3,8 +3,14 @@ package gpservice
 import (
 	"context"
 	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/repository"
 )
 
+// ErrVersionConflict is returned when the version informed by the client is not the current one of the record
+var ErrVersionConflict = repository.ErrVersionConflict
+
 type CreateContext struct {
 	Ctx       context.Context
 	Request   CreateRequest
@@ -16,6 +22,22 @@ type UpdateContext struct {
 	Request   UpdateRequest
 	UserToken string
 	Id        string
+	Version   uint
+}
+
+type PatchContext struct {
+	Ctx       context.Context
+	Request   PatchRequest
+	UserToken string
+	Id        string
+	Version   uint
+}
+
+type DeleteContext struct {
+	Ctx       context.Context
+	UserToken string
+	Id        string
+	Version   uint
 }
 
 type CreateGainContext struct {
@@ -25,6 +47,19 @@ type CreateGainContext struct {
 	Id        string
 }
 
+type RevertGainContext struct {
+	Ctx       context.Context
+	Request   RevertGainRequest
+	UserToken string
+	Id        string
+}
+
+type CreateGainBatchContext struct {
+	Ctx       context.Context
+	Request   CreateGainBatchRequest
+	UserToken string
+}
+
 type SearchContext struct {
 	Ctx       context.Context
 	Params    SearchParams
@@ -33,35 +68,76 @@ type SearchContext struct {
 }
 
 type CreateRequest struct {
-	PayIn       time.Time `json:"pay_in"`
-	Description string    `json:"description"`
-	Value       float64   `json:"value"`
-	IsPassive   bool      `json:"is_passive"`
-	Recurrence  uint      `json:"recurrence"`
-	CategoryId  uint      `json:"category_id"`
+	PayIn       time.Time   `json:"pay_in" binding:"required"`
+	Description string      `json:"description" binding:"notblank,max=255"`
+	Value       money.Money `json:"value" binding:"gt=0" swaggertype:"number"`
+	Currency    string      `json:"currency" binding:"omitempty,iso4217"`
+	IsPassive   bool        `json:"is_passive"`
+	Recurrence  uint        `json:"recurrence" binding:"max=120"`
+	CategoryId  uint        `json:"category_id" binding:"required"`
 }
 
 type UpdateRequest struct {
-	PayIn       time.Time `json:"pay_in"`
-	Description string    `json:"description"`
-	Value       float64   `json:"value"`
-	IsPassive   bool      `json:"is_passive"`
-	CategoryId  uint      `json:"category_id"`
+	PayIn       time.Time   `json:"pay_in" binding:"required"`
+	Description string      `json:"description" binding:"notblank,max=255"`
+	Value       money.Money `json:"value" binding:"gt=0" swaggertype:"number"`
+	Currency    string      `json:"currency" binding:"omitempty,iso4217"`
+	IsPassive   bool        `json:"is_passive"`
+	CategoryId  uint        `json:"category_id" binding:"required"`
+}
+
+// PatchRequest holds only the fields that must be changed, the omitted ones keep the stored value
+type PatchRequest struct {
+	PayIn       *time.Time   `json:"pay_in"`
+	Description *string      `json:"description" binding:"omitempty,notblank,max=255"`
+	Value       *money.Money `json:"value" binding:"omitempty,gt=0" swaggertype:"number"`
+	Currency    *string      `json:"currency" binding:"omitempty,iso4217"`
+	IsPassive   *bool        `json:"is_passive"`
+	CategoryId  *uint        `json:"category_id" binding:"omitempty,min=1"`
 }
 
 type CreateGainRequest struct {
-	Value float64   `json:"value"`
-	PayIn time.Time `json:"pay_in"`
+	Value money.Money `json:"value" binding:"gte=0" swaggertype:"number"`
+	PayIn time.Time   `json:"pay_in"`
+}
+
+type CreateGainBatchRequest struct {
+	Items  []CreateGainBatchItem  `json:"items" binding:"dive"`
+	Filter *CreateGainBatchFilter `json:"filter"`
+}
+
+type CreateGainBatchItem struct {
+	Id    string      `json:"id"`
+	Value money.Money `json:"value" binding:"gte=0" swaggertype:"number"`
+	PayIn time.Time   `json:"pay_in"`
+}
+
+type CreateGainBatchFilter struct {
+	Month      uint `json:"month"`
+	Year       uint `json:"year"`
+	CategoryId uint `json:"category_id"`
+}
+
+type RevertGainRequest struct {
+	KeepGain bool `json:"keep_gain"`
+	Force    bool `json:"force"`
 }
 
 type GainProjectionResponse struct {
 	Id          string           `json:"id"`
 	PayIn       time.Time        `json:"pay_in"`
 	Description string           `json:"description"`
-	Value       float64          `json:"value"`
+	Value       money.Money      `json:"value" swaggertype:"number"`
+	Currency    string           `json:"currency"`
 	IsPassive   bool             `json:"is_passive"`
 	Recurrence  uint             `json:"recurrence,omitempty"`
 	Category    CategoryResponse `json:"category"`
+	DeletedAt   *time.Time       `json:"deleted_at,omitempty"`
+	Version     uint             `json:"-"`
+}
+
+type GainProjectionTrashResponse struct {
+	Records []GainProjectionResponse `json:"records"`
 }
 
 type CategoryResponse struct {
@@ -74,15 +150,33 @@ type GainResponse struct {
 	GainProjectionId string           `json:"gain_projection_id"`
 	PayIn            time.Time        `json:"pay_in"`
 	Description      string           `json:"description"`
-	Value            float64          `json:"value"`
+	Value            money.Money      `json:"value" swaggertype:"number"`
+	Currency         string           `json:"currency"`
 	IsPassive        bool             `json:"is_passive"`
 	Category         CategoryResponse `json:"category"`
 }
 
 type GainStat struct {
+	ProjectionIsFound       bool          `json:"projection_is_found"`
+	ProjectionIsAlreadyDone bool          `json:"projection_is_already_done"`
+	Gain                    *GainResponse `json:"gain,omitempty"`
+}
+
+type GainBatchItemResult struct {
+	ProjectionId string `json:"projection_id"`
+	GainStat
+}
+
+type GainBatchResponse struct {
+	TotalCreated uint                  `json:"total_created"`
+	Results      []GainBatchItemResult `json:"results"`
+}
+
+type RevertGainStat struct {
 	ProjectionIsFound       bool
 	ProjectionIsAlreadyDone bool
-	Gain                    *GainResponse
+	GainIsModified          bool
+	GainProjection          *GainProjectionResponse
 }
 
 type GainProjectionPaginateResponse struct {
@@ -1,12 +1,16 @@
 package gainprojection
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/etag"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
 	"github.com/ruanlas/wallet-core-api/internal/tracing"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/gpservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	"go.elastic.co/apm"
 )
 
@@ -14,9 +18,14 @@ type Handler interface {
 	Create(c *gin.Context)
 	GetById(c *gin.Context)
 	Update(c *gin.Context)
+	Patch(c *gin.Context)
 	Delete(c *gin.Context)
 	GetAll(c *gin.Context)
 	CreateGain(c *gin.Context)
+	CreateGainBatch(c *gin.Context)
+	RevertGain(c *gin.Context)
+	GetTrash(c *gin.Context)
+	Restore(c *gin.Context)
 }
 
 type ResponseDefault interface {
@@ -48,9 +57,9 @@ func (h *handler) Create(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	// apm.TransactionFromContext(ctx)
 
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("GainProjection::StorageProcess::Create", "Create new gain-projection", nil)
@@ -61,7 +70,7 @@ func (h *handler) Create(c *gin.Context) {
 	}
 	gainCreated, err := h.storageProcess.Create(createCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
@@ -77,6 +86,7 @@ func (h *handler) Create(c *gin.Context) {
 // @Param id path string true "Id da receita prevista"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
 // @Success 200 {object} gpservice.GainProjectionResponse
+// @Header 200 {string} ETag "Versão atual do registro, deve ser enviada no If-Match da edição e remoção"
 // @Router /v1/gain-projection/{id} [get]
 func (h *handler) GetById(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -93,15 +103,12 @@ func (h *handler) GetById(c *gin.Context) {
 	}
 	gainProjection, err := h.readingProcess.GetById(searchCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
-	if gainProjection == nil {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Object not found"})
-		return
-	}
 	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(gainProjection.Version))
 	c.JSON(http.StatusOK, gainProjection)
 }
 
@@ -113,7 +120,10 @@ func (h *handler) GetById(c *gin.Context) {
 // @Produce json
 // @Param gain_projection body gpservice.UpdateRequest true "Modelo de edição da receita prevista"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
 // @Success 200 {object} gpservice.GainProjectionResponse
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
 // @Router /v1/gain-projection/{id} [put]
 func (h *handler) Update(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -122,9 +132,19 @@ func (h *handler) Update(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	id := c.Param("id")
 	var request gpservice.UpdateRequest
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("GainProjection::StorageProcess::Update", "Create new gain-projection", nil)
@@ -132,19 +152,80 @@ func (h *handler) Update(c *gin.Context) {
 		Ctx:       ctx,
 		Request:   request,
 		Id:        id,
+		Version:   version,
 		UserToken: userToken,
 	}
 	gainUpdated, err := h.storageProcess.Update(updateCtx)
+	if errors.Is(err, gpservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The gain-projection was modified by another request"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
-	if gainUpdated == nil {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Gain projection not found"})
+	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(gainUpdated.Version))
+	c.JSON(http.StatusOK, gainUpdated)
+}
+
+// @Summary Editar parcialmente uma Receita Prevista
+// @Description Este endpoint permite editar somente os campos informados de uma receita prevista.
+// @Description Os campos omitidos mantêm o valor atual e o registro resultante é validado antes de ser salvo.
+// @Tags Gain-Projection
+// @Accept json
+// @Produce json
+// @Param gain_projection body gpservice.PatchRequest true "Modelo de edição parcial da receita prevista"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
+// @Success 200 {object} gpservice.GainProjectionResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/gain-projection/{id} [patch]
+func (h *handler) Patch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	id := c.Param("id")
+	var request gpservice.PatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("GainProjection::StorageProcess::Patch", "Patch a gain-projection", nil)
+	patchCtx := gpservice.PatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		Id:        id,
+		Version:   version,
+		UserToken: userToken,
+	}
+	gainUpdated, err := h.storageProcess.Patch(patchCtx)
+	if errors.Is(err, gpservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The gain-projection was modified by another request"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
 		return
 	}
 	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(gainUpdated.Version))
 	c.JSON(http.StatusOK, gainUpdated)
 }
 
@@ -155,7 +236,11 @@ func (h *handler) Update(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Id da receita prevista"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
 // @Success 200 {object} ResponseDefault{status=int,message=string}
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
 // @Router /v1/gain-projection/{id} [delete]
 func (h *handler) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -163,15 +248,30 @@ func (h *handler) Delete(c *gin.Context) {
 
 	id := c.Param("id")
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	span := tx.StartSpan("GainProjection::StorageProcess::Delete", "Delete a gain-projection", nil)
-	searchCtx := gpservice.SearchContext{
+	deleteCtx := gpservice.DeleteContext{
 		Ctx:       ctx,
 		Id:        id,
+		Version:   version,
 		UserToken: userToken,
 	}
-	err := h.storageProcess.Delete(searchCtx)
+	err = h.storageProcess.Delete(deleteCtx)
+	if errors.Is(err, gpservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The gain-projection was modified by another request"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
@@ -198,7 +298,7 @@ func (h *handler) GetAll(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	searchParams, err := validateAndGetSearchParams(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("GainProjection::ReadingProcess::GetAllPaginated", "Get a gain-projection paginated", nil)
@@ -209,7 +309,7 @@ func (h *handler) GetAll(c *gin.Context) {
 	}
 	resultPaginated, err := h.readingProcess.GetAllPaginated(searchCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
@@ -235,9 +335,9 @@ func (h *handler) CreateGain(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	id := c.Param("id")
 	var request gpservice.CreateGainRequest
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("GainProjection::StorageProcess::CreateGain", "Create a gain from gain-projection", nil)
@@ -248,18 +348,175 @@ func (h *handler) CreateGain(c *gin.Context) {
 	}
 	stat, err := h.storageProcess.CreateGain(createGainCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
 	if !stat.ProjectionIsFound {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Gain-projection not found"})
+		c.Error(apperror.NotFound("Gain-projection not found"))
 		return
 	}
 	if stat.ProjectionIsAlreadyDone {
-		c.JSON(http.StatusConflict, gin.H{"status": http.StatusConflict, "message": "A gain is already created"})
+		c.Error(apperror.Conflict("A gain is already created"))
 		return
 	}
 	span.End()
 	c.JSON(http.StatusCreated, stat.Gain)
 }
+
+// @Summary Realizar Receitas Previstas em lote
+// @Description Este endpoint permite realizar várias receitas previstas em uma única transação.
+// @Description As previsões podem ser informadas por id, com valor e data opcionais, ou por um filtro de mês, ano e categoria.
+// @Tags Gain-Projection
+// @Accept json
+// @Produce json
+// @Param gain body gpservice.CreateGainBatchRequest true "Modelo de realização em lote"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} gpservice.GainBatchResponse
+// @Failure 409 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/gain-projection/batch/create-gain [post]
+func (h *handler) CreateGainBatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request gpservice.CreateGainBatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	err = validateCreateGainBatchRequest(request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("GainProjection::StorageProcess::CreateGainBatch", "Create gains from a batch of gain-projections", nil)
+	createGainBatchCtx := gpservice.CreateGainBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.CreateGainBatch(createGainBatchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Reverter a realização de uma Receita Prevista
+// @Description Este endpoint permite desfazer a realização de uma receita prevista, reabrindo a previsão.
+// @Description A receita realizada vai para a lixeira desvinculada da previsão, ou é apenas desvinculada quando keep_gain for verdadeiro.
+// @Description Caso a receita realizada tenha sido editada, a reversão só ocorre com keep_gain ou force.
+// @Tags Gain-Projection
+// @Accept json
+// @Produce json
+// @Param id path string true "Id da receita prevista"
+// @Param gain body gpservice.RevertGainRequest false "Modelo de reversão da receita"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} gpservice.GainProjectionResponse
+// @Router /v1/gain-projection/{id}/revert-gain [post]
+func (h *handler) RevertGain(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	id := c.Param("id")
+	var request gpservice.RevertGainRequest
+	if c.Request.ContentLength > 0 {
+		err := validation.Bind(c, &request)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+	}
+	span := tx.StartSpan("GainProjection::StorageProcess::RevertGain", "Revert the gain created from gain-projection", nil)
+	revertGainCtx := gpservice.RevertGainContext{
+		Ctx:       ctx,
+		Request:   request,
+		Id:        id,
+		UserToken: userToken,
+	}
+	stat, err := h.storageProcess.RevertGain(revertGainCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	if !stat.ProjectionIsFound {
+		c.Error(apperror.NotFound("Gain-projection not found"))
+		return
+	}
+	if !stat.ProjectionIsAlreadyDone {
+		c.Error(apperror.Conflict("The gain-projection is not realized"))
+		return
+	}
+	if stat.GainIsModified {
+		c.Error(apperror.Conflict("The realized gain was modified, use keep_gain or force to revert"))
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, stat.GainProjection)
+}
+
+// @Summary Obter a lixeira de Receitas Previstas
+// @Description Este endpoint permite obter as receitas previstas removidas que ainda podem ser restauradas
+// @Tags Gain-Projection
+// @Accept json
+// @Produce json
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} gpservice.GainProjectionTrashResponse
+// @Router /v1/gain-projection/trash [get]
+func (h *handler) GetTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	span := tx.StartSpan("GainProjection::ReadingProcess::GetTrash", "Get the trashed gain-projection", nil)
+	searchCtx := gpservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+	}
+	trash, err := h.readingProcess.GetTrash(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, trash)
+}
+
+// @Summary Restaurar uma Receita Prevista
+// @Description Este endpoint permite restaurar uma receita prevista que está na lixeira
+// @Tags Gain-Projection
+// @Accept json
+// @Produce json
+// @Param id path string true "Id da receita prevista"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} gpservice.GainProjectionResponse
+// @Router /v1/gain-projection/{id}/restore [post]
+func (h *handler) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	id := c.Param("id")
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	span := tx.StartSpan("GainProjection::StorageProcess::Restore", "Restore a gain-projection", nil)
+	searchCtx := gpservice.SearchContext{
+		Ctx:       ctx,
+		Id:        id,
+		UserToken: userToken,
+	}
+	gainProjectionRestored, err := h.storageProcess.Restore(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, gainProjectionRestored)
+}
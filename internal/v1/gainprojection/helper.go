@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/gpservice"
 )
 
@@ -15,10 +16,10 @@ func validateAndGetSearchParams(c *gin.Context) (*gpservice.SearchParams, error)
 	pagesize, _ := strconv.ParseUint(c.Query("page_size"), 10, 32)
 
 	if month == uint64(0) || month > 12 {
-		return nil, &InvalidArgs{message: fmt.Sprintf("A param month %d is invalid", month)}
+		return nil, apperror.Validation(fmt.Sprintf("A param month %d is invalid", month))
 	}
 	if year == uint64(0) {
-		return nil, &InvalidArgs{message: fmt.Sprintf("A param year %d is invalid", year)}
+		return nil, apperror.Validation(fmt.Sprintf("A param year %d is invalid", year))
 	}
 	if page == uint64(0) {
 		page = uint64(1)
@@ -33,3 +34,23 @@ func validateAndGetSearchParams(c *gin.Context) (*gpservice.SearchParams, error)
 		AddPageSize(uint(pagesize)).
 		Build(), nil
 }
+
+func validateCreateGainBatchRequest(request gpservice.CreateGainBatchRequest) error {
+	if len(request.Items) == 0 && request.Filter == nil {
+		return apperror.Validation("The items or the filter must be informed")
+	}
+	for _, item := range request.Items {
+		if item.Id == "" {
+			return apperror.Validation("The id of every item must be informed")
+		}
+	}
+	if request.Filter != nil {
+		if request.Filter.Month == 0 || request.Filter.Month > 12 {
+			return apperror.Validation(fmt.Sprintf("A filter month %d is invalid", request.Filter.Month))
+		}
+		if request.Filter.Year == 0 {
+			return apperror.Validation(fmt.Sprintf("A filter year %d is invalid", request.Filter.Year))
+		}
+	}
+	return nil
+}
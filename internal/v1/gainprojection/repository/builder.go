@@ -1,17 +1,23 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type GainProjectionBuilder struct {
 	id            string
 	createdAt     time.Time
 	payIn         time.Time
 	description   string
-	value         float64
+	value         money.Money
+	currency      string
 	isPassive     bool
 	isAlreadyDone bool
 	userId        string
 	category      GainCategory
+	version       uint
 }
 
 func NewGainProjectionBuilder() *GainProjectionBuilder {
@@ -33,10 +39,14 @@ func (builder *GainProjectionBuilder) AddDescription(description string) *GainPr
 	builder.description = description
 	return builder
 }
-func (builder *GainProjectionBuilder) AddValue(value float64) *GainProjectionBuilder {
+func (builder *GainProjectionBuilder) AddValue(value money.Money) *GainProjectionBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *GainProjectionBuilder) AddCurrency(currency string) *GainProjectionBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *GainProjectionBuilder) AddIsPassive(isPassive bool) *GainProjectionBuilder {
 	builder.isPassive = isPassive
 	return builder
@@ -53,6 +63,10 @@ func (builder *GainProjectionBuilder) AddCategory(category GainCategory) *GainPr
 	builder.category = category
 	return builder
 }
+func (builder *GainProjectionBuilder) AddVersion(version uint) *GainProjectionBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *GainProjectionBuilder) Build() *GainProjection {
 	gainProjection := GainProjection{}
 
@@ -61,20 +75,23 @@ func (builder *GainProjectionBuilder) Build() *GainProjection {
 	gainProjection.PayIn = builder.payIn
 	gainProjection.Description = builder.description
 	gainProjection.Value = builder.value
+	gainProjection.Currency = builder.currency
 	gainProjection.IsPassive = builder.isPassive
 	gainProjection.IsAlreadyDone = builder.isAlreadyDone
 	gainProjection.UserId = builder.userId
 	gainProjection.Category = builder.category
+	gainProjection.Version = builder.version
 
 	return &gainProjection
 }
 
 type QueryParamsBuilder struct {
-	userId string
-	limit  uint
-	offset uint
-	month  uint
-	year   uint
+	userId     string
+	limit      uint
+	offset     uint
+	month      uint
+	year       uint
+	categoryId uint
 }
 
 func NewQueryParamsBuilder() *QueryParamsBuilder {
@@ -100,13 +117,18 @@ func (builder *QueryParamsBuilder) AddOffset(offset uint) *QueryParamsBuilder {
 	builder.offset = offset
 	return builder
 }
+func (builder *QueryParamsBuilder) AddCategoryId(categoryId uint) *QueryParamsBuilder {
+	builder.categoryId = categoryId
+	return builder
+}
 func (builder *QueryParamsBuilder) Build() QueryParams {
 	return QueryParams{
-		userId: builder.userId,
-		month:  builder.month,
-		year:   builder.year,
-		limit:  builder.limit,
-		offset: builder.offset,
+		userId:     builder.userId,
+		month:      builder.month,
+		year:       builder.year,
+		categoryId: builder.categoryId,
+		limit:      builder.limit,
+		offset:     builder.offset,
 	}
 }
 
@@ -115,10 +137,12 @@ type GainBuilder struct {
 	createdAt        time.Time
 	payIn            time.Time
 	description      string
-	value            float64
+	value            money.Money
+	currency         string
 	isPassive        bool
 	userId           string
 	category         GainCategory
+	version          uint
 	gainProjectionId string
 }
 
@@ -141,10 +165,14 @@ func (builder *GainBuilder) AddDescription(description string) *GainBuilder {
 	builder.description = description
 	return builder
 }
-func (builder *GainBuilder) AddValue(value float64) *GainBuilder {
+func (builder *GainBuilder) AddValue(value money.Money) *GainBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *GainBuilder) AddCurrency(currency string) *GainBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *GainBuilder) AddIsPassive(isPassive bool) *GainBuilder {
 	builder.isPassive = isPassive
 	return builder
@@ -161,6 +189,10 @@ func (builder *GainBuilder) AddCategory(category GainCategory) *GainBuilder {
 	builder.category = category
 	return builder
 }
+func (builder *GainBuilder) AddVersion(version uint) *GainBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *GainBuilder) Build() *Gain {
 	gain := Gain{}
 
@@ -169,10 +201,12 @@ func (builder *GainBuilder) Build() *Gain {
 	gain.PayIn = builder.payIn
 	gain.Description = builder.description
 	gain.Value = builder.value
+	gain.Currency = builder.currency
 	gain.IsPassive = builder.isPassive
 	gain.GainProjectionId = builder.gainProjectionId
 	gain.UserId = builder.userId
 	gain.Category = builder.category
+	gain.Version = builder.version
 
 	return &gain
 }
@@ -3,19 +3,37 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 )
 
 type Repository interface {
 	Save(ctx context.Context, gainProjection GainProjection) (*GainProjection, error)
 	GetById(ctx context.Context, id string, userId string) (*GainProjection, error)
 	Edit(ctx context.Context, gainProjection GainProjection) (*GainProjection, error)
-	Remove(ctx context.Context, id string, userId string) error
+	Remove(ctx context.Context, id string, userId string, version uint) error
 	GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error)
 	GetAll(ctx context.Context, params QueryParams) (*[]GainProjection, error)
 	SaveGain(ctx context.Context, gain Gain) (*Gain, error)
+	GetAllPending(ctx context.Context, params QueryParams) (*[]GainProjection, error)
+	RealizeGains(ctx context.Context, gains []Gain) error
+	GetGainByProjectionId(ctx context.Context, gainProjectionId string, userId string) (*Gain, error)
+	RemoveGain(ctx context.Context, id string, userId string) error
+	UnlinkGain(ctx context.Context, id string, userId string) error
+	GetTrash(ctx context.Context, userId string) (*[]GainProjection, error)
+	Restore(ctx context.Context, id string, userId string) error
+	Purge(ctx context.Context, deletedBefore time.Time) error
 }
 
+// ErrVersionConflict is returned when the record was changed after the version informed by the caller
+var ErrVersionConflict = errors.New("the record was modified by another request")
+
+// ErrAlreadyDone is returned when a projection was realized by another request while it was being realized
+var ErrAlreadyDone = errors.New("the projection is already done")
+
 type repository struct {
 	db *sql.DB
 }
@@ -25,13 +43,13 @@ func New(db *sql.DB) Repository {
 }
 
 func (r *repository) Save(ctx context.Context, gainProjection GainProjection) (*GainProjection, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO gain_projection (id, created_at, pay_in, description, value, is_passive, is_already_done, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		INSERT INTO gain_projection (id, created_at, pay_in, description, value, currency, is_passive, is_already_done, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +60,7 @@ func (r *repository) Save(ctx context.Context, gainProjection GainProjection) (*
 		gainProjection.PayIn,
 		gainProjection.Description,
 		gainProjection.Value,
+		gainProjection.Currency,
 		gainProjection.IsPassive,
 		gainProjection.IsAlreadyDone,
 		gainProjection.UserId,
@@ -58,30 +77,32 @@ func (r *repository) Save(ctx context.Context, gainProjection GainProjection) (*
 }
 
 func (r *repository) GetById(ctx context.Context, id string, userId string) (*GainProjection, error) {
-	results, err := r.db.QueryContext(ctx, `
+	results, err := dbtx.Get(ctx, r.db).QueryContext(ctx, `
 		SELECT
 			gp.id,
 			gp.created_at,
 			gp.pay_in,
 			gp.description,
 			gp.value,
+			gp.currency,
 			gp.is_passive,
 			gp.is_already_done,
 			gp.user_id,
 			gc.id,
-			gc.category
+			gc.category,
+			gp.version
 		FROM
 			gain_projection gp
 		INNER JOIN gain_category gc ON 
 			gc.id = gp.category_id
-		WHERE gp.id = ? AND gp.user_id = ?`, id, userId)
+		WHERE gp.id = ? AND gp.user_id = ? AND gp.deleted_at IS NULL`, id, userId)
 	if err != nil {
 		return nil, err
 	}
 	defer results.Close()
 	gainProjection := &GainProjection{Category: GainCategory{}}
 	if results.Next() {
-		var value sql.NullFloat64
+		var value money.NullMoney
 		var categoryId sql.NullInt64
 		var createdAtTimestamp sql.NullInt64
 		err := results.Scan(
@@ -90,18 +111,20 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*Ga
 			&gainProjection.PayIn,
 			&gainProjection.Description,
 			&value,
+			&gainProjection.Currency,
 			&gainProjection.IsPassive,
 			&gainProjection.IsAlreadyDone,
 			&gainProjection.UserId,
 			&categoryId,
 			&gainProjection.Category.Category,
+			&gainProjection.Version,
 		)
 		if err != nil {
 			return nil, err
 		}
 		gainProjection.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
 		gainProjection.Category.Id = uint(categoryId.Int64)
-		gainProjection.Value = value.Float64
+		gainProjection.Value = value.Money
 	} else {
 		return nil, nil
 	}
@@ -109,30 +132,40 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*Ga
 }
 
 func (r *repository) Edit(ctx context.Context, gainProjection GainProjection) (*GainProjection, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ?, is_already_done = ? 
-		WHERE id = ? AND user_id = ?`)
+		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, is_already_done = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`)
 	if err != nil {
 		return nil, err
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(
+	result, err := stmt.Exec(
 		gainProjection.PayIn,
 		gainProjection.Description,
 		gainProjection.Value,
+		gainProjection.Currency,
 		gainProjection.IsPassive,
 		gainProjection.Category.Id,
 		gainProjection.IsAlreadyDone,
 		gainProjection.Id,
 		gainProjection.UserId,
+		gainProjection.Version,
 	)
 	if err != nil {
 		return nil, err
 	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return nil, ErrVersionConflict
+	}
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -140,20 +173,28 @@ func (r *repository) Edit(ctx context.Context, gainProjection GainProjection) (*
 	return &gainProjection, nil
 }
 
-func (r *repository) Remove(ctx context.Context, id string, userId string) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+func (r *repository) Remove(ctx context.Context, id string, userId string, version uint) error {
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return err
 	}
-	stmt, err := tx.PrepareContext(ctx, `DELETE FROM gain_projection WHERE id = ? AND user_id = ?`)
+	stmt, err := tx.PrepareContext(ctx, `UPDATE gain_projection SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(id, userId)
+	result, err := stmt.Exec(time.Now().Unix(), id, userId, version)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return ErrVersionConflict
+	}
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -163,8 +204,8 @@ func (r *repository) Remove(ctx context.Context, id string, userId string) error
 
 func (r *repository) GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error) {
 	var totalRecords uint
-	query := `SELECT COUNT(*) as total_records FROM gain_projection WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ?`
-	row := r.db.QueryRowContext(ctx, query, params.month, params.year, params.userId)
+	query := `SELECT COUNT(*) as total_records FROM gain_projection WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ? AND deleted_at IS NULL`
+	row := dbtx.Get(ctx, r.db).QueryRowContext(ctx, query, params.month, params.year, params.userId)
 	err := row.Scan(&totalRecords)
 	if err != nil {
 		return nil, err
@@ -180,6 +221,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]GainPro
 			gp.pay_in,
 			gp.description,
 			gp.value,
+			gp.currency,
 			gp.is_passive,
 			gp.is_already_done,
 			gp.user_id,
@@ -190,9 +232,9 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]GainPro
 		INNER JOIN gain_category gc ON 
 			gc.id = gp.category_id
 		WHERE 
-			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ?
+			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ? AND gp.deleted_at IS NULL
 		LIMIT ? OFFSET ?`
-	rows, err := r.db.QueryContext(ctx, query, params.month, params.year, params.userId, params.limit, params.offset)
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, params.month, params.year, params.userId, params.limit, params.offset)
 	if err != nil {
 		return nil, err
 	}
@@ -200,7 +242,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]GainPro
 
 	var gainProjectionList []GainProjection
 	for rows.Next() {
-		var value sql.NullFloat64
+		var value money.NullMoney
 		var categoryId sql.NullInt64
 		var createdAtTimestamp sql.NullInt64
 		var gp GainProjection
@@ -212,6 +254,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]GainPro
 			&gp.PayIn,
 			&gp.Description,
 			&value,
+			&gp.Currency,
 			&gp.IsPassive,
 			&gp.IsAlreadyDone,
 			&gp.UserId,
@@ -221,7 +264,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]GainPro
 			return nil, err
 		}
 		gp.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
-		gp.Value = value.Float64
+		gp.Value = value.Money
 		category.Id = uint(categoryId.Int64)
 		gp.Category = category
 
@@ -232,13 +275,13 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]GainPro
 }
 
 func (r *repository) SaveGain(ctx context.Context, gain Gain) (*Gain, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id, gain_projection_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id, gain_projection_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -249,6 +292,7 @@ func (r *repository) SaveGain(ctx context.Context, gain Gain) (*Gain, error) {
 		gain.PayIn,
 		gain.Description,
 		gain.Value,
+		gain.Currency,
 		gain.IsPassive,
 		gain.UserId,
 		gain.Category.Id,
@@ -263,3 +307,366 @@ func (r *repository) SaveGain(ctx context.Context, gain Gain) (*Gain, error) {
 	}
 	return &gain, nil
 }
+
+func (r *repository) GetAllPending(ctx context.Context, params QueryParams) (*[]GainProjection, error) {
+	query := `
+		SELECT
+			gp.id,
+			gp.created_at,
+			gp.pay_in,
+			gp.description,
+			gp.value,
+			gp.currency,
+			gp.is_passive,
+			gp.is_already_done,
+			gp.user_id,
+			gc.id,
+			gc.category
+		FROM
+			gain_projection gp
+		INNER JOIN gain_category gc ON 
+			gc.id = gp.category_id
+		WHERE 
+			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ? AND gp.deleted_at IS NULL
+			AND gp.is_already_done = false AND (? = 0 OR gp.category_id = ?)
+		ORDER BY gp.pay_in ASC`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, params.month, params.year, params.userId, params.categoryId, params.categoryId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var gainProjectionList []GainProjection
+	for rows.Next() {
+		var value money.NullMoney
+		var categoryId sql.NullInt64
+		var createdAtTimestamp sql.NullInt64
+		var gp GainProjection
+		var category GainCategory
+
+		err := rows.Scan(
+			&gp.Id,
+			&createdAtTimestamp,
+			&gp.PayIn,
+			&gp.Description,
+			&value,
+			&gp.Currency,
+			&gp.IsPassive,
+			&gp.IsAlreadyDone,
+			&gp.UserId,
+			&categoryId,
+			&category.Category)
+		if err != nil {
+			return nil, err
+		}
+		gp.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		gp.Value = value.Money
+		category.Id = uint(categoryId.Int64)
+		gp.Category = category
+
+		gainProjectionList = append(gainProjectionList, gp)
+	}
+
+	return &gainProjectionList, nil
+}
+
+// RealizeGains saves every gain and flags its projection as done in a single transaction
+// RealizeGains creates the gains and flags their projections as done, failing with ErrAlreadyDone when a
+// projection was already realized so that no projection is realized twice
+func (r *repository) RealizeGains(ctx context.Context, gains []Gain) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	saveStmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id, gain_projection_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer saveStmt.Close()
+	doneStmt, err := tx.PrepareContext(ctx, `UPDATE gain_projection SET is_already_done = true, version = version + 1 WHERE id = ? AND user_id = ? AND is_already_done = false`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer doneStmt.Close()
+	for _, gain := range gains {
+		_, err = saveStmt.Exec(
+			gain.Id,
+			gain.CreatedAt.Unix(),
+			gain.PayIn,
+			gain.Description,
+			gain.Value,
+			gain.Currency,
+			gain.IsPassive,
+			gain.UserId,
+			gain.Category.Id,
+			gain.GainProjectionId,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		result, err := doneStmt.Exec(gain.GainProjectionId, gain.UserId)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if rowsAffected == 0 {
+			tx.Rollback()
+			return ErrAlreadyDone
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetGainByProjectionId finds the gain realized from the projection, including the one moved to the trash while
+// still linked to it
+func (r *repository) GetGainByProjectionId(ctx context.Context, gainProjectionId string, userId string) (*Gain, error) {
+	results, err := dbtx.Get(ctx, r.db).QueryContext(ctx, `
+		SELECT
+			g.id,
+			g.created_at,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency,
+			g.is_passive,
+			g.user_id,
+			gc.id,
+			gc.category,
+			g.gain_projection_id,
+			g.version,
+			g.deleted_at
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON 
+			gc.id = g.category_id
+		WHERE g.gain_projection_id = ? AND g.user_id = ?`, gainProjectionId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	gain := &Gain{Category: GainCategory{}}
+	if results.Next() {
+		var value money.NullMoney
+		var categoryId sql.NullInt64
+		var createdAtTimestamp sql.NullInt64
+		var gainProjectionId sql.NullString
+		var deletedAtTimestamp sql.NullInt64
+		err := results.Scan(
+			&gain.Id,
+			&createdAtTimestamp,
+			&gain.PayIn,
+			&gain.Description,
+			&value,
+			&gain.Currency,
+			&gain.IsPassive,
+			&gain.UserId,
+			&categoryId,
+			&gain.Category.Category,
+			&gainProjectionId,
+			&gain.Version,
+			&deletedAtTimestamp,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if deletedAtTimestamp.Valid {
+			gain.DeletedAt = time.Unix(deletedAtTimestamp.Int64, 0)
+		}
+		gain.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		gain.Category.Id = uint(categoryId.Int64)
+		gain.Value = value.Money
+		gain.GainProjectionId = gainProjectionId.String
+	} else {
+		return nil, nil
+	}
+	return gain, nil
+}
+
+// RemoveGain moves the realized gain to the trash dropping its link to the projection, so restoring it
+// later does not realize the projection twice
+func (r *repository) RemoveGain(ctx context.Context, id string, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE gain SET deleted_at = ?, gain_projection_id = NULL, version = version + 1 WHERE id = ? AND user_id = ? AND deleted_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(time.Now().Unix(), id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) UnlinkGain(ctx context.Context, id string, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE gain SET gain_projection_id = NULL, version = version + 1 WHERE id = ? AND user_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetTrash(ctx context.Context, userId string) (*[]GainProjection, error) {
+	query := `
+		SELECT
+			gp.id,
+			gp.created_at,
+			gp.pay_in,
+			gp.description,
+			gp.value,
+			gp.currency,
+			gp.is_passive,
+			gp.is_already_done,
+			gp.user_id,
+			gc.id,
+			gc.category,
+			gp.deleted_at
+		FROM
+			gain_projection gp
+		INNER JOIN gain_category gc ON 
+			gc.id = gp.category_id
+		WHERE 
+			gp.user_id = ? AND gp.deleted_at IS NOT NULL
+		ORDER BY gp.deleted_at DESC`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var gainProjectionList []GainProjection
+	for rows.Next() {
+		var value money.NullMoney
+		var categoryId sql.NullInt64
+		var createdAtTimestamp sql.NullInt64
+		var deletedAtTimestamp sql.NullInt64
+		var gp GainProjection
+		var category GainCategory
+
+		err := rows.Scan(
+			&gp.Id,
+			&createdAtTimestamp,
+			&gp.PayIn,
+			&gp.Description,
+			&value,
+			&gp.Currency,
+			&gp.IsPassive,
+			&gp.IsAlreadyDone,
+			&gp.UserId,
+			&categoryId,
+			&category.Category,
+			&deletedAtTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		gp.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		gp.DeletedAt = time.Unix(deletedAtTimestamp.Int64, 0)
+		gp.Value = value.Money
+		category.Id = uint(categoryId.Int64)
+		gp.Category = category
+
+		gainProjectionList = append(gainProjectionList, gp)
+	}
+
+	return &gainProjectionList, nil
+}
+
+func (r *repository) Restore(ctx context.Context, id string, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE gain_projection SET deleted_at = NULL, version = version + 1 WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) Purge(ctx context.Context, deletedBefore time.Time) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	// The realized gain outlives its projection, so it only loses the reference
+	detachStmt, err := tx.PrepareContext(ctx, `
+		UPDATE gain SET gain_projection_id = NULL, version = version + 1 
+		WHERE gain_projection_id IN (SELECT id FROM gain_projection WHERE deleted_at IS NOT NULL AND deleted_at < ?)`)
+	if err != nil {
+		return err
+	}
+	defer detachStmt.Close()
+	_, err = detachStmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	// The share of a split keeps its value without the projection that was receiving it
+	detachShareStmt, err := tx.PrepareContext(ctx, `
+		UPDATE invoice_split_share SET gain_projection_id = NULL
+		WHERE gain_projection_id IN (SELECT id FROM gain_projection WHERE deleted_at IS NOT NULL AND deleted_at < ?)`)
+	if err != nil {
+		return err
+	}
+	defer detachShareStmt.Close()
+	_, err = detachShareStmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM gain_projection WHERE deleted_at IS NOT NULL AND deleted_at < ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,25 +26,29 @@ func TestEditGainProjectionSuccess(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ?, is_already_done = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, is_already_done = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			gainPMock.PayIn,
 			gainPMock.Description,
 			gainPMock.Value,
+			gainPMock.Currency,
 			gainPMock.IsPassive,
 			gainPMock.Category.Id,
 			gainPMock.IsAlreadyDone,
 			gainPMock.Id,
-			gainPMock.UserId).
+			gainPMock.UserId,
+			gainPMock.Version).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit()
 
@@ -72,8 +77,10 @@ func TestEditGainProjectionBeginFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
@@ -104,16 +111,18 @@ func TestEditGainProjectionPrepareFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ?, is_already_done = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, is_already_done = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Edit(context.Background(), *gainPMock)
@@ -140,26 +149,30 @@ func TestEditGainProjectionExecFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ?, is_already_done = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, is_already_done = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			gainPMock.PayIn,
 			gainPMock.Description,
 			gainPMock.Value,
+			gainPMock.Currency,
 			gainPMock.IsPassive,
 			gainPMock.Category.Id,
 			gainPMock.IsAlreadyDone,
 			gainPMock.Id,
-			gainPMock.UserId).
+			gainPMock.UserId,
+			gainPMock.Version).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Edit(context.Background(), *gainPMock)
@@ -186,26 +199,30 @@ func TestEditGainProjectionCommitFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, is_passive = ?, category_id = ?, is_already_done = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, is_already_done = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			gainPMock.PayIn,
 			gainPMock.Description,
 			gainPMock.Value,
+			gainPMock.Currency,
 			gainPMock.IsPassive,
 			gainPMock.Category.Id,
 			gainPMock.IsAlreadyDone,
 			gainPMock.Id,
-			gainPMock.UserId).
+			gainPMock.UserId,
+			gainPMock.Version).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit().WillReturnError(errors.New("An error has been ocurred"))
 
@@ -216,3 +233,54 @@ func TestEditGainProjectionCommitFail(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestEditGainProjectionVersionConflict(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	gainPMock := NewGainProjectionBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(now).
+		AddPayIn(now).
+		AddIsPassive(true).
+		AddIsAlreadyDone(false).
+		AddCategory(GainCategory{Id: 1}).
+		AddDescription("Description de teste").
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddUserId("User1").
+		AddVersion(3).
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		UPDATE gain_projection SET pay_in = ?, description = ?, value = ?, currency = ?, is_passive = ?, category_id = ?, is_already_done = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
+		ExpectExec().
+		WithArgs(
+			gainPMock.PayIn,
+			gainPMock.Description,
+			gainPMock.Value,
+			gainPMock.Currency,
+			gainPMock.IsPassive,
+			gainPMock.Category.Id,
+			gainPMock.IsAlreadyDone,
+			gainPMock.Id,
+			gainPMock.UserId,
+			gainPMock.Version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	_, err = _repository.Edit(context.Background(), *gainPMock)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getAllPendingQueryMock = `
+		SELECT
+			gp.id,
+			gp.created_at,
+			gp.pay_in,
+			gp.description,
+			gp.value,
+			gp.currency,
+			gp.is_passive,
+			gp.is_already_done,
+			gp.user_id,
+			gc.id,
+			gc.category
+		FROM
+			gain_projection gp
+		INNER JOIN gain_category gc ON 
+			gc.id = gp.category_id
+		WHERE 
+			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ? AND gp.deleted_at IS NULL
+			AND gp.is_already_done = false AND (? = 0 OR gp.category_id = ?)
+		ORDER BY gp.pay_in ASC`
+
+func TestGetAllPendingSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	queryParams := NewQueryParamsBuilder().
+		AddMonth(10).
+		AddYear(2024).
+		AddCategoryId(1).
+		AddUserId("User1").
+		Build()
+
+	now := time.Now()
+	gainPMock := NewGainProjectionBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(now).
+		AddPayIn(now).
+		AddIsPassive(true).
+		AddIsAlreadyDone(false).
+		AddCategory(GainCategory{Id: 1, Category: "Salário"}).
+		AddDescription("Description de teste").
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddUserId("User1").
+		Build()
+
+	rowsGainProjectionMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"pay_in",
+		"description",
+		"value",
+		"currency",
+		"is_passive",
+		"is_already_done",
+		"user_id",
+		"category_id",
+		"category",
+	}).AddRow(
+		gainPMock.Id,
+		gainPMock.CreatedAt.Unix(),
+		gainPMock.PayIn,
+		gainPMock.Description,
+		gainPMock.Value,
+		gainPMock.Currency,
+		gainPMock.IsPassive,
+		gainPMock.IsAlreadyDone,
+		gainPMock.UserId,
+		gainPMock.Category.Id,
+		gainPMock.Category.Category,
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllPendingQueryMock).
+		WithArgs(uint(10), uint(2024), "User1", uint(1), uint(1)).
+		WillReturnRows(rowsGainProjectionMock)
+
+	gainProjectionList, err := _repository.GetAllPending(context.Background(), queryParams)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(*gainProjectionList))
+	assert.Equal(t, gainPMock.Id, (*gainProjectionList)[0].Id)
+	assert.Equal(t, gainPMock.Category, (*gainProjectionList)[0].Category)
+	assert.False(t, (*gainProjectionList)[0].IsAlreadyDone)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllPendingQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	queryParams := NewQueryParamsBuilder().
+		AddMonth(10).
+		AddYear(2024).
+		AddUserId("User1").
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllPendingQueryMock).
+		WithArgs(uint(10), uint(2024), "User1", uint(0), uint(0)).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAllPending(context.Background(), queryParams)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,7 +35,8 @@ func TestGetAllSuccess(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1, Category: "Salário"}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -44,6 +46,7 @@ func TestGetAllSuccess(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"is_already_done",
 		"user_id",
@@ -55,6 +58,7 @@ func TestGetAllSuccess(t *testing.T) {
 		gainPMock.PayIn,
 		gainPMock.Description,
 		gainPMock.Value,
+		gainPMock.Currency,
 		gainPMock.IsPassive,
 		gainPMock.IsAlreadyDone,
 		gainPMock.UserId,
@@ -71,6 +75,7 @@ func TestGetAllSuccess(t *testing.T) {
 			gp.pay_in,
 			gp.description,
 			gp.value,
+			gp.currency,
 			gp.is_passive,
 			gp.is_already_done,
 			gp.user_id,
@@ -81,7 +86,7 @@ func TestGetAllSuccess(t *testing.T) {
 		INNER JOIN gain_category gc ON 
 			gc.id = gp.category_id
 		WHERE 
-			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ?
+			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ? AND gp.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnRows(rowsGainProjectionMock)
@@ -119,6 +124,7 @@ func TestGetAllQueryFail(t *testing.T) {
 			gp.pay_in,
 			gp.description,
 			gp.value,
+			gp.currency,
 			gp.is_passive,
 			gp.is_already_done,
 			gp.user_id,
@@ -129,7 +135,7 @@ func TestGetAllQueryFail(t *testing.T) {
 		INNER JOIN gain_category gc ON 
 			gc.id = gp.category_id
 		WHERE 
-			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ?
+			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ? AND gp.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnError(errors.New("An error has been ocurred"))
@@ -163,6 +169,7 @@ func TestGetAllScanFail(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"is_already_done",
 		"user_id",
@@ -179,6 +186,7 @@ func TestGetAllScanFail(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	).RowError(1, errors.New("An error has been ocurred"))
 
 	_repository := New(dbMock)
@@ -190,6 +198,7 @@ func TestGetAllScanFail(t *testing.T) {
 			gp.pay_in,
 			gp.description,
 			gp.value,
+			gp.currency,
 			gp.is_passive,
 			gp.is_already_done,
 			gp.user_id,
@@ -200,7 +209,7 @@ func TestGetAllScanFail(t *testing.T) {
 		INNER JOIN gain_category gc ON 
 			gc.id = gp.category_id
 		WHERE 
-			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ?
+			MONTH(gp.pay_in) = ? AND YEAR(gp.pay_in) = ? AND gp.user_id = ? AND gp.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnRows(rowsGainProjectionMock)
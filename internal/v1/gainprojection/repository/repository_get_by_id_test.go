@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,8 +27,10 @@ func TestGetGainProjectionByIdSuccess(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1, Category: "Salário"}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	rowsGainProjectionMock := sqlMock.NewRows([]string{
@@ -36,22 +39,26 @@ func TestGetGainProjectionByIdSuccess(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"is_already_done",
 		"user_id",
 		"category_id",
 		"category",
+		"version",
 	}).AddRow(
 		gainPMock.Id,
 		gainPMock.CreatedAt.Unix(),
 		gainPMock.PayIn,
 		gainPMock.Description,
 		gainPMock.Value,
+		gainPMock.Currency,
 		gainPMock.IsPassive,
 		gainPMock.IsAlreadyDone,
 		gainPMock.UserId,
 		gainPMock.Category.Id,
 		gainPMock.Category.Category,
+		gainPMock.Version,
 	)
 
 	_repository := New(dbMock)
@@ -63,22 +70,25 @@ func TestGetGainProjectionByIdSuccess(t *testing.T) {
 			gp.pay_in,
 			gp.description,
 			gp.value,
+			gp.currency,
 			gp.is_passive,
 			gp.is_already_done,
 			gp.user_id,
 			gc.id,
-			gc.category
+			gc.category,
+			gp.version
 		FROM
 			gain_projection gp
 		INNER JOIN gain_category gc ON 
 			gc.id = gp.category_id
-		WHERE gp.id = ? AND gp.user_id = ?`).
+		WHERE gp.id = ? AND gp.user_id = ? AND gp.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsGainProjectionMock)
 
 	gainPReturn, err := _repository.GetById(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
 	assert.NoError(t, err)
 	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", gainPReturn.Id)
+	assert.Equal(t, uint(3), gainPReturn.Version)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -101,16 +111,18 @@ func TestGetGainProjectionByIdQueryFail(t *testing.T) {
 			gp.pay_in,
 			gp.description,
 			gp.value,
+			gp.currency,
 			gp.is_passive,
 			gp.is_already_done,
 			gp.user_id,
 			gc.id,
-			gc.category
+			gc.category,
+			gp.version
 		FROM
 			gain_projection gp
 		INNER JOIN gain_category gc ON 
 			gc.id = gp.category_id
-		WHERE gp.id = ? AND gp.user_id = ?`).
+		WHERE gp.id = ? AND gp.user_id = ? AND gp.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnError(errors.New("An error has been ocurred"))
 
@@ -135,11 +147,13 @@ func TestGetGainProjectionByIdRowEmpty(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"is_already_done",
 		"user_id",
 		"category_id",
 		"category",
+		"version",
 	})
 
 	_repository := New(dbMock)
@@ -151,16 +165,18 @@ func TestGetGainProjectionByIdRowEmpty(t *testing.T) {
 			gp.pay_in,
 			gp.description,
 			gp.value,
+			gp.currency,
 			gp.is_passive,
 			gp.is_already_done,
 			gp.user_id,
 			gc.id,
-			gc.category
+			gc.category,
+			gp.version
 		FROM
 			gain_projection gp
 		INNER JOIN gain_category gc ON 
 			gc.id = gp.category_id
-		WHERE gp.id = ? AND gp.user_id = ?`).
+		WHERE gp.id = ? AND gp.user_id = ? AND gp.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsGainProjectionMock)
 
@@ -186,13 +202,15 @@ func TestGetGainProjectionByIdRowScanFail(t *testing.T) {
 		"pay_in",
 		"description",
 		"value",
+		"currency",
 		"is_passive",
 		"is_already_done",
 		"user_id",
 		"category_id",
 		"category",
+		"version",
 	}).AddRow(
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).
 		RowError(1, errors.New("An error has been ocurred"))
 
 	_repository := New(dbMock)
@@ -204,16 +222,18 @@ func TestGetGainProjectionByIdRowScanFail(t *testing.T) {
 			gp.pay_in,
 			gp.description,
 			gp.value,
+			gp.currency,
 			gp.is_passive,
 			gp.is_already_done,
 			gp.user_id,
 			gc.id,
-			gc.category
+			gc.category,
+			gp.version
 		FROM
 			gain_projection gp
 		INNER JOIN gain_category gc ON 
 			gc.id = gp.category_id
-		WHERE gp.id = ? AND gp.user_id = ?`).
+		WHERE gp.id = ? AND gp.user_id = ? AND gp.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsGainProjectionMock)
 
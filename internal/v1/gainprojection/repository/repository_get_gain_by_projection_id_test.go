@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGainByProjectionIdSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"pay_in",
+		"description",
+		"value",
+		"currency",
+		"is_passive",
+		"user_id",
+		"category_id",
+		"category",
+		"gain_projection_id",
+		"version",
+		"deleted_at",
+	}).AddRow(
+		"71e31eb6-dde2-4dcb-b7ef-c7e8a699c628",
+		now.Unix(),
+		now,
+		"Description de teste",
+		500.50,
+		"USD",
+		true,
+		"User1",
+		1,
+		"Categoria",
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		2,
+		nil,
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			g.id,
+			g.created_at,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency,
+			g.is_passive,
+			g.user_id,
+			gc.id,
+			gc.category,
+			g.gain_projection_id,
+			g.version,
+			g.deleted_at
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON 
+			gc.id = g.category_id
+		WHERE g.gain_projection_id = ? AND g.user_id = ?`).
+		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WillReturnRows(rowsMock)
+
+	gain, err := _repository.GetGainByProjectionId(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", gain.Id)
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", gain.GainProjectionId)
+	assert.Equal(t, uint(2), gain.Version)
+	assert.True(t, gain.DeletedAt.IsZero())
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainByProjectionIdTrashed(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"pay_in",
+		"description",
+		"value",
+		"currency",
+		"is_passive",
+		"user_id",
+		"category_id",
+		"category",
+		"gain_projection_id",
+		"version",
+		"deleted_at",
+	}).AddRow(
+		"71e31eb6-dde2-4dcb-b7ef-c7e8a699c628",
+		now.Unix(),
+		now,
+		"Description de teste",
+		500.50,
+		"USD",
+		true,
+		"User1",
+		1,
+		"Categoria",
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		2,
+		now.Unix(),
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			g.id,
+			g.created_at,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency,
+			g.is_passive,
+			g.user_id,
+			gc.id,
+			gc.category,
+			g.gain_projection_id,
+			g.version,
+			g.deleted_at
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON 
+			gc.id = g.category_id
+		WHERE g.gain_projection_id = ? AND g.user_id = ?`).
+		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WillReturnRows(rowsMock)
+
+	gain, err := _repository.GetGainByProjectionId(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", gain.Id)
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", gain.GainProjectionId)
+	assert.Equal(t, uint(2), gain.Version)
+	assert.Equal(t, now.Unix(), gain.DeletedAt.Unix())
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainByProjectionIdNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			g.id,
+			g.created_at,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency,
+			g.is_passive,
+			g.user_id,
+			gc.id,
+			gc.category,
+			g.gain_projection_id,
+			g.version,
+			g.deleted_at
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON 
+			gc.id = g.category_id
+		WHERE g.gain_projection_id = ? AND g.user_id = ?`).
+		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WillReturnRows(sqlMock.NewRows([]string{"id"}))
+
+	gain, err := _repository.GetGainByProjectionId(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, gain)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainByProjectionIdQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			g.id,
+			g.created_at,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency,
+			g.is_passive,
+			g.user_id,
+			gc.id,
+			gc.category,
+			g.gain_projection_id,
+			g.version,
+			g.deleted_at
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON 
+			gc.id = g.category_id
+		WHERE g.gain_projection_id = ? AND g.user_id = ?`).
+		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetGainByProjectionId(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
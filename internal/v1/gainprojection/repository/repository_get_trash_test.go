@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrashSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"pay_in",
+		"description",
+		"value",
+		"currency",
+		"is_passive",
+		"is_already_done",
+		"user_id",
+		"category_id",
+		"category",
+		"deleted_at",
+	}).AddRow(
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		now.Unix(),
+		now,
+		"Description de teste",
+		500.50,
+		"USD",
+		true,
+		false,
+		"User1",
+		1,
+		"Categoria",
+		now.Unix(),
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			gp.id,
+			gp.created_at,
+			gp.pay_in,
+			gp.description,
+			gp.value,
+			gp.currency,
+			gp.is_passive,
+			gp.is_already_done,
+			gp.user_id,
+			gc.id,
+			gc.category,
+			gp.deleted_at
+		FROM
+			gain_projection gp
+		INNER JOIN gain_category gc ON 
+			gc.id = gp.category_id
+		WHERE 
+			gp.user_id = ? AND gp.deleted_at IS NOT NULL
+		ORDER BY gp.deleted_at DESC`).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	trash, err := _repository.GetTrash(context.Background(), "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(*trash))
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", (*trash)[0].Id)
+	assert.Equal(t, now.Unix(), (*trash)[0].DeletedAt.Unix())
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetTrashQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			gp.id,
+			gp.created_at,
+			gp.pay_in,
+			gp.description,
+			gp.value,
+			gp.currency,
+			gp.is_passive,
+			gp.is_already_done,
+			gp.user_id,
+			gc.id,
+			gc.category,
+			gp.deleted_at
+		FROM
+			gain_projection gp
+		INNER JOIN gain_category gc ON 
+			gc.id = gp.category_id
+		WHERE 
+			gp.user_id = ? AND gp.deleted_at IS NOT NULL
+		ORDER BY gp.deleted_at DESC`).
+		WithArgs("User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetTrash(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
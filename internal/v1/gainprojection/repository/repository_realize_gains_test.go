@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const realizeGainsInsertMock = `
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id, gain_projection_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+const realizeGainsUpdateMock = `UPDATE gain_projection SET is_already_done = true, version = version + 1 WHERE id = ? AND user_id = ? AND is_already_done = false`
+
+func buildRealizeGainsMock() []Gain {
+	now := time.Now()
+	return []Gain{
+		*NewGainBuilder().
+			AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+			AddCreatedAt(now).
+			AddPayIn(now).
+			AddIsPassive(true).
+			AddCategory(GainCategory{Id: 1}).
+			AddDescription("Description de teste").
+			AddValue(money.FromCents(50050)).
+			AddCurrency("BRL").
+			AddUserId("User1").
+			AddGainProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
+			Build(),
+		*NewGainBuilder().
+			AddId("2b4a2b1e-0c1b-4f6e-9f65-3bd0a5cfa7a9").
+			AddCreatedAt(now).
+			AddPayIn(now).
+			AddIsPassive(false).
+			AddCategory(GainCategory{Id: 2}).
+			AddDescription("Description de teste 2").
+			AddValue(money.FromCents(10000)).
+			AddCurrency("BRL").
+			AddUserId("User1").
+			AddGainProjectionId("c1a3b3f9-63f7-4ab4-8f5b-8e2f4f3a4d1d").
+			Build(),
+	}
+}
+
+func TestRealizeGainsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildRealizeGainsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	insertStmt := sqlMock.ExpectPrepare(realizeGainsInsertMock)
+	updateStmt := sqlMock.ExpectPrepare(realizeGainsUpdateMock)
+	for _, gain := range gainsMock {
+		insertStmt.ExpectExec().
+			WithArgs(
+				gain.Id,
+				gain.CreatedAt.Unix(),
+				gain.PayIn,
+				gain.Description,
+				gain.Value,
+				gain.Currency,
+				gain.IsPassive,
+				gain.UserId,
+				gain.Category.Id,
+				gain.GainProjectionId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		updateStmt.ExpectExec().
+			WithArgs(gain.GainProjectionId, gain.UserId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	sqlMock.ExpectCommit()
+
+	err = _repository.RealizeGains(context.Background(), gainsMock)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRealizeGainsBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.RealizeGains(context.Background(), buildRealizeGainsMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRealizeGainsExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildRealizeGainsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	insertStmt := sqlMock.ExpectPrepare(realizeGainsInsertMock)
+	updateStmt := sqlMock.ExpectPrepare(realizeGainsUpdateMock)
+	insertStmt.ExpectExec().
+		WithArgs(
+			gainsMock[0].Id,
+			gainsMock[0].CreatedAt.Unix(),
+			gainsMock[0].PayIn,
+			gainsMock[0].Description,
+			gainsMock[0].Value,
+			gainsMock[0].Currency,
+			gainsMock[0].IsPassive,
+			gainsMock[0].UserId,
+			gainsMock[0].Category.Id,
+			gainsMock[0].GainProjectionId).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	updateStmt.ExpectExec().
+		WithArgs(gainsMock[0].GainProjectionId, gainsMock[0].UserId).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	insertStmt.ExpectExec().
+		WithArgs(
+			gainsMock[1].Id,
+			gainsMock[1].CreatedAt.Unix(),
+			gainsMock[1].PayIn,
+			gainsMock[1].Description,
+			gainsMock[1].Value,
+			gainsMock[1].Currency,
+			gainsMock[1].IsPassive,
+			gainsMock[1].UserId,
+			gainsMock[1].Category.Id,
+			gainsMock[1].GainProjectionId).
+		WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.RealizeGains(context.Background(), gainsMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRealizeGainsAlreadyDone(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildRealizeGainsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	insertStmt := sqlMock.ExpectPrepare(realizeGainsInsertMock)
+	updateStmt := sqlMock.ExpectPrepare(realizeGainsUpdateMock)
+	insertStmt.ExpectExec().
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	updateStmt.ExpectExec().
+		WithArgs(gainsMock[0].GainProjectionId, gainsMock[0].UserId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	err = _repository.RealizeGains(context.Background(), gainsMock)
+	assert.ErrorIs(t, err, ErrAlreadyDone)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRealizeGainsCommitFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	gainsMock := buildRealizeGainsMock()[:1]
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	insertStmt := sqlMock.ExpectPrepare(realizeGainsInsertMock)
+	updateStmt := sqlMock.ExpectPrepare(realizeGainsUpdateMock)
+	insertStmt.ExpectExec().
+		WithArgs(
+			gainsMock[0].Id,
+			gainsMock[0].CreatedAt.Unix(),
+			gainsMock[0].PayIn,
+			gainsMock[0].Description,
+			gainsMock[0].Value,
+			gainsMock[0].Currency,
+			gainsMock[0].IsPassive,
+			gainsMock[0].UserId,
+			gainsMock[0].Category.Id,
+			gainsMock[0].GainProjectionId).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	updateStmt.ExpectExec().
+		WithArgs(gainsMock[0].GainProjectionId, gainsMock[0].UserId).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit().WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.RealizeGains(context.Background(), gainsMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveGainSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`UPDATE gain SET deleted_at = ?, gain_projection_id = NULL, version = version + 1 WHERE id = ? AND user_id = ? AND deleted_at IS NULL`).
+		ExpectExec().
+		WithArgs(sqlmock.AnyArg(), "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", "User1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.RemoveGain(context.Background(), "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", "User1")
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRemoveGainExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`UPDATE gain SET deleted_at = ?, gain_projection_id = NULL, version = version + 1 WHERE id = ? AND user_id = ? AND deleted_at IS NULL`).
+		ExpectExec().
+		WithArgs(sqlmock.AnyArg(), "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.RemoveGain(context.Background(), "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
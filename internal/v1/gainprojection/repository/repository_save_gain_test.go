@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,7 +26,8 @@ func TestSaveGainSuccess(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddGainProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -34,8 +36,8 @@ func TestSaveGainSuccess(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id, gain_projection_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id, gain_projection_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			gainMock.Id,
@@ -43,6 +45,7 @@ func TestSaveGainSuccess(t *testing.T) {
 			gainMock.PayIn,
 			gainMock.Description,
 			gainMock.Value,
+			gainMock.Currency,
 			gainMock.IsPassive,
 			gainMock.UserId,
 			gainMock.Category.Id,
@@ -74,7 +77,8 @@ func TestSaveGainBeginFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddGainProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -106,7 +110,8 @@ func TestSaveGainPrepareFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddGainProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -115,8 +120,8 @@ func TestSaveGainPrepareFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id, gain_projection_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id, gain_projection_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.SaveGain(context.Background(), *gainMock)
@@ -142,7 +147,8 @@ func TestSaveGainExecFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddGainProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -151,8 +157,8 @@ func TestSaveGainExecFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id, gain_projection_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id, gain_projection_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			gainMock.Id,
@@ -160,6 +166,7 @@ func TestSaveGainExecFail(t *testing.T) {
 			gainMock.PayIn,
 			gainMock.Description,
 			gainMock.Value,
+			gainMock.Currency,
 			gainMock.IsPassive,
 			gainMock.UserId,
 			gainMock.Category.Id,
@@ -189,7 +196,8 @@ func TestSaveGainCommitFail(t *testing.T) {
 		AddIsPassive(true).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddGainProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -197,8 +205,8 @@ func TestSaveGainCommitFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain (id, created_at, pay_in, description, value, is_passive, user_id, category_id, gain_projection_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain (id, created_at, pay_in, description, value, currency, is_passive, user_id, category_id, gain_projection_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			gainMock.Id,
@@ -206,6 +214,7 @@ func TestSaveGainCommitFail(t *testing.T) {
 			gainMock.PayIn,
 			gainMock.Description,
 			gainMock.Value,
+			gainMock.Currency,
 			gainMock.IsPassive,
 			gainMock.UserId,
 			gainMock.Category.Id,
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,7 +27,8 @@ func TestSaveGainProjectionSuccess(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -34,8 +36,8 @@ func TestSaveGainProjectionSuccess(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain_projection (id, created_at, pay_in, description, value, is_passive, is_already_done, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain_projection (id, created_at, pay_in, description, value, currency, is_passive, is_already_done, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			gainPMock.Id,
@@ -43,6 +45,7 @@ func TestSaveGainProjectionSuccess(t *testing.T) {
 			gainPMock.PayIn,
 			gainPMock.Description,
 			gainPMock.Value,
+			gainPMock.Currency,
 			gainPMock.IsPassive,
 			gainPMock.IsAlreadyDone,
 			gainPMock.UserId,
@@ -75,7 +78,8 @@ func TestSaveGainProjectionBeginFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -107,7 +111,8 @@ func TestSaveGainProjectionPrepareFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -115,8 +120,8 @@ func TestSaveGainProjectionPrepareFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain_projection (id, created_at, pay_in, description, value, is_passive, is_already_done, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain_projection (id, created_at, pay_in, description, value, currency, is_passive, is_already_done, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Save(context.Background(), *gainPMock)
@@ -143,7 +148,8 @@ func TestSaveGainProjectionExecFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -151,8 +157,8 @@ func TestSaveGainProjectionExecFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain_projection (id, created_at, pay_in, description, value, is_passive, is_already_done, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain_projection (id, created_at, pay_in, description, value, currency, is_passive, is_already_done, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			gainPMock.Id,
@@ -160,6 +166,7 @@ func TestSaveGainProjectionExecFail(t *testing.T) {
 			gainPMock.PayIn,
 			gainPMock.Description,
 			gainPMock.Value,
+			gainPMock.Currency,
 			gainPMock.IsPassive,
 			gainPMock.IsAlreadyDone,
 			gainPMock.UserId,
@@ -190,7 +197,8 @@ func TestSaveGainProjectionCommitFail(t *testing.T) {
 		AddIsAlreadyDone(false).
 		AddCategory(GainCategory{Id: 1}).
 		AddDescription("Description de teste").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -198,8 +206,8 @@ func TestSaveGainProjectionCommitFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO gain_projection (id, created_at, pay_in, description, value, is_passive, is_already_done, user_id, category_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO gain_projection (id, created_at, pay_in, description, value, currency, is_passive, is_already_done, user_id, category_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			gainPMock.Id,
@@ -207,6 +215,7 @@ func TestSaveGainProjectionCommitFail(t *testing.T) {
 			gainPMock.PayIn,
 			gainPMock.Description,
 			gainPMock.Value,
+			gainPMock.Currency,
 			gainPMock.IsPassive,
 			gainPMock.IsAlreadyDone,
 			gainPMock.UserId,
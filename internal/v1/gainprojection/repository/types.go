@@ -1,16 +1,23 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type GainProjection struct {
 	Id            string
 	CreatedAt     time.Time
 	PayIn         time.Time
 	Description   string
-	Value         float64
+	Value         money.Money
+	Currency      string
 	IsPassive     bool
 	IsAlreadyDone bool
 	UserId        string
+	DeletedAt     time.Time
+	Version       uint
 	Category      GainCategory
 }
 
@@ -19,10 +26,13 @@ type Gain struct {
 	CreatedAt        time.Time
 	PayIn            time.Time
 	Description      string
-	Value            float64
+	Value            money.Money
+	Currency         string
 	IsPassive        bool
 	GainProjectionId string
 	UserId           string
+	DeletedAt        time.Time
+	Version          uint
 	Category         GainCategory
 }
 
@@ -32,9 +42,10 @@ type GainCategory struct {
 }
 
 type QueryParams struct {
-	userId string
-	month  uint
-	year   uint
-	limit  uint
-	offset uint
+	userId     string
+	month      uint
+	year       uint
+	categoryId uint
+	limit      uint
+	offset     uint
 }
@@ -0,0 +1,102 @@
+package glservice
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/goal/repository"
+)
+
+// progressCalculator sums the contributions of a goal in its currency and tells how much is left to save a month
+type progressCalculator struct {
+	repository repository.Repository
+	converter  erservice.Converter
+}
+
+func (pc *progressCalculator) buildResponse(ctx context.Context, goal repository.Goal, now time.Time) (*GoalResponse, error) {
+	contributions, err := pc.repository.GetContributions(ctx, goal)
+	if err != nil {
+		return nil, err
+	}
+	var saved money.Money
+	for _, contribution := range *contributions {
+		value, err := pc.converter.Convert(erservice.ConvertContext{
+			Ctx:    ctx,
+			UserId: goal.UserId,
+			Amount: contribution.Value,
+			From:   contribution.Currency,
+			To:     goal.Currency,
+			Date:   contribution.PayAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		saved = saved.Add(value)
+	}
+	remaining := goal.TargetValue.Sub(saved)
+	if remaining < 0 {
+		remaining = 0
+	}
+	monthsRemaining := getMonthsRemaining(getToday(now, goal.Deadline), goal.Deadline)
+	requiredMonthlySaving := remaining
+	if monthsRemaining > 0 {
+		requiredMonthlySaving = remaining.Split(int(monthsRemaining))[0]
+	}
+	return &GoalResponse{
+		Id:                    goal.Id,
+		Description:           goal.Description,
+		TargetValue:           goal.TargetValue,
+		Currency:              goal.Currency,
+		StartAt:               goal.StartAt,
+		Deadline:              goal.Deadline,
+		CategoryId:            goal.CategoryId,
+		Label:                 goal.Label,
+		Saved:                 saved,
+		Remaining:             remaining,
+		Progress:              math.Round(float64(saved)/float64(goal.TargetValue)*10000) / 10000,
+		MonthsRemaining:       monthsRemaining,
+		RequiredMonthlySaving: requiredMonthlySaving,
+		IsAchieved:            saved >= goal.TargetValue,
+		CreatedAt:             goal.CreatedAt,
+	}, nil
+}
+
+// getMonthsRemaining counts the months from the current one to the month of the deadline, both included,
+// there is no month left once the deadline has passed
+func getMonthsRemaining(today time.Time, deadline time.Time) uint {
+	if deadline.Before(today) {
+		return 0
+	}
+	return uint((deadline.Year()-today.Year())*12 + int(deadline.Month()) - int(today.Month()) + 1)
+}
+
+// getContributionDates plans a contribution a month on the day of the deadline, or on the last day of the shorter months,
+// from the later of the current day and the start of the goal
+func getContributionDates(now time.Time, startAt time.Time, deadline time.Time) []time.Time {
+	from := getToday(now, deadline)
+	if startAt.After(from) {
+		from = startAt
+	}
+	dates := []time.Time{}
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, deadline.Location()); !month.After(deadline); month = month.AddDate(0, 1, 0) {
+		lastDay := month.AddDate(0, 1, -1).Day()
+		day := deadline.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		date := time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, deadline.Location())
+		if date.Before(from) {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// getToday is the current day in the location of the deadline, so that the dates of the goal are compared day by day
+func getToday(now time.Time, deadline time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, deadline.Location())
+}
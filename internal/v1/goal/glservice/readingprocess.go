@@ -0,0 +1,59 @@
+package glservice
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/goal/repository"
+)
+
+type ReadingProcess interface {
+	GetById(searchCtx SearchContext) (*GoalResponse, error)
+	GetAll(searchCtx SearchContext) (*GoalListResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+	progress   *progressCalculator
+	now        func() time.Time
+}
+
+func NewReadingProcess(repository repository.Repository, converter erservice.Converter, now func() time.Time) ReadingProcess {
+	return &readingProcess{
+		repository: repository,
+		progress:   &progressCalculator{repository: repository, converter: converter},
+		now:        now,
+	}
+}
+
+func (rp *readingProcess) GetById(searchCtx SearchContext) (*GoalResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	goal, err := rp.repository.GetById(searchCtx.Ctx, searchCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, apperror.NotFound("Goal not found")
+	}
+	return rp.progress.buildResponse(searchCtx.Ctx, *goal, rp.now())
+}
+
+func (rp *readingProcess) GetAll(searchCtx SearchContext) (*GoalListResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	goals, err := rp.repository.GetAll(searchCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	now := rp.now()
+	records := []GoalResponse{}
+	for _, goal := range *goals {
+		goalResponse, err := rp.progress.buildResponse(searchCtx.Ctx, goal, now)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *goalResponse)
+	}
+	return &GoalListResponse{Records: records}, nil
+}
@@ -0,0 +1,37 @@
+package glservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllSuccess(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{goals: buildGoalsMock()}, &mockConverter{}, nowMock)
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Len(t, response.Records, 2)
+	assert.Equal(t, "Reserva de emergência", response.Records[0].Description)
+	assert.Equal(t, uint(10), response.Records[0].MonthsRemaining)
+	assert.Equal(t, "Viagem", response.Records[1].Description)
+	assert.Equal(t, 0.0, response.Records[1].Progress)
+}
+
+func TestGetAllEmpty(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{}, &mockConverter{}, nowMock)
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, &GoalListResponse{Records: []GoalResponse{}}, response)
+}
+
+func TestGetAllFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, &mockConverter{}, nowMock)
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,66 @@
+package glservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByIdSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{goals: buildGoalsMock(), contributions: buildContributionsMock()}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{}, nowMock)
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", UserToken: tokenMock})
+	assert.NoError(t, err)
+	categoryId := uint(11)
+	assert.Equal(t, &GoalResponse{
+		Id:                    "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01",
+		Description:           "Reserva de emergência",
+		TargetValue:           money.FromCents(1200000),
+		Currency:              "BRL",
+		StartAt:               date(2024, 1, 1),
+		Deadline:              date(2024, 12, 31),
+		CategoryId:            &categoryId,
+		Saved:                 money.FromCents(120100),
+		Remaining:             money.FromCents(1079900),
+		Progress:              0.1001,
+		MonthsRemaining:       10,
+		RequiredMonthlySaving: money.FromCents(107990),
+		IsAchieved:            false,
+		CreatedAt:             date(2024, 1, 1),
+	}, response)
+}
+
+func TestGetByIdDeadlinePassed(t *testing.T) {
+	_mockRepository := &mockRepository{goals: buildGoalsMock(), contributions: buildContributionsMock()}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{}, nowMock)
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "7e1f3a5b-92c4-4d6e-8f0a-1b2c3d4e5f60", UserToken: tokenMock})
+	assert.NoError(t, err)
+	// the contributions in BRL are converted to the USD of the goal
+	assert.Equal(t, money.FromCents(210050), response.Saved)
+	assert.Equal(t, money.FromCents(89950), response.Remaining)
+	assert.Equal(t, 0.7002, response.Progress)
+	assert.Equal(t, uint(0), response.MonthsRemaining)
+	assert.Equal(t, money.FromCents(89950), response.RequiredMonthlySaving)
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{goals: buildGoalsMock()}, &mockConverter{}, nowMock)
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d", UserToken: tokenMock})
+	assert.Equal(t, apperror.NotFound("Goal not found"), err)
+	assert.Nil(t, response)
+}
+
+func TestGetByIdFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, &mockConverter{}, nowMock)
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
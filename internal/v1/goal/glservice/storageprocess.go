@@ -0,0 +1,218 @@
+package glservice
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/goal/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/ipservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type StorageProcess interface {
+	Create(createCtx CreateContext) (*GoalResponse, error)
+	Delete(deleteCtx DeleteContext) error
+}
+
+type storageProcess struct {
+	repository        repository.Repository
+	generateUUID      func() uuid.UUID
+	referenceChecker  validation.ReferenceChecker
+	projectionProcess ipservice.StorageProcess
+	progress          *progressCalculator
+	now               func() time.Time
+	transactor        dbtx.Transactor
+}
+
+func NewStorageProcess(
+	repository repository.Repository,
+	generateUUID func() uuid.UUID,
+	referenceChecker validation.ReferenceChecker,
+	projectionProcess ipservice.StorageProcess,
+	converter erservice.Converter,
+	now func() time.Time,
+	transactor dbtx.Transactor) StorageProcess {
+	return &storageProcess{
+		repository:        repository,
+		generateUUID:      generateUUID,
+		referenceChecker:  referenceChecker,
+		projectionProcess: projectionProcess,
+		progress:          &progressCalculator{repository: repository, converter: converter},
+		now:               now,
+		transactor:        transactor,
+	}
+}
+
+func (sp *storageProcess) Create(createCtx CreateContext) (*GoalResponse, error) {
+	request := createCtx.Request
+	user := idpauth.GetUser(createCtx.UserToken)
+	now := sp.now()
+	request.Label = strings.TrimSpace(request.Label)
+	if request.CategoryId == nil && request.Label == "" {
+		categoryId := DEFAULT_CATEGORY_ID
+		request.CategoryId = &categoryId
+	}
+	startAt := getToday(now, request.Deadline)
+	if request.StartAt != nil {
+		startAt = *request.StartAt
+	}
+	err := validateGoal(request, startAt)
+	if err != nil {
+		return nil, err
+	}
+	err = sp.checkReferences(createCtx.Ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	goal := repository.NewGoalBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(now).
+		AddUserId(user.Id).
+		AddDescription(request.Description).
+		AddTargetValue(request.TargetValue).
+		AddCurrency(money.NormalizeCurrency(request.Currency)).
+		AddStartAt(startAt).
+		AddDeadline(request.Deadline).
+		AddCategoryId(request.CategoryId).
+		AddLabel(request.Label).
+		Build()
+	// the goal and its projections are saved in the same transaction, so a failed projection does not leave the goal behind
+	var goalResponse *GoalResponse
+	err = sp.transactor.Within(createCtx.Ctx, func(ctx context.Context) error {
+		goalSaved, err := sp.repository.Save(ctx, *goal)
+		if err != nil {
+			return err
+		}
+		goalResponse, err = sp.progress.buildResponse(ctx, *goalSaved, now)
+		if err != nil {
+			return err
+		}
+		if !request.GenerateProjections {
+			return nil
+		}
+		goalResponse.Projections, err = sp.createProjections(ctx, createCtx.UserToken, *goalSaved, goalResponse.Remaining, *request.PaymentTypeId, now)
+		if err != nil {
+			return err
+		}
+		projectionIds := []string{}
+		for _, projection := range goalResponse.Projections {
+			projectionIds = append(projectionIds, projection.Id)
+		}
+		return sp.repository.SaveProjections(ctx, goalSaved.Id, projectionIds)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return goalResponse, nil
+}
+
+// createProjections splits the remaining amount in the invoice projections of the monthly contributions until the deadline
+func (sp *storageProcess) createProjections(ctx context.Context, userToken string, goal repository.Goal, remaining money.Money, paymentTypeId uint, now time.Time) ([]ProjectionResponse, error) {
+	projections := []ProjectionResponse{}
+	dates := getContributionDates(now, goal.StartAt, goal.Deadline)
+	if remaining <= 0 || len(dates) == 0 {
+		return projections, nil
+	}
+	for index, value := range remaining.Split(len(dates)) {
+		projection, err := sp.projectionProcess.Create(ipservice.CreateContext{
+			Ctx: ctx,
+			Request: ipservice.CreateRequest{
+				PayIn:         dates[index],
+				BuyAt:         dates[index],
+				Description:   goal.Description,
+				Value:         value,
+				Currency:      goal.Currency,
+				CategoryId:    *goal.CategoryId,
+				PaymentTypeId: paymentTypeId,
+			},
+			UserToken: userToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		projections = append(projections, ProjectionResponse{Id: projection.Id, PayIn: projection.PayIn, Value: projection.Value})
+	}
+	return projections, nil
+}
+
+// Delete removes the goal and moves to the trash the invoice projections generated for it that are still pending,
+// the realized ones are kept as they are the contributions already paid
+func (sp *storageProcess) Delete(deleteCtx DeleteContext) error {
+	user := idpauth.GetUser(deleteCtx.UserToken)
+	goal, err := sp.repository.GetById(deleteCtx.Ctx, deleteCtx.Id, user.Id)
+	if err != nil {
+		return err
+	}
+	if goal == nil {
+		return apperror.NotFound("Goal not found")
+	}
+	return sp.transactor.Within(deleteCtx.Ctx, func(ctx context.Context) error {
+		projections, err := sp.repository.GetPendingProjections(ctx, goal.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		for _, projection := range *projections {
+			err := sp.projectionProcess.Delete(ipservice.DeleteContext{
+				Ctx:       ctx,
+				UserToken: deleteCtx.UserToken,
+				Id:        projection.Id,
+				Version:   projection.Version,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return sp.repository.Delete(ctx, goal.Id, user.Id)
+	})
+}
+
+// validateGoal checks the combination of the fields, which the binding of each field can not tell
+func validateGoal(request CreateRequest, startAt time.Time) error {
+	errs := validation.Errors{}
+	if request.Deadline.Before(startAt) {
+		errs = append(errs, validation.FieldError{Field: "deadline", Code: validation.CODE_INVALID, Message: "The deadline must not be before the start_at"})
+	}
+	if request.GenerateProjections && request.PaymentTypeId == nil {
+		errs = append(errs, validation.FieldError{Field: "payment_type_id", Code: validation.CODE_REQUIRED, Message: "The payment_type_id must be informed to generate the projections"})
+	}
+	if request.GenerateProjections && request.CategoryId == nil {
+		errs = append(errs, validation.FieldError{Field: "category_id", Code: validation.CODE_REQUIRED, Message: "The category_id must be informed to generate the projections"})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (sp *storageProcess) checkReferences(ctx context.Context, request CreateRequest) error {
+	errs := validation.Errors{}
+	if request.CategoryId != nil {
+		exists, err := sp.referenceChecker.InvoiceCategoryExists(ctx, *request.CategoryId)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			errs = append(errs, validation.NotFound("category_id"))
+		}
+	}
+	if request.GenerateProjections {
+		exists, err := sp.referenceChecker.PaymentTypeExists(ctx, *request.PaymentTypeId)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			errs = append(errs, validation.NotFound("payment_type_id"))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,415 @@
+package glservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/goal/repository"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/ipservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	goals            []repository.Goal
+	contributions    []repository.Contribution
+	projections      []repository.Projection
+	goalSaved        *repository.Goal
+	projectionsSaved []string
+	deleted          string
+	err              error
+}
+
+func (m *mockRepository) Save(ctx context.Context, goal repository.Goal) (*repository.Goal, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.goalSaved = &goal
+	return &goal, nil
+}
+
+func (m *mockRepository) GetById(ctx context.Context, id string, userId string) (*repository.Goal, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, goal := range m.goals {
+		if goal.Id == id && goal.UserId == userId {
+			goalFound := goal
+			return &goalFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) GetAll(ctx context.Context, userId string) (*[]repository.Goal, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &m.goals, nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id string, userId string) error {
+	m.deleted = id
+	return nil
+}
+
+func (m *mockRepository) GetContributions(ctx context.Context, goal repository.Goal) (*[]repository.Contribution, error) {
+	return &m.contributions, nil
+}
+
+func (m *mockRepository) SaveProjections(ctx context.Context, goalId string, projectionIds []string) error {
+	m.projectionsSaved = append(m.projectionsSaved, projectionIds...)
+	return nil
+}
+
+func (m *mockRepository) GetPendingProjections(ctx context.Context, goalId string, userId string) (*[]repository.Projection, error) {
+	return &m.projections, nil
+}
+
+type mockReferenceChecker struct {
+	missingInvoiceCategories map[uint]bool
+	missingPaymentTypes      map[uint]bool
+	err                      error
+}
+
+func (rc *mockReferenceChecker) GainCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return true, rc.err
+}
+
+func (rc *mockReferenceChecker) InvoiceCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingInvoiceCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) PaymentTypeExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingPaymentTypes[id], rc.err
+}
+
+// mockConverter doubles the amounts in USD
+type mockConverter struct {
+	err error
+}
+
+func (m *mockConverter) Convert(convertCtx erservice.ConvertContext) (money.Money, error) {
+	if convertCtx.From == convertCtx.To {
+		return convertCtx.Amount, nil
+	}
+	if m.err != nil {
+		return 0, m.err
+	}
+	return convertCtx.Amount * 2, nil
+}
+
+type mockProjectionProcess struct {
+	requests  []ipservice.CreateRequest
+	deleted   []ipservice.DeleteContext
+	err       error
+	deleteErr error
+}
+
+func (m *mockProjectionProcess) Create(createCtx ipservice.CreateContext) (*ipservice.InvoiceProjectionResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.requests = append(m.requests, createCtx.Request)
+	return &ipservice.InvoiceProjectionResponse{
+		Id:    fmt.Sprintf("projection-%d", len(m.requests)),
+		PayIn: createCtx.Request.PayIn,
+		Value: createCtx.Request.Value,
+	}, nil
+}
+
+func (m *mockProjectionProcess) CreateFromBoleto(createFromBoletoCtx ipservice.CreateFromBoletoContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) Update(updateCtx ipservice.UpdateContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) Patch(patchCtx ipservice.PatchContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) Delete(deleteCtx ipservice.DeleteContext) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	m.deleted = append(m.deleted, deleteCtx)
+	return nil
+}
+
+func (m *mockProjectionProcess) Restore(searchCtx ipservice.SearchContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) CreateInvoice(createInvoiceCtx ipservice.CreateInvoiceContext) (*ipservice.InvoiceStat, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) CreateInvoiceBatch(createInvoiceBatchCtx ipservice.CreateInvoiceBatchContext) (*ipservice.InvoiceBatchResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) RevertInvoice(revertInvoiceCtx ipservice.RevertInvoiceContext) (*ipservice.RevertInvoiceStat, error) {
+	return nil, nil
+}
+
+// mockTransactor tells whether the function ran in the transaction failed, when it would be rolled back
+type mockTransactor struct {
+	rolledBack bool
+}
+
+func (t *mockTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	t.rolledBack = err != nil
+	return err
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func nowMock() time.Time {
+	return time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+}
+
+func uuidMock() uuid.UUID {
+	return uuid.FromStringOrNil("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01")
+}
+
+func buildContributionsMock() []repository.Contribution {
+	return []repository.Contribution{
+		{Currency: "BRL", PayAt: date(2024, 2, 10), Value: money.FromCents(100000)},
+		{Currency: "USD", PayAt: date(2024, 3, 10), Value: money.FromCents(10050)},
+	}
+}
+
+func TestCreateWithProjections(t *testing.T) {
+	startAt := date(2024, 1, 1)
+	paymentTypeId := uint(2)
+	_mockRepository := &mockRepository{contributions: buildContributionsMock()}
+	_mockProjectionProcess := &mockProjectionProcess{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{}, _mockProjectionProcess, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description:         "Reserva de emergência",
+			TargetValue:         money.FromCents(1200000),
+			StartAt:             &startAt,
+			Deadline:            date(2024, 12, 31),
+			GenerateProjections: true,
+			PaymentTypeId:       &paymentTypeId,
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	categoryId := uint(11)
+	assert.Equal(t, &repository.Goal{
+		Id:          "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01",
+		CreatedAt:   nowMock(),
+		UserId:      "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Description: "Reserva de emergência",
+		TargetValue: money.FromCents(1200000),
+		Currency:    "BRL",
+		StartAt:     startAt,
+		Deadline:    date(2024, 12, 31),
+		CategoryId:  &categoryId,
+	}, _mockRepository.goalSaved)
+	assert.Equal(t, money.FromCents(120100), response.Saved)
+	assert.Equal(t, money.FromCents(1079900), response.Remaining)
+	assert.Equal(t, 0.1001, response.Progress)
+	assert.Equal(t, uint(10), response.MonthsRemaining)
+	assert.Equal(t, money.FromCents(107990), response.RequiredMonthlySaving)
+	assert.False(t, response.IsAchieved)
+
+	payIns := []time.Time{
+		date(2024, 3, 31), date(2024, 4, 30), date(2024, 5, 31), date(2024, 6, 30), date(2024, 7, 31),
+		date(2024, 8, 31), date(2024, 9, 30), date(2024, 10, 31), date(2024, 11, 30), date(2024, 12, 31),
+	}
+	assert.Len(t, response.Projections, len(payIns))
+	for index, payIn := range payIns {
+		assert.Equal(t, payIn, response.Projections[index].PayIn)
+		assert.Equal(t, money.FromCents(107990), response.Projections[index].Value)
+		assert.Equal(t, ipservice.CreateRequest{
+			PayIn:         payIn,
+			BuyAt:         payIn,
+			Description:   "Reserva de emergência",
+			Value:         money.FromCents(107990),
+			Currency:      "BRL",
+			CategoryId:    11,
+			PaymentTypeId: 2,
+		}, _mockProjectionProcess.requests[index])
+	}
+	assert.Equal(t, []string{
+		"projection-1", "projection-2", "projection-3", "projection-4", "projection-5",
+		"projection-6", "projection-7", "projection-8", "projection-9", "projection-10",
+	}, _mockRepository.projectionsSaved)
+}
+
+func TestCreateProjectionsFromNextMonth(t *testing.T) {
+	paymentTypeId := uint(4)
+	categoryId := uint(9)
+	_mockProjectionProcess := &mockProjectionProcess{}
+	now := func() time.Time { return time.Date(2024, 3, 20, 10, 0, 0, 0, time.UTC) }
+	_storageProcess := NewStorageProcess(&mockRepository{}, uuidMock, &mockReferenceChecker{}, _mockProjectionProcess, &mockConverter{}, now, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description:         "Viagem",
+			TargetValue:         money.FromCents(100000),
+			Deadline:            date(2024, 6, 15),
+			CategoryId:          &categoryId,
+			Label:               " viagem ",
+			GenerateProjections: true,
+			PaymentTypeId:       &paymentTypeId,
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, date(2024, 3, 20), response.StartAt)
+	assert.Equal(t, "viagem", response.Label)
+	assert.Equal(t, uint(4), response.MonthsRemaining)
+	assert.Equal(t, money.FromCents(25000), response.RequiredMonthlySaving)
+	assert.Equal(t, []ProjectionResponse{
+		{Id: "projection-1", PayIn: date(2024, 4, 15), Value: money.FromCents(33334)},
+		{Id: "projection-2", PayIn: date(2024, 5, 15), Value: money.FromCents(33333)},
+		{Id: "projection-3", PayIn: date(2024, 6, 15), Value: money.FromCents(33333)},
+	}, response.Projections)
+}
+
+func TestCreateWithoutProjections(t *testing.T) {
+	_mockRepository := &mockRepository{contributions: buildContributionsMock()}
+	_mockProjectionProcess := &mockProjectionProcess{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{}, _mockProjectionProcess, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description: "Curso",
+			TargetValue: money.FromCents(100000),
+			Currency:    "BRL",
+			Deadline:    date(2024, 3, 31),
+			Label:       "curso",
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, _mockRepository.goalSaved.CategoryId)
+	assert.Equal(t, money.FromCents(120100), response.Saved)
+	assert.Equal(t, money.FromCents(0), response.Remaining)
+	assert.Equal(t, 1.201, response.Progress)
+	assert.Equal(t, money.FromCents(0), response.RequiredMonthlySaving)
+	assert.True(t, response.IsAchieved)
+	assert.Nil(t, response.Projections)
+	assert.Empty(t, _mockProjectionProcess.requests)
+}
+
+func TestCreateInvalidFields(t *testing.T) {
+	startAt := date(2024, 6, 1)
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{}, &mockProjectionProcess{}, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description:         "Viagem",
+			TargetValue:         money.FromCents(100000),
+			StartAt:             &startAt,
+			Deadline:            date(2024, 5, 31),
+			Label:               "viagem",
+			GenerateProjections: true,
+		},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, validation.Errors{
+		{Field: "deadline", Code: validation.CODE_INVALID, Message: "The deadline must not be before the start_at"},
+		{Field: "payment_type_id", Code: validation.CODE_REQUIRED, Message: "The payment_type_id must be informed to generate the projections"},
+		{Field: "category_id", Code: validation.CODE_REQUIRED, Message: "The category_id must be informed to generate the projections"},
+	}, err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.goalSaved)
+}
+
+func TestCreateReferencesNotFound(t *testing.T) {
+	categoryId := uint(99)
+	paymentTypeId := uint(99)
+	_mockRepository := &mockRepository{}
+	_referenceChecker := &mockReferenceChecker{missingInvoiceCategories: map[uint]bool{99: true}, missingPaymentTypes: map[uint]bool{99: true}}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _referenceChecker, &mockProjectionProcess{}, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description:         "Viagem",
+			TargetValue:         money.FromCents(100000),
+			Deadline:            date(2024, 12, 31),
+			CategoryId:          &categoryId,
+			GenerateProjections: true,
+			PaymentTypeId:       &paymentTypeId,
+		},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, validation.Errors{validation.NotFound("category_id"), validation.NotFound("payment_type_id")}, err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.goalSaved)
+}
+
+func TestCreateConvertFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{contributions: buildContributionsMock()}, uuidMock, &mockReferenceChecker{},
+		&mockProjectionProcess{}, &mockConverter{err: errors.New("An error has been ocurred")}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Viagem", TargetValue: money.FromCents(100000), Deadline: date(2024, 12, 31)},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestCreateProjectionFail(t *testing.T) {
+	paymentTypeId := uint(2)
+	_mockRepository := &mockRepository{}
+	_mockTransactor := &mockTransactor{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{},
+		&mockProjectionProcess{err: errors.New("An error has been ocurred")}, &mockConverter{}, nowMock, _mockTransactor)
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description:         "Viagem",
+			TargetValue:         money.FromCents(100000),
+			Deadline:            date(2024, 12, 31),
+			GenerateProjections: true,
+			PaymentTypeId:       &paymentTypeId,
+		},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.NotNil(t, _mockRepository.goalSaved)
+	assert.True(t, _mockTransactor.rolledBack)
+}
+
+func TestCreateFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{err: errors.New("An error has been ocurred")}, uuidMock, &mockReferenceChecker{},
+		&mockProjectionProcess{}, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Viagem", TargetValue: money.FromCents(100000), Deadline: date(2024, 12, 31)},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
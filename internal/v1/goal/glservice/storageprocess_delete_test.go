@@ -0,0 +1,96 @@
+package glservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/goal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildGoalsMock() []repository.Goal {
+	categoryId := uint(11)
+	return []repository.Goal{
+		{
+			Id:          "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01",
+			CreatedAt:   date(2024, 1, 1),
+			UserId:      "5832a502-bede-492d-8dc1-b13b32c30f29",
+			Description: "Reserva de emergência",
+			TargetValue: money.FromCents(1200000),
+			Currency:    "BRL",
+			StartAt:     date(2024, 1, 1),
+			Deadline:    date(2024, 12, 31),
+			CategoryId:  &categoryId,
+		},
+		{
+			Id:          "7e1f3a5b-92c4-4d6e-8f0a-1b2c3d4e5f60",
+			CreatedAt:   date(2023, 6, 1),
+			UserId:      "5832a502-bede-492d-8dc1-b13b32c30f29",
+			Description: "Viagem",
+			TargetValue: money.FromCents(300000),
+			Currency:    "USD",
+			StartAt:     date(2023, 6, 1),
+			Deadline:    date(2024, 2, 29),
+			Label:       "viagem",
+		},
+	}
+}
+
+func TestDeleteSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{goals: buildGoalsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{}, &mockProjectionProcess{}, &mockConverter{}, nowMock, &mockTransactor{})
+
+	err := _storageProcess.Delete(DeleteContext{Ctx: context.TODO(), Id: "7e1f3a5b-92c4-4d6e-8f0a-1b2c3d4e5f60", UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, "7e1f3a5b-92c4-4d6e-8f0a-1b2c3d4e5f60", _mockRepository.deleted)
+}
+
+func TestDeleteWithProjections(t *testing.T) {
+	_mockRepository := &mockRepository{goals: buildGoalsMock(), projections: []repository.Projection{
+		{Id: "projection-1", Version: 1},
+		{Id: "projection-2", Version: 3},
+	}}
+	_mockProjectionProcess := &mockProjectionProcess{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{}, _mockProjectionProcess, &mockConverter{}, nowMock, &mockTransactor{})
+
+	err := _storageProcess.Delete(DeleteContext{Ctx: context.TODO(), Id: "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Len(t, _mockProjectionProcess.deleted, 2)
+	assert.Equal(t, "projection-1", _mockProjectionProcess.deleted[0].Id)
+	assert.Equal(t, uint(1), _mockProjectionProcess.deleted[0].Version)
+	assert.Equal(t, "projection-2", _mockProjectionProcess.deleted[1].Id)
+	assert.Equal(t, uint(3), _mockProjectionProcess.deleted[1].Version)
+	assert.Equal(t, "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", _mockRepository.deleted)
+}
+
+func TestDeleteProjectionFail(t *testing.T) {
+	_mockRepository := &mockRepository{goals: buildGoalsMock(), projections: []repository.Projection{{Id: "projection-1", Version: 1}}}
+	_mockTransactor := &mockTransactor{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{},
+		&mockProjectionProcess{deleteErr: errors.New("An error has been ocurred")}, &mockConverter{}, nowMock, _mockTransactor)
+
+	err := _storageProcess.Delete(DeleteContext{Ctx: context.TODO(), Id: "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.True(t, _mockTransactor.rolledBack)
+	assert.Empty(t, _mockRepository.deleted)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{goals: buildGoalsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{}, &mockProjectionProcess{}, &mockConverter{}, nowMock, &mockTransactor{})
+
+	err := _storageProcess.Delete(DeleteContext{Ctx: context.TODO(), Id: "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d", UserToken: tokenMock})
+	assert.Equal(t, apperror.NotFound("Goal not found"), err)
+	assert.Empty(t, _mockRepository.deleted)
+}
+
+func TestDeleteFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{err: errors.New("An error has been ocurred")}, uuidMock, &mockReferenceChecker{},
+		&mockProjectionProcess{}, &mockConverter{}, nowMock, &mockTransactor{})
+
+	err := _storageProcess.Delete(DeleteContext{Ctx: context.TODO(), Id: "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", UserToken: tokenMock})
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package glservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+// DEFAULT_CATEGORY_ID is the invoice category of the contributions when the goal informs neither a category nor a label
+const DEFAULT_CATEGORY_ID = uint(11) // Investimentos
+
+type CreateContext struct {
+	Ctx       context.Context
+	Request   CreateRequest
+	UserToken string
+}
+
+type SearchContext struct {
+	Ctx       context.Context
+	Id        string
+	UserToken string
+}
+
+type DeleteContext struct {
+	Ctx       context.Context
+	Id        string
+	UserToken string
+}
+
+// CreateRequest counts as contributions the invoices of the category and of the label, the start_at defaults to the current day.
+// The generate_projections plans the remaining amount in invoice projections, one a month until the deadline,
+// and needs the payment_type_id and a category
+type CreateRequest struct {
+	Description         string      `json:"description" binding:"notblank,max=255"`
+	TargetValue         money.Money `json:"target_value" binding:"gt=0" swaggertype:"number"`
+	Currency            string      `json:"currency" binding:"omitempty,iso4217"`
+	StartAt             *time.Time  `json:"start_at"`
+	Deadline            time.Time   `json:"deadline" binding:"required"`
+	CategoryId          *uint       `json:"category_id" binding:"omitempty,min=1"`
+	Label               string      `json:"label" binding:"max=255"`
+	GenerateProjections bool        `json:"generate_projections"`
+	PaymentTypeId       *uint       `json:"payment_type_id" binding:"omitempty,min=1"`
+}
+
+type GoalResponse struct {
+	Id                    string               `json:"id"`
+	Description           string               `json:"description"`
+	TargetValue           money.Money          `json:"target_value" swaggertype:"number"`
+	Currency              string               `json:"currency"`
+	StartAt               time.Time            `json:"start_at"`
+	Deadline              time.Time            `json:"deadline"`
+	CategoryId            *uint                `json:"category_id,omitempty"`
+	Label                 string               `json:"label,omitempty"`
+	Saved                 money.Money          `json:"saved" swaggertype:"number"`
+	Remaining             money.Money          `json:"remaining" swaggertype:"number"`
+	Progress              float64              `json:"progress"`
+	MonthsRemaining       uint                 `json:"months_remaining"`
+	RequiredMonthlySaving money.Money          `json:"required_monthly_saving" swaggertype:"number"`
+	IsAchieved            bool                 `json:"is_achieved"`
+	Projections           []ProjectionResponse `json:"projections,omitempty"`
+	CreatedAt             time.Time            `json:"created_at"`
+}
+
+// ProjectionResponse is an invoice projection planned for a contribution of the goal
+type ProjectionResponse struct {
+	Id    string      `json:"id"`
+	PayIn time.Time   `json:"pay_in"`
+	Value money.Money `json:"value" swaggertype:"number"`
+}
+
+type GoalListResponse struct {
+	Records []GoalResponse `json:"records"`
+}
@@ -0,0 +1,161 @@
+package goal
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/goal/glservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	Create(c *gin.Context)
+	GetAll(c *gin.Context)
+	GetById(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	storageProcess glservice.StorageProcess
+	readingProcess glservice.ReadingProcess
+}
+
+func NewHandler(storageProcess glservice.StorageProcess, readingProcess glservice.ReadingProcess) Handler {
+	return &handler{storageProcess: storageProcess, readingProcess: readingProcess}
+}
+
+// Create godoc
+// @Summary Criar uma meta de economia
+// @Description Este endpoint permite criar uma meta com o valor a ser guardado até o prazo. As contribuições são as despesas pagas desde o início da meta na categoria e com o marcador informados, e na categoria Investimentos quando nenhum dos dois é informado.
+// @Description Com generate_projections, o valor que falta é dividido em despesas previstas, uma por mês no dia do prazo até o prazo, com o tipo de pagamento informado
+// @Tags Goal
+// @Accept json
+// @Produce json
+// @Param goal body glservice.CreateRequest true "Modelo de criação da meta"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} glservice.GoalResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/goal [post]
+func (h *handler) Create(c *gin.Context) {
+	var request glservice.CreateRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Goal::StorageProcess::Create", "Create new goal", nil)
+	createCtx := glservice.CreateContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	goalCreated, err := h.storageProcess.Create(createCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, goalCreated)
+}
+
+// @Summary Obter as metas de economia
+// @Description Este endpoint permite obter as metas, da mais próxima do prazo para a mais distante, com o valor guardado, o progresso e a economia mensal necessária para atingir cada uma
+// @Tags Goal
+// @Accept json
+// @Produce json
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} glservice.GoalListResponse
+// @Router /v1/goal [get]
+func (h *handler) GetAll(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Goal::ReadingProcess::GetAll", "Get all goals", nil)
+	searchCtx := glservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+	}
+	goals, err := h.readingProcess.GetAll(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, goals)
+}
+
+// @Summary Obter uma meta de economia
+// @Description Este endpoint permite obter uma meta com o valor guardado, o progresso e a economia mensal necessária para atingi-la.
+// @Description A economia mensal divide o valor que falta pelos meses até o prazo, contando o mês atual, e é o próprio valor que falta quando o prazo já passou
+// @Tags Goal
+// @Accept json
+// @Produce json
+// @Param id path string true "Id da meta"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} glservice.GoalResponse
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/goal/{id} [get]
+func (h *handler) GetById(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Goal::ReadingProcess::GetById", "Get a goal by id", nil)
+	searchCtx := glservice.SearchContext{
+		Ctx:       ctx,
+		Id:        c.Param("id"),
+		UserToken: userToken,
+	}
+	goal, err := h.readingProcess.GetById(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, goal)
+}
+
+// @Summary Remover uma meta de economia
+// @Description Este endpoint permite remover uma meta. As despesas previstas geradas para ela são mantidas
+// @Tags Goal
+// @Accept json
+// @Produce json
+// @Param id path string true "Id da meta"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ResponseDefault{status=int,message=string}
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/goal/{id} [delete]
+func (h *handler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Goal::StorageProcess::Delete", "Delete a goal", nil)
+	deleteCtx := glservice.DeleteContext{
+		Ctx:       ctx,
+		Id:        c.Param("id"),
+		UserToken: userToken,
+	}
+	err := h.storageProcess.Delete(deleteCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Goal removed"})
+}
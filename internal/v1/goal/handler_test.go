@@ -0,0 +1,228 @@
+package goal
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/goal/glservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type storageProcessMock struct {
+	err     error
+	goal    *glservice.GoalResponse
+	request glservice.CreateRequest
+	deleted string
+}
+
+func (sp *storageProcessMock) Create(createCtx glservice.CreateContext) (*glservice.GoalResponse, error) {
+	sp.request = createCtx.Request
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.goal, nil
+}
+
+func (sp *storageProcessMock) Delete(deleteCtx glservice.DeleteContext) error {
+	if sp.err != nil {
+		return sp.err
+	}
+	sp.deleted = deleteCtx.Id
+	return nil
+}
+
+type readingProcessMock struct {
+	err   error
+	goal  *glservice.GoalResponse
+	goals *glservice.GoalListResponse
+	id    string
+}
+
+func (rp *readingProcessMock) GetById(searchCtx glservice.SearchContext) (*glservice.GoalResponse, error) {
+	rp.id = searchCtx.Id
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.goal, nil
+}
+
+func (rp *readingProcessMock) GetAll(searchCtx glservice.SearchContext) (*glservice.GoalListResponse, error) {
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.goals, nil
+}
+
+func buildGoalResponseMock() *glservice.GoalResponse {
+	categoryId := uint(11)
+	return &glservice.GoalResponse{
+		Id:                    "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01",
+		Description:           "Reserva de emergência",
+		TargetValue:           money.FromCents(1200000),
+		Currency:              "BRL",
+		StartAt:               time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Deadline:              time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		CategoryId:            &categoryId,
+		Saved:                 money.FromCents(120100),
+		Remaining:             money.FromCents(1079900),
+		Progress:              0.1001,
+		MonthsRemaining:       10,
+		RequiredMonthlySaving: money.FromCents(107990),
+		CreatedAt:             time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+const goalResponseBody = `{"id":"2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01","description":"Reserva de emergência","target_value":12000,"currency":"BRL","start_at":"2024-01-01T00:00:00Z","deadline":"2024-12-31T00:00:00Z","category_id":11,"saved":1201,"remaining":10799,"progress":0.1001,"months_remaining":10,"required_monthly_saving":1079.9,"is_achieved":false,"created_at":"2024-01-01T10:00:00Z"}`
+
+func TestCreateSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{goal: buildGoalResponseMock()}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/goal", handler.Create)
+
+	body := []byte(`{"description": "Reserva de emergência", "target_value": 12000, "start_at": "2024-01-01T00:00:00Z", "deadline": "2024-12-31T00:00:00Z"}`)
+	req, _ := http.NewRequest("POST", "/v1/goal", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, goalResponseBody, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, money.FromCents(1200000), _storageProcessMock.request.TargetValue)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *_storageProcessMock.request.StartAt)
+}
+
+func TestCreateInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/goal", handler.Create)
+
+	body := []byte(`{"description": " ", "target_value": 0, "deadline": "2024-12-31T00:00:00Z", "category_id": 0}`)
+	req, _ := http.NewRequest("POST", "/v1/goal", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"description","code":"required","message":"The description must be informed"},{"field":"target_value","code":"too_small","message":"The target_value must be greater than 0"},{"field":"category_id","code":"too_small","message":"The category_id must be at least 1"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestGetAllSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{
+		goals: &glservice.GoalListResponse{Records: []glservice.GoalResponse{*buildGoalResponseMock()}},
+	}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/goal", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/goal", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, `{"records":[`+goalResponseBody+`]}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetAllFail(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/goal", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/goal", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetByIdSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{goal: buildGoalResponseMock()}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/goal/:id", handler.GetById)
+
+	req, _ := http.NewRequest("GET", "/v1/goal/2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, goalResponseBody, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", _readingProcessMock.id)
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: apperror.NotFound("Goal not found")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/goal/:id", handler.GetById)
+
+	req, _ := http.NewRequest("GET", "/v1/goal/2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Goal not found"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.DELETE("/goal/:id", handler.Delete)
+
+	req, _ := http.NewRequest("DELETE", "/v1/goal/2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, `{"message":"Goal removed","status":200}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", _storageProcessMock.deleted)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{err: apperror.NotFound("Goal not found")}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.DELETE("/goal/:id", handler.Delete)
+
+	req, _ := http.NewRequest("DELETE", "/v1/goal/2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Goal not found"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
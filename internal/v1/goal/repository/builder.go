@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type GoalBuilder struct {
+	id          string
+	createdAt   time.Time
+	userId      string
+	description string
+	targetValue money.Money
+	currency    string
+	startAt     time.Time
+	deadline    time.Time
+	categoryId  *uint
+	label       string
+}
+
+func NewGoalBuilder() *GoalBuilder {
+	return &GoalBuilder{}
+}
+func (builder *GoalBuilder) AddId(id string) *GoalBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *GoalBuilder) AddCreatedAt(createdAt time.Time) *GoalBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *GoalBuilder) AddUserId(userId string) *GoalBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *GoalBuilder) AddDescription(description string) *GoalBuilder {
+	builder.description = description
+	return builder
+}
+func (builder *GoalBuilder) AddTargetValue(targetValue money.Money) *GoalBuilder {
+	builder.targetValue = targetValue
+	return builder
+}
+func (builder *GoalBuilder) AddCurrency(currency string) *GoalBuilder {
+	builder.currency = currency
+	return builder
+}
+func (builder *GoalBuilder) AddStartAt(startAt time.Time) *GoalBuilder {
+	builder.startAt = startAt
+	return builder
+}
+func (builder *GoalBuilder) AddDeadline(deadline time.Time) *GoalBuilder {
+	builder.deadline = deadline
+	return builder
+}
+func (builder *GoalBuilder) AddCategoryId(categoryId *uint) *GoalBuilder {
+	builder.categoryId = categoryId
+	return builder
+}
+func (builder *GoalBuilder) AddLabel(label string) *GoalBuilder {
+	builder.label = label
+	return builder
+}
+func (builder *GoalBuilder) Build() *Goal {
+	goal := Goal{}
+
+	goal.Id = builder.id
+	goal.CreatedAt = builder.createdAt
+	goal.UserId = builder.userId
+	goal.Description = builder.description
+	goal.TargetValue = builder.targetValue
+	goal.Currency = builder.currency
+	goal.StartAt = builder.startAt
+	goal.Deadline = builder.deadline
+	goal.CategoryId = builder.categoryId
+	goal.Label = builder.label
+
+	return &goal
+}
@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+)
+
+type Repository interface {
+	Save(ctx context.Context, goal Goal) (*Goal, error)
+	GetById(ctx context.Context, id string, userId string) (*Goal, error)
+	GetAll(ctx context.Context, userId string) (*[]Goal, error)
+	Delete(ctx context.Context, id string, userId string) error
+	GetContributions(ctx context.Context, goal Goal) (*[]Contribution, error)
+	SaveProjections(ctx context.Context, goalId string, projectionIds []string) error
+	GetPendingProjections(ctx context.Context, goalId string, userId string) (*[]Projection, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Save(ctx context.Context, goal Goal) (*Goal, error) {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO goal (id, created_at, user_id, description, target_value, currency, start_at, deadline, category_id, label)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		goal.Id,
+		goal.CreatedAt.Unix(),
+		goal.UserId,
+		goal.Description,
+		goal.TargetValue,
+		goal.Currency,
+		goal.StartAt,
+		goal.Deadline,
+		goal.CategoryId,
+		goal.Label,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+func (r *repository) scanGoals(rows *sql.Rows) (*[]Goal, error) {
+	goalList := []Goal{}
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var categoryId sql.NullInt64
+		var goal Goal
+
+		err := rows.Scan(
+			&goal.Id,
+			&createdAtTimestamp,
+			&goal.UserId,
+			&goal.Description,
+			&goal.TargetValue,
+			&goal.Currency,
+			&goal.StartAt,
+			&goal.Deadline,
+			&categoryId,
+			&goal.Label)
+		if err != nil {
+			return nil, err
+		}
+		goal.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		if categoryId.Valid {
+			id := uint(categoryId.Int64)
+			goal.CategoryId = &id
+		}
+
+		goalList = append(goalList, goal)
+	}
+	return &goalList, nil
+}
+
+func (r *repository) GetById(ctx context.Context, id string, userId string) (*Goal, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			description,
+			target_value,
+			currency,
+			start_at,
+			deadline,
+			category_id,
+			label
+		FROM
+			goal
+		WHERE
+			id = ? AND user_id = ?`
+	rows, err := r.db.QueryContext(ctx, query, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	goalList, err := r.scanGoals(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(*goalList) == 0 {
+		return nil, nil
+	}
+	return &(*goalList)[0], nil
+}
+
+// GetAll returns the goals of the user from the nearest deadline
+func (r *repository) GetAll(ctx context.Context, userId string) (*[]Goal, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			description,
+			target_value,
+			currency,
+			start_at,
+			deadline,
+			category_id,
+			label
+		FROM
+			goal
+		WHERE
+			user_id = ?
+		ORDER BY deadline ASC, created_at ASC`
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanGoals(rows)
+}
+
+// Delete removes the goal with the links to the projections generated for it
+func (r *repository) Delete(ctx context.Context, id string, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	projectionStmt, err := tx.PrepareContext(ctx, `
+		DELETE FROM goal_projection WHERE goal_id IN (SELECT id FROM goal WHERE id = ? AND user_id = ?)`)
+	if err != nil {
+		return err
+	}
+	defer projectionStmt.Close()
+	_, err = projectionStmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM goal WHERE id = ? AND user_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetContributions sums, by currency and day, the invoices paid between the start and the deadline of the goal
+// that are in its category and carry its label, the category and the label are only compared when informed
+func (r *repository) GetContributions(ctx context.Context, goal Goal) (*[]Contribution, error) {
+	query := `
+		SELECT
+			i.currency,
+			i.pay_at,
+			SUM(i.value)
+		FROM
+			invoice i
+		WHERE
+			i.user_id = ? AND i.deleted_at IS NULL AND i.pay_at BETWEEN ? AND ?
+			AND (? IS NULL OR i.category_id = ?)
+			AND (? = '' OR EXISTS (
+				SELECT 1
+				FROM record_label rl
+				INNER JOIN label l ON l.id = rl.label_id
+				WHERE rl.entity = 'invoice' AND rl.entity_id = i.id AND l.user_id = i.user_id AND l.label = ?))
+		GROUP BY i.currency, i.pay_at
+		ORDER BY i.pay_at ASC`
+	rows, err := r.db.QueryContext(ctx, query,
+		goal.UserId, goal.StartAt, goal.Deadline, goal.CategoryId, goal.CategoryId, goal.Label, goal.Label)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	contributionList := []Contribution{}
+	for rows.Next() {
+		var contribution Contribution
+		err := rows.Scan(&contribution.Currency, &contribution.PayAt, &contribution.Value)
+		if err != nil {
+			return nil, err
+		}
+		contributionList = append(contributionList, contribution)
+	}
+	return &contributionList, nil
+}
+
+// SaveProjections links the invoice projections generated for the monthly contributions to the goal
+func (r *repository) SaveProjections(ctx context.Context, goalId string, projectionIds []string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO goal_projection (goal_id, invoice_projection_id) VALUES (?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, projectionId := range projectionIds {
+		_, err = stmt.Exec(goalId, projectionId)
+		if err != nil {
+			return err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPendingProjections returns the projections generated for the goal that were neither realized nor removed
+func (r *repository) GetPendingProjections(ctx context.Context, goalId string, userId string) (*[]Projection, error) {
+	query := `
+		SELECT
+			ip.id,
+			ip.version
+		FROM
+			goal_projection gp
+		INNER JOIN invoice_projection ip ON
+			ip.id = gp.invoice_projection_id
+		WHERE
+			gp.goal_id = ? AND ip.user_id = ? AND ip.is_already_done = FALSE AND ip.deleted_at IS NULL
+		ORDER BY ip.pay_in ASC`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, goalId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	projectionList := []Projection{}
+	for rows.Next() {
+		var projection Projection
+		err := rows.Scan(&projection.Id, &projection.Version)
+		if err != nil {
+			return nil, err
+		}
+		projectionList = append(projectionList, projection)
+	}
+	return &projectionList, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const deleteProjectionsQuery = `
+		DELETE FROM goal_projection WHERE goal_id IN (SELECT id FROM goal WHERE id = ? AND user_id = ?)`
+
+func TestDeleteSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(deleteProjectionsQuery).
+		ExpectExec().
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	sqlMock.ExpectPrepare(`DELETE FROM goal WHERE id = ? AND user_id = ?`).
+		ExpectExec().
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.Delete(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1")
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDeleteFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(deleteProjectionsQuery).
+		ExpectExec().
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	sqlMock.ExpectPrepare(`DELETE FROM goal WHERE id = ? AND user_id = ?`).
+		ExpectExec().
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.Delete(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDeleteProjectionsFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(deleteProjectionsQuery).
+		ExpectExec().
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.Delete(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
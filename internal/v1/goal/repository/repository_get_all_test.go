@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getAllQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			description,
+			target_value,
+			currency,
+			start_at,
+			deadline,
+			category_id,
+			label
+		FROM
+			goal
+		WHERE
+			user_id = ?
+		ORDER BY deadline ASC, created_at ASC`
+
+func TestGetAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(goalColumns).
+		AddRow("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", time.Now().Unix(), "User1", "Reserva de emergência", "12000.00", "BRL",
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 11, "").
+		AddRow("7e1f3a5b-92c4-4d6e-8f0a-1b2c3d4e5f60", time.Now().Unix(), "User1", "Viagem", "3000.00", "USD",
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), nil, "viagem")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	goalList, err := _repository.GetAll(context.Background(), "User1")
+	assert.NoError(t, err)
+	assert.Len(t, *goalList, 2)
+	assert.Equal(t, "viagem", (*goalList)[1].Label)
+	assert.Nil(t, (*goalList)[1].CategoryId)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(goalColumns).
+		AddRow("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", time.Now().Unix(), "User1", "Reserva de emergência", "12000.00", "BRL",
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), "eleven", "")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetAll(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAll(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
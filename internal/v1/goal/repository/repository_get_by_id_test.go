@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var goalColumns = []string{"id", "created_at", "user_id", "description", "target_value", "currency", "start_at", "deadline",
+	"category_id", "label"}
+
+const getByIdQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			description,
+			target_value,
+			currency,
+			start_at,
+			deadline,
+			category_id,
+			label
+		FROM
+			goal
+		WHERE
+			id = ? AND user_id = ?`
+
+func TestGetByIdSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	startAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deadline := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows(goalColumns).
+		AddRow("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", time.Now().Unix(), "User1", "Reserva de emergência", "12000.00", "BRL", startAt, deadline, 11, "reserva")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnRows(rowsMock)
+
+	goal, err := _repository.GetById(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromCents(1200000), goal.TargetValue)
+	assert.Equal(t, startAt, goal.StartAt)
+	assert.Equal(t, deadline, goal.Deadline)
+	assert.Equal(t, uint(11), *goal.CategoryId)
+	assert.Equal(t, "reserva", goal.Label)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnRows(sqlMock.NewRows(goalColumns))
+
+	goal, err := _repository.GetById(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, goal)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(goalColumns).
+		AddRow("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", time.Now().Unix(), "User1", "Reserva de emergência", "12000.00", "BRL", "first day", nil, nil, "")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetById(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetById(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
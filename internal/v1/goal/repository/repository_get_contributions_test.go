@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getContributionsQuery = `
+		SELECT
+			i.currency,
+			i.pay_at,
+			SUM(i.value)
+		FROM
+			invoice i
+		WHERE
+			i.user_id = ? AND i.deleted_at IS NULL AND i.pay_at BETWEEN ? AND ?
+			AND (? IS NULL OR i.category_id = ?)
+			AND (? = '' OR EXISTS (
+				SELECT 1
+				FROM record_label rl
+				INNER JOIN label l ON l.id = rl.label_id
+				WHERE rl.entity = 'invoice' AND rl.entity_id = i.id AND l.user_id = i.user_id AND l.label = ?))
+		GROUP BY i.currency, i.pay_at
+		ORDER BY i.pay_at ASC`
+
+func TestGetContributionsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	goalMock := buildGoalMock()
+	rowsMock := sqlMock.NewRows([]string{"currency", "pay_at", "value"}).
+		AddRow("BRL", time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), "1000.00").
+		AddRow("USD", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), "100.50")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContributionsQuery).
+		WithArgs("User1", goalMock.StartAt, goalMock.Deadline, int64(11), int64(11), "reserva", "reserva").
+		WillReturnRows(rowsMock)
+
+	contributionList, err := _repository.GetContributions(context.Background(), *goalMock)
+	assert.NoError(t, err)
+	assert.Equal(t, &[]Contribution{
+		{Currency: "BRL", PayAt: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), Value: money.FromCents(100000)},
+		{Currency: "USD", PayAt: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), Value: money.FromCents(10050)},
+	}, contributionList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetContributionsWithoutCategory(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	goalMock := buildGoalMock()
+	goalMock.CategoryId = nil
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContributionsQuery).
+		WithArgs("User1", goalMock.StartAt, goalMock.Deadline, nil, nil, "reserva", "reserva").
+		WillReturnRows(sqlMock.NewRows([]string{"currency", "pay_at", "value"}))
+
+	contributionList, err := _repository.GetContributions(context.Background(), *goalMock)
+	assert.NoError(t, err)
+	assert.Empty(t, *contributionList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetContributionsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	goalMock := buildGoalMock()
+	rowsMock := sqlMock.NewRows([]string{"currency", "pay_at", "value"}).
+		AddRow("BRL", time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), "one thousand")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContributionsQuery).
+		WithArgs("User1", goalMock.StartAt, goalMock.Deadline, int64(11), int64(11), "reserva", "reserva").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetContributions(context.Background(), *goalMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetContributionsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	goalMock := buildGoalMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContributionsQuery).
+		WithArgs("User1", goalMock.StartAt, goalMock.Deadline, int64(11), int64(11), "reserva", "reserva").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetContributions(context.Background(), *goalMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getPendingProjectionsQuery = `
+		SELECT
+			ip.id,
+			ip.version
+		FROM
+			goal_projection gp
+		INNER JOIN invoice_projection ip ON
+			ip.id = gp.invoice_projection_id
+		WHERE
+			gp.goal_id = ? AND ip.user_id = ? AND ip.is_already_done = FALSE AND ip.deleted_at IS NULL
+		ORDER BY ip.pay_in ASC`
+
+func TestGetPendingProjectionsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "version"}).
+		AddRow("cd1cc27b-28a1-47dc-ac76-70e8185e159d", 1).
+		AddRow("53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a", 2)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getPendingProjectionsQuery).
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnRows(rowsMock)
+
+	projectionList, err := _repository.GetPendingProjections(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Projection{
+		{Id: "cd1cc27b-28a1-47dc-ac76-70e8185e159d", Version: 1},
+		{Id: "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a", Version: 2},
+	}, *projectionList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetPendingProjectionsFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getPendingProjectionsQuery).
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetPendingProjections(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
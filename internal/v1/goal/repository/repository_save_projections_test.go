@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveProjectionsQuery = `INSERT INTO goal_projection (goal_id, invoice_projection_id) VALUES (?, ?)`
+
+func TestSaveProjectionsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	prepare := sqlMock.ExpectPrepare(saveProjectionsQuery)
+	prepare.ExpectExec().
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	prepare.ExpectExec().
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.SaveProjections(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01",
+		[]string{"cd1cc27b-28a1-47dc-ac76-70e8185e159d", "53f8b0d2-5d0b-4b7c-9d1e-0c3c1b8d2f6a"})
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveProjectionsFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveProjectionsQuery).
+		ExpectExec().
+		WithArgs("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", "cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.SaveProjections(context.Background(), "2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01", []string{"cd1cc27b-28a1-47dc-ac76-70e8185e159d"})
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
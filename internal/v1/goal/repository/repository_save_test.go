@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveQuery = `
+		INSERT INTO goal (id, created_at, user_id, description, target_value, currency, start_at, deadline, category_id, label)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func buildGoalMock() *Goal {
+	categoryId := uint(11)
+	return NewGoalBuilder().
+		AddId("2c9d4e6f-81a3-4b7c-9e0d-5f6a7b8c9d01").
+		AddCreatedAt(time.Now()).
+		AddUserId("User1").
+		AddDescription("Reserva de emergência").
+		AddTargetValue(money.FromCents(1200000)).
+		AddCurrency("BRL").
+		AddStartAt(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+		AddDeadline(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)).
+		AddCategoryId(&categoryId).
+		AddLabel("reserva").
+		Build()
+}
+
+func TestSaveSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	goalMock := buildGoalMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveQuery).
+		ExpectExec().
+		WithArgs(
+			goalMock.Id,
+			goalMock.CreatedAt.Unix(),
+			goalMock.UserId,
+			goalMock.Description,
+			"12000.00",
+			goalMock.Currency,
+			goalMock.StartAt,
+			goalMock.Deadline,
+			int64(11),
+			goalMock.Label).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	goalSaved, err := _repository.Save(context.Background(), *goalMock)
+	assert.NoError(t, err)
+	assert.Equal(t, goalMock.Id, goalSaved.Id)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveQuery).
+		ExpectExec().
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	goalSaved, err := _repository.Save(context.Background(), *buildGoalMock())
+	assert.Error(t, err)
+	assert.Empty(t, goalSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	goalSaved, err := _repository.Save(context.Background(), *buildGoalMock())
+	assert.Error(t, err)
+	assert.Empty(t, goalSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
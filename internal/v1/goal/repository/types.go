@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+// Goal is a target amount to be saved until the deadline, the contributions are the invoices
+// paid from the start date that match its category and its label
+type Goal struct {
+	Id          string
+	CreatedAt   time.Time
+	UserId      string
+	Description string
+	TargetValue money.Money
+	Currency    string
+	StartAt     time.Time
+	Deadline    time.Time
+	CategoryId  *uint
+	Label       string
+}
+
+// Contribution is the amount paid on a day in a currency towards a goal
+type Contribution struct {
+	Currency string
+	PayAt    time.Time
+	Value    money.Money
+}
+
+// Projection is a pending invoice projection generated for the monthly contributions of a goal
+type Projection struct {
+	Id      string
+	Version uint
+}
@@ -0,0 +1,280 @@
+package investment
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment/ivservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	CreateAsset(c *gin.Context)
+	GetAll(c *gin.Context)
+	GetById(c *gin.Context)
+	UpdatePrice(c *gin.Context)
+	CreateOperation(c *gin.Context)
+	LinkDividend(c *gin.Context)
+	UnlinkDividend(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	storageProcess ivservice.StorageProcess
+	readingProcess ivservice.ReadingProcess
+}
+
+func NewHandler(storageProcess ivservice.StorageProcess, readingProcess ivservice.ReadingProcess) Handler {
+	return &handler{storageProcess: storageProcess, readingProcess: readingProcess}
+}
+
+// CreateAsset godoc
+// @Summary Criar um ativo da carteira de investimentos
+// @Description Este endpoint permite criar um ativo com o ticker, o nome, o tipo (stock, reit, etf, fixed_income, fund, crypto, other) e a moeda em que é negociado. O ticker é único na carteira do usuário
+// @Tags Investment
+// @Accept json
+// @Produce json
+// @Param asset body ivservice.CreateAssetRequest true "Modelo de criação do ativo"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} ivservice.PositionResponse
+// @Failure 409 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/asset [post]
+func (h *handler) CreateAsset(c *gin.Context) {
+	var request ivservice.CreateAssetRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Investment::StorageProcess::CreateAsset", "Create new asset", nil)
+	createAssetCtx := ivservice.CreateAssetContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	assetCreated, err := h.storageProcess.CreateAsset(createAssetCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, assetCreated)
+}
+
+// @Summary Obter a carteira de investimentos
+// @Description Este endpoint permite obter as posições dos ativos, em ordem alfabética de ticker, com a quantidade, o preço médio, o custo, os resultados realizado e não realizado e os dividendos de cada uma
+// @Tags Investment
+// @Accept json
+// @Produce json
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ivservice.PortfolioResponse
+// @Router /v1/asset [get]
+func (h *handler) GetAll(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Investment::ReadingProcess::GetAll", "Get all asset positions", nil)
+	searchCtx := ivservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+	}
+	portfolio, err := h.readingProcess.GetAll(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, portfolio)
+}
+
+// @Summary Obter a posição de um ativo
+// @Description Este endpoint permite obter a posição de um ativo pelo preço médio, com as operações e as receitas de dividendos vinculadas.
+// @Description O resultado não realizado compara o valor de mercado, pelo último preço informado, com o custo da posição e só é informado quando o ativo tem preço
+// @Tags Investment
+// @Accept json
+// @Produce json
+// @Param id path string true "Id do ativo"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ivservice.PositionResponse
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/asset/{id} [get]
+func (h *handler) GetById(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Investment::ReadingProcess::GetById", "Get an asset position by id", nil)
+	searchCtx := ivservice.SearchContext{
+		Ctx:       ctx,
+		Id:        c.Param("id"),
+		UserToken: userToken,
+	}
+	position, err := h.readingProcess.GetById(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, position)
+}
+
+// @Summary Atualizar o preço de um ativo
+// @Description Este endpoint permite informar manualmente o preço unitário do ativo, na moeda do ativo, usado para calcular o valor de mercado da posição. Quando a data não é informada, é usada a data atual
+// @Tags Investment
+// @Accept json
+// @Produce json
+// @Param id path string true "Id do ativo"
+// @Param price body ivservice.UpdatePriceRequest true "Modelo de atualização do preço"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ivservice.PositionResponse
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/asset/{id}/price [put]
+func (h *handler) UpdatePrice(c *gin.Context) {
+	var request ivservice.UpdatePriceRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Investment::StorageProcess::UpdatePrice", "Update the price of an asset", nil)
+	updatePriceCtx := ivservice.UpdatePriceContext{
+		Ctx:       ctx,
+		Id:        c.Param("id"),
+		Request:   request,
+		UserToken: userToken,
+	}
+	position, err := h.storageProcess.UpdatePrice(updatePriceCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, position)
+}
+
+// @Summary Registrar uma operação de um ativo
+// @Description Este endpoint permite registrar uma compra ou uma venda do ativo, com a quantidade, o preço unitário e as taxas na moeda do ativo.
+// @Description A compra soma o valor e as taxas ao custo da posição. A venda retira o custo médio das unidades vendidas e realiza a diferença para o valor recebido descontadas as taxas, e não pode exceder a quantidade em carteira na data
+// @Tags Investment
+// @Accept json
+// @Produce json
+// @Param id path string true "Id do ativo"
+// @Param operation body ivservice.CreateOperationRequest true "Modelo de criação da operação"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} ivservice.OperationResponse
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/asset/{id}/operation [post]
+func (h *handler) CreateOperation(c *gin.Context) {
+	var request ivservice.CreateOperationRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Investment::StorageProcess::CreateOperation", "Create new asset operation", nil)
+	createOperationCtx := ivservice.CreateOperationContext{
+		Ctx:       ctx,
+		AssetId:   c.Param("id"),
+		Request:   request,
+		UserToken: userToken,
+	}
+	operationCreated, err := h.storageProcess.CreateOperation(createOperationCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, operationCreated)
+}
+
+// @Summary Vincular uma receita de dividendos a um ativo
+// @Description Este endpoint permite vincular uma receita, como dividendos ou juros sobre capital próprio, ao ativo que a gerou. A receita vinculada a outro ativo passa a ser deste
+// @Tags Investment
+// @Accept json
+// @Produce json
+// @Param id path string true "Id do ativo"
+// @Param gain_id path string true "Id da receita"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ivservice.DividendResponse
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/asset/{id}/dividend/{gain_id} [put]
+func (h *handler) LinkDividend(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Investment::StorageProcess::LinkDividend", "Link a gain to an asset", nil)
+	dividendCtx := ivservice.DividendContext{
+		Ctx:       ctx,
+		AssetId:   c.Param("id"),
+		GainId:    c.Param("gain_id"),
+		UserToken: userToken,
+	}
+	dividend, err := h.storageProcess.LinkDividend(dividendCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, dividend)
+}
+
+// @Summary Desvincular uma receita de dividendos de um ativo
+// @Description Este endpoint permite desvincular uma receita do ativo. A receita é mantida
+// @Tags Investment
+// @Accept json
+// @Produce json
+// @Param id path string true "Id do ativo"
+// @Param gain_id path string true "Id da receita"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ResponseDefault{status=int,message=string}
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/asset/{id}/dividend/{gain_id} [delete]
+func (h *handler) UnlinkDividend(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Investment::StorageProcess::UnlinkDividend", "Unlink a gain from an asset", nil)
+	dividendCtx := ivservice.DividendContext{
+		Ctx:       ctx,
+		AssetId:   c.Param("id"),
+		GainId:    c.Param("gain_id"),
+		UserToken: userToken,
+	}
+	err := h.storageProcess.UnlinkDividend(dividendCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Dividend unlinked"})
+}
@@ -0,0 +1,415 @@
+package investment
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment/ivservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type storageProcessMock struct {
+	err              error
+	position         *ivservice.PositionResponse
+	operation        *ivservice.OperationResponse
+	dividend         *ivservice.DividendResponse
+	assetRequest     ivservice.CreateAssetRequest
+	priceRequest     ivservice.UpdatePriceRequest
+	operationRequest ivservice.CreateOperationRequest
+	id               string
+	gainId           string
+}
+
+func (sp *storageProcessMock) CreateAsset(createAssetCtx ivservice.CreateAssetContext) (*ivservice.PositionResponse, error) {
+	sp.assetRequest = createAssetCtx.Request
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.position, nil
+}
+
+func (sp *storageProcessMock) UpdatePrice(updatePriceCtx ivservice.UpdatePriceContext) (*ivservice.PositionResponse, error) {
+	sp.id = updatePriceCtx.Id
+	sp.priceRequest = updatePriceCtx.Request
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.position, nil
+}
+
+func (sp *storageProcessMock) CreateOperation(createOperationCtx ivservice.CreateOperationContext) (*ivservice.OperationResponse, error) {
+	sp.id = createOperationCtx.AssetId
+	sp.operationRequest = createOperationCtx.Request
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.operation, nil
+}
+
+func (sp *storageProcessMock) LinkDividend(dividendCtx ivservice.DividendContext) (*ivservice.DividendResponse, error) {
+	sp.id = dividendCtx.AssetId
+	sp.gainId = dividendCtx.GainId
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.dividend, nil
+}
+
+func (sp *storageProcessMock) UnlinkDividend(dividendCtx ivservice.DividendContext) error {
+	sp.id = dividendCtx.AssetId
+	sp.gainId = dividendCtx.GainId
+	return sp.err
+}
+
+type readingProcessMock struct {
+	err       error
+	position  *ivservice.PositionResponse
+	portfolio *ivservice.PortfolioResponse
+	id        string
+}
+
+func (rp *readingProcessMock) GetById(searchCtx ivservice.SearchContext) (*ivservice.PositionResponse, error) {
+	rp.id = searchCtx.Id
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.position, nil
+}
+
+func (rp *readingProcessMock) GetAll(searchCtx ivservice.SearchContext) (*ivservice.PortfolioResponse, error) {
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.portfolio, nil
+}
+
+func buildPositionResponseMock() *ivservice.PositionResponse {
+	price := money.FromCents(3825)
+	priceAt := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	marketValue := money.FromCents(344250)
+	unrealizedResult := money.FromCents(58830)
+	return &ivservice.PositionResponse{
+		Id:               "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		Ticker:           "PETR4",
+		Name:             "Petrobras PN",
+		AssetType:        "stock",
+		Currency:         "BRL",
+		Quantity:         money.Quantity(9000000000),
+		AveragePrice:     money.FromCents(3171),
+		Cost:             money.FromCents(285420),
+		Price:            &price,
+		PriceAt:          &priceAt,
+		MarketValue:      &marketValue,
+		UnrealizedResult: &unrealizedResult,
+		RealizedResult:   money.FromCents(25420),
+		Dividends:        money.FromCents(16530),
+		TotalResult:      money.FromCents(100780),
+		CreatedAt:        time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+const positionResponseBody = `{"id":"6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e","ticker":"PETR4","name":"Petrobras PN","asset_type":"stock","currency":"BRL","quantity":90,"average_price":31.71,"cost":2854.2,"price":38.25,"price_at":"2024-03-15T00:00:00Z","market_value":3442.5,"unrealized_result":588.3,"realized_result":254.2,"dividends":165.3,"total_result":1007.8,"created_at":"2024-02-01T10:00:00Z"}`
+
+func TestCreateAssetSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{position: &ivservice.PositionResponse{
+		Id:        "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		Ticker:    "PETR4",
+		Name:      "Petrobras PN",
+		AssetType: "stock",
+		Currency:  "BRL",
+		CreatedAt: time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC),
+	}}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/asset", handler.CreateAsset)
+
+	body := []byte(`{"ticker": "PETR4", "name": "Petrobras PN", "asset_type": "stock"}`)
+	req, _ := http.NewRequest("POST", "/v1/asset", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"id":"6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e","ticker":"PETR4","name":"Petrobras PN","asset_type":"stock","currency":"BRL","quantity":0,"average_price":0,"cost":0,"realized_result":0,"dividends":0,"total_result":0,"created_at":"2024-02-01T10:00:00Z"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, ivservice.CreateAssetRequest{Ticker: "PETR4", Name: "Petrobras PN", AssetType: "stock"}, _storageProcessMock.assetRequest)
+}
+
+func TestCreateAssetInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/asset", handler.CreateAsset)
+
+	body := []byte(`{"ticker": " ", "name": "Petrobras PN", "asset_type": "stocks"}`)
+	req, _ := http.NewRequest("POST", "/v1/asset", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"ticker","code":"required","message":"The ticker must be informed"},{"field":"asset_type","code":"invalid","message":"The asset_type is not valid"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestCreateAssetConflict(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{err: apperror.Conflict("The asset PETR4 is already registered")}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/asset", handler.CreateAsset)
+
+	body := []byte(`{"ticker": "PETR4", "name": "Petrobras PN", "asset_type": "stock"}`)
+	req, _ := http.NewRequest("POST", "/v1/asset", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Conflict","status":409,"detail":"The asset PETR4 is already registered"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestGetAllSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{
+		portfolio: &ivservice.PortfolioResponse{Records: []ivservice.PositionResponse{*buildPositionResponseMock()}},
+	}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/asset", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/asset", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, `{"records":[`+positionResponseBody+`]}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetAllFail(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/asset", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/asset", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetByIdSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{position: buildPositionResponseMock()}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/asset/:id", handler.GetById)
+
+	req, _ := http.NewRequest("GET", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, positionResponseBody, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", _readingProcessMock.id)
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: apperror.NotFound("Asset not found")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/asset/:id", handler.GetById)
+
+	req, _ := http.NewRequest("GET", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Asset not found"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdatePriceSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{position: buildPositionResponseMock()}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.PUT("/asset/:id/price", handler.UpdatePrice)
+
+	body := []byte(`{"price": 38.25, "price_at": "2024-03-15T00:00:00Z"}`)
+	req, _ := http.NewRequest("PUT", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e/price", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, positionResponseBody, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", _storageProcessMock.id)
+	assert.Equal(t, money.FromCents(3825), _storageProcessMock.priceRequest.Price)
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), *_storageProcessMock.priceRequest.PriceAt)
+}
+
+func TestUpdatePriceInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.PUT("/asset/:id/price", handler.UpdatePrice)
+
+	body := []byte(`{"price": -1}`)
+	req, _ := http.NewRequest("PUT", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e/price", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestCreateOperationSuccess(t *testing.T) {
+	realizedResult := money.FromCents(24860)
+	_storageProcessMock := &storageProcessMock{operation: &ivservice.OperationResponse{
+		Id:             "8d3f1a4c-5e6b-4c7d-8f9a-1b2c3d4e5f60",
+		OperationType:  ivservice.OPERATION_SELL,
+		OperatedAt:     time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		Quantity:       money.Quantity(3000000000),
+		Price:          money.FromCents(4000),
+		Value:          money.FromCents(120000),
+		RealizedResult: &realizedResult,
+	}}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/asset/:id/operation", handler.CreateOperation)
+
+	body := []byte(`{"operation_type": "sell", "operated_at": "2024-03-10T00:00:00Z", "quantity": 30, "price": 40}`)
+	req, _ := http.NewRequest("POST", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e/operation", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"id":"8d3f1a4c-5e6b-4c7d-8f9a-1b2c3d4e5f60","operation_type":"sell","operated_at":"2024-03-10T00:00:00Z","quantity":30,"price":40,"fees":0,"value":1200,"realized_result":248.6}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", _storageProcessMock.id)
+	assert.Equal(t, money.Quantity(3000000000), _storageProcessMock.operationRequest.Quantity)
+}
+
+func TestCreateOperationInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/asset/:id/operation", handler.CreateOperation)
+
+	body := []byte(`{"operation_type": "swap", "operated_at": "2024-03-10T00:00:00Z", "quantity": 0, "price": 40}`)
+	req, _ := http.NewRequest("POST", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e/operation", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"operation_type","code":"invalid","message":"The operation_type is not valid"},{"field":"quantity","code":"too_small","message":"The quantity must be greater than 0"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestLinkDividendSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{dividend: &ivservice.DividendResponse{
+		GainId:      "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+		PayIn:       time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC),
+		Description: "Dividendos PETR4",
+		Value:       money.FromCents(14530),
+		Currency:    "BRL",
+	}}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.PUT("/asset/:id/dividend/:gain_id", handler.LinkDividend)
+
+	req, _ := http.NewRequest("PUT", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e/dividend/1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"gain_id":"1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d","pay_in":"2024-02-20T00:00:00Z","description":"Dividendos PETR4","value":145.3,"currency":"BRL"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", _storageProcessMock.id)
+	assert.Equal(t, "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", _storageProcessMock.gainId)
+}
+
+func TestLinkDividendGainNotFound(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{err: apperror.NotFound("Gain not found")}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.PUT("/asset/:id/dividend/:gain_id", handler.LinkDividend)
+
+	req, _ := http.NewRequest("PUT", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e/dividend/1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Gain not found"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUnlinkDividendSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.DELETE("/asset/:id/dividend/:gain_id", handler.UnlinkDividend)
+
+	req, _ := http.NewRequest("DELETE", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e/dividend/1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, `{"message":"Dividend unlinked","status":200}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", _storageProcessMock.gainId)
+}
+
+func TestUnlinkDividendNotFound(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{err: apperror.NotFound("Dividend not found")}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.DELETE("/asset/:id/dividend/:gain_id", handler.UnlinkDividend)
+
+	req, _ := http.NewRequest("DELETE", "/v1/asset/6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e/dividend/1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,121 @@
+package ivservice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment/repository"
+)
+
+var errSellExceedsPosition = errors.New("the quantity sold exceeds the position")
+
+// positionCalculator replays the operations of an asset and sums its dividends in the currency of the asset
+type positionCalculator struct {
+	repository repository.Repository
+	converter  erservice.Converter
+}
+
+// buildResponse returns the position of the asset, with the operations and the dividend gains when detailed
+func (pc *positionCalculator) buildResponse(ctx context.Context, asset repository.Asset, detailed bool) (*PositionResponse, error) {
+	operations, err := pc.repository.GetOperations(ctx, asset.Id)
+	if err != nil {
+		return nil, err
+	}
+	dividends, err := pc.repository.GetDividends(ctx, asset.Id)
+	if err != nil {
+		return nil, err
+	}
+	response := newPositionResponse(asset)
+	operationResponses, err := replayOperations(*operations, response)
+	if err != nil {
+		return nil, err
+	}
+	dividendResponses := []DividendResponse{}
+	for _, dividend := range *dividends {
+		value, err := pc.converter.Convert(erservice.ConvertContext{
+			Ctx:    ctx,
+			UserId: asset.UserId,
+			Amount: dividend.Value,
+			From:   dividend.Currency,
+			To:     asset.Currency,
+			Date:   dividend.PayIn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.Dividends = response.Dividends.Add(value)
+		dividendResponses = append(dividendResponses, newDividendResponse(dividend))
+	}
+	response.TotalResult = response.RealizedResult.Add(response.Dividends)
+	if asset.Price != nil {
+		marketValue := asset.Price.Times(response.Quantity)
+		unrealizedResult := marketValue.Sub(response.Cost)
+		response.MarketValue = &marketValue
+		response.UnrealizedResult = &unrealizedResult
+		response.TotalResult = response.TotalResult.Add(unrealizedResult)
+	}
+	if detailed {
+		response.Operations = operationResponses
+		response.DividendGains = dividendResponses
+	}
+	return response, nil
+}
+
+// replayOperations applies the operations to the position in the order they were made. A buy adds its value and fees
+// to the cost, a sell takes out the average cost of the units sold and realizes the difference to the value received
+// net of the fees. It fails when a sell exceeds the units held at that moment
+func replayOperations(operations []repository.Operation, position *PositionResponse) ([]OperationResponse, error) {
+	operationResponses := []OperationResponse{}
+	for _, operation := range operations {
+		operationResponse := OperationResponse{
+			Id:            operation.Id,
+			OperationType: operation.OperationType,
+			OperatedAt:    operation.OperatedAt,
+			Quantity:      operation.Quantity,
+			Price:         operation.Price,
+			Fees:          operation.Fees,
+			Value:         operation.Price.Times(operation.Quantity),
+		}
+		if operation.OperationType == OPERATION_SELL {
+			if operation.Quantity > position.Quantity {
+				return nil, errSellExceedsPosition
+			}
+			costSold := position.Cost.Prorate(operation.Quantity, position.Quantity)
+			realizedResult := operationResponse.Value.Sub(operation.Fees).Sub(costSold)
+			operationResponse.RealizedResult = &realizedResult
+			position.Quantity -= operation.Quantity
+			position.Cost = position.Cost.Sub(costSold)
+			position.RealizedResult = position.RealizedResult.Add(realizedResult)
+		} else {
+			position.Quantity += operation.Quantity
+			position.Cost = position.Cost.Add(operationResponse.Value).Add(operation.Fees)
+		}
+		operationResponses = append(operationResponses, operationResponse)
+	}
+	position.AveragePrice = position.Cost.Per(position.Quantity)
+	return operationResponses, nil
+}
+
+func newPositionResponse(asset repository.Asset) *PositionResponse {
+	return &PositionResponse{
+		Id:        asset.Id,
+		Ticker:    asset.Ticker,
+		Name:      asset.Name,
+		AssetType: asset.AssetType,
+		Currency:  asset.Currency,
+		Price:     asset.Price,
+		PriceAt:   asset.PriceAt,
+		CreatedAt: asset.CreatedAt,
+	}
+}
+
+func newDividendResponse(dividend repository.Dividend) DividendResponse {
+	return DividendResponse{
+		GainId:      dividend.GainId,
+		PayIn:       dividend.PayIn,
+		Description: dividend.Description,
+		Value:       dividend.Value,
+		Currency:    dividend.Currency,
+	}
+}
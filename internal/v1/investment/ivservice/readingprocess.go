@@ -0,0 +1,56 @@
+package ivservice
+
+import (
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment/repository"
+)
+
+type ReadingProcess interface {
+	GetById(searchCtx SearchContext) (*PositionResponse, error)
+	GetAll(searchCtx SearchContext) (*PortfolioResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+	position   *positionCalculator
+}
+
+func NewReadingProcess(repository repository.Repository, converter erservice.Converter) ReadingProcess {
+	return &readingProcess{
+		repository: repository,
+		position:   &positionCalculator{repository: repository, converter: converter},
+	}
+}
+
+// GetById returns the position of the asset with its operations and dividend gains
+func (rp *readingProcess) GetById(searchCtx SearchContext) (*PositionResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	asset, err := rp.repository.GetAssetById(searchCtx.Ctx, searchCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, apperror.NotFound("Asset not found")
+	}
+	return rp.position.buildResponse(searchCtx.Ctx, *asset, true)
+}
+
+// GetAll returns the positions of the portfolio, leaving out the operations and the dividend gains
+func (rp *readingProcess) GetAll(searchCtx SearchContext) (*PortfolioResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	assets, err := rp.repository.GetAllAssets(searchCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	records := []PositionResponse{}
+	for _, asset := range *assets {
+		position, err := rp.position.buildResponse(searchCtx.Ctx, asset, false)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *position)
+	}
+	return &PortfolioResponse{Records: records}, nil
+}
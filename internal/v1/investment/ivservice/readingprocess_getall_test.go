@@ -0,0 +1,40 @@
+package ivservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllSuccess(t *testing.T) {
+	_readingProcess := NewReadingProcess(buildRepositoryMock(), &mockConverter{})
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Len(t, response.Records, 2)
+	assert.Equal(t, "PETR4", response.Records[0].Ticker)
+	assert.Equal(t, money.FromCents(100780), response.Records[0].TotalResult)
+	assert.Nil(t, response.Records[0].Operations)
+	assert.Nil(t, response.Records[0].DividendGains)
+	assert.Equal(t, "VOO", response.Records[1].Ticker)
+	assert.Equal(t, money.FromCents(112500), response.Records[1].Cost)
+}
+
+func TestGetAllEmpty(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{}, &mockConverter{})
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, &PortfolioResponse{Records: []PositionResponse{}}, response)
+}
+
+func TestGetAllFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
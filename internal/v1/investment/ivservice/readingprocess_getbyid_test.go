@@ -0,0 +1,174 @@
+package ivservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildAssetsMock() []repository.Asset {
+	price := money.FromCents(3825)
+	priceAt := date(2024, 3, 15)
+	return []repository.Asset{
+		{
+			Id:        "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+			CreatedAt: date(2024, 2, 1),
+			UserId:    "5832a502-bede-492d-8dc1-b13b32c30f29",
+			Ticker:    "PETR4",
+			Name:      "Petrobras PN",
+			AssetType: "stock",
+			Currency:  "BRL",
+			Price:     &price,
+			PriceAt:   &priceAt,
+		},
+		{
+			Id:        "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f",
+			CreatedAt: date(2024, 2, 1),
+			UserId:    "5832a502-bede-492d-8dc1-b13b32c30f29",
+			Ticker:    "VOO",
+			Name:      "Vanguard S&P 500",
+			AssetType: "etf",
+			Currency:  "USD",
+		},
+	}
+}
+
+func buildOperationsMock() map[string][]repository.Operation {
+	return map[string][]repository.Operation{
+		"6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e": {
+			{
+				Id:            "8d3f1a4c-5e6b-4c7d-8f9a-1b2c3d4e5f60",
+				AssetId:       "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+				OperationType: OPERATION_BUY,
+				OperatedAt:    date(2024, 2, 1),
+				Quantity:      money.Quantity(10000000000),
+				Price:         money.FromCents(3250),
+				Fees:          money.FromCents(490),
+			},
+			{
+				Id:            "9e4a2b5d-6f7c-4d8e-9a0b-2c3d4e5f6a71",
+				AssetId:       "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+				OperationType: OPERATION_BUY,
+				OperatedAt:    date(2024, 2, 15),
+				Quantity:      money.Quantity(5000000000),
+				Price:         money.FromCents(3000),
+				Fees:          money.FromCents(210),
+			},
+			{
+				Id:            "0f5b3c6e-7a8d-4e9f-8b1c-3d4e5f6a7b82",
+				AssetId:       "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+				OperationType: OPERATION_SELL,
+				OperatedAt:    date(2024, 3, 1),
+				Quantity:      money.Quantity(6000000000),
+				Price:         money.FromCents(3600),
+				Fees:          money.FromCents(300),
+			},
+		},
+		"7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f": {
+			{
+				Id:            "1a6c4d7f-8b9e-4f0a-9c2d-4e5f6a7b8c93",
+				AssetId:       "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f",
+				OperationType: OPERATION_BUY,
+				OperatedAt:    date(2024, 2, 5),
+				Quantity:      money.Quantity(250000000),
+				Price:         money.FromCents(45000),
+			},
+		},
+	}
+}
+
+func buildDividendsMock() map[string][]repository.Dividend {
+	return map[string][]repository.Dividend{
+		"6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e": {
+			{
+				GainId:      "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+				AssetId:     "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+				PayIn:       date(2024, 2, 20),
+				Description: "Dividendos PETR4",
+				Value:       money.FromCents(14530),
+				Currency:    "BRL",
+			},
+			{
+				GainId:      "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+				AssetId:     "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+				PayIn:       date(2024, 3, 10),
+				Description: "Dividendos PETR4 ADR",
+				Value:       money.FromCents(1000),
+				Currency:    "USD",
+			},
+		},
+	}
+}
+
+func buildRepositoryMock() *mockRepository {
+	return &mockRepository{assets: buildAssetsMock(), operations: buildOperationsMock(), dividends: buildDividendsMock()}
+}
+
+func TestGetByIdSuccess(t *testing.T) {
+	_readingProcess := NewReadingProcess(buildRepositoryMock(), &mockConverter{})
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, money.Quantity(9000000000), response.Quantity)
+	assert.Equal(t, money.FromCents(285420), response.Cost)
+	assert.Equal(t, money.FromCents(3171), response.AveragePrice)
+	assert.Equal(t, money.FromCents(344250), *response.MarketValue)
+	assert.Equal(t, money.FromCents(58830), *response.UnrealizedResult)
+	assert.Equal(t, money.FromCents(25420), response.RealizedResult)
+	// the dividend in USD is converted to the BRL of the asset
+	assert.Equal(t, money.FromCents(16530), response.Dividends)
+	assert.Equal(t, money.FromCents(100780), response.TotalResult)
+
+	assert.Len(t, response.Operations, 3)
+	assert.Equal(t, money.FromCents(325000), response.Operations[0].Value)
+	assert.Nil(t, response.Operations[0].RealizedResult)
+	assert.Equal(t, money.FromCents(216000), response.Operations[2].Value)
+	assert.Equal(t, money.FromCents(25420), *response.Operations[2].RealizedResult)
+	assert.Equal(t, []DividendResponse{
+		{GainId: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", PayIn: date(2024, 2, 20), Description: "Dividendos PETR4", Value: money.FromCents(14530), Currency: "BRL"},
+		{GainId: "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e", PayIn: date(2024, 3, 10), Description: "Dividendos PETR4 ADR", Value: money.FromCents(1000), Currency: "USD"},
+	}, response.DividendGains)
+}
+
+func TestGetByIdWithoutPrice(t *testing.T) {
+	_readingProcess := NewReadingProcess(buildRepositoryMock(), &mockConverter{})
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f", UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, money.Quantity(250000000), response.Quantity)
+	assert.Equal(t, money.FromCents(112500), response.Cost)
+	assert.Equal(t, money.FromCents(45000), response.AveragePrice)
+	assert.Nil(t, response.MarketValue)
+	assert.Nil(t, response.UnrealizedResult)
+	assert.Equal(t, money.FromCents(0), response.TotalResult)
+	assert.Equal(t, []DividendResponse{}, response.DividendGains)
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	_readingProcess := NewReadingProcess(buildRepositoryMock(), &mockConverter{})
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d", UserToken: tokenMock})
+	assert.Equal(t, apperror.NotFound("Asset not found"), err)
+	assert.Nil(t, response)
+}
+
+func TestGetByIdConvertFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(buildRepositoryMock(), &mockConverter{err: errors.New("An error has been ocurred")})
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestGetByIdFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
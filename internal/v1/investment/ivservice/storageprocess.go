@@ -0,0 +1,214 @@
+package ivservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type StorageProcess interface {
+	CreateAsset(createAssetCtx CreateAssetContext) (*PositionResponse, error)
+	UpdatePrice(updatePriceCtx UpdatePriceContext) (*PositionResponse, error)
+	CreateOperation(createOperationCtx CreateOperationContext) (*OperationResponse, error)
+	LinkDividend(dividendCtx DividendContext) (*DividendResponse, error)
+	UnlinkDividend(dividendCtx DividendContext) error
+}
+
+type storageProcess struct {
+	repository   repository.Repository
+	generateUUID func() uuid.UUID
+	position     *positionCalculator
+	now          func() time.Time
+	transactor   dbtx.Transactor
+}
+
+func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID, converter erservice.Converter, now func() time.Time,
+	transactor dbtx.Transactor) StorageProcess {
+	return &storageProcess{
+		repository:   repository,
+		generateUUID: generateUUID,
+		position:     &positionCalculator{repository: repository, converter: converter},
+		now:          now,
+		transactor:   transactor,
+	}
+}
+
+// CreateAsset registers the asset in the portfolio, the ticker is unique for each user
+func (sp *storageProcess) CreateAsset(createAssetCtx CreateAssetContext) (*PositionResponse, error) {
+	request := createAssetCtx.Request
+	user := idpauth.GetUser(createAssetCtx.UserToken)
+	ticker := strings.ToUpper(strings.TrimSpace(request.Ticker))
+	assetFound, err := sp.repository.GetAssetByTicker(createAssetCtx.Ctx, ticker, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if assetFound != nil {
+		return nil, apperror.Conflict(fmt.Sprintf("The asset %s is already registered", ticker))
+	}
+	asset := repository.NewAssetBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(sp.now()).
+		AddUserId(user.Id).
+		AddTicker(ticker).
+		AddName(strings.TrimSpace(request.Name)).
+		AddAssetType(request.AssetType).
+		AddCurrency(money.NormalizeCurrency(request.Currency)).
+		Build()
+	assetSaved, err := sp.repository.SaveAsset(createAssetCtx.Ctx, *asset)
+	if err != nil {
+		return nil, err
+	}
+	return newPositionResponse(*assetSaved), nil
+}
+
+// UpdatePrice sets the price the position is valued at, replacing the previous one
+func (sp *storageProcess) UpdatePrice(updatePriceCtx UpdatePriceContext) (*PositionResponse, error) {
+	request := updatePriceCtx.Request
+	user := idpauth.GetUser(updatePriceCtx.UserToken)
+	asset, err := sp.repository.GetAssetById(updatePriceCtx.Ctx, updatePriceCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, apperror.NotFound("Asset not found")
+	}
+	priceAt := request.PriceAt
+	if priceAt == nil {
+		now := sp.now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		priceAt = &today
+	}
+	price := request.Price
+	asset.Price = &price
+	asset.PriceAt = priceAt
+	err = sp.repository.UpdatePrice(updatePriceCtx.Ctx, *asset)
+	if err != nil {
+		return nil, err
+	}
+	return sp.position.buildResponse(updatePriceCtx.Ctx, *asset, false)
+}
+
+// CreateOperation registers a buy or a sell of the asset. The operations are replayed with the new one in its place,
+// so a sell is refused when it exceeds the units held on its date or leaves a later sell without enough units.
+// The asset is locked while the operations are replayed, so concurrent sells can not both pass the check
+func (sp *storageProcess) CreateOperation(createOperationCtx CreateOperationContext) (*OperationResponse, error) {
+	request := createOperationCtx.Request
+	user := idpauth.GetUser(createOperationCtx.UserToken)
+	var operationResponse *OperationResponse
+	err := sp.transactor.Within(createOperationCtx.Ctx, func(ctx context.Context) error {
+		asset, err := sp.repository.GetAssetById(ctx, createOperationCtx.AssetId, user.Id)
+		if err != nil {
+			return err
+		}
+		if asset == nil {
+			return apperror.NotFound("Asset not found")
+		}
+		err = sp.repository.LockAsset(ctx, asset.Id)
+		if err != nil {
+			return err
+		}
+		operation := repository.NewOperationBuilder().
+			AddId(sp.generateUUID().String()).
+			AddCreatedAt(sp.now()).
+			AddAssetId(asset.Id).
+			AddOperationType(request.OperationType).
+			AddOperatedAt(request.OperatedAt).
+			AddQuantity(request.Quantity).
+			AddPrice(request.Price).
+			AddFees(request.Fees).
+			Build()
+		operations, err := sp.repository.GetOperations(ctx, asset.Id)
+		if err != nil {
+			return err
+		}
+		// the new operation goes after the ones operated until its date, the responses follow the same order
+		position := 0
+		for _, operationFound := range *operations {
+			if !operationFound.OperatedAt.After(operation.OperatedAt) {
+				position++
+			}
+		}
+		replayed := append(append([]repository.Operation{}, *operations...), *operation)
+		sort.SliceStable(replayed, func(i, j int) bool {
+			return replayed[i].OperatedAt.Before(replayed[j].OperatedAt)
+		})
+		operationResponses, err := replayOperations(replayed, newPositionResponse(*asset))
+		if errors.Is(err, errSellExceedsPosition) {
+			return validation.Errors{{
+				Field:   "quantity",
+				Code:    validation.CODE_INVALID,
+				Message: "The quantity must not exceed the position held on the operated_at",
+			}}
+		}
+		if err != nil {
+			return err
+		}
+		_, err = sp.repository.SaveOperation(ctx, *operation)
+		if err != nil {
+			return err
+		}
+		operationResponse = &operationResponses[position]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return operationResponse, nil
+}
+
+// LinkDividend links a gain of the user to the asset that produced it, moving it from the asset it was linked to
+func (sp *storageProcess) LinkDividend(dividendCtx DividendContext) (*DividendResponse, error) {
+	user := idpauth.GetUser(dividendCtx.UserToken)
+	asset, err := sp.repository.GetAssetById(dividendCtx.Ctx, dividendCtx.AssetId, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, apperror.NotFound("Asset not found")
+	}
+	gain, err := sp.repository.GetGain(dividendCtx.Ctx, dividendCtx.GainId, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if gain == nil {
+		return nil, apperror.NotFound("Gain not found")
+	}
+	err = sp.repository.SaveDividend(dividendCtx.Ctx, asset.Id, gain.GainId)
+	if err != nil {
+		return nil, err
+	}
+	dividendResponse := newDividendResponse(*gain)
+	return &dividendResponse, nil
+}
+
+// UnlinkDividend removes the link between the gain and the asset, the gain itself is kept
+func (sp *storageProcess) UnlinkDividend(dividendCtx DividendContext) error {
+	user := idpauth.GetUser(dividendCtx.UserToken)
+	asset, err := sp.repository.GetAssetById(dividendCtx.Ctx, dividendCtx.AssetId, user.Id)
+	if err != nil {
+		return err
+	}
+	if asset == nil {
+		return apperror.NotFound("Asset not found")
+	}
+	gain, err := sp.repository.GetGain(dividendCtx.Ctx, dividendCtx.GainId, user.Id)
+	if err != nil {
+		return err
+	}
+	if gain == nil || gain.AssetId != asset.Id {
+		return apperror.NotFound("Dividend not found")
+	}
+	return sp.repository.DeleteDividend(dividendCtx.Ctx, asset.Id, gain.GainId)
+}
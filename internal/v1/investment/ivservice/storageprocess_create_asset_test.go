@@ -0,0 +1,209 @@
+package ivservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment/repository"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	assets          []repository.Asset
+	operations      map[string][]repository.Operation
+	dividends       map[string][]repository.Dividend
+	gains           []repository.Dividend
+	assetSaved      *repository.Asset
+	priceUpdated    *repository.Asset
+	assetLocked     string
+	operationSaved  *repository.Operation
+	dividendSaved   []string
+	dividendDeleted []string
+	err             error
+}
+
+func (m *mockRepository) SaveAsset(ctx context.Context, asset repository.Asset) (*repository.Asset, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.assetSaved = &asset
+	return &asset, nil
+}
+
+func (m *mockRepository) GetAssetById(ctx context.Context, id string, userId string) (*repository.Asset, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, asset := range m.assets {
+		if asset.Id == id && asset.UserId == userId {
+			assetFound := asset
+			return &assetFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) GetAssetByTicker(ctx context.Context, ticker string, userId string) (*repository.Asset, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, asset := range m.assets {
+		if asset.Ticker == ticker && asset.UserId == userId {
+			assetFound := asset
+			return &assetFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) GetAllAssets(ctx context.Context, userId string) (*[]repository.Asset, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &m.assets, nil
+}
+
+func (m *mockRepository) UpdatePrice(ctx context.Context, asset repository.Asset) error {
+	m.priceUpdated = &asset
+	return nil
+}
+
+func (m *mockRepository) LockAsset(ctx context.Context, id string) error {
+	m.assetLocked = id
+	return nil
+}
+
+func (m *mockRepository) SaveOperation(ctx context.Context, operation repository.Operation) (*repository.Operation, error) {
+	m.operationSaved = &operation
+	return &operation, nil
+}
+
+func (m *mockRepository) GetOperations(ctx context.Context, assetId string) (*[]repository.Operation, error) {
+	operations := m.operations[assetId]
+	return &operations, nil
+}
+
+func (m *mockRepository) GetGain(ctx context.Context, gainId string, userId string) (*repository.Dividend, error) {
+	for _, gain := range m.gains {
+		if gain.GainId == gainId {
+			gainFound := gain
+			return &gainFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) SaveDividend(ctx context.Context, assetId string, gainId string) error {
+	m.dividendSaved = []string{assetId, gainId}
+	return nil
+}
+
+func (m *mockRepository) DeleteDividend(ctx context.Context, assetId string, gainId string) error {
+	m.dividendDeleted = []string{assetId, gainId}
+	return nil
+}
+
+func (m *mockRepository) GetDividends(ctx context.Context, assetId string) (*[]repository.Dividend, error) {
+	dividends := m.dividends[assetId]
+	return &dividends, nil
+}
+
+// mockConverter doubles the amounts in another currency
+type mockConverter struct {
+	err error
+}
+
+func (m *mockConverter) Convert(convertCtx erservice.ConvertContext) (money.Money, error) {
+	if convertCtx.From == convertCtx.To {
+		return convertCtx.Amount, nil
+	}
+	if m.err != nil {
+		return 0, m.err
+	}
+	return convertCtx.Amount * 2, nil
+}
+
+type mockTransactor struct {
+	rolledBack bool
+}
+
+func (t *mockTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	t.rolledBack = err != nil
+	return err
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func nowMock() time.Time {
+	return time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+}
+
+func uuidMock() uuid.UUID {
+	return uuid.FromStringOrNil("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e")
+}
+
+func TestCreateAssetSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.CreateAsset(CreateAssetContext{
+		Ctx:       context.TODO(),
+		Request:   CreateAssetRequest{Ticker: " petr4 ", Name: "Petrobras PN", AssetType: "stock"},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &repository.Asset{
+		Id:        "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		CreatedAt: nowMock(),
+		UserId:    "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Ticker:    "PETR4",
+		Name:      "Petrobras PN",
+		AssetType: "stock",
+		Currency:  "BRL",
+	}, _mockRepository.assetSaved)
+	assert.Equal(t, &PositionResponse{
+		Id:        "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		Ticker:    "PETR4",
+		Name:      "Petrobras PN",
+		AssetType: "stock",
+		Currency:  "BRL",
+		CreatedAt: nowMock(),
+	}, response)
+}
+
+func TestCreateAssetAlreadyRegistered(t *testing.T) {
+	_mockRepository := &mockRepository{assets: buildAssetsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.CreateAsset(CreateAssetContext{
+		Ctx:       context.TODO(),
+		Request:   CreateAssetRequest{Ticker: "PETR4", Name: "Petrobras PN", AssetType: "stock"},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, apperror.Conflict("The asset PETR4 is already registered"), err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.assetSaved)
+}
+
+func TestCreateAssetFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{err: errors.New("An error has been ocurred")}, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.CreateAsset(CreateAssetContext{
+		Ctx:       context.TODO(),
+		Request:   CreateAssetRequest{Ticker: "PETR4", Name: "Petrobras PN", AssetType: "stock"},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,146 @@
+package ivservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOperationBuy(t *testing.T) {
+	_mockRepository := buildRepositoryMock()
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.CreateOperation(CreateOperationContext{
+		Ctx:     context.TODO(),
+		AssetId: "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f",
+		Request: CreateOperationRequest{
+			OperationType: OPERATION_BUY,
+			OperatedAt:    date(2024, 3, 12),
+			Quantity:      money.Quantity(12500000),
+			Price:         money.FromCents(46000),
+			Fees:          money.FromCents(100),
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &repository.Operation{
+		Id:            "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		CreatedAt:     nowMock(),
+		AssetId:       "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f",
+		OperationType: OPERATION_BUY,
+		OperatedAt:    date(2024, 3, 12),
+		Quantity:      money.Quantity(12500000),
+		Price:         money.FromCents(46000),
+		Fees:          money.FromCents(100),
+	}, _mockRepository.operationSaved)
+	assert.Equal(t, "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f", _mockRepository.assetLocked)
+	assert.Equal(t, &OperationResponse{
+		Id:            "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		OperationType: OPERATION_BUY,
+		OperatedAt:    date(2024, 3, 12),
+		Quantity:      money.Quantity(12500000),
+		Price:         money.FromCents(46000),
+		Fees:          money.FromCents(100),
+		Value:         money.FromCents(5750),
+	}, response)
+}
+
+func TestCreateOperationSell(t *testing.T) {
+	_mockRepository := buildRepositoryMock()
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.CreateOperation(CreateOperationContext{
+		Ctx:     context.TODO(),
+		AssetId: "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		Request: CreateOperationRequest{
+			OperationType: OPERATION_SELL,
+			OperatedAt:    date(2024, 3, 10),
+			Quantity:      money.Quantity(3000000000),
+			Price:         money.FromCents(4000),
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	// the 30 units sold leave the position of 90 units at the cost of 951.40
+	assert.Equal(t, money.FromCents(120000), response.Value)
+	assert.Equal(t, money.FromCents(24860), *response.RealizedResult)
+	assert.NotNil(t, _mockRepository.operationSaved)
+}
+
+func TestCreateOperationSellExceedsPosition(t *testing.T) {
+	_mockRepository := buildRepositoryMock()
+	_mockTransactor := &mockTransactor{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, _mockTransactor)
+
+	response, err := _storageProcess.CreateOperation(CreateOperationContext{
+		Ctx:     context.TODO(),
+		AssetId: "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		Request: CreateOperationRequest{
+			OperationType: OPERATION_SELL,
+			OperatedAt:    date(2024, 3, 10),
+			Quantity:      money.Quantity(9100000000),
+			Price:         money.FromCents(4000),
+		},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, validation.Errors{
+		{Field: "quantity", Code: validation.CODE_INVALID, Message: "The quantity must not exceed the position held on the operated_at"},
+	}, err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.operationSaved)
+	assert.True(t, _mockTransactor.rolledBack)
+}
+
+func TestCreateOperationSellLeavesLaterSellWithoutUnits(t *testing.T) {
+	_mockRepository := buildRepositoryMock()
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	// the 150 units held on the date allow the sell, but the 60 units sold in March would exceed the 50 units left
+	response, err := _storageProcess.CreateOperation(CreateOperationContext{
+		Ctx:     context.TODO(),
+		AssetId: "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		Request: CreateOperationRequest{
+			OperationType: OPERATION_SELL,
+			OperatedAt:    date(2024, 2, 20),
+			Quantity:      money.Quantity(10000000000),
+			Price:         money.FromCents(3500),
+		},
+		UserToken: tokenMock,
+	})
+	assert.IsType(t, validation.Errors{}, err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.operationSaved)
+	assert.Len(t, _mockRepository.operations["6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e"], 3)
+}
+
+func TestCreateOperationAssetNotFound(t *testing.T) {
+	_storageProcess := NewStorageProcess(buildRepositoryMock(), uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.CreateOperation(CreateOperationContext{
+		Ctx:       context.TODO(),
+		AssetId:   "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d",
+		Request:   CreateOperationRequest{OperationType: OPERATION_BUY, OperatedAt: date(2024, 3, 12), Quantity: money.Quantity(100000000)},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, apperror.NotFound("Asset not found"), err)
+	assert.Nil(t, response)
+}
+
+func TestCreateOperationFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{err: errors.New("An error has been ocurred")}, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.CreateOperation(CreateOperationContext{
+		Ctx:       context.TODO(),
+		AssetId:   "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		Request:   CreateOperationRequest{OperationType: OPERATION_BUY, OperatedAt: date(2024, 3, 12), Quantity: money.Quantity(100000000)},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,95 @@
+package ivservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildGainsMock() []repository.Dividend {
+	return []repository.Dividend{
+		{
+			GainId:      "3c4d5e6f-7a8b-4c9d-8e0f-2a3b4c5d6e7f",
+			PayIn:       date(2024, 3, 12),
+			Description: "Dividendos VOO",
+			Value:       money.FromCents(320),
+			Currency:    "USD",
+		},
+		{
+			GainId:      "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+			AssetId:     "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+			PayIn:       date(2024, 2, 20),
+			Description: "Dividendos PETR4",
+			Value:       money.FromCents(14530),
+			Currency:    "BRL",
+		},
+	}
+}
+
+func TestLinkDividendSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{assets: buildAssetsMock(), gains: buildGainsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.LinkDividend(DividendContext{
+		Ctx:       context.TODO(),
+		AssetId:   "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f",
+		GainId:    "3c4d5e6f-7a8b-4c9d-8e0f-2a3b4c5d6e7f",
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f", "3c4d5e6f-7a8b-4c9d-8e0f-2a3b4c5d6e7f"}, _mockRepository.dividendSaved)
+	assert.Equal(t, &DividendResponse{
+		GainId:      "3c4d5e6f-7a8b-4c9d-8e0f-2a3b4c5d6e7f",
+		PayIn:       date(2024, 3, 12),
+		Description: "Dividendos VOO",
+		Value:       money.FromCents(320),
+		Currency:    "USD",
+	}, response)
+}
+
+func TestLinkDividendGainNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{assets: buildAssetsMock(), gains: buildGainsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.LinkDividend(DividendContext{
+		Ctx:       context.TODO(),
+		AssetId:   "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f",
+		GainId:    "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d",
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, apperror.NotFound("Gain not found"), err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.dividendSaved)
+}
+
+func TestLinkDividendAssetNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{assets: buildAssetsMock(), gains: buildGainsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.LinkDividend(DividendContext{
+		Ctx:       context.TODO(),
+		AssetId:   "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d",
+		GainId:    "3c4d5e6f-7a8b-4c9d-8e0f-2a3b4c5d6e7f",
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, apperror.NotFound("Asset not found"), err)
+	assert.Nil(t, response)
+}
+
+func TestLinkDividendFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{err: errors.New("An error has been ocurred")}, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.LinkDividend(DividendContext{
+		Ctx:       context.TODO(),
+		AssetId:   "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f",
+		GainId:    "3c4d5e6f-7a8b-4c9d-8e0f-2a3b4c5d6e7f",
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
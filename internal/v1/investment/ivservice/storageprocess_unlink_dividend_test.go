@@ -0,0 +1,62 @@
+package ivservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnlinkDividendSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{assets: buildAssetsMock(), gains: buildGainsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	err := _storageProcess.UnlinkDividend(DividendContext{
+		Ctx:       context.TODO(),
+		AssetId:   "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		GainId:    "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"}, _mockRepository.dividendDeleted)
+}
+
+func TestUnlinkDividendNotLinked(t *testing.T) {
+	_mockRepository := &mockRepository{assets: buildAssetsMock(), gains: buildGainsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	err := _storageProcess.UnlinkDividend(DividendContext{
+		Ctx:       context.TODO(),
+		AssetId:   "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f",
+		GainId:    "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, apperror.NotFound("Dividend not found"), err)
+	assert.Nil(t, _mockRepository.dividendDeleted)
+}
+
+func TestUnlinkDividendAssetNotFound(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{assets: buildAssetsMock(), gains: buildGainsMock()}, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	err := _storageProcess.UnlinkDividend(DividendContext{
+		Ctx:       context.TODO(),
+		AssetId:   "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d",
+		GainId:    "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, apperror.NotFound("Asset not found"), err)
+}
+
+func TestUnlinkDividendFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{err: errors.New("An error has been ocurred")}, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	err := _storageProcess.UnlinkDividend(DividendContext{
+		Ctx:       context.TODO(),
+		AssetId:   "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		GainId:    "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package ivservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePriceSuccess(t *testing.T) {
+	_mockRepository := buildRepositoryMock()
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	priceAt := date(2024, 3, 14)
+	response, err := _storageProcess.UpdatePrice(UpdatePriceContext{
+		Ctx:       context.TODO(),
+		Id:        "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		Request:   UpdatePriceRequest{Price: money.FromCents(4000), PriceAt: &priceAt},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromCents(4000), *_mockRepository.priceUpdated.Price)
+	assert.Equal(t, priceAt, *_mockRepository.priceUpdated.PriceAt)
+	assert.Equal(t, money.FromCents(360000), *response.MarketValue)
+	assert.Equal(t, money.FromCents(74580), *response.UnrealizedResult)
+	assert.Nil(t, response.Operations)
+}
+
+func TestUpdatePriceToday(t *testing.T) {
+	_mockRepository := buildRepositoryMock()
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.UpdatePrice(UpdatePriceContext{
+		Ctx:       context.TODO(),
+		Id:        "7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f",
+		Request:   UpdatePriceRequest{Price: money.FromCents(47000)},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, date(2024, 3, 15), *_mockRepository.priceUpdated.PriceAt)
+	assert.Equal(t, date(2024, 3, 15), *response.PriceAt)
+	assert.Equal(t, money.FromCents(5000), *response.UnrealizedResult)
+}
+
+func TestUpdatePriceNotFound(t *testing.T) {
+	_mockRepository := buildRepositoryMock()
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.UpdatePrice(UpdatePriceContext{
+		Ctx:       context.TODO(),
+		Id:        "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d",
+		Request:   UpdatePriceRequest{Price: money.FromCents(4000)},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, apperror.NotFound("Asset not found"), err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.priceUpdated)
+}
+
+func TestUpdatePriceFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{err: errors.New("An error has been ocurred")}, uuidMock, &mockConverter{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.UpdatePrice(UpdatePriceContext{
+		Ctx:       context.TODO(),
+		Id:        "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e",
+		Request:   UpdatePriceRequest{Price: money.FromCents(4000)},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
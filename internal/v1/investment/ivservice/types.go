@@ -0,0 +1,116 @@
+package ivservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const (
+	OPERATION_BUY  = "buy"
+	OPERATION_SELL = "sell"
+)
+
+type CreateAssetContext struct {
+	Ctx       context.Context
+	Request   CreateAssetRequest
+	UserToken string
+}
+
+type UpdatePriceContext struct {
+	Ctx       context.Context
+	Id        string
+	Request   UpdatePriceRequest
+	UserToken string
+}
+
+type CreateOperationContext struct {
+	Ctx       context.Context
+	AssetId   string
+	Request   CreateOperationRequest
+	UserToken string
+}
+
+type DividendContext struct {
+	Ctx       context.Context
+	AssetId   string
+	GainId    string
+	UserToken string
+}
+
+type SearchContext struct {
+	Ctx       context.Context
+	Id        string
+	UserToken string
+}
+
+// CreateAssetRequest has the ticker that identifies the asset in the portfolio of the user, such as PETR4 or BTC
+type CreateAssetRequest struct {
+	Ticker    string `json:"ticker" binding:"notblank,max=20"`
+	Name      string `json:"name" binding:"notblank,max=255"`
+	AssetType string `json:"asset_type" binding:"required,oneof=stock reit etf fixed_income fund crypto other"`
+	Currency  string `json:"currency" binding:"omitempty,iso4217"`
+}
+
+// UpdatePriceRequest has the unit price of the asset in its currency, the price_at is the current day when not informed
+type UpdatePriceRequest struct {
+	Price   money.Money `json:"price" binding:"gte=0" swaggertype:"number"`
+	PriceAt *time.Time  `json:"price_at"`
+}
+
+// CreateOperationRequest has the unit price and the fees in the currency of the asset
+type CreateOperationRequest struct {
+	OperationType string         `json:"operation_type" binding:"required,oneof=buy sell"`
+	OperatedAt    time.Time      `json:"operated_at" binding:"required"`
+	Quantity      money.Quantity `json:"quantity" binding:"gt=0" swaggertype:"number"`
+	Price         money.Money    `json:"price" binding:"gte=0" swaggertype:"number"`
+	Fees          money.Money    `json:"fees" binding:"gte=0" swaggertype:"number"`
+}
+
+// PositionResponse has the position of the asset at the average cost. The market value and the unrealized result are
+// only informed once the asset has a price, the dividends are the linked gains in the currency of the asset
+type PositionResponse struct {
+	Id               string              `json:"id"`
+	Ticker           string              `json:"ticker"`
+	Name             string              `json:"name"`
+	AssetType        string              `json:"asset_type"`
+	Currency         string              `json:"currency"`
+	Quantity         money.Quantity      `json:"quantity" swaggertype:"number"`
+	AveragePrice     money.Money         `json:"average_price" swaggertype:"number"`
+	Cost             money.Money         `json:"cost" swaggertype:"number"`
+	Price            *money.Money        `json:"price,omitempty" swaggertype:"number"`
+	PriceAt          *time.Time          `json:"price_at,omitempty"`
+	MarketValue      *money.Money        `json:"market_value,omitempty" swaggertype:"number"`
+	UnrealizedResult *money.Money        `json:"unrealized_result,omitempty" swaggertype:"number"`
+	RealizedResult   money.Money         `json:"realized_result" swaggertype:"number"`
+	Dividends        money.Money         `json:"dividends" swaggertype:"number"`
+	TotalResult      money.Money         `json:"total_result" swaggertype:"number"`
+	Operations       []OperationResponse `json:"operations,omitempty"`
+	DividendGains    []DividendResponse  `json:"dividend_gains,omitempty"`
+	CreatedAt        time.Time           `json:"created_at"`
+}
+
+// OperationResponse has the value of the units, the realized result is only informed for the sells
+type OperationResponse struct {
+	Id             string         `json:"id"`
+	OperationType  string         `json:"operation_type"`
+	OperatedAt     time.Time      `json:"operated_at"`
+	Quantity       money.Quantity `json:"quantity" swaggertype:"number"`
+	Price          money.Money    `json:"price" swaggertype:"number"`
+	Fees           money.Money    `json:"fees" swaggertype:"number"`
+	Value          money.Money    `json:"value" swaggertype:"number"`
+	RealizedResult *money.Money   `json:"realized_result,omitempty" swaggertype:"number"`
+}
+
+type DividendResponse struct {
+	GainId      string      `json:"gain_id"`
+	PayIn       time.Time   `json:"pay_in"`
+	Description string      `json:"description"`
+	Value       money.Money `json:"value" swaggertype:"number"`
+	Currency    string      `json:"currency"`
+}
+
+type PortfolioResponse struct {
+	Records []PositionResponse `json:"records"`
+}
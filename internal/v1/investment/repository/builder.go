@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type AssetBuilder struct {
+	id        string
+	createdAt time.Time
+	userId    string
+	ticker    string
+	name      string
+	assetType string
+	currency  string
+}
+
+func NewAssetBuilder() *AssetBuilder {
+	return &AssetBuilder{}
+}
+func (builder *AssetBuilder) AddId(id string) *AssetBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *AssetBuilder) AddCreatedAt(createdAt time.Time) *AssetBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *AssetBuilder) AddUserId(userId string) *AssetBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *AssetBuilder) AddTicker(ticker string) *AssetBuilder {
+	builder.ticker = ticker
+	return builder
+}
+func (builder *AssetBuilder) AddName(name string) *AssetBuilder {
+	builder.name = name
+	return builder
+}
+func (builder *AssetBuilder) AddAssetType(assetType string) *AssetBuilder {
+	builder.assetType = assetType
+	return builder
+}
+func (builder *AssetBuilder) AddCurrency(currency string) *AssetBuilder {
+	builder.currency = currency
+	return builder
+}
+func (builder *AssetBuilder) Build() *Asset {
+	asset := Asset{}
+	asset.Id = builder.id
+	asset.CreatedAt = builder.createdAt
+	asset.UserId = builder.userId
+	asset.Ticker = builder.ticker
+	asset.Name = builder.name
+	asset.AssetType = builder.assetType
+	asset.Currency = builder.currency
+	return &asset
+}
+
+type OperationBuilder struct {
+	id            string
+	createdAt     time.Time
+	assetId       string
+	operationType string
+	operatedAt    time.Time
+	quantity      money.Quantity
+	price         money.Money
+	fees          money.Money
+}
+
+func NewOperationBuilder() *OperationBuilder {
+	return &OperationBuilder{}
+}
+func (builder *OperationBuilder) AddId(id string) *OperationBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *OperationBuilder) AddCreatedAt(createdAt time.Time) *OperationBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *OperationBuilder) AddAssetId(assetId string) *OperationBuilder {
+	builder.assetId = assetId
+	return builder
+}
+func (builder *OperationBuilder) AddOperationType(operationType string) *OperationBuilder {
+	builder.operationType = operationType
+	return builder
+}
+func (builder *OperationBuilder) AddOperatedAt(operatedAt time.Time) *OperationBuilder {
+	builder.operatedAt = operatedAt
+	return builder
+}
+func (builder *OperationBuilder) AddQuantity(quantity money.Quantity) *OperationBuilder {
+	builder.quantity = quantity
+	return builder
+}
+func (builder *OperationBuilder) AddPrice(price money.Money) *OperationBuilder {
+	builder.price = price
+	return builder
+}
+func (builder *OperationBuilder) AddFees(fees money.Money) *OperationBuilder {
+	builder.fees = fees
+	return builder
+}
+func (builder *OperationBuilder) Build() *Operation {
+	operation := Operation{}
+	operation.Id = builder.id
+	operation.CreatedAt = builder.createdAt
+	operation.AssetId = builder.assetId
+	operation.OperationType = builder.operationType
+	operation.OperatedAt = builder.operatedAt
+	operation.Quantity = builder.quantity
+	operation.Price = builder.price
+	operation.Fees = builder.fees
+	return &operation
+}
@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type Repository interface {
+	SaveAsset(ctx context.Context, asset Asset) (*Asset, error)
+	GetAssetById(ctx context.Context, id string, userId string) (*Asset, error)
+	GetAssetByTicker(ctx context.Context, ticker string, userId string) (*Asset, error)
+	GetAllAssets(ctx context.Context, userId string) (*[]Asset, error)
+	UpdatePrice(ctx context.Context, asset Asset) error
+	LockAsset(ctx context.Context, id string) error
+	SaveOperation(ctx context.Context, operation Operation) (*Operation, error)
+	GetOperations(ctx context.Context, assetId string) (*[]Operation, error)
+	GetGain(ctx context.Context, gainId string, userId string) (*Dividend, error)
+	SaveDividend(ctx context.Context, assetId string, gainId string) error
+	DeleteDividend(ctx context.Context, assetId string, gainId string) error
+	GetDividends(ctx context.Context, assetId string) (*[]Dividend, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) SaveAsset(ctx context.Context, asset Asset) (*Asset, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO asset (id, created_at, user_id, ticker, name, asset_type, currency)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		asset.Id,
+		asset.CreatedAt.Unix(),
+		asset.UserId,
+		asset.Ticker,
+		asset.Name,
+		asset.AssetType,
+		asset.Currency,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (r *repository) scanAssets(rows *sql.Rows) (*[]Asset, error) {
+	assetList := []Asset{}
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var price money.NullMoney
+		var priceAt sql.NullTime
+		var asset Asset
+
+		err := rows.Scan(
+			&asset.Id,
+			&createdAtTimestamp,
+			&asset.UserId,
+			&asset.Ticker,
+			&asset.Name,
+			&asset.AssetType,
+			&asset.Currency,
+			&price,
+			&priceAt)
+		if err != nil {
+			return nil, err
+		}
+		asset.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		if price.Valid {
+			asset.Price = &price.Money
+		}
+		if priceAt.Valid {
+			asset.PriceAt = &priceAt.Time
+		}
+
+		assetList = append(assetList, asset)
+	}
+	return &assetList, nil
+}
+
+func (r *repository) getAsset(ctx context.Context, query string, args ...any) (*Asset, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	assetList, err := r.scanAssets(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(*assetList) == 0 {
+		return nil, nil
+	}
+	return &(*assetList)[0], nil
+}
+
+func (r *repository) GetAssetById(ctx context.Context, id string, userId string) (*Asset, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			ticker,
+			name,
+			asset_type,
+			currency,
+			price,
+			price_at
+		FROM
+			asset
+		WHERE
+			id = ? AND user_id = ?`
+	return r.getAsset(ctx, query, id, userId)
+}
+
+func (r *repository) GetAssetByTicker(ctx context.Context, ticker string, userId string) (*Asset, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			ticker,
+			name,
+			asset_type,
+			currency,
+			price,
+			price_at
+		FROM
+			asset
+		WHERE
+			ticker = ? AND user_id = ?`
+	return r.getAsset(ctx, query, ticker, userId)
+}
+
+// GetAllAssets returns the assets of the user in the alphabetical order of the tickers
+func (r *repository) GetAllAssets(ctx context.Context, userId string) (*[]Asset, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			ticker,
+			name,
+			asset_type,
+			currency,
+			price,
+			price_at
+		FROM
+			asset
+		WHERE
+			user_id = ?
+		ORDER BY ticker ASC`
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanAssets(rows)
+}
+
+func (r *repository) UpdatePrice(ctx context.Context, asset Asset) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE asset SET price = ?, price_at = ? WHERE id = ? AND user_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(asset.Price, asset.PriceAt, asset.Id, asset.UserId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// LockAsset locks the row of the asset until the transaction of the context ends, so the operations
+// of the asset are not changed by a concurrent request while they are replayed
+func (r *repository) LockAsset(ctx context.Context, id string) error {
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, `SELECT id FROM asset WHERE id = ? FOR UPDATE`, id)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+func (r *repository) SaveOperation(ctx context.Context, operation Operation) (*Operation, error) {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO asset_operation (id, created_at, asset_id, operation_type, operated_at, quantity, price, fees)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(
+		operation.Id,
+		operation.CreatedAt.Unix(),
+		operation.AssetId,
+		operation.OperationType,
+		operation.OperatedAt,
+		operation.Quantity,
+		operation.Price,
+		operation.Fees,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &operation, nil
+}
+
+// GetOperations returns the operations of the asset in the order they were made
+func (r *repository) GetOperations(ctx context.Context, assetId string) (*[]Operation, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			asset_id,
+			operation_type,
+			operated_at,
+			quantity,
+			price,
+			fees
+		FROM
+			asset_operation
+		WHERE
+			asset_id = ?
+		ORDER BY operated_at ASC, created_at ASC`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, assetId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	operationList := []Operation{}
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var operation Operation
+
+		err := rows.Scan(
+			&operation.Id,
+			&createdAtTimestamp,
+			&operation.AssetId,
+			&operation.OperationType,
+			&operation.OperatedAt,
+			&operation.Quantity,
+			&operation.Price,
+			&operation.Fees)
+		if err != nil {
+			return nil, err
+		}
+		operation.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+
+		operationList = append(operationList, operation)
+	}
+	return &operationList, nil
+}
+
+// GetGain returns the gain of the user that is not in the trash, with the asset it is linked to when there is one
+func (r *repository) GetGain(ctx context.Context, gainId string, userId string) (*Dividend, error) {
+	query := `
+		SELECT
+			g.id,
+			COALESCE(ad.asset_id, ''),
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency
+		FROM
+			gain g
+		LEFT JOIN asset_dividend ad ON ad.gain_id = g.id
+		WHERE
+			g.id = ? AND g.user_id = ? AND g.deleted_at IS NULL`
+	rows, err := r.db.QueryContext(ctx, query, gainId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	dividendList, err := r.scanDividends(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(*dividendList) == 0 {
+		return nil, nil
+	}
+	return &(*dividendList)[0], nil
+}
+
+// SaveDividend links the gain to the asset, a gain already linked to another asset is moved to this one
+func (r *repository) SaveDividend(ctx context.Context, assetId string, gainId string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO asset_dividend (gain_id, asset_id) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE asset_id = VALUES(asset_id)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(gainId, assetId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) DeleteDividend(ctx context.Context, assetId string, gainId string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM asset_dividend WHERE gain_id = ? AND asset_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(gainId, assetId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) scanDividends(rows *sql.Rows) (*[]Dividend, error) {
+	dividendList := []Dividend{}
+	for rows.Next() {
+		var dividend Dividend
+		err := rows.Scan(
+			&dividend.GainId,
+			&dividend.AssetId,
+			&dividend.PayIn,
+			&dividend.Description,
+			&dividend.Value,
+			&dividend.Currency)
+		if err != nil {
+			return nil, err
+		}
+		dividendList = append(dividendList, dividend)
+	}
+	return &dividendList, nil
+}
+
+// GetDividends returns the gains linked to the asset by the date they were paid, leaving out the ones in the trash
+func (r *repository) GetDividends(ctx context.Context, assetId string) (*[]Dividend, error) {
+	query := `
+		SELECT
+			g.id,
+			ad.asset_id,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency
+		FROM
+			asset_dividend ad
+		INNER JOIN gain g ON g.id = ad.gain_id
+		WHERE
+			ad.asset_id = ? AND g.deleted_at IS NULL
+		ORDER BY g.pay_in ASC`
+	rows, err := r.db.QueryContext(ctx, query, assetId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanDividends(rows)
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getAllAssetsQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			ticker,
+			name,
+			asset_type,
+			currency,
+			price,
+			price_at
+		FROM
+			asset
+		WHERE
+			user_id = ?
+		ORDER BY ticker ASC`
+
+func TestGetAllAssetsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(assetColumns).
+		AddRow("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", time.Now().Unix(), "User1", "HGLG11", "CSHG Logística", "reit", "BRL", "160.10", time.Now()).
+		AddRow("7c2e0f3b-4d5a-4b6c-9e8f-0a1b2c3d4e5f", time.Now().Unix(), "User1", "PETR4", "Petrobras PN", "stock", "BRL", nil, nil)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllAssetsQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	assets, err := _repository.GetAllAssets(context.Background(), "User1")
+	assert.NoError(t, err)
+	assert.Len(t, *assets, 2)
+	assert.Equal(t, "HGLG11", (*assets)[0].Ticker)
+	assert.Equal(t, "PETR4", (*assets)[1].Ticker)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllAssetsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(assetColumns).
+		AddRow("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", time.Now().Unix(), "User1", "HGLG11", "CSHG Logística", "reit", "BRL", "160.10", "yesterday")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllAssetsQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetAllAssets(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllAssetsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllAssetsQuery).
+		WithArgs("User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAllAssets(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var assetColumns = []string{"id", "created_at", "user_id", "ticker", "name", "asset_type", "currency", "price", "price_at"}
+
+const getAssetByIdQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			ticker,
+			name,
+			asset_type,
+			currency,
+			price,
+			price_at
+		FROM
+			asset
+		WHERE
+			id = ? AND user_id = ?`
+
+func TestGetAssetByIdSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	priceAt := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows(assetColumns).
+		AddRow("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", time.Now().Unix(), "User1", "PETR4", "Petrobras PN", "stock", "BRL", "38.25", priceAt)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAssetByIdQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1").
+		WillReturnRows(rowsMock)
+
+	asset, err := _repository.GetAssetById(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "PETR4", asset.Ticker)
+	assert.Equal(t, "stock", asset.AssetType)
+	assert.Equal(t, money.FromCents(3825), *asset.Price)
+	assert.Equal(t, priceAt, *asset.PriceAt)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAssetByIdWithoutPrice(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(assetColumns).
+		AddRow("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", time.Now().Unix(), "User1", "PETR4", "Petrobras PN", "stock", "BRL", nil, nil)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAssetByIdQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1").
+		WillReturnRows(rowsMock)
+
+	asset, err := _repository.GetAssetById(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, asset.Price)
+	assert.Nil(t, asset.PriceAt)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAssetByIdNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAssetByIdQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1").
+		WillReturnRows(sqlMock.NewRows(assetColumns))
+
+	asset, err := _repository.GetAssetById(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, asset)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAssetByIdScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(assetColumns).
+		AddRow("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", time.Now().Unix(), "User1", "PETR4", "Petrobras PN", "stock", "BRL", "free", nil)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAssetByIdQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetAssetById(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAssetByIdQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAssetByIdQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAssetById(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
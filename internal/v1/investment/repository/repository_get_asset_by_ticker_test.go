@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getAssetByTickerQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			ticker,
+			name,
+			asset_type,
+			currency,
+			price,
+			price_at
+		FROM
+			asset
+		WHERE
+			ticker = ? AND user_id = ?`
+
+func TestGetAssetByTickerSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(assetColumns).
+		AddRow("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", time.Now().Unix(), "User1", "PETR4", "Petrobras PN", "stock", "BRL", nil, nil)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAssetByTickerQuery).
+		WithArgs("PETR4", "User1").
+		WillReturnRows(rowsMock)
+
+	asset, err := _repository.GetAssetByTicker(context.Background(), "PETR4", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", asset.Id)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAssetByTickerNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAssetByTickerQuery).
+		WithArgs("PETR4", "User1").
+		WillReturnRows(sqlMock.NewRows(assetColumns))
+
+	asset, err := _repository.GetAssetByTicker(context.Background(), "PETR4", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, asset)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAssetByTickerQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAssetByTickerQuery).
+		WithArgs("PETR4", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAssetByTicker(context.Background(), "PETR4", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getDividendsQuery = `
+		SELECT
+			g.id,
+			ad.asset_id,
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency
+		FROM
+			asset_dividend ad
+		INNER JOIN gain g ON g.id = ad.gain_id
+		WHERE
+			ad.asset_id = ? AND g.deleted_at IS NULL
+		ORDER BY g.pay_in ASC`
+
+func TestGetDividendsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(dividendColumns).
+		AddRow("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), "Dividendos PETR4", "145.30", "BRL").
+		AddRow("2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e", "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC), "JCP PETR4", "52.10", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getDividendsQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnRows(rowsMock)
+
+	dividends, err := _repository.GetDividends(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e")
+	assert.NoError(t, err)
+	assert.Len(t, *dividends, 2)
+	assert.Equal(t, money.FromCents(14530), (*dividends)[0].Value)
+	assert.Equal(t, "JCP PETR4", (*dividends)[1].Description)
+	assert.Equal(t, "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", (*dividends)[1].AssetId)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetDividendsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(dividendColumns).
+		AddRow("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), "Dividendos PETR4", "a lot", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getDividendsQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetDividends(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetDividendsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getDividendsQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetDividends(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
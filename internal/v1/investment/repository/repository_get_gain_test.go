@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var dividendColumns = []string{"id", "asset_id", "pay_in", "description", "value", "currency"}
+
+const getGainQuery = `
+		SELECT
+			g.id,
+			COALESCE(ad.asset_id, ''),
+			g.pay_in,
+			g.description,
+			g.value,
+			g.currency
+		FROM
+			gain g
+		LEFT JOIN asset_dividend ad ON ad.gain_id = g.id
+		WHERE
+			g.id = ? AND g.user_id = ? AND g.deleted_at IS NULL`
+
+func TestGetGainSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	payIn := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows(dividendColumns).
+		AddRow("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "", payIn, "Dividendos PETR4", "145.30", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainQuery).
+		WithArgs("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "User1").
+		WillReturnRows(rowsMock)
+
+	gain, err := _repository.GetGain(context.Background(), "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Dividend{
+		GainId:      "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+		PayIn:       payIn,
+		Description: "Dividendos PETR4",
+		Value:       money.FromCents(14530),
+		Currency:    "BRL",
+	}, gain)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainQuery).
+		WithArgs("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "User1").
+		WillReturnRows(sqlMock.NewRows(dividendColumns))
+
+	gain, err := _repository.GetGain(context.Background(), "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, gain)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainQuery).
+		WithArgs("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetGain(context.Background(), "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
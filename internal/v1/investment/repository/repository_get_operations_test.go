@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var operationColumns = []string{"id", "created_at", "asset_id", "operation_type", "operated_at", "quantity", "price", "fees"}
+
+const getOperationsQuery = `
+		SELECT
+			id,
+			created_at,
+			asset_id,
+			operation_type,
+			operated_at,
+			quantity,
+			price,
+			fees
+		FROM
+			asset_operation
+		WHERE
+			asset_id = ?
+		ORDER BY operated_at ASC, created_at ASC`
+
+func TestGetOperationsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(operationColumns).
+		AddRow("8d3f1a4c-5e6b-4c7d-8f9a-1b2c3d4e5f60", time.Now().Unix(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "buy",
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), "100.00000000", "32.50", "4.90").
+		AddRow("9e4a2b5d-6f7c-4d8e-9a0b-2c3d4e5f6a71", time.Now().Unix(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "sell",
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "40.50000000", "36.00", "0.00")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getOperationsQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnRows(rowsMock)
+
+	operations, err := _repository.GetOperations(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e")
+	assert.NoError(t, err)
+	assert.Len(t, *operations, 2)
+	assert.Equal(t, money.Quantity(10000000000), (*operations)[0].Quantity)
+	assert.Equal(t, money.FromCents(490), (*operations)[0].Fees)
+	assert.Equal(t, "sell", (*operations)[1].OperationType)
+	assert.Equal(t, money.Quantity(4050000000), (*operations)[1].Quantity)
+	assert.Equal(t, money.FromCents(3600), (*operations)[1].Price)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetOperationsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(operationColumns).
+		AddRow("8d3f1a4c-5e6b-4c7d-8f9a-1b2c3d4e5f60", time.Now().Unix(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "buy",
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), "a hundred", "32.50", "4.90")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getOperationsQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetOperations(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetOperationsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getOperationsQuery).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetOperations(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
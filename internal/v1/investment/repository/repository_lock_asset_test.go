@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockAssetSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`SELECT id FROM asset WHERE id = ? FOR UPDATE`).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnRows(sqlMock.NewRows([]string{"id"}).AddRow("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e"))
+
+	err = _repository.LockAsset(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e")
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestLockAssetFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`SELECT id FROM asset WHERE id = ? FOR UPDATE`).
+		WithArgs("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.LockAsset(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveAssetQuery = `
+		INSERT INTO asset (id, created_at, user_id, ticker, name, asset_type, currency)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+func buildAssetMock() *Asset {
+	return NewAssetBuilder().
+		AddId("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		AddCreatedAt(time.Now()).
+		AddUserId("User1").
+		AddTicker("PETR4").
+		AddName("Petrobras PN").
+		AddAssetType("stock").
+		AddCurrency("BRL").
+		Build()
+}
+
+func TestSaveAssetSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	assetMock := buildAssetMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveAssetQuery).
+		ExpectExec().
+		WithArgs(
+			assetMock.Id,
+			assetMock.CreatedAt.Unix(),
+			assetMock.UserId,
+			assetMock.Ticker,
+			assetMock.Name,
+			assetMock.AssetType,
+			assetMock.Currency).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	asset, err := _repository.SaveAsset(context.Background(), *assetMock)
+	assert.NoError(t, err)
+	assert.Equal(t, assetMock, asset)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveAssetFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	assetMock := buildAssetMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveAssetQuery).
+		ExpectExec().
+		WithArgs(
+			assetMock.Id,
+			assetMock.CreatedAt.Unix(),
+			assetMock.UserId,
+			assetMock.Ticker,
+			assetMock.Name,
+			assetMock.AssetType,
+			assetMock.Currency).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	asset, err := _repository.SaveAsset(context.Background(), *assetMock)
+	assert.Error(t, err)
+	assert.Nil(t, asset)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
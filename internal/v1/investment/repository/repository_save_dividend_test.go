@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveDividendQuery = `
+		INSERT INTO asset_dividend (gain_id, asset_id) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE asset_id = VALUES(asset_id)`
+
+func TestSaveDividendSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveDividendQuery).
+		ExpectExec().
+		WithArgs("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.SaveDividend(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d")
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveDividendFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveDividendQuery).
+		ExpectExec().
+		WithArgs("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.SaveDividend(context.Background(), "6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e", "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveOperationQuery = `
+		INSERT INTO asset_operation (id, created_at, asset_id, operation_type, operated_at, quantity, price, fees)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+func buildOperationMock() *Operation {
+	return NewOperationBuilder().
+		AddId("8d3f1a4c-5e6b-4c7d-8f9a-1b2c3d4e5f60").
+		AddCreatedAt(time.Now()).
+		AddAssetId("6b1d9e2a-3c4f-4a5b-8d7e-9f0a1b2c3d4e").
+		AddOperationType("buy").
+		AddOperatedAt(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)).
+		AddQuantity(money.Quantity(10000000000)).
+		AddPrice(money.FromCents(3250)).
+		AddFees(money.FromCents(490)).
+		Build()
+}
+
+func TestSaveOperationSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	operationMock := buildOperationMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveOperationQuery).
+		ExpectExec().
+		WithArgs(
+			operationMock.Id,
+			operationMock.CreatedAt.Unix(),
+			operationMock.AssetId,
+			operationMock.OperationType,
+			operationMock.OperatedAt,
+			"100",
+			"32.50",
+			"4.90").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	operation, err := _repository.SaveOperation(context.Background(), *operationMock)
+	assert.NoError(t, err)
+	assert.Equal(t, operationMock, operation)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveOperationFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	operationMock := buildOperationMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveOperationQuery).
+		ExpectExec().
+		WithArgs(
+			operationMock.Id,
+			operationMock.CreatedAt.Unix(),
+			operationMock.AssetId,
+			operationMock.OperationType,
+			operationMock.OperatedAt,
+			"100",
+			"32.50",
+			"4.90").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	operation, err := _repository.SaveOperation(context.Background(), *operationMock)
+	assert.Error(t, err)
+	assert.Nil(t, operation)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
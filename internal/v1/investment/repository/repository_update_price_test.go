@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const updatePriceQuery = `UPDATE asset SET price = ?, price_at = ? WHERE id = ? AND user_id = ?`
+
+func TestUpdatePriceSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	assetMock := buildAssetMock()
+	price := money.FromCents(3825)
+	priceAt := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	assetMock.Price = &price
+	assetMock.PriceAt = &priceAt
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(updatePriceQuery).
+		ExpectExec().
+		WithArgs("38.25", priceAt, assetMock.Id, assetMock.UserId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.UpdatePrice(context.Background(), *assetMock)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUpdatePriceFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	assetMock := buildAssetMock()
+	price := money.FromCents(3825)
+	priceAt := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	assetMock.Price = &price
+	assetMock.PriceAt = &priceAt
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(updatePriceQuery).
+		ExpectExec().
+		WithArgs("38.25", priceAt, assetMock.Id, assetMock.UserId).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.UpdatePrice(context.Background(), *assetMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+// Asset is an investment of the user, the price is the last one informed manually and is nil until the first update
+type Asset struct {
+	Id        string
+	CreatedAt time.Time
+	UserId    string
+	Ticker    string
+	Name      string
+	AssetType string
+	Currency  string
+	Price     *money.Money
+	PriceAt   *time.Time
+}
+
+// Operation is a buy or a sell of units of an asset at the unit price, the fees are paid apart from the units
+type Operation struct {
+	Id            string
+	CreatedAt     time.Time
+	AssetId       string
+	OperationType string
+	OperatedAt    time.Time
+	Quantity      money.Quantity
+	Price         money.Money
+	Fees          money.Money
+}
+
+// Dividend is a gain produced by an asset, such as a dividend or an interest on equity
+type Dividend struct {
+	GainId      string
+	AssetId     string
+	PayIn       time.Time
+	Description string
+	Value       money.Money
+	Currency    string
+}
@@ -1,21 +1,33 @@
 package invoice
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/etag"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
 	"github.com/ruanlas/wallet-core-api/internal/tracing"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoice/iservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	"go.elastic.co/apm"
 )
 
 type Handler interface {
 	Create(c *gin.Context)
+	CreateFromPix(c *gin.Context)
 	GetById(c *gin.Context)
 	Update(c *gin.Context)
+	Patch(c *gin.Context)
 	Delete(c *gin.Context)
 	GetAll(c *gin.Context)
+	GetTrash(c *gin.Context)
+	Restore(c *gin.Context)
+	CreateBatch(c *gin.Context)
+	UpdateBatch(c *gin.Context)
+	DeleteBatch(c *gin.Context)
+	ApplyRules(c *gin.Context)
 }
 
 type ResponseDefault interface {
@@ -32,7 +44,9 @@ func NewHandler(storageProcess iservice.StorageProcess, readingProcess iservice.
 
 // Create godoc
 // @Summary Criar uma Despesa
-// @Description Este endpoint permite criar uma despesa
+// @Description Este endpoint permite criar uma despesa.
+// @Description A categoria é escolhida pelas regras de categorização quando não informada.
+// @Description Com auto_category, a categoria ainda não escolhida é sugerida a partir das despesas já categorizadas do usuário quando a confiança da sugestão é suficiente
 // @Tags Invoice
 // @Accept json
 // @Produce json
@@ -47,9 +61,9 @@ func (h *handler) Create(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	// apm.TransactionFromContext(ctx)
 
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("Invoice::StorageProcess::Create", "Create new invoice", nil)
@@ -60,7 +74,45 @@ func (h *handler) Create(c *gin.Context) {
 	}
 	invoiceCreated, err := h.storageProcess.Create(createCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, invoiceCreated)
+}
+
+// @Summary Criar uma Despesa a partir de um PIX
+// @Description Este endpoint permite criar uma despesa a partir de um PIX copia e cola ou do conteúdo do seu QR code.
+// @Description O valor e a descrição são obtidos do PIX quando não informados, e a categoria é escolhida pelas regras de categorização quando não informada
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param invoice body iservice.CreateFromPixRequest true "Modelo de criação da despesa a partir do PIX"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} iservice.InvoiceResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/invoice/pix [post]
+func (h *handler) CreateFromPix(c *gin.Context) {
+	var request iservice.CreateFromPixRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Invoice::StorageProcess::CreateFromPix", "Create new invoice from a PIX", nil)
+	createFromPixCtx := iservice.CreateFromPixContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Request:   request,
+	}
+	invoiceCreated, err := h.storageProcess.CreateFromPix(createFromPixCtx)
+	if err != nil {
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
@@ -76,6 +128,7 @@ func (h *handler) Create(c *gin.Context) {
 // @Param id path string true "Id da despesa"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
 // @Success 200 {object} iservice.InvoiceResponse
+// @Header 200 {string} ETag "Versão atual do registro, deve ser enviada no If-Match da edição e remoção"
 // @Router /v1/invoice/{id} [get]
 func (h *handler) GetById(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -92,15 +145,12 @@ func (h *handler) GetById(c *gin.Context) {
 	}
 	invoice, err := h.readingProcess.GetById(searchCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
-	if invoice == nil {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Object not found"})
-		return
-	}
 	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(invoice.Version))
 	c.JSON(http.StatusOK, invoice)
 }
 
@@ -112,7 +162,10 @@ func (h *handler) GetById(c *gin.Context) {
 // @Produce json
 // @Param invoice body iservice.UpdateRequest true "Modelo de edição da despesa"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
 // @Success 200 {object} iservice.InvoiceResponse
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
 // @Router /v1/invoice/{id} [put]
 func (h *handler) Update(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -121,9 +174,19 @@ func (h *handler) Update(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	id := c.Param("id")
 	var request iservice.UpdateRequest
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("Invoice::StorageProcess::Update", "Create new invoice", nil)
@@ -131,19 +194,80 @@ func (h *handler) Update(c *gin.Context) {
 		Ctx:       ctx,
 		Request:   request,
 		Id:        id,
+		Version:   version,
 		UserToken: userToken,
 	}
 	invoiceUpdated, err := h.storageProcess.Update(updateCtx)
+	if errors.Is(err, iservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The invoice was modified by another request"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
-	if invoiceUpdated == nil {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Invoice projection not found"})
+	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(invoiceUpdated.Version))
+	c.JSON(http.StatusOK, invoiceUpdated)
+}
+
+// @Summary Editar parcialmente uma Despesa
+// @Description Este endpoint permite editar somente os campos informados de uma despesa.
+// @Description Os campos omitidos mantêm o valor atual e o registro resultante é validado antes de ser salvo.
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param invoice body iservice.PatchRequest true "Modelo de edição parcial da despesa"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
+// @Success 200 {object} iservice.InvoiceResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/invoice/{id} [patch]
+func (h *handler) Patch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	id := c.Param("id")
+	var request iservice.PatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Invoice::StorageProcess::Patch", "Patch an invoice", nil)
+	patchCtx := iservice.PatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		Id:        id,
+		Version:   version,
+		UserToken: userToken,
+	}
+	invoiceUpdated, err := h.storageProcess.Patch(patchCtx)
+	if errors.Is(err, iservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The invoice was modified by another request"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
 		return
 	}
 	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(invoiceUpdated.Version))
 	c.JSON(http.StatusOK, invoiceUpdated)
 }
 
@@ -154,7 +278,11 @@ func (h *handler) Update(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Id da despesa"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
 // @Success 200 {object} ResponseDefault{status=int,message=string}
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
 // @Router /v1/invoice/{id} [delete]
 func (h *handler) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -162,20 +290,213 @@ func (h *handler) Delete(c *gin.Context) {
 
 	id := c.Param("id")
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	span := tx.StartSpan("Invoice::StorageProcess::Delete", "Delete a invoice", nil)
-	searchCtx := iservice.SearchContext{
+	deleteCtx := iservice.DeleteContext{
 		Ctx:       ctx,
 		Id:        id,
+		Version:   version,
 		UserToken: userToken,
 	}
-	err := h.storageProcess.Delete(searchCtx)
+	err = h.storageProcess.Delete(deleteCtx)
+	if errors.Is(err, iservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The invoice was modified by another request"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
 	span.End()
-	c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Invoice projection removed"})
+	c.JSON(http.StatusOK, gin.H{"status": http.StatusOK, "message": "Invoice removed"})
+}
+
+// @Summary Criar Despesas em lote
+// @Description Este endpoint permite criar várias despesas de uma vez.
+// @Description No modo atomic (padrão) nenhuma despesa é criada caso algum item seja inválido, no modo partial os itens válidos são criados.
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param invoice body iservice.CreateBatchRequest true "Modelo de criação das despesas em lote"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} iservice.InvoiceBatchResponse
+// @Success 207 {object} iservice.InvoiceBatchResponse
+// @Failure 422 {object} iservice.InvoiceBatchResponse
+// @Router /v1/invoice/batch [post]
+func (h *handler) CreateBatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request iservice.CreateBatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	err = validateBatch(request.Mode, len(request.Items))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Invoice::StorageProcess::CreateBatch", "Create a batch of invoices", nil)
+	createBatchCtx := iservice.CreateBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.CreateBatch(createBatchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(getBatchStatusCode(response, http.StatusCreated), response)
+}
+
+// @Summary Editar Despesas em lote
+// @Description Este endpoint permite editar várias despesas de uma vez.
+// @Description No modo atomic (padrão) nenhuma despesa é editada caso algum item seja inválido, no modo partial os itens válidos são editados.
+// @Description Cada item deve informar a version obtida no ETag da consulta, o item alterado por outra requisição falha com version_conflict.
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param invoice body iservice.UpdateBatchRequest true "Modelo de edição das despesas em lote"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} iservice.InvoiceBatchResponse
+// @Success 207 {object} iservice.InvoiceBatchResponse
+// @Failure 422 {object} iservice.InvoiceBatchResponse
+// @Router /v1/invoice/batch [put]
+func (h *handler) UpdateBatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request iservice.UpdateBatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	err = validateBatch(request.Mode, len(request.Items))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Invoice::StorageProcess::UpdateBatch", "Update a batch of invoices", nil)
+	updateBatchCtx := iservice.UpdateBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.UpdateBatch(updateBatchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(getBatchStatusCode(response, http.StatusOK), response)
+}
+
+// @Summary Remover Despesas em lote
+// @Description Este endpoint permite remover várias despesas de uma vez.
+// @Description No modo atomic (padrão) nenhuma despesa é removida caso algum item seja inválido, no modo partial os itens válidos são removidos.
+// @Description Cada item deve informar a version obtida no ETag da consulta, o item alterado por outra requisição falha com version_conflict.
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param invoice body iservice.DeleteBatchRequest true "Modelo de remoção das despesas em lote"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} iservice.InvoiceBatchResponse
+// @Success 207 {object} iservice.InvoiceBatchResponse
+// @Failure 422 {object} iservice.InvoiceBatchResponse
+// @Router /v1/invoice/batch [delete]
+func (h *handler) DeleteBatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request iservice.DeleteBatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	err = validateBatch(request.Mode, len(request.Items))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Invoice::StorageProcess::DeleteBatch", "Delete a batch of invoices", nil)
+	deleteBatchCtx := iservice.DeleteBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.DeleteBatch(deleteBatchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(getBatchStatusCode(response, http.StatusOK), response)
+}
+
+// @Summary Reaplicar as regras de categorização nas Despesas
+// @Description Este endpoint permite aplicar as regras de categorização nas despesas já registradas no mês, escolhendo a categoria e o marcador conforme as regras atuais.
+// @Description Com o dry_run as alterações são apenas simuladas, sem alterar as despesas
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param applyRules body iservice.ApplyRulesRequest true "Modelo da reaplicação das regras"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} iservice.ApplyRulesResponse
+// @Failure 409 {object} problem.Problem
+// @Failure 422 {object} problem.Problem
+// @Failure 500 {object} problem.Problem
+// @Router /v1/invoice/apply-rules [post]
+func (h *handler) ApplyRules(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request iservice.ApplyRulesRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Invoice::StorageProcess::ApplyRules", "Apply the category rules to the invoices of the month", nil)
+	applyRulesCtx := iservice.ApplyRulesContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.ApplyRules(applyRulesCtx)
+	if errors.Is(err, iservice.ErrVersionConflict) {
+		c.Error(apperror.Conflict("An invoice of the month was modified by another request, try again"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary Obter uma listagem de Despesas
@@ -187,6 +508,7 @@ func (h *handler) Delete(c *gin.Context) {
 // @Param page query string false "A página que será buscada"
 // @Param month query string true "O mês que será filtrado a busca"
 // @Param year query string true "O ano que será filtrado a busca"
+// @Param currency query string false "A moeda (ISO 4217) para a qual os valores serão convertidos"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
 // @Success 200 {object} iservice.InvoicePaginateResponse
 // @Router /v1/invoice [get]
@@ -197,7 +519,7 @@ func (h *handler) GetAll(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	searchParams, err := validateAndGetSearchParams(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("Invoice::ReadingProcess::GetAllPaginated", "Get a invoice paginated", nil)
@@ -208,10 +530,69 @@ func (h *handler) GetAll(c *gin.Context) {
 	}
 	resultPaginated, err := h.readingProcess.GetAllPaginated(searchCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
 	span.End()
 	c.JSON(http.StatusOK, resultPaginated)
 }
+
+// @Summary Obter a lixeira de Despesas
+// @Description Este endpoint permite obter as despesas removidas que ainda podem ser restauradas
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} iservice.InvoiceTrashResponse
+// @Router /v1/invoice/trash [get]
+func (h *handler) GetTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	span := tx.StartSpan("Invoice::ReadingProcess::GetTrash", "Get the trashed invoice", nil)
+	searchCtx := iservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+	}
+	trash, err := h.readingProcess.GetTrash(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, trash)
+}
+
+// @Summary Restaurar uma Despesa
+// @Description Este endpoint permite restaurar uma despesa que está na lixeira
+// @Tags Invoice
+// @Accept json
+// @Produce json
+// @Param id path string true "Id da despesa"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} iservice.InvoiceResponse
+// @Router /v1/invoice/{id}/restore [post]
+func (h *handler) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	id := c.Param("id")
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	span := tx.StartSpan("Invoice::StorageProcess::Restore", "Restore a invoice", nil)
+	searchCtx := iservice.SearchContext{
+		Ctx:       ctx,
+		Id:        id,
+		UserToken: userToken,
+	}
+	invoiceRestored, err := h.storageProcess.Restore(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, invoiceRestored)
+}
@@ -2,23 +2,33 @@ package invoice
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoice/iservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 )
 
+const MAX_BATCH_SIZE = 500
+
 func validateAndGetSearchParams(c *gin.Context) (*iservice.SearchParams, error) {
 	month, _ := strconv.ParseUint(c.Query("month"), 10, 32)
 	year, _ := strconv.ParseUint(c.Query("year"), 10, 32)
 	page, _ := strconv.ParseUint(c.Query("page"), 10, 32)
 	pagesize, _ := strconv.ParseUint(c.Query("page_size"), 10, 32)
+	currency := strings.ToUpper(c.Query("currency"))
 
 	if month == uint64(0) || month > 12 {
-		return nil, &InvalidArgs{message: fmt.Sprintf("A param month %d is invalid", month)}
+		return nil, apperror.Validation(fmt.Sprintf("A param month %d is invalid", month))
 	}
 	if year == uint64(0) {
-		return nil, &InvalidArgs{message: fmt.Sprintf("A param year %d is invalid", year)}
+		return nil, apperror.Validation(fmt.Sprintf("A param year %d is invalid", year))
+	}
+	if currency != "" && !validation.IsCurrency(currency) {
+		return nil, apperror.Validation(fmt.Sprintf("A param currency %s is invalid", currency))
 	}
 	if page == uint64(0) {
 		page = uint64(1)
@@ -29,7 +39,31 @@ func validateAndGetSearchParams(c *gin.Context) (*iservice.SearchParams, error)
 	return iservice.NewSearchParamsBuilder().
 		AddMonth(uint(month)).
 		AddYear(uint(year)).
+		AddCurrency(currency).
 		AddPage(uint(page)).
 		AddPageSize(uint(pagesize)).
 		Build(), nil
 }
+
+func validateBatch(mode string, size int) error {
+	if mode != "" && mode != iservice.BATCH_MODE_ATOMIC && mode != iservice.BATCH_MODE_PARTIAL {
+		return apperror.Validation(fmt.Sprintf("A batch mode %s is invalid", mode))
+	}
+	if size == 0 {
+		return apperror.Validation("The batch must have at least one item")
+	}
+	if size > MAX_BATCH_SIZE {
+		return apperror.Validation(fmt.Sprintf("The batch must have at most %d items", MAX_BATCH_SIZE))
+	}
+	return nil
+}
+
+func getBatchStatusCode(response *iservice.InvoiceBatchResponse, successStatusCode int) int {
+	if response.Failed == 0 {
+		return successStatusCode
+	}
+	if response.Mode == iservice.BATCH_MODE_ATOMIC || response.Succeeded == 0 {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusMultiStatus
+}
@@ -1,10 +1,15 @@
 package iservice
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type SearchParamsBuilder struct {
 	month    *uint
 	year     *uint
+	currency string
 	page     *uint
 	pagesize *uint
 }
@@ -21,6 +26,10 @@ func (builder *SearchParamsBuilder) AddYear(year uint) *SearchParamsBuilder {
 	builder.year = &year
 	return builder
 }
+func (builder *SearchParamsBuilder) AddCurrency(currency string) *SearchParamsBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *SearchParamsBuilder) AddPage(page uint) *SearchParamsBuilder {
 	builder.page = &page
 	return builder
@@ -31,8 +40,9 @@ func (builder *SearchParamsBuilder) AddPageSize(pagesize uint) *SearchParamsBuil
 }
 func (builder *SearchParamsBuilder) Build() *SearchParams {
 	return &SearchParams{
-		month: builder.month,
-		year:  builder.year,
+		month:    builder.month,
+		year:     builder.year,
+		currency: builder.currency,
 		paginate: &Paginate{
 			page:     builder.page,
 			pagesize: builder.pagesize,
@@ -45,10 +55,13 @@ type InvoiceResponseBuilder struct {
 	payAt               time.Time
 	buyAt               time.Time
 	description         string
-	value               float64
+	value               money.Money
+	currency            string
 	invoiceProjectionId string
 	category            CategoryResponse
 	paymentType         PaymentTypeResponse
+	deletedAt           *time.Time
+	version             uint
 }
 
 func NewInvoiceResponseBuilder() *InvoiceResponseBuilder {
@@ -70,10 +83,14 @@ func (builder *InvoiceResponseBuilder) AddDescription(description string) *Invoi
 	builder.description = description
 	return builder
 }
-func (builder *InvoiceResponseBuilder) AddValue(value float64) *InvoiceResponseBuilder {
+func (builder *InvoiceResponseBuilder) AddValue(value money.Money) *InvoiceResponseBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *InvoiceResponseBuilder) AddCurrency(currency string) *InvoiceResponseBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *InvoiceResponseBuilder) AddPaymentType(paymentType PaymentTypeResponse) *InvoiceResponseBuilder {
 	builder.paymentType = paymentType
 	return builder
@@ -86,17 +103,28 @@ func (builder *InvoiceResponseBuilder) AddCategory(category CategoryResponse) *I
 	builder.category = category
 	return builder
 }
+func (builder *InvoiceResponseBuilder) AddDeletedAt(deletedAt time.Time) *InvoiceResponseBuilder {
+	builder.deletedAt = &deletedAt
+	return builder
+}
+func (builder *InvoiceResponseBuilder) AddVersion(version uint) *InvoiceResponseBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *InvoiceResponseBuilder) Build() *InvoiceResponse {
 	invoiceResponse := InvoiceResponse{}
 
 	invoiceResponse.Id = builder.id
 	invoiceResponse.Description = builder.description
 	invoiceResponse.Value = builder.value
+	invoiceResponse.Currency = builder.currency
 	invoiceResponse.BuyAt = builder.buyAt
 	invoiceResponse.PayAt = builder.payAt
 	invoiceResponse.PaymentType = builder.paymentType
 	invoiceResponse.InvoiceProjectionId = builder.invoiceProjectionId
 	invoiceResponse.Category = builder.category
+	invoiceResponse.DeletedAt = builder.deletedAt
+	invoiceResponse.Version = builder.version
 
 	return &invoiceResponse
 }
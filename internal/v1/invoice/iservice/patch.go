@@ -0,0 +1,54 @@
+package iservice
+
+import (
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoice/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+// apply copies only the fields informed in the request over the stored invoice
+func (request PatchRequest) apply(invoice *repository.Invoice) {
+	if request.PayAt != nil {
+		invoice.PayAt = *request.PayAt
+	}
+	if request.BuyAt != nil {
+		invoice.BuyAt = *request.BuyAt
+	}
+	if request.Description != nil {
+		invoice.Description = *request.Description
+	}
+	if request.Value != nil {
+		invoice.Value = *request.Value
+	}
+	if request.Currency != nil {
+		invoice.Currency = money.NormalizeCurrency(*request.Currency)
+	}
+	if request.CategoryId != nil {
+		invoice.Category = repository.InvoiceCategory{Id: *request.CategoryId}
+	}
+	if request.PaymentTypeId != nil {
+		invoice.PaymentType = repository.PaymentType{Id: *request.PaymentTypeId}
+	}
+}
+
+func validatePatchedInvoice(invoice repository.Invoice) error {
+	errs := validation.Errors{}
+	if strings.TrimSpace(invoice.Description) == "" {
+		errs = append(errs, validation.Required("description"))
+	}
+	if invoice.Value <= 0 {
+		errs = append(errs, validation.FieldError{Field: "value", Code: validation.CODE_TOO_SMALL, Message: "The value must be greater than 0"})
+	}
+	if invoice.Category.Id == 0 {
+		errs = append(errs, validation.Required("category_id"))
+	}
+	if invoice.PaymentType.Id == 0 {
+		errs = append(errs, validation.Required("payment_type_id"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
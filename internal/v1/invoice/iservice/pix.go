@@ -0,0 +1,66 @@
+package iservice
+
+import (
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/pix"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+// PAYMENT_TYPE_PIX is the payment type assumed for the invoices created from a PIX
+const PAYMENT_TYPE_PIX = 4
+
+// CreateFromPix registers the invoice paid through a PIX copia e cola, whose merchant is the receiver
+func (sp *storageProcess) CreateFromPix(createFromPixCtx CreateFromPixContext) (*InvoiceResponse, error) {
+	request := createFromPixCtx.Request
+	payment, err := pix.Parse(request.Code)
+	if err != nil {
+		return nil, validation.Errors{{Field: "code", Code: validation.CODE_INVALID, Message: "The code is not valid, " + err.Error()}}
+	}
+	createRequest := CreateRequest{
+		PayAt:         request.PayAt,
+		BuyAt:         request.BuyAt,
+		Description:   strings.TrimSpace(request.Description),
+		Value:         request.Value,
+		Currency:      money.CURRENCY_DEFAULT,
+		CategoryId:    request.CategoryId,
+		AutoCategory:  request.AutoCategory,
+		PaymentTypeId: request.PaymentTypeId,
+	}
+	if createRequest.Description == "" {
+		createRequest.Description = payment.MerchantName
+	}
+	if createRequest.Value == 0 {
+		createRequest.Value = payment.Amount
+	}
+	if createRequest.PaymentTypeId == 0 {
+		createRequest.PaymentTypeId = PAYMENT_TYPE_PIX
+	}
+	err = validatePixRequest(createRequest)
+	if err != nil {
+		return nil, err
+	}
+	return sp.Create(CreateContext{
+		Ctx:          createFromPixCtx.Ctx,
+		Request:      createRequest,
+		MerchantName: payment.MerchantName,
+		UserToken:    createFromPixCtx.UserToken,
+	})
+}
+
+// validatePixRequest requires the fields that neither the PIX nor the request informed,
+// the category is left to the category rules applied by Create
+func validatePixRequest(request CreateRequest) error {
+	errs := validation.Errors{}
+	if request.Description == "" {
+		errs = append(errs, validation.Required("description"))
+	}
+	if request.Value <= 0 {
+		errs = append(errs, validation.Required("value"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -1,21 +1,25 @@
 package iservice
 
 import (
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoice/repository"
 )
 
 type ReadingProcess interface {
 	GetById(searchCtx SearchContext) (*InvoiceResponse, error)
 	GetAllPaginated(searchCtx SearchContext) (*InvoicePaginateResponse, error)
+	GetTrash(searchCtx SearchContext) (*InvoiceTrashResponse, error)
 }
 
 type readingProcess struct {
 	repository repository.Repository
+	converter  erservice.Converter
 }
 
-func NewReadingProcess(repository repository.Repository) ReadingProcess {
-	return &readingProcess{repository: repository}
+func NewReadingProcess(repository repository.Repository, converter erservice.Converter) ReadingProcess {
+	return &readingProcess{repository: repository, converter: converter}
 }
 
 func (rp *readingProcess) GetById(searchCtx SearchContext) (*InvoiceResponse, error) {
@@ -25,7 +29,7 @@ func (rp *readingProcess) GetById(searchCtx SearchContext) (*InvoiceResponse, er
 		return nil, err
 	}
 	if invoice == nil {
-		return nil, nil
+		return nil, apperror.NotFound("Invoice not found")
 	}
 
 	return NewInvoiceResponseBuilder().
@@ -34,9 +38,11 @@ func (rp *readingProcess) GetById(searchCtx SearchContext) (*InvoiceResponse, er
 		AddBuyAt(invoice.BuyAt).
 		AddDescription(invoice.Description).
 		AddValue(invoice.Value).
+		AddCurrency(invoice.Currency).
 		AddPaymentType(PaymentTypeResponse{Id: invoice.PaymentType.Id, Type: invoice.PaymentType.Type}).
 		AddCategory(CategoryResponse{Id: invoice.Category.Id, Category: invoice.Category.Category}).
 		AddInvoiceProjectionId(invoice.InvoiceProjectionId).
+		AddVersion(invoice.Version).
 		Build(), nil
 }
 
@@ -92,16 +98,59 @@ func (rp *readingProcess) GetAllPaginated(searchCtx SearchContext) (*InvoicePagi
 			AddPayAt(invoice.PayAt).
 			AddBuyAt(invoice.BuyAt).
 			AddValue(invoice.Value).
+			AddCurrency(invoice.Currency).
 			AddInvoiceProjectionId(invoice.InvoiceProjectionId).
 			Build()
+		if search.currency != "" {
+			convertedValue, err := rp.converter.Convert(erservice.ConvertContext{
+				Ctx:    searchCtx.Ctx,
+				UserId: user.Id,
+				Amount: invoice.Value,
+				From:   invoice.Currency,
+				To:     search.currency,
+				Date:   invoice.PayAt,
+			})
+			if err != nil {
+				return nil, err
+			}
+			invoiceResponse.ConvertedValue = &convertedValue
+		}
 		invoiceResponseList = append(invoiceResponseList, *invoiceResponse)
 	}
 
 	return &InvoicePaginateResponse{
-		CurrentPage:  *search.paginate.page,
-		PageLimit:    *search.paginate.pagesize,
-		TotalRecords: *totalRecords,
-		TotalPages:   totalPages,
-		Records:      invoiceResponseList,
+		CurrentPage:       *search.paginate.page,
+		PageLimit:         *search.paginate.pagesize,
+		TotalRecords:      *totalRecords,
+		TotalPages:        totalPages,
+		ConvertedCurrency: search.currency,
+		Records:           invoiceResponseList,
 	}, nil
 }
+
+func (rp *readingProcess) GetTrash(searchCtx SearchContext) (*InvoiceTrashResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	invoiceList, err := rp.repository.GetTrash(searchCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceResponseList := []InvoiceResponse{}
+	for _, invoice := range *invoiceList {
+		invoiceResponse := NewInvoiceResponseBuilder().
+			AddId(invoice.Id).
+			AddPayAt(invoice.PayAt).
+			AddBuyAt(invoice.BuyAt).
+			AddDescription(invoice.Description).
+			AddValue(invoice.Value).
+			AddCurrency(invoice.Currency).
+			AddPaymentType(PaymentTypeResponse{Id: invoice.PaymentType.Id, Type: invoice.PaymentType.Type}).
+			AddCategory(CategoryResponse{Id: invoice.Category.Id, Category: invoice.Category.Category}).
+			AddInvoiceProjectionId(invoice.InvoiceProjectionId).
+			AddDeletedAt(invoice.DeletedAt).
+			Build()
+		invoiceResponseList = append(invoiceResponseList, *invoiceResponse)
+	}
+
+	return &InvoiceTrashResponse{Records: invoiceResponseList}, nil
+}
@@ -0,0 +1,138 @@
+package iservice
+
+import (
+	"context"
+
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoice/repository"
+)
+
+// applyRules fills the category of the request from the category rules of the user,
+// the category informed in the request takes precedence. It returns the label chosen by the rules.
+func (sp *storageProcess) applyRules(ctx context.Context, userId string, request *CreateRequest, merchantName string) (string, error) {
+	categorization, err := sp.categorizer.Categorize(crservice.CategorizeContext{
+		Ctx:           ctx,
+		UserId:        userId,
+		Entity:        crservice.ENTITY_INVOICE,
+		Description:   request.Description,
+		MerchantName:  merchantName,
+		Value:         request.Value,
+		PaymentTypeId: request.PaymentTypeId,
+	})
+	if err != nil || categorization == nil {
+		return "", err
+	}
+	if request.CategoryId == 0 && categorization.CategoryId != nil {
+		request.CategoryId = *categorization.CategoryId
+	}
+	return categorization.Label, nil
+}
+
+func (sp *storageProcess) label(ctx context.Context, userId string, invoiceId string, label string) error {
+	if label == "" {
+		return nil
+	}
+	return sp.categorizer.Label(crservice.LabelContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Entity:   crservice.ENTITY_INVOICE,
+		EntityId: invoiceId,
+		Label:    label,
+	})
+}
+
+// ApplyRules runs the category rules over the invoices of the month, in which the rules take precedence over
+// the stored category. The changed invoices are edited in a single transaction unless it is a dry run.
+func (sp *storageProcess) ApplyRules(applyRulesCtx ApplyRulesContext) (*ApplyRulesResponse, error) {
+	request := applyRulesCtx.Request
+	user := idpauth.GetUser(applyRulesCtx.UserToken)
+	queryParams := repository.NewQueryParamsBuilder().
+		AddUserId(user.Id).
+		AddMonth(request.Month).
+		AddYear(request.Year).
+		Build()
+	totalRecords, err := sp.repository.GetTotalRecords(applyRulesCtx.Ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	invoiceList, err := sp.repository.GetAll(applyRulesCtx.Ctx, repository.NewQueryParamsBuilder().
+		AddUserId(user.Id).
+		AddMonth(request.Month).
+		AddYear(request.Year).
+		AddLimit(*totalRecords).
+		Build())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApplyRulesResponse{DryRun: request.DryRun, Results: []ApplyRulesResult{}}
+	originalInvoices := []repository.Invoice{}
+	changedInvoices := []repository.Invoice{}
+	for _, invoice := range *invoiceList {
+		categorization, err := sp.categorizer.Categorize(crservice.CategorizeContext{
+			Ctx:           applyRulesCtx.Ctx,
+			UserId:        user.Id,
+			Entity:        crservice.ENTITY_INVOICE,
+			Description:   invoice.Description,
+			Value:         invoice.Value,
+			PaymentTypeId: invoice.PaymentType.Id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if categorization == nil {
+			continue
+		}
+		result := ApplyRulesResult{
+			Id:               invoice.Id,
+			Description:      invoice.Description,
+			CategoryIdBefore: invoice.Category.Id,
+			CategoryIdAfter:  invoice.Category.Id,
+			Label:            categorization.Label,
+		}
+		if categorization.CategoryId != nil {
+			result.CategoryIdAfter = *categorization.CategoryId
+		}
+		result.Changed = result.CategoryIdAfter != result.CategoryIdBefore
+		response.Matched++
+		if result.Changed {
+			response.Changed++
+			changedInvoice := invoice
+			changedInvoice.UserId = user.Id
+			changedInvoice.Category = repository.InvoiceCategory{Id: result.CategoryIdAfter}
+			originalInvoices = append(originalInvoices, invoice)
+			changedInvoices = append(changedInvoices, changedInvoice)
+		}
+		response.Results = append(response.Results, result)
+	}
+	if request.DryRun {
+		return response, nil
+	}
+
+	err = sp.transactor.Within(applyRulesCtx.Ctx, func(ctx context.Context) error {
+		if len(changedInvoices) > 0 {
+			err := sp.repository.EditAll(ctx, changedInvoices)
+			if err != nil {
+				return err
+			}
+		}
+		for index := range changedInvoices {
+			_, err := sp.recordUpdate(ctx, user.Id, sp.buildResponse(&originalInvoices[index]))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range response.Results {
+		err = sp.label(applyRulesCtx.Ctx, user.Id, result.Id, result.Label)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
@@ -1,69 +1,106 @@
 package iservice
 
 import (
+	"context"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion/csservice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoice/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	uuid "github.com/satori/go.uuid"
 )
 
 type StorageProcess interface {
 	Create(createCtx CreateContext) (*InvoiceResponse, error)
+	CreateFromPix(createFromPixCtx CreateFromPixContext) (*InvoiceResponse, error)
 	Update(updateCtx UpdateContext) (*InvoiceResponse, error)
-	Delete(searchCtx SearchContext) error
+	Patch(patchCtx PatchContext) (*InvoiceResponse, error)
+	Delete(deleteCtx DeleteContext) error
+	Restore(searchCtx SearchContext) (*InvoiceResponse, error)
+	CreateBatch(createBatchCtx CreateBatchContext) (*InvoiceBatchResponse, error)
+	UpdateBatch(updateBatchCtx UpdateBatchContext) (*InvoiceBatchResponse, error)
+	DeleteBatch(deleteBatchCtx DeleteBatchContext) (*InvoiceBatchResponse, error)
+	ApplyRules(applyRulesCtx ApplyRulesContext) (*ApplyRulesResponse, error)
 }
 
 type storageProcess struct {
-	repository   repository.Repository
-	generateUUID func() uuid.UUID
+	repository       repository.Repository
+	generateUUID     func() uuid.UUID
+	auditProcess     aservice.StorageProcess
+	referenceChecker validation.ReferenceChecker
+	categorizer      crservice.Categorizer
+	suggester        csservice.Suggester
+	transactor       dbtx.Transactor
 }
 
-func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID) StorageProcess {
-	return &storageProcess{repository: repository, generateUUID: generateUUID}
+func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID, auditProcess aservice.StorageProcess, referenceChecker validation.ReferenceChecker, categorizer crservice.Categorizer, suggester csservice.Suggester, transactor dbtx.Transactor) StorageProcess {
+	return &storageProcess{repository: repository, generateUUID: generateUUID, auditProcess: auditProcess, referenceChecker: referenceChecker, categorizer: categorizer, suggester: suggester, transactor: transactor}
 }
 
 func (sp *storageProcess) Create(createCtx CreateContext) (*InvoiceResponse, error) {
 	request := createCtx.Request
 	user := idpauth.GetUser(createCtx.UserToken)
-	createdAt := time.Now()
-	invoiceBuilder := repository.NewInvoiceBuilder().
-		AddId(sp.generateUUID().String()).
-		AddCreatedAt(createdAt).
-		AddPayAt(request.PayAt).
-		AddBuyAt(request.BuyAt).
-		AddPaymentType(repository.PaymentType{Id: request.PaymentTypeId}).
-		AddCategory(repository.InvoiceCategory{Id: request.CategoryId}).
-		AddDescription(request.Description).
-		AddValue(request.Value).
-		AddUserId(user.Id)
-	if request.PayAt.IsZero() {
-		request.PayAt = createdAt
-		invoiceBuilder.AddPayAt(request.PayAt)
+	label, err := sp.applyRules(createCtx.Ctx, user.Id, &request, createCtx.MerchantName)
+	if err != nil {
+		return nil, err
 	}
-	if request.BuyAt.IsZero() {
-		invoiceBuilder.AddBuyAt(request.PayAt)
+	err = sp.suggestCategory(createCtx.Ctx, user.Id, &request)
+	if err != nil {
+		return nil, err
+	}
+	if request.CategoryId == 0 {
+		return nil, validation.Errors{validation.Required("category_id")}
 	}
-	invoice := invoiceBuilder.Build()
-	invoiceSaved, err := sp.repository.Save(createCtx.Ctx, *invoice)
+	err = sp.checkReferences(createCtx.Ctx, request.CategoryId, request.PaymentTypeId)
 	if err != nil {
 		return nil, err
 	}
+	invoice := sp.buildInvoice(request, time.Now(), user.Id)
+	var invoiceResponse *InvoiceResponse
+	err = sp.transactor.Within(createCtx.Ctx, func(ctx context.Context) error {
+		invoiceSaved, err := sp.repository.Save(ctx, *invoice)
+		if err != nil {
+			return err
+		}
+
+		invoiceSaved, err = sp.repository.GetById(ctx, invoiceSaved.Id, user.Id)
+		if err != nil {
+			return err
+		}
 
-	invoiceSaved, err = sp.repository.GetById(createCtx.Ctx, invoiceSaved.Id, user.Id)
+		invoiceResponse = NewInvoiceResponseBuilder().
+			AddId(invoice.Id).
+			AddPayAt(invoice.PayAt).
+			AddBuyAt(invoice.BuyAt).
+			AddDescription(invoice.Description).
+			AddValue(invoice.Value).
+			AddCurrency(invoice.Currency).
+			AddPaymentType(PaymentTypeResponse{Id: invoiceSaved.PaymentType.Id, Type: invoiceSaved.PaymentType.Type}).
+			AddCategory(CategoryResponse{Id: invoiceSaved.Category.Id, Category: invoiceSaved.Category.Category}).
+			Build()
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_CREATE,
+			Entity:   aservice.ENTITY_INVOICE,
+			EntityId: invoiceResponse.Id,
+			After:    invoiceResponse,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	return NewInvoiceResponseBuilder().
-		AddId(invoice.Id).
-		AddPayAt(invoice.PayAt).
-		AddBuyAt(invoice.BuyAt).
-		AddDescription(invoice.Description).
-		AddValue(invoice.Value).
-		AddPaymentType(PaymentTypeResponse{Id: invoiceSaved.PaymentType.Id, Type: invoiceSaved.PaymentType.Type}).
-		AddCategory(CategoryResponse{Id: invoiceSaved.Category.Id, Category: invoiceSaved.Category.Category}).
-		Build(), nil
+	err = sp.label(createCtx.Ctx, user.Id, invoiceResponse.Id, label)
+	if err != nil {
+		return nil, err
+	}
+	return invoiceResponse, nil
 }
 
 func (sp *storageProcess) Update(updateCtx UpdateContext) (*InvoiceResponse, error) {
@@ -76,37 +113,517 @@ func (sp *storageProcess) Update(updateCtx UpdateContext) (*InvoiceResponse, err
 		AddPaymentType(repository.PaymentType{Id: request.PaymentTypeId}).
 		AddCategory(repository.InvoiceCategory{Id: request.CategoryId}).
 		AddDescription(request.Description).
-		AddValue(request.Value)
+		AddValue(request.Value).
+		AddCurrency(money.NormalizeCurrency(request.Currency))
 	invoiceExists, err := sp.repository.GetById(updateCtx.Ctx, updateCtx.Id, user.Id)
 	if err != nil {
 		return nil, err
 	}
 	if invoiceExists == nil {
-		return nil, nil
+		return nil, apperror.NotFound("Invoice not found")
+	}
+	err = sp.checkReferences(updateCtx.Ctx, request.CategoryId, request.PaymentTypeId)
+	if err != nil {
+		return nil, err
 	}
 	invoiceBuilder.AddUserId(user.Id)
-	invoiceUpdated, err := sp.repository.Edit(updateCtx.Ctx, *invoiceBuilder.Build())
+	invoiceBuilder.AddVersion(updateCtx.Version)
+	var invoiceResponse *InvoiceResponse
+	err = sp.transactor.Within(updateCtx.Ctx, func(ctx context.Context) error {
+		invoiceUpdated, err := sp.repository.Edit(ctx, *invoiceBuilder.Build())
+		if err != nil {
+			return err
+		}
+
+		invoiceUpdated, err = sp.repository.GetById(ctx, invoiceUpdated.Id, user.Id)
+		if err != nil {
+			return err
+		}
+
+		invoiceResponse = NewInvoiceResponseBuilder().
+			AddId(invoiceUpdated.Id).
+			AddPayAt(invoiceUpdated.PayAt).
+			AddBuyAt(invoiceExists.BuyAt).
+			AddDescription(invoiceUpdated.Description).
+			AddValue(invoiceUpdated.Value).
+			AddCurrency(invoiceUpdated.Currency).
+			AddPaymentType(PaymentTypeResponse{Id: invoiceUpdated.PaymentType.Id, Type: invoiceUpdated.PaymentType.Type}).
+			AddCategory(CategoryResponse{Id: invoiceUpdated.Category.Id, Category: invoiceUpdated.Category.Category}).
+			AddVersion(invoiceUpdated.Version).
+			Build()
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_INVOICE,
+			EntityId: invoiceResponse.Id,
+			Before:   sp.buildResponse(invoiceExists),
+			After:    invoiceResponse,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
+	return invoiceResponse, nil
+}
 
-	invoiceUpdated, err = sp.repository.GetById(updateCtx.Ctx, invoiceUpdated.Id, user.Id)
+func (sp *storageProcess) Patch(patchCtx PatchContext) (*InvoiceResponse, error) {
+	user := idpauth.GetUser(patchCtx.UserToken)
+	invoiceExists, err := sp.repository.GetById(patchCtx.Ctx, patchCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if invoiceExists == nil {
+		return nil, apperror.NotFound("Invoice not found")
+	}
+	invoice := *invoiceExists
+	patchCtx.Request.apply(&invoice)
+	err = validatePatchedInvoice(invoice)
+	if err != nil {
+		return nil, err
+	}
+	err = sp.checkReferences(patchCtx.Ctx, invoice.Category.Id, invoice.PaymentType.Id)
 	if err != nil {
 		return nil, err
 	}
+	invoice.UserId = user.Id
+	invoice.Version = patchCtx.Version
+	var invoiceResponse *InvoiceResponse
+	err = sp.transactor.Within(patchCtx.Ctx, func(ctx context.Context) error {
+		invoiceUpdated, err := sp.repository.Edit(ctx, invoice)
+		if err != nil {
+			return err
+		}
 
-	return NewInvoiceResponseBuilder().
-		AddId(invoiceUpdated.Id).
-		AddPayAt(invoiceUpdated.PayAt).
-		AddBuyAt(invoiceExists.BuyAt).
-		AddDescription(invoiceUpdated.Description).
-		AddValue(invoiceUpdated.Value).
-		AddPaymentType(PaymentTypeResponse{Id: invoiceUpdated.PaymentType.Id, Type: invoiceUpdated.PaymentType.Type}).
-		AddCategory(CategoryResponse{Id: invoiceUpdated.Category.Id, Category: invoiceUpdated.Category.Category}).
-		Build(), nil
+		invoiceUpdated, err = sp.repository.GetById(ctx, invoiceUpdated.Id, user.Id)
+		if err != nil {
+			return err
+		}
+
+		invoiceResponse = sp.buildResponse(invoiceUpdated)
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_INVOICE,
+			EntityId: invoiceResponse.Id,
+			Before:   sp.buildResponse(invoiceExists),
+			After:    invoiceResponse,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoiceResponse, nil
 }
 
-func (sp *storageProcess) Delete(searchCtx SearchContext) error {
+func (sp *storageProcess) Delete(deleteCtx DeleteContext) error {
+	user := idpauth.GetUser(deleteCtx.UserToken)
+	invoiceExists, err := sp.repository.GetById(deleteCtx.Ctx, deleteCtx.Id, user.Id)
+	if err != nil {
+		return err
+	}
+	if invoiceExists == nil {
+		return apperror.NotFound("Invoice not found")
+	}
+	return sp.transactor.Within(deleteCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.Remove(ctx, deleteCtx.Id, user.Id, deleteCtx.Version)
+		if err != nil {
+			return err
+		}
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_DELETE,
+			Entity:   aservice.ENTITY_INVOICE,
+			EntityId: invoiceExists.Id,
+			Before:   sp.buildResponse(invoiceExists),
+		})
+	})
+}
+
+func (sp *storageProcess) Restore(searchCtx SearchContext) (*InvoiceResponse, error) {
 	user := idpauth.GetUser(searchCtx.UserToken)
-	return sp.repository.Remove(searchCtx.Ctx, searchCtx.Id, user.Id)
+	var invoiceResponse *InvoiceResponse
+	err := sp.transactor.Within(searchCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.Restore(ctx, searchCtx.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		invoiceRestored, err := sp.repository.GetById(ctx, searchCtx.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		if invoiceRestored == nil {
+			return apperror.NotFound("Invoice not found")
+		}
+
+		invoiceResponse = sp.buildResponse(invoiceRestored)
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_RESTORE,
+			Entity:   aservice.ENTITY_INVOICE,
+			EntityId: invoiceResponse.Id,
+			After:    invoiceResponse,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoiceResponse, nil
+}
+
+func (sp *storageProcess) CreateBatch(createBatchCtx CreateBatchContext) (*InvoiceBatchResponse, error) {
+	request := createBatchCtx.Request
+	user := idpauth.GetUser(createBatchCtx.UserToken)
+	createdAt := time.Now()
+	batch := newInvoiceBatch(request.Mode, len(request.Items))
+
+	pending := []int{}
+	invoices := []repository.Invoice{}
+	labels := make([]string, len(request.Items))
+	for index, item := range request.Items {
+		label, err := sp.applyRules(createBatchCtx.Ctx, user.Id, &item, "")
+		if err != nil {
+			return nil, err
+		}
+		labels[index] = label
+		err = sp.suggestCategory(createBatchCtx.Ctx, user.Id, &item)
+		if err != nil {
+			return nil, err
+		}
+		itemErr, err := sp.validateItem(createBatchCtx.Ctx, item.PayAt, item.BuyAt, item.Description, item.Value, item.Currency, item.CategoryId, item.PaymentTypeId)
+		if err != nil {
+			return nil, err
+		}
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		invoice := sp.buildInvoice(item, createdAt, user.Id)
+		batch.results[index].Id = invoice.Id
+		pending = append(pending, index)
+		invoices = append(invoices, *invoice)
+	}
+	invoicesCreated := make([]*InvoiceResponse, len(invoices))
+	err := batch.persist(pending,
+		func() error {
+			return sp.transactor.Within(createBatchCtx.Ctx, func(ctx context.Context) error {
+				err := sp.repository.SaveAll(ctx, invoices)
+				if err != nil {
+					return err
+				}
+				for position := range invoices {
+					invoicesCreated[position], err = sp.recordCreation(ctx, user.Id, invoices[position].Id)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		},
+		func(position int) error {
+			return sp.transactor.Within(createBatchCtx.Ctx, func(ctx context.Context) error {
+				_, err := sp.repository.Save(ctx, invoices[position])
+				if err != nil {
+					return err
+				}
+				invoicesCreated[position], err = sp.recordCreation(ctx, user.Id, invoices[position].Id)
+				return err
+			})
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for position, index := range pending {
+		if batch.results[index].Status != "" {
+			continue
+		}
+		err = sp.label(createBatchCtx.Ctx, user.Id, invoicesCreated[position].Id, labels[index])
+		if err != nil {
+			return nil, err
+		}
+		batch.succeed(index, BATCH_STATUS_CREATED, invoicesCreated[position])
+	}
+	return batch.response(), nil
+}
+
+func (sp *storageProcess) UpdateBatch(updateBatchCtx UpdateBatchContext) (*InvoiceBatchResponse, error) {
+	request := updateBatchCtx.Request
+	user := idpauth.GetUser(updateBatchCtx.UserToken)
+	batch := newInvoiceBatch(request.Mode, len(request.Items))
+
+	pending := []int{}
+	invoices := []repository.Invoice{}
+	invoicesBefore := []*InvoiceResponse{}
+	for index, item := range request.Items {
+		batch.results[index].Id = item.Id
+		itemErr := batch.checkId(item.Id)
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		itemErr, err := sp.validateItem(updateBatchCtx.Ctx, item.PayAt, item.BuyAt, item.Description, item.Value, item.Currency, item.CategoryId, item.PaymentTypeId)
+		if err != nil {
+			return nil, err
+		}
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		invoiceExists, err := sp.repository.GetById(updateBatchCtx.Ctx, item.Id, user.Id)
+		if err != nil {
+			return nil, err
+		}
+		if invoiceExists == nil {
+			batch.fail(index, &BatchItemError{Code: BATCH_ERROR_NOT_FOUND, Message: "Invoice not found"})
+			continue
+		}
+		itemErr = checkVersion(item.Version, invoiceExists.Version)
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		invoice := repository.NewInvoiceBuilder().
+			AddId(item.Id).
+			AddPayAt(item.PayAt).
+			AddBuyAt(item.BuyAt).
+			AddPaymentType(repository.PaymentType{Id: item.PaymentTypeId}).
+			AddCategory(repository.InvoiceCategory{Id: item.CategoryId}).
+			AddDescription(item.Description).
+			AddValue(item.Value).
+			AddCurrency(money.NormalizeCurrency(item.Currency)).
+			AddUserId(user.Id).
+			AddVersion(item.Version).
+			Build()
+		pending = append(pending, index)
+		invoices = append(invoices, *invoice)
+		invoicesBefore = append(invoicesBefore, sp.buildResponse(invoiceExists))
+	}
+	invoicesUpdated := make([]*InvoiceResponse, len(invoices))
+	err := batch.persist(pending,
+		func() error {
+			return sp.transactor.Within(updateBatchCtx.Ctx, func(ctx context.Context) error {
+				err := sp.repository.EditAll(ctx, invoices)
+				if err != nil {
+					return err
+				}
+				for position := range invoices {
+					invoicesUpdated[position], err = sp.recordUpdate(ctx, user.Id, invoicesBefore[position])
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		},
+		func(position int) error {
+			return sp.transactor.Within(updateBatchCtx.Ctx, func(ctx context.Context) error {
+				_, err := sp.repository.Edit(ctx, invoices[position])
+				if err != nil {
+					return err
+				}
+				invoicesUpdated[position], err = sp.recordUpdate(ctx, user.Id, invoicesBefore[position])
+				return err
+			})
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for position, index := range pending {
+		if batch.results[index].Status != "" {
+			continue
+		}
+		batch.succeed(index, BATCH_STATUS_UPDATED, invoicesUpdated[position])
+	}
+	return batch.response(), nil
+}
+
+func (sp *storageProcess) DeleteBatch(deleteBatchCtx DeleteBatchContext) (*InvoiceBatchResponse, error) {
+	request := deleteBatchCtx.Request
+	user := idpauth.GetUser(deleteBatchCtx.UserToken)
+	batch := newInvoiceBatch(request.Mode, len(request.Items))
+
+	pending := []int{}
+	ids := []string{}
+	versions := []uint{}
+	invoicesBefore := []*InvoiceResponse{}
+	for index, item := range request.Items {
+		batch.results[index].Id = item.Id
+		itemErr := batch.checkId(item.Id)
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		invoiceExists, err := sp.repository.GetById(deleteBatchCtx.Ctx, item.Id, user.Id)
+		if err != nil {
+			return nil, err
+		}
+		if invoiceExists == nil {
+			batch.fail(index, &BatchItemError{Code: BATCH_ERROR_NOT_FOUND, Message: "Invoice not found"})
+			continue
+		}
+		itemErr = checkVersion(item.Version, invoiceExists.Version)
+		if itemErr != nil {
+			batch.fail(index, itemErr)
+			continue
+		}
+		pending = append(pending, index)
+		ids = append(ids, item.Id)
+		versions = append(versions, item.Version)
+		invoicesBefore = append(invoicesBefore, sp.buildResponse(invoiceExists))
+	}
+	err := batch.persist(pending,
+		func() error {
+			return sp.transactor.Within(deleteBatchCtx.Ctx, func(ctx context.Context) error {
+				err := sp.repository.RemoveAll(ctx, ids, versions, user.Id)
+				if err != nil {
+					return err
+				}
+				for position := range ids {
+					err = sp.recordDeletion(ctx, user.Id, invoicesBefore[position])
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		},
+		func(position int) error {
+			return sp.transactor.Within(deleteBatchCtx.Ctx, func(ctx context.Context) error {
+				err := sp.repository.Remove(ctx, ids[position], user.Id, versions[position])
+				if err != nil {
+					return err
+				}
+				return sp.recordDeletion(ctx, user.Id, invoicesBefore[position])
+			})
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range pending {
+		if batch.results[index].Status != "" {
+			continue
+		}
+		batch.succeed(index, BATCH_STATUS_DELETED, nil)
+	}
+	return batch.response(), nil
+}
+
+// recordCreation reads the invoice stored in the transaction of the context and records its creation
+func (sp *storageProcess) recordCreation(ctx context.Context, userId string, id string) (*InvoiceResponse, error) {
+	invoiceSaved, err := sp.repository.GetById(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	invoiceResponse := sp.buildResponse(invoiceSaved)
+	err = sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_CREATE,
+		Entity:   aservice.ENTITY_INVOICE,
+		EntityId: invoiceResponse.Id,
+		After:    invoiceResponse,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoiceResponse, nil
+}
+
+// recordUpdate reads the invoice edited in the transaction of the context and records its update
+func (sp *storageProcess) recordUpdate(ctx context.Context, userId string, invoiceBefore *InvoiceResponse) (*InvoiceResponse, error) {
+	invoiceUpdated, err := sp.repository.GetById(ctx, invoiceBefore.Id, userId)
+	if err != nil {
+		return nil, err
+	}
+	invoiceResponse := sp.buildResponse(invoiceUpdated)
+	err = sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_UPDATE,
+		Entity:   aservice.ENTITY_INVOICE,
+		EntityId: invoiceResponse.Id,
+		Before:   invoiceBefore,
+		After:    invoiceResponse,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoiceResponse, nil
+}
+
+func (sp *storageProcess) recordDeletion(ctx context.Context, userId string, invoiceBefore *InvoiceResponse) error {
+	return sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_DELETE,
+		Entity:   aservice.ENTITY_INVOICE,
+		EntityId: invoiceBefore.Id,
+		Before:   invoiceBefore,
+	})
+}
+
+func (sp *storageProcess) buildInvoice(request CreateRequest, createdAt time.Time, userId string) *repository.Invoice {
+	invoiceBuilder := repository.NewInvoiceBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(createdAt).
+		AddPayAt(request.PayAt).
+		AddBuyAt(request.BuyAt).
+		AddPaymentType(repository.PaymentType{Id: request.PaymentTypeId}).
+		AddCategory(repository.InvoiceCategory{Id: request.CategoryId}).
+		AddDescription(request.Description).
+		AddValue(request.Value).
+		AddCurrency(money.NormalizeCurrency(request.Currency)).
+		AddUserId(userId)
+	if request.PayAt.IsZero() {
+		request.PayAt = createdAt
+		invoiceBuilder.AddPayAt(request.PayAt)
+	}
+	if request.BuyAt.IsZero() {
+		invoiceBuilder.AddBuyAt(request.PayAt)
+	}
+	return invoiceBuilder.Build()
+}
+
+// checkReferences reports as validation errors the category and payment type that are not registered
+func (sp *storageProcess) checkReferences(ctx context.Context, categoryId uint, paymentTypeId uint) error {
+	errs := validation.Errors{}
+	categoryExists, err := sp.referenceChecker.InvoiceCategoryExists(ctx, categoryId)
+	if err != nil {
+		return err
+	}
+	if !categoryExists {
+		errs = append(errs, validation.NotFound("category_id"))
+	}
+	paymentTypeExists, err := sp.referenceChecker.PaymentTypeExists(ctx, paymentTypeId)
+	if err != nil {
+		return err
+	}
+	if !paymentTypeExists {
+		errs = append(errs, validation.NotFound("payment_type_id"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (sp *storageProcess) buildResponse(invoice *repository.Invoice) *InvoiceResponse {
+	return NewInvoiceResponseBuilder().
+		AddId(invoice.Id).
+		AddPayAt(invoice.PayAt).
+		AddBuyAt(invoice.BuyAt).
+		AddDescription(invoice.Description).
+		AddValue(invoice.Value).
+		AddCurrency(invoice.Currency).
+		AddInvoiceProjectionId(invoice.InvoiceProjectionId).
+		AddPaymentType(PaymentTypeResponse{Id: invoice.PaymentType.Id, Type: invoice.PaymentType.Type}).
+		AddCategory(CategoryResponse{Id: invoice.Category.Id, Category: invoice.Category.Category}).
+		AddVersion(invoice.Version).
+		Build()
 }
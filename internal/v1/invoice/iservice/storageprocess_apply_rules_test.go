@@ -0,0 +1,173 @@
+package iservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoice/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+const applyRulesTokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+func buildApplyRulesRepositoryMock(invoices []repository.Invoice) *mockRepository {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetTotalRecordsCalls(func(ctx context.Context, params repository.QueryParams) (*uint, error) {
+		total := uint(len(invoices))
+		return &total, nil
+	})
+	_mockRepository.AddGetAllCalls(func(ctx context.Context, params repository.QueryParams) (*[]repository.Invoice, error) {
+		return &invoices, nil
+	})
+	return _mockRepository
+}
+
+func TestApplyRulesDryRun(t *testing.T) {
+	categoryId := uint(4)
+	_mockCategorizer := &mockCategorizer{categorization: &crservice.Categorization{CategoryId: &categoryId, Label: "transporte"}}
+	invoiceChanged := buildInvoiceMock("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	invoiceUnchanged := buildInvoiceMock("8a1e2d3c-4b5a-4f6e-9d8c-7b6a5f4e3d2c")
+	invoiceUnchanged.Category = repository.InvoiceCategory{Id: 4, Category: "Transporte"}
+	_mockRepository := buildApplyRulesRepositoryMock([]repository.Invoice{*invoiceChanged, *invoiceUnchanged})
+	_mockRepository.AddEditAllCall(func(ctx context.Context, invoices []repository.Invoice) error {
+		return errors.New("The dry run must not edit the invoices")
+	})
+	_storageProcess := NewStorageProcess(_mockRepository, nil, &mockAuditProcess{}, &mockReferenceChecker{}, _mockCategorizer, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.ApplyRules(ApplyRulesContext{
+		Ctx:       context.TODO(),
+		Request:   ApplyRulesRequest{Month: 3, Year: 2024, DryRun: true},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.DryRun)
+	assert.Equal(t, uint(2), response.Matched)
+	assert.Equal(t, uint(1), response.Changed)
+	assert.Equal(t, ApplyRulesResult{
+		Id:               "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628",
+		Description:      "Description teste",
+		CategoryIdBefore: 2,
+		CategoryIdAfter:  4,
+		Label:            "transporte",
+		Changed:          true,
+	}, response.Results[0])
+	assert.False(t, response.Results[1].Changed)
+	assert.Equal(t, money.FromCents(75050), _mockCategorizer.value)
+	assert.Equal(t, uint(2), _mockCategorizer.paymentTypeId)
+	assert.Empty(t, _mockCategorizer.labels)
+}
+
+func TestApplyRulesSuccess(t *testing.T) {
+	categoryId := uint(4)
+	_mockCategorizer := &mockCategorizer{categorization: &crservice.Categorization{CategoryId: &categoryId, Label: "transporte"}}
+	invoice := buildInvoiceMock("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	_mockRepository := buildApplyRulesRepositoryMock([]repository.Invoice{*invoice})
+	var invoicesEdited []repository.Invoice
+	_mockRepository.AddEditAllCall(func(ctx context.Context, invoices []repository.Invoice) error {
+		invoicesEdited = invoices
+		return nil
+	})
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Invoice, error) {
+		invoiceUpdated := invoicesEdited[0]
+		invoiceUpdated.Category.Category = "Transporte"
+		return &invoiceUpdated, nil
+	})
+	var recordCtx aservice.RecordContext
+	_mockAuditProcess := &mockAuditProcess{}
+	_mockAuditProcess.AddRecordCall(func(ctx aservice.RecordContext) error {
+		recordCtx = ctx
+		return nil
+	})
+	_storageProcess := NewStorageProcess(_mockRepository, nil, _mockAuditProcess, &mockReferenceChecker{}, _mockCategorizer, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.ApplyRules(ApplyRulesContext{
+		Ctx:       context.TODO(),
+		Request:   ApplyRulesRequest{Month: 3, Year: 2024},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), response.Changed)
+	assert.Equal(t, uint(4), invoicesEdited[0].Category.Id)
+	assert.Equal(t, "5832a502-bede-492d-8dc1-b13b32c30f29", invoicesEdited[0].UserId)
+	assert.Equal(t, aservice.ACTION_UPDATE, recordCtx.Action)
+	assert.Equal(t, uint(2), recordCtx.Before.(*InvoiceResponse).Category.Id)
+	assert.Equal(t, "Transporte", recordCtx.After.(*InvoiceResponse).Category.Category)
+	assert.Equal(t, []crservice.LabelContext{{
+		Ctx:      context.TODO(),
+		UserId:   "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Entity:   crservice.ENTITY_INVOICE,
+		EntityId: "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628",
+		Label:    "transporte",
+	}}, _mockCategorizer.labels)
+}
+
+func TestApplyRulesNoMatch(t *testing.T) {
+	_mockRepository := buildApplyRulesRepositoryMock([]repository.Invoice{*buildInvoiceMock("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")})
+	_storageProcess := NewStorageProcess(_mockRepository, nil, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.ApplyRules(ApplyRulesContext{
+		Ctx:       context.TODO(),
+		Request:   ApplyRulesRequest{Month: 3, Year: 2024},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &ApplyRulesResponse{Results: []ApplyRulesResult{}}, response)
+}
+
+func TestApplyRulesCategorizeFail(t *testing.T) {
+	_mockRepository := buildApplyRulesRepositoryMock([]repository.Invoice{*buildInvoiceMock("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")})
+	_mockCategorizer := &mockCategorizer{err: errors.New("An error has been ocurred")}
+	_storageProcess := NewStorageProcess(_mockRepository, nil, &mockAuditProcess{}, &mockReferenceChecker{}, _mockCategorizer, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.ApplyRules(ApplyRulesContext{
+		Ctx:       context.TODO(),
+		Request:   ApplyRulesRequest{Month: 3, Year: 2024},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestCreateWithCategoryRules(t *testing.T) {
+	categoryId := uint(4)
+	_mockCategorizer := &mockCategorizer{categorization: &crservice.Categorization{CategoryId: &categoryId, Label: "transporte"}}
+	var invoiceSaved repository.Invoice
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, invoice repository.Invoice) (*repository.Invoice, error) {
+		invoiceSaved = invoice
+		return &invoice, nil
+	})
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Invoice, error) {
+		return &invoiceSaved, nil
+	})
+	_storageProcess := NewStorageProcess(_mockRepository, pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, _mockCategorizer, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Uber *Trip", Value: money.FromCents(2350), PaymentTypeId: 3},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), invoiceSaved.Category.Id)
+	assert.Equal(t, uint(3), _mockCategorizer.paymentTypeId)
+	assert.Equal(t, "Uber *Trip", _mockCategorizer.description)
+	assert.Equal(t, response.Id, _mockCategorizer.labels[0].EntityId)
+	assert.Equal(t, "transporte", _mockCategorizer.labels[0].Label)
+}
+
+func TestCreateWithoutCategory(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{}, pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Uber *Trip", Value: money.FromCents(2350), PaymentTypeId: 3},
+		UserToken: applyRulesTokenMock,
+	})
+	assert.Equal(t, validation.Errors{validation.Required("category_id")}, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,117 @@
+package iservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule/crservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoice/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// pixCodeMock is a static PIX of R$ 25,90 from the merchant PADARIA PAO QUENTE
+const pixCodeMock = "00020126550014br.gov.bcb.pix0119padaria@example.com0210Pedido 123520458125303986540525.905802BR5918PADARIA PAO QUENTE6009SAO PAULO62100506PED12363042D78"
+
+// pixWithoutAmountMock is a static PIX in which the payer chooses the amount
+const pixWithoutAmountMock = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func buildPixRepositoryMock(saved *repository.Invoice) *mockRepository {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddSaveCall(func(ctx context.Context, invoice repository.Invoice) (*repository.Invoice, error) {
+		*saved = invoice
+		return &invoice, nil
+	})
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.Invoice, error) {
+		return saved, nil
+	})
+	return _mockRepository
+}
+
+func buildCreateFromPixContext(request CreateFromPixRequest) CreateFromPixContext {
+	return CreateFromPixContext{Ctx: context.TODO(), Request: request, UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"}
+}
+
+func pixUUIDMock() uuid.UUID {
+	return uuid.FromStringOrNil("cd1cc27b-28a1-47dc-ac76-70e8185e159d")
+}
+
+func TestCreateFromPixSuccess(t *testing.T) {
+	saved := &repository.Invoice{}
+	categoryId := uint(3)
+	_mockCategorizer := &mockCategorizer{categorization: &crservice.Categorization{CategoryId: &categoryId}}
+	_storageProcess := NewStorageProcess(buildPixRepositoryMock(saved), pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, _mockCategorizer, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.CreateFromPix(buildCreateFromPixContext(CreateFromPixRequest{Code: pixCodeMock}))
+	assert.NoError(t, err)
+	assert.Equal(t, "cd1cc27b-28a1-47dc-ac76-70e8185e159d", response.Id)
+	assert.Equal(t, "PADARIA PAO QUENTE", saved.Description)
+	assert.Equal(t, money.FromCents(2590), saved.Value)
+	assert.Equal(t, money.CURRENCY_DEFAULT, saved.Currency)
+	assert.Equal(t, uint(3), saved.Category.Id)
+	assert.Equal(t, uint(PAYMENT_TYPE_PIX), saved.PaymentType.Id)
+	assert.False(t, saved.PayAt.IsZero())
+	assert.Equal(t, saved.PayAt, saved.BuyAt)
+	assert.Equal(t, "PADARIA PAO QUENTE", _mockCategorizer.description)
+	assert.Equal(t, crservice.ENTITY_INVOICE, _mockCategorizer.entity)
+}
+
+func TestCreateFromPixInformedFieldsSuccess(t *testing.T) {
+	saved := &repository.Invoice{}
+	categoryId := uint(5)
+	_mockCategorizer := &mockCategorizer{categorization: &crservice.Categorization{CategoryId: &categoryId}}
+	_storageProcess := NewStorageProcess(buildPixRepositoryMock(saved), pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, _mockCategorizer, &mockSuggester{}, &mockTransactor{})
+
+	request := CreateFromPixRequest{
+		Code:          pixWithoutAmountMock,
+		Description:   "Conserto do carro",
+		Value:         money.FromCents(120000),
+		CategoryId:    2,
+		PaymentTypeId: 3,
+	}
+	_, err := _storageProcess.CreateFromPix(buildCreateFromPixContext(request))
+	assert.NoError(t, err)
+	assert.Equal(t, "Conserto do carro", saved.Description)
+	assert.Equal(t, money.FromCents(120000), saved.Value)
+	assert.Equal(t, uint(3), saved.PaymentType.Id)
+	assert.Equal(t, uint(2), saved.Category.Id)
+	assert.Equal(t, crservice.ENTITY_INVOICE, _mockCategorizer.entity)
+	assert.Equal(t, "Fulano de Tal", _mockCategorizer.merchantName)
+}
+
+func TestCreateFromPixMissingFields(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{}, pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.CreateFromPix(buildCreateFromPixContext(CreateFromPixRequest{Code: pixWithoutAmountMock}))
+	assert.Equal(t, validation.Errors{validation.Required("value")}, err)
+	assert.Nil(t, response)
+}
+
+func TestCreateFromPixInvalidCode(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{}, pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.CreateFromPix(buildCreateFromPixContext(CreateFromPixRequest{Code: pixCodeMock[:len(pixCodeMock)-1] + "0"}))
+	expected := validation.Errors{{Field: "code", Code: validation.CODE_INVALID, Message: "The code is not valid, the CRC of the code does not match"}}
+	assert.Equal(t, expected, err)
+	assert.Nil(t, response)
+}
+
+func TestCreateFromPixCategorizeFail(t *testing.T) {
+	_mockCategorizer := &mockCategorizer{err: errors.New("An error has been ocurred")}
+	_storageProcess := NewStorageProcess(&mockRepository{}, pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, _mockCategorizer, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.CreateFromPix(buildCreateFromPixContext(CreateFromPixRequest{Code: pixCodeMock}))
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestCreateFromPixWithoutCategory(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{}, pixUUIDMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockCategorizer{}, &mockSuggester{}, &mockTransactor{})
+
+	response, err := _storageProcess.CreateFromPix(buildCreateFromPixContext(CreateFromPixRequest{Code: pixCodeMock}))
+	assert.Equal(t, validation.Errors{validation.Required("category_id")}, err)
+	assert.Nil(t, response)
+}
@@ -3,12 +3,50 @@ package iservice
 import (
 	"context"
 	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoice/repository"
 )
 
+// ErrVersionConflict is returned when the version informed by the client is not the current one of the record
+var ErrVersionConflict = repository.ErrVersionConflict
+
+const (
+	BATCH_MODE_ATOMIC  = "atomic"
+	BATCH_MODE_PARTIAL = "partial"
+)
+
+const (
+	BATCH_STATUS_CREATED = "created"
+	BATCH_STATUS_UPDATED = "updated"
+	BATCH_STATUS_DELETED = "deleted"
+	BATCH_STATUS_FAILED  = "failed"
+	BATCH_STATUS_SKIPPED = "skipped"
+)
+
+const (
+	BATCH_ERROR_INVALID_ID           = "invalid_id"
+	BATCH_ERROR_DUPLICATED_ID        = "duplicated_id"
+	BATCH_ERROR_INVALID_PAY_AT       = "invalid_pay_at"
+	BATCH_ERROR_INVALID_BUY_AT       = "invalid_buy_at"
+	BATCH_ERROR_INVALID_DESCRIPTION  = "invalid_description"
+	BATCH_ERROR_INVALID_VALUE        = "invalid_value"
+	BATCH_ERROR_INVALID_CURRENCY     = "invalid_currency"
+	BATCH_ERROR_INVALID_CATEGORY     = "invalid_category"
+	BATCH_ERROR_INVALID_PAYMENT_TYPE = "invalid_payment_type"
+	BATCH_ERROR_INVALID_VERSION      = "invalid_version"
+	BATCH_ERROR_NOT_FOUND            = "not_found"
+	BATCH_ERROR_VERSION_CONFLICT     = "version_conflict"
+	BATCH_ERROR_STORAGE              = "storage_error"
+)
+
+// CreateContext carries the merchant name of the PIX the invoice came from, matched by the category rules
+// whatever description is informed
 type CreateContext struct {
-	Ctx       context.Context
-	Request   CreateRequest
-	UserToken string
+	Ctx          context.Context
+	Request      CreateRequest
+	MerchantName string
+	UserToken    string
 }
 
 type UpdateContext struct {
@@ -16,6 +54,22 @@ type UpdateContext struct {
 	Request   UpdateRequest
 	UserToken string
 	Id        string
+	Version   uint
+}
+
+type PatchContext struct {
+	Ctx       context.Context
+	Request   PatchRequest
+	UserToken string
+	Id        string
+	Version   uint
+}
+
+type DeleteContext struct {
+	Ctx       context.Context
+	UserToken string
+	Id        string
+	Version   uint
 }
 
 type CreateInvoiceContext struct {
@@ -25,6 +79,30 @@ type CreateInvoiceContext struct {
 	Id        string
 }
 
+type CreateBatchContext struct {
+	Ctx       context.Context
+	Request   CreateBatchRequest
+	UserToken string
+}
+
+type UpdateBatchContext struct {
+	Ctx       context.Context
+	Request   UpdateBatchRequest
+	UserToken string
+}
+
+type DeleteBatchContext struct {
+	Ctx       context.Context
+	Request   DeleteBatchRequest
+	UserToken string
+}
+
+type CreateFromPixContext struct {
+	Ctx       context.Context
+	Request   CreateFromPixRequest
+	UserToken string
+}
+
 type SearchContext struct {
 	Ctx       context.Context
 	Params    SearchParams
@@ -32,28 +110,85 @@ type SearchContext struct {
 	Id        string
 }
 
+// CreateRequest leaves the category to the category rules when it is not informed,
+// and to the suggestion learned from the history of the user when the auto_category is set
 type CreateRequest struct {
-	PayAt         time.Time `json:"pay_at"`
-	BuyAt         time.Time `json:"buy_at"`
-	Description   string    `json:"description"`
-	Value         float64   `json:"value"`
-	CategoryId    uint      `json:"category_id"`
-	PaymentTypeId uint      `json:"payment_type_id"`
+	PayAt         time.Time   `json:"pay_at" binding:"required"`
+	BuyAt         time.Time   `json:"buy_at" binding:"required"`
+	Description   string      `json:"description" binding:"notblank,max=255"`
+	Value         money.Money `json:"value" binding:"gt=0" swaggertype:"number"`
+	Currency      string      `json:"currency" binding:"omitempty,iso4217"`
+	CategoryId    uint        `json:"category_id"`
+	AutoCategory  bool        `json:"auto_category"`
+	PaymentTypeId uint        `json:"payment_type_id" binding:"required"`
+}
+
+// CreateFromPixRequest takes the value and the description from the PIX, the informed ones take precedence.
+// The category is chosen by the category rules of the user when it is not informed, as in the creation of any invoice.
+type CreateFromPixRequest struct {
+	Code          string      `json:"code" binding:"notblank,max=512"`
+	PayAt         time.Time   `json:"pay_at"`
+	BuyAt         time.Time   `json:"buy_at"`
+	Description   string      `json:"description" binding:"max=255"`
+	Value         money.Money `json:"value" binding:"gte=0" swaggertype:"number"`
+	CategoryId    uint        `json:"category_id"`
+	AutoCategory  bool        `json:"auto_category"`
+	PaymentTypeId uint        `json:"payment_type_id"`
 }
 
 type UpdateRequest struct {
-	PayAt         time.Time `json:"pay_at"`
-	BuyAt         time.Time `json:"buy_at"`
-	Description   string    `json:"description"`
-	Value         float64   `json:"value"`
-	CategoryId    uint      `json:"category_id"`
-	PaymentTypeId uint      `json:"payment_type_id"`
+	PayAt         time.Time   `json:"pay_at" binding:"required"`
+	BuyAt         time.Time   `json:"buy_at" binding:"required"`
+	Description   string      `json:"description" binding:"notblank,max=255"`
+	Value         money.Money `json:"value" binding:"gt=0" swaggertype:"number"`
+	Currency      string      `json:"currency" binding:"omitempty,iso4217"`
+	CategoryId    uint        `json:"category_id" binding:"required"`
+	PaymentTypeId uint        `json:"payment_type_id" binding:"required"`
+}
+
+// PatchRequest holds only the fields that must be changed, the omitted ones keep the stored value
+type PatchRequest struct {
+	PayAt         *time.Time   `json:"pay_at"`
+	BuyAt         *time.Time   `json:"buy_at"`
+	Description   *string      `json:"description" binding:"omitempty,notblank,max=255"`
+	Value         *money.Money `json:"value" binding:"omitempty,gt=0" swaggertype:"number"`
+	Currency      *string      `json:"currency" binding:"omitempty,iso4217"`
+	CategoryId    *uint        `json:"category_id" binding:"omitempty,min=1"`
+	PaymentTypeId *uint        `json:"payment_type_id" binding:"omitempty,min=1"`
 }
 
 type CreateInvoiceRequest struct {
-	Value float64   `json:"value"`
-	PayAt time.Time `json:"pay_at"`
-	BuyAt time.Time `json:"buy_at"`
+	Value money.Money `json:"value" swaggertype:"number"`
+	PayAt time.Time   `json:"pay_at"`
+	BuyAt time.Time   `json:"buy_at"`
+}
+
+type CreateBatchRequest struct {
+	Mode  string          `json:"mode"`
+	Items []CreateRequest `json:"items"`
+}
+
+type UpdateBatchRequest struct {
+	Mode  string            `json:"mode"`
+	Items []UpdateBatchItem `json:"items"`
+}
+
+// UpdateBatchItem carries the version of the invoice read by the client, the one returned in the ETag
+type UpdateBatchItem struct {
+	Id      string `json:"id"`
+	Version uint   `json:"version"`
+	UpdateRequest
+}
+
+type DeleteBatchRequest struct {
+	Mode  string            `json:"mode"`
+	Items []DeleteBatchItem `json:"items"`
+}
+
+// DeleteBatchItem carries the version of the invoice read by the client, the one returned in the ETag
+type DeleteBatchItem struct {
+	Id      string `json:"id"`
+	Version uint   `json:"version"`
 }
 
 type CategoryResponse struct {
@@ -71,9 +206,17 @@ type InvoiceResponse struct {
 	PayAt               time.Time           `json:"pay_at"`
 	BuyAt               time.Time           `json:"buy_at"`
 	Description         string              `json:"description"`
-	Value               float64             `json:"value"`
+	Value               money.Money         `json:"value" swaggertype:"number"`
+	Currency            string              `json:"currency"`
+	ConvertedValue      *money.Money        `json:"converted_value,omitempty" swaggertype:"number"`
 	Category            CategoryResponse    `json:"category"`
 	PaymentType         PaymentTypeResponse `json:"payment_type"`
+	DeletedAt           *time.Time          `json:"deleted_at,omitempty"`
+	Version             uint                `json:"-"`
+}
+
+type InvoiceTrashResponse struct {
+	Records []InvoiceResponse `json:"records"`
 }
 
 type InvoiceStat struct {
@@ -82,12 +225,33 @@ type InvoiceStat struct {
 	Invoice                 *InvoiceResponse
 }
 
+type BatchItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type InvoiceBatchItemResult struct {
+	Index   int              `json:"index"`
+	Id      string           `json:"id,omitempty"`
+	Status  string           `json:"status"`
+	Error   *BatchItemError  `json:"error,omitempty"`
+	Invoice *InvoiceResponse `json:"invoice,omitempty"`
+}
+
+type InvoiceBatchResponse struct {
+	Mode      string                   `json:"mode"`
+	Succeeded uint                     `json:"succeeded"`
+	Failed    uint                     `json:"failed"`
+	Results   []InvoiceBatchItemResult `json:"results"`
+}
+
 type InvoicePaginateResponse struct {
-	CurrentPage  uint              `json:"current_page"`
-	TotalPages   uint              `json:"total_pages"`
-	TotalRecords uint              `json:"total_records"`
-	PageLimit    uint              `json:"page_limit"`
-	Records      []InvoiceResponse `json:"records"`
+	CurrentPage       uint              `json:"current_page"`
+	TotalPages        uint              `json:"total_pages"`
+	TotalRecords      uint              `json:"total_records"`
+	PageLimit         uint              `json:"page_limit"`
+	ConvertedCurrency string            `json:"converted_currency,omitempty"`
+	Records           []InvoiceResponse `json:"records"`
 }
 
 type Paginate struct {
@@ -98,5 +262,35 @@ type Paginate struct {
 type SearchParams struct {
 	month    *uint
 	year     *uint
+	currency string
 	paginate *Paginate
 }
+
+type ApplyRulesContext struct {
+	Ctx       context.Context
+	Request   ApplyRulesRequest
+	UserToken string
+}
+
+// ApplyRulesRequest selects the month whose invoices are categorized again, the dry run only previews the changes
+type ApplyRulesRequest struct {
+	Month  uint `json:"month" binding:"required,min=1,max=12"`
+	Year   uint `json:"year" binding:"required,min=1900"`
+	DryRun bool `json:"dry_run"`
+}
+
+type ApplyRulesResult struct {
+	Id               string `json:"id"`
+	Description      string `json:"description"`
+	CategoryIdBefore uint   `json:"category_id_before"`
+	CategoryIdAfter  uint   `json:"category_id_after"`
+	Label            string `json:"label,omitempty"`
+	Changed          bool   `json:"changed"`
+}
+
+type ApplyRulesResponse struct {
+	DryRun  bool               `json:"dry_run"`
+	Matched uint               `json:"matched"`
+	Changed uint               `json:"changed"`
+	Results []ApplyRulesResult `json:"results"`
+}
@@ -1,6 +1,10 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type QueryParamsBuilder struct {
 	userId string
@@ -49,11 +53,13 @@ type InvoiceBuilder struct {
 	buyAt               time.Time
 	payAt               time.Time
 	description         string
-	value               float64
+	value               money.Money
+	currency            string
 	userId              string
 	category            InvoiceCategory
 	paymentType         PaymentType
 	invoiceProjectionId string
+	version             uint
 }
 
 func NewInvoiceBuilder() *InvoiceBuilder {
@@ -79,10 +85,14 @@ func (builder *InvoiceBuilder) AddDescription(description string) *InvoiceBuilde
 	builder.description = description
 	return builder
 }
-func (builder *InvoiceBuilder) AddValue(value float64) *InvoiceBuilder {
+func (builder *InvoiceBuilder) AddValue(value money.Money) *InvoiceBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *InvoiceBuilder) AddCurrency(currency string) *InvoiceBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *InvoiceBuilder) AddPaymentType(paymentType PaymentType) *InvoiceBuilder {
 	builder.paymentType = paymentType
 	return builder
@@ -99,6 +109,10 @@ func (builder *InvoiceBuilder) AddCategory(category InvoiceCategory) *InvoiceBui
 	builder.category = category
 	return builder
 }
+func (builder *InvoiceBuilder) AddVersion(version uint) *InvoiceBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *InvoiceBuilder) Build() *Invoice {
 	invoice := Invoice{}
 
@@ -108,10 +122,12 @@ func (builder *InvoiceBuilder) Build() *Invoice {
 	invoice.BuyAt = builder.buyAt
 	invoice.Description = builder.description
 	invoice.Value = builder.value
+	invoice.Currency = builder.currency
 	invoice.PaymentType = builder.paymentType
 	invoice.InvoiceProjectionId = builder.invoiceProjectionId
 	invoice.UserId = builder.userId
 	invoice.Category = builder.category
+	invoice.Version = builder.version
 
 	return &invoice
 }
@@ -3,16 +3,44 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 )
 
 type Repository interface {
 	Save(ctx context.Context, invoice Invoice) (*Invoice, error)
 	GetById(ctx context.Context, id string, userId string) (*Invoice, error)
 	Edit(ctx context.Context, invoice Invoice) (*Invoice, error)
-	Remove(ctx context.Context, id string, userId string) error
+	Remove(ctx context.Context, id string, userId string, version uint) error
+	SaveAll(ctx context.Context, invoices []Invoice) error
+	EditAll(ctx context.Context, invoices []Invoice) error
+	RemoveAll(ctx context.Context, ids []string, versions []uint, userId string) error
 	GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error)
 	GetAll(ctx context.Context, params QueryParams) (*[]Invoice, error)
+	GetTrash(ctx context.Context, userId string) (*[]Invoice, error)
+	Restore(ctx context.Context, id string, userId string) error
+	Purge(ctx context.Context, deletedBefore time.Time) error
+}
+
+// ErrVersionConflict is returned when the record was changed after the version informed by the caller
+var ErrVersionConflict = errors.New("the record was modified by another request")
+
+// BatchConflictError tells which item of a batch was changed after the version informed by the caller,
+// it matches ErrVersionConflict
+type BatchConflictError struct {
+	Position int
+}
+
+func (e *BatchConflictError) Error() string {
+	return fmt.Sprintf("the record at the position %d of the batch was modified by another request", e.Position)
+}
+
+func (e *BatchConflictError) Unwrap() error {
+	return ErrVersionConflict
 }
 
 type repository struct {
@@ -24,13 +52,13 @@ func New(db *sql.DB) Repository {
 }
 
 func (r *repository) Save(ctx context.Context, invoice Invoice) (*Invoice, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +70,7 @@ func (r *repository) Save(ctx context.Context, invoice Invoice) (*Invoice, error
 		invoice.BuyAt,
 		invoice.Description,
 		invoice.Value,
+		invoice.Currency,
 		invoice.UserId,
 		invoice.Category.Id,
 		invoice.PaymentType.Id,
@@ -57,7 +86,7 @@ func (r *repository) Save(ctx context.Context, invoice Invoice) (*Invoice, error
 }
 
 func (r *repository) GetById(ctx context.Context, id string, userId string) (*Invoice, error) {
-	results, err := r.db.QueryContext(ctx, `
+	results, err := dbtx.Get(ctx, r.db).QueryContext(ctx, `
 		SELECT
 			i.id,
 			i.created_at,
@@ -65,26 +94,28 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*In
 			i.buy_at,
 			i.description,
 			i.value,
+			i.currency,
 			i.user_id,
 			i.invoice_projection_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			i.version
 		FROM
 			invoice i
 		INNER JOIN invoice_category ic ON 
 			ic.id = i.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = i.payment_type_id
-		WHERE i.id = ? AND i.user_id = ?`, id, userId)
+		WHERE i.id = ? AND i.user_id = ? AND i.deleted_at IS NULL`, id, userId)
 	if err != nil {
 		return nil, err
 	}
 	defer results.Close()
 	invoice := &Invoice{Category: InvoiceCategory{}, PaymentType: PaymentType{}}
 	if results.Next() {
-		var value sql.NullFloat64
+		var value money.NullMoney
 		var categoryId sql.NullInt64
 		var paymentTypeId sql.NullInt64
 		var createdAtTimestamp sql.NullInt64
@@ -96,12 +127,14 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*In
 			&invoice.BuyAt,
 			&invoice.Description,
 			&value,
+			&invoice.Currency,
 			&invoice.UserId,
 			&invoiceProjectionId,
 			&categoryId,
 			&invoice.Category.Category,
 			&paymentTypeId,
 			&invoice.PaymentType.Type,
+			&invoice.Version,
 		)
 		if err != nil {
 			return nil, err
@@ -110,7 +143,7 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*In
 		invoice.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
 		invoice.Category.Id = uint(categoryId.Int64)
 		invoice.PaymentType.Id = uint(paymentTypeId.Int64)
-		invoice.Value = value.Float64
+		invoice.Value = value.Money
 	} else {
 		return nil, nil
 	}
@@ -118,30 +151,40 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*In
 }
 
 func (r *repository) Edit(ctx context.Context, invoice Invoice) (*Invoice, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, category_id = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`)
+		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`)
 	if err != nil {
 		return nil, err
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(
+	result, err := stmt.Exec(
 		invoice.PayAt,
 		invoice.BuyAt,
 		invoice.Description,
 		invoice.Value,
+		invoice.Currency,
 		invoice.Category.Id,
 		invoice.PaymentType.Id,
 		invoice.Id,
 		invoice.UserId,
+		invoice.Version,
 	)
 	if err != nil {
 		return nil, err
 	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return nil, ErrVersionConflict
+	}
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -149,20 +192,153 @@ func (r *repository) Edit(ctx context.Context, invoice Invoice) (*Invoice, error
 	return &invoice, nil
 }
 
-func (r *repository) Remove(ctx context.Context, id string, userId string) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+func (r *repository) Remove(ctx context.Context, id string, userId string, version uint) error {
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return err
 	}
-	stmt, err := tx.PrepareContext(ctx, `DELETE FROM invoice WHERE id = ? AND user_id = ?`)
+	stmt, err := tx.PrepareContext(ctx, `UPDATE invoice SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(id, userId)
+	result, err := stmt.Exec(time.Now().Unix(), id, userId, version)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return ErrVersionConflict
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SaveAll saves every invoice in a single transaction, so either all of them are stored or none
+func (r *repository) SaveAll(ctx context.Context, invoices []Invoice) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for _, invoice := range invoices {
+		_, err = stmt.Exec(
+			invoice.Id,
+			invoice.CreatedAt.Unix(),
+			invoice.PayAt,
+			invoice.BuyAt,
+			invoice.Description,
+			invoice.Value,
+			invoice.Currency,
+			invoice.UserId,
+			invoice.Category.Id,
+			invoice.PaymentType.Id,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// EditAll edits every invoice in a single transaction, so either all of them are changed or none.
+// An invoice whose version is not the current one aborts the batch with a BatchConflictError
+func (r *repository) EditAll(ctx context.Context, invoices []Invoice) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for position, invoice := range invoices {
+		result, err := stmt.Exec(
+			invoice.PayAt,
+			invoice.BuyAt,
+			invoice.Description,
+			invoice.Value,
+			invoice.Currency,
+			invoice.Category.Id,
+			invoice.PaymentType.Id,
+			invoice.Id,
+			invoice.UserId,
+			invoice.Version,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if rowsAffected == 0 {
+			tx.Rollback()
+			return &BatchConflictError{Position: position}
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoveAll moves every invoice to the trash in a single transaction, so either all of them are removed or none.
+// An invoice whose version is not the current one aborts the batch with a BatchConflictError
+func (r *repository) RemoveAll(ctx context.Context, ids []string, versions []uint, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE invoice SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	deletedAt := time.Now().Unix()
+	for position, id := range ids {
+		result, err := stmt.Exec(deletedAt, id, userId, versions[position])
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if rowsAffected == 0 {
+			tx.Rollback()
+			return &BatchConflictError{Position: position}
+		}
+	}
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -172,8 +348,8 @@ func (r *repository) Remove(ctx context.Context, id string, userId string) error
 
 func (r *repository) GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error) {
 	var totalRecords uint
-	query := `SELECT COUNT(*) as total_records FROM invoice WHERE MONTH(pay_at) = ? AND YEAR(pay_at) = ? AND user_id = ?`
-	row := r.db.QueryRowContext(ctx, query, params.month, params.year, params.userId)
+	query := `SELECT COUNT(*) as total_records FROM invoice WHERE MONTH(pay_at) = ? AND YEAR(pay_at) = ? AND user_id = ? AND deleted_at IS NULL`
+	row := dbtx.Get(ctx, r.db).QueryRowContext(ctx, query, params.month, params.year, params.userId)
 	err := row.Scan(&totalRecords)
 	if err != nil {
 		return nil, err
@@ -190,7 +366,9 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 			i.buy_at,
 			i.description,
 			i.value,
+			i.currency,
 			i.user_id,
+			i.version,
 			i.invoice_projection_id,
 			ic.id,
 			ic.category,
@@ -203,9 +381,9 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 		INNER JOIN payment_type pt ON
 			pt.id = i.payment_type_id
 		WHERE 
-			MONTH(i.pay_at) = ? AND YEAR(i.pay_at) = ? AND i.user_id = ?
+			MONTH(i.pay_at) = ? AND YEAR(i.pay_at) = ? AND i.user_id = ? AND i.deleted_at IS NULL
 		LIMIT ? OFFSET ?`
-	rows, err := r.db.QueryContext(ctx, query, params.month, params.year, params.userId, params.limit, params.offset)
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, params.month, params.year, params.userId, params.limit, params.offset)
 	if err != nil {
 		return nil, err
 	}
@@ -213,7 +391,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 
 	var invoiceList []Invoice
 	for rows.Next() {
-		var value sql.NullFloat64
+		var value money.NullMoney
 		var categoryId sql.NullInt64
 		var paymentTypeId sql.NullInt64
 		var createdAtTimestamp sql.NullInt64
@@ -230,7 +408,9 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 			&invoice.BuyAt,
 			&invoice.Description,
 			&value,
+			&invoice.Currency,
 			&invoice.UserId,
+			&invoice.Version,
 			&invoiceProjectionId,
 			&categoryId,
 			&category.Category,
@@ -240,7 +420,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 			return nil, err
 		}
 		invoice.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
-		invoice.Value = value.Float64
+		invoice.Value = value.Money
 		category.Id = uint(categoryId.Int64)
 		invoice.Category = category
 		paymentType.Id = uint(paymentTypeId.Int64)
@@ -252,3 +432,135 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 
 	return &invoiceList, nil
 }
+
+func (r *repository) GetTrash(ctx context.Context, userId string) (*[]Invoice, error) {
+	query := `
+		SELECT
+			i.id,
+			i.created_at,
+			i.pay_at,
+			i.buy_at,
+			i.description,
+			i.value,
+			i.currency,
+			i.user_id,
+			i.invoice_projection_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name,
+			i.deleted_at
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON 
+			ic.id = i.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = i.payment_type_id
+		WHERE 
+			i.user_id = ? AND i.deleted_at IS NOT NULL
+		ORDER BY i.deleted_at DESC`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoiceList []Invoice
+	for rows.Next() {
+		var value money.NullMoney
+		var categoryId sql.NullInt64
+		var paymentTypeId sql.NullInt64
+		var createdAtTimestamp sql.NullInt64
+		var deletedAtTimestamp sql.NullInt64
+		var invoiceProjectionId sql.NullString
+
+		var invoice Invoice
+		var category InvoiceCategory
+		var paymentType PaymentType
+
+		err := rows.Scan(
+			&invoice.Id,
+			&createdAtTimestamp,
+			&invoice.PayAt,
+			&invoice.BuyAt,
+			&invoice.Description,
+			&value,
+			&invoice.Currency,
+			&invoice.UserId,
+			&invoiceProjectionId,
+			&categoryId,
+			&category.Category,
+			&paymentTypeId,
+			&paymentType.Type,
+			&deletedAtTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		invoice.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		invoice.DeletedAt = time.Unix(deletedAtTimestamp.Int64, 0)
+		invoice.Value = value.Money
+		category.Id = uint(categoryId.Int64)
+		invoice.Category = category
+		paymentType.Id = uint(paymentTypeId.Int64)
+		invoice.PaymentType = paymentType
+		invoice.InvoiceProjectionId = invoiceProjectionId.String
+
+		invoiceList = append(invoiceList, invoice)
+	}
+
+	return &invoiceList, nil
+}
+
+func (r *repository) Restore(ctx context.Context, id string, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE invoice SET deleted_at = NULL, version = version + 1 WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) Purge(ctx context.Context, deletedBefore time.Time) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	// The split outlives the invoice it divided, so it only loses the reference
+	detachSplitStmt, err := tx.PrepareContext(ctx, `
+		UPDATE invoice_split SET invoice_id = NULL
+		WHERE invoice_id IN (SELECT id FROM invoice WHERE deleted_at IS NOT NULL AND deleted_at < ?)`)
+	if err != nil {
+		return err
+	}
+	defer detachSplitStmt.Close()
+	_, err = detachSplitStmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM invoice WHERE deleted_at IS NOT NULL AND deleted_at < ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const editAllUpdateMock = `
+		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`
+
+func TestEditAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildInvoicesMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	stmt := sqlMock.ExpectPrepare(editAllUpdateMock)
+	for _, invoice := range invoicesMock {
+		stmt.ExpectExec().
+			WithArgs(
+				invoice.PayAt,
+				invoice.BuyAt,
+				invoice.Description,
+				invoice.Value,
+				invoice.Currency,
+				invoice.Category.Id,
+				invoice.PaymentType.Id,
+				invoice.Id,
+				invoice.UserId,
+				invoice.Version).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	sqlMock.ExpectCommit()
+
+	err = _repository.EditAll(context.Background(), invoicesMock)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEditAllExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildInvoicesMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(editAllUpdateMock).
+		ExpectExec().
+		WithArgs(
+			invoicesMock[0].PayAt,
+			invoicesMock[0].BuyAt,
+			invoicesMock[0].Description,
+			invoicesMock[0].Value,
+			invoicesMock[0].Currency,
+			invoicesMock[0].Category.Id,
+			invoicesMock[0].PaymentType.Id,
+			invoicesMock[0].Id,
+			invoicesMock[0].UserId,
+			invoicesMock[0].Version).
+		WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.EditAll(context.Background(), invoicesMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEditAllCommitFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildInvoicesMock()[:1]
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(editAllUpdateMock).
+		ExpectExec().
+		WithArgs(
+			invoicesMock[0].PayAt,
+			invoicesMock[0].BuyAt,
+			invoicesMock[0].Description,
+			invoicesMock[0].Value,
+			invoicesMock[0].Currency,
+			invoicesMock[0].Category.Id,
+			invoicesMock[0].PaymentType.Id,
+			invoicesMock[0].Id,
+			invoicesMock[0].UserId,
+			invoicesMock[0].Version).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit().WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.EditAll(context.Background(), invoicesMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEditAllVersionConflict(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildInvoicesMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	stmt := sqlMock.ExpectPrepare(editAllUpdateMock)
+	for index, invoice := range invoicesMock {
+		stmt.ExpectExec().
+			WithArgs(
+				invoice.PayAt,
+				invoice.BuyAt,
+				invoice.Description,
+				invoice.Value,
+				invoice.Currency,
+				invoice.Category.Id,
+				invoice.PaymentType.Id,
+				invoice.Id,
+				invoice.UserId,
+				invoice.Version).
+			WillReturnResult(sqlmock.NewResult(0, int64(1-index)))
+	}
+	sqlMock.ExpectRollback()
+
+	err = _repository.EditAll(context.Background(), invoicesMock)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	var conflict *BatchConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 1, conflict.Position)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
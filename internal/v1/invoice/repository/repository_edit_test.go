@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,25 +26,29 @@ func TestEditInvoiceSuccess(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, category_id = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			invoiceMock.PayAt,
 			invoiceMock.BuyAt,
 			invoiceMock.Description,
 			invoiceMock.Value,
+			invoiceMock.Currency,
 			invoiceMock.Category.Id,
 			invoiceMock.PaymentType.Id,
 			invoiceMock.Id,
-			invoiceMock.UserId).
+			invoiceMock.UserId,
+			invoiceMock.Version).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit()
 
@@ -72,8 +77,10 @@ func TestEditInvoiceBeginFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
@@ -104,16 +111,18 @@ func TestEditInvoicePrepareFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, category_id = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Edit(context.Background(), *invoiceMock)
@@ -140,26 +149,30 @@ func TestEditInvoiceExecFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, category_id = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			invoiceMock.PayAt,
 			invoiceMock.BuyAt,
 			invoiceMock.Description,
 			invoiceMock.Value,
+			invoiceMock.Currency,
 			invoiceMock.Category.Id,
 			invoiceMock.PaymentType.Id,
 			invoiceMock.Id,
-			invoiceMock.UserId).
+			invoiceMock.UserId,
+			invoiceMock.Version).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Edit(context.Background(), *invoiceMock)
@@ -186,26 +199,30 @@ func TestEditInvoiceCommitFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, category_id = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			invoiceMock.PayAt,
 			invoiceMock.BuyAt,
 			invoiceMock.Description,
 			invoiceMock.Value,
+			invoiceMock.Currency,
 			invoiceMock.Category.Id,
 			invoiceMock.PaymentType.Id,
 			invoiceMock.Id,
-			invoiceMock.UserId).
+			invoiceMock.UserId,
+			invoiceMock.Version).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit().WillReturnError(errors.New("An error has been ocurred"))
 
@@ -216,3 +233,54 @@ func TestEditInvoiceCommitFail(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestEditInvoiceVersionConflict(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	invoiceMock := NewInvoiceBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(now).
+		AddPayAt(now).
+		AddBuyAt(now).
+		AddCategory(InvoiceCategory{Id: 1}).
+		AddDescription("Description de teste").
+		AddPaymentType(PaymentType{Id: 2}).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddUserId("User1").
+		AddVersion(3).
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		UPDATE invoice SET pay_at = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
+		ExpectExec().
+		WithArgs(
+			invoiceMock.PayAt,
+			invoiceMock.BuyAt,
+			invoiceMock.Description,
+			invoiceMock.Value,
+			invoiceMock.Currency,
+			invoiceMock.Category.Id,
+			invoiceMock.PaymentType.Id,
+			invoiceMock.Id,
+			invoiceMock.UserId,
+			invoiceMock.Version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	_, err = _repository.Edit(context.Background(), *invoiceMock)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
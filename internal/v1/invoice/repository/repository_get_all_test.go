@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,9 +35,11 @@ func TestGetAllSuccess(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1, Category: "Moradia"}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2, Type: "Transferência"}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddInvoiceProjectionId("4c3939f7-2b39-4bb1-8367-54fc56abea3a").
 		AddUserId("User1").
+		AddVersion(1).
 		Build()
 
 	rowsInvoiceMock := sqlMock.NewRows([]string{
@@ -46,7 +49,9 @@ func TestGetAllSuccess(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
 		"user_id",
+		"version",
 		"invoice_projection_id",
 		"category_id",
 		"category",
@@ -59,7 +64,9 @@ func TestGetAllSuccess(t *testing.T) {
 		invoiceMock.BuyAt,
 		invoiceMock.Description,
 		invoiceMock.Value,
+		invoiceMock.Currency,
 		invoiceMock.UserId,
+		invoiceMock.Version,
 		invoiceMock.InvoiceProjectionId,
 		invoiceMock.Category.Id,
 		invoiceMock.Category.Category,
@@ -77,7 +84,9 @@ func TestGetAllSuccess(t *testing.T) {
 			i.buy_at,
 			i.description,
 			i.value,
+			i.currency,
 			i.user_id,
+			i.version,
 			i.invoice_projection_id,
 			ic.id,
 			ic.category,
@@ -90,7 +99,7 @@ func TestGetAllSuccess(t *testing.T) {
 		INNER JOIN payment_type pt ON
 			pt.id = i.payment_type_id
 		WHERE 
-			MONTH(i.pay_at) = ? AND YEAR(i.pay_at) = ? AND i.user_id = ?
+			MONTH(i.pay_at) = ? AND YEAR(i.pay_at) = ? AND i.user_id = ? AND i.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnRows(rowsInvoiceMock)
@@ -98,6 +107,7 @@ func TestGetAllSuccess(t *testing.T) {
 	listInvoice, err := _repository.GetAll(context.Background(), queryParams)
 	assert.NoError(t, err)
 	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", (*listInvoice)[0].Id)
+	assert.Equal(t, uint(1), (*listInvoice)[0].Version)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -129,7 +139,9 @@ func TestGetAllQueryFail(t *testing.T) {
 			i.buy_at,
 			i.description,
 			i.value,
+			i.currency,
 			i.user_id,
+			i.version,
 			i.invoice_projection_id,
 			ic.id,
 			ic.category,
@@ -142,7 +154,7 @@ func TestGetAllQueryFail(t *testing.T) {
 		INNER JOIN payment_type pt ON
 			pt.id = i.payment_type_id
 		WHERE 
-			MONTH(i.pay_at) = ? AND YEAR(i.pay_at) = ? AND i.user_id = ?
+			MONTH(i.pay_at) = ? AND YEAR(i.pay_at) = ? AND i.user_id = ? AND i.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnError(errors.New("An error has been ocurred"))
@@ -177,7 +189,9 @@ func TestGetAllScanFail(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
 		"user_id",
+		"version",
 		"invoice_projection_id",
 		"category_id",
 		"category",
@@ -196,6 +210,8 @@ func TestGetAllScanFail(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 	).RowError(1, errors.New("An error has been ocurred"))
 
 	_repository := New(dbMock)
@@ -208,7 +224,9 @@ func TestGetAllScanFail(t *testing.T) {
 			i.buy_at,
 			i.description,
 			i.value,
+			i.currency,
 			i.user_id,
+			i.version,
 			i.invoice_projection_id,
 			ic.id,
 			ic.category,
@@ -221,7 +239,7 @@ func TestGetAllScanFail(t *testing.T) {
 		INNER JOIN payment_type pt ON
 			pt.id = i.payment_type_id
 		WHERE 
-			MONTH(i.pay_at) = ? AND YEAR(i.pay_at) = ? AND i.user_id = ?
+			MONTH(i.pay_at) = ? AND YEAR(i.pay_at) = ? AND i.user_id = ? AND i.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnRows(rowsInvoiceMock)
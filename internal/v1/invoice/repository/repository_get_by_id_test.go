@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,8 +28,10 @@ func TestGetInvoiceByIdSuccess(t *testing.T) {
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2, Type: "Transferência"}).
 		AddInvoiceProjectionId("4c3939f7-2b39-4bb1-8367-54fc56abea3a").
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	rowsInvoiceMock := sqlMock.NewRows([]string{
@@ -38,12 +41,14 @@ func TestGetInvoiceByIdSuccess(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
 		"user_id",
 		"invoice_projection_id",
 		"category_id",
 		"category",
 		"payment_type_id",
 		"payment_type",
+		"version",
 	}).AddRow(
 		invoiceMock.Id,
 		invoiceMock.CreatedAt.Unix(),
@@ -51,12 +56,14 @@ func TestGetInvoiceByIdSuccess(t *testing.T) {
 		invoiceMock.BuyAt,
 		invoiceMock.Description,
 		invoiceMock.Value,
+		invoiceMock.Currency,
 		invoiceMock.UserId,
 		invoiceMock.InvoiceProjectionId,
 		invoiceMock.Category.Id,
 		invoiceMock.Category.Category,
 		invoiceMock.PaymentType.Id,
 		invoiceMock.PaymentType.Type,
+		invoiceMock.Version,
 	)
 
 	_repository := New(dbMock)
@@ -69,25 +76,28 @@ func TestGetInvoiceByIdSuccess(t *testing.T) {
 			i.buy_at,
 			i.description,
 			i.value,
+			i.currency,
 			i.user_id,
 			i.invoice_projection_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			i.version
 		FROM
 			invoice i
 		INNER JOIN invoice_category ic ON 
 			ic.id = i.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = i.payment_type_id
-		WHERE i.id = ? AND i.user_id = ?`).
+		WHERE i.id = ? AND i.user_id = ? AND i.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsInvoiceMock)
 
 	invoiceReturn, err := _repository.GetById(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
 	assert.NoError(t, err)
 	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", invoiceReturn.Id)
+	assert.Equal(t, uint(3), invoiceReturn.Version)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -111,19 +121,21 @@ func TestGetInvoiceByIdQueryFail(t *testing.T) {
 			i.buy_at,
 			i.description,
 			i.value,
+			i.currency,
 			i.user_id,
 			i.invoice_projection_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			i.version
 		FROM
 			invoice i
 		INNER JOIN invoice_category ic ON 
 			ic.id = i.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = i.payment_type_id
-		WHERE i.id = ? AND i.user_id = ?`).
+		WHERE i.id = ? AND i.user_id = ? AND i.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnError(errors.New("An error has been ocurred"))
 
@@ -149,12 +161,14 @@ func TestGetInvoiceByIdRowEmpty(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
 		"user_id",
 		"invoice_projection_id",
 		"category_id",
 		"category",
 		"payment_type",
 		"payment_type_id",
+		"version",
 	})
 
 	_repository := New(dbMock)
@@ -167,19 +181,21 @@ func TestGetInvoiceByIdRowEmpty(t *testing.T) {
 			i.buy_at,
 			i.description,
 			i.value,
+			i.currency,
 			i.user_id,
 			i.invoice_projection_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			i.version
 		FROM
 			invoice i
 		INNER JOIN invoice_category ic ON 
 			ic.id = i.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = i.payment_type_id
-		WHERE i.id = ? AND i.user_id = ?`).
+		WHERE i.id = ? AND i.user_id = ? AND i.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsInvoiceMock)
 
@@ -206,14 +222,16 @@ func TestGetInvoiceByIdRowScanFail(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
 		"user_id",
 		"invoice_projection_id",
 		"category_id",
 		"category",
 		"payment_type_id",
 		"payment_type",
+		"version",
 	}).AddRow(
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).
 		RowError(1, errors.New("An error has been ocurred"))
 
 	_repository := New(dbMock)
@@ -226,19 +244,21 @@ func TestGetInvoiceByIdRowScanFail(t *testing.T) {
 			i.buy_at,
 			i.description,
 			i.value,
+			i.currency,
 			i.user_id,
 			i.invoice_projection_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			i.version
 		FROM
 			invoice i
 		INNER JOIN invoice_category ic ON 
 			ic.id = i.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = i.payment_type_id
-		WHERE i.id = ? AND i.user_id = ?`).
+		WHERE i.id = ? AND i.user_id = ? AND i.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsInvoiceMock)
 
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrashSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"pay_at",
+		"buy_at",
+		"description",
+		"value",
+		"currency",
+		"user_id",
+		"invoice_projection_id",
+		"category_id",
+		"category",
+		"payment_type_id",
+		"type_name",
+		"deleted_at",
+	}).AddRow(
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		now.Unix(),
+		now,
+		now,
+		"Description de teste",
+		500.50,
+		"USD",
+		"User1",
+		nil,
+		1,
+		"Categoria",
+		2,
+		"Pix",
+		now.Unix(),
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			i.id,
+			i.created_at,
+			i.pay_at,
+			i.buy_at,
+			i.description,
+			i.value,
+			i.currency,
+			i.user_id,
+			i.invoice_projection_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name,
+			i.deleted_at
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON 
+			ic.id = i.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = i.payment_type_id
+		WHERE 
+			i.user_id = ? AND i.deleted_at IS NOT NULL
+		ORDER BY i.deleted_at DESC`).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	trash, err := _repository.GetTrash(context.Background(), "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(*trash))
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", (*trash)[0].Id)
+	assert.Equal(t, now.Unix(), (*trash)[0].DeletedAt.Unix())
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetTrashQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			i.id,
+			i.created_at,
+			i.pay_at,
+			i.buy_at,
+			i.description,
+			i.value,
+			i.currency,
+			i.user_id,
+			i.invoice_projection_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name,
+			i.deleted_at
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON 
+			ic.id = i.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = i.payment_type_id
+		WHERE 
+			i.user_id = ? AND i.deleted_at IS NOT NULL
+		ORDER BY i.deleted_at DESC`).
+		WithArgs("User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetTrash(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	stmt := sqlMock.ExpectPrepare(`UPDATE invoice SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`)
+	stmt.ExpectExec().
+		WithArgs(sqlmock.AnyArg(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", uint(1)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	stmt.ExpectExec().
+		WithArgs(sqlmock.AnyArg(), "2b4a2b1e-0c1b-4f6e-9f65-3bd0a5cfa7a9", "User1", uint(3)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.RemoveAll(context.Background(), []string{"519fd73e-45e6-4471-8a66-5057486f5cc8", "2b4a2b1e-0c1b-4f6e-9f65-3bd0a5cfa7a9"}, []uint{1, 3}, "User1")
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRemoveAllExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`UPDATE invoice SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`).
+		ExpectExec().
+		WithArgs(sqlmock.AnyArg(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", uint(1)).
+		WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.RemoveAll(context.Background(), []string{"519fd73e-45e6-4471-8a66-5057486f5cc8", "2b4a2b1e-0c1b-4f6e-9f65-3bd0a5cfa7a9"}, []uint{1, 3}, "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRemoveAllVersionConflict(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`UPDATE invoice SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`).
+		ExpectExec().
+		WithArgs(sqlmock.AnyArg(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1", uint(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	err = _repository.RemoveAll(context.Background(), []string{"519fd73e-45e6-4471-8a66-5057486f5cc8", "2b4a2b1e-0c1b-4f6e-9f65-3bd0a5cfa7a9"}, []uint{1, 3}, "User1")
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	var conflict *BatchConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 0, conflict.Position)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
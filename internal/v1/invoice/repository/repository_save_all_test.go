@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveAllInsertMock = `
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func buildInvoicesMock() []Invoice {
+	now := time.Now()
+	return []Invoice{
+		*NewInvoiceBuilder().
+			AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+			AddCreatedAt(now).
+			AddPayAt(now).
+			AddBuyAt(now).
+			AddPaymentType(PaymentType{Id: 2}).
+			AddCategory(InvoiceCategory{Id: 1}).
+			AddDescription("Description de teste").
+			AddValue(money.FromCents(50050)).
+			AddCurrency("BRL").
+			AddUserId("User1").
+			Build(),
+		*NewInvoiceBuilder().
+			AddId("2b4a2b1e-0c1b-4f6e-9f65-3bd0a5cfa7a9").
+			AddCreatedAt(now).
+			AddPayAt(now).
+			AddBuyAt(now).
+			AddPaymentType(PaymentType{Id: 1}).
+			AddCategory(InvoiceCategory{Id: 2}).
+			AddDescription("Description de teste 2").
+			AddValue(money.FromCents(10000)).
+			AddCurrency("BRL").
+			AddUserId("User1").
+			Build(),
+	}
+}
+
+func TestSaveAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildInvoicesMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	stmt := sqlMock.ExpectPrepare(saveAllInsertMock)
+	for _, invoice := range invoicesMock {
+		stmt.ExpectExec().
+			WithArgs(
+				invoice.Id,
+				invoice.CreatedAt.Unix(),
+				invoice.PayAt,
+				invoice.BuyAt,
+				invoice.Description,
+				invoice.Value,
+				invoice.Currency,
+				invoice.UserId,
+				invoice.Category.Id,
+				invoice.PaymentType.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	sqlMock.ExpectCommit()
+
+	err = _repository.SaveAll(context.Background(), invoicesMock)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveAllPrepareFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveAllInsertMock).WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.SaveAll(context.Background(), buildInvoicesMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveAllExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildInvoicesMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	stmt := sqlMock.ExpectPrepare(saveAllInsertMock)
+	stmt.ExpectExec().
+		WithArgs(
+			invoicesMock[0].Id,
+			invoicesMock[0].CreatedAt.Unix(),
+			invoicesMock[0].PayAt,
+			invoicesMock[0].BuyAt,
+			invoicesMock[0].Description,
+			invoicesMock[0].Value,
+			invoicesMock[0].Currency,
+			invoicesMock[0].UserId,
+			invoicesMock[0].Category.Id,
+			invoicesMock[0].PaymentType.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	stmt.ExpectExec().
+		WithArgs(
+			invoicesMock[1].Id,
+			invoicesMock[1].CreatedAt.Unix(),
+			invoicesMock[1].PayAt,
+			invoicesMock[1].BuyAt,
+			invoicesMock[1].Description,
+			invoicesMock[1].Value,
+			invoicesMock[1].Currency,
+			invoicesMock[1].UserId,
+			invoicesMock[1].Category.Id,
+			invoicesMock[1].PaymentType.Id).
+		WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.SaveAll(context.Background(), invoicesMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
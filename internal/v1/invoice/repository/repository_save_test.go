@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,7 +27,8 @@ func TestSaveInvoiceSuccess(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -34,8 +36,8 @@ func TestSaveInvoiceSuccess(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			invoiceMock.Id,
@@ -44,6 +46,7 @@ func TestSaveInvoiceSuccess(t *testing.T) {
 			invoiceMock.BuyAt,
 			invoiceMock.Description,
 			invoiceMock.Value,
+			invoiceMock.Currency,
 			invoiceMock.UserId,
 			invoiceMock.Category.Id,
 			invoiceMock.PaymentType.Id).
@@ -75,7 +78,8 @@ func TestSaveInvoiceBeginFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -107,7 +111,8 @@ func TestSaveInvoicePrepareFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -115,8 +120,8 @@ func TestSaveInvoicePrepareFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Save(context.Background(), *invoiceMock)
@@ -143,7 +148,8 @@ func TestSaveInvoiceExecFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -151,8 +157,8 @@ func TestSaveInvoiceExecFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			invoiceMock.Id,
@@ -161,6 +167,7 @@ func TestSaveInvoiceExecFail(t *testing.T) {
 			invoiceMock.BuyAt,
 			invoiceMock.Description,
 			invoiceMock.Value,
+			invoiceMock.Currency,
 			invoiceMock.UserId,
 			invoiceMock.Category.Id,
 			invoiceMock.PaymentType.Id).
@@ -190,7 +197,8 @@ func TestSaveInvoiceCommitFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -198,8 +206,8 @@ func TestSaveInvoiceCommitFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			invoiceMock.Id,
@@ -208,6 +216,7 @@ func TestSaveInvoiceCommitFail(t *testing.T) {
 			invoiceMock.BuyAt,
 			invoiceMock.Description,
 			invoiceMock.Value,
+			invoiceMock.Currency,
 			invoiceMock.UserId,
 			invoiceMock.Category.Id,
 			invoiceMock.PaymentType.Id).
@@ -1,6 +1,10 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type Invoice struct {
 	Id                  string
@@ -8,9 +12,12 @@ type Invoice struct {
 	BuyAt               time.Time
 	PayAt               time.Time
 	Description         string
-	Value               float64
+	Value               money.Money
+	Currency            string
 	InvoiceProjectionId string
 	UserId              string
+	DeletedAt           time.Time
+	Version             uint
 	Category            InvoiceCategory
 	PaymentType         PaymentType
 }
@@ -1,22 +1,33 @@
 package invoiceprojection
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/etag"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
 	"github.com/ruanlas/wallet-core-api/internal/tracing"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/ipservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	"go.elastic.co/apm"
 )
 
 type Handler interface {
 	Create(c *gin.Context)
+	ParseBoleto(c *gin.Context)
+	CreateFromBoleto(c *gin.Context)
 	GetById(c *gin.Context)
 	Update(c *gin.Context)
+	Patch(c *gin.Context)
 	Delete(c *gin.Context)
 	GetAll(c *gin.Context)
 	CreateInvoice(c *gin.Context)
+	CreateInvoiceBatch(c *gin.Context)
+	RevertInvoice(c *gin.Context)
+	GetTrash(c *gin.Context)
+	Restore(c *gin.Context)
 }
 
 type ResponseDefault interface {
@@ -48,9 +59,9 @@ func (h *handler) Create(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	// apm.TransactionFromContext(ctx)
 
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("InvoiceProjection::StorageProcess::Create", "Create new invoice-projection", nil)
@@ -61,7 +72,84 @@ func (h *handler) Create(c *gin.Context) {
 	}
 	invoiceCreated, err := h.storageProcess.Create(createCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, invoiceCreated)
+}
+
+// @Summary Ler um Boleto
+// @Description Este endpoint permite ler a linha digitável ou o código de barras de um boleto, validando seus dígitos verificadores.
+// @Description O valor e o vencimento retornados podem ser usados para preencher uma despesa prevista.
+// @Tags Invoice-Projection
+// @Accept json
+// @Produce json
+// @Param boleto body ipservice.ParseBoletoRequest true "Linha digitável ou código de barras do boleto"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ipservice.BoletoResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/invoice-projection/boleto/parse [post]
+func (h *handler) ParseBoleto(c *gin.Context) {
+	var request ipservice.ParseBoletoRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("InvoiceProjection::ReadingProcess::ParseBoleto", "Parse a boleto code", nil)
+	parseBoletoCtx := ipservice.ParseBoletoContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Request:   request,
+	}
+	boleto, err := h.readingProcess.ParseBoleto(parseBoletoCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, boleto)
+}
+
+// @Summary Criar uma Despesa Prevista a partir de um Boleto
+// @Description Este endpoint permite criar uma despesa prevista com o valor e o vencimento lidos do boleto, guardando o código para o pagamento.
+// @Description O valor e o vencimento informados substituem os do boleto e são obrigatórios quando o boleto não os possui.
+// @Description Quando omitido, o tipo de pagamento é o Boleto.
+// @Tags Invoice-Projection
+// @Accept json
+// @Produce json
+// @Param invoice_projection body ipservice.CreateFromBoletoRequest true "Modelo de criação da despesa prevista a partir do boleto"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} ipservice.InvoiceProjectionResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/invoice-projection/boleto [post]
+func (h *handler) CreateFromBoleto(c *gin.Context) {
+	var request ipservice.CreateFromBoletoRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("InvoiceProjection::StorageProcess::CreateFromBoleto", "Create new invoice-projection from a boleto", nil)
+	createFromBoletoCtx := ipservice.CreateFromBoletoContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+		Request:   request,
+	}
+	invoiceCreated, err := h.storageProcess.CreateFromBoleto(createFromBoletoCtx)
+	if err != nil {
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
@@ -77,6 +165,7 @@ func (h *handler) Create(c *gin.Context) {
 // @Param id path string true "Id da despesa prevista"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
 // @Success 200 {object} ipservice.InvoiceProjectionResponse
+// @Header 200 {string} ETag "Versão atual do registro, deve ser enviada no If-Match da edição e remoção"
 // @Router /v1/invoice-projection/{id} [get]
 func (h *handler) GetById(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -93,15 +182,12 @@ func (h *handler) GetById(c *gin.Context) {
 	}
 	invoiceProjection, err := h.readingProcess.GetById(searchCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
-	if invoiceProjection == nil {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Object not found"})
-		return
-	}
 	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(invoiceProjection.Version))
 	c.JSON(http.StatusOK, invoiceProjection)
 }
 
@@ -113,7 +199,10 @@ func (h *handler) GetById(c *gin.Context) {
 // @Produce json
 // @Param invoice_projection body ipservice.UpdateRequest true "Modelo de edição da despesa prevista"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
 // @Success 200 {object} ipservice.InvoiceProjectionResponse
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
 // @Router /v1/invoice-projection/{id} [put]
 func (h *handler) Update(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -122,9 +211,19 @@ func (h *handler) Update(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	id := c.Param("id")
 	var request ipservice.UpdateRequest
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
+		return
+	}
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("InvoiceProjection::StorageProcess::Update", "Create new invoice-projection", nil)
@@ -132,19 +231,80 @@ func (h *handler) Update(c *gin.Context) {
 		Ctx:       ctx,
 		Request:   request,
 		Id:        id,
+		Version:   version,
 		UserToken: userToken,
 	}
 	invoiceUpdated, err := h.storageProcess.Update(updateCtx)
+	if errors.Is(err, ipservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The invoice-projection was modified by another request"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
-	if invoiceUpdated == nil {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Invoice projection not found"})
+	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(invoiceUpdated.Version))
+	c.JSON(http.StatusOK, invoiceUpdated)
+}
+
+// @Summary Editar parcialmente uma Despesa Prevista
+// @Description Este endpoint permite editar somente os campos informados de uma despesa prevista.
+// @Description Os campos omitidos mantêm o valor atual e o registro resultante é validado antes de ser salvo.
+// @Tags Invoice-Projection
+// @Accept json
+// @Produce json
+// @Param invoice_projection body ipservice.PatchRequest true "Modelo de edição parcial da despesa prevista"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
+// @Success 200 {object} ipservice.InvoiceProjectionResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/invoice-projection/{id} [patch]
+func (h *handler) Patch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	id := c.Param("id")
+	var request ipservice.PatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("InvoiceProjection::StorageProcess::Patch", "Patch an invoice-projection", nil)
+	patchCtx := ipservice.PatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		Id:        id,
+		Version:   version,
+		UserToken: userToken,
+	}
+	invoiceUpdated, err := h.storageProcess.Patch(patchCtx)
+	if errors.Is(err, ipservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The invoice-projection was modified by another request"))
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
 		return
 	}
 	span.End()
+	c.Header(etag.ETAG_HEADER, etag.Format(invoiceUpdated.Version))
 	c.JSON(http.StatusOK, invoiceUpdated)
 }
 
@@ -155,7 +315,11 @@ func (h *handler) Update(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Id da despesa prevista"
 // @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Param   If-Match	header	string	true	"ETag obtido na consulta do registro"
 // @Success 200 {object} ResponseDefault{status=int,message=string}
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 412 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 428 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
 // @Router /v1/invoice-projection/{id} [delete]
 func (h *handler) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -163,15 +327,30 @@ func (h *handler) Delete(c *gin.Context) {
 
 	id := c.Param("id")
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	ifMatch := c.GetHeader(etag.IF_MATCH_HEADER)
+	if ifMatch == "" {
+		c.Error(apperror.PreconditionRequired("The If-Match header is required"))
+		return
+	}
+	version, err := etag.Parse(ifMatch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	span := tx.StartSpan("InvoiceProjection::StorageProcess::Delete", "Delete a invoice-projection", nil)
-	searchCtx := ipservice.SearchContext{
+	deleteCtx := ipservice.DeleteContext{
 		Ctx:       ctx,
 		Id:        id,
+		Version:   version,
 		UserToken: userToken,
 	}
-	err := h.storageProcess.Delete(searchCtx)
+	err = h.storageProcess.Delete(deleteCtx)
+	if errors.Is(err, ipservice.ErrVersionConflict) {
+		c.Error(apperror.PreconditionFailed("The invoice-projection was modified by another request"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
@@ -198,7 +377,7 @@ func (h *handler) GetAll(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	searchParams, err := validateAndGetSearchParams(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("InvoiceProjection::ReadingProcess::GetAllPaginated", "Get a invoice-projection paginated", nil)
@@ -209,7 +388,7 @@ func (h *handler) GetAll(c *gin.Context) {
 	}
 	resultPaginated, err := h.readingProcess.GetAllPaginated(searchCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
@@ -235,9 +414,9 @@ func (h *handler) CreateInvoice(c *gin.Context) {
 	userToken := c.GetHeader(idpauth.AUTH_HEADER)
 	id := c.Param("id")
 	var request ipservice.CreateInvoiceRequest
-	err := c.ShouldBindJSON(&request)
+	err := validation.Bind(c, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": http.StatusBadRequest, "message": err.Error()})
+		c.Error(err)
 		return
 	}
 	span := tx.StartSpan("InvoiceProjection::StorageProcess::CreateInvoice", "Create a invoice from invoice-projection", nil)
@@ -248,18 +427,175 @@ func (h *handler) CreateInvoice(c *gin.Context) {
 	}
 	stat, err := h.storageProcess.CreateInvoice(createInvoiceCtx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "message": err.Error()})
+		c.Error(err)
 		tracing.SendSpanErr(span, err)
 		return
 	}
 	if !stat.ProjectionIsFound {
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "message": "Invoice-projection not found"})
+		c.Error(apperror.NotFound("Invoice-projection not found"))
 		return
 	}
 	if stat.ProjectionIsAlreadyDone {
-		c.JSON(http.StatusConflict, gin.H{"status": http.StatusConflict, "message": "A invoice is already created"})
+		c.Error(apperror.Conflict("A invoice is already created"))
 		return
 	}
 	span.End()
 	c.JSON(http.StatusCreated, stat.Invoice)
 }
+
+// @Summary Realizar Despesas Previstas em lote
+// @Description Este endpoint permite realizar várias despesas previstas em uma única transação.
+// @Description As previsões podem ser informadas por id, com valor e datas opcionais, ou por um filtro de mês, ano e categoria.
+// @Tags Invoice-Projection
+// @Accept json
+// @Produce json
+// @Param invoice body ipservice.CreateInvoiceBatchRequest true "Modelo de realização em lote"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ipservice.InvoiceBatchResponse
+// @Failure 409 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/invoice-projection/batch/create-invoice [post]
+func (h *handler) CreateInvoiceBatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	var request ipservice.CreateInvoiceBatchRequest
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	err = validateCreateInvoiceBatchRequest(request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("InvoiceProjection::StorageProcess::CreateInvoiceBatch", "Create invoices from a batch of invoice-projections", nil)
+	createInvoiceBatchCtx := ipservice.CreateInvoiceBatchContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	response, err := h.storageProcess.CreateInvoiceBatch(createInvoiceBatchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Reverter a realização de uma Despesa Prevista
+// @Description Este endpoint permite desfazer a realização de uma despesa prevista, reabrindo a previsão.
+// @Description A despesa realizada vai para a lixeira desvinculada da previsão, ou é apenas desvinculada quando keep_invoice for verdadeiro.
+// @Description Caso a despesa realizada tenha sido editada, a reversão só ocorre com keep_invoice ou force.
+// @Tags Invoice-Projection
+// @Accept json
+// @Produce json
+// @Param id path string true "Id da despesa prevista"
+// @Param invoice body ipservice.RevertInvoiceRequest false "Modelo de reversão da despesa"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ipservice.InvoiceProjectionResponse
+// @Router /v1/invoice-projection/{id}/revert-invoice [post]
+func (h *handler) RevertInvoice(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	id := c.Param("id")
+	var request ipservice.RevertInvoiceRequest
+	if c.Request.ContentLength > 0 {
+		err := validation.Bind(c, &request)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+	}
+	span := tx.StartSpan("InvoiceProjection::StorageProcess::RevertInvoice", "Revert the invoice created from invoice-projection", nil)
+	revertInvoiceCtx := ipservice.RevertInvoiceContext{
+		Ctx:       ctx,
+		Request:   request,
+		Id:        id,
+		UserToken: userToken,
+	}
+	stat, err := h.storageProcess.RevertInvoice(revertInvoiceCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	if !stat.ProjectionIsFound {
+		c.Error(apperror.NotFound("Invoice-projection not found"))
+		return
+	}
+	if !stat.ProjectionIsAlreadyDone {
+		c.Error(apperror.Conflict("The invoice-projection is not realized"))
+		return
+	}
+	if stat.InvoiceIsModified {
+		c.Error(apperror.Conflict("The realized invoice was modified, use keep_invoice or force to revert"))
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, stat.InvoiceProjection)
+}
+
+// @Summary Obter a lixeira de Despesas Previstas
+// @Description Este endpoint permite obter as despesas previstas removidas que ainda podem ser restauradas
+// @Tags Invoice-Projection
+// @Accept json
+// @Produce json
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ipservice.InvoiceProjectionTrashResponse
+// @Router /v1/invoice-projection/trash [get]
+func (h *handler) GetTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	span := tx.StartSpan("InvoiceProjection::ReadingProcess::GetTrash", "Get the trashed invoice-projection", nil)
+	searchCtx := ipservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+	}
+	trash, err := h.readingProcess.GetTrash(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, trash)
+}
+
+// @Summary Restaurar uma Despesa Prevista
+// @Description Este endpoint permite restaurar uma despesa prevista que está na lixeira
+// @Tags Invoice-Projection
+// @Accept json
+// @Produce json
+// @Param id path string true "Id da despesa prevista"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} ipservice.InvoiceProjectionResponse
+// @Router /v1/invoice-projection/{id}/restore [post]
+func (h *handler) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+
+	id := c.Param("id")
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	span := tx.StartSpan("InvoiceProjection::StorageProcess::Restore", "Restore a invoice-projection", nil)
+	searchCtx := ipservice.SearchContext{
+		Ctx:       ctx,
+		Id:        id,
+		UserToken: userToken,
+	}
+	invoiceProjectionRestored, err := h.storageProcess.Restore(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, invoiceProjectionRestored)
+}
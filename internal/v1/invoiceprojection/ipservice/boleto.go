@@ -0,0 +1,47 @@
+package ipservice
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/boleto"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+// PAYMENT_TYPE_BOLETO is the payment type assumed for the invoice projections created from a boleto
+const PAYMENT_TYPE_BOLETO = 1
+
+// parseBoleto reports the code that can not be read as a validation error of the field code
+func parseBoleto(code string) (*boleto.Boleto, error) {
+	parsedBoleto, err := boleto.Parse(code, time.Now())
+	if err != nil {
+		return nil, validation.Errors{{Field: "code", Code: validation.CODE_INVALID, Message: "The code is not valid, " + err.Error()}}
+	}
+	return parsedBoleto, nil
+}
+
+func toBoletoResponse(parsedBoleto *boleto.Boleto) *BoletoResponse {
+	return &BoletoResponse{
+		Kind:          parsedBoleto.Kind,
+		Barcode:       parsedBoleto.Barcode,
+		DigitableLine: parsedBoleto.DigitableLine,
+		BankCode:      parsedBoleto.BankCode,
+		Value:         parsedBoleto.Value,
+		DueDate:       parsedBoleto.DueDate,
+	}
+}
+
+// validateBoletoInvoiceProjection requires the value and the due date that neither the boleto nor the request informed
+func validateBoletoInvoiceProjection(invoiceProjection repository.InvoiceProjection) error {
+	errs := validation.Errors{}
+	if invoiceProjection.Value <= 0 {
+		errs = append(errs, validation.Required("value"))
+	}
+	if invoiceProjection.PayIn.IsZero() {
+		errs = append(errs, validation.Required("pay_in"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
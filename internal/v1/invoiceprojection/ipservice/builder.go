@@ -1,16 +1,24 @@
 package ipservice
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type InvoiceProjectionResponseBuilder struct {
 	id          string
 	payIn       time.Time
 	buyAt       time.Time
 	description string
-	value       float64
+	value       money.Money
+	currency    string
+	boletoCode  string
 	recurrence  uint
 	category    CategoryResponse
 	paymentType PaymentTypeResponse
+	deletedAt   *time.Time
+	version     uint
 }
 
 func NewInvoiceProjectionResponseBuilder() *InvoiceProjectionResponseBuilder {
@@ -32,10 +40,18 @@ func (builder *InvoiceProjectionResponseBuilder) AddDescription(description stri
 	builder.description = description
 	return builder
 }
-func (builder *InvoiceProjectionResponseBuilder) AddValue(value float64) *InvoiceProjectionResponseBuilder {
+func (builder *InvoiceProjectionResponseBuilder) AddValue(value money.Money) *InvoiceProjectionResponseBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *InvoiceProjectionResponseBuilder) AddCurrency(currency string) *InvoiceProjectionResponseBuilder {
+	builder.currency = currency
+	return builder
+}
+func (builder *InvoiceProjectionResponseBuilder) AddBoletoCode(boletoCode string) *InvoiceProjectionResponseBuilder {
+	builder.boletoCode = boletoCode
+	return builder
+}
 func (builder *InvoiceProjectionResponseBuilder) AddPaymentType(paymentType PaymentTypeResponse) *InvoiceProjectionResponseBuilder {
 	builder.paymentType = paymentType
 	return builder
@@ -48,17 +64,29 @@ func (builder *InvoiceProjectionResponseBuilder) AddCategory(category CategoryRe
 	builder.category = category
 	return builder
 }
+func (builder *InvoiceProjectionResponseBuilder) AddDeletedAt(deletedAt time.Time) *InvoiceProjectionResponseBuilder {
+	builder.deletedAt = &deletedAt
+	return builder
+}
+func (builder *InvoiceProjectionResponseBuilder) AddVersion(version uint) *InvoiceProjectionResponseBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *InvoiceProjectionResponseBuilder) Build() *InvoiceProjectionResponse {
 	invoiceProjectionResponse := InvoiceProjectionResponse{}
 
 	invoiceProjectionResponse.Id = builder.id
 	invoiceProjectionResponse.Description = builder.description
 	invoiceProjectionResponse.Value = builder.value
+	invoiceProjectionResponse.Currency = builder.currency
+	invoiceProjectionResponse.BoletoCode = builder.boletoCode
 	invoiceProjectionResponse.PayIn = builder.payIn
 	invoiceProjectionResponse.BuyAt = builder.buyAt
 	invoiceProjectionResponse.PaymentType = builder.paymentType
 	invoiceProjectionResponse.Recurrence = builder.recurrence
 	invoiceProjectionResponse.Category = builder.category
+	invoiceProjectionResponse.DeletedAt = builder.deletedAt
+	invoiceProjectionResponse.Version = builder.version
 
 	return &invoiceProjectionResponse
 }
@@ -106,7 +134,8 @@ type InvoiceResponseBuilder struct {
 	payAt               time.Time
 	buyAt               time.Time
 	description         string
-	value               float64
+	value               money.Money
+	currency            string
 	invoiceProjectionId string
 	category            CategoryResponse
 	paymentType         PaymentTypeResponse
@@ -131,10 +160,14 @@ func (builder *InvoiceResponseBuilder) AddDescription(description string) *Invoi
 	builder.description = description
 	return builder
 }
-func (builder *InvoiceResponseBuilder) AddValue(value float64) *InvoiceResponseBuilder {
+func (builder *InvoiceResponseBuilder) AddValue(value money.Money) *InvoiceResponseBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *InvoiceResponseBuilder) AddCurrency(currency string) *InvoiceResponseBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *InvoiceResponseBuilder) AddPaymentType(paymentType PaymentTypeResponse) *InvoiceResponseBuilder {
 	builder.paymentType = paymentType
 	return builder
@@ -153,6 +186,7 @@ func (builder *InvoiceResponseBuilder) Build() *InvoiceResponse {
 	invoiceResponse.Id = builder.id
 	invoiceResponse.Description = builder.description
 	invoiceResponse.Value = builder.value
+	invoiceResponse.Currency = builder.currency
 	invoiceResponse.PayAt = builder.payAt
 	invoiceResponse.BuyAt = builder.buyAt
 	invoiceResponse.PaymentType = builder.paymentType
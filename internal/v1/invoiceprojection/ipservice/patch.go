@@ -0,0 +1,54 @@
+package ipservice
+
+import (
+	"strings"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+// apply copies only the fields informed in the request over the stored invoice projection
+func (request PatchRequest) apply(invoiceProjection *repository.InvoiceProjection) {
+	if request.PayIn != nil {
+		invoiceProjection.PayIn = *request.PayIn
+	}
+	if request.BuyAt != nil {
+		invoiceProjection.BuyAt = *request.BuyAt
+	}
+	if request.Description != nil {
+		invoiceProjection.Description = *request.Description
+	}
+	if request.Value != nil {
+		invoiceProjection.Value = *request.Value
+	}
+	if request.Currency != nil {
+		invoiceProjection.Currency = money.NormalizeCurrency(*request.Currency)
+	}
+	if request.CategoryId != nil {
+		invoiceProjection.Category = repository.InvoiceCategory{Id: *request.CategoryId}
+	}
+	if request.PaymentTypeId != nil {
+		invoiceProjection.PaymentType = repository.PaymentType{Id: *request.PaymentTypeId}
+	}
+}
+
+func validatePatchedInvoiceProjection(invoiceProjection repository.InvoiceProjection) error {
+	errs := validation.Errors{}
+	if strings.TrimSpace(invoiceProjection.Description) == "" {
+		errs = append(errs, validation.Required("description"))
+	}
+	if invoiceProjection.Value <= 0 {
+		errs = append(errs, validation.FieldError{Field: "value", Code: validation.CODE_TOO_SMALL, Message: "The value must be greater than 0"})
+	}
+	if invoiceProjection.Category.Id == 0 {
+		errs = append(errs, validation.Required("category_id"))
+	}
+	if invoiceProjection.PaymentType.Id == 0 {
+		errs = append(errs, validation.Required("payment_type_id"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
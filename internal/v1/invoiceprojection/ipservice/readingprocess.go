@@ -1,6 +1,7 @@
 package ipservice
 
 import (
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/repository"
 )
@@ -8,6 +9,8 @@ import (
 type ReadingProcess interface {
 	GetById(searchCtx SearchContext) (*InvoiceProjectionResponse, error)
 	GetAllPaginated(searchCtx SearchContext) (*InvoiceProjectionPaginateResponse, error)
+	GetTrash(searchCtx SearchContext) (*InvoiceProjectionTrashResponse, error)
+	ParseBoleto(parseBoletoCtx ParseBoletoContext) (*BoletoResponse, error)
 }
 
 type readingProcess struct {
@@ -25,7 +28,7 @@ func (rp *readingProcess) GetById(searchCtx SearchContext) (*InvoiceProjectionRe
 		return nil, err
 	}
 	if invoiceProjection == nil {
-		return nil, nil
+		return nil, apperror.NotFound("Invoice projection not found")
 	}
 
 	return NewInvoiceProjectionResponseBuilder().
@@ -34,8 +37,11 @@ func (rp *readingProcess) GetById(searchCtx SearchContext) (*InvoiceProjectionRe
 		AddBuyAt(invoiceProjection.BuyAt).
 		AddDescription(invoiceProjection.Description).
 		AddValue(invoiceProjection.Value).
+		AddCurrency(invoiceProjection.Currency).
+		AddBoletoCode(invoiceProjection.BoletoCode).
 		AddPaymentType(PaymentTypeResponse{Id: invoiceProjection.PaymentType.Id, Type: invoiceProjection.PaymentType.Type}).
 		AddCategory(CategoryResponse{Id: invoiceProjection.Category.Id, Category: invoiceProjection.Category.Category}).
+		AddVersion(invoiceProjection.Version).
 		Build(), nil
 }
 
@@ -91,6 +97,7 @@ func (rp *readingProcess) GetAllPaginated(searchCtx SearchContext) (*InvoiceProj
 			AddPayIn(invoiceProjection.PayIn).
 			AddBuyAt(invoiceProjection.BuyAt).
 			AddValue(invoiceProjection.Value).
+			AddCurrency(invoiceProjection.Currency).
 			Build()
 		invoiceProjectionResponseList = append(invoiceProjectionResponseList, *invoiceProjectionResponse)
 	}
@@ -103,3 +110,38 @@ func (rp *readingProcess) GetAllPaginated(searchCtx SearchContext) (*InvoiceProj
 		Records:      invoiceProjectionResponseList,
 	}, nil
 }
+
+func (rp *readingProcess) GetTrash(searchCtx SearchContext) (*InvoiceProjectionTrashResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	invoiceProjectionList, err := rp.repository.GetTrash(searchCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceProjectionResponseList := []InvoiceProjectionResponse{}
+	for _, invoiceProjection := range *invoiceProjectionList {
+		invoiceProjectionResponse := NewInvoiceProjectionResponseBuilder().
+			AddId(invoiceProjection.Id).
+			AddPayIn(invoiceProjection.PayIn).
+			AddBuyAt(invoiceProjection.BuyAt).
+			AddDescription(invoiceProjection.Description).
+			AddValue(invoiceProjection.Value).
+			AddCurrency(invoiceProjection.Currency).
+			AddPaymentType(PaymentTypeResponse{Id: invoiceProjection.PaymentType.Id, Type: invoiceProjection.PaymentType.Type}).
+			AddCategory(CategoryResponse{Id: invoiceProjection.Category.Id, Category: invoiceProjection.Category.Category}).
+			AddDeletedAt(invoiceProjection.DeletedAt).
+			Build()
+		invoiceProjectionResponseList = append(invoiceProjectionResponseList, *invoiceProjectionResponse)
+	}
+
+	return &InvoiceProjectionTrashResponse{Records: invoiceProjectionResponseList}, nil
+}
+
+// ParseBoleto reads the boleto informed by the user, so the client can pre-fill the invoice projection
+func (rp *readingProcess) ParseBoleto(parseBoletoCtx ParseBoletoContext) (*BoletoResponse, error) {
+	parsedBoleto, err := parseBoleto(parseBoletoCtx.Request.Code)
+	if err != nil {
+		return nil, err
+	}
+	return toBoletoResponse(parsedBoleto), nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/repository"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,7 +22,7 @@ func TestGetAllPaginatedSuccess(t *testing.T) {
 		AddCategory(repository.InvoiceCategory{Id: 2, Category: "Alimentação"}).
 		AddDescription("Description teste").
 		AddPaymentType(repository.PaymentType{Id: 2, Type: "Transferência"}).
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
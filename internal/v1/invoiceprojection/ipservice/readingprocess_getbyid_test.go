@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/repository"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,7 +23,7 @@ func TestGetByIdSuccess(t *testing.T) {
 		AddCategory(repository.InvoiceCategory{Id: 2, Category: "Alimentação"}).
 		AddDescription("Description teste").
 		AddPaymentType(repository.PaymentType{Id: 2, Type: "Transferência"}).
-		AddValue(750.50).
+		AddValue(money.FromCents(75050)).
 		AddUserId("User1").
 		Build()
 	_mockRepository := &mockRepository{}
@@ -59,7 +61,7 @@ func TestGetByIdNotFound(t *testing.T) {
 		Ctx:       ctx,
 	}
 	invoiceProject, err := _readingProcess.GetById(searchCtx)
-	assert.NoError(t, err)
+	assert.Equal(t, apperror.NotFound("Invoice projection not found"), err)
 	assert.Empty(t, invoiceProject)
 }
 
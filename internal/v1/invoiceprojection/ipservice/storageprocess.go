@@ -2,32 +2,50 @@ package ipservice
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
 	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
 	uuid "github.com/satori/go.uuid"
 )
 
 type StorageProcess interface {
 	Create(createCtx CreateContext) (*InvoiceProjectionResponse, error)
+	CreateFromBoleto(createFromBoletoCtx CreateFromBoletoContext) (*InvoiceProjectionResponse, error)
 	Update(updateCtx UpdateContext) (*InvoiceProjectionResponse, error)
-	Delete(searchCtx SearchContext) error
+	Patch(patchCtx PatchContext) (*InvoiceProjectionResponse, error)
+	Delete(deleteCtx DeleteContext) error
+	Restore(searchCtx SearchContext) (*InvoiceProjectionResponse, error)
 	CreateInvoice(createInvoiceCtx CreateInvoiceContext) (*InvoiceStat, error)
+	CreateInvoiceBatch(createInvoiceBatchCtx CreateInvoiceBatchContext) (*InvoiceBatchResponse, error)
+	RevertInvoice(revertInvoiceCtx RevertInvoiceContext) (*RevertInvoiceStat, error)
 }
 
 type storageProcess struct {
-	repository   repository.Repository
-	generateUUID func() uuid.UUID
+	repository       repository.Repository
+	generateUUID     func() uuid.UUID
+	auditProcess     aservice.StorageProcess
+	referenceChecker validation.ReferenceChecker
+	transactor       dbtx.Transactor
 }
 
-func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID) StorageProcess {
-	return &storageProcess{repository: repository, generateUUID: generateUUID}
+func NewStorageProcess(repository repository.Repository, generateUUID func() uuid.UUID, auditProcess aservice.StorageProcess, referenceChecker validation.ReferenceChecker, transactor dbtx.Transactor) StorageProcess {
+	return &storageProcess{repository: repository, generateUUID: generateUUID, auditProcess: auditProcess, referenceChecker: referenceChecker, transactor: transactor}
 }
 
 func (sp *storageProcess) Create(createCtx CreateContext) (*InvoiceProjectionResponse, error) {
 	request := createCtx.Request
 	user := idpauth.GetUser(createCtx.UserToken)
+	err := sp.checkReferences(createCtx.Ctx, request.CategoryId, request.PaymentTypeId)
+	if err != nil {
+		return nil, err
+	}
 	createdAt := time.Now()
 	invoiceProjectionBuilder := repository.NewInvoiceProjectionBuilder().
 		AddId(sp.generateUUID().String()).
@@ -39,28 +57,46 @@ func (sp *storageProcess) Create(createCtx CreateContext) (*InvoiceProjectionRes
 		AddCategory(repository.InvoiceCategory{Id: request.CategoryId}).
 		AddDescription(request.Description).
 		AddValue(request.Value).
+		AddCurrency(money.NormalizeCurrency(request.Currency)).
 		AddUserId(user.Id)
 	if request.BuyAt.IsZero() {
 		invoiceProjectionBuilder.AddBuyAt(createdAt)
 	}
 	invoiceProjection := invoiceProjectionBuilder.Build()
-	invoiceProjectionSaved, err := sp.repository.Save(createCtx.Ctx, *invoiceProjection)
-	if err != nil {
-		return nil, err
-	}
 
-	if request.Recurrence > 1 {
-		err = sp.createRecurrence(createCtx.Ctx, request, createdAt, user.Id)
+	var invoiceProjectionSaved *repository.InvoiceProjection
+	err = sp.transactor.Within(createCtx.Ctx, func(ctx context.Context) error {
+		_, err := sp.repository.Save(ctx, *invoiceProjection)
 		if err != nil {
-			return nil, err
+			return err
 		}
-	} else {
-		request.Recurrence = 1
-	}
-	invoiceProjectionSaved, err = sp.repository.GetById(createCtx.Ctx, invoiceProjectionSaved.Id, user.Id)
+		err = sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_CREATE,
+			Entity:   aservice.ENTITY_INVOICE_PROJECTION,
+			EntityId: invoiceProjection.Id,
+			After:    sp.buildResponse(invoiceProjection),
+		})
+		if err != nil {
+			return err
+		}
+
+		if request.Recurrence > 1 {
+			err = sp.createRecurrence(ctx, request, createdAt, user.Id)
+			if err != nil {
+				return err
+			}
+		}
+		invoiceProjectionSaved, err = sp.repository.GetById(ctx, invoiceProjection.Id, user.Id)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	if request.Recurrence <= 1 {
+		request.Recurrence = 1
+	}
 
 	return NewInvoiceProjectionResponseBuilder().
 		AddId(invoiceProjection.Id).
@@ -68,12 +104,90 @@ func (sp *storageProcess) Create(createCtx CreateContext) (*InvoiceProjectionRes
 		AddBuyAt(invoiceProjection.BuyAt).
 		AddDescription(invoiceProjection.Description).
 		AddValue(invoiceProjection.Value).
+		AddCurrency(invoiceProjection.Currency).
 		AddPaymentType(PaymentTypeResponse{Id: invoiceProjectionSaved.PaymentType.Id, Type: invoiceProjectionSaved.PaymentType.Type}).
 		AddCategory(CategoryResponse{Id: invoiceProjectionSaved.Category.Id, Category: invoiceProjectionSaved.Category.Category}).
 		AddRecurrence(request.Recurrence).
 		Build(), nil
 }
 
+// CreateFromBoleto registers the invoice projection of a boleto, keeping its barcode to be paid later
+func (sp *storageProcess) CreateFromBoleto(createFromBoletoCtx CreateFromBoletoContext) (*InvoiceProjectionResponse, error) {
+	request := createFromBoletoCtx.Request
+	user := idpauth.GetUser(createFromBoletoCtx.UserToken)
+	parsedBoleto, err := parseBoleto(request.Code)
+	if err != nil {
+		return nil, err
+	}
+	if request.PaymentTypeId == 0 {
+		request.PaymentTypeId = PAYMENT_TYPE_BOLETO
+	}
+	createdAt := time.Now()
+	invoiceProjectionBuilder := repository.NewInvoiceProjectionBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(createdAt).
+		AddBuyAt(createdAt).
+		AddPaymentType(repository.PaymentType{Id: request.PaymentTypeId}).
+		AddIsAlreadyDone(false).
+		AddCategory(repository.InvoiceCategory{Id: request.CategoryId}).
+		AddDescription(request.Description).
+		AddValue(parsedBoleto.Value).
+		AddCurrency(money.CURRENCY_DEFAULT).
+		AddBoletoCode(parsedBoleto.Barcode).
+		AddUserId(user.Id)
+	if parsedBoleto.DueDate != nil {
+		invoiceProjectionBuilder.AddPayIn(*parsedBoleto.DueDate)
+	}
+	if !request.PayIn.IsZero() {
+		invoiceProjectionBuilder.AddPayIn(request.PayIn)
+	}
+	if !request.BuyAt.IsZero() {
+		invoiceProjectionBuilder.AddBuyAt(request.BuyAt)
+	}
+	if request.Value != 0 {
+		invoiceProjectionBuilder.AddValue(request.Value)
+	}
+	invoiceProjection := invoiceProjectionBuilder.Build()
+	err = validateBoletoInvoiceProjection(*invoiceProjection)
+	if err != nil {
+		return nil, err
+	}
+	err = sp.checkReferences(createFromBoletoCtx.Ctx, request.CategoryId, request.PaymentTypeId)
+	if err != nil {
+		return nil, err
+	}
+	var invoiceProjectionSaved *repository.InvoiceProjection
+	err = sp.transactor.Within(createFromBoletoCtx.Ctx, func(ctx context.Context) error {
+		_, err := sp.repository.Save(ctx, *invoiceProjection)
+		if err != nil {
+			return err
+		}
+		err = sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_CREATE,
+			Entity:   aservice.ENTITY_INVOICE_PROJECTION,
+			EntityId: invoiceProjection.Id,
+			After:    sp.buildResponse(invoiceProjection),
+		})
+		if err != nil {
+			return err
+		}
+		invoiceProjectionSaved, err = sp.repository.GetById(ctx, invoiceProjection.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		if invoiceProjectionSaved == nil {
+			return apperror.NotFound("Invoice projection not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sp.buildResponse(invoiceProjectionSaved), nil
+}
+
 func (sp *storageProcess) createRecurrence(ctx context.Context, request CreateRequest, createdAt time.Time, userId string) error {
 	for i := 1; i < int(request.Recurrence+1); i++ {
 		invoiceProjectionBuilder := repository.NewInvoiceProjectionBuilder().
@@ -86,6 +200,7 @@ func (sp *storageProcess) createRecurrence(ctx context.Context, request CreateRe
 			AddCategory(repository.InvoiceCategory{Id: request.CategoryId}).
 			AddDescription(request.Description).
 			AddValue(request.Value).
+			AddCurrency(money.NormalizeCurrency(request.Currency)).
 			AddUserId(userId)
 		if request.BuyAt.IsZero() {
 			invoiceProjectionBuilder.AddBuyAt(createdAt)
@@ -95,6 +210,17 @@ func (sp *storageProcess) createRecurrence(ctx context.Context, request CreateRe
 		if err != nil {
 			return err
 		}
+		err = sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   userId,
+			Action:   aservice.ACTION_CREATE,
+			Entity:   aservice.ENTITY_INVOICE_PROJECTION,
+			EntityId: invoiceProjection.Id,
+			After:    sp.buildResponse(invoiceProjection),
+		})
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -109,40 +235,134 @@ func (sp *storageProcess) Update(updateCtx UpdateContext) (*InvoiceProjectionRes
 		AddPaymentType(repository.PaymentType{Id: request.PaymentTypeId}).
 		AddCategory(repository.InvoiceCategory{Id: request.CategoryId}).
 		AddDescription(request.Description).
-		AddValue(request.Value)
+		AddValue(request.Value).
+		AddCurrency(money.NormalizeCurrency(request.Currency))
 	invoiceProjectionExists, err := sp.repository.GetById(updateCtx.Ctx, updateCtx.Id, user.Id)
 	if err != nil {
 		return nil, err
 	}
 	if invoiceProjectionExists == nil {
-		return nil, nil
+		return nil, apperror.NotFound("Invoice projection not found")
+	}
+	err = sp.checkReferences(updateCtx.Ctx, request.CategoryId, request.PaymentTypeId)
+	if err != nil {
+		return nil, err
 	}
 	invoiceProjectionBuilder.AddIsAlreadyDone(invoiceProjectionExists.IsAlreadyDone)
 	invoiceProjectionBuilder.AddUserId(user.Id)
-	invoiceProjectionUpdated, err := sp.repository.Edit(updateCtx.Ctx, *invoiceProjectionBuilder.Build())
+	invoiceProjectionBuilder.AddVersion(updateCtx.Version)
+	var invoiceProjectionResponse *InvoiceProjectionResponse
+	err = sp.transactor.Within(updateCtx.Ctx, func(ctx context.Context) error {
+		invoiceProjectionUpdated, err := sp.repository.Edit(ctx, *invoiceProjectionBuilder.Build())
+		if err != nil {
+			return err
+		}
+
+		invoiceProjectionUpdated, err = sp.repository.GetById(ctx, invoiceProjectionUpdated.Id, user.Id)
+		if err != nil {
+			return err
+		}
+
+		invoiceProjectionResponse = NewInvoiceProjectionResponseBuilder().
+			AddId(invoiceProjectionUpdated.Id).
+			AddPayIn(invoiceProjectionUpdated.PayIn).
+			AddBuyAt(invoiceProjectionExists.BuyAt).
+			AddDescription(invoiceProjectionUpdated.Description).
+			AddValue(invoiceProjectionUpdated.Value).
+			AddCurrency(invoiceProjectionUpdated.Currency).
+			AddPaymentType(PaymentTypeResponse{Id: invoiceProjectionUpdated.PaymentType.Id, Type: invoiceProjectionUpdated.PaymentType.Type}).
+			AddCategory(CategoryResponse{Id: invoiceProjectionUpdated.Category.Id, Category: invoiceProjectionUpdated.Category.Category}).
+			AddVersion(invoiceProjectionUpdated.Version).
+			Build()
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_INVOICE_PROJECTION,
+			EntityId: invoiceProjectionResponse.Id,
+			Before:   sp.buildResponse(invoiceProjectionExists),
+			After:    invoiceProjectionResponse,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
+	return invoiceProjectionResponse, nil
+}
 
-	invoiceProjectionUpdated, err = sp.repository.GetById(updateCtx.Ctx, invoiceProjectionUpdated.Id, user.Id)
+func (sp *storageProcess) Patch(patchCtx PatchContext) (*InvoiceProjectionResponse, error) {
+	user := idpauth.GetUser(patchCtx.UserToken)
+	invoiceProjectionExists, err := sp.repository.GetById(patchCtx.Ctx, patchCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if invoiceProjectionExists == nil {
+		return nil, apperror.NotFound("Invoice projection not found")
+	}
+	invoiceProjection := *invoiceProjectionExists
+	patchCtx.Request.apply(&invoiceProjection)
+	err = validatePatchedInvoiceProjection(invoiceProjection)
 	if err != nil {
 		return nil, err
 	}
+	err = sp.checkReferences(patchCtx.Ctx, invoiceProjection.Category.Id, invoiceProjection.PaymentType.Id)
+	if err != nil {
+		return nil, err
+	}
+	invoiceProjection.UserId = user.Id
+	invoiceProjection.Version = patchCtx.Version
+	var invoiceProjectionResponse *InvoiceProjectionResponse
+	err = sp.transactor.Within(patchCtx.Ctx, func(ctx context.Context) error {
+		invoiceProjectionUpdated, err := sp.repository.Edit(ctx, invoiceProjection)
+		if err != nil {
+			return err
+		}
 
-	return NewInvoiceProjectionResponseBuilder().
-		AddId(invoiceProjectionUpdated.Id).
-		AddPayIn(invoiceProjectionUpdated.PayIn).
-		AddBuyAt(invoiceProjectionExists.BuyAt).
-		AddDescription(invoiceProjectionUpdated.Description).
-		AddValue(invoiceProjectionUpdated.Value).
-		AddPaymentType(PaymentTypeResponse{Id: invoiceProjectionUpdated.PaymentType.Id, Type: invoiceProjectionUpdated.PaymentType.Type}).
-		AddCategory(CategoryResponse{Id: invoiceProjectionUpdated.Category.Id, Category: invoiceProjectionUpdated.Category.Category}).
-		Build(), nil
+		invoiceProjectionUpdated, err = sp.repository.GetById(ctx, invoiceProjectionUpdated.Id, user.Id)
+		if err != nil {
+			return err
+		}
+
+		invoiceProjectionResponse = sp.buildResponse(invoiceProjectionUpdated)
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_INVOICE_PROJECTION,
+			EntityId: invoiceProjectionResponse.Id,
+			Before:   sp.buildResponse(invoiceProjectionExists),
+			After:    invoiceProjectionResponse,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoiceProjectionResponse, nil
 }
 
-func (sp *storageProcess) Delete(searchCtx SearchContext) error {
-	user := idpauth.GetUser(searchCtx.UserToken)
-	return sp.repository.Remove(searchCtx.Ctx, searchCtx.Id, user.Id)
+func (sp *storageProcess) Delete(deleteCtx DeleteContext) error {
+	user := idpauth.GetUser(deleteCtx.UserToken)
+	invoiceProjectionExists, err := sp.repository.GetById(deleteCtx.Ctx, deleteCtx.Id, user.Id)
+	if err != nil {
+		return err
+	}
+	if invoiceProjectionExists == nil {
+		return apperror.NotFound("Invoice projection not found")
+	}
+	return sp.transactor.Within(deleteCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.Remove(ctx, deleteCtx.Id, user.Id, deleteCtx.Version)
+		if err != nil {
+			return err
+		}
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_DELETE,
+			Entity:   aservice.ENTITY_INVOICE_PROJECTION,
+			EntityId: invoiceProjectionExists.Id,
+			Before:   sp.buildResponse(invoiceProjectionExists),
+		})
+	})
 }
 
 func (sp *storageProcess) CreateInvoice(createInvoiceCtx CreateInvoiceContext) (*InvoiceStat, error) {
@@ -158,6 +378,119 @@ func (sp *storageProcess) CreateInvoice(createInvoiceCtx CreateInvoiceContext) (
 	if invoiceProjection.IsAlreadyDone == true {
 		return &InvoiceStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: true}, nil
 	}
+	var invoiceResponse *InvoiceResponse
+	err = sp.transactor.Within(createInvoiceCtx.Ctx, func(ctx context.Context) error {
+		invoice, err := sp.repository.SaveInvoice(ctx, *sp.buildInvoice(invoiceProjection, request))
+		if err != nil {
+			return err
+		}
+		invoiceProjectionBefore := sp.buildResponse(invoiceProjection)
+		invoiceProjection.IsAlreadyDone = true
+		_, err = sp.repository.Edit(ctx, *invoiceProjection)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return apperror.Conflict("The invoice projection was changed by another request")
+		}
+		if err != nil {
+			return err
+		}
+
+		invoiceResponse = sp.buildInvoiceResponse(invoice)
+		return sp.recordRealization(ctx, user.Id, invoiceProjectionBefore, invoiceResponse)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &InvoiceStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: false, Invoice: invoiceResponse}, nil
+}
+
+func (sp *storageProcess) CreateInvoiceBatch(createInvoiceBatchCtx CreateInvoiceBatchContext) (*InvoiceBatchResponse, error) {
+	request := createInvoiceBatchCtx.Request
+	user := idpauth.GetUser(createInvoiceBatchCtx.UserToken)
+
+	projectionIds := []string{}
+	overrides := map[string]CreateInvoiceRequest{}
+	for _, item := range request.Items {
+		if _, exists := overrides[item.Id]; !exists {
+			projectionIds = append(projectionIds, item.Id)
+		}
+		overrides[item.Id] = CreateInvoiceRequest{Value: item.Value, PayIn: item.PayIn, BuyAt: item.BuyAt}
+	}
+	invoiceProjections := map[string]*repository.InvoiceProjection{}
+	if request.Filter != nil {
+		queryParams := repository.NewQueryParamsBuilder().
+			AddMonth(request.Filter.Month).
+			AddYear(request.Filter.Year).
+			AddCategoryId(request.Filter.CategoryId).
+			AddUserId(user.Id).
+			Build()
+		pendingList, err := sp.repository.GetAllPending(createInvoiceBatchCtx.Ctx, queryParams)
+		if err != nil {
+			return nil, err
+		}
+		for i := range *pendingList {
+			invoiceProjection := &(*pendingList)[i]
+			if _, exists := overrides[invoiceProjection.Id]; !exists {
+				projectionIds = append(projectionIds, invoiceProjection.Id)
+			}
+			invoiceProjections[invoiceProjection.Id] = invoiceProjection
+		}
+	}
+
+	results := []InvoiceBatchItemResult{}
+	invoices := []repository.Invoice{}
+	invoiceProjectionsBefore := []*InvoiceProjectionResponse{}
+	for _, projectionId := range projectionIds {
+		invoiceProjection, found := invoiceProjections[projectionId]
+		if !found {
+			var err error
+			invoiceProjection, err = sp.repository.GetById(createInvoiceBatchCtx.Ctx, projectionId, user.Id)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if invoiceProjection == nil {
+			results = append(results, InvoiceBatchItemResult{ProjectionId: projectionId, InvoiceStat: InvoiceStat{ProjectionIsFound: false}})
+			continue
+		}
+		if invoiceProjection.IsAlreadyDone {
+			results = append(results, InvoiceBatchItemResult{ProjectionId: projectionId, InvoiceStat: InvoiceStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: true}})
+			continue
+		}
+		invoice := sp.buildInvoice(invoiceProjection, overrides[projectionId])
+		invoices = append(invoices, *invoice)
+		invoiceProjectionsBefore = append(invoiceProjectionsBefore, sp.buildResponse(invoiceProjection))
+		results = append(results, InvoiceBatchItemResult{
+			ProjectionId: projectionId,
+			InvoiceStat:  InvoiceStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: false, Invoice: sp.buildInvoiceResponse(invoice)},
+		})
+	}
+	if len(invoices) == 0 {
+		return &InvoiceBatchResponse{TotalCreated: 0, Results: results}, nil
+	}
+
+	err := sp.transactor.Within(createInvoiceBatchCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.RealizeInvoices(ctx, invoices)
+		if errors.Is(err, repository.ErrAlreadyDone) {
+			return apperror.Conflict("A invoice projection of the batch is already done")
+		}
+		if err != nil {
+			return err
+		}
+		for i := range invoices {
+			err = sp.recordRealization(ctx, user.Id, invoiceProjectionsBefore[i], sp.buildInvoiceResponse(&invoices[i]))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &InvoiceBatchResponse{TotalCreated: uint(len(invoices)), Results: results}, nil
+}
+
+func (sp *storageProcess) buildInvoice(invoiceProjection *repository.InvoiceProjection, request CreateInvoiceRequest) *repository.Invoice {
 	invoiceBuilder := repository.NewInvoiceBuilder().
 		AddId(sp.generateUUID().String()).
 		AddCategory(invoiceProjection.Category).
@@ -167,6 +500,7 @@ func (sp *storageProcess) CreateInvoice(createInvoiceCtx CreateInvoiceContext) (
 		AddPaymentType(invoiceProjection.PaymentType).
 		AddUserId(invoiceProjection.UserId).
 		AddValue(invoiceProjection.Value).
+		AddCurrency(invoiceProjection.Currency).
 		AddPayAt(invoiceProjection.PayIn).
 		AddBuyAt(invoiceProjection.BuyAt)
 	if request.Value != 0 {
@@ -178,25 +512,209 @@ func (sp *storageProcess) CreateInvoice(createInvoiceCtx CreateInvoiceContext) (
 	if !request.BuyAt.IsZero() {
 		invoiceBuilder.AddBuyAt(request.BuyAt)
 	}
-	invoice, err := sp.repository.SaveInvoice(createInvoiceCtx.Ctx, *invoiceBuilder.Build())
+	return invoiceBuilder.Build()
+}
+
+func (sp *storageProcess) recordRealization(ctx context.Context, userId string, invoiceProjectionBefore *InvoiceProjectionResponse, invoiceResponse *InvoiceResponse) error {
+	err := sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_CREATE,
+		Entity:   aservice.ENTITY_INVOICE,
+		EntityId: invoiceResponse.Id,
+		After:    invoiceResponse,
+	})
+	if err != nil {
+		return err
+	}
+	return sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_REALIZE,
+		Entity:   aservice.ENTITY_INVOICE_PROJECTION,
+		EntityId: invoiceProjectionBefore.Id,
+		Before:   invoiceProjectionBefore,
+		After:    invoiceResponse,
+	})
+}
+
+func (sp *storageProcess) RevertInvoice(revertInvoiceCtx RevertInvoiceContext) (*RevertInvoiceStat, error) {
+	request := revertInvoiceCtx.Request
+	user := idpauth.GetUser(revertInvoiceCtx.UserToken)
+	invoiceProjection, err := sp.repository.GetById(revertInvoiceCtx.Ctx, revertInvoiceCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if invoiceProjection == nil {
+		return &RevertInvoiceStat{ProjectionIsFound: false}, nil
+	}
+	if !invoiceProjection.IsAlreadyDone {
+		return &RevertInvoiceStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: false}, nil
+	}
+	invoice, err := sp.repository.GetInvoiceByProjectionId(revertInvoiceCtx.Ctx, invoiceProjection.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice != nil && invoice.DeletedAt.IsZero() {
+		invoiceIsModified := sp.isInvoiceModified(invoice)
+		if invoiceIsModified && !request.KeepInvoice && !request.Force {
+			return &RevertInvoiceStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: true, InvoiceIsModified: true}, nil
+		}
+	}
+
+	invoiceProjectionBefore := sp.buildResponse(invoiceProjection)
+	invoiceProjection.IsAlreadyDone = false
+	invoiceProjectionResponse := sp.buildResponse(invoiceProjection)
+	err = sp.transactor.Within(revertInvoiceCtx.Ctx, func(ctx context.Context) error {
+		if invoice != nil {
+			err := sp.detachInvoice(ctx, user.Id, invoice, request.KeepInvoice)
+			if err != nil {
+				return err
+			}
+		}
+		_, err := sp.repository.Edit(ctx, *invoiceProjection)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return apperror.Conflict("The invoice projection was changed by another request")
+		}
+		if err != nil {
+			return err
+		}
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_REVERT,
+			Entity:   aservice.ENTITY_INVOICE_PROJECTION,
+			EntityId: invoiceProjection.Id,
+			Before:   invoiceProjectionBefore,
+			After:    invoiceProjectionResponse,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
-	invoiceProjection.IsAlreadyDone = true
-	_, err = sp.repository.Edit(createInvoiceCtx.Ctx, *invoiceProjection)
+	return &RevertInvoiceStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: true, InvoiceProjection: invoiceProjectionResponse}, nil
+}
+
+// detachInvoice removes the realized invoice to the trash or, when asked to keep it, only drops its link to the projection.
+// An invoice already in the trash only has its link dropped, so restoring it later does not realize the projection twice
+func (sp *storageProcess) detachInvoice(ctx context.Context, userId string, invoice *repository.Invoice, keepInvoice bool) error {
+	invoiceBefore := sp.buildInvoiceResponse(invoice)
+	if keepInvoice || !invoice.DeletedAt.IsZero() {
+		err := sp.repository.UnlinkInvoice(ctx, invoice.Id, userId)
+		if err != nil {
+			return err
+		}
+		invoice.InvoiceProjectionId = ""
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   userId,
+			Action:   aservice.ACTION_UPDATE,
+			Entity:   aservice.ENTITY_INVOICE,
+			EntityId: invoice.Id,
+			Before:   invoiceBefore,
+			After:    sp.buildInvoiceResponse(invoice),
+		})
+	}
+	err := sp.repository.RemoveInvoice(ctx, invoice.Id, userId)
+	if err != nil {
+		return err
+	}
+	return sp.auditProcess.Record(aservice.RecordContext{
+		Ctx:      ctx,
+		UserId:   userId,
+		Action:   aservice.ACTION_DELETE,
+		Entity:   aservice.ENTITY_INVOICE,
+		EntityId: invoice.Id,
+		Before:   invoiceBefore,
+	})
+}
+
+// isInvoiceModified reports whether the realized invoice was edited after being created from the projection. The invoice
+// is created with the version 1 and each edit increments it, so the values informed when realizing it are not edits
+func (sp *storageProcess) isInvoiceModified(invoice *repository.Invoice) bool {
+	return invoice.Version > 1
+}
+
+func (sp *storageProcess) Restore(searchCtx SearchContext) (*InvoiceProjectionResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	var invoiceProjectionResponse *InvoiceProjectionResponse
+	err := sp.transactor.Within(searchCtx.Ctx, func(ctx context.Context) error {
+		err := sp.repository.Restore(ctx, searchCtx.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		invoiceProjectionRestored, err := sp.repository.GetById(ctx, searchCtx.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		if invoiceProjectionRestored == nil {
+			return apperror.NotFound("Invoice projection not found")
+		}
+
+		invoiceProjectionResponse = sp.buildResponse(invoiceProjectionRestored)
+		return sp.auditProcess.Record(aservice.RecordContext{
+			Ctx:      ctx,
+			UserId:   user.Id,
+			Action:   aservice.ACTION_RESTORE,
+			Entity:   aservice.ENTITY_INVOICE_PROJECTION,
+			EntityId: invoiceProjectionResponse.Id,
+			After:    invoiceProjectionResponse,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
+	return invoiceProjectionResponse, nil
+}
+
+// checkReferences reports as validation errors the category and payment type that are not registered
+func (sp *storageProcess) checkReferences(ctx context.Context, categoryId uint, paymentTypeId uint) error {
+	errs := validation.Errors{}
+	categoryExists, err := sp.referenceChecker.InvoiceCategoryExists(ctx, categoryId)
+	if err != nil {
+		return err
+	}
+	if !categoryExists {
+		errs = append(errs, validation.NotFound("category_id"))
+	}
+	paymentTypeExists, err := sp.referenceChecker.PaymentTypeExists(ctx, paymentTypeId)
+	if err != nil {
+		return err
+	}
+	if !paymentTypeExists {
+		errs = append(errs, validation.NotFound("payment_type_id"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
 
-	invoiceResponse := NewInvoiceResponseBuilder().
+func (sp *storageProcess) buildResponse(invoiceProjection *repository.InvoiceProjection) *InvoiceProjectionResponse {
+	return NewInvoiceProjectionResponseBuilder().
+		AddId(invoiceProjection.Id).
+		AddPayIn(invoiceProjection.PayIn).
+		AddBuyAt(invoiceProjection.BuyAt).
+		AddDescription(invoiceProjection.Description).
+		AddValue(invoiceProjection.Value).
+		AddCurrency(invoiceProjection.Currency).
+		AddBoletoCode(invoiceProjection.BoletoCode).
+		AddPaymentType(PaymentTypeResponse{Id: invoiceProjection.PaymentType.Id, Type: invoiceProjection.PaymentType.Type}).
+		AddCategory(CategoryResponse{Id: invoiceProjection.Category.Id, Category: invoiceProjection.Category.Category}).
+		AddVersion(invoiceProjection.Version).
+		Build()
+}
+
+func (sp *storageProcess) buildInvoiceResponse(invoice *repository.Invoice) *InvoiceResponse {
+	return NewInvoiceResponseBuilder().
 		AddId(invoice.Id).
 		AddInvoiceProjectionId(invoice.InvoiceProjectionId).
 		AddPayAt(invoice.PayAt).
 		AddBuyAt(invoice.BuyAt).
 		AddDescription(invoice.Description).
 		AddValue(invoice.Value).
+		AddCurrency(invoice.Currency).
 		AddPaymentType(PaymentTypeResponse{Id: invoice.PaymentType.Id, Type: invoice.PaymentType.Type}).
 		AddCategory(CategoryResponse{Id: invoice.Category.Id, Category: invoice.Category.Category}).
 		Build()
-	return &InvoiceStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: false, Invoice: invoiceResponse}, nil
 }
@@ -0,0 +1,384 @@
+package ipservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit/aservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/repository"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildRevertInvoiceMocks(payIn time.Time) (*repository.InvoiceProjection, *repository.Invoice) {
+	invoiceProjectMock := repository.NewInvoiceProjectionBuilder().
+		AddId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddPayIn(payIn).
+		AddBuyAt(payIn).
+		AddIsAlreadyDone(true).
+		AddCategory(repository.InvoiceCategory{Id: 2, Category: "Alimentação"}).
+		AddPaymentType(repository.PaymentType{Id: 2, Type: "Transferência"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		Build()
+	invoiceMock := repository.NewInvoiceBuilder().
+		AddId("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628").
+		AddPayAt(payIn).
+		AddBuyAt(payIn).
+		AddCategory(repository.InvoiceCategory{Id: 2, Category: "Alimentação"}).
+		AddPaymentType(repository.PaymentType{Id: 2, Type: "Transferência"}).
+		AddDescription("Description teste").
+		AddValue(money.FromCents(75050)).
+		AddUserId("User1").
+		AddInvoiceProjectionId("cd1cc27b-28a1-47dc-ac76-70e8185e159d").
+		AddVersion(1).
+		Build()
+	return invoiceProjectMock, invoiceMock
+}
+
+func newRevertInvoiceCtx(request RevertInvoiceRequest) RevertInvoiceContext {
+	return RevertInvoiceContext{
+		Ctx:       context.TODO(),
+		Request:   request,
+		Id:        "cd1cc27b-28a1-47dc-ac76-70e8185e159d",
+		UserToken: "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg",
+	}
+}
+
+func TestRevertInvoiceSuccess(t *testing.T) {
+	invoiceProjectMock, invoiceMock := buildRevertInvoiceMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddGetInvoiceByProjectionIdCall(func(ctx context.Context, invoiceProjectionId string, userId string) (*repository.Invoice, error) {
+		return invoiceMock, nil
+	})
+	var invoiceRemovedId string
+	_mockRepository.AddRemoveInvoiceCall(func(ctx context.Context, id string, userId string) error {
+		invoiceRemovedId = id
+		return nil
+	})
+	var invoiceProjectionEdited repository.InvoiceProjection
+	_mockRepository.AddEditCall(func(ctx context.Context, invoiceProjection repository.InvoiceProjection) (*repository.InvoiceProjection, error) {
+		invoiceProjectionEdited = invoiceProjection
+		return &invoiceProjection, nil
+	})
+	var recordCtxList []aservice.RecordContext
+	_mockAuditProcess := &mockAuditProcess{}
+	for i := 0; i < 2; i++ {
+		_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+			recordCtxList = append(recordCtxList, recordCtx)
+			return nil
+		})
+	}
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, true, stat.ProjectionIsFound)
+	assert.Equal(t, true, stat.ProjectionIsAlreadyDone)
+	assert.Equal(t, false, stat.InvoiceIsModified)
+	assert.Equal(t, "cd1cc27b-28a1-47dc-ac76-70e8185e159d", stat.InvoiceProjection.Id)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", invoiceRemovedId)
+	assert.Equal(t, false, invoiceProjectionEdited.IsAlreadyDone)
+	assert.Equal(t, 2, len(recordCtxList))
+	assert.Equal(t, aservice.ACTION_DELETE, recordCtxList[0].Action)
+	assert.Equal(t, aservice.ENTITY_INVOICE, recordCtxList[0].Entity)
+	assert.Equal(t, aservice.ACTION_REVERT, recordCtxList[1].Action)
+	assert.Equal(t, aservice.ENTITY_INVOICE_PROJECTION, recordCtxList[1].Entity)
+}
+
+func TestRevertInvoiceKeepInvoice(t *testing.T) {
+	invoiceProjectMock, invoiceMock := buildRevertInvoiceMocks(time.Now())
+	invoiceMock.Value = 800
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddGetInvoiceByProjectionIdCall(func(ctx context.Context, invoiceProjectionId string, userId string) (*repository.Invoice, error) {
+		return invoiceMock, nil
+	})
+	var invoiceUnlinkedId string
+	_mockRepository.AddUnlinkInvoiceCall(func(ctx context.Context, id string, userId string) error {
+		invoiceUnlinkedId = id
+		return nil
+	})
+	_mockRepository.AddRemoveInvoiceCall(func(ctx context.Context, id string, userId string) error {
+		return errors.New("The invoice should not be removed")
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, invoiceProjection repository.InvoiceProjection) (*repository.InvoiceProjection, error) {
+		return &invoiceProjection, nil
+	})
+	var recordCtxList []aservice.RecordContext
+	_mockAuditProcess := &mockAuditProcess{}
+	for i := 0; i < 2; i++ {
+		_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+			recordCtxList = append(recordCtxList, recordCtx)
+			return nil
+		})
+	}
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{KeepInvoice: true}))
+	assert.NoError(t, err)
+	assert.NotNil(t, stat.InvoiceProjection)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", invoiceUnlinkedId)
+	assert.Equal(t, aservice.ACTION_UPDATE, recordCtxList[0].Action)
+	assert.Equal(t, "", recordCtxList[0].After.(*InvoiceResponse).InvoiceProjectionId)
+}
+
+func TestRevertInvoiceModified(t *testing.T) {
+	invoiceProjectMock, invoiceMock := buildRevertInvoiceMocks(time.Now())
+	invoiceMock.Version = 2
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddGetInvoiceByProjectionIdCall(func(ctx context.Context, invoiceProjectionId string, userId string) (*repository.Invoice, error) {
+		return invoiceMock, nil
+	})
+	_mockRepository.AddRemoveInvoiceCall(func(ctx context.Context, id string, userId string) error {
+		return errors.New("The invoice should not be removed")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, true, stat.InvoiceIsModified)
+	assert.Nil(t, stat.InvoiceProjection)
+}
+
+func TestRevertInvoiceModifiedForce(t *testing.T) {
+	invoiceProjectMock, invoiceMock := buildRevertInvoiceMocks(time.Now())
+	invoiceMock.Version = 2
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddGetInvoiceByProjectionIdCall(func(ctx context.Context, invoiceProjectionId string, userId string) (*repository.Invoice, error) {
+		return invoiceMock, nil
+	})
+	var invoiceRemovedId string
+	_mockRepository.AddRemoveInvoiceCall(func(ctx context.Context, id string, userId string) error {
+		invoiceRemovedId = id
+		return nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, invoiceProjection repository.InvoiceProjection) (*repository.InvoiceProjection, error) {
+		return &invoiceProjection, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{Force: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, false, stat.InvoiceIsModified)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", invoiceRemovedId)
+}
+
+func TestRevertInvoiceOverriddenOnRealization(t *testing.T) {
+	invoiceProjectMock, invoiceMock := buildRevertInvoiceMocks(time.Now())
+	invoiceMock.Value = money.FromCents(80000)
+	invoiceMock.PayAt = invoiceMock.PayAt.AddDate(0, 0, 2)
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddGetInvoiceByProjectionIdCall(func(ctx context.Context, invoiceProjectionId string, userId string) (*repository.Invoice, error) {
+		return invoiceMock, nil
+	})
+	var invoiceRemovedId string
+	_mockRepository.AddRemoveInvoiceCall(func(ctx context.Context, id string, userId string) error {
+		invoiceRemovedId = id
+		return nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, invoiceProjection repository.InvoiceProjection) (*repository.InvoiceProjection, error) {
+		return &invoiceProjection, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, false, stat.InvoiceIsModified)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", invoiceRemovedId)
+}
+
+func TestRevertInvoiceWithoutLinkedInvoice(t *testing.T) {
+	invoiceProjectMock, _ := buildRevertInvoiceMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, invoiceProjection repository.InvoiceProjection) (*repository.InvoiceProjection, error) {
+		return &invoiceProjection, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.NoError(t, err)
+	assert.NotNil(t, stat.InvoiceProjection)
+}
+
+func TestRevertInvoiceNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return nil, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, false, stat.ProjectionIsFound)
+}
+
+func TestRevertInvoiceNotRealized(t *testing.T) {
+	invoiceProjectMock, _ := buildRevertInvoiceMocks(time.Now())
+	invoiceProjectMock.IsAlreadyDone = false
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, true, stat.ProjectionIsFound)
+	assert.Equal(t, false, stat.ProjectionIsAlreadyDone)
+}
+
+func TestRevertInvoiceRemoveInvoiceFail(t *testing.T) {
+	invoiceProjectMock, invoiceMock := buildRevertInvoiceMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddGetInvoiceByProjectionIdCall(func(ctx context.Context, invoiceProjectionId string, userId string) (*repository.Invoice, error) {
+		return invoiceMock, nil
+	})
+	_mockRepository.AddRemoveInvoiceCall(func(ctx context.Context, id string, userId string) error {
+		return errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	_, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.Error(t, err)
+}
+
+func TestRevertInvoiceEditFail(t *testing.T) {
+	invoiceProjectMock, _ := buildRevertInvoiceMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, invoiceProjection repository.InvoiceProjection) (*repository.InvoiceProjection, error) {
+		return nil, errors.New("An error has been ocurred")
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	_, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.Error(t, err)
+}
+
+func TestRevertInvoiceTrashedInvoice(t *testing.T) {
+	invoiceProjectMock, invoiceMock := buildRevertInvoiceMocks(time.Now())
+	invoiceMock.Version = 2
+	invoiceMock.DeletedAt = time.Now()
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddGetInvoiceByProjectionIdCall(func(ctx context.Context, invoiceProjectionId string, userId string) (*repository.Invoice, error) {
+		return invoiceMock, nil
+	})
+	var invoiceUnlinkedId string
+	_mockRepository.AddUnlinkInvoiceCall(func(ctx context.Context, id string, userId string) error {
+		invoiceUnlinkedId = id
+		return nil
+	})
+	_mockRepository.AddRemoveInvoiceCall(func(ctx context.Context, id string, userId string) error {
+		return errors.New("The invoice should not be removed")
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, invoiceProjection repository.InvoiceProjection) (*repository.InvoiceProjection, error) {
+		return &invoiceProjection, nil
+	})
+	_mockAuditProcess := &mockAuditProcess{}
+	for i := 0; i < 2; i++ {
+		_mockAuditProcess.AddRecordCall(func(recordCtx aservice.RecordContext) error {
+			return nil
+		})
+	}
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _mockAuditProcess, &mockReferenceChecker{}, &mockTransactor{})
+	stat, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, false, stat.InvoiceIsModified)
+	assert.NotNil(t, stat.InvoiceProjection)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", invoiceUnlinkedId)
+}
+
+func TestRevertInvoiceEditConflict(t *testing.T) {
+	invoiceProjectMock, _ := buildRevertInvoiceMocks(time.Now())
+	_mockRepository := &mockRepository{}
+	_mockRepository.AddGetByIdCall(func(ctx context.Context, id string, userId string) (*repository.InvoiceProjection, error) {
+		return invoiceProjectMock, nil
+	})
+	_mockRepository.AddEditCall(func(ctx context.Context, invoiceProjection repository.InvoiceProjection) (*repository.InvoiceProjection, error) {
+		return nil, repository.ErrVersionConflict
+	})
+
+	uuidMock := func() uuid.UUID {
+		return uuid.FromStringOrNil("71e31eb6-dde2-4dcb-b7ef-c7e8a699c628")
+	}
+
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockAuditProcess{}, &mockReferenceChecker{}, &mockTransactor{})
+	_, err := _storageProcess.RevertInvoice(newRevertInvoiceCtx(RevertInvoiceRequest{}))
+	assert.Equal(t, apperror.KIND_CONFLICT, apperror.KindOf(err))
+}
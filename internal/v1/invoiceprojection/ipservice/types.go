@@ -3,8 +3,14 @@ package ipservice
 import (
 	"context"
 	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/repository"
 )
 
+// ErrVersionConflict is returned when the version informed by the client is not the current one of the record
+var ErrVersionConflict = repository.ErrVersionConflict
+
 type CreateContext struct {
 	Ctx       context.Context
 	Request   CreateRequest
@@ -16,6 +22,22 @@ type UpdateContext struct {
 	Request   UpdateRequest
 	UserToken string
 	Id        string
+	Version   uint
+}
+
+type PatchContext struct {
+	Ctx       context.Context
+	Request   PatchRequest
+	UserToken string
+	Id        string
+	Version   uint
+}
+
+type DeleteContext struct {
+	Ctx       context.Context
+	UserToken string
+	Id        string
+	Version   uint
 }
 
 type CreateInvoiceContext struct {
@@ -25,6 +47,31 @@ type CreateInvoiceContext struct {
 	Id        string
 }
 
+type RevertInvoiceContext struct {
+	Ctx       context.Context
+	Request   RevertInvoiceRequest
+	UserToken string
+	Id        string
+}
+
+type CreateInvoiceBatchContext struct {
+	Ctx       context.Context
+	Request   CreateInvoiceBatchRequest
+	UserToken string
+}
+
+type ParseBoletoContext struct {
+	Ctx       context.Context
+	Request   ParseBoletoRequest
+	UserToken string
+}
+
+type CreateFromBoletoContext struct {
+	Ctx       context.Context
+	Request   CreateFromBoletoRequest
+	UserToken string
+}
+
 type SearchContext struct {
 	Ctx       context.Context
 	Params    SearchParams
@@ -33,28 +80,80 @@ type SearchContext struct {
 }
 
 type CreateRequest struct {
-	PayIn         time.Time `json:"pay_in"`
-	BuyAt         time.Time `json:"buy_at"`
-	Description   string    `json:"description"`
-	Value         float64   `json:"value"`
-	Recurrence    uint      `json:"recurrence"`
-	CategoryId    uint      `json:"category_id"`
-	PaymentTypeId uint      `json:"payment_type_id"`
+	PayIn         time.Time   `json:"pay_in" binding:"required"`
+	BuyAt         time.Time   `json:"buy_at" binding:"required"`
+	Description   string      `json:"description" binding:"notblank,max=255"`
+	Value         money.Money `json:"value" binding:"gt=0" swaggertype:"number"`
+	Currency      string      `json:"currency" binding:"omitempty,iso4217"`
+	Recurrence    uint        `json:"recurrence" binding:"max=120"`
+	CategoryId    uint        `json:"category_id" binding:"required"`
+	PaymentTypeId uint        `json:"payment_type_id" binding:"required"`
 }
 
 type UpdateRequest struct {
-	PayIn         time.Time `json:"pay_in"`
-	BuyAt         time.Time `json:"buy_at"`
-	Description   string    `json:"description"`
-	Value         float64   `json:"value"`
-	CategoryId    uint      `json:"category_id"`
-	PaymentTypeId uint      `json:"payment_type_id"`
+	PayIn         time.Time   `json:"pay_in" binding:"required"`
+	BuyAt         time.Time   `json:"buy_at" binding:"required"`
+	Description   string      `json:"description" binding:"notblank,max=255"`
+	Value         money.Money `json:"value" binding:"gt=0" swaggertype:"number"`
+	Currency      string      `json:"currency" binding:"omitempty,iso4217"`
+	CategoryId    uint        `json:"category_id" binding:"required"`
+	PaymentTypeId uint        `json:"payment_type_id" binding:"required"`
+}
+
+// PatchRequest holds only the fields that must be changed, the omitted ones keep the stored value
+type PatchRequest struct {
+	PayIn         *time.Time   `json:"pay_in"`
+	BuyAt         *time.Time   `json:"buy_at"`
+	Description   *string      `json:"description" binding:"omitempty,notblank,max=255"`
+	Value         *money.Money `json:"value" binding:"omitempty,gt=0" swaggertype:"number"`
+	Currency      *string      `json:"currency" binding:"omitempty,iso4217"`
+	CategoryId    *uint        `json:"category_id" binding:"omitempty,min=1"`
+	PaymentTypeId *uint        `json:"payment_type_id" binding:"omitempty,min=1"`
 }
 
 type CreateInvoiceRequest struct {
-	Value float64   `json:"value"`
-	PayIn time.Time `json:"pay_in"`
-	BuyAt time.Time `json:"buy_at"`
+	Value money.Money `json:"value" binding:"gte=0" swaggertype:"number"`
+	PayIn time.Time   `json:"pay_in"`
+	BuyAt time.Time   `json:"buy_at"`
+}
+
+type CreateInvoiceBatchRequest struct {
+	Items  []CreateInvoiceBatchItem  `json:"items" binding:"dive"`
+	Filter *CreateInvoiceBatchFilter `json:"filter"`
+}
+
+type CreateInvoiceBatchItem struct {
+	Id    string      `json:"id"`
+	Value money.Money `json:"value" binding:"gte=0" swaggertype:"number"`
+	PayIn time.Time   `json:"pay_in"`
+	BuyAt time.Time   `json:"buy_at"`
+}
+
+type CreateInvoiceBatchFilter struct {
+	Month      uint `json:"month"`
+	Year       uint `json:"year"`
+	CategoryId uint `json:"category_id"`
+}
+
+type ParseBoletoRequest struct {
+	Code string `json:"code" binding:"notblank,max=64"`
+}
+
+// CreateFromBoletoRequest takes the value and the due date from the boleto, the informed ones take precedence
+// and are required when the boleto does not carry them
+type CreateFromBoletoRequest struct {
+	Code          string      `json:"code" binding:"notblank,max=64"`
+	Description   string      `json:"description" binding:"notblank,max=255"`
+	Value         money.Money `json:"value" binding:"gte=0" swaggertype:"number"`
+	PayIn         time.Time   `json:"pay_in"`
+	BuyAt         time.Time   `json:"buy_at"`
+	CategoryId    uint        `json:"category_id" binding:"required"`
+	PaymentTypeId uint        `json:"payment_type_id"`
+}
+
+type RevertInvoiceRequest struct {
+	KeepInvoice bool `json:"keep_invoice"`
+	Force       bool `json:"force"`
 }
 
 type InvoiceProjectionResponse struct {
@@ -62,10 +161,27 @@ type InvoiceProjectionResponse struct {
 	PayIn       time.Time           `json:"pay_in"`
 	BuyAt       time.Time           `json:"buy_at"`
 	Description string              `json:"description"`
-	Value       float64             `json:"value"`
+	Value       money.Money         `json:"value" swaggertype:"number"`
+	Currency    string              `json:"currency"`
+	BoletoCode  string              `json:"boleto_code,omitempty"`
 	Recurrence  uint                `json:"recurrence,omitempty"`
 	Category    CategoryResponse    `json:"category"`
 	PaymentType PaymentTypeResponse `json:"payment_type"`
+	DeletedAt   *time.Time          `json:"deleted_at,omitempty"`
+	Version     uint                `json:"-"`
+}
+
+type BoletoResponse struct {
+	Kind          string      `json:"kind"`
+	Barcode       string      `json:"barcode"`
+	DigitableLine string      `json:"digitable_line"`
+	BankCode      string      `json:"bank_code,omitempty"`
+	Value         money.Money `json:"value" swaggertype:"number"`
+	DueDate       *time.Time  `json:"due_date,omitempty"`
+}
+
+type InvoiceProjectionTrashResponse struct {
+	Records []InvoiceProjectionResponse `json:"records"`
 }
 
 type CategoryResponse struct {
@@ -83,15 +199,33 @@ type InvoiceResponse struct {
 	PayAt               time.Time           `json:"pay_at"`
 	BuyAt               time.Time           `json:"buy_at"`
 	Description         string              `json:"description"`
-	Value               float64             `json:"value"`
+	Value               money.Money         `json:"value" swaggertype:"number"`
+	Currency            string              `json:"currency"`
 	Category            CategoryResponse    `json:"category"`
 	PaymentType         PaymentTypeResponse `json:"payment_type"`
 }
 
 type InvoiceStat struct {
+	ProjectionIsFound       bool             `json:"projection_is_found"`
+	ProjectionIsAlreadyDone bool             `json:"projection_is_already_done"`
+	Invoice                 *InvoiceResponse `json:"invoice,omitempty"`
+}
+
+type InvoiceBatchItemResult struct {
+	ProjectionId string `json:"projection_id"`
+	InvoiceStat
+}
+
+type InvoiceBatchResponse struct {
+	TotalCreated uint                     `json:"total_created"`
+	Results      []InvoiceBatchItemResult `json:"results"`
+}
+
+type RevertInvoiceStat struct {
 	ProjectionIsFound       bool
 	ProjectionIsAlreadyDone bool
-	Invoice                 *InvoiceResponse
+	InvoiceIsModified       bool
+	InvoiceProjection       *InvoiceProjectionResponse
 }
 
 type InvoiceProjectionPaginateResponse struct {
@@ -1,6 +1,10 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type InvoiceProjectionBuilder struct {
 	id            string
@@ -8,11 +12,14 @@ type InvoiceProjectionBuilder struct {
 	buyAt         time.Time
 	payIn         time.Time
 	description   string
-	value         float64
+	value         money.Money
+	currency      string
+	boletoCode    string
 	isAlreadyDone bool
 	userId        string
 	category      InvoiceCategory
 	paymentType   PaymentType
+	version       uint
 }
 
 func NewInvoiceProjectionBuilder() *InvoiceProjectionBuilder {
@@ -38,10 +45,18 @@ func (builder *InvoiceProjectionBuilder) AddDescription(description string) *Inv
 	builder.description = description
 	return builder
 }
-func (builder *InvoiceProjectionBuilder) AddValue(value float64) *InvoiceProjectionBuilder {
+func (builder *InvoiceProjectionBuilder) AddValue(value money.Money) *InvoiceProjectionBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *InvoiceProjectionBuilder) AddCurrency(currency string) *InvoiceProjectionBuilder {
+	builder.currency = currency
+	return builder
+}
+func (builder *InvoiceProjectionBuilder) AddBoletoCode(boletoCode string) *InvoiceProjectionBuilder {
+	builder.boletoCode = boletoCode
+	return builder
+}
 func (builder *InvoiceProjectionBuilder) AddPaymentType(paymentType PaymentType) *InvoiceProjectionBuilder {
 	builder.paymentType = paymentType
 	return builder
@@ -58,6 +73,10 @@ func (builder *InvoiceProjectionBuilder) AddCategory(category InvoiceCategory) *
 	builder.category = category
 	return builder
 }
+func (builder *InvoiceProjectionBuilder) AddVersion(version uint) *InvoiceProjectionBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *InvoiceProjectionBuilder) Build() *InvoiceProjection {
 	invoiceProjection := InvoiceProjection{}
 
@@ -67,20 +86,24 @@ func (builder *InvoiceProjectionBuilder) Build() *InvoiceProjection {
 	invoiceProjection.BuyAt = builder.buyAt
 	invoiceProjection.Description = builder.description
 	invoiceProjection.Value = builder.value
+	invoiceProjection.Currency = builder.currency
+	invoiceProjection.BoletoCode = builder.boletoCode
 	invoiceProjection.PaymentType = builder.paymentType
 	invoiceProjection.IsAlreadyDone = builder.isAlreadyDone
 	invoiceProjection.UserId = builder.userId
 	invoiceProjection.Category = builder.category
+	invoiceProjection.Version = builder.version
 
 	return &invoiceProjection
 }
 
 type QueryParamsBuilder struct {
-	userId string
-	limit  uint
-	offset uint
-	month  uint
-	year   uint
+	userId     string
+	limit      uint
+	offset     uint
+	month      uint
+	year       uint
+	categoryId uint
 }
 
 func NewQueryParamsBuilder() *QueryParamsBuilder {
@@ -106,13 +129,18 @@ func (builder *QueryParamsBuilder) AddOffset(offset uint) *QueryParamsBuilder {
 	builder.offset = offset
 	return builder
 }
+func (builder *QueryParamsBuilder) AddCategoryId(categoryId uint) *QueryParamsBuilder {
+	builder.categoryId = categoryId
+	return builder
+}
 func (builder *QueryParamsBuilder) Build() QueryParams {
 	return QueryParams{
-		userId: builder.userId,
-		month:  builder.month,
-		year:   builder.year,
-		limit:  builder.limit,
-		offset: builder.offset,
+		userId:     builder.userId,
+		month:      builder.month,
+		year:       builder.year,
+		categoryId: builder.categoryId,
+		limit:      builder.limit,
+		offset:     builder.offset,
 	}
 }
 
@@ -122,9 +150,11 @@ type InvoiceBuilder struct {
 	buyAt               time.Time
 	payAt               time.Time
 	description         string
-	value               float64
+	value               money.Money
+	currency            string
 	userId              string
 	category            InvoiceCategory
+	version             uint
 	paymentType         PaymentType
 	invoiceProjectionId string
 }
@@ -152,10 +182,14 @@ func (builder *InvoiceBuilder) AddDescription(description string) *InvoiceBuilde
 	builder.description = description
 	return builder
 }
-func (builder *InvoiceBuilder) AddValue(value float64) *InvoiceBuilder {
+func (builder *InvoiceBuilder) AddValue(value money.Money) *InvoiceBuilder {
 	builder.value = value
 	return builder
 }
+func (builder *InvoiceBuilder) AddCurrency(currency string) *InvoiceBuilder {
+	builder.currency = currency
+	return builder
+}
 func (builder *InvoiceBuilder) AddPaymentType(paymentType PaymentType) *InvoiceBuilder {
 	builder.paymentType = paymentType
 	return builder
@@ -172,6 +206,10 @@ func (builder *InvoiceBuilder) AddCategory(category InvoiceCategory) *InvoiceBui
 	builder.category = category
 	return builder
 }
+func (builder *InvoiceBuilder) AddVersion(version uint) *InvoiceBuilder {
+	builder.version = version
+	return builder
+}
 func (builder *InvoiceBuilder) Build() *Invoice {
 	invoice := Invoice{}
 
@@ -181,10 +219,12 @@ func (builder *InvoiceBuilder) Build() *Invoice {
 	invoice.BuyAt = builder.buyAt
 	invoice.Description = builder.description
 	invoice.Value = builder.value
+	invoice.Currency = builder.currency
 	invoice.PaymentType = builder.paymentType
 	invoice.InvoiceProjectionId = builder.invoiceProjectionId
 	invoice.UserId = builder.userId
 	invoice.Category = builder.category
+	invoice.Version = builder.version
 
 	return &invoice
 }
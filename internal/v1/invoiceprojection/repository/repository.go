@@ -3,19 +3,37 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 )
 
 type Repository interface {
 	Save(ctx context.Context, invoiceProjection InvoiceProjection) (*InvoiceProjection, error)
 	GetById(ctx context.Context, id string, userId string) (*InvoiceProjection, error)
 	Edit(ctx context.Context, invoiceProjection InvoiceProjection) (*InvoiceProjection, error)
-	Remove(ctx context.Context, id string, userId string) error
+	Remove(ctx context.Context, id string, userId string, version uint) error
 	GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error)
 	GetAll(ctx context.Context, params QueryParams) (*[]InvoiceProjection, error)
 	SaveInvoice(ctx context.Context, invoice Invoice) (*Invoice, error)
+	GetAllPending(ctx context.Context, params QueryParams) (*[]InvoiceProjection, error)
+	RealizeInvoices(ctx context.Context, invoices []Invoice) error
+	GetInvoiceByProjectionId(ctx context.Context, invoiceProjectionId string, userId string) (*Invoice, error)
+	RemoveInvoice(ctx context.Context, id string, userId string) error
+	UnlinkInvoice(ctx context.Context, id string, userId string) error
+	GetTrash(ctx context.Context, userId string) (*[]InvoiceProjection, error)
+	Restore(ctx context.Context, id string, userId string) error
+	Purge(ctx context.Context, deletedBefore time.Time) error
 }
 
+// ErrVersionConflict is returned when the record was changed after the version informed by the caller
+var ErrVersionConflict = errors.New("the record was modified by another request")
+
+// ErrAlreadyDone is returned when a projection was realized by another request while it was being realized
+var ErrAlreadyDone = errors.New("the projection is already done")
+
 type repository struct {
 	db *sql.DB
 }
@@ -25,13 +43,13 @@ func New(db *sql.DB) Repository {
 }
 
 func (r *repository) Save(ctx context.Context, invoiceProjection InvoiceProjection) (*InvoiceProjection, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, is_already_done, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, currency, boleto_code, is_already_done, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +61,8 @@ func (r *repository) Save(ctx context.Context, invoiceProjection InvoiceProjecti
 		invoiceProjection.BuyAt,
 		invoiceProjection.Description,
 		invoiceProjection.Value,
+		invoiceProjection.Currency,
+		invoiceProjection.BoletoCode,
 		invoiceProjection.IsAlreadyDone,
 		invoiceProjection.UserId,
 		invoiceProjection.Category.Id,
@@ -59,7 +79,7 @@ func (r *repository) Save(ctx context.Context, invoiceProjection InvoiceProjecti
 }
 
 func (r *repository) GetById(ctx context.Context, id string, userId string) (*InvoiceProjection, error) {
-	results, err := r.db.QueryContext(ctx, `
+	results, err := dbtx.Get(ctx, r.db).QueryContext(ctx, `
 		SELECT
 			ip.id,
 			ip.created_at,
@@ -67,26 +87,29 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*In
 			ip.buy_at,
 			ip.description,
 			ip.value,
+			ip.currency,
+			ip.boleto_code,
 			ip.is_already_done,
 			ip.user_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			ip.version
 		FROM
 			invoice_projection ip
 		INNER JOIN invoice_category ic ON 
 			ic.id = ip.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = ip.payment_type_id
-		WHERE ip.id = ? AND ip.user_id = ?`, id, userId)
+		WHERE ip.id = ? AND ip.user_id = ? AND ip.deleted_at IS NULL`, id, userId)
 	if err != nil {
 		return nil, err
 	}
 	defer results.Close()
 	invoiceProjection := &InvoiceProjection{Category: InvoiceCategory{}, PaymentType: PaymentType{}}
 	if results.Next() {
-		var value sql.NullFloat64
+		var value money.NullMoney
 		var categoryId sql.NullInt64
 		var paymentTypeId sql.NullInt64
 		var createdAtTimestamp sql.NullInt64
@@ -97,12 +120,15 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*In
 			&invoiceProjection.BuyAt,
 			&invoiceProjection.Description,
 			&value,
+			&invoiceProjection.Currency,
+			&invoiceProjection.BoletoCode,
 			&invoiceProjection.IsAlreadyDone,
 			&invoiceProjection.UserId,
 			&categoryId,
 			&invoiceProjection.Category.Category,
 			&paymentTypeId,
 			&invoiceProjection.PaymentType.Type,
+			&invoiceProjection.Version,
 		)
 		if err != nil {
 			return nil, err
@@ -110,7 +136,7 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*In
 		invoiceProjection.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
 		invoiceProjection.Category.Id = uint(categoryId.Int64)
 		invoiceProjection.PaymentType.Id = uint(paymentTypeId.Int64)
-		invoiceProjection.Value = value.Float64
+		invoiceProjection.Value = value.Money
 	} else {
 		return nil, nil
 	}
@@ -118,31 +144,41 @@ func (r *repository) GetById(ctx context.Context, id string, userId string) (*In
 }
 
 func (r *repository) Edit(ctx context.Context, invoiceProjection InvoiceProjection) (*InvoiceProjection, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, category_id = ?, is_already_done = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`)
+		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, is_already_done = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`)
 	if err != nil {
 		return nil, err
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(
+	result, err := stmt.Exec(
 		invoiceProjection.PayIn,
 		invoiceProjection.BuyAt,
 		invoiceProjection.Description,
 		invoiceProjection.Value,
+		invoiceProjection.Currency,
 		invoiceProjection.Category.Id,
 		invoiceProjection.IsAlreadyDone,
 		invoiceProjection.PaymentType.Id,
 		invoiceProjection.Id,
 		invoiceProjection.UserId,
+		invoiceProjection.Version,
 	)
 	if err != nil {
 		return nil, err
 	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return nil, ErrVersionConflict
+	}
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -150,20 +186,28 @@ func (r *repository) Edit(ctx context.Context, invoiceProjection InvoiceProjecti
 	return &invoiceProjection, nil
 }
 
-func (r *repository) Remove(ctx context.Context, id string, userId string) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+func (r *repository) Remove(ctx context.Context, id string, userId string, version uint) error {
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return err
 	}
-	stmt, err := tx.PrepareContext(ctx, `DELETE FROM invoice_projection WHERE id = ? AND user_id = ?`)
+	stmt, err := tx.PrepareContext(ctx, `UPDATE invoice_projection SET deleted_at = ?, version = version + 1 WHERE id = ? AND user_id = ? AND version = ? AND deleted_at IS NULL`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(id, userId)
+	result, err := stmt.Exec(time.Now().Unix(), id, userId, version)
 	if err != nil {
 		return err
 	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return ErrVersionConflict
+	}
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -173,8 +217,8 @@ func (r *repository) Remove(ctx context.Context, id string, userId string) error
 
 func (r *repository) GetTotalRecords(ctx context.Context, params QueryParams) (*uint, error) {
 	var totalRecords uint
-	query := `SELECT COUNT(*) as total_records FROM invoice_projection WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ?`
-	row := r.db.QueryRowContext(ctx, query, params.month, params.year, params.userId)
+	query := `SELECT COUNT(*) as total_records FROM invoice_projection WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ? AND deleted_at IS NULL`
+	row := dbtx.Get(ctx, r.db).QueryRowContext(ctx, query, params.month, params.year, params.userId)
 	err := row.Scan(&totalRecords)
 	if err != nil {
 		return nil, err
@@ -191,6 +235,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 			ip.buy_at,
 			ip.description,
 			ip.value,
+			ip.currency,
 			ip.is_already_done,
 			ip.user_id,
 			ic.id,
@@ -204,9 +249,9 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 		INNER JOIN payment_type pt ON
 			pt.id = ip.payment_type_id
 		WHERE 
-			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ?
+			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ? AND ip.deleted_at IS NULL
 		LIMIT ? OFFSET ?`
-	rows, err := r.db.QueryContext(ctx, query, params.month, params.year, params.userId, params.limit, params.offset)
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, params.month, params.year, params.userId, params.limit, params.offset)
 	if err != nil {
 		return nil, err
 	}
@@ -214,7 +259,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 
 	var invoiceProjectionList []InvoiceProjection
 	for rows.Next() {
-		var value sql.NullFloat64
+		var value money.NullMoney
 		var categoryId sql.NullInt64
 		var paymentTypeId sql.NullInt64
 		var createdAtTimestamp sql.NullInt64
@@ -229,6 +274,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 			&ip.BuyAt,
 			&ip.Description,
 			&value,
+			&ip.Currency,
 			&ip.IsAlreadyDone,
 			&ip.UserId,
 			&categoryId,
@@ -239,7 +285,7 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 			return nil, err
 		}
 		ip.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
-		ip.Value = value.Float64
+		ip.Value = value.Money
 		category.Id = uint(categoryId.Int64)
 		ip.Category = category
 		paymentType.Id = uint(paymentTypeId.Int64)
@@ -252,13 +298,13 @@ func (r *repository) GetAll(ctx context.Context, params QueryParams) (*[]Invoice
 }
 
 func (r *repository) SaveInvoice(ctx context.Context, invoice Invoice) (*Invoice, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := dbtx.Begin(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, invoice_projection_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, invoice_projection_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -270,6 +316,7 @@ func (r *repository) SaveInvoice(ctx context.Context, invoice Invoice) (*Invoice
 		invoice.BuyAt,
 		invoice.Description,
 		invoice.Value,
+		invoice.Currency,
 		invoice.UserId,
 		invoice.Category.Id,
 		invoice.InvoiceProjectionId,
@@ -284,3 +331,419 @@ func (r *repository) SaveInvoice(ctx context.Context, invoice Invoice) (*Invoice
 	}
 	return &invoice, nil
 }
+
+func (r *repository) GetAllPending(ctx context.Context, params QueryParams) (*[]InvoiceProjection, error) {
+	query := `
+		SELECT
+			ip.id,
+			ip.created_at,
+			ip.pay_in,
+			ip.buy_at,
+			ip.description,
+			ip.value,
+			ip.currency,
+			ip.is_already_done,
+			ip.user_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name
+		FROM
+			invoice_projection ip
+		INNER JOIN invoice_category ic ON 
+			ic.id = ip.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = ip.payment_type_id
+		WHERE 
+			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ? AND ip.deleted_at IS NULL
+			AND ip.is_already_done = false AND (? = 0 OR ip.category_id = ?)
+		ORDER BY ip.pay_in ASC`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, params.month, params.year, params.userId, params.categoryId, params.categoryId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoiceProjectionList []InvoiceProjection
+	for rows.Next() {
+		var value money.NullMoney
+		var categoryId sql.NullInt64
+		var paymentTypeId sql.NullInt64
+		var createdAtTimestamp sql.NullInt64
+		var ip InvoiceProjection
+		var category InvoiceCategory
+		var paymentType PaymentType
+
+		err := rows.Scan(
+			&ip.Id,
+			&createdAtTimestamp,
+			&ip.PayIn,
+			&ip.BuyAt,
+			&ip.Description,
+			&value,
+			&ip.Currency,
+			&ip.IsAlreadyDone,
+			&ip.UserId,
+			&categoryId,
+			&category.Category,
+			&paymentTypeId,
+			&paymentType.Type)
+		if err != nil {
+			return nil, err
+		}
+		ip.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		ip.Value = value.Money
+		category.Id = uint(categoryId.Int64)
+		ip.Category = category
+		paymentType.Id = uint(paymentTypeId.Int64)
+		ip.PaymentType = paymentType
+
+		invoiceProjectionList = append(invoiceProjectionList, ip)
+	}
+
+	return &invoiceProjectionList, nil
+}
+
+// RealizeInvoices saves every invoice and flags its projection as done in a single transaction
+// RealizeInvoices creates the invoices and flags their projections as done, failing with ErrAlreadyDone when a
+// projection was already realized so that no projection is realized twice
+func (r *repository) RealizeInvoices(ctx context.Context, invoices []Invoice) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	saveStmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, invoice_projection_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer saveStmt.Close()
+	doneStmt, err := tx.PrepareContext(ctx, `UPDATE invoice_projection SET is_already_done = true, version = version + 1 WHERE id = ? AND user_id = ? AND is_already_done = false`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer doneStmt.Close()
+	for _, invoice := range invoices {
+		_, err = saveStmt.Exec(
+			invoice.Id,
+			invoice.CreatedAt.Unix(),
+			invoice.PayAt,
+			invoice.BuyAt,
+			invoice.Description,
+			invoice.Value,
+			invoice.Currency,
+			invoice.UserId,
+			invoice.Category.Id,
+			invoice.InvoiceProjectionId,
+			invoice.PaymentType.Id,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		result, err := doneStmt.Exec(invoice.InvoiceProjectionId, invoice.UserId)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if rowsAffected == 0 {
+			tx.Rollback()
+			return ErrAlreadyDone
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetInvoiceByProjectionId finds the invoice realized from the projection, including the one moved to the trash while
+// still linked to it
+func (r *repository) GetInvoiceByProjectionId(ctx context.Context, invoiceProjectionId string, userId string) (*Invoice, error) {
+	results, err := dbtx.Get(ctx, r.db).QueryContext(ctx, `
+		SELECT
+			i.id,
+			i.created_at,
+			i.pay_at,
+			i.buy_at,
+			i.description,
+			i.value,
+			i.currency,
+			i.user_id,
+			i.invoice_projection_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name,
+			i.version,
+			i.deleted_at
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON 
+			ic.id = i.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = i.payment_type_id
+		WHERE i.invoice_projection_id = ? AND i.user_id = ?`, invoiceProjectionId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	invoice := &Invoice{Category: InvoiceCategory{}, PaymentType: PaymentType{}}
+	if results.Next() {
+		var value money.NullMoney
+		var categoryId sql.NullInt64
+		var paymentTypeId sql.NullInt64
+		var createdAtTimestamp sql.NullInt64
+		var invoiceProjectionId sql.NullString
+		var deletedAtTimestamp sql.NullInt64
+		err := results.Scan(
+			&invoice.Id,
+			&createdAtTimestamp,
+			&invoice.PayAt,
+			&invoice.BuyAt,
+			&invoice.Description,
+			&value,
+			&invoice.Currency,
+			&invoice.UserId,
+			&invoiceProjectionId,
+			&categoryId,
+			&invoice.Category.Category,
+			&paymentTypeId,
+			&invoice.PaymentType.Type,
+			&invoice.Version,
+			&deletedAtTimestamp,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if deletedAtTimestamp.Valid {
+			invoice.DeletedAt = time.Unix(deletedAtTimestamp.Int64, 0)
+		}
+		invoice.InvoiceProjectionId = invoiceProjectionId.String
+		invoice.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		invoice.Category.Id = uint(categoryId.Int64)
+		invoice.PaymentType.Id = uint(paymentTypeId.Int64)
+		invoice.Value = value.Money
+	} else {
+		return nil, nil
+	}
+	return invoice, nil
+}
+
+// RemoveInvoice moves the realized invoice to the trash dropping its link to the projection, so restoring it
+// later does not realize the projection twice
+func (r *repository) RemoveInvoice(ctx context.Context, id string, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE invoice SET deleted_at = ?, invoice_projection_id = NULL, version = version + 1 WHERE id = ? AND user_id = ? AND deleted_at IS NULL`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(time.Now().Unix(), id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) UnlinkInvoice(ctx context.Context, id string, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE invoice SET invoice_projection_id = NULL, version = version + 1 WHERE id = ? AND user_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetTrash(ctx context.Context, userId string) (*[]InvoiceProjection, error) {
+	query := `
+		SELECT
+			ip.id,
+			ip.created_at,
+			ip.pay_in,
+			ip.buy_at,
+			ip.description,
+			ip.value,
+			ip.currency,
+			ip.is_already_done,
+			ip.user_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name,
+			ip.deleted_at
+		FROM
+			invoice_projection ip
+		INNER JOIN invoice_category ic ON 
+			ic.id = ip.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = ip.payment_type_id
+		WHERE 
+			ip.user_id = ? AND ip.deleted_at IS NOT NULL
+		ORDER BY ip.deleted_at DESC`
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoiceProjectionList []InvoiceProjection
+	for rows.Next() {
+		var value money.NullMoney
+		var categoryId sql.NullInt64
+		var paymentTypeId sql.NullInt64
+		var createdAtTimestamp sql.NullInt64
+		var deletedAtTimestamp sql.NullInt64
+		var ip InvoiceProjection
+		var category InvoiceCategory
+		var paymentType PaymentType
+
+		err := rows.Scan(
+			&ip.Id,
+			&createdAtTimestamp,
+			&ip.PayIn,
+			&ip.BuyAt,
+			&ip.Description,
+			&value,
+			&ip.Currency,
+			&ip.IsAlreadyDone,
+			&ip.UserId,
+			&categoryId,
+			&category.Category,
+			&paymentTypeId,
+			&paymentType.Type,
+			&deletedAtTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		ip.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		ip.DeletedAt = time.Unix(deletedAtTimestamp.Int64, 0)
+		ip.Value = value.Money
+		category.Id = uint(categoryId.Int64)
+		ip.Category = category
+		paymentType.Id = uint(paymentTypeId.Int64)
+		ip.PaymentType = paymentType
+
+		invoiceProjectionList = append(invoiceProjectionList, ip)
+	}
+
+	return &invoiceProjectionList, nil
+}
+
+func (r *repository) Restore(ctx context.Context, id string, userId string) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `UPDATE invoice_projection SET deleted_at = NULL, version = version + 1 WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(id, userId)
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) Purge(ctx context.Context, deletedBefore time.Time) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	// The realized invoice outlives its projection, so it only loses the reference
+	detachStmt, err := tx.PrepareContext(ctx, `
+		UPDATE invoice SET invoice_projection_id = NULL, version = version + 1 
+		WHERE invoice_projection_id IN (SELECT id FROM invoice_projection WHERE deleted_at IS NOT NULL AND deleted_at < ?)`)
+	if err != nil {
+		return err
+	}
+	defer detachStmt.Close()
+	_, err = detachStmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	// The installment of a loan keeps its place in the schedule without the projection
+	detachInstallmentStmt, err := tx.PrepareContext(ctx, `
+		UPDATE loan_installment SET invoice_projection_id = NULL
+		WHERE invoice_projection_id IN (SELECT id FROM invoice_projection WHERE deleted_at IS NOT NULL AND deleted_at < ?)`)
+	if err != nil {
+		return err
+	}
+	defer detachInstallmentStmt.Close()
+	_, err = detachInstallmentStmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	// The goal does not depend on its generated projections, only the link to them is dropped
+	detachGoalStmt, err := tx.PrepareContext(ctx, `
+		DELETE FROM goal_projection
+		WHERE invoice_projection_id IN (SELECT id FROM invoice_projection WHERE deleted_at IS NOT NULL AND deleted_at < ?)`)
+	if err != nil {
+		return err
+	}
+	defer detachGoalStmt.Close()
+	_, err = detachGoalStmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	// The share of a split keeps its value without the projection that was paying it
+	detachShareStmt, err := tx.PrepareContext(ctx, `
+		UPDATE invoice_split_share SET invoice_projection_id = NULL
+		WHERE invoice_projection_id IN (SELECT id FROM invoice_projection WHERE deleted_at IS NOT NULL AND deleted_at < ?)`)
+	if err != nil {
+		return err
+	}
+	defer detachShareStmt.Close()
+	_, err = detachShareStmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM invoice_projection WHERE deleted_at IS NOT NULL AND deleted_at < ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(deletedBefore.Unix())
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
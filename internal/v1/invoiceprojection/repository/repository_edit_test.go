@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,26 +27,30 @@ func TestEditInvoiceProjectionSuccess(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, category_id = ?, is_already_done = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, is_already_done = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			invoicePMock.PayIn,
 			invoicePMock.BuyAt,
 			invoicePMock.Description,
 			invoicePMock.Value,
+			invoicePMock.Currency,
 			invoicePMock.Category.Id,
 			invoicePMock.IsAlreadyDone,
 			invoicePMock.PaymentType.Id,
 			invoicePMock.Id,
-			invoicePMock.UserId).
+			invoicePMock.UserId,
+			invoicePMock.Version).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit()
 
@@ -75,8 +80,10 @@ func TestEditInvoiceProjectionBeginFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
@@ -108,16 +115,18 @@ func TestEditInvoiceProjectionPrepareFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, category_id = ?, is_already_done = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, is_already_done = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Edit(context.Background(), *invoicePMock)
@@ -145,27 +154,31 @@ func TestEditInvoiceProjectionExecFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, category_id = ?, is_already_done = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, is_already_done = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			invoicePMock.PayIn,
 			invoicePMock.BuyAt,
 			invoicePMock.Description,
 			invoicePMock.Value,
+			invoicePMock.Currency,
 			invoicePMock.Category.Id,
 			invoicePMock.IsAlreadyDone,
 			invoicePMock.PaymentType.Id,
 			invoicePMock.Id,
-			invoicePMock.UserId).
+			invoicePMock.UserId,
+			invoicePMock.Version).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Edit(context.Background(), *invoicePMock)
@@ -193,27 +206,31 @@ func TestEditInvoiceProjectionCommitFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	_repository := New(dbMock)
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, category_id = ?, is_already_done = ?, payment_type_id = ? 
-		WHERE id = ? AND user_id = ?`).
+		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, is_already_done = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
 		ExpectExec().
 		WithArgs(
 			invoicePMock.PayIn,
 			invoicePMock.BuyAt,
 			invoicePMock.Description,
 			invoicePMock.Value,
+			invoicePMock.Currency,
 			invoicePMock.Category.Id,
 			invoicePMock.IsAlreadyDone,
 			invoicePMock.PaymentType.Id,
 			invoicePMock.Id,
-			invoicePMock.UserId).
+			invoicePMock.UserId,
+			invoicePMock.Version).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	sqlMock.ExpectCommit().WillReturnError(errors.New("An error has been ocurred"))
 
@@ -224,3 +241,56 @@ func TestEditInvoiceProjectionCommitFail(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestEditInvoiceProjectionVersionConflict(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	invoicePMock := NewInvoiceProjectionBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(now).
+		AddPayIn(now).
+		AddBuyAt(now).
+		AddIsAlreadyDone(false).
+		AddCategory(InvoiceCategory{Id: 1}).
+		AddDescription("Description de teste").
+		AddPaymentType(PaymentType{Id: 2}).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddUserId("User1").
+		AddVersion(3).
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(`
+		UPDATE invoice_projection SET pay_in = ?, buy_at = ?, description = ?, value = ?, currency = ?, category_id = ?, is_already_done = ?, payment_type_id = ?, version = version + 1 
+		WHERE id = ? AND user_id = ? AND version = ?`).
+		ExpectExec().
+		WithArgs(
+			invoicePMock.PayIn,
+			invoicePMock.BuyAt,
+			invoicePMock.Description,
+			invoicePMock.Value,
+			invoicePMock.Currency,
+			invoicePMock.Category.Id,
+			invoicePMock.IsAlreadyDone,
+			invoicePMock.PaymentType.Id,
+			invoicePMock.Id,
+			invoicePMock.UserId,
+			invoicePMock.Version).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	_, err = _repository.Edit(context.Background(), *invoicePMock)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
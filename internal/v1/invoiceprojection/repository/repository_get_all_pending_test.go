@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getAllPendingQueryMock = `
+		SELECT
+			ip.id,
+			ip.created_at,
+			ip.pay_in,
+			ip.buy_at,
+			ip.description,
+			ip.value,
+			ip.currency,
+			ip.is_already_done,
+			ip.user_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name
+		FROM
+			invoice_projection ip
+		INNER JOIN invoice_category ic ON 
+			ic.id = ip.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = ip.payment_type_id
+		WHERE 
+			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ? AND ip.deleted_at IS NULL
+			AND ip.is_already_done = false AND (? = 0 OR ip.category_id = ?)
+		ORDER BY ip.pay_in ASC`
+
+func TestGetAllPendingSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	queryParams := NewQueryParamsBuilder().
+		AddMonth(10).
+		AddYear(2024).
+		AddCategoryId(1).
+		AddUserId("User1").
+		Build()
+
+	now := time.Now()
+	invoicePMock := NewInvoiceProjectionBuilder().
+		AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+		AddCreatedAt(now).
+		AddPayIn(now).
+		AddBuyAt(now).
+		AddIsAlreadyDone(false).
+		AddCategory(InvoiceCategory{Id: 1, Category: "Moradia"}).
+		AddDescription("Description de teste").
+		AddPaymentType(PaymentType{Id: 2, Type: "Transferência"}).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddUserId("User1").
+		Build()
+
+	rowsInvoiceProjectionMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"pay_in",
+		"buy_at",
+		"description",
+		"value",
+		"currency",
+		"is_already_done",
+		"user_id",
+		"category_id",
+		"category",
+		"payment_type_id",
+		"payment_type",
+	}).AddRow(
+		invoicePMock.Id,
+		invoicePMock.CreatedAt.Unix(),
+		invoicePMock.PayIn,
+		invoicePMock.BuyAt,
+		invoicePMock.Description,
+		invoicePMock.Value,
+		invoicePMock.Currency,
+		invoicePMock.IsAlreadyDone,
+		invoicePMock.UserId,
+		invoicePMock.Category.Id,
+		invoicePMock.Category.Category,
+		invoicePMock.PaymentType.Id,
+		invoicePMock.PaymentType.Type,
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllPendingQueryMock).
+		WithArgs(uint(10), uint(2024), "User1", uint(1), uint(1)).
+		WillReturnRows(rowsInvoiceProjectionMock)
+
+	invoiceProjectionList, err := _repository.GetAllPending(context.Background(), queryParams)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(*invoiceProjectionList))
+	assert.Equal(t, invoicePMock.Id, (*invoiceProjectionList)[0].Id)
+	assert.Equal(t, invoicePMock.Category, (*invoiceProjectionList)[0].Category)
+	assert.Equal(t, invoicePMock.PaymentType, (*invoiceProjectionList)[0].PaymentType)
+	assert.False(t, (*invoiceProjectionList)[0].IsAlreadyDone)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllPendingQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	queryParams := NewQueryParamsBuilder().
+		AddMonth(10).
+		AddYear(2024).
+		AddUserId("User1").
+		Build()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllPendingQueryMock).
+		WithArgs(uint(10), uint(2024), "User1", uint(0), uint(0)).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAllPending(context.Background(), queryParams)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,7 +36,8 @@ func TestGetAllSuccess(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1, Category: "Moradia"}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2, Type: "Transferência"}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -46,6 +48,7 @@ func TestGetAllSuccess(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
 		"is_already_done",
 		"user_id",
 		"category_id",
@@ -59,6 +62,7 @@ func TestGetAllSuccess(t *testing.T) {
 		invoicePMock.BuyAt,
 		invoicePMock.Description,
 		invoicePMock.Value,
+		invoicePMock.Currency,
 		invoicePMock.IsAlreadyDone,
 		invoicePMock.UserId,
 		invoicePMock.Category.Id,
@@ -77,6 +81,7 @@ func TestGetAllSuccess(t *testing.T) {
 			ip.buy_at,
 			ip.description,
 			ip.value,
+			ip.currency,
 			ip.is_already_done,
 			ip.user_id,
 			ic.id,
@@ -90,7 +95,7 @@ func TestGetAllSuccess(t *testing.T) {
 		INNER JOIN payment_type pt ON
 			pt.id = ip.payment_type_id
 		WHERE 
-			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ?
+			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ? AND ip.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnRows(rowsInvoiceProjectionMock)
@@ -129,6 +134,7 @@ func TestGetAllQueryFail(t *testing.T) {
 			ip.buy_at,
 			ip.description,
 			ip.value,
+			ip.currency,
 			ip.is_already_done,
 			ip.user_id,
 			ic.id,
@@ -142,7 +148,7 @@ func TestGetAllQueryFail(t *testing.T) {
 		INNER JOIN payment_type pt ON
 			pt.id = ip.payment_type_id
 		WHERE 
-			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ?
+			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ? AND ip.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnError(errors.New("An error has been ocurred"))
@@ -177,6 +183,7 @@ func TestGetAllScanFail(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
 		"is_already_done",
 		"user_id",
 		"category_id",
@@ -196,6 +203,7 @@ func TestGetAllScanFail(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 	).RowError(1, errors.New("An error has been ocurred"))
 
 	_repository := New(dbMock)
@@ -208,6 +216,7 @@ func TestGetAllScanFail(t *testing.T) {
 			ip.buy_at,
 			ip.description,
 			ip.value,
+			ip.currency,
 			ip.is_already_done,
 			ip.user_id,
 			ic.id,
@@ -221,7 +230,7 @@ func TestGetAllScanFail(t *testing.T) {
 		INNER JOIN payment_type pt ON
 			pt.id = ip.payment_type_id
 		WHERE 
-			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ?
+			MONTH(ip.pay_in) = ? AND YEAR(ip.pay_in) = ? AND ip.user_id = ? AND ip.deleted_at IS NULL
 		LIMIT ? OFFSET ?`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId, queryParams.limit, queryParams.offset).
 		WillReturnRows(rowsInvoiceProjectionMock)
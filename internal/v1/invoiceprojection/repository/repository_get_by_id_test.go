@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,8 +28,11 @@ func TestGetInvoiceProjectionByIdSuccess(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1, Category: "Moradia"}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2, Type: "Transferência"}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
+		AddBoletoCode("34199101600001234561091234567812345678901230").
 		AddUserId("User1").
+		AddVersion(3).
 		Build()
 
 	rowsInvoiceProjectionMock := sqlMock.NewRows([]string{
@@ -38,12 +42,15 @@ func TestGetInvoiceProjectionByIdSuccess(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
+		"boleto_code",
 		"is_already_done",
 		"user_id",
 		"category_id",
 		"category",
 		"payment_type_id",
 		"payment_type",
+		"version",
 	}).AddRow(
 		invoicePMock.Id,
 		invoicePMock.CreatedAt.Unix(),
@@ -51,12 +58,15 @@ func TestGetInvoiceProjectionByIdSuccess(t *testing.T) {
 		invoicePMock.BuyAt,
 		invoicePMock.Description,
 		invoicePMock.Value,
+		invoicePMock.Currency,
+		invoicePMock.BoletoCode,
 		invoicePMock.IsAlreadyDone,
 		invoicePMock.UserId,
 		invoicePMock.Category.Id,
 		invoicePMock.Category.Category,
 		invoicePMock.PaymentType.Id,
 		invoicePMock.PaymentType.Type,
+		invoicePMock.Version,
 	)
 
 	_repository := New(dbMock)
@@ -69,25 +79,29 @@ func TestGetInvoiceProjectionByIdSuccess(t *testing.T) {
 			ip.buy_at,
 			ip.description,
 			ip.value,
+			ip.currency,
+			ip.boleto_code,
 			ip.is_already_done,
 			ip.user_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			ip.version
 		FROM
 			invoice_projection ip
 		INNER JOIN invoice_category ic ON 
 			ic.id = ip.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = ip.payment_type_id
-		WHERE ip.id = ? AND ip.user_id = ?`).
+		WHERE ip.id = ? AND ip.user_id = ? AND ip.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsInvoiceProjectionMock)
 
 	invoicePReturn, err := _repository.GetById(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
 	assert.NoError(t, err)
 	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", invoicePReturn.Id)
+	assert.Equal(t, uint(3), invoicePReturn.Version)
 
 	if err := sqlMock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -111,19 +125,22 @@ func TestGetInvoiceProjectionByIdQueryFail(t *testing.T) {
 			ip.buy_at,
 			ip.description,
 			ip.value,
+			ip.currency,
+			ip.boleto_code,
 			ip.is_already_done,
 			ip.user_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			ip.version
 		FROM
 			invoice_projection ip
 		INNER JOIN invoice_category ic ON 
 			ic.id = ip.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = ip.payment_type_id
-		WHERE ip.id = ? AND ip.user_id = ?`).
+		WHERE ip.id = ? AND ip.user_id = ? AND ip.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnError(errors.New("An error has been ocurred"))
 
@@ -149,12 +166,15 @@ func TestGetInvoiceProjectionByIdRowEmpty(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
+		"boleto_code",
 		"is_already_done",
 		"user_id",
 		"category_id",
 		"category",
 		"payment_type",
 		"payment_type_id",
+		"version",
 	})
 
 	_repository := New(dbMock)
@@ -167,19 +187,22 @@ func TestGetInvoiceProjectionByIdRowEmpty(t *testing.T) {
 			ip.buy_at,
 			ip.description,
 			ip.value,
+			ip.currency,
+			ip.boleto_code,
 			ip.is_already_done,
 			ip.user_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			ip.version
 		FROM
 			invoice_projection ip
 		INNER JOIN invoice_category ic ON 
 			ic.id = ip.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = ip.payment_type_id
-		WHERE ip.id = ? AND ip.user_id = ?`).
+		WHERE ip.id = ? AND ip.user_id = ? AND ip.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsInvoiceProjectionMock)
 
@@ -206,14 +229,17 @@ func TestGetInvoiceProjectionByIdRowScanFail(t *testing.T) {
 		"buy_at",
 		"description",
 		"value",
+		"currency",
+		"boleto_code",
 		"is_already_done",
 		"user_id",
 		"category_id",
 		"category",
 		"payment_type_id",
 		"payment_type",
+		"version",
 	}).AddRow(
-		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).
 		RowError(1, errors.New("An error has been ocurred"))
 
 	_repository := New(dbMock)
@@ -226,19 +252,22 @@ func TestGetInvoiceProjectionByIdRowScanFail(t *testing.T) {
 			ip.buy_at,
 			ip.description,
 			ip.value,
+			ip.currency,
+			ip.boleto_code,
 			ip.is_already_done,
 			ip.user_id,
 			ic.id,
 			ic.category,
 			pt.id,
-			pt.type_name
+			pt.type_name,
+			ip.version
 		FROM
 			invoice_projection ip
 		INNER JOIN invoice_category ic ON 
 			ic.id = ip.category_id
 		INNER JOIN payment_type pt ON
 			pt.id = ip.payment_type_id
-		WHERE ip.id = ? AND ip.user_id = ?`).
+		WHERE ip.id = ? AND ip.user_id = ? AND ip.deleted_at IS NULL`).
 		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
 		WillReturnRows(rowsInvoiceProjectionMock)
 
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInvoiceByProjectionIdSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"pay_at",
+		"buy_at",
+		"description",
+		"value",
+		"currency",
+		"user_id",
+		"invoice_projection_id",
+		"category_id",
+		"category",
+		"payment_type_id",
+		"type_name",
+		"version",
+		"deleted_at",
+	}).AddRow(
+		"71e31eb6-dde2-4dcb-b7ef-c7e8a699c628",
+		now.Unix(),
+		now,
+		now,
+		"Description de teste",
+		500.50,
+		"USD",
+		"User1",
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		1,
+		"Categoria",
+		2,
+		"Pix",
+		2,
+		nil,
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			i.id,
+			i.created_at,
+			i.pay_at,
+			i.buy_at,
+			i.description,
+			i.value,
+			i.currency,
+			i.user_id,
+			i.invoice_projection_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name,
+			i.version,
+			i.deleted_at
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON 
+			ic.id = i.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = i.payment_type_id
+		WHERE i.invoice_projection_id = ? AND i.user_id = ?`).
+		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WillReturnRows(rowsMock)
+
+	invoice, err := _repository.GetInvoiceByProjectionId(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", invoice.Id)
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", invoice.InvoiceProjectionId)
+	assert.Equal(t, uint(2), invoice.Version)
+	assert.True(t, invoice.DeletedAt.IsZero())
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceByProjectionIdTrashed(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	now := time.Now()
+	rowsMock := sqlMock.NewRows([]string{
+		"id",
+		"created_at",
+		"pay_at",
+		"buy_at",
+		"description",
+		"value",
+		"currency",
+		"user_id",
+		"invoice_projection_id",
+		"category_id",
+		"category",
+		"payment_type_id",
+		"type_name",
+		"version",
+		"deleted_at",
+	}).AddRow(
+		"71e31eb6-dde2-4dcb-b7ef-c7e8a699c628",
+		now.Unix(),
+		now,
+		now,
+		"Description de teste",
+		500.50,
+		"USD",
+		"User1",
+		"519fd73e-45e6-4471-8a66-5057486f5cc8",
+		1,
+		"Categoria",
+		2,
+		"Pix",
+		2,
+		now.Unix(),
+	)
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			i.id,
+			i.created_at,
+			i.pay_at,
+			i.buy_at,
+			i.description,
+			i.value,
+			i.currency,
+			i.user_id,
+			i.invoice_projection_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name,
+			i.version,
+			i.deleted_at
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON 
+			ic.id = i.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = i.payment_type_id
+		WHERE i.invoice_projection_id = ? AND i.user_id = ?`).
+		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WillReturnRows(rowsMock)
+
+	invoice, err := _repository.GetInvoiceByProjectionId(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "71e31eb6-dde2-4dcb-b7ef-c7e8a699c628", invoice.Id)
+	assert.Equal(t, "519fd73e-45e6-4471-8a66-5057486f5cc8", invoice.InvoiceProjectionId)
+	assert.Equal(t, uint(2), invoice.Version)
+	assert.Equal(t, now.Unix(), invoice.DeletedAt.Unix())
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceByProjectionIdNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			i.id,
+			i.created_at,
+			i.pay_at,
+			i.buy_at,
+			i.description,
+			i.value,
+			i.currency,
+			i.user_id,
+			i.invoice_projection_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name,
+			i.version,
+			i.deleted_at
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON 
+			ic.id = i.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = i.payment_type_id
+		WHERE i.invoice_projection_id = ? AND i.user_id = ?`).
+		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WillReturnRows(sqlMock.NewRows([]string{"id"}))
+
+	invoice, err := _repository.GetInvoiceByProjectionId(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, invoice)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceByProjectionIdQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(`
+		SELECT
+			i.id,
+			i.created_at,
+			i.pay_at,
+			i.buy_at,
+			i.description,
+			i.value,
+			i.currency,
+			i.user_id,
+			i.invoice_projection_id,
+			ic.id,
+			ic.category,
+			pt.id,
+			pt.type_name,
+			i.version,
+			i.deleted_at
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON 
+			ic.id = i.category_id
+		INNER JOIN payment_type pt ON
+			pt.id = i.payment_type_id
+		WHERE i.invoice_projection_id = ? AND i.user_id = ?`).
+		WithArgs("519fd73e-45e6-4471-8a66-5057486f5cc8", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetInvoiceByProjectionId(context.Background(), "519fd73e-45e6-4471-8a66-5057486f5cc8", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
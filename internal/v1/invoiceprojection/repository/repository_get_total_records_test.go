@@ -27,7 +27,7 @@ func TestGetTotalRecordsSuccess(t *testing.T) {
 	_repository := New(dbMock)
 
 	sqlMock.ExpectQuery(`
-		SELECT COUNT(*) as total_records FROM invoice_projection WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ?`).
+		SELECT COUNT(*) as total_records FROM invoice_projection WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ? AND deleted_at IS NULL`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId).
 		WillReturnRows(totalRecordsMock)
 
@@ -58,7 +58,7 @@ func TestGetTotalRecordsScanFail(t *testing.T) {
 	_repository := New(dbMock)
 
 	sqlMock.ExpectQuery(`
-		SELECT COUNT(*) as total_records FROM invoice_projection WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ?`).
+		SELECT COUNT(*) as total_records FROM invoice_projection WHERE MONTH(pay_in) = ? AND YEAR(pay_in) = ? AND user_id = ? AND deleted_at IS NULL`).
 		WithArgs(queryParams.month, queryParams.year, queryParams.userId).
 		WillReturnRows(totalRecordsMock)
 
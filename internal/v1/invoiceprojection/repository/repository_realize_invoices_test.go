@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const realizeInvoicesInsertMock = `
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, invoice_projection_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+const realizeInvoicesUpdateMock = `UPDATE invoice_projection SET is_already_done = true, version = version + 1 WHERE id = ? AND user_id = ? AND is_already_done = false`
+
+func buildRealizeInvoicesMock() []Invoice {
+	now := time.Now()
+	return []Invoice{
+		*NewInvoiceBuilder().
+			AddId("519fd73e-45e6-4471-8a66-5057486f5cc8").
+			AddCreatedAt(now).
+			AddPayAt(now).
+			AddBuyAt(now).
+			AddCategory(InvoiceCategory{Id: 1}).
+			AddPaymentType(PaymentType{Id: 2}).
+			AddDescription("Description de teste").
+			AddValue(money.FromCents(50050)).
+			AddCurrency("BRL").
+			AddUserId("User1").
+			AddInvoiceProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
+			Build(),
+		*NewInvoiceBuilder().
+			AddId("2b4a2b1e-0c1b-4f6e-9f65-3bd0a5cfa7a9").
+			AddCreatedAt(now).
+			AddPayAt(now).
+			AddBuyAt(now).
+			AddCategory(InvoiceCategory{Id: 2}).
+			AddPaymentType(PaymentType{Id: 1}).
+			AddDescription("Description de teste 2").
+			AddValue(money.FromCents(10000)).
+			AddCurrency("BRL").
+			AddUserId("User1").
+			AddInvoiceProjectionId("c1a3b3f9-63f7-4ab4-8f5b-8e2f4f3a4d1d").
+			Build(),
+	}
+}
+
+func TestRealizeInvoicesSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildRealizeInvoicesMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	insertStmt := sqlMock.ExpectPrepare(realizeInvoicesInsertMock)
+	updateStmt := sqlMock.ExpectPrepare(realizeInvoicesUpdateMock)
+	for _, invoice := range invoicesMock {
+		insertStmt.ExpectExec().
+			WithArgs(
+				invoice.Id,
+				invoice.CreatedAt.Unix(),
+				invoice.PayAt,
+				invoice.BuyAt,
+				invoice.Description,
+				invoice.Value,
+				invoice.Currency,
+				invoice.UserId,
+				invoice.Category.Id,
+				invoice.InvoiceProjectionId,
+				invoice.PaymentType.Id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		updateStmt.ExpectExec().
+			WithArgs(invoice.InvoiceProjectionId, invoice.UserId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	sqlMock.ExpectCommit()
+
+	err = _repository.RealizeInvoices(context.Background(), invoicesMock)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRealizeInvoicesBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.RealizeInvoices(context.Background(), buildRealizeInvoicesMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRealizeInvoicesExecFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildRealizeInvoicesMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	insertStmt := sqlMock.ExpectPrepare(realizeInvoicesInsertMock)
+	updateStmt := sqlMock.ExpectPrepare(realizeInvoicesUpdateMock)
+	insertStmt.ExpectExec().
+		WithArgs(
+			invoicesMock[0].Id,
+			invoicesMock[0].CreatedAt.Unix(),
+			invoicesMock[0].PayAt,
+			invoicesMock[0].BuyAt,
+			invoicesMock[0].Description,
+			invoicesMock[0].Value,
+			invoicesMock[0].Currency,
+			invoicesMock[0].UserId,
+			invoicesMock[0].Category.Id,
+			invoicesMock[0].InvoiceProjectionId,
+			invoicesMock[0].PaymentType.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	updateStmt.ExpectExec().
+		WithArgs(invoicesMock[0].InvoiceProjectionId, invoicesMock[0].UserId).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	insertStmt.ExpectExec().
+		WithArgs(
+			invoicesMock[1].Id,
+			invoicesMock[1].CreatedAt.Unix(),
+			invoicesMock[1].PayAt,
+			invoicesMock[1].BuyAt,
+			invoicesMock[1].Description,
+			invoicesMock[1].Value,
+			invoicesMock[1].Currency,
+			invoicesMock[1].UserId,
+			invoicesMock[1].Category.Id,
+			invoicesMock[1].InvoiceProjectionId,
+			invoicesMock[1].PaymentType.Id).
+		WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.RealizeInvoices(context.Background(), invoicesMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRealizeInvoicesAlreadyDone(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildRealizeInvoicesMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	insertStmt := sqlMock.ExpectPrepare(realizeInvoicesInsertMock)
+	updateStmt := sqlMock.ExpectPrepare(realizeInvoicesUpdateMock)
+	insertStmt.ExpectExec().
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	updateStmt.ExpectExec().
+		WithArgs(invoicesMock[0].InvoiceProjectionId, invoicesMock[0].UserId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	err = _repository.RealizeInvoices(context.Background(), invoicesMock)
+	assert.ErrorIs(t, err, ErrAlreadyDone)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRealizeInvoicesCommitFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	invoicesMock := buildRealizeInvoicesMock()[:1]
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	insertStmt := sqlMock.ExpectPrepare(realizeInvoicesInsertMock)
+	updateStmt := sqlMock.ExpectPrepare(realizeInvoicesUpdateMock)
+	insertStmt.ExpectExec().
+		WithArgs(
+			invoicesMock[0].Id,
+			invoicesMock[0].CreatedAt.Unix(),
+			invoicesMock[0].PayAt,
+			invoicesMock[0].BuyAt,
+			invoicesMock[0].Description,
+			invoicesMock[0].Value,
+			invoicesMock[0].Currency,
+			invoicesMock[0].UserId,
+			invoicesMock[0].Category.Id,
+			invoicesMock[0].InvoiceProjectionId,
+			invoicesMock[0].PaymentType.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	updateStmt.ExpectExec().
+		WithArgs(invoicesMock[0].InvoiceProjectionId, invoicesMock[0].UserId).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit().WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.RealizeInvoices(context.Background(), invoicesMock)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
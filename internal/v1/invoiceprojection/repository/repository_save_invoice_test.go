@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,7 +27,8 @@ func TestSaveInvoiceSuccess(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddInvoiceProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -35,8 +37,8 @@ func TestSaveInvoiceSuccess(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, invoice_projection_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, invoice_projection_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			invoiceMock.Id,
@@ -45,6 +47,7 @@ func TestSaveInvoiceSuccess(t *testing.T) {
 			invoiceMock.BuyAt,
 			invoiceMock.Description,
 			invoiceMock.Value,
+			invoiceMock.Currency,
 			invoiceMock.UserId,
 			invoiceMock.Category.Id,
 			invoiceMock.InvoiceProjectionId,
@@ -77,7 +80,8 @@ func TestSaveInvoiceBeginFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddInvoiceProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -110,7 +114,8 @@ func TestSaveInvoicePrepareFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddInvoiceProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -119,8 +124,8 @@ func TestSaveInvoicePrepareFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, invoice_projection_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, invoice_projection_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.SaveInvoice(context.Background(), *invoiceMock)
@@ -147,7 +152,8 @@ func TestSaveInvoiceExecFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddInvoiceProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -156,8 +162,8 @@ func TestSaveInvoiceExecFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, invoice_projection_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, invoice_projection_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			invoiceMock.Id,
@@ -166,6 +172,7 @@ func TestSaveInvoiceExecFail(t *testing.T) {
 			invoiceMock.BuyAt,
 			invoiceMock.Description,
 			invoiceMock.Value,
+			invoiceMock.Currency,
 			invoiceMock.UserId,
 			invoiceMock.Category.Id,
 			invoiceMock.InvoiceProjectionId,
@@ -196,7 +203,8 @@ func TestSaveInvoiceCommitFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		AddInvoiceProjectionId("7a494375-53a1-41e4-a9db-6bb30eaf23c2").
 		Build()
@@ -204,8 +212,8 @@ func TestSaveInvoiceCommitFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, user_id, category_id, invoice_projection_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice (id, created_at, pay_at, buy_at, description, value, currency, user_id, category_id, invoice_projection_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			invoiceMock.Id,
@@ -214,6 +222,7 @@ func TestSaveInvoiceCommitFail(t *testing.T) {
 			invoiceMock.BuyAt,
 			invoiceMock.Description,
 			invoiceMock.Value,
+			invoiceMock.Currency,
 			invoiceMock.UserId,
 			invoiceMock.Category.Id,
 			invoiceMock.InvoiceProjectionId,
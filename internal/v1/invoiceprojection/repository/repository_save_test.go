@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +28,8 @@ func TestSaveInvoiceProjectionSuccess(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -35,8 +37,8 @@ func TestSaveInvoiceProjectionSuccess(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, is_already_done, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, currency, boleto_code, is_already_done, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			invoicePMock.Id,
@@ -45,6 +47,8 @@ func TestSaveInvoiceProjectionSuccess(t *testing.T) {
 			invoicePMock.BuyAt,
 			invoicePMock.Description,
 			invoicePMock.Value,
+			invoicePMock.Currency,
+			invoicePMock.BoletoCode,
 			invoicePMock.IsAlreadyDone,
 			invoicePMock.UserId,
 			invoicePMock.Category.Id,
@@ -78,7 +82,8 @@ func TestSaveInvoiceProjectionBeginFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -111,7 +116,8 @@ func TestSaveInvoiceProjectionPrepareFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -119,8 +125,8 @@ func TestSaveInvoiceProjectionPrepareFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, is_already_done, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, currency, boleto_code, is_already_done, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		WillReturnError(errors.New("An error has been ocurred"))
 
 	_, err = _repository.Save(context.Background(), *invoicePMock)
@@ -148,7 +154,8 @@ func TestSaveInvoiceProjectionExecFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -156,8 +163,8 @@ func TestSaveInvoiceProjectionExecFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, is_already_done, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, currency, boleto_code, is_already_done, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			invoicePMock.Id,
@@ -166,6 +173,8 @@ func TestSaveInvoiceProjectionExecFail(t *testing.T) {
 			invoicePMock.BuyAt,
 			invoicePMock.Description,
 			invoicePMock.Value,
+			invoicePMock.Currency,
+			invoicePMock.BoletoCode,
 			invoicePMock.IsAlreadyDone,
 			invoicePMock.UserId,
 			invoicePMock.Category.Id,
@@ -197,7 +206,8 @@ func TestSaveInvoiceProjectionCommitFail(t *testing.T) {
 		AddCategory(InvoiceCategory{Id: 1}).
 		AddDescription("Description de teste").
 		AddPaymentType(PaymentType{Id: 2}).
-		AddValue(500.50).
+		AddValue(money.FromCents(50050)).
+		AddCurrency("BRL").
 		AddUserId("User1").
 		Build()
 
@@ -205,8 +215,8 @@ func TestSaveInvoiceProjectionCommitFail(t *testing.T) {
 
 	sqlMock.ExpectBegin()
 	sqlMock.ExpectPrepare(`
-		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, is_already_done, user_id, category_id, payment_type_id) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
+		INSERT INTO invoice_projection (id, created_at, pay_in, buy_at, description, value, currency, boleto_code, is_already_done, user_id, category_id, payment_type_id) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`).
 		ExpectExec().
 		WithArgs(
 			invoicePMock.Id,
@@ -215,6 +225,8 @@ func TestSaveInvoiceProjectionCommitFail(t *testing.T) {
 			invoicePMock.BuyAt,
 			invoicePMock.Description,
 			invoicePMock.Value,
+			invoicePMock.Currency,
+			invoicePMock.BoletoCode,
 			invoicePMock.IsAlreadyDone,
 			invoicePMock.UserId,
 			invoicePMock.Category.Id,
@@ -1,6 +1,10 @@
 package repository
 
-import "time"
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
 
 type InvoiceProjection struct {
 	Id            string
@@ -8,9 +12,13 @@ type InvoiceProjection struct {
 	BuyAt         time.Time
 	PayIn         time.Time
 	Description   string
-	Value         float64
+	Value         money.Money
+	Currency      string
+	BoletoCode    string
 	IsAlreadyDone bool
 	UserId        string
+	DeletedAt     time.Time
+	Version       uint
 	Category      InvoiceCategory
 	PaymentType   PaymentType
 }
@@ -21,9 +29,12 @@ type Invoice struct {
 	BuyAt               time.Time
 	PayAt               time.Time
 	Description         string
-	Value               float64
+	Value               money.Money
+	Currency            string
 	InvoiceProjectionId string
 	UserId              string
+	DeletedAt           time.Time
+	Version             uint
 	Category            InvoiceCategory
 	PaymentType         PaymentType
 }
@@ -39,9 +50,10 @@ type PaymentType struct {
 }
 
 type QueryParams struct {
-	userId string
-	month  uint
-	year   uint
-	limit  uint
-	offset uint
+	userId     string
+	month      uint
+	year       uint
+	categoryId uint
+	limit      uint
+	offset     uint
 }
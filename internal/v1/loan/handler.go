@@ -0,0 +1,168 @@
+package loan
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/loan/lnservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	Create(c *gin.Context)
+	GetAll(c *gin.Context)
+	GetById(c *gin.Context)
+	SimulatePayoff(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	storageProcess lnservice.StorageProcess
+	readingProcess lnservice.ReadingProcess
+}
+
+func NewHandler(storageProcess lnservice.StorageProcess, readingProcess lnservice.ReadingProcess) Handler {
+	return &handler{storageProcess: storageProcess, readingProcess: readingProcess}
+}
+
+// Create godoc
+// @Summary Criar um empréstimo ou financiamento
+// @Description Este endpoint permite criar um empréstimo com o valor financiado, a taxa de juros mensal em percentual, o prazo em meses e o sistema de amortização (sac ou price).
+// @Description As parcelas são geradas como despesas previstas, uma por mês a partir do primeiro vencimento, com a separação de amortização e juros. O saldo devedor diminui conforme as parcelas são realizadas
+// @Tags Loan
+// @Accept json
+// @Produce json
+// @Param loan body lnservice.CreateRequest true "Modelo de criação do empréstimo"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} lnservice.LoanResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/loan [post]
+func (h *handler) Create(c *gin.Context) {
+	var request lnservice.CreateRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Loan::StorageProcess::Create", "Create new loan", nil)
+	createCtx := lnservice.CreateContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	loanCreated, err := h.storageProcess.Create(createCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, loanCreated)
+}
+
+// @Summary Obter os empréstimos e financiamentos
+// @Description Este endpoint permite obter os empréstimos com o total de juros, as parcelas pagas e o saldo devedor de cada um, sem as parcelas
+// @Tags Loan
+// @Accept json
+// @Produce json
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} lnservice.LoanListResponse
+// @Router /v1/loan [get]
+func (h *handler) GetAll(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Loan::ReadingProcess::GetAll", "Get all loans", nil)
+	searchCtx := lnservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+	}
+	loans, err := h.readingProcess.GetAll(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, loans)
+}
+
+// @Summary Obter um empréstimo ou financiamento
+// @Description Este endpoint permite obter um empréstimo com as parcelas, o saldo devedor após cada uma e se ela já foi realizada
+// @Tags Loan
+// @Accept json
+// @Produce json
+// @Param id path string true "Id do empréstimo"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} lnservice.LoanResponse
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/loan/{id} [get]
+func (h *handler) GetById(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Loan::ReadingProcess::GetById", "Get a loan by id", nil)
+	searchCtx := lnservice.SearchContext{
+		Ctx:       ctx,
+		Id:        c.Param("id"),
+		UserToken: userToken,
+	}
+	loan, err := h.readingProcess.GetById(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, loan)
+}
+
+// @Summary Simular a quitação antecipada de um empréstimo
+// @Description Este endpoint permite simular a quitação do empréstimo na data informada: o saldo devedor com os juros desde o vencimento da última parcela realizada, comparado com o total das parcelas que faltam
+// @Tags Loan
+// @Accept json
+// @Produce json
+// @Param id path string true "Id do empréstimo"
+// @Param date query string false "Data da quitação (AAAA-MM-DD), o padrão é a data atual"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} lnservice.PayoffResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/loan/{id}/payoff [get]
+func (h *handler) SimulatePayoff(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	date, err := getPayoffDate(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Loan::ReadingProcess::SimulatePayoff", "Simulate the payoff of a loan", nil)
+	payoffCtx := lnservice.PayoffContext{
+		Ctx:       ctx,
+		Id:        c.Param("id"),
+		Date:      date,
+		UserToken: userToken,
+	}
+	payoff, err := h.readingProcess.SimulatePayoff(payoffCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, payoff)
+}
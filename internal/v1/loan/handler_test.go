@@ -0,0 +1,280 @@
+package loan
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/loan/lnservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type storageProcessMock struct {
+	err     error
+	loan    *lnservice.LoanResponse
+	request lnservice.CreateRequest
+}
+
+func (sp *storageProcessMock) Create(createCtx lnservice.CreateContext) (*lnservice.LoanResponse, error) {
+	sp.request = createCtx.Request
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.loan, nil
+}
+
+type readingProcessMock struct {
+	err    error
+	loan   *lnservice.LoanResponse
+	loans  *lnservice.LoanListResponse
+	payoff *lnservice.PayoffResponse
+	id     string
+	date   time.Time
+}
+
+func (rp *readingProcessMock) GetById(searchCtx lnservice.SearchContext) (*lnservice.LoanResponse, error) {
+	rp.id = searchCtx.Id
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.loan, nil
+}
+
+func (rp *readingProcessMock) GetAll(searchCtx lnservice.SearchContext) (*lnservice.LoanListResponse, error) {
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.loans, nil
+}
+
+func (rp *readingProcessMock) SimulatePayoff(payoffCtx lnservice.PayoffContext) (*lnservice.PayoffResponse, error) {
+	rp.id = payoffCtx.Id
+	rp.date = payoffCtx.Date
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.payoff, nil
+}
+
+func buildLoanResponseMock() *lnservice.LoanResponse {
+	return &lnservice.LoanResponse{
+		Id:                 "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d",
+		Description:        "Financiamento do carro",
+		Principal:          money.FromCents(1200000),
+		Currency:           "BRL",
+		InterestRate:       money.Rate(150000000),
+		Term:               2,
+		Amortization:       lnservice.AMORTIZATION_SAC,
+		FirstDueDate:       time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+		CategoryId:         3,
+		PaymentTypeId:      1,
+		TotalInterest:      money.FromCents(27000),
+		TotalValue:         money.FromCents(1227000),
+		PaidInstallments:   1,
+		OutstandingBalance: money.FromCents(600000),
+		Installments: []lnservice.InstallmentResponse{
+			{
+				Number:              1,
+				DueDate:             time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+				Amortization:        money.FromCents(600000),
+				Interest:            money.FromCents(18000),
+				Value:               money.FromCents(618000),
+				Balance:             money.FromCents(600000),
+				InvoiceProjectionId: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c51",
+				IsRealized:          true,
+			},
+			{
+				Number:              2,
+				DueDate:             time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+				Amortization:        money.FromCents(600000),
+				Interest:            money.FromCents(9000),
+				Value:               money.FromCents(609000),
+				Balance:             money.FromCents(0),
+				InvoiceProjectionId: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c52",
+			},
+		},
+		CreatedAt: time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+const loanResponseBody = `{"id":"4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d","description":"Financiamento do carro","principal":12000,"currency":"BRL","interest_rate":1.5,"term":2,"amortization":"sac","first_due_date":"2024-02-10T00:00:00Z","category_id":3,"payment_type_id":1,"total_interest":270,"total_value":12270,"paid_installments":1,"outstanding_balance":6000,"installments":[{"number":1,"due_date":"2024-02-10T00:00:00Z","amortization":6000,"interest":180,"value":6180,"balance":6000,"invoice_projection_id":"a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c51","is_realized":true},{"number":2,"due_date":"2024-03-10T00:00:00Z","amortization":6000,"interest":90,"value":6090,"balance":0,"invoice_projection_id":"a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c52","is_realized":false}],"created_at":"2024-01-10T10:00:00Z"}`
+
+func TestCreateSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{loan: buildLoanResponseMock()}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/loan", handler.Create)
+
+	body := []byte(`{"description": "Financiamento do carro", "principal": 12000, "interest_rate": 1.5, "term": 2, "amortization": "sac", "first_due_date": "2024-02-10T00:00:00Z", "category_id": 3, "payment_type_id": 1}`)
+	req, _ := http.NewRequest("POST", "/v1/loan", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, loanResponseBody, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, money.FromCents(1200000), _storageProcessMock.request.Principal)
+	assert.Equal(t, money.Rate(150000000), _storageProcessMock.request.InterestRate)
+}
+
+func TestCreateInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/loan", handler.Create)
+
+	body := []byte(`{"description": "Financiamento do carro", "principal": 0, "term": 2, "amortization": "germano", "first_due_date": "2024-02-10T00:00:00Z", "category_id": 3, "payment_type_id": 1}`)
+	req, _ := http.NewRequest("POST", "/v1/loan", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"principal","code":"too_small","message":"The principal must be greater than 0"},{"field":"amortization","code":"invalid","message":"The amortization is not valid"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestGetAllSuccess(t *testing.T) {
+	loanResponse := buildLoanResponseMock()
+	loanResponse.Installments = nil
+	_readingProcessMock := &readingProcessMock{
+		loans: &lnservice.LoanListResponse{Records: []lnservice.LoanResponse{*loanResponse}},
+	}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/loan", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/loan", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"records":[{"id":"4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d","description":"Financiamento do carro","principal":12000,"currency":"BRL","interest_rate":1.5,"term":2,"amortization":"sac","first_due_date":"2024-02-10T00:00:00Z","category_id":3,"payment_type_id":1,"total_interest":270,"total_value":12270,"paid_installments":1,"outstanding_balance":6000,"created_at":"2024-01-10T10:00:00Z"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetAllFail(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/loan", handler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/v1/loan", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetByIdSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{loan: buildLoanResponseMock()}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/loan/:id", handler.GetById)
+
+	req, _ := http.NewRequest("GET", "/v1/loan/4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, loanResponseBody, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d", _readingProcessMock.id)
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: apperror.NotFound("Loan not found")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/loan/:id", handler.GetById)
+
+	req, _ := http.NewRequest("GET", "/v1/loan/4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Loan not found"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSimulatePayoffSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{payoff: &lnservice.PayoffResponse{
+		Date:                  time.Date(2024, 2, 25, 0, 0, 0, 0, time.UTC),
+		OutstandingBalance:    money.FromCents(600000),
+		AccruedInterest:       money.FromCents(4465),
+		PayoffValue:           money.FromCents(604465),
+		RemainingInstallments: 1,
+		RemainingValue:        money.FromCents(609000),
+		Savings:               money.FromCents(4535),
+	}}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/loan/:id/payoff", handler.SimulatePayoff)
+
+	req, _ := http.NewRequest("GET", "/v1/loan/4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d/payoff?date=2024-02-25", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"date":"2024-02-25T00:00:00Z","outstanding_balance":6000,"accrued_interest":44.65,"payoff_value":6044.65,"remaining_installments":1,"remaining_value":6090,"savings":45.35}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d", _readingProcessMock.id)
+	assert.Equal(t, time.Date(2024, 2, 25, 0, 0, 0, 0, time.UTC), _readingProcessMock.date)
+}
+
+func TestSimulatePayoffInvalidDate(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/loan/:id/payoff", handler.SimulatePayoff)
+
+	req, _ := http.NewRequest("GET", "/v1/loan/4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d/payoff?date=25-02-2024", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"A param date 25-02-2024 is invalid"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSimulatePayoffNotFound(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: apperror.NotFound("Loan not found")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/loan/:id/payoff", handler.SimulatePayoff)
+
+	req, _ := http.NewRequest("GET", "/v1/loan/4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d/payoff", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,22 @@
+package loan
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+)
+
+// getPayoffDate returns the zero date when the param is not informed, so the payoff is simulated on the current day
+func getPayoffDate(c *gin.Context) (time.Time, error) {
+	dateParam := c.Query("date")
+	if dateParam == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(time.DateOnly, dateParam)
+	if err != nil {
+		return time.Time{}, apperror.Validation(fmt.Sprintf("A param date %s is invalid", dateParam))
+	}
+	return date, nil
+}
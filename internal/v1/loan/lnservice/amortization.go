@@ -0,0 +1,62 @@
+package lnservice
+
+import (
+	"math"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/loan/repository"
+)
+
+// buildSchedule amortizes the principal in the term of the loan. The interest of each installment is charged on the
+// outstanding balance, the sac amortizes the same amount each month and the price pays the same installment each month,
+// with the last one settling the cents left by the rounding
+func buildSchedule(loan repository.Loan) []repository.Installment {
+	rate := getMonthlyRate(loan.InterestRate)
+	amortizations := loan.Principal.Split(int(loan.Term))
+	var payment money.Money
+	if loan.Amortization == AMORTIZATION_PRICE && rate > 0 {
+		payment = money.Money(math.Round(float64(loan.Principal) * rate / (1 - math.Pow(1+rate, -float64(loan.Term)))))
+	}
+	installments := []repository.Installment{}
+	balance := loan.Principal
+	for number := uint(1); number <= loan.Term; number++ {
+		interest := getInterest(balance, rate)
+		amortization := amortizations[number-1]
+		if payment > 0 {
+			amortization = payment.Sub(interest)
+			if number == loan.Term || amortization > balance {
+				amortization = balance
+			}
+		}
+		balance = balance.Sub(amortization)
+		installments = append(installments, repository.Installment{
+			Number:       number,
+			DueDate:      getDueDate(loan.FirstDueDate, number),
+			Amortization: amortization,
+			Interest:     interest,
+			Value:        amortization.Add(interest),
+		})
+	}
+	return installments
+}
+
+// getMonthlyRate turns the percentage of the loan into the fraction charged each month
+func getMonthlyRate(interestRate money.Rate) float64 {
+	return float64(interestRate) / money.RATE_SCALE / 100
+}
+
+func getInterest(balance money.Money, rate float64) money.Money {
+	return money.Money(math.Round(float64(balance) * rate))
+}
+
+// getDueDate is the due date of the installment, on the day of the first due date or on the last day of the shorter months
+func getDueDate(firstDueDate time.Time, number uint) time.Time {
+	month := time.Date(firstDueDate.Year(), firstDueDate.Month(), 1, 0, 0, 0, 0, firstDueDate.Location()).AddDate(0, int(number)-1, 0)
+	day := firstDueDate.Day()
+	lastDay := month.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, firstDueDate.Location())
+}
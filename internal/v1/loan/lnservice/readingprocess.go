@@ -0,0 +1,100 @@
+package lnservice
+
+import (
+	"math"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/loan/repository"
+)
+
+// DAYS_IN_MONTH is the number of days the monthly rate is prorated by when the interest is accrued between due dates
+const DAYS_IN_MONTH = 30
+
+type ReadingProcess interface {
+	GetById(searchCtx SearchContext) (*LoanResponse, error)
+	GetAll(searchCtx SearchContext) (*LoanListResponse, error)
+	SimulatePayoff(payoffCtx PayoffContext) (*PayoffResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+	now        func() time.Time
+}
+
+func NewReadingProcess(repository repository.Repository, now func() time.Time) ReadingProcess {
+	return &readingProcess{repository: repository, now: now}
+}
+
+func (rp *readingProcess) GetById(searchCtx SearchContext) (*LoanResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	loan, err := rp.repository.GetById(searchCtx.Ctx, searchCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, apperror.NotFound("Loan not found")
+	}
+	installments, err := rp.repository.GetInstallments(searchCtx.Ctx, loan.Id)
+	if err != nil {
+		return nil, err
+	}
+	return buildResponse(*loan, *installments), nil
+}
+
+// GetAll returns the loans with their totals and outstanding balance, leaving out the schedules
+func (rp *readingProcess) GetAll(searchCtx SearchContext) (*LoanListResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	loans, err := rp.repository.GetAll(searchCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	records := []LoanResponse{}
+	for _, loan := range *loans {
+		installments, err := rp.repository.GetInstallments(searchCtx.Ctx, loan.Id)
+		if err != nil {
+			return nil, err
+		}
+		loanResponse := buildResponse(loan, *installments)
+		loanResponse.Installments = nil
+		records = append(records, *loanResponse)
+	}
+	return &LoanListResponse{Records: records}, nil
+}
+
+// SimulatePayoff tells how much settles the loan on the date: the outstanding balance plus its interest, compounded daily
+// at the monthly rate, since the due date of the last realized installment or since a month before the first due date
+func (rp *readingProcess) SimulatePayoff(payoffCtx PayoffContext) (*PayoffResponse, error) {
+	loanResponse, err := rp.GetById(SearchContext{Ctx: payoffCtx.Ctx, Id: payoffCtx.Id, UserToken: payoffCtx.UserToken})
+	if err != nil {
+		return nil, err
+	}
+	date := payoffCtx.Date
+	if date.IsZero() {
+		now := rp.now()
+		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loanResponse.FirstDueDate.Location())
+	}
+	response := &PayoffResponse{Date: date, OutstandingBalance: loanResponse.OutstandingBalance}
+	lastPaidDate := loanResponse.FirstDueDate.AddDate(0, 0, -DAYS_IN_MONTH)
+	for _, installment := range loanResponse.Installments {
+		if installment.IsRealized {
+			if installment.DueDate.After(lastPaidDate) {
+				lastPaidDate = installment.DueDate
+			}
+			continue
+		}
+		response.RemainingInstallments++
+		response.RemainingValue = response.RemainingValue.Add(installment.Value)
+	}
+	days := math.Floor(date.Sub(lastPaidDate).Hours() / 24)
+	if days > 0 {
+		rate := getMonthlyRate(loanResponse.InterestRate)
+		factor := math.Pow(1+rate, days/DAYS_IN_MONTH) - 1
+		response.AccruedInterest = money.Money(math.Round(float64(response.OutstandingBalance) * factor))
+	}
+	response.PayoffValue = response.OutstandingBalance.Add(response.AccruedInterest)
+	response.Savings = response.RemainingValue.Sub(response.PayoffValue)
+	return response, nil
+}
@@ -0,0 +1,37 @@
+package lnservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllSuccess(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{loans: buildLoansMock(), installments: buildInstallmentsMock()}, nowMock)
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Len(t, response.Records, 1)
+	assert.Equal(t, money.FromCents(669978), response.Records[0].OutstandingBalance)
+	assert.Equal(t, uint(1), response.Records[0].PaidInstallments)
+	assert.Nil(t, response.Records[0].Installments)
+}
+
+func TestGetAllEmpty(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{}, nowMock)
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, &LoanListResponse{Records: []LoanResponse{}}, response)
+}
+
+func TestGetAllFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, nowMock)
+
+	response, err := _readingProcess.GetAll(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
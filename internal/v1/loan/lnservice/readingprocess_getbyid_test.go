@@ -0,0 +1,99 @@
+package lnservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/loan/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildLoansMock() []repository.Loan {
+	return []repository.Loan{
+		{
+			Id:            "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d",
+			CreatedAt:     date(2024, 1, 2),
+			UserId:        "5832a502-bede-492d-8dc1-b13b32c30f29",
+			Description:   "Empréstimo pessoal",
+			Principal:     money.FromCents(1000000),
+			Currency:      "BRL",
+			InterestRate:  money.Rate(100000000),
+			Term:          3,
+			Amortization:  AMORTIZATION_PRICE,
+			FirstDueDate:  date(2024, 1, 31),
+			CategoryId:    9,
+			PaymentTypeId: 2,
+		},
+	}
+}
+
+func buildInstallmentsMock() map[string][]repository.Installment {
+	return map[string][]repository.Installment{
+		"4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d": {
+			{
+				LoanId:              "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d",
+				Number:              1,
+				DueDate:             date(2024, 1, 31),
+				Amortization:        money.FromCents(330022),
+				Interest:            money.FromCents(10000),
+				Value:               money.FromCents(340022),
+				InvoiceProjectionId: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c51",
+				IsRealized:          true,
+			},
+			{
+				LoanId:              "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d",
+				Number:              2,
+				DueDate:             date(2024, 2, 29),
+				Amortization:        money.FromCents(333322),
+				Interest:            money.FromCents(6700),
+				Value:               money.FromCents(340022),
+				InvoiceProjectionId: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c52",
+			},
+			{
+				LoanId:              "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d",
+				Number:              3,
+				DueDate:             date(2024, 3, 31),
+				Amortization:        money.FromCents(336656),
+				Interest:            money.FromCents(3367),
+				Value:               money.FromCents(340023),
+				InvoiceProjectionId: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c53",
+			},
+		},
+	}
+}
+
+func TestGetByIdSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{loans: buildLoansMock(), installments: buildInstallmentsMock()}
+	_readingProcess := NewReadingProcess(_mockRepository, nowMock)
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d", UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, "Empréstimo pessoal", response.Description)
+	assert.Equal(t, money.FromCents(20067), response.TotalInterest)
+	assert.Equal(t, money.FromCents(1020067), response.TotalValue)
+	assert.Equal(t, uint(1), response.PaidInstallments)
+	assert.Equal(t, money.FromCents(669978), response.OutstandingBalance)
+	assert.Len(t, response.Installments, 3)
+	assert.True(t, response.Installments[0].IsRealized)
+	assert.Equal(t, money.FromCents(336656), response.Installments[1].Balance)
+	assert.Equal(t, "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c53", response.Installments[2].InvoiceProjectionId)
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{loans: buildLoansMock()}, nowMock)
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d", UserToken: tokenMock})
+	assert.Equal(t, apperror.NotFound("Loan not found"), err)
+	assert.Nil(t, response)
+}
+
+func TestGetByIdFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, nowMock)
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d", UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,60 @@
+package lnservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatePayoffSuccess(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{loans: buildLoansMock(), installments: buildInstallmentsMock()}, nowMock)
+
+	response, err := _readingProcess.SimulatePayoff(PayoffContext{
+		Ctx:       context.TODO(),
+		Id:        "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d",
+		Date:      date(2024, 2, 15),
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	// 15 days of interest since the due date of the first installment, the last one paid
+	assert.Equal(t, &PayoffResponse{
+		Date:                  date(2024, 2, 15),
+		OutstandingBalance:    money.FromCents(669978),
+		AccruedInterest:       money.FromCents(3342),
+		PayoffValue:           money.FromCents(673320),
+		RemainingInstallments: 2,
+		RemainingValue:        money.FromCents(680045),
+		Savings:               money.FromCents(6725),
+	}, response)
+}
+
+func TestSimulatePayoffToday(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{loans: buildLoansMock(), installments: buildInstallmentsMock()}, nowMock)
+
+	response, err := _readingProcess.SimulatePayoff(PayoffContext{Ctx: context.TODO(), Id: "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d", UserToken: tokenMock})
+	assert.NoError(t, err)
+	// today is before the due date of the last installment paid, so no interest is accrued
+	assert.Equal(t, date(2024, 1, 10), response.Date)
+	assert.Equal(t, money.FromCents(0), response.AccruedInterest)
+	assert.Equal(t, money.FromCents(669978), response.PayoffValue)
+}
+
+func TestSimulatePayoffNotFound(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{loans: buildLoansMock()}, nowMock)
+
+	response, err := _readingProcess.SimulatePayoff(PayoffContext{Ctx: context.TODO(), Id: "a8b7c6d5-e4f3-4a2b-9c1d-0e9f8a7b6c5d", UserToken: tokenMock})
+	assert.Equal(t, apperror.NotFound("Loan not found"), err)
+	assert.Nil(t, response)
+}
+
+func TestSimulatePayoffFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, nowMock)
+
+	response, err := _readingProcess.SimulatePayoff(PayoffContext{Ctx: context.TODO(), Id: "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d", UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,166 @@
+package lnservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/ipservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/loan/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+type StorageProcess interface {
+	Create(createCtx CreateContext) (*LoanResponse, error)
+}
+
+type storageProcess struct {
+	repository        repository.Repository
+	generateUUID      func() uuid.UUID
+	referenceChecker  validation.ReferenceChecker
+	projectionProcess ipservice.StorageProcess
+	now               func() time.Time
+	transactor        dbtx.Transactor
+}
+
+func NewStorageProcess(
+	repository repository.Repository,
+	generateUUID func() uuid.UUID,
+	referenceChecker validation.ReferenceChecker,
+	projectionProcess ipservice.StorageProcess,
+	now func() time.Time,
+	transactor dbtx.Transactor) StorageProcess {
+	return &storageProcess{
+		repository:        repository,
+		generateUUID:      generateUUID,
+		referenceChecker:  referenceChecker,
+		projectionProcess: projectionProcess,
+		now:               now,
+		transactor:        transactor,
+	}
+}
+
+// Create registers the loan and generates its schedule, each installment is an invoice projection to be realized when paid.
+// The loan, the projections and the schedule are saved in the same transaction
+func (sp *storageProcess) Create(createCtx CreateContext) (*LoanResponse, error) {
+	request := createCtx.Request
+	user := idpauth.GetUser(createCtx.UserToken)
+	err := sp.checkReferences(createCtx.Ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	loan := repository.NewLoanBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(sp.now()).
+		AddUserId(user.Id).
+		AddDescription(request.Description).
+		AddPrincipal(request.Principal).
+		AddCurrency(money.NormalizeCurrency(request.Currency)).
+		AddInterestRate(request.InterestRate).
+		AddTerm(request.Term).
+		AddAmortization(request.Amortization).
+		AddFirstDueDate(request.FirstDueDate).
+		AddCategoryId(request.CategoryId).
+		AddPaymentTypeId(request.PaymentTypeId).
+		Build()
+	installments := buildSchedule(*loan)
+	var loanSaved *repository.Loan
+	err = sp.transactor.Within(createCtx.Ctx, func(ctx context.Context) error {
+		loanSaved, err = sp.repository.Save(ctx, *loan)
+		if err != nil {
+			return err
+		}
+		for index := range installments {
+			installments[index].LoanId = loan.Id
+			installments[index].Description = fmt.Sprintf("%s %d/%d", loan.Description, installments[index].Number, loan.Term)
+			projection, err := sp.projectionProcess.Create(ipservice.CreateContext{
+				Ctx: ctx,
+				Request: ipservice.CreateRequest{
+					PayIn:         installments[index].DueDate,
+					BuyAt:         loan.CreatedAt,
+					Description:   installments[index].Description,
+					Value:         installments[index].Value,
+					Currency:      loan.Currency,
+					CategoryId:    loan.CategoryId,
+					PaymentTypeId: loan.PaymentTypeId,
+				},
+				UserToken: createCtx.UserToken,
+			})
+			if err != nil {
+				return err
+			}
+			installments[index].InvoiceProjectionId = projection.Id
+		}
+		return sp.repository.SaveInstallments(ctx, installments)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buildResponse(*loanSaved, installments), nil
+}
+
+func (sp *storageProcess) checkReferences(ctx context.Context, request CreateRequest) error {
+	errs := validation.Errors{}
+	exists, err := sp.referenceChecker.InvoiceCategoryExists(ctx, request.CategoryId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		errs = append(errs, validation.NotFound("category_id"))
+	}
+	exists, err = sp.referenceChecker.PaymentTypeExists(ctx, request.PaymentTypeId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		errs = append(errs, validation.NotFound("payment_type_id"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// buildResponse totals the schedule, the outstanding balance is the principal not yet amortized by the realized installments
+func buildResponse(loan repository.Loan, installments []repository.Installment) *LoanResponse {
+	response := &LoanResponse{
+		Id:                 loan.Id,
+		Description:        loan.Description,
+		Principal:          loan.Principal,
+		Currency:           loan.Currency,
+		InterestRate:       loan.InterestRate,
+		Term:               loan.Term,
+		Amortization:       loan.Amortization,
+		FirstDueDate:       loan.FirstDueDate,
+		CategoryId:         loan.CategoryId,
+		PaymentTypeId:      loan.PaymentTypeId,
+		OutstandingBalance: loan.Principal,
+		Installments:       []InstallmentResponse{},
+		CreatedAt:          loan.CreatedAt,
+	}
+	balance := loan.Principal
+	for _, installment := range installments {
+		balance = balance.Sub(installment.Amortization)
+		response.TotalInterest = response.TotalInterest.Add(installment.Interest)
+		response.TotalValue = response.TotalValue.Add(installment.Value)
+		if installment.IsRealized {
+			response.PaidInstallments++
+			response.OutstandingBalance = response.OutstandingBalance.Sub(installment.Amortization)
+		}
+		response.Installments = append(response.Installments, InstallmentResponse{
+			Number:              installment.Number,
+			DueDate:             installment.DueDate,
+			Amortization:        installment.Amortization,
+			Interest:            installment.Interest,
+			Value:               installment.Value,
+			Balance:             balance,
+			InvoiceProjectionId: installment.InvoiceProjectionId,
+			IsRealized:          installment.IsRealized,
+		})
+	}
+	return response
+}
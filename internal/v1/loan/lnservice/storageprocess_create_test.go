@@ -0,0 +1,365 @@
+package lnservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/ipservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/loan/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	loans             []repository.Loan
+	installments      map[string][]repository.Installment
+	loanSaved         *repository.Loan
+	installmentsSaved []repository.Installment
+	err               error
+}
+
+func (m *mockRepository) Save(ctx context.Context, loan repository.Loan) (*repository.Loan, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.loanSaved = &loan
+	return &loan, nil
+}
+
+func (m *mockRepository) SaveInstallments(ctx context.Context, installments []repository.Installment) error {
+	m.installmentsSaved = installments
+	return nil
+}
+
+func (m *mockRepository) GetById(ctx context.Context, id string, userId string) (*repository.Loan, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, loan := range m.loans {
+		if loan.Id == id && loan.UserId == userId {
+			loanFound := loan
+			return &loanFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) GetAll(ctx context.Context, userId string) (*[]repository.Loan, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &m.loans, nil
+}
+
+func (m *mockRepository) GetInstallments(ctx context.Context, loanId string) (*[]repository.Installment, error) {
+	installments := m.installments[loanId]
+	return &installments, nil
+}
+
+type mockReferenceChecker struct {
+	missingInvoiceCategories map[uint]bool
+	missingPaymentTypes      map[uint]bool
+	err                      error
+}
+
+func (rc *mockReferenceChecker) GainCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return true, rc.err
+}
+
+func (rc *mockReferenceChecker) InvoiceCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingInvoiceCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) PaymentTypeExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingPaymentTypes[id], rc.err
+}
+
+type mockProjectionProcess struct {
+	requests []ipservice.CreateRequest
+	err      error
+}
+
+func (m *mockProjectionProcess) Create(createCtx ipservice.CreateContext) (*ipservice.InvoiceProjectionResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.requests = append(m.requests, createCtx.Request)
+	return &ipservice.InvoiceProjectionResponse{
+		Id:    fmt.Sprintf("projection-%d", len(m.requests)),
+		PayIn: createCtx.Request.PayIn,
+		Value: createCtx.Request.Value,
+	}, nil
+}
+
+func (m *mockProjectionProcess) CreateFromBoleto(createFromBoletoCtx ipservice.CreateFromBoletoContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) Update(updateCtx ipservice.UpdateContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) Patch(patchCtx ipservice.PatchContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) Delete(deleteCtx ipservice.DeleteContext) error {
+	return nil
+}
+
+func (m *mockProjectionProcess) Restore(searchCtx ipservice.SearchContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) CreateInvoice(createInvoiceCtx ipservice.CreateInvoiceContext) (*ipservice.InvoiceStat, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) CreateInvoiceBatch(createInvoiceBatchCtx ipservice.CreateInvoiceBatchContext) (*ipservice.InvoiceBatchResponse, error) {
+	return nil, nil
+}
+
+func (m *mockProjectionProcess) RevertInvoice(revertInvoiceCtx ipservice.RevertInvoiceContext) (*ipservice.RevertInvoiceStat, error) {
+	return nil, nil
+}
+
+// mockTransactor tells whether the function ran in the transaction failed, when it would be rolled back
+type mockTransactor struct {
+	rolledBack bool
+}
+
+func (t *mockTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	t.rolledBack = err != nil
+	return err
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func nowMock() time.Time {
+	return time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC)
+}
+
+func uuidMock() uuid.UUID {
+	return uuid.FromStringOrNil("4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d")
+}
+
+func TestCreateSac(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockProjectionProcess := &mockProjectionProcess{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{}, _mockProjectionProcess, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description:   "Financiamento do carro",
+			Principal:     money.FromCents(1200000),
+			InterestRate:  money.Rate(150000000),
+			Term:          2,
+			Amortization:  AMORTIZATION_SAC,
+			FirstDueDate:  date(2024, 2, 10),
+			CategoryId:    3,
+			PaymentTypeId: 1,
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &repository.Loan{
+		Id:            "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d",
+		CreatedAt:     nowMock(),
+		UserId:        "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Description:   "Financiamento do carro",
+		Principal:     money.FromCents(1200000),
+		Currency:      "BRL",
+		InterestRate:  money.Rate(150000000),
+		Term:          2,
+		Amortization:  AMORTIZATION_SAC,
+		FirstDueDate:  date(2024, 2, 10),
+		CategoryId:    3,
+		PaymentTypeId: 1,
+	}, _mockRepository.loanSaved)
+	assert.Equal(t, []repository.Installment{
+		{
+			LoanId:              "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d",
+			Number:              1,
+			DueDate:             date(2024, 2, 10),
+			Description:         "Financiamento do carro 1/2",
+			Amortization:        money.FromCents(600000),
+			Interest:            money.FromCents(18000),
+			Value:               money.FromCents(618000),
+			InvoiceProjectionId: "projection-1",
+		},
+		{
+			LoanId:              "4f8a2b6c-1d3e-4a5b-9c7d-8e0f1a2b3c4d",
+			Number:              2,
+			DueDate:             date(2024, 3, 10),
+			Description:         "Financiamento do carro 2/2",
+			Amortization:        money.FromCents(600000),
+			Interest:            money.FromCents(9000),
+			Value:               money.FromCents(609000),
+			InvoiceProjectionId: "projection-2",
+		},
+	}, _mockRepository.installmentsSaved)
+	assert.Equal(t, []ipservice.CreateRequest{
+		{PayIn: date(2024, 2, 10), BuyAt: nowMock(), Description: "Financiamento do carro 1/2", Value: money.FromCents(618000), Currency: "BRL", CategoryId: 3, PaymentTypeId: 1},
+		{PayIn: date(2024, 3, 10), BuyAt: nowMock(), Description: "Financiamento do carro 2/2", Value: money.FromCents(609000), Currency: "BRL", CategoryId: 3, PaymentTypeId: 1},
+	}, _mockProjectionProcess.requests)
+	assert.Equal(t, money.FromCents(27000), response.TotalInterest)
+	assert.Equal(t, money.FromCents(1227000), response.TotalValue)
+	assert.Equal(t, money.FromCents(1200000), response.OutstandingBalance)
+	assert.Equal(t, uint(0), response.PaidInstallments)
+	assert.Equal(t, money.FromCents(600000), response.Installments[0].Balance)
+	assert.Equal(t, money.FromCents(0), response.Installments[1].Balance)
+}
+
+func TestCreatePrice(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{}, &mockProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description:   "Empréstimo pessoal",
+			Principal:     money.FromCents(1000000),
+			Currency:      "usd",
+			InterestRate:  money.Rate(100000000),
+			Term:          3,
+			Amortization:  AMORTIZATION_PRICE,
+			FirstDueDate:  date(2024, 1, 31),
+			CategoryId:    9,
+			PaymentTypeId: 2,
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", _mockRepository.loanSaved.Currency)
+	assert.Equal(t, []InstallmentResponse{
+		{
+			Number:              1,
+			DueDate:             date(2024, 1, 31),
+			Amortization:        money.FromCents(330022),
+			Interest:            money.FromCents(10000),
+			Value:               money.FromCents(340022),
+			Balance:             money.FromCents(669978),
+			InvoiceProjectionId: "projection-1",
+		},
+		{
+			Number:              2,
+			DueDate:             date(2024, 2, 29),
+			Amortization:        money.FromCents(333322),
+			Interest:            money.FromCents(6700),
+			Value:               money.FromCents(340022),
+			Balance:             money.FromCents(336656),
+			InvoiceProjectionId: "projection-2",
+		},
+		{
+			Number:              3,
+			DueDate:             date(2024, 3, 31),
+			Amortization:        money.FromCents(336656),
+			Interest:            money.FromCents(3367),
+			Value:               money.FromCents(340023),
+			Balance:             money.FromCents(0),
+			InvoiceProjectionId: "projection-3",
+		},
+	}, response.Installments)
+	assert.Equal(t, money.FromCents(20067), response.TotalInterest)
+	assert.Equal(t, money.FromCents(1020067), response.TotalValue)
+}
+
+func TestCreatePriceWithoutInterest(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{}, &mockProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description:   "Parcelamento",
+			Principal:     money.FromCents(10000),
+			Term:          3,
+			Amortization:  AMORTIZATION_PRICE,
+			FirstDueDate:  date(2024, 2, 5),
+			CategoryId:    9,
+			PaymentTypeId: 3,
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromCents(0), response.TotalInterest)
+	assert.Equal(t, money.FromCents(3334), response.Installments[0].Value)
+	assert.Equal(t, money.FromCents(3333), response.Installments[1].Value)
+	assert.Equal(t, money.FromCents(3333), response.Installments[2].Value)
+}
+
+func TestCreateReferencesNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_referenceChecker := &mockReferenceChecker{missingInvoiceCategories: map[uint]bool{99: true}, missingPaymentTypes: map[uint]bool{99: true}}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, _referenceChecker, &mockProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			Description:   "Parcelamento",
+			Principal:     money.FromCents(10000),
+			Term:          3,
+			Amortization:  AMORTIZATION_SAC,
+			FirstDueDate:  date(2024, 2, 5),
+			CategoryId:    99,
+			PaymentTypeId: 99,
+		},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, validation.Errors{validation.NotFound("category_id"), validation.NotFound("payment_type_id")}, err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.loanSaved)
+}
+
+func TestCreateReferenceCheckFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{}, uuidMock, &mockReferenceChecker{err: errors.New("An error has been ocurred")}, &mockProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Parcelamento", Principal: money.FromCents(10000), Term: 3, Amortization: AMORTIZATION_SAC, FirstDueDate: date(2024, 2, 5), CategoryId: 9, PaymentTypeId: 1},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestCreateFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{err: errors.New("An error has been ocurred")}, uuidMock, &mockReferenceChecker{}, &mockProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Parcelamento", Principal: money.FromCents(10000), Term: 3, Amortization: AMORTIZATION_SAC, FirstDueDate: date(2024, 2, 5), CategoryId: 9, PaymentTypeId: 1},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestCreateProjectionFail(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_mockTransactor := &mockTransactor{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidMock, &mockReferenceChecker{},
+		&mockProjectionProcess{err: errors.New("An error has been ocurred")}, nowMock, _mockTransactor)
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx:       context.TODO(),
+		Request:   CreateRequest{Description: "Parcelamento", Principal: money.FromCents(10000), Term: 3, Amortization: AMORTIZATION_SAC, FirstDueDate: date(2024, 2, 5), CategoryId: 9, PaymentTypeId: 1},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+	assert.NotNil(t, _mockRepository.loanSaved)
+	assert.Nil(t, _mockRepository.installmentsSaved)
+	assert.True(t, _mockTransactor.rolledBack)
+}
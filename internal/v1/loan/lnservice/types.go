@@ -0,0 +1,94 @@
+package lnservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const (
+	AMORTIZATION_SAC   = "sac"
+	AMORTIZATION_PRICE = "price"
+)
+
+type CreateContext struct {
+	Ctx       context.Context
+	Request   CreateRequest
+	UserToken string
+}
+
+type SearchContext struct {
+	Ctx       context.Context
+	Id        string
+	UserToken string
+}
+
+// PayoffContext simulates the payoff on the date, the zero date is the current day
+type PayoffContext struct {
+	Ctx       context.Context
+	Id        string
+	Date      time.Time
+	UserToken string
+}
+
+// CreateRequest has the monthly interest rate in percentage, such as 1.5 for 1.5% a month.
+// The installments are paid monthly from the first due date, with the constant amortization (sac) or the constant installment (price)
+type CreateRequest struct {
+	Description   string      `json:"description" binding:"notblank,max=255"`
+	Principal     money.Money `json:"principal" binding:"gt=0" swaggertype:"number"`
+	Currency      string      `json:"currency" binding:"omitempty,iso4217"`
+	InterestRate  money.Rate  `json:"interest_rate" binding:"gte=0" swaggertype:"number"`
+	Term          uint        `json:"term" binding:"required,min=1,max=480"`
+	Amortization  string      `json:"amortization" binding:"required,oneof=sac price"`
+	FirstDueDate  time.Time   `json:"first_due_date" binding:"required"`
+	CategoryId    uint        `json:"category_id" binding:"required,min=1"`
+	PaymentTypeId uint        `json:"payment_type_id" binding:"required,min=1"`
+}
+
+type LoanResponse struct {
+	Id                 string                `json:"id"`
+	Description        string                `json:"description"`
+	Principal          money.Money           `json:"principal" swaggertype:"number"`
+	Currency           string                `json:"currency"`
+	InterestRate       money.Rate            `json:"interest_rate" swaggertype:"number"`
+	Term               uint                  `json:"term"`
+	Amortization       string                `json:"amortization"`
+	FirstDueDate       time.Time             `json:"first_due_date"`
+	CategoryId         uint                  `json:"category_id"`
+	PaymentTypeId      uint                  `json:"payment_type_id"`
+	TotalInterest      money.Money           `json:"total_interest" swaggertype:"number"`
+	TotalValue         money.Money           `json:"total_value" swaggertype:"number"`
+	PaidInstallments   uint                  `json:"paid_installments"`
+	OutstandingBalance money.Money           `json:"outstanding_balance" swaggertype:"number"`
+	Installments       []InstallmentResponse `json:"installments,omitempty"`
+	CreatedAt          time.Time             `json:"created_at"`
+}
+
+// InstallmentResponse is a row of the schedule, the balance is the outstanding principal after the installment is paid
+type InstallmentResponse struct {
+	Number              uint        `json:"number"`
+	DueDate             time.Time   `json:"due_date"`
+	Amortization        money.Money `json:"amortization" swaggertype:"number"`
+	Interest            money.Money `json:"interest" swaggertype:"number"`
+	Value               money.Money `json:"value" swaggertype:"number"`
+	Balance             money.Money `json:"balance" swaggertype:"number"`
+	InvoiceProjectionId string      `json:"invoice_projection_id,omitempty"`
+	IsRealized          bool        `json:"is_realized"`
+}
+
+type LoanListResponse struct {
+	Records []LoanResponse `json:"records"`
+}
+
+// PayoffResponse compares paying the outstanding balance on the date with paying the remaining installments,
+// the accrued interest is the interest of the outstanding balance since the last paid due date
+type PayoffResponse struct {
+	Date                  time.Time   `json:"date"`
+	OutstandingBalance    money.Money `json:"outstanding_balance" swaggertype:"number"`
+	AccruedInterest       money.Money `json:"accrued_interest" swaggertype:"number"`
+	PayoffValue           money.Money `json:"payoff_value" swaggertype:"number"`
+	RemainingInstallments uint        `json:"remaining_installments"`
+	RemainingValue        money.Money `json:"remaining_value" swaggertype:"number"`
+	Savings               money.Money `json:"savings" swaggertype:"number"`
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type LoanBuilder struct {
+	id            string
+	createdAt     time.Time
+	userId        string
+	description   string
+	principal     money.Money
+	currency      string
+	interestRate  money.Rate
+	term          uint
+	amortization  string
+	firstDueDate  time.Time
+	categoryId    uint
+	paymentTypeId uint
+}
+
+func NewLoanBuilder() *LoanBuilder {
+	return &LoanBuilder{}
+}
+func (builder *LoanBuilder) AddId(id string) *LoanBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *LoanBuilder) AddCreatedAt(createdAt time.Time) *LoanBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *LoanBuilder) AddUserId(userId string) *LoanBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *LoanBuilder) AddDescription(description string) *LoanBuilder {
+	builder.description = description
+	return builder
+}
+func (builder *LoanBuilder) AddPrincipal(principal money.Money) *LoanBuilder {
+	builder.principal = principal
+	return builder
+}
+func (builder *LoanBuilder) AddCurrency(currency string) *LoanBuilder {
+	builder.currency = currency
+	return builder
+}
+func (builder *LoanBuilder) AddInterestRate(interestRate money.Rate) *LoanBuilder {
+	builder.interestRate = interestRate
+	return builder
+}
+func (builder *LoanBuilder) AddTerm(term uint) *LoanBuilder {
+	builder.term = term
+	return builder
+}
+func (builder *LoanBuilder) AddAmortization(amortization string) *LoanBuilder {
+	builder.amortization = amortization
+	return builder
+}
+func (builder *LoanBuilder) AddFirstDueDate(firstDueDate time.Time) *LoanBuilder {
+	builder.firstDueDate = firstDueDate
+	return builder
+}
+func (builder *LoanBuilder) AddCategoryId(categoryId uint) *LoanBuilder {
+	builder.categoryId = categoryId
+	return builder
+}
+func (builder *LoanBuilder) AddPaymentTypeId(paymentTypeId uint) *LoanBuilder {
+	builder.paymentTypeId = paymentTypeId
+	return builder
+}
+func (builder *LoanBuilder) Build() *Loan {
+	loan := Loan{}
+
+	loan.Id = builder.id
+	loan.CreatedAt = builder.createdAt
+	loan.UserId = builder.userId
+	loan.Description = builder.description
+	loan.Principal = builder.principal
+	loan.Currency = builder.currency
+	loan.InterestRate = builder.interestRate
+	loan.Term = builder.term
+	loan.Amortization = builder.amortization
+	loan.FirstDueDate = builder.firstDueDate
+	loan.CategoryId = builder.categoryId
+	loan.PaymentTypeId = builder.paymentTypeId
+
+	return &loan
+}
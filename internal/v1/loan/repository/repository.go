@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+)
+
+type Repository interface {
+	Save(ctx context.Context, loan Loan) (*Loan, error)
+	SaveInstallments(ctx context.Context, installments []Installment) error
+	GetById(ctx context.Context, id string, userId string) (*Loan, error)
+	GetAll(ctx context.Context, userId string) (*[]Loan, error)
+	GetInstallments(ctx context.Context, loanId string) (*[]Installment, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Save(ctx context.Context, loan Loan) (*Loan, error) {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO loan (id, created_at, user_id, description, principal, currency, interest_rate, term, amortization,
+			first_due_date, category_id, payment_type_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		loan.Id,
+		loan.CreatedAt.Unix(),
+		loan.UserId,
+		loan.Description,
+		loan.Principal,
+		loan.Currency,
+		loan.InterestRate,
+		loan.Term,
+		loan.Amortization,
+		loan.FirstDueDate,
+		loan.CategoryId,
+		loan.PaymentTypeId,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+// SaveInstallments registers the schedule of the loan, linking each installment to the invoice projection created for it
+func (r *repository) SaveInstallments(ctx context.Context, installments []Installment) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	for _, installment := range installments {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO loan_installment (loan_id, number, due_date, amortization, interest, value, invoice_projection_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			installment.LoanId,
+			installment.Number,
+			installment.DueDate,
+			installment.Amortization,
+			installment.Interest,
+			installment.Value,
+			installment.InvoiceProjectionId,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *repository) scanLoans(rows *sql.Rows) (*[]Loan, error) {
+	loanList := []Loan{}
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var loan Loan
+
+		err := rows.Scan(
+			&loan.Id,
+			&createdAtTimestamp,
+			&loan.UserId,
+			&loan.Description,
+			&loan.Principal,
+			&loan.Currency,
+			&loan.InterestRate,
+			&loan.Term,
+			&loan.Amortization,
+			&loan.FirstDueDate,
+			&loan.CategoryId,
+			&loan.PaymentTypeId)
+		if err != nil {
+			return nil, err
+		}
+		loan.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+
+		loanList = append(loanList, loan)
+	}
+	return &loanList, nil
+}
+
+func (r *repository) GetById(ctx context.Context, id string, userId string) (*Loan, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			description,
+			principal,
+			currency,
+			interest_rate,
+			term,
+			amortization,
+			first_due_date,
+			category_id,
+			payment_type_id
+		FROM
+			loan
+		WHERE
+			id = ? AND user_id = ?`
+	rows, err := r.db.QueryContext(ctx, query, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	loanList, err := r.scanLoans(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(*loanList) == 0 {
+		return nil, nil
+	}
+	return &(*loanList)[0], nil
+}
+
+func (r *repository) GetAll(ctx context.Context, userId string) (*[]Loan, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			description,
+			principal,
+			currency,
+			interest_rate,
+			term,
+			amortization,
+			first_due_date,
+			category_id,
+			payment_type_id
+		FROM
+			loan
+		WHERE
+			user_id = ?
+		ORDER BY created_at ASC`
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanLoans(rows)
+}
+
+// GetInstallments returns the schedule of the loan, an installment is realized when its invoice projection has become an invoice.
+// The installments whose invoice projection was purged from the trash come without it
+func (r *repository) GetInstallments(ctx context.Context, loanId string) (*[]Installment, error) {
+	query := `
+		SELECT
+			li.loan_id,
+			li.number,
+			li.due_date,
+			li.amortization,
+			li.interest,
+			li.value,
+			li.invoice_projection_id,
+			COALESCE(ip.is_already_done AND ip.deleted_at IS NULL, FALSE)
+		FROM
+			loan_installment li
+			LEFT JOIN invoice_projection ip ON ip.id = li.invoice_projection_id
+		WHERE
+			li.loan_id = ?
+		ORDER BY li.number ASC`
+	rows, err := r.db.QueryContext(ctx, query, loanId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	installmentList := []Installment{}
+	for rows.Next() {
+		var invoiceProjectionId sql.NullString
+		var installment Installment
+		err := rows.Scan(
+			&installment.LoanId,
+			&installment.Number,
+			&installment.DueDate,
+			&installment.Amortization,
+			&installment.Interest,
+			&installment.Value,
+			&invoiceProjectionId,
+			&installment.IsRealized)
+		if err != nil {
+			return nil, err
+		}
+		installment.InvoiceProjectionId = invoiceProjectionId.String
+		installmentList = append(installmentList, installment)
+	}
+	return &installmentList, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getAllQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			description,
+			principal,
+			currency,
+			interest_rate,
+			term,
+			amortization,
+			first_due_date,
+			category_id,
+			payment_type_id
+		FROM
+			loan
+		WHERE
+			user_id = ?
+		ORDER BY created_at ASC`
+
+func TestGetAllSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(loanColumns).
+		AddRow("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", time.Now().Unix(), "User1", "Financiamento do carro", "12000.00", "BRL", "1.5", 2, "sac", time.Now(), 3, 1).
+		AddRow("6e4d3c2b-1a0f-4e9d-8c7b-a69584736251", time.Now().Unix(), "User1", "Empréstimo pessoal", "5000.00", "BRL", "2.1", 12, "price", time.Now(), 9, 2)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	loanList, err := _repository.GetAll(context.Background(), "User1")
+	assert.NoError(t, err)
+	assert.Len(t, *loanList, 2)
+	assert.Equal(t, "price", (*loanList)[1].Amortization)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(loanColumns).
+		AddRow("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", time.Now().Unix(), "User1", "Financiamento do carro", "12000.00", "BRL", "1.5", "two", "sac", time.Now(), 3, 1)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetAll(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetAllQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getAllQuery).
+		WithArgs("User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetAll(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var loanColumns = []string{"id", "created_at", "user_id", "description", "principal", "currency", "interest_rate", "term",
+	"amortization", "first_due_date", "category_id", "payment_type_id"}
+
+const getByIdQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			description,
+			principal,
+			currency,
+			interest_rate,
+			term,
+			amortization,
+			first_due_date,
+			category_id,
+			payment_type_id
+		FROM
+			loan
+		WHERE
+			id = ? AND user_id = ?`
+
+func TestGetByIdSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	firstDueDate := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows(loanColumns).
+		AddRow("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", time.Now().Unix(), "User1", "Financiamento do carro", "12000.00", "BRL", "1.50000000", 2, "sac", firstDueDate, 3, 1)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", "User1").
+		WillReturnRows(rowsMock)
+
+	loan, err := _repository.GetById(context.Background(), "5d3c2b1a-0f9e-4d8c-b7a6-958473625140", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromCents(1200000), loan.Principal)
+	assert.Equal(t, money.Rate(150000000), loan.InterestRate)
+	assert.Equal(t, uint(2), loan.Term)
+	assert.Equal(t, firstDueDate, loan.FirstDueDate)
+	assert.Equal(t, uint(1), loan.PaymentTypeId)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", "User1").
+		WillReturnRows(sqlMock.NewRows(loanColumns))
+
+	loan, err := _repository.GetById(context.Background(), "5d3c2b1a-0f9e-4d8c-b7a6-958473625140", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, loan)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(loanColumns).
+		AddRow("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", time.Now().Unix(), "User1", "Financiamento do carro", "12000.00", "BRL", "one and a half", 2, "sac", time.Now(), 3, 1)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", "User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetById(context.Background(), "5d3c2b1a-0f9e-4d8c-b7a6-958473625140", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetById(context.Background(), "5d3c2b1a-0f9e-4d8c-b7a6-958473625140", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var installmentColumns = []string{"loan_id", "number", "due_date", "amortization", "interest", "value", "invoice_projection_id", "is_realized"}
+
+const getInstallmentsQuery = `
+		SELECT
+			li.loan_id,
+			li.number,
+			li.due_date,
+			li.amortization,
+			li.interest,
+			li.value,
+			li.invoice_projection_id,
+			COALESCE(ip.is_already_done AND ip.deleted_at IS NULL, FALSE)
+		FROM
+			loan_installment li
+			LEFT JOIN invoice_projection ip ON ip.id = li.invoice_projection_id
+		WHERE
+			li.loan_id = ?
+		ORDER BY li.number ASC`
+
+func TestGetInstallmentsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(installmentColumns).
+		AddRow("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", 1, time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), "6000.00", "180.00", "6180.00", "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d", 1).
+		AddRow("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", 2, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), "6000.00", "90.00", "6090.00", "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e", 0).
+		AddRow("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", 3, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), "6000.00", "0.00", "6000.00", nil, 0)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInstallmentsQuery).
+		WithArgs("5d3c2b1a-0f9e-4d8c-b7a6-958473625140").
+		WillReturnRows(rowsMock)
+
+	installmentList, err := _repository.GetInstallments(context.Background(), "5d3c2b1a-0f9e-4d8c-b7a6-958473625140")
+	assert.NoError(t, err)
+	assert.Equal(t, &[]Installment{
+		{
+			LoanId:              "5d3c2b1a-0f9e-4d8c-b7a6-958473625140",
+			Number:              1,
+			DueDate:             time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+			Amortization:        money.FromCents(600000),
+			Interest:            money.FromCents(18000),
+			Value:               money.FromCents(618000),
+			InvoiceProjectionId: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+			IsRealized:          true,
+		},
+		{
+			LoanId:              "5d3c2b1a-0f9e-4d8c-b7a6-958473625140",
+			Number:              2,
+			DueDate:             time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+			Amortization:        money.FromCents(600000),
+			Interest:            money.FromCents(9000),
+			Value:               money.FromCents(609000),
+			InvoiceProjectionId: "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e",
+			IsRealized:          false,
+		},
+		{
+			LoanId:       "5d3c2b1a-0f9e-4d8c-b7a6-958473625140",
+			Number:       3,
+			DueDate:      time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+			Amortization: money.FromCents(600000),
+			Interest:     money.FromCents(0),
+			Value:        money.FromCents(600000),
+			IsRealized:   false,
+		},
+	}, installmentList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInstallmentsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(installmentColumns).
+		AddRow("5d3c2b1a-0f9e-4d8c-b7a6-958473625140", 1, time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), "six thousand", "180.00", "6180.00", "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d", 1)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInstallmentsQuery).
+		WithArgs("5d3c2b1a-0f9e-4d8c-b7a6-958473625140").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetInstallments(context.Background(), "5d3c2b1a-0f9e-4d8c-b7a6-958473625140")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInstallmentsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInstallmentsQuery).
+		WithArgs("5d3c2b1a-0f9e-4d8c-b7a6-958473625140").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetInstallments(context.Background(), "5d3c2b1a-0f9e-4d8c-b7a6-958473625140")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveLoanQuery = `
+		INSERT INTO loan (id, created_at, user_id, description, principal, currency, interest_rate, term, amortization,
+			first_due_date, category_id, payment_type_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+const saveInstallmentQuery = `
+			INSERT INTO loan_installment (loan_id, number, due_date, amortization, interest, value, invoice_projection_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+func buildLoanMock() *Loan {
+	return NewLoanBuilder().
+		AddId("5d3c2b1a-0f9e-4d8c-b7a6-958473625140").
+		AddCreatedAt(time.Now()).
+		AddUserId("User1").
+		AddDescription("Financiamento do carro").
+		AddPrincipal(money.FromCents(1200000)).
+		AddCurrency("BRL").
+		AddInterestRate(money.Rate(150000000)).
+		AddTerm(2).
+		AddAmortization("sac").
+		AddFirstDueDate(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)).
+		AddCategoryId(3).
+		AddPaymentTypeId(1).
+		Build()
+}
+
+func buildInstallmentsMock() []Installment {
+	return []Installment{
+		{
+			LoanId:              "5d3c2b1a-0f9e-4d8c-b7a6-958473625140",
+			Number:              1,
+			DueDate:             time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+			Description:         "Financiamento do carro 1/2",
+			Amortization:        money.FromCents(600000),
+			Interest:            money.FromCents(18000),
+			Value:               money.FromCents(618000),
+			InvoiceProjectionId: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+		},
+		{
+			LoanId:              "5d3c2b1a-0f9e-4d8c-b7a6-958473625140",
+			Number:              2,
+			DueDate:             time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+			Description:         "Financiamento do carro 2/2",
+			Amortization:        money.FromCents(600000),
+			Interest:            money.FromCents(9000),
+			Value:               money.FromCents(609000),
+			InvoiceProjectionId: "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e",
+		},
+	}
+}
+
+func TestSaveSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	loanMock := buildLoanMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveLoanQuery).
+		WithArgs(loanMock.Id, loanMock.CreatedAt.Unix(), "User1", "Financiamento do carro", "12000.00", "BRL", "1.5", 2, "sac",
+			loanMock.FirstDueDate, 3, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	loanSaved, err := _repository.Save(context.Background(), *loanMock)
+	assert.NoError(t, err)
+	assert.Equal(t, loanMock.Id, loanSaved.Id)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveLoanQuery).WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	loanSaved, err := _repository.Save(context.Background(), *buildLoanMock())
+	assert.Error(t, err)
+	assert.Empty(t, loanSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	loanSaved, err := _repository.Save(context.Background(), *buildLoanMock())
+	assert.Error(t, err)
+	assert.Empty(t, loanSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveInstallmentsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	installmentsMock := buildInstallmentsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	for _, installment := range installmentsMock {
+		sqlMock.ExpectExec(saveInstallmentQuery).
+			WithArgs(installment.LoanId, installment.Number, installment.DueDate, installment.Amortization.String(), installment.Interest.String(),
+				installment.Value.String(), installment.InvoiceProjectionId).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	sqlMock.ExpectCommit()
+
+	err = _repository.SaveInstallments(context.Background(), installmentsMock)
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveInstallmentsFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveInstallmentQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectExec(saveInstallmentQuery).WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.SaveInstallments(context.Background(), buildInstallmentsMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
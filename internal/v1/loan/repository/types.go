@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+// Loan is a loan or a financing paid in monthly installments, the interest rate is the monthly percentage
+type Loan struct {
+	Id            string
+	CreatedAt     time.Time
+	UserId        string
+	Description   string
+	Principal     money.Money
+	Currency      string
+	InterestRate  money.Rate
+	Term          uint
+	Amortization  string
+	FirstDueDate  time.Time
+	CategoryId    uint
+	PaymentTypeId uint
+}
+
+// Installment is a row of the amortization schedule, paid through its invoice projection.
+// The description is the one of the invoice projection and is only used when the schedule is saved
+type Installment struct {
+	LoanId              string
+	Number              uint
+	DueDate             time.Time
+	Description         string
+	Amortization        money.Money
+	Interest            money.Money
+	Value               money.Money
+	InvoiceProjectionId string
+	IsRealized          bool
+}
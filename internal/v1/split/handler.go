@@ -0,0 +1,212 @@
+package split
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split/spservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	Create(c *gin.Context)
+	CreateDebt(c *gin.Context)
+	GetById(c *gin.Context)
+	GetBalances(c *gin.Context)
+	Settle(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	storageProcess spservice.StorageProcess
+	readingProcess spservice.ReadingProcess
+}
+
+func NewHandler(storageProcess spservice.StorageProcess, readingProcess spservice.ReadingProcess) Handler {
+	return &handler{storageProcess: storageProcess, readingProcess: readingProcess}
+}
+
+// Create godoc
+// @Summary Criar um rateio de despesa
+// @Description Este endpoint permite ratear uma despesa paga pelo usuário entre contatos, informados pelo nome e registrados no primeiro rateio de que participam.
+// @Description No método shares o valor é dividido proporcionalmente às cotas dos participantes e à cota do usuário (owner_share, padrão 1), no método amounts cada participante deve o seu valor e o usuário fica com o restante.
+// @Description A parte de cada participante é gerada como uma receita prevista da categoria informada (padrão Rateio), esperada na data informada ou no pagamento da despesa. Uma despesa só pode ser rateada uma vez
+// @Tags Split
+// @Accept json
+// @Produce json
+// @Param split body spservice.CreateRequest true "Modelo de criação do rateio"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} spservice.SplitResponse
+// @Failure 409 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/split [post]
+func (h *handler) Create(c *gin.Context) {
+	var request spservice.CreateRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Split::StorageProcess::Create", "Create new split", nil)
+	createCtx := spservice.CreateContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	splitCreated, err := h.storageProcess.Create(createCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, splitCreated)
+}
+
+// CreateDebt godoc
+// @Summary Criar um rateio de despesa paga por um contato
+// @Description Este endpoint permite registrar uma despesa paga por um contato e rateada com o usuário, o contato é informado pelo nome e registrado no primeiro rateio de que participa.
+// @Description No método shares o valor é dividido entre a cota do usuário (owner_share, padrão 1) e a cota do contato, no método amounts o usuário deve o valor informado em owner_value.
+// @Description A parte do usuário é gerada como uma despesa prevista da categoria e do tipo de pagamento informados, a ser paga ao contato na data informada ou na data do pagamento da despesa
+// @Tags Split
+// @Accept json
+// @Produce json
+// @Param split body spservice.DebtRequest true "Modelo de criação do rateio"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 201 {object} spservice.SplitResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/split/debt [post]
+func (h *handler) CreateDebt(c *gin.Context) {
+	var request spservice.DebtRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Split::StorageProcess::CreateDebt", "Create new split of a debt", nil)
+	createDebtCtx := spservice.CreateDebtContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	splitCreated, err := h.storageProcess.CreateDebt(createDebtCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusCreated, splitCreated)
+}
+
+// @Summary Obter um rateio de despesa
+// @Description Este endpoint permite obter um rateio com a parte de cada participante e a sua situação: pendente, quitada quando a receita ou a despesa prevista foi realizada ou cancelada quando ela foi excluída
+// @Tags Split
+// @Accept json
+// @Produce json
+// @Param id path string true "Id do rateio"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} spservice.SplitResponse
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/split/{id} [get]
+func (h *handler) GetById(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Split::ReadingProcess::GetById", "Get a split by id", nil)
+	searchCtx := spservice.SearchContext{
+		Ctx:       ctx,
+		Id:        c.Param("id"),
+		UserToken: userToken,
+	}
+	split, err := h.readingProcess.GetById(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, split)
+}
+
+// @Summary Obter o saldo dos contatos nos rateios
+// @Description Este endpoint permite obter, por contato e moeda, o total rateado, o valor já quitado e o valor pendente.
+// @Description Os valores são positivos quando o contato deve ao usuário e negativos quando o usuário deve ao contato
+// @Tags Split
+// @Accept json
+// @Produce json
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} spservice.BalanceListResponse
+// @Router /v1/split/balance [get]
+func (h *handler) GetBalances(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("Split::ReadingProcess::GetBalances", "Get the balances of the contacts", nil)
+	searchCtx := spservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+	}
+	balances, err := h.readingProcess.GetBalances(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, balances)
+}
+
+// @Summary Quitar o saldo de um contato
+// @Description Este endpoint permite quitar as partes pendentes de um contato, realizando as receitas e as despesas previstas na data informada ou no dia atual
+// @Tags Split
+// @Accept json
+// @Produce json
+// @Param id path string true "Id do contato"
+// @Param settle body spservice.SettleRequest true "Modelo de quitação"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} spservice.SettleResponse
+// @Failure 404 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/split/contact/{id}/settle [post]
+func (h *handler) Settle(c *gin.Context) {
+	var request spservice.SettleRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("Split::StorageProcess::Settle", "Settle the shares of a contact", nil)
+	settleCtx := spservice.SettleContext{
+		Ctx:       ctx,
+		Id:        c.Param("id"),
+		Request:   request,
+		UserToken: userToken,
+	}
+	settlement, err := h.storageProcess.Settle(settleCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, settlement)
+}
@@ -0,0 +1,341 @@
+package split
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split/spservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type storageProcessMock struct {
+	err           error
+	split         *spservice.SplitResponse
+	settlement    *spservice.SettleResponse
+	request       spservice.CreateRequest
+	debtRequest   spservice.DebtRequest
+	settleRequest spservice.SettleRequest
+	id            string
+}
+
+func (sp *storageProcessMock) Create(createCtx spservice.CreateContext) (*spservice.SplitResponse, error) {
+	sp.request = createCtx.Request
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.split, nil
+}
+
+func (sp *storageProcessMock) CreateDebt(createDebtCtx spservice.CreateDebtContext) (*spservice.SplitResponse, error) {
+	sp.debtRequest = createDebtCtx.Request
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.split, nil
+}
+
+func (sp *storageProcessMock) Settle(settleCtx spservice.SettleContext) (*spservice.SettleResponse, error) {
+	sp.id = settleCtx.Id
+	sp.settleRequest = settleCtx.Request
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.settlement, nil
+}
+
+type readingProcessMock struct {
+	err      error
+	split    *spservice.SplitResponse
+	balances *spservice.BalanceListResponse
+	id       string
+}
+
+func (rp *readingProcessMock) GetById(searchCtx spservice.SearchContext) (*spservice.SplitResponse, error) {
+	rp.id = searchCtx.Id
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.split, nil
+}
+
+func (rp *readingProcessMock) GetBalances(searchCtx spservice.SearchContext) (*spservice.BalanceListResponse, error) {
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.balances, nil
+}
+
+func buildSplitResponseMock() *spservice.SplitResponse {
+	ownerShare := uint(1)
+	return &spservice.SplitResponse{
+		Id:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+		Direction:   "receivable",
+		InvoiceId:   "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+		Description: "Pizza",
+		Value:       money.FromCents(4958),
+		Currency:    "BRL",
+		Method:      spservice.METHOD_SHARES,
+		OwnerShare:  &ownerShare,
+		OwnerValue:  money.FromCents(1240),
+		PayIn:       time.Date(2023, 11, 25, 0, 0, 0, 0, time.UTC),
+		Shares: []spservice.ShareResponse{
+			{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", Name: "Ana", Share: 1, Value: money.FromCents(1239), GainProjectionId: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", Status: spservice.SHARE_STATUS_SETTLED},
+			{ContactId: "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", Name: "Bruno", Share: 2, Value: money.FromCents(2479), GainProjectionId: "f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b", Status: spservice.SHARE_STATUS_PENDING},
+		},
+		CreatedAt: time.Date(2023, 11, 26, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+const splitResponseBody = `{"id":"7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c","direction":"receivable","invoice_id":"3f2e1d0c-b9a8-4765-8432-10fedcba9876","description":"Pizza","value":49.58,"currency":"BRL","method":"shares","owner_share":1,"owner_value":12.4,"pay_in":"2023-11-25T00:00:00Z","shares":[{"contact_id":"c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8","name":"Ana","share":1,"value":12.39,"gain_projection_id":"e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a","status":"settled"},{"contact_id":"d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809","name":"Bruno","share":2,"value":24.79,"gain_projection_id":"f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b","status":"pending"}],"created_at":"2023-11-26T10:00:00Z"}`
+
+func TestCreateSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{split: buildSplitResponseMock()}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/split", handler.Create)
+
+	body := []byte(`{"invoice_id": "3f2e1d0c-b9a8-4765-8432-10fedcba9876", "method": "shares", "participants": [{"name": "Ana", "share": 1}, {"name": "Bruno", "share": 2}]}`)
+	req, _ := http.NewRequest("POST", "/v1/split", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, splitResponseBody, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, spservice.CreateRequest{
+		InvoiceId: "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+		Method:    spservice.METHOD_SHARES,
+		Participants: []spservice.ParticipantRequest{
+			{Name: "Ana", Share: 1},
+			{Name: "Bruno", Share: 2},
+		},
+	}, _storageProcessMock.request)
+}
+
+func TestCreateInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/split", handler.Create)
+
+	body := []byte(`{"invoice_id": " ", "method": "equal", "participants": []}`)
+	req, _ := http.NewRequest("POST", "/v1/split", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"invoice_id","code":"required","message":"The invoice_id must be informed"},{"field":"method","code":"invalid","message":"The method is not valid"},{"field":"participants","code":"too_small","message":"The participants must be at least 1"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestCreateDebtSuccess(t *testing.T) {
+	ownerShare := uint(1)
+	_storageProcessMock := &storageProcessMock{split: &spservice.SplitResponse{
+		Id:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+		Direction:   "payable",
+		Description: "Hotel",
+		Value:       money.FromCents(9000),
+		Currency:    "BRL",
+		Method:      spservice.METHOD_SHARES,
+		OwnerShare:  &ownerShare,
+		OwnerValue:  money.FromCents(3000),
+		PayIn:       time.Date(2023, 11, 20, 0, 0, 0, 0, time.UTC),
+		Shares: []spservice.ShareResponse{
+			{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", Name: "Ana", Share: 2, Value: money.FromCents(3000), InvoiceProjectionId: "a5e6f708-192a-43b4-85d6-e7f8091a2b3c", Status: spservice.SHARE_STATUS_PENDING},
+		},
+		CreatedAt: time.Date(2023, 11, 26, 10, 0, 0, 0, time.UTC),
+	}}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/split/debt", handler.CreateDebt)
+
+	body := []byte(`{"contact": "Ana", "description": "Hotel", "value": 90, "paid_at": "2023-11-20T00:00:00Z", "method": "shares", "share": 2, "category_id": 4, "payment_type_id": 2}`)
+	req, _ := http.NewRequest("POST", "/v1/split/debt", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"id":"7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c","direction":"payable","description":"Hotel","value":90,"currency":"BRL","method":"shares","owner_share":1,"owner_value":30,"pay_in":"2023-11-20T00:00:00Z","shares":[{"contact_id":"c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8","name":"Ana","share":2,"value":30,"invoice_projection_id":"a5e6f708-192a-43b4-85d6-e7f8091a2b3c","status":"pending"}],"created_at":"2023-11-26T10:00:00Z"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, spservice.DebtRequest{
+		Contact:       "Ana",
+		Description:   "Hotel",
+		Value:         money.FromCents(9000),
+		PaidAt:        time.Date(2023, 11, 20, 0, 0, 0, 0, time.UTC),
+		Method:        spservice.METHOD_SHARES,
+		Share:         2,
+		CategoryId:    4,
+		PaymentTypeId: 2,
+	}, _storageProcessMock.debtRequest)
+}
+
+func TestCreateDebtInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/split/debt", handler.CreateDebt)
+
+	body := []byte(`{"contact": " ", "description": "Hotel", "value": 90, "paid_at": "2023-11-20T00:00:00Z", "method": "shares", "share": 2, "payment_type_id": 2}`)
+	req, _ := http.NewRequest("POST", "/v1/split/debt", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"contact","code":"required","message":"The contact must be informed"},{"field":"category_id","code":"required","message":"The category_id must be informed"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestCreateConflict(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{err: apperror.Conflict("The invoice is already split")}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/split", handler.Create)
+
+	body := []byte(`{"invoice_id": "3f2e1d0c-b9a8-4765-8432-10fedcba9876", "method": "amounts", "participants": [{"name": "Ana", "value": 20}]}`)
+	req, _ := http.NewRequest("POST", "/v1/split", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Conflict","status":409,"detail":"The invoice is already split"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestGetByIdSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{split: buildSplitResponseMock()}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/split/:id", handler.GetById)
+
+	req, _ := http.NewRequest("GET", "/v1/split/7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, splitResponseBody, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", _readingProcessMock.id)
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: apperror.NotFound("Split not found")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/split/:id", handler.GetById)
+
+	req, _ := http.NewRequest("GET", "/v1/split/7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Split not found"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetBalancesSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{balances: &spservice.BalanceListResponse{Records: []spservice.BalanceResponse{
+		{ContactId: "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", Name: "Bruno", Currency: "BRL", Total: money.FromCents(2479), Settled: money.FromCents(0), Pending: money.FromCents(2479)},
+	}}}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/split/balance", handler.GetBalances)
+	apiRouter.GET("/split/:id", handler.GetById)
+
+	req, _ := http.NewRequest("GET", "/v1/split/balance", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"records":[{"contact_id":"d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809","name":"Bruno","currency":"BRL","total":24.79,"settled":0,"pending":24.79}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, _readingProcessMock.id)
+}
+
+func TestGetBalancesFail(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/split/balance", handler.GetBalances)
+
+	req, _ := http.NewRequest("GET", "/v1/split/balance", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestSettleSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{settlement: &spservice.SettleResponse{
+		ContactId:     "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809",
+		Name:          "Bruno",
+		SettledShares: 1,
+		Balances: []spservice.BalanceResponse{
+			{ContactId: "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", Name: "Bruno", Currency: "BRL", Total: money.FromCents(2479), Settled: money.FromCents(2479), Pending: money.FromCents(0)},
+		},
+	}}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/split/contact/:id/settle", handler.Settle)
+
+	body := []byte(`{"pay_in": "2023-11-30T00:00:00Z"}`)
+	req, _ := http.NewRequest("POST", "/v1/split/contact/d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809/settle", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"contact_id":"d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809","name":"Bruno","settled_shares":1,"balances":[{"contact_id":"d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809","name":"Bruno","currency":"BRL","total":24.79,"settled":24.79,"pending":0}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", _storageProcessMock.id)
+	assert.Equal(t, time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC), *_storageProcessMock.settleRequest.PayIn)
+}
+
+func TestSettleContactNotFound(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{err: apperror.NotFound("Contact not found")}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.POST("/split/contact/:id/settle", handler.Settle)
+
+	req, _ := http.NewRequest("POST", "/v1/split/contact/d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809/settle", bytes.NewReader([]byte(`{}`)))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Contact not found"}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type SplitBuilder struct {
+	id          string
+	createdAt   time.Time
+	userId      string
+	direction   string
+	invoiceId   string
+	method      string
+	ownerShare  uint
+	ownerValue  money.Money
+	payIn       time.Time
+	description string
+	value       money.Money
+	currency    string
+}
+
+func NewSplitBuilder() *SplitBuilder {
+	return &SplitBuilder{}
+}
+func (builder *SplitBuilder) AddId(id string) *SplitBuilder {
+	builder.id = id
+	return builder
+}
+func (builder *SplitBuilder) AddCreatedAt(createdAt time.Time) *SplitBuilder {
+	builder.createdAt = createdAt
+	return builder
+}
+func (builder *SplitBuilder) AddUserId(userId string) *SplitBuilder {
+	builder.userId = userId
+	return builder
+}
+func (builder *SplitBuilder) AddDirection(direction string) *SplitBuilder {
+	builder.direction = direction
+	return builder
+}
+func (builder *SplitBuilder) AddInvoiceId(invoiceId string) *SplitBuilder {
+	builder.invoiceId = invoiceId
+	return builder
+}
+func (builder *SplitBuilder) AddMethod(method string) *SplitBuilder {
+	builder.method = method
+	return builder
+}
+func (builder *SplitBuilder) AddOwnerShare(ownerShare uint) *SplitBuilder {
+	builder.ownerShare = ownerShare
+	return builder
+}
+func (builder *SplitBuilder) AddOwnerValue(ownerValue money.Money) *SplitBuilder {
+	builder.ownerValue = ownerValue
+	return builder
+}
+func (builder *SplitBuilder) AddPayIn(payIn time.Time) *SplitBuilder {
+	builder.payIn = payIn
+	return builder
+}
+func (builder *SplitBuilder) AddDescription(description string) *SplitBuilder {
+	builder.description = description
+	return builder
+}
+func (builder *SplitBuilder) AddValue(value money.Money) *SplitBuilder {
+	builder.value = value
+	return builder
+}
+func (builder *SplitBuilder) AddCurrency(currency string) *SplitBuilder {
+	builder.currency = currency
+	return builder
+}
+func (builder *SplitBuilder) Build() *Split {
+	split := Split{}
+
+	split.Id = builder.id
+	split.CreatedAt = builder.createdAt
+	split.UserId = builder.userId
+	split.Direction = builder.direction
+	split.InvoiceId = builder.invoiceId
+	split.Method = builder.method
+	split.OwnerShare = builder.ownerShare
+	split.OwnerValue = builder.ownerValue
+	split.PayIn = builder.payIn
+	split.Description = builder.description
+	split.Value = builder.value
+	split.Currency = builder.currency
+
+	return &split
+}
@@ -0,0 +1,449 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+)
+
+type Repository interface {
+	Save(ctx context.Context, split Split, contacts []Contact) (*Split, error)
+	SaveShares(ctx context.Context, shares []Share) error
+	GetById(ctx context.Context, id string, userId string) (*Split, error)
+	GetByInvoiceId(ctx context.Context, invoiceId string, userId string) (*Split, error)
+	GetShares(ctx context.Context, splitId string) (*[]Share, error)
+	GetPendingShares(ctx context.Context, contactId string) (*[]Share, error)
+	GetInvoice(ctx context.Context, id string, userId string) (*Invoice, error)
+	GetContacts(ctx context.Context, userId string) (*[]Contact, error)
+	GetContactById(ctx context.Context, id string, userId string) (*Contact, error)
+	GetBalances(ctx context.Context, userId string) (*[]Balance, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// Save registers the split with the contacts not yet registered, its shares are saved apart once their projections
+// have been created
+func (r *repository) Save(ctx context.Context, split Split, contacts []Contact) (*Split, error) {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	for _, contact := range contacts {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO split_contact (id, created_at, user_id, name)
+			VALUES (?, ?, ?, ?)`,
+			contact.Id,
+			contact.CreatedAt.Unix(),
+			contact.UserId,
+			contact.Name,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO invoice_split (id, created_at, user_id, direction, invoice_id, method, owner_share, owner_value, pay_in, description, value, currency)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		split.Id,
+		split.CreatedAt.Unix(),
+		split.UserId,
+		split.Direction,
+		sql.NullString{String: split.InvoiceId, Valid: split.InvoiceId != ""},
+		split.Method,
+		split.OwnerShare,
+		split.OwnerValue,
+		split.PayIn,
+		split.Description,
+		split.Value,
+		split.Currency,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &split, nil
+}
+
+func (r *repository) SaveShares(ctx context.Context, shares []Share) error {
+	tx, err := dbtx.Begin(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	for _, share := range shares {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO invoice_split_share (split_id, contact_id, share, value, gain_projection_id, invoice_projection_id)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			share.SplitId,
+			share.ContactId,
+			share.Share,
+			share.Value,
+			sql.NullString{String: share.GainProjectionId, Valid: share.GainProjectionId != ""},
+			sql.NullString{String: share.InvoiceProjectionId, Valid: share.InvoiceProjectionId != ""},
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *repository) scanSplits(rows *sql.Rows) (*[]Split, error) {
+	splitList := []Split{}
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var invoiceId sql.NullString
+		var split Split
+
+		err := rows.Scan(
+			&split.Id,
+			&createdAtTimestamp,
+			&split.UserId,
+			&split.Direction,
+			&invoiceId,
+			&split.Method,
+			&split.OwnerShare,
+			&split.OwnerValue,
+			&split.PayIn,
+			&split.Description,
+			&split.Value,
+			&split.Currency)
+		if err != nil {
+			return nil, err
+		}
+		split.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+		split.InvoiceId = invoiceId.String
+
+		splitList = append(splitList, split)
+	}
+	return &splitList, nil
+}
+
+func (r *repository) getSplit(ctx context.Context, query string, args ...interface{}) (*Split, error) {
+	rows, err := dbtx.Get(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	splitList, err := r.scanSplits(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(*splitList) == 0 {
+		return nil, nil
+	}
+	return &(*splitList)[0], nil
+}
+
+func (r *repository) GetById(ctx context.Context, id string, userId string) (*Split, error) {
+	query := `
+		SELECT
+			s.id,
+			s.created_at,
+			s.user_id,
+			s.direction,
+			s.invoice_id,
+			s.method,
+			s.owner_share,
+			s.owner_value,
+			s.pay_in,
+			COALESCE(i.description, s.description),
+			COALESCE(i.value, s.value),
+			COALESCE(i.currency, s.currency)
+		FROM
+			invoice_split s
+			LEFT JOIN invoice i ON i.id = s.invoice_id
+		WHERE
+			s.id = ? AND s.user_id = ?`
+	return r.getSplit(ctx, query, id, userId)
+}
+
+func (r *repository) GetByInvoiceId(ctx context.Context, invoiceId string, userId string) (*Split, error) {
+	query := `
+		SELECT
+			s.id,
+			s.created_at,
+			s.user_id,
+			s.direction,
+			s.invoice_id,
+			s.method,
+			s.owner_share,
+			s.owner_value,
+			s.pay_in,
+			COALESCE(i.description, s.description),
+			COALESCE(i.value, s.value),
+			COALESCE(i.currency, s.currency)
+		FROM
+			invoice_split s
+			LEFT JOIN invoice i ON i.id = s.invoice_id
+		WHERE
+			s.invoice_id = ? AND s.user_id = ?`
+	return r.getSplit(ctx, query, invoiceId, userId)
+}
+
+// scanShares reads the shares with the state of their projections, a share is cancelled when its projection has been
+// deleted
+func (r *repository) scanShares(rows *sql.Rows) (*[]Share, error) {
+	shareList := []Share{}
+	for rows.Next() {
+		var gainProjectionId sql.NullString
+		var invoiceProjectionId sql.NullString
+		var share Share
+		err := rows.Scan(
+			&share.SplitId,
+			&share.ContactId,
+			&share.ContactName,
+			&share.Share,
+			&share.Value,
+			&gainProjectionId,
+			&invoiceProjectionId,
+			&share.IsSettled,
+			&share.IsCancelled)
+		if err != nil {
+			return nil, err
+		}
+		share.GainProjectionId = gainProjectionId.String
+		share.InvoiceProjectionId = invoiceProjectionId.String
+		shareList = append(shareList, share)
+	}
+	return &shareList, nil
+}
+
+func (r *repository) GetShares(ctx context.Context, splitId string) (*[]Share, error) {
+	query := `
+		SELECT
+			ss.split_id,
+			ss.contact_id,
+			c.name,
+			ss.share,
+			ss.value,
+			ss.gain_projection_id,
+			ss.invoice_projection_id,
+			COALESCE(gp.is_already_done, ip.is_already_done, FALSE),
+			COALESCE(gp.id, ip.id) IS NULL OR COALESCE(gp.deleted_at, ip.deleted_at) IS NOT NULL
+		FROM
+			invoice_split_share ss
+			INNER JOIN split_contact c ON c.id = ss.contact_id
+			LEFT JOIN gain_projection gp ON gp.id = ss.gain_projection_id
+			LEFT JOIN invoice_projection ip ON ip.id = ss.invoice_projection_id
+		WHERE
+			ss.split_id = ?
+		ORDER BY c.name ASC`
+	rows, err := r.db.QueryContext(ctx, query, splitId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanShares(rows)
+}
+
+// GetPendingShares returns the shares of the contact whose projections are neither realized nor deleted
+func (r *repository) GetPendingShares(ctx context.Context, contactId string) (*[]Share, error) {
+	query := `
+		SELECT
+			ss.split_id,
+			ss.contact_id,
+			c.name,
+			ss.share,
+			ss.value,
+			ss.gain_projection_id,
+			ss.invoice_projection_id,
+			COALESCE(gp.is_already_done, ip.is_already_done, FALSE),
+			COALESCE(gp.id, ip.id) IS NULL OR COALESCE(gp.deleted_at, ip.deleted_at) IS NOT NULL
+		FROM
+			invoice_split_share ss
+			INNER JOIN split_contact c ON c.id = ss.contact_id
+			LEFT JOIN gain_projection gp ON gp.id = ss.gain_projection_id
+			LEFT JOIN invoice_projection ip ON ip.id = ss.invoice_projection_id
+		WHERE
+			ss.contact_id = ? AND COALESCE(gp.is_already_done, ip.is_already_done) = FALSE
+			AND COALESCE(gp.deleted_at, ip.deleted_at) IS NULL
+		ORDER BY COALESCE(gp.pay_in, ip.pay_in) ASC`
+	rows, err := r.db.QueryContext(ctx, query, contactId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanShares(rows)
+}
+
+func (r *repository) GetInvoice(ctx context.Context, id string, userId string) (*Invoice, error) {
+	query := `
+		SELECT
+			id,
+			pay_at,
+			description,
+			value,
+			currency
+		FROM
+			invoice
+		WHERE
+			id = ? AND user_id = ? AND deleted_at IS NULL`
+	rows, err := r.db.QueryContext(ctx, query, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	invoiceList := []Invoice{}
+	for rows.Next() {
+		var invoice Invoice
+		err := rows.Scan(
+			&invoice.Id,
+			&invoice.PayAt,
+			&invoice.Description,
+			&invoice.Value,
+			&invoice.Currency)
+		if err != nil {
+			return nil, err
+		}
+		invoiceList = append(invoiceList, invoice)
+	}
+	if len(invoiceList) == 0 {
+		return nil, nil
+	}
+	return &invoiceList[0], nil
+}
+
+func (r *repository) scanContacts(rows *sql.Rows) (*[]Contact, error) {
+	contactList := []Contact{}
+	for rows.Next() {
+		var createdAtTimestamp sql.NullInt64
+		var contact Contact
+
+		err := rows.Scan(
+			&contact.Id,
+			&createdAtTimestamp,
+			&contact.UserId,
+			&contact.Name)
+		if err != nil {
+			return nil, err
+		}
+		contact.CreatedAt = time.Unix(createdAtTimestamp.Int64, 0)
+
+		contactList = append(contactList, contact)
+	}
+	return &contactList, nil
+}
+
+func (r *repository) GetContacts(ctx context.Context, userId string) (*[]Contact, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			name
+		FROM
+			split_contact
+		WHERE
+			user_id = ?
+		ORDER BY name ASC`
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanContacts(rows)
+}
+
+func (r *repository) GetContactById(ctx context.Context, id string, userId string) (*Contact, error) {
+	query := `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			name
+		FROM
+			split_contact
+		WHERE
+			id = ? AND user_id = ?`
+	rows, err := r.db.QueryContext(ctx, query, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	contactList, err := r.scanContacts(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(*contactList) == 0 {
+		return nil, nil
+	}
+	return &(*contactList)[0], nil
+}
+
+// GetBalances sums the shares of each contact by currency, ignoring the ones whose projection has been deleted.
+// The shares of the receivable splits count positive and the ones of the payable splits negative, the settled amount is
+// the value of the gains received and of the invoices paid, the pending one is the value of the shares not realized yet
+func (r *repository) GetBalances(ctx context.Context, userId string) (*[]Balance, error) {
+	query := `
+		SELECT
+			c.id,
+			c.name,
+			b.currency,
+			SUM(b.total),
+			SUM(b.settled),
+			SUM(b.pending)
+		FROM
+			split_contact c
+			INNER JOIN (
+				SELECT
+					ss.contact_id,
+					gp.currency,
+					ss.value AS total,
+					CASE WHEN gp.is_already_done THEN COALESCE(g.value, 0) ELSE 0 END AS settled,
+					CASE WHEN gp.is_already_done THEN 0 ELSE ss.value END AS pending
+				FROM
+					invoice_split_share ss
+					INNER JOIN gain_projection gp ON gp.id = ss.gain_projection_id AND gp.deleted_at IS NULL
+					LEFT JOIN gain g ON g.gain_projection_id = gp.id AND g.deleted_at IS NULL
+				UNION ALL
+				SELECT
+					ss.contact_id,
+					ip.currency,
+					-ss.value AS total,
+					CASE WHEN ip.is_already_done THEN -COALESCE(i.value, 0) ELSE 0 END AS settled,
+					CASE WHEN ip.is_already_done THEN 0 ELSE -ss.value END AS pending
+				FROM
+					invoice_split_share ss
+					INNER JOIN invoice_projection ip ON ip.id = ss.invoice_projection_id AND ip.deleted_at IS NULL
+					LEFT JOIN invoice i ON i.invoice_projection_id = ip.id AND i.deleted_at IS NULL
+			) b ON b.contact_id = c.id
+		WHERE
+			c.user_id = ?
+		GROUP BY c.id, c.name, b.currency
+		ORDER BY c.name ASC, b.currency ASC`
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	balanceList := []Balance{}
+	for rows.Next() {
+		var balance Balance
+		err := rows.Scan(
+			&balance.ContactId,
+			&balance.ContactName,
+			&balance.Currency,
+			&balance.Total,
+			&balance.Settled,
+			&balance.Pending)
+		if err != nil {
+			return nil, err
+		}
+		balanceList = append(balanceList, balance)
+	}
+	return &balanceList, nil
+}
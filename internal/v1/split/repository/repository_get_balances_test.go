@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var balanceColumns = []string{"id", "name", "currency", "total", "settled", "pending"}
+
+const getBalancesQuery = `
+		SELECT
+			c.id,
+			c.name,
+			b.currency,
+			SUM(b.total),
+			SUM(b.settled),
+			SUM(b.pending)
+		FROM
+			split_contact c
+			INNER JOIN (
+				SELECT
+					ss.contact_id,
+					gp.currency,
+					ss.value AS total,
+					CASE WHEN gp.is_already_done THEN COALESCE(g.value, 0) ELSE 0 END AS settled,
+					CASE WHEN gp.is_already_done THEN 0 ELSE ss.value END AS pending
+				FROM
+					invoice_split_share ss
+					INNER JOIN gain_projection gp ON gp.id = ss.gain_projection_id AND gp.deleted_at IS NULL
+					LEFT JOIN gain g ON g.gain_projection_id = gp.id AND g.deleted_at IS NULL
+				UNION ALL
+				SELECT
+					ss.contact_id,
+					ip.currency,
+					-ss.value AS total,
+					CASE WHEN ip.is_already_done THEN -COALESCE(i.value, 0) ELSE 0 END AS settled,
+					CASE WHEN ip.is_already_done THEN 0 ELSE -ss.value END AS pending
+				FROM
+					invoice_split_share ss
+					INNER JOIN invoice_projection ip ON ip.id = ss.invoice_projection_id AND ip.deleted_at IS NULL
+					LEFT JOIN invoice i ON i.invoice_projection_id = ip.id AND i.deleted_at IS NULL
+			) b ON b.contact_id = c.id
+		WHERE
+			c.user_id = ?
+		GROUP BY c.id, c.name, b.currency
+		ORDER BY c.name ASC, b.currency ASC`
+
+func TestGetBalancesSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(balanceColumns).
+		AddRow("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "Ana", "BRL", "66.43", "16.53", "49.90").
+		AddRow("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "Ana", "USD", "12.00", "0.00", "12.00").
+		AddRow("d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", "Bruno", "BRL", "-30.00", "-10.00", "-20.00")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getBalancesQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	balanceList, err := _repository.GetBalances(context.Background(), "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, &[]Balance{
+		{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", ContactName: "Ana", Currency: "BRL", Total: money.FromCents(6643), Settled: money.FromCents(1653), Pending: money.FromCents(4990)},
+		{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", ContactName: "Ana", Currency: "USD", Total: money.FromCents(1200), Settled: money.FromCents(0), Pending: money.FromCents(1200)},
+		{ContactId: "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", ContactName: "Bruno", Currency: "BRL", Total: money.FromCents(-3000), Settled: money.FromCents(-1000), Pending: money.FromCents(-2000)},
+	}, balanceList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetBalancesScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(balanceColumns).
+		AddRow("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "Ana", "BRL", "sixty six", "16.53", "49.90")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getBalancesQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetBalances(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetBalancesQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getBalancesQuery).
+		WithArgs("User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetBalances(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var splitColumns = []string{"id", "created_at", "user_id", "direction", "invoice_id", "method", "owner_share", "owner_value", "pay_in",
+	"description", "value", "currency"}
+
+const getByIdQuery = `
+		SELECT
+			s.id,
+			s.created_at,
+			s.user_id,
+			s.direction,
+			s.invoice_id,
+			s.method,
+			s.owner_share,
+			s.owner_value,
+			s.pay_in,
+			COALESCE(i.description, s.description),
+			COALESCE(i.value, s.value),
+			COALESCE(i.currency, s.currency)
+		FROM
+			invoice_split s
+			LEFT JOIN invoice i ON i.id = s.invoice_id
+		WHERE
+			s.id = ? AND s.user_id = ?`
+
+func TestGetByIdSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	payIn := time.Date(2023, 11, 25, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows(splitColumns).
+		AddRow("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", time.Now().Unix(), "User1", "receivable", "3f2e1d0c-b9a8-4765-8432-10fedcba9876", "shares", 1, "16.53", payIn, "Pizza", "49.58", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1").
+		WillReturnRows(rowsMock)
+
+	split, err := _repository.GetById(context.Background(), "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", split.Id)
+	assert.Equal(t, "receivable", split.Direction)
+	assert.Equal(t, "3f2e1d0c-b9a8-4765-8432-10fedcba9876", split.InvoiceId)
+	assert.Equal(t, "shares", split.Method)
+	assert.Equal(t, uint(1), split.OwnerShare)
+	assert.Equal(t, money.FromCents(1653), split.OwnerValue)
+	assert.Equal(t, payIn, split.PayIn)
+	assert.Equal(t, "Pizza", split.Description)
+	assert.Equal(t, money.FromCents(4958), split.Value)
+	assert.Equal(t, "BRL", split.Currency)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdPayableSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(splitColumns).
+		AddRow("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", time.Now().Unix(), "User1", "payable", nil, "amounts", 0, "30.00", time.Now(), "Hotel", "90.00", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1").
+		WillReturnRows(rowsMock)
+
+	split, err := _repository.GetById(context.Background(), "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "payable", split.Direction)
+	assert.Empty(t, split.InvoiceId)
+	assert.Equal(t, money.FromCents(3000), split.OwnerValue)
+	assert.Equal(t, "Hotel", split.Description)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1").
+		WillReturnRows(sqlMock.NewRows(splitColumns))
+
+	split, err := _repository.GetById(context.Background(), "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, split)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(splitColumns).
+		AddRow("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", time.Now().Unix(), "User1", "receivable", "3f2e1d0c-b9a8-4765-8432-10fedcba9876", "shares", 1, "sixteen", time.Now(), "Pizza", "49.58", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetById(context.Background(), "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetByIdQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getByIdQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetById(context.Background(), "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
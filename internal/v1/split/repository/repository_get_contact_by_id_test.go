@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getContactByIdQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			name
+		FROM
+			split_contact
+		WHERE
+			id = ? AND user_id = ?`
+
+func TestGetContactByIdSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(contactColumns).
+		AddRow("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", time.Now().Unix(), "User1", "Ana")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContactByIdQuery).
+		WithArgs("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "User1").
+		WillReturnRows(rowsMock)
+
+	contact, err := _repository.GetContactById(context.Background(), "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", contact.Id)
+	assert.Equal(t, "Ana", contact.Name)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetContactByIdNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContactByIdQuery).
+		WithArgs("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "User1").
+		WillReturnRows(sqlMock.NewRows(contactColumns))
+
+	contact, err := _repository.GetContactById(context.Background(), "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, contact)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetContactByIdQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContactByIdQuery).
+		WithArgs("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetContactById(context.Background(), "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var contactColumns = []string{"id", "created_at", "user_id", "name"}
+
+const getContactsQuery = `
+		SELECT
+			id,
+			created_at,
+			user_id,
+			name
+		FROM
+			split_contact
+		WHERE
+			user_id = ?
+		ORDER BY name ASC`
+
+func TestGetContactsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	createdAt := time.Date(2023, 11, 25, 10, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows(contactColumns).
+		AddRow("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", createdAt.Unix(), "User1", "Ana").
+		AddRow("d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", createdAt.Unix(), "User1", "Bruno")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContactsQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	contactList, err := _repository.GetContacts(context.Background(), "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(*contactList))
+	assert.Equal(t, "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", (*contactList)[0].Id)
+	assert.Equal(t, "Ana", (*contactList)[0].Name)
+	assert.Equal(t, createdAt.Unix(), (*contactList)[0].CreatedAt.Unix())
+	assert.Equal(t, "Bruno", (*contactList)[1].Name)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetContactsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(contactColumns).
+		AddRow("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "yesterday", "User1", "Ana")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContactsQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetContacts(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetContactsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getContactsQuery).
+		WithArgs("User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetContacts(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var invoiceColumns = []string{"id", "pay_at", "description", "value", "currency"}
+
+const getInvoiceQuery = `
+		SELECT
+			id,
+			pay_at,
+			description,
+			value,
+			currency
+		FROM
+			invoice
+		WHERE
+			id = ? AND user_id = ? AND deleted_at IS NULL`
+
+func TestGetInvoiceSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	payAt := time.Date(2023, 11, 25, 0, 0, 0, 0, time.UTC)
+	rowsMock := sqlMock.NewRows(invoiceColumns).
+		AddRow("3f2e1d0c-b9a8-4765-8432-10fedcba9876", payAt, "Pizza", "49.58", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceQuery).
+		WithArgs("3f2e1d0c-b9a8-4765-8432-10fedcba9876", "User1").
+		WillReturnRows(rowsMock)
+
+	invoice, err := _repository.GetInvoice(context.Background(), "3f2e1d0c-b9a8-4765-8432-10fedcba9876", "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, &Invoice{Id: "3f2e1d0c-b9a8-4765-8432-10fedcba9876", PayAt: payAt, Description: "Pizza", Value: money.FromCents(4958), Currency: "BRL"}, invoice)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceNotFound(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceQuery).
+		WithArgs("3f2e1d0c-b9a8-4765-8432-10fedcba9876", "User1").
+		WillReturnRows(sqlMock.NewRows(invoiceColumns))
+
+	invoice, err := _repository.GetInvoice(context.Background(), "3f2e1d0c-b9a8-4765-8432-10fedcba9876", "User1")
+	assert.NoError(t, err)
+	assert.Nil(t, invoice)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(invoiceColumns).
+		AddRow("3f2e1d0c-b9a8-4765-8432-10fedcba9876", time.Now(), "Pizza", "forty nine", "BRL")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceQuery).
+		WithArgs("3f2e1d0c-b9a8-4765-8432-10fedcba9876", "User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetInvoice(context.Background(), "3f2e1d0c-b9a8-4765-8432-10fedcba9876", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceQuery).
+		WithArgs("3f2e1d0c-b9a8-4765-8432-10fedcba9876", "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetInvoice(context.Background(), "3f2e1d0c-b9a8-4765-8432-10fedcba9876", "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getPendingSharesQuery = `
+		SELECT
+			ss.split_id,
+			ss.contact_id,
+			c.name,
+			ss.share,
+			ss.value,
+			ss.gain_projection_id,
+			ss.invoice_projection_id,
+			COALESCE(gp.is_already_done, ip.is_already_done, FALSE),
+			COALESCE(gp.id, ip.id) IS NULL OR COALESCE(gp.deleted_at, ip.deleted_at) IS NOT NULL
+		FROM
+			invoice_split_share ss
+			INNER JOIN split_contact c ON c.id = ss.contact_id
+			LEFT JOIN gain_projection gp ON gp.id = ss.gain_projection_id
+			LEFT JOIN invoice_projection ip ON ip.id = ss.invoice_projection_id
+		WHERE
+			ss.contact_id = ? AND COALESCE(gp.is_already_done, ip.is_already_done) = FALSE
+			AND COALESCE(gp.deleted_at, ip.deleted_at) IS NULL
+		ORDER BY COALESCE(gp.pay_in, ip.pay_in) ASC`
+
+func TestGetPendingSharesSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(shareColumns).
+		AddRow("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "Ana", 1, "16.53", "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", nil, 0, 0).
+		AddRow("8f5b3d2c-0e9a-4f7b-c6d4-3a2e1f0b9c8d", "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "Ana", 0, "30.00", nil, "a5e6f708-192a-43b4-85d6-e7f8091a2b3c", 0, 0)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getPendingSharesQuery).
+		WithArgs("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8").
+		WillReturnRows(rowsMock)
+
+	shareList, err := _repository.GetPendingShares(context.Background(), "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8")
+	assert.NoError(t, err)
+	assert.Equal(t, &[]Share{
+		{
+			SplitId:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+			ContactId:        "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+			ContactName:      "Ana",
+			Share:            1,
+			Value:            money.FromCents(1653),
+			GainProjectionId: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a",
+		},
+		{
+			SplitId:             "8f5b3d2c-0e9a-4f7b-c6d4-3a2e1f0b9c8d",
+			ContactId:           "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+			ContactName:         "Ana",
+			Value:               money.FromCents(3000),
+			InvoiceProjectionId: "a5e6f708-192a-43b4-85d6-e7f8091a2b3c",
+		},
+	}, shareList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetPendingSharesEmpty(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getPendingSharesQuery).
+		WithArgs("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8").
+		WillReturnRows(sqlMock.NewRows(shareColumns))
+
+	shareList, err := _repository.GetPendingShares(context.Background(), "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8")
+	assert.NoError(t, err)
+	assert.Equal(t, &[]Share{}, shareList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetPendingSharesQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getPendingSharesQuery).
+		WithArgs("c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetPendingShares(context.Background(), "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var shareColumns = []string{"split_id", "contact_id", "name", "share", "value", "gain_projection_id", "invoice_projection_id", "is_settled", "is_cancelled"}
+
+const getSharesQuery = `
+		SELECT
+			ss.split_id,
+			ss.contact_id,
+			c.name,
+			ss.share,
+			ss.value,
+			ss.gain_projection_id,
+			ss.invoice_projection_id,
+			COALESCE(gp.is_already_done, ip.is_already_done, FALSE),
+			COALESCE(gp.id, ip.id) IS NULL OR COALESCE(gp.deleted_at, ip.deleted_at) IS NOT NULL
+		FROM
+			invoice_split_share ss
+			INNER JOIN split_contact c ON c.id = ss.contact_id
+			LEFT JOIN gain_projection gp ON gp.id = ss.gain_projection_id
+			LEFT JOIN invoice_projection ip ON ip.id = ss.invoice_projection_id
+		WHERE
+			ss.split_id = ?
+		ORDER BY c.name ASC`
+
+func TestGetSharesSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(shareColumns).
+		AddRow("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "Ana", 1, "16.53", "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", nil, 1, 0).
+		AddRow("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", "Bruno", 1, "16.52", "f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b", nil, 0, 1)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getSharesQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c").
+		WillReturnRows(rowsMock)
+
+	shareList, err := _repository.GetShares(context.Background(), "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c")
+	assert.NoError(t, err)
+	assert.Equal(t, &[]Share{
+		{
+			SplitId:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+			ContactId:        "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+			ContactName:      "Ana",
+			Share:            1,
+			Value:            money.FromCents(1653),
+			GainProjectionId: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a",
+			IsSettled:        true,
+		},
+		{
+			SplitId:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+			ContactId:        "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809",
+			ContactName:      "Bruno",
+			Share:            1,
+			Value:            money.FromCents(1652),
+			GainProjectionId: "f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b",
+			IsCancelled:      true,
+		},
+	}, shareList)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetSharesScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows(shareColumns).
+		AddRow("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", "Ana", 1, "sixteen", "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", nil, 0, 0)
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getSharesQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetShares(context.Background(), "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetSharesQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getSharesQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetShares(context.Background(), "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveShareQuery = `
+			INSERT INTO invoice_split_share (split_id, contact_id, share, value, gain_projection_id, invoice_projection_id)
+			VALUES (?, ?, ?, ?, ?, ?)`
+
+func buildSharesMock() []Share {
+	return []Share{
+		{
+			SplitId:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+			ContactId:        "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+			Share:            1,
+			Value:            money.FromCents(1653),
+			GainProjectionId: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a",
+		},
+		{
+			SplitId:             "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+			ContactId:           "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809",
+			Share:               1,
+			Value:               money.FromCents(1652),
+			InvoiceProjectionId: "f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b",
+		},
+	}
+}
+
+func TestSaveSharesSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveShareQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", 1, "16.53", "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectExec(saveShareQuery).
+		WithArgs("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", 1, "16.52", nil, "f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	err = _repository.SaveShares(context.Background(), buildSharesMock())
+	assert.NoError(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveSharesFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveShareQuery).WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectExec(saveShareQuery).WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	err = _repository.SaveShares(context.Background(), buildSharesMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveSharesBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	err = _repository.SaveShares(context.Background(), buildSharesMock())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
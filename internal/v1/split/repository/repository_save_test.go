@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveContactQuery = `
+			INSERT INTO split_contact (id, created_at, user_id, name)
+			VALUES (?, ?, ?, ?)`
+
+const saveSplitQuery = `
+		INSERT INTO invoice_split (id, created_at, user_id, direction, invoice_id, method, owner_share, owner_value, pay_in, description, value, currency)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func buildSplitMock() *Split {
+	return NewSplitBuilder().
+		AddId("7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c").
+		AddCreatedAt(time.Now()).
+		AddUserId("User1").
+		AddDirection(DIRECTION_RECEIVABLE).
+		AddInvoiceId("3f2e1d0c-b9a8-4765-8432-10fedcba9876").
+		AddMethod("shares").
+		AddOwnerShare(1).
+		AddOwnerValue(money.FromCents(1653)).
+		AddPayIn(time.Date(2023, 11, 25, 0, 0, 0, 0, time.UTC)).
+		AddDescription("Pizza").
+		AddValue(money.FromCents(4958)).
+		AddCurrency("BRL").
+		Build()
+}
+
+func buildContactsMock() []Contact {
+	return []Contact{
+		{Id: "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", CreatedAt: time.Now(), UserId: "User1", Name: "Bruno"},
+	}
+}
+
+func TestSaveSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	splitMock := buildSplitMock()
+	contactsMock := buildContactsMock()
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveContactQuery).
+		WithArgs("d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", contactsMock[0].CreatedAt.Unix(), "User1", "Bruno").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectExec(saveSplitQuery).
+		WithArgs(splitMock.Id, splitMock.CreatedAt.Unix(), "User1", "receivable", "3f2e1d0c-b9a8-4765-8432-10fedcba9876", "shares", 1, "16.53", splitMock.PayIn, "Pizza", "49.58", "BRL").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	splitSaved, err := _repository.Save(context.Background(), *splitMock, contactsMock)
+	assert.NoError(t, err)
+	assert.Equal(t, splitMock.Id, splitSaved.Id)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveContactFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveContactQuery).WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	splitSaved, err := _repository.Save(context.Background(), *buildSplitMock(), buildContactsMock())
+	assert.Error(t, err)
+	assert.Empty(t, splitSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSavePayableSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	splitMock := buildSplitMock()
+	splitMock.Direction = DIRECTION_PAYABLE
+	splitMock.InvoiceId = ""
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveSplitQuery).
+		WithArgs(splitMock.Id, splitMock.CreatedAt.Unix(), "User1", "payable", nil, "shares", 1, "16.53", splitMock.PayIn, "Pizza", "49.58", "BRL").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	splitSaved, err := _repository.Save(context.Background(), *splitMock, []Contact{})
+	assert.NoError(t, err)
+	assert.Equal(t, "payable", splitSaved.Direction)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(saveSplitQuery).WillReturnError(errors.New("An error has been ocurred"))
+	sqlMock.ExpectRollback()
+
+	splitSaved, err := _repository.Save(context.Background(), *buildSplitMock(), []Contact{})
+	assert.Error(t, err)
+	assert.Empty(t, splitSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	splitSaved, err := _repository.Save(context.Background(), *buildSplitMock(), buildContactsMock())
+	assert.Error(t, err)
+	assert.Empty(t, splitSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const (
+	DIRECTION_RECEIVABLE = "receivable"
+	DIRECTION_PAYABLE    = "payable"
+)
+
+// Contact is a person with whom the invoices of the user are split, identified by the name
+type Contact struct {
+	Id        string
+	CreatedAt time.Time
+	UserId    string
+	Name      string
+}
+
+// Split is the division of an expense among the user and contacts, the owner share and value are the part of the user.
+// A receivable split divides an invoice paid by the user, whose description, value and currency it keeps, a payable
+// split divides an expense paid by a contact and has no invoice
+type Split struct {
+	Id          string
+	CreatedAt   time.Time
+	UserId      string
+	Direction   string
+	InvoiceId   string
+	Method      string
+	OwnerShare  uint
+	OwnerValue  money.Money
+	PayIn       time.Time
+	Description string
+	Value       money.Money
+	Currency    string
+}
+
+// Share is the part of a contact in the split. On a receivable split it is what the contact owes, received through
+// the gain projection, on a payable split it is what the user owes to the contact who paid, paid through the
+// invoice projection
+type Share struct {
+	SplitId             string
+	ContactId           string
+	ContactName         string
+	Share               uint
+	Value               money.Money
+	GainProjectionId    string
+	InvoiceProjectionId string
+	IsSettled           bool
+	IsCancelled         bool
+}
+
+// Invoice holds the fields of the invoice needed to split it
+type Invoice struct {
+	Id          string
+	PayAt       time.Time
+	Description string
+	Value       money.Money
+	Currency    string
+}
+
+// Balance sums the shares of a contact in a currency, positive when the contact owes the user and negative when the
+// user owes the contact
+type Balance struct {
+	ContactId   string
+	ContactName string
+	Currency    string
+	Total       money.Money
+	Settled     money.Money
+	Pending     money.Money
+}
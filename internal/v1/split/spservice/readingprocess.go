@@ -0,0 +1,51 @@
+package spservice
+
+import (
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split/repository"
+)
+
+type ReadingProcess interface {
+	GetById(searchCtx SearchContext) (*SplitResponse, error)
+	GetBalances(searchCtx SearchContext) (*BalanceListResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+}
+
+func NewReadingProcess(repository repository.Repository) ReadingProcess {
+	return &readingProcess{repository: repository}
+}
+
+// GetById returns the split with the status of each share, which follows its gain projection
+func (rp *readingProcess) GetById(searchCtx SearchContext) (*SplitResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	split, err := rp.repository.GetById(searchCtx.Ctx, searchCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if split == nil {
+		return nil, apperror.NotFound("Split not found")
+	}
+	shares, err := rp.repository.GetShares(searchCtx.Ctx, split.Id)
+	if err != nil {
+		return nil, err
+	}
+	return buildResponse(*split, *shares), nil
+}
+
+// GetBalances returns what each contact owes to the user by currency, the contacts without shares are left out
+func (rp *readingProcess) GetBalances(searchCtx SearchContext) (*BalanceListResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	balances, err := rp.repository.GetBalances(searchCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	records := []BalanceResponse{}
+	for _, balance := range *balances {
+		records = append(records, newBalanceResponse(balance))
+	}
+	return &BalanceListResponse{Records: records}, nil
+}
@@ -0,0 +1,37 @@
+package spservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBalancesSuccess(t *testing.T) {
+	_readingProcess := NewReadingProcess(buildSettleRepositoryMock())
+
+	response, err := _readingProcess.GetBalances(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(response.Records))
+	assert.Equal(t, "Ana", response.Records[0].Name)
+	assert.Equal(t, "Bruno", response.Records[1].Name)
+	assert.Equal(t, "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", response.Records[1].ContactId)
+	assert.Equal(t, "24.79", response.Records[1].Pending.String())
+}
+
+func TestGetBalancesEmpty(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{})
+
+	response, err := _readingProcess.GetBalances(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, &BalanceListResponse{Records: []BalanceResponse{}}, response)
+}
+
+func TestGetBalancesFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")})
+
+	response, err := _readingProcess.GetBalances(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.Nil(t, response)
+	assert.Error(t, err)
+}
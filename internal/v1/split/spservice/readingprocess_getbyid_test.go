@@ -0,0 +1,77 @@
+package spservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildSplitRepositoryMock() *mockRepository {
+	return &mockRepository{
+		splits: []repository.Split{
+			{
+				Id:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+				CreatedAt:   nowMock(),
+				UserId:      "5832a502-bede-492d-8dc1-b13b32c30f29",
+				InvoiceId:   "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+				Method:      METHOD_AMOUNTS,
+				OwnerValue:  money.FromCents(1958),
+				PayIn:       date(2023, 11, 25),
+				Description: "Pizza",
+				Value:       money.FromCents(4958),
+				Currency:    "BRL",
+			},
+		},
+		shares: map[string][]repository.Share{
+			"7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c": {
+				{SplitId: "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", ContactName: "Ana", Value: money.FromCents(1500), GainProjectionId: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", IsSettled: true},
+				{SplitId: "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", ContactId: "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", ContactName: "Bruno", Value: money.FromCents(1000), GainProjectionId: "f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b"},
+				{SplitId: "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", ContactId: "0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e", ContactName: "Carla", Value: money.FromCents(500), GainProjectionId: "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f", IsSettled: true, IsCancelled: true},
+			},
+		},
+	}
+}
+
+func TestGetByIdSuccess(t *testing.T) {
+	_readingProcess := NewReadingProcess(buildSplitRepositoryMock())
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, &SplitResponse{
+		Id:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+		InvoiceId:   "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+		Description: "Pizza",
+		Value:       money.FromCents(4958),
+		Currency:    "BRL",
+		Method:      METHOD_AMOUNTS,
+		OwnerValue:  money.FromCents(1958),
+		PayIn:       date(2023, 11, 25),
+		Shares: []ShareResponse{
+			{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", Name: "Ana", Value: money.FromCents(1500), GainProjectionId: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", Status: SHARE_STATUS_SETTLED},
+			{ContactId: "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", Name: "Bruno", Value: money.FromCents(1000), GainProjectionId: "f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b", Status: SHARE_STATUS_PENDING},
+			{ContactId: "0b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e", Name: "Carla", Value: money.FromCents(500), GainProjectionId: "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f", Status: SHARE_STATUS_CANCELLED},
+		},
+		CreatedAt: nowMock(),
+	}, response)
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	_readingProcess := NewReadingProcess(buildSplitRepositoryMock())
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a", UserToken: tokenMock})
+	assert.Nil(t, response)
+	assert.Equal(t, apperror.NotFound("Split not found"), err)
+}
+
+func TestGetByIdFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")})
+
+	response, err := _readingProcess.GetById(SearchContext{Ctx: context.TODO(), Id: "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", UserToken: tokenMock})
+	assert.Nil(t, response)
+	assert.Error(t, err)
+}
@@ -0,0 +1,460 @@
+package spservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/dbtx"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/gpservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/ipservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MAX_DESCRIPTION_LENGTH is the length of the description of the projections
+const MAX_DESCRIPTION_LENGTH = 255
+
+type StorageProcess interface {
+	Create(createCtx CreateContext) (*SplitResponse, error)
+	CreateDebt(createDebtCtx CreateDebtContext) (*SplitResponse, error)
+	Settle(settleCtx SettleContext) (*SettleResponse, error)
+}
+
+type storageProcess struct {
+	repository               repository.Repository
+	generateUUID             func() uuid.UUID
+	gainProjectionProcess    gpservice.StorageProcess
+	invoiceProjectionProcess ipservice.StorageProcess
+	now                      func() time.Time
+	transactor               dbtx.Transactor
+}
+
+func NewStorageProcess(
+	repository repository.Repository,
+	generateUUID func() uuid.UUID,
+	gainProjectionProcess gpservice.StorageProcess,
+	invoiceProjectionProcess ipservice.StorageProcess,
+	now func() time.Time,
+	transactor dbtx.Transactor) StorageProcess {
+	return &storageProcess{
+		repository:               repository,
+		generateUUID:             generateUUID,
+		gainProjectionProcess:    gainProjectionProcess,
+		invoiceProjectionProcess: invoiceProjectionProcess,
+		now:                      now,
+		transactor:               transactor,
+	}
+}
+
+// Create splits the invoice among the participants, each share becomes a gain projection of the category of the request.
+// An invoice can only be split once, the split, the contacts and the projections are saved in the same transaction
+func (sp *storageProcess) Create(createCtx CreateContext) (*SplitResponse, error) {
+	request := createCtx.Request
+	user := idpauth.GetUser(createCtx.UserToken)
+	for index := range request.Participants {
+		request.Participants[index].Name = strings.TrimSpace(request.Participants[index].Name)
+	}
+	err := validateParticipants(request)
+	if err != nil {
+		return nil, err
+	}
+	invoice, err := sp.repository.GetInvoice(createCtx.Ctx, request.InvoiceId, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, validation.Errors{validation.NotFound("invoice_id")}
+	}
+	ownerShare, ownerValue, values, err := getValues(request, invoice.Value)
+	if err != nil {
+		return nil, err
+	}
+	payIn := invoice.PayAt
+	if request.PayIn != nil {
+		payIn = *request.PayIn
+	}
+	categoryId := DEFAULT_CATEGORY_ID
+	if request.CategoryId != nil {
+		categoryId = *request.CategoryId
+	}
+	now := sp.now()
+	split := repository.NewSplitBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(now).
+		AddUserId(user.Id).
+		AddDirection(repository.DIRECTION_RECEIVABLE).
+		AddInvoiceId(invoice.Id).
+		AddMethod(request.Method).
+		AddOwnerShare(ownerShare).
+		AddOwnerValue(ownerValue).
+		AddPayIn(payIn).
+		AddDescription(invoice.Description).
+		AddValue(invoice.Value).
+		AddCurrency(invoice.Currency).
+		Build()
+
+	contactsByName, err := sp.getContactsByName(createCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	newContacts := []repository.Contact{}
+	shares := []repository.Share{}
+	for index, participant := range request.Participants {
+		contact, found := contactsByName[strings.ToLower(participant.Name)]
+		if !found {
+			contact = repository.Contact{Id: sp.generateUUID().String(), CreatedAt: now, UserId: user.Id, Name: participant.Name}
+			newContacts = append(newContacts, contact)
+		}
+		share := repository.Share{SplitId: split.Id, ContactId: contact.Id, ContactName: contact.Name, Value: values[index]}
+		if request.Method == METHOD_SHARES {
+			share.Share = participant.Share
+		}
+		shares = append(shares, share)
+	}
+	var splitSaved *repository.Split
+	err = sp.transactor.Within(createCtx.Ctx, func(ctx context.Context) error {
+		// the invoice is checked in the same transaction that saves its split
+		splitFound, err := sp.repository.GetByInvoiceId(ctx, invoice.Id, user.Id)
+		if err != nil {
+			return err
+		}
+		if splitFound != nil {
+			return apperror.Conflict("The invoice is already split")
+		}
+		splitSaved, err = sp.repository.Save(ctx, *split, newContacts)
+		if err != nil {
+			return err
+		}
+		for index := range shares {
+			projection, err := sp.gainProjectionProcess.Create(gpservice.CreateContext{
+				Ctx: ctx,
+				Request: gpservice.CreateRequest{
+					PayIn:       split.PayIn,
+					Description: getShareDescription(split.Description, shares[index].ContactName),
+					Value:       shares[index].Value,
+					Currency:    split.Currency,
+					CategoryId:  categoryId,
+				},
+				UserToken: createCtx.UserToken,
+			})
+			if err != nil {
+				return err
+			}
+			shares[index].GainProjectionId = projection.Id
+		}
+		return sp.repository.SaveShares(ctx, shares)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buildResponse(*splitSaved, shares), nil
+}
+
+// CreateDebt splits an expense paid by a contact, the part of the user becomes an invoice projection to be paid back
+// to the contact on the pay_in. The split, the contact and the projection are saved in the same transaction
+func (sp *storageProcess) CreateDebt(createDebtCtx CreateDebtContext) (*SplitResponse, error) {
+	request := createDebtCtx.Request
+	user := idpauth.GetUser(createDebtCtx.UserToken)
+	request.Contact = strings.TrimSpace(request.Contact)
+	ownerShare, ownerValue, err := getDebtValue(request)
+	if err != nil {
+		return nil, err
+	}
+	payIn := request.PaidAt
+	if request.PayIn != nil {
+		payIn = *request.PayIn
+	}
+	now := sp.now()
+	split := repository.NewSplitBuilder().
+		AddId(sp.generateUUID().String()).
+		AddCreatedAt(now).
+		AddUserId(user.Id).
+		AddDirection(repository.DIRECTION_PAYABLE).
+		AddMethod(request.Method).
+		AddOwnerShare(ownerShare).
+		AddOwnerValue(ownerValue).
+		AddPayIn(payIn).
+		AddDescription(request.Description).
+		AddValue(request.Value).
+		AddCurrency(money.NormalizeCurrency(request.Currency)).
+		Build()
+
+	contactsByName, err := sp.getContactsByName(createDebtCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	newContacts := []repository.Contact{}
+	contact, found := contactsByName[strings.ToLower(request.Contact)]
+	if !found {
+		contact = repository.Contact{Id: sp.generateUUID().String(), CreatedAt: now, UserId: user.Id, Name: request.Contact}
+		newContacts = append(newContacts, contact)
+	}
+	share := repository.Share{SplitId: split.Id, ContactId: contact.Id, ContactName: contact.Name, Value: ownerValue}
+	if request.Method == METHOD_SHARES {
+		share.Share = request.Share
+	}
+	var splitSaved *repository.Split
+	err = sp.transactor.Within(createDebtCtx.Ctx, func(ctx context.Context) error {
+		splitSaved, err = sp.repository.Save(ctx, *split, newContacts)
+		if err != nil {
+			return err
+		}
+		projection, err := sp.invoiceProjectionProcess.Create(ipservice.CreateContext{
+			Ctx: ctx,
+			Request: ipservice.CreateRequest{
+				PayIn:         split.PayIn,
+				BuyAt:         request.PaidAt,
+				Description:   getShareDescription(split.Description, contact.Name),
+				Value:         share.Value,
+				Currency:      split.Currency,
+				CategoryId:    request.CategoryId,
+				PaymentTypeId: request.PaymentTypeId,
+			},
+			UserToken: createDebtCtx.UserToken,
+		})
+		if err != nil {
+			return err
+		}
+		share.InvoiceProjectionId = projection.Id
+		return sp.repository.SaveShares(ctx, []repository.Share{share})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buildResponse(*splitSaved, []repository.Share{share}), nil
+}
+
+// Settle realizes the pending projections of the contact in the same transaction, registering the gains received from
+// it and the invoices paid to it
+func (sp *storageProcess) Settle(settleCtx SettleContext) (*SettleResponse, error) {
+	user := idpauth.GetUser(settleCtx.UserToken)
+	contact, err := sp.repository.GetContactById(settleCtx.Ctx, settleCtx.Id, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if contact == nil {
+		return nil, apperror.NotFound("Contact not found")
+	}
+	payIn := getToday(sp.now())
+	if settleCtx.Request.PayIn != nil {
+		payIn = *settleCtx.Request.PayIn
+	}
+	shares, err := sp.repository.GetPendingShares(settleCtx.Ctx, contact.Id)
+	if err != nil {
+		return nil, err
+	}
+	response := &SettleResponse{ContactId: contact.Id, Name: contact.Name, Balances: []BalanceResponse{}}
+	err = sp.transactor.Within(settleCtx.Ctx, func(ctx context.Context) error {
+		for _, share := range *shares {
+			if share.InvoiceProjectionId != "" {
+				invoiceStat, err := sp.invoiceProjectionProcess.CreateInvoice(ipservice.CreateInvoiceContext{
+					Ctx:       ctx,
+					Request:   ipservice.CreateInvoiceRequest{PayIn: payIn},
+					UserToken: settleCtx.UserToken,
+					Id:        share.InvoiceProjectionId,
+				})
+				if err != nil {
+					return err
+				}
+				if invoiceStat.Invoice != nil {
+					response.SettledShares++
+				}
+				continue
+			}
+			gainStat, err := sp.gainProjectionProcess.CreateGain(gpservice.CreateGainContext{
+				Ctx:       ctx,
+				Request:   gpservice.CreateGainRequest{PayIn: payIn},
+				UserToken: settleCtx.UserToken,
+				Id:        share.GainProjectionId,
+			})
+			if err != nil {
+				return err
+			}
+			if gainStat.Gain != nil {
+				response.SettledShares++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	balances, err := sp.repository.GetBalances(settleCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, balance := range *balances {
+		if balance.ContactId == contact.Id {
+			response.Balances = append(response.Balances, newBalanceResponse(balance))
+		}
+	}
+	return response, nil
+}
+
+// getContactsByName indexes the contacts of the user by the name, which is unique regardless of the case
+func (sp *storageProcess) getContactsByName(ctx context.Context, userId string) (map[string]repository.Contact, error) {
+	contacts, err := sp.repository.GetContacts(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	contactsByName := map[string]repository.Contact{}
+	for _, contact := range *contacts {
+		contactsByName[strings.ToLower(contact.Name)] = contact
+	}
+	return contactsByName, nil
+}
+
+// validateParticipants checks each participant against the method, which the binding of the request can not tell
+func validateParticipants(request CreateRequest) error {
+	errs := validation.Errors{}
+	names := map[string]bool{}
+	for index, participant := range request.Participants {
+		field := fmt.Sprintf("participants[%d]", index)
+		if participant.Name == "" {
+			errs = append(errs, validation.Required(field+".name"))
+		} else if utf8.RuneCountInString(participant.Name) > MAX_DESCRIPTION_LENGTH {
+			errs = append(errs, validation.FieldError{Field: field + ".name", Code: validation.CODE_TOO_LARGE, Message: fmt.Sprintf("The %s.name must have at most %d characters", field, MAX_DESCRIPTION_LENGTH)})
+		} else if names[strings.ToLower(participant.Name)] {
+			errs = append(errs, validation.FieldError{Field: field + ".name", Code: validation.CODE_INVALID, Message: fmt.Sprintf("The %s.name is repeated", field)})
+		}
+		names[strings.ToLower(participant.Name)] = true
+		if request.Method == METHOD_SHARES && participant.Share == 0 {
+			errs = append(errs, validation.Required(field+".share"))
+		}
+		if request.Method == METHOD_AMOUNTS && participant.Value <= 0 {
+			errs = append(errs, validation.FieldError{Field: field + ".value", Code: validation.CODE_TOO_SMALL, Message: fmt.Sprintf("The %s.value must be greater than 0", field)})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// getValues divides the invoice value, returning the share and the value of the user and the values of the participants
+func getValues(request CreateRequest, invoiceValue money.Money) (uint, money.Money, []money.Money, error) {
+	if request.Method == METHOD_AMOUNTS {
+		values := []money.Money{}
+		for _, participant := range request.Participants {
+			values = append(values, participant.Value)
+		}
+		total := money.Sum(values...)
+		if total > invoiceValue {
+			return 0, 0, nil, validation.Errors{validation.FieldError{Field: "participants", Code: validation.CODE_INVALID, Message: "The sum of the participants values must not exceed the invoice value"}}
+		}
+		return 0, invoiceValue.Sub(total), values, nil
+	}
+	ownerShare := DEFAULT_OWNER_SHARE
+	if request.OwnerShare != nil {
+		ownerShare = *request.OwnerShare
+	}
+	weights := []uint{ownerShare}
+	for _, participant := range request.Participants {
+		weights = append(weights, participant.Share)
+	}
+	values := invoiceValue.Allocate(weights...)
+	return ownerShare, values[0], values[1:], nil
+}
+
+// getDebtValue returns the share and the value of the user in the expense paid by the contact
+func getDebtValue(request DebtRequest) (uint, money.Money, error) {
+	if request.Method == METHOD_AMOUNTS {
+		if request.OwnerValue <= 0 {
+			return 0, 0, validation.Errors{validation.FieldError{Field: "owner_value", Code: validation.CODE_TOO_SMALL, Message: "The owner_value must be greater than 0"}}
+		}
+		if request.OwnerValue > request.Value {
+			return 0, 0, validation.Errors{validation.FieldError{Field: "owner_value", Code: validation.CODE_INVALID, Message: "The owner_value must not exceed the value"}}
+		}
+		return 0, request.OwnerValue, nil
+	}
+	if request.Share == 0 {
+		return 0, 0, validation.Errors{validation.Required("share")}
+	}
+	ownerShare := DEFAULT_OWNER_SHARE
+	if request.OwnerShare != nil {
+		ownerShare = *request.OwnerShare
+	}
+	if ownerShare == 0 {
+		return 0, 0, validation.Errors{validation.Required("owner_share")}
+	}
+	values := request.Value.Allocate(ownerShare, request.Share)
+	return ownerShare, values[0], nil
+}
+
+// getShareDescription names the projection after the invoice and the contact, cutting the invoice description to fit
+func getShareDescription(description string, name string) string {
+	suffix := fmt.Sprintf(" (%s)", name)
+	available := MAX_DESCRIPTION_LENGTH - utf8.RuneCountInString(suffix)
+	if available < 0 {
+		available = 0
+	}
+	runes := []rune(description)
+	if len(runes) > available {
+		runes = runes[:available]
+	}
+	result := string(runes) + suffix
+	if utf8.RuneCountInString(result) > MAX_DESCRIPTION_LENGTH {
+		return string([]rune(result)[:MAX_DESCRIPTION_LENGTH])
+	}
+	return result
+}
+
+func getToday(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func buildResponse(split repository.Split, shares []repository.Share) *SplitResponse {
+	response := &SplitResponse{
+		Id:          split.Id,
+		Direction:   split.Direction,
+		InvoiceId:   split.InvoiceId,
+		Description: split.Description,
+		Value:       split.Value,
+		Currency:    split.Currency,
+		Method:      split.Method,
+		OwnerValue:  split.OwnerValue,
+		PayIn:       split.PayIn,
+		Shares:      []ShareResponse{},
+		CreatedAt:   split.CreatedAt,
+	}
+	if split.Method == METHOD_SHARES {
+		ownerShare := split.OwnerShare
+		response.OwnerShare = &ownerShare
+	}
+	for _, share := range shares {
+		status := SHARE_STATUS_PENDING
+		if share.IsCancelled {
+			status = SHARE_STATUS_CANCELLED
+		} else if share.IsSettled {
+			status = SHARE_STATUS_SETTLED
+		}
+		response.Shares = append(response.Shares, ShareResponse{
+			ContactId:           share.ContactId,
+			Name:                share.ContactName,
+			Share:               share.Share,
+			Value:               share.Value,
+			GainProjectionId:    share.GainProjectionId,
+			InvoiceProjectionId: share.InvoiceProjectionId,
+			Status:              status,
+		})
+	}
+	return response
+}
+
+func newBalanceResponse(balance repository.Balance) BalanceResponse {
+	return BalanceResponse{
+		ContactId: balance.ContactId,
+		Name:      balance.ContactName,
+		Currency:  balance.Currency,
+		Total:     balance.Total,
+		Settled:   balance.Settled,
+		Pending:   balance.Pending,
+	}
+}
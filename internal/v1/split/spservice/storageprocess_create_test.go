@@ -0,0 +1,484 @@
+package spservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/gpservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	invoices      []repository.Invoice
+	splits        []repository.Split
+	shares        map[string][]repository.Share
+	contacts      []repository.Contact
+	balances      []repository.Balance
+	splitSaved    *repository.Split
+	contactsSaved []repository.Contact
+	sharesSaved   []repository.Share
+	err           error
+}
+
+func (m *mockRepository) Save(ctx context.Context, split repository.Split, contacts []repository.Contact) (*repository.Split, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.splitSaved = &split
+	m.contactsSaved = contacts
+	return &split, nil
+}
+
+func (m *mockRepository) SaveShares(ctx context.Context, shares []repository.Share) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sharesSaved = shares
+	return nil
+}
+
+func (m *mockRepository) GetById(ctx context.Context, id string, userId string) (*repository.Split, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, split := range m.splits {
+		if split.Id == id && split.UserId == userId {
+			splitFound := split
+			return &splitFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) GetByInvoiceId(ctx context.Context, invoiceId string, userId string) (*repository.Split, error) {
+	for _, split := range m.splits {
+		if split.InvoiceId == invoiceId && split.UserId == userId {
+			splitFound := split
+			return &splitFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) GetShares(ctx context.Context, splitId string) (*[]repository.Share, error) {
+	shares := m.shares[splitId]
+	return &shares, nil
+}
+
+func (m *mockRepository) GetPendingShares(ctx context.Context, contactId string) (*[]repository.Share, error) {
+	shares := []repository.Share{}
+	for _, splitShares := range m.shares {
+		for _, share := range splitShares {
+			if share.ContactId == contactId && !share.IsSettled && !share.IsCancelled {
+				shares = append(shares, share)
+			}
+		}
+	}
+	return &shares, nil
+}
+
+func (m *mockRepository) GetInvoice(ctx context.Context, id string, userId string) (*repository.Invoice, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, invoice := range m.invoices {
+		if invoice.Id == id {
+			invoiceFound := invoice
+			return &invoiceFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) GetContacts(ctx context.Context, userId string) (*[]repository.Contact, error) {
+	return &m.contacts, nil
+}
+
+func (m *mockRepository) GetContactById(ctx context.Context, id string, userId string) (*repository.Contact, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, contact := range m.contacts {
+		if contact.Id == id && contact.UserId == userId {
+			contactFound := contact
+			return &contactFound, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepository) GetBalances(ctx context.Context, userId string) (*[]repository.Balance, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &m.balances, nil
+}
+
+// mockTransactor tells whether the function ran in the transaction failed, when it would be rolled back
+type mockTransactor struct {
+	rolledBack bool
+}
+
+func (t *mockTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	t.rolledBack = err != nil
+	return err
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func nowMock() time.Time {
+	return time.Date(2023, 11, 26, 10, 0, 0, 0, time.UTC)
+}
+
+var uuidsMock = []string{
+	"7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+	"e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a",
+	"d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809",
+	"f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b",
+}
+
+// uuidSequenceMock generates the uuids of the list in order
+func uuidSequenceMock() func() uuid.UUID {
+	index := 0
+	return func() uuid.UUID {
+		id := uuid.FromStringOrNil(uuidsMock[index%len(uuidsMock)])
+		index++
+		return id
+	}
+}
+
+func buildInvoicesMock() []repository.Invoice {
+	return []repository.Invoice{
+		{Id: "3f2e1d0c-b9a8-4765-8432-10fedcba9876", PayAt: date(2023, 11, 25), Description: "Pizza", Value: money.FromCents(4958), Currency: "BRL"},
+	}
+}
+
+func buildContactsMock() []repository.Contact {
+	return []repository.Contact{
+		{Id: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", CreatedAt: date(2023, 10, 1), UserId: "5832a502-bede-492d-8dc1-b13b32c30f29", Name: "Ana"},
+	}
+}
+
+func TestCreateByShares(t *testing.T) {
+	_mockRepository := &mockRepository{invoices: buildInvoicesMock(), contacts: buildContactsMock()}
+	_mockProjectionProcess := &mockGainProjectionProcess{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), _mockProjectionProcess, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId: "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:    METHOD_SHARES,
+			Participants: []ParticipantRequest{
+				{Name: " ana ", Share: 1},
+				{Name: "Bruno", Share: 2},
+			},
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &repository.Split{
+		Id:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+		CreatedAt:   nowMock(),
+		UserId:      "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Direction:   repository.DIRECTION_RECEIVABLE,
+		InvoiceId:   "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+		Method:      METHOD_SHARES,
+		OwnerShare:  1,
+		OwnerValue:  money.FromCents(1240),
+		PayIn:       date(2023, 11, 25),
+		Description: "Pizza",
+		Value:       money.FromCents(4958),
+		Currency:    "BRL",
+	}, _mockRepository.splitSaved)
+	assert.Equal(t, []repository.Contact{
+		{Id: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", CreatedAt: nowMock(), UserId: "5832a502-bede-492d-8dc1-b13b32c30f29", Name: "Bruno"},
+	}, _mockRepository.contactsSaved)
+	assert.Equal(t, []gpservice.CreateRequest{
+		{PayIn: date(2023, 11, 25), Description: "Pizza (Ana)", Value: money.FromCents(1239), Currency: "BRL", CategoryId: DEFAULT_CATEGORY_ID},
+		{PayIn: date(2023, 11, 25), Description: "Pizza (Bruno)", Value: money.FromCents(2479), Currency: "BRL", CategoryId: DEFAULT_CATEGORY_ID},
+	}, _mockProjectionProcess.requests)
+	assert.Equal(t, []repository.Share{
+		{
+			SplitId:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+			ContactId:        "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+			ContactName:      "Ana",
+			Share:            1,
+			Value:            money.FromCents(1239),
+			GainProjectionId: "gain-projection-1",
+		},
+		{
+			SplitId:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+			ContactId:        "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a",
+			ContactName:      "Bruno",
+			Share:            2,
+			Value:            money.FromCents(2479),
+			GainProjectionId: "gain-projection-2",
+		},
+	}, _mockRepository.sharesSaved)
+
+	ownerShare := uint(1)
+	assert.Equal(t, &SplitResponse{
+		Id:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+		Direction:   repository.DIRECTION_RECEIVABLE,
+		InvoiceId:   "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+		Description: "Pizza",
+		Value:       money.FromCents(4958),
+		Currency:    "BRL",
+		Method:      METHOD_SHARES,
+		OwnerShare:  &ownerShare,
+		OwnerValue:  money.FromCents(1240),
+		PayIn:       date(2023, 11, 25),
+		Shares: []ShareResponse{
+			{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", Name: "Ana", Share: 1, Value: money.FromCents(1239), GainProjectionId: "gain-projection-1", Status: SHARE_STATUS_PENDING},
+			{ContactId: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", Name: "Bruno", Share: 2, Value: money.FromCents(2479), GainProjectionId: "gain-projection-2", Status: SHARE_STATUS_PENDING},
+		},
+		CreatedAt: nowMock(),
+	}, response)
+}
+
+func TestCreateOnCategory(t *testing.T) {
+	_mockRepository := &mockRepository{invoices: buildInvoicesMock(), contacts: buildContactsMock()}
+	_mockProjectionProcess := &mockGainProjectionProcess{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), _mockProjectionProcess, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	categoryId := uint(3)
+	_, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId:    "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:       METHOD_SHARES,
+			CategoryId:   &categoryId,
+			Participants: []ParticipantRequest{{Name: "Ana", Share: 1}},
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), _mockProjectionProcess.requests[0].CategoryId)
+}
+
+func TestCreateProjectionFail(t *testing.T) {
+	_mockRepository := &mockRepository{invoices: buildInvoicesMock(), contacts: buildContactsMock()}
+	_mockTransactor := &mockTransactor{}
+	_storageProcess := NewStorageProcess(
+		_mockRepository,
+		uuidSequenceMock(),
+		&mockGainProjectionProcess{err: validation.Errors{validation.NotFound("category_id")}},
+		&mockInvoiceProjectionProcess{},
+		nowMock,
+		_mockTransactor)
+
+	categoryId := uint(99)
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId:    "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:       METHOD_SHARES,
+			CategoryId:   &categoryId,
+			Participants: []ParticipantRequest{{Name: "Ana", Share: 1}},
+		},
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Equal(t, validation.Errors{validation.NotFound("category_id")}, err)
+	assert.True(t, _mockTransactor.rolledBack)
+	assert.Nil(t, _mockRepository.sharesSaved)
+}
+
+func TestCreateBySharesWithoutOwner(t *testing.T) {
+	_mockRepository := &mockRepository{invoices: buildInvoicesMock(), contacts: buildContactsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	ownerShare := uint(0)
+	payIn := date(2023, 12, 5)
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId:  "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:     METHOD_SHARES,
+			OwnerShare: &ownerShare,
+			PayIn:      &payIn,
+			Participants: []ParticipantRequest{
+				{Name: "Ana", Share: 1},
+				{Name: "Bruno", Share: 1},
+			},
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromCents(0), response.OwnerValue)
+	assert.Equal(t, uint(0), *response.OwnerShare)
+	assert.Equal(t, payIn, response.PayIn)
+	assert.Equal(t, money.FromCents(2479), response.Shares[0].Value)
+	assert.Equal(t, money.FromCents(2479), response.Shares[1].Value)
+}
+
+func TestCreateByAmounts(t *testing.T) {
+	_mockRepository := &mockRepository{invoices: buildInvoicesMock(), contacts: buildContactsMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId: "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:    METHOD_AMOUNTS,
+			Participants: []ParticipantRequest{
+				{Name: "Ana", Share: 3, Value: money.FromCents(2000)},
+				{Name: "Bruno", Value: money.FromCents(1000)},
+			},
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), _mockRepository.splitSaved.OwnerShare)
+	assert.Nil(t, response.OwnerShare)
+	assert.Equal(t, money.FromCents(1958), response.OwnerValue)
+	assert.Equal(t, []ShareResponse{
+		{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", Name: "Ana", Value: money.FromCents(2000), GainProjectionId: "gain-projection-1", Status: SHARE_STATUS_PENDING},
+		{ContactId: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", Name: "Bruno", Value: money.FromCents(1000), GainProjectionId: "gain-projection-2", Status: SHARE_STATUS_PENDING},
+	}, response.Shares)
+}
+
+func TestCreateAmountsExceedInvoice(t *testing.T) {
+	_mockRepository := &mockRepository{invoices: buildInvoicesMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId: "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:    METHOD_AMOUNTS,
+			Participants: []ParticipantRequest{
+				{Name: "Ana", Value: money.FromCents(4000)},
+				{Name: "Bruno", Value: money.FromCents(1000)},
+			},
+		},
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Equal(t, validation.Errors{
+		{Field: "participants", Code: validation.CODE_INVALID, Message: "The sum of the participants values must not exceed the invoice value"},
+	}, err)
+	assert.Nil(t, _mockRepository.splitSaved)
+}
+
+func TestCreateInvalidParticipants(t *testing.T) {
+	_mockRepository := &mockRepository{invoices: buildInvoicesMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId: "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:    METHOD_SHARES,
+			Participants: []ParticipantRequest{
+				{Name: " "},
+				{Name: "Ana", Share: 1},
+				{Name: "ANA", Share: 1},
+			},
+		},
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Equal(t, validation.Errors{
+		{Field: "participants[0].name", Code: validation.CODE_REQUIRED, Message: "The participants[0].name must be informed"},
+		{Field: "participants[0].share", Code: validation.CODE_REQUIRED, Message: "The participants[0].share must be informed"},
+		{Field: "participants[2].name", Code: validation.CODE_INVALID, Message: "The participants[2].name is repeated"},
+	}, err)
+}
+
+func TestCreateInvalidAmounts(t *testing.T) {
+	_mockRepository := &mockRepository{invoices: buildInvoicesMock()}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	_, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId:    "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:       METHOD_AMOUNTS,
+			Participants: []ParticipantRequest{{Name: "Ana", Share: 1}},
+		},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, validation.Errors{
+		{Field: "participants[0].value", Code: validation.CODE_TOO_SMALL, Message: "The participants[0].value must be greater than 0"},
+	}, err)
+}
+
+func TestCreateInvoiceNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId:    "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:       METHOD_SHARES,
+			Participants: []ParticipantRequest{{Name: "Ana", Share: 1}},
+		},
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Equal(t, validation.Errors{validation.NotFound("invoice_id")}, err)
+}
+
+func TestCreateInvoiceAlreadySplit(t *testing.T) {
+	_mockRepository := &mockRepository{
+		invoices: buildInvoicesMock(),
+		splits: []repository.Split{
+			{Id: "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", UserId: "5832a502-bede-492d-8dc1-b13b32c30f29", InvoiceId: "3f2e1d0c-b9a8-4765-8432-10fedcba9876"},
+		},
+	}
+	_mockTransactor := &mockTransactor{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, &mockInvoiceProjectionProcess{}, nowMock, _mockTransactor)
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId:    "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:       METHOD_SHARES,
+			Participants: []ParticipantRequest{{Name: "Ana", Share: 1}},
+		},
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Equal(t, apperror.Conflict("The invoice is already split"), err)
+	assert.Nil(t, _mockRepository.splitSaved)
+	assert.True(t, _mockTransactor.rolledBack)
+}
+
+func TestCreateFail(t *testing.T) {
+	_mockRepository := &mockRepository{invoices: buildInvoicesMock(), err: errors.New("An error has been ocurred")}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Create(CreateContext{
+		Ctx: context.TODO(),
+		Request: CreateRequest{
+			InvoiceId:    "3f2e1d0c-b9a8-4765-8432-10fedcba9876",
+			Method:       METHOD_SHARES,
+			Participants: []ParticipantRequest{{Name: "Ana", Share: 1}},
+		},
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Error(t, err)
+}
+
+func TestGetShareDescription(t *testing.T) {
+	assert.Equal(t, "Pizza (Ana)", getShareDescription("Pizza", "Ana"))
+	longDescription := getShareDescription(string(make([]rune, 300)), "Ana")
+	assert.Equal(t, MAX_DESCRIPTION_LENGTH, len([]rune(longDescription)))
+	assert.Equal(t, " (Ana)", string([]rune(longDescription)[249:]))
+}
@@ -0,0 +1,233 @@
+package spservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/ipservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockInvoiceProjectionProcess struct {
+	requests []ipservice.CreateRequest
+	contexts []ipservice.CreateInvoiceContext
+	err      error
+}
+
+func (m *mockInvoiceProjectionProcess) Create(createCtx ipservice.CreateContext) (*ipservice.InvoiceProjectionResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.requests = append(m.requests, createCtx.Request)
+	return &ipservice.InvoiceProjectionResponse{Id: fmt.Sprintf("invoice-projection-%d", len(m.requests))}, nil
+}
+
+func (m *mockInvoiceProjectionProcess) CreateFromBoleto(createFromBoletoCtx ipservice.CreateFromBoletoContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockInvoiceProjectionProcess) Update(updateCtx ipservice.UpdateContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockInvoiceProjectionProcess) Patch(patchCtx ipservice.PatchContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockInvoiceProjectionProcess) Delete(deleteCtx ipservice.DeleteContext) error {
+	return nil
+}
+
+func (m *mockInvoiceProjectionProcess) Restore(searchCtx ipservice.SearchContext) (*ipservice.InvoiceProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockInvoiceProjectionProcess) CreateInvoice(createInvoiceCtx ipservice.CreateInvoiceContext) (*ipservice.InvoiceStat, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.contexts = append(m.contexts, createInvoiceCtx)
+	return &ipservice.InvoiceStat{ProjectionIsFound: true, Invoice: &ipservice.InvoiceResponse{}}, nil
+}
+
+func (m *mockInvoiceProjectionProcess) CreateInvoiceBatch(createInvoiceBatchCtx ipservice.CreateInvoiceBatchContext) (*ipservice.InvoiceBatchResponse, error) {
+	return nil, nil
+}
+
+func (m *mockInvoiceProjectionProcess) RevertInvoice(revertInvoiceCtx ipservice.RevertInvoiceContext) (*ipservice.RevertInvoiceStat, error) {
+	return nil, nil
+}
+
+func TestCreateDebtByShares(t *testing.T) {
+	_mockRepository := &mockRepository{contacts: buildContactsMock()}
+	_mockProjectionProcess := &mockInvoiceProjectionProcess{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, _mockProjectionProcess, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.CreateDebt(CreateDebtContext{
+		Ctx: context.TODO(),
+		Request: DebtRequest{
+			Contact:       " ANA ",
+			Description:   "Hotel",
+			Value:         money.FromCents(9000),
+			PaidAt:        date(2023, 11, 20),
+			Method:        METHOD_SHARES,
+			Share:         2,
+			CategoryId:    4,
+			PaymentTypeId: 2,
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &repository.Split{
+		Id:          "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+		CreatedAt:   nowMock(),
+		UserId:      "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Direction:   repository.DIRECTION_PAYABLE,
+		Method:      METHOD_SHARES,
+		OwnerShare:  1,
+		OwnerValue:  money.FromCents(3000),
+		PayIn:       date(2023, 11, 20),
+		Description: "Hotel",
+		Value:       money.FromCents(9000),
+		Currency:    "BRL",
+	}, _mockRepository.splitSaved)
+	assert.Empty(t, _mockRepository.contactsSaved)
+	assert.Equal(t, []ipservice.CreateRequest{
+		{
+			PayIn:         date(2023, 11, 20),
+			BuyAt:         date(2023, 11, 20),
+			Description:   "Hotel (Ana)",
+			Value:         money.FromCents(3000),
+			Currency:      "BRL",
+			CategoryId:    4,
+			PaymentTypeId: 2,
+		},
+	}, _mockProjectionProcess.requests)
+	assert.Equal(t, []repository.Share{
+		{
+			SplitId:             "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c",
+			ContactId:           "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+			ContactName:         "Ana",
+			Share:               2,
+			Value:               money.FromCents(3000),
+			InvoiceProjectionId: "invoice-projection-1",
+		},
+	}, _mockRepository.sharesSaved)
+	assert.Equal(t, repository.DIRECTION_PAYABLE, response.Direction)
+	assert.Empty(t, response.InvoiceId)
+	assert.Equal(t, []ShareResponse{
+		{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", Name: "Ana", Share: 2, Value: money.FromCents(3000), InvoiceProjectionId: "invoice-projection-1", Status: SHARE_STATUS_PENDING},
+	}, response.Shares)
+}
+
+func TestCreateDebtByAmounts(t *testing.T) {
+	_mockRepository := &mockRepository{contacts: buildContactsMock()}
+	_mockProjectionProcess := &mockInvoiceProjectionProcess{}
+	_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, _mockProjectionProcess, nowMock, &mockTransactor{})
+
+	payIn := date(2023, 12, 5)
+	response, err := _storageProcess.CreateDebt(CreateDebtContext{
+		Ctx: context.TODO(),
+		Request: DebtRequest{
+			Contact:       "Bruno",
+			Description:   "Hotel",
+			Value:         money.FromCents(9000),
+			Currency:      "usd",
+			PaidAt:        date(2023, 11, 20),
+			Method:        METHOD_AMOUNTS,
+			OwnerValue:    money.FromCents(2500),
+			PayIn:         &payIn,
+			CategoryId:    4,
+			PaymentTypeId: 2,
+		},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []repository.Contact{
+		{Id: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", CreatedAt: nowMock(), UserId: "5832a502-bede-492d-8dc1-b13b32c30f29", Name: "Bruno"},
+	}, _mockRepository.contactsSaved)
+	assert.Equal(t, "USD", _mockProjectionProcess.requests[0].Currency)
+	assert.Equal(t, payIn, _mockProjectionProcess.requests[0].PayIn)
+	assert.Nil(t, response.OwnerShare)
+	assert.Equal(t, money.FromCents(2500), response.OwnerValue)
+	assert.Equal(t, money.FromCents(2500), response.Shares[0].Value)
+	assert.Equal(t, uint(0), response.Shares[0].Share)
+}
+
+func TestCreateDebtInvalidValues(t *testing.T) {
+	ownerShare := uint(0)
+	tests := []struct {
+		name    string
+		request DebtRequest
+		err     error
+	}{
+		{
+			name:    "share not informed",
+			request: DebtRequest{Method: METHOD_SHARES, Value: money.FromCents(9000)},
+			err:     validation.Errors{validation.Required("share")},
+		},
+		{
+			name:    "owner share zero",
+			request: DebtRequest{Method: METHOD_SHARES, Value: money.FromCents(9000), Share: 1, OwnerShare: &ownerShare},
+			err:     validation.Errors{validation.Required("owner_share")},
+		},
+		{
+			name:    "owner value not informed",
+			request: DebtRequest{Method: METHOD_AMOUNTS, Value: money.FromCents(9000)},
+			err:     validation.Errors{{Field: "owner_value", Code: validation.CODE_TOO_SMALL, Message: "The owner_value must be greater than 0"}},
+		},
+		{
+			name:    "owner value exceeds the value",
+			request: DebtRequest{Method: METHOD_AMOUNTS, Value: money.FromCents(9000), OwnerValue: money.FromCents(9001)},
+			err:     validation.Errors{{Field: "owner_value", Code: validation.CODE_INVALID, Message: "The owner_value must not exceed the value"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_mockRepository := &mockRepository{}
+			_storageProcess := NewStorageProcess(_mockRepository, uuidSequenceMock(), &mockGainProjectionProcess{}, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+			test.request.Contact = "Ana"
+			response, err := _storageProcess.CreateDebt(CreateDebtContext{Ctx: context.TODO(), Request: test.request, UserToken: tokenMock})
+			assert.Nil(t, response)
+			assert.Equal(t, test.err, err)
+			assert.Nil(t, _mockRepository.splitSaved)
+		})
+	}
+}
+
+func TestCreateDebtProjectionFail(t *testing.T) {
+	_mockRepository := &mockRepository{contacts: buildContactsMock()}
+	_mockTransactor := &mockTransactor{}
+	_storageProcess := NewStorageProcess(
+		_mockRepository,
+		uuidSequenceMock(),
+		&mockGainProjectionProcess{},
+		&mockInvoiceProjectionProcess{err: errors.New("An error has been ocurred")},
+		nowMock,
+		_mockTransactor)
+
+	response, err := _storageProcess.CreateDebt(CreateDebtContext{
+		Ctx: context.TODO(),
+		Request: DebtRequest{
+			Contact:       "Ana",
+			Description:   "Hotel",
+			Value:         money.FromCents(9000),
+			PaidAt:        date(2023, 11, 20),
+			Method:        METHOD_SHARES,
+			Share:         1,
+			CategoryId:    4,
+			PaymentTypeId: 2,
+		},
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Error(t, err)
+	assert.True(t, _mockTransactor.rolledBack)
+	assert.Nil(t, _mockRepository.sharesSaved)
+}
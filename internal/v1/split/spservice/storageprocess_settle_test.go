@@ -0,0 +1,185 @@
+package spservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection/gpservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection/ipservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockGainProjectionProcess struct {
+	requests    []gpservice.CreateRequest
+	contexts    []gpservice.CreateGainContext
+	alreadyDone map[string]bool
+	err         error
+}
+
+func (m *mockGainProjectionProcess) Create(createCtx gpservice.CreateContext) (*gpservice.GainProjectionResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.requests = append(m.requests, createCtx.Request)
+	return &gpservice.GainProjectionResponse{Id: fmt.Sprintf("gain-projection-%d", len(m.requests))}, nil
+}
+
+func (m *mockGainProjectionProcess) Update(updateCtx gpservice.UpdateContext) (*gpservice.GainProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockGainProjectionProcess) Patch(patchCtx gpservice.PatchContext) (*gpservice.GainProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockGainProjectionProcess) Delete(deleteCtx gpservice.DeleteContext) error {
+	return nil
+}
+
+func (m *mockGainProjectionProcess) Restore(searchCtx gpservice.SearchContext) (*gpservice.GainProjectionResponse, error) {
+	return nil, nil
+}
+
+func (m *mockGainProjectionProcess) CreateGain(createGainCtx gpservice.CreateGainContext) (*gpservice.GainStat, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.contexts = append(m.contexts, createGainCtx)
+	if m.alreadyDone[createGainCtx.Id] {
+		return &gpservice.GainStat{ProjectionIsFound: true, ProjectionIsAlreadyDone: true}, nil
+	}
+	return &gpservice.GainStat{ProjectionIsFound: true, Gain: &gpservice.GainResponse{GainProjectionId: createGainCtx.Id}}, nil
+}
+
+func (m *mockGainProjectionProcess) CreateGainBatch(createGainBatchCtx gpservice.CreateGainBatchContext) (*gpservice.GainBatchResponse, error) {
+	return nil, nil
+}
+
+func (m *mockGainProjectionProcess) RevertGain(revertGainCtx gpservice.RevertGainContext) (*gpservice.RevertGainStat, error) {
+	return nil, nil
+}
+
+func buildSettleRepositoryMock() *mockRepository {
+	return &mockRepository{
+		contacts: buildContactsMock(),
+		shares: map[string][]repository.Share{
+			"7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c": {
+				{SplitId: "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", ContactName: "Ana", Value: money.FromCents(1239), GainProjectionId: "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a"},
+				{SplitId: "7e4a2c1b-9d8f-4e6a-b5c3-2f1d0e9a8b7c", ContactId: "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", ContactName: "Bruno", Value: money.FromCents(2479), GainProjectionId: "f4d5e6f7-0819-42a3-b4c5-d6e7f8091a2b"},
+			},
+			"8a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d": {
+				{SplitId: "8a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", ContactName: "Ana", Value: money.FromCents(1000), GainProjectionId: "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4e", IsSettled: true},
+			},
+			"9b2c3d4e-5f6a-4b7c-9d8e-0f1a2b3c4d5e": {
+				{SplitId: "9b2c3d4e-5f6a-4b7c-9d8e-0f1a2b3c4d5e", ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", ContactName: "Ana", Value: money.FromCents(3000), InvoiceProjectionId: "1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5f"},
+			},
+		},
+		balances: []repository.Balance{
+			{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", ContactName: "Ana", Currency: "BRL", Total: money.FromCents(-761), Settled: money.FromCents(-761), Pending: money.FromCents(0)},
+			{ContactId: "d2b3c4d5-e6f7-4081-92a3-b4c5d6e7f809", ContactName: "Bruno", Currency: "BRL", Total: money.FromCents(2479), Settled: money.FromCents(0), Pending: money.FromCents(2479)},
+		},
+	}
+}
+
+func TestSettleSuccess(t *testing.T) {
+	_mockProjectionProcess := &mockGainProjectionProcess{}
+	_mockInvoiceProjectionProcess := &mockInvoiceProjectionProcess{}
+	_storageProcess := NewStorageProcess(buildSettleRepositoryMock(), uuidSequenceMock(), _mockProjectionProcess, _mockInvoiceProjectionProcess, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Settle(SettleContext{
+		Ctx:       context.TODO(),
+		Id:        "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(_mockInvoiceProjectionProcess.contexts))
+	assert.Equal(t, "1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5f", _mockInvoiceProjectionProcess.contexts[0].Id)
+	assert.Equal(t, ipservice.CreateInvoiceRequest{PayIn: date(2023, 11, 26)}, _mockInvoiceProjectionProcess.contexts[0].Request)
+	assert.Equal(t, 1, len(_mockProjectionProcess.contexts))
+	assert.Equal(t, "e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a", _mockProjectionProcess.contexts[0].Id)
+	assert.Equal(t, gpservice.CreateGainRequest{PayIn: date(2023, 11, 26)}, _mockProjectionProcess.contexts[0].Request)
+	assert.Equal(t, tokenMock, _mockProjectionProcess.contexts[0].UserToken)
+	assert.Equal(t, &SettleResponse{
+		ContactId:     "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+		Name:          "Ana",
+		SettledShares: 2,
+		Balances: []BalanceResponse{
+			{ContactId: "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8", Name: "Ana", Currency: "BRL", Total: money.FromCents(-761), Settled: money.FromCents(-761), Pending: money.FromCents(0)},
+		},
+	}, response)
+}
+
+func TestSettleOnPayIn(t *testing.T) {
+	_mockProjectionProcess := &mockGainProjectionProcess{alreadyDone: map[string]bool{"e3c4d5e6-f708-4192-a3b4-c5d6e7f8091a": true}}
+	_storageProcess := NewStorageProcess(buildSettleRepositoryMock(), uuidSequenceMock(), _mockProjectionProcess, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	payIn := date(2023, 11, 30)
+	response, err := _storageProcess.Settle(SettleContext{
+		Ctx:       context.TODO(),
+		Id:        "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+		Request:   SettleRequest{PayIn: &payIn},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, payIn, _mockProjectionProcess.contexts[0].Request.PayIn)
+	assert.Equal(t, uint(1), response.SettledShares)
+}
+
+func TestSettleContactNotFound(t *testing.T) {
+	_mockProjectionProcess := &mockGainProjectionProcess{}
+	_storageProcess := NewStorageProcess(buildSettleRepositoryMock(), uuidSequenceMock(), _mockProjectionProcess, &mockInvoiceProjectionProcess{}, nowMock, &mockTransactor{})
+
+	response, err := _storageProcess.Settle(SettleContext{
+		Ctx:       context.TODO(),
+		Id:        "9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a",
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Equal(t, apperror.NotFound("Contact not found"), err)
+	assert.Empty(t, _mockProjectionProcess.contexts)
+}
+
+func TestSettleFail(t *testing.T) {
+	_mockTransactor := &mockTransactor{}
+	_storageProcess := NewStorageProcess(
+		buildSettleRepositoryMock(),
+		uuidSequenceMock(),
+		&mockGainProjectionProcess{err: errors.New("An error has been ocurred")},
+		&mockInvoiceProjectionProcess{},
+		nowMock,
+		_mockTransactor)
+
+	response, err := _storageProcess.Settle(SettleContext{
+		Ctx:       context.TODO(),
+		Id:        "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Error(t, err)
+	assert.True(t, _mockTransactor.rolledBack)
+}
+
+func TestSettleInvoiceFail(t *testing.T) {
+	_mockTransactor := &mockTransactor{}
+	_storageProcess := NewStorageProcess(
+		buildSettleRepositoryMock(),
+		uuidSequenceMock(),
+		&mockGainProjectionProcess{},
+		&mockInvoiceProjectionProcess{err: errors.New("An error has been ocurred")},
+		nowMock,
+		_mockTransactor)
+
+	response, err := _storageProcess.Settle(SettleContext{
+		Ctx:       context.TODO(),
+		Id:        "c1a2b3c4-d5e6-4f70-8192-a3b4c5d6e7f8",
+		UserToken: tokenMock,
+	})
+	assert.Nil(t, response)
+	assert.Error(t, err)
+	assert.True(t, _mockTransactor.rolledBack)
+}
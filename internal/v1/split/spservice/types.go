@@ -0,0 +1,147 @@
+package spservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const (
+	METHOD_SHARES  = "shares"
+	METHOD_AMOUNTS = "amounts"
+)
+
+const (
+	SHARE_STATUS_PENDING   = "pending"
+	SHARE_STATUS_SETTLED   = "settled"
+	SHARE_STATUS_CANCELLED = "cancelled"
+)
+
+// DEFAULT_OWNER_SHARE is the part of the user when the split by shares does not inform the owner_share
+const DEFAULT_OWNER_SHARE = uint(1)
+
+// DEFAULT_CATEGORY_ID is the gain category of the expected gains of the participants when the split does not inform one
+const DEFAULT_CATEGORY_ID = uint(10) // Rateio
+
+type CreateContext struct {
+	Ctx       context.Context
+	Request   CreateRequest
+	UserToken string
+}
+
+type CreateDebtContext struct {
+	Ctx       context.Context
+	Request   DebtRequest
+	UserToken string
+}
+
+type SearchContext struct {
+	Ctx       context.Context
+	Id        string
+	UserToken string
+}
+
+// SettleContext settles the pending shares of the contact of the id
+type SettleContext struct {
+	Ctx       context.Context
+	Id        string
+	Request   SettleRequest
+	UserToken string
+}
+
+// CreateRequest splits an invoice paid by the user among the participants, which are contacts registered by the name
+// on their first split. With the shares method the invoice value is divided proportionally to the share of each
+// participant and to the owner_share of the user, with the amounts method each participant owes its value and the user
+// keeps the rest. The expected gains of the participants are received on the pay_in, which defaults to the invoice pay_at,
+// in the gain category of the category_id, which defaults to Rateio
+type CreateRequest struct {
+	InvoiceId    string               `json:"invoice_id" binding:"notblank"`
+	Method       string               `json:"method" binding:"required,oneof=shares amounts"`
+	OwnerShare   *uint                `json:"owner_share"`
+	PayIn        *time.Time           `json:"pay_in"`
+	CategoryId   *uint                `json:"category_id"`
+	Participants []ParticipantRequest `json:"participants" binding:"required,min=1,max=50"`
+}
+
+// DebtRequest splits an expense paid by the contact with the user, the contact is registered by the name on its first
+// split. With the shares method the value is divided between the owner_share of the user and the share of the contact,
+// with the amounts method the user owes the owner_value. The part of the user is an invoice projection of the category_id
+// and the payment_type_id to be paid back on the pay_in, which defaults to the paid_at
+type DebtRequest struct {
+	Contact       string      `json:"contact" binding:"notblank,max=255"`
+	Description   string      `json:"description" binding:"notblank,max=255"`
+	Value         money.Money `json:"value" binding:"gt=0" swaggertype:"number"`
+	Currency      string      `json:"currency" binding:"omitempty,iso4217"`
+	PaidAt        time.Time   `json:"paid_at" binding:"required"`
+	Method        string      `json:"method" binding:"required,oneof=shares amounts"`
+	OwnerShare    *uint       `json:"owner_share"`
+	Share         uint        `json:"share"`
+	OwnerValue    money.Money `json:"owner_value" swaggertype:"number"`
+	PayIn         *time.Time  `json:"pay_in"`
+	CategoryId    uint        `json:"category_id" binding:"required"`
+	PaymentTypeId uint        `json:"payment_type_id" binding:"required"`
+}
+
+type ParticipantRequest struct {
+	Name  string      `json:"name"`
+	Share uint        `json:"share"`
+	Value money.Money `json:"value" swaggertype:"number"`
+}
+
+// SettleRequest realizes the pending projections of the contact on the pay_in, which defaults to the current day
+type SettleRequest struct {
+	PayIn *time.Time `json:"pay_in"`
+}
+
+// SplitResponse has the description, the value and the currency of the expense, the owner value is the part of the user.
+// A receivable split divides an invoice of the user, a payable split an expense paid by the contact of its share
+type SplitResponse struct {
+	Id          string          `json:"id"`
+	Direction   string          `json:"direction"`
+	InvoiceId   string          `json:"invoice_id,omitempty"`
+	Description string          `json:"description"`
+	Value       money.Money     `json:"value" swaggertype:"number"`
+	Currency    string          `json:"currency"`
+	Method      string          `json:"method"`
+	OwnerShare  *uint           `json:"owner_share,omitempty"`
+	OwnerValue  money.Money     `json:"owner_value" swaggertype:"number"`
+	PayIn       time.Time       `json:"pay_in"`
+	Shares      []ShareResponse `json:"shares"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// ShareResponse is the part of a contact, received through the gain projection or paid through the invoice projection,
+// cancelled when its projection has been deleted
+type ShareResponse struct {
+	ContactId           string      `json:"contact_id"`
+	Name                string      `json:"name"`
+	Share               uint        `json:"share,omitempty"`
+	Value               money.Money `json:"value" swaggertype:"number"`
+	GainProjectionId    string      `json:"gain_projection_id,omitempty"`
+	InvoiceProjectionId string      `json:"invoice_projection_id,omitempty"`
+	Status              string      `json:"status"`
+}
+
+// BalanceResponse sums the shares of a contact in a currency, positive when the contact owes the user and negative when
+// the user owes the contact
+type BalanceResponse struct {
+	ContactId string      `json:"contact_id"`
+	Name      string      `json:"name"`
+	Currency  string      `json:"currency"`
+	Total     money.Money `json:"total" swaggertype:"number"`
+	Settled   money.Money `json:"settled" swaggertype:"number"`
+	Pending   money.Money `json:"pending" swaggertype:"number"`
+}
+
+type BalanceListResponse struct {
+	Records []BalanceResponse `json:"records"`
+}
+
+// SettleResponse tells how many shares were settled and the balances of the contact after the settlement
+type SettleResponse struct {
+	ContactId     string            `json:"contact_id"`
+	Name          string            `json:"name"`
+	SettledShares uint              `json:"settled_shares"`
+	Balances      []BalanceResponse `json:"balances"`
+}
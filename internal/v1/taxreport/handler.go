@@ -0,0 +1,152 @@
+package taxreport
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/tracing"
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport/trservice"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"go.elastic.co/apm"
+)
+
+type Handler interface {
+	SaveMapping(c *gin.Context)
+	GetMappings(c *gin.Context)
+	GetReport(c *gin.Context)
+}
+
+type ResponseDefault interface {
+}
+
+type handler struct {
+	storageProcess trservice.StorageProcess
+	readingProcess trservice.ReadingProcess
+}
+
+func NewHandler(storageProcess trservice.StorageProcess, readingProcess trservice.ReadingProcess) Handler {
+	return &handler{storageProcess: storageProcess, readingProcess: readingProcess}
+}
+
+// SaveMapping godoc
+// @Summary Mapear a natureza de uma categoria no IRPF
+// @Description Este endpoint permite definir em qual seção da declaração do IRPF entram os registros de uma categoria.
+// @Description As receitas podem ser tributáveis (taxable), de tributação exclusiva (exclusive) ou isentas (exempt), as despesas podem ser de saúde (health) ou de educação (education), e a natureza ignored deixa a categoria fora do relatório
+// @Tags TaxReport
+// @Accept json
+// @Produce json
+// @Param mapping body trservice.SaveMappingRequest true "Modelo do mapeamento da categoria"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} trservice.CategoryMappingResponse
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string,errors=[]validation.FieldError}
+// @Router /v1/tax-report/mapping [put]
+func (h *handler) SaveMapping(c *gin.Context) {
+	var request trservice.SaveMappingRequest
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	err := validation.Bind(c, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("TaxReport::StorageProcess::SaveMapping", "Save the tax nature of a category", nil)
+	saveCtx := trservice.SaveMappingContext{
+		Ctx:       ctx,
+		Request:   request,
+		UserToken: userToken,
+	}
+	mapping, err := h.storageProcess.SaveMapping(saveCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, mapping)
+}
+
+// @Summary Obter o mapeamento das categorias no IRPF
+// @Description Este endpoint permite obter a natureza de todas as categorias de receitas e despesas na declaração do IRPF.
+// @Description As categorias que o usuário não mapeou trazem a natureza padrão e são sinalizadas por is_default
+// @Tags TaxReport
+// @Accept json
+// @Produce json
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} trservice.CategoryMappingListResponse
+// @Router /v1/tax-report/mapping [get]
+func (h *handler) GetMappings(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+
+	span := tx.StartSpan("TaxReport::ReadingProcess::GetMappings", "Get the tax nature of the categories", nil)
+	searchCtx := trservice.SearchContext{
+		Ctx:       ctx,
+		UserToken: userToken,
+	}
+	mappings, err := h.readingProcess.GetMappings(searchCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	c.JSON(http.StatusOK, mappings)
+}
+
+// @Summary Obter o relatório anual do IRPF
+// @Description Este endpoint permite obter os totais do ano agrupados nas seções da declaração do IRPF: rendimentos tributáveis, de tributação exclusiva e isentos, e pagamentos de saúde e de educação.
+// @Description Os registros em outras moedas são convertidos para BRL pela cotação da data de pagamento, e o relatório pode ser obtido em JSON ou em CSV
+// @Tags TaxReport
+// @Accept json
+// @Produce json,text/csv
+// @Param year query int true "Ano-calendário do relatório"
+// @Param format query string false "Formato do relatório (json, csv), o padrão é json"
+// @Param   X-Access-Token	header	string	true	"Token de autenticação do usuário"
+// @Success 200 {object} trservice.TaxReportResponse
+// @Failure 400 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Failure 422 {object} ResponseDefault{type=string,title=string,status=int,detail=string}
+// @Router /v1/tax-report [get]
+func (h *handler) GetReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	tx := apm.TransactionFromContext(ctx)
+	userToken := c.GetHeader(idpauth.AUTH_HEADER)
+	format := c.DefaultQuery("format", FORMAT_JSON)
+
+	year, err := validateReportParams(c.Query("year"), format)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	span := tx.StartSpan("TaxReport::ReadingProcess::GetReport", "Get the yearly tax report", nil)
+	reportCtx := trservice.ReportContext{
+		Ctx:       ctx,
+		Year:      year,
+		UserToken: userToken,
+	}
+	report, err := h.readingProcess.GetReport(reportCtx)
+	if err != nil {
+		c.Error(err)
+		tracing.SendSpanErr(span, err)
+		return
+	}
+	span.End()
+	if format == FORMAT_JSON {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	var content bytes.Buffer
+	err = writeReportCSV(&content, report)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fmt.Sprintf("irpf-%d.csv", year)}))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", content.Bytes())
+}
@@ -0,0 +1,220 @@
+package taxreport
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/problem"
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport/trservice"
+	"github.com/stretchr/testify/assert"
+)
+
+const userToken = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type storageProcessMock struct {
+	err     error
+	mapping *trservice.CategoryMappingResponse
+}
+
+func (sp *storageProcessMock) SaveMapping(saveCtx trservice.SaveMappingContext) (*trservice.CategoryMappingResponse, error) {
+	if sp.err != nil {
+		return nil, sp.err
+	}
+	return sp.mapping, nil
+}
+
+type readingProcessMock struct {
+	err      error
+	mappings *trservice.CategoryMappingListResponse
+	report   *trservice.TaxReportResponse
+	year     uint
+}
+
+func (rp *readingProcessMock) GetMappings(searchCtx trservice.SearchContext) (*trservice.CategoryMappingListResponse, error) {
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.mappings, nil
+}
+
+func (rp *readingProcessMock) GetReport(reportCtx trservice.ReportContext) (*trservice.TaxReportResponse, error) {
+	rp.year = reportCtx.Year
+	if rp.err != nil {
+		return nil, rp.err
+	}
+	return rp.report, nil
+}
+
+func buildReportMock() *trservice.TaxReportResponse {
+	return &trservice.TaxReportResponse{
+		Year:     2024,
+		Currency: "BRL",
+		Sections: []trservice.TaxReportSectionResponse{
+			{
+				Nature: trservice.NATURE_TAXABLE,
+				Title:  "Rendimentos Tributáveis",
+				Total:  money.FromCents(1050050),
+				Items: []trservice.TaxReportItemResponse{
+					{CategoryId: 1, Category: "Salário", Total: money.FromCents(1000000)},
+					{CategoryId: 7, Category: "Aluguéis", Total: money.FromCents(50050)},
+				},
+			},
+			{
+				Nature: trservice.NATURE_HEALTH,
+				Title:  "Pagamentos Efetuados - Saúde",
+				Items:  []trservice.TaxReportItemResponse{},
+			},
+		},
+	}
+}
+
+func TestSaveMappingSuccess(t *testing.T) {
+	_storageProcessMock := &storageProcessMock{
+		mapping: &trservice.CategoryMappingResponse{Entity: "gain", CategoryId: 7, Nature: "exempt"},
+	}
+	handler := NewHandler(_storageProcessMock, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.PUT("/tax-report/mapping", handler.SaveMapping)
+
+	body := []byte(`{"entity": "gain", "category_id": 7, "nature": "exempt"}`)
+	req, _ := http.NewRequest("PUT", "/v1/tax-report/mapping", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, `{"entity":"gain","category_id":7,"nature":"exempt","is_default":false}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSaveMappingInvalidFields(t *testing.T) {
+	handler := NewHandler(&storageProcessMock{}, nil)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.PUT("/tax-report/mapping", handler.SaveMapping)
+
+	body := []byte(`{"entity": "transfer", "category_id": 7, "nature": "deductible"}`)
+	req, _ := http.NewRequest("PUT", "/v1/tax-report/mapping", bytes.NewReader(body))
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"The request contains invalid fields","errors":[{"field":"entity","code":"invalid","message":"The entity is not valid"},{"field":"nature","code":"invalid","message":"The nature is not valid"}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestGetMappingsSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{
+		mappings: &trservice.CategoryMappingListResponse{
+			Records: []trservice.CategoryMappingResponse{
+				{Entity: "gain", CategoryId: 1, Category: "Salário", Nature: "taxable", IsDefault: true},
+			},
+		},
+	}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/tax-report/mapping", handler.GetMappings)
+
+	req, _ := http.NewRequest("GET", "/v1/tax-report/mapping", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, `{"records":[{"entity":"gain","category_id":1,"category":"Salário","nature":"taxable","is_default":true}]}`, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetReportSuccess(t *testing.T) {
+	_readingProcessMock := &readingProcessMock{report: buildReportMock()}
+	handler := NewHandler(nil, _readingProcessMock)
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/tax-report", handler.GetReport)
+
+	req, _ := http.NewRequest("GET", "/v1/tax-report?year=2024", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := `{"year":2024,"currency":"BRL","sections":[{"nature":"taxable","title":"Rendimentos Tributáveis","total":10500.5,"items":[{"category_id":1,"category":"Salário","total":10000},{"category_id":7,"category":"Aluguéis","total":500.5}]},{"nature":"health","title":"Pagamentos Efetuados - Saúde","total":0,"items":[]}]}`
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, uint(2024), _readingProcessMock.year)
+}
+
+func TestGetReportCSVSuccess(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{report: buildReportMock()})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/tax-report", handler.GetReport)
+
+	req, _ := http.NewRequest("GET", "/v1/tax-report?year=2024&format=csv", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	bodyExpected := "nature,section,category_id,category,total\n" +
+		"taxable,Rendimentos Tributáveis,1,Salário,10000.00\n" +
+		"taxable,Rendimentos Tributáveis,7,Aluguéis,500.50\n" +
+		"taxable,Rendimentos Tributáveis,,Total,10500.50\n" +
+		"health,Pagamentos Efetuados - Saúde,,Total,0.00\n"
+	assert.Equal(t, bodyExpected, w.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=irpf-2024.csv", w.Header().Get("Content-Disposition"))
+}
+
+func TestGetReportInvalidParams(t *testing.T) {
+	tests := []struct {
+		query  string
+		detail string
+	}{
+		{query: "", detail: "A param year  is invalid"},
+		{query: "year=abc", detail: "A param year abc is invalid"},
+		{query: "year=2024&format=pdf", detail: "A param format pdf is invalid"},
+	}
+	for _, test := range tests {
+		handler := NewHandler(nil, &readingProcessMock{})
+		w := httptest.NewRecorder()
+		router := gin.Default()
+		router.Use(problem.Middleware)
+		apiRouter := router.Group("/v1")
+		apiRouter.GET("/tax-report", handler.GetReport)
+
+		req, _ := http.NewRequest("GET", "/v1/tax-report?"+test.query, nil)
+		req.Header.Add(idpauth.AUTH_HEADER, userToken)
+		router.ServeHTTP(w, req)
+
+		bodyExpected := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"` + test.detail + `"}`
+		assert.Equal(t, bodyExpected, w.Body.String())
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetReportFail(t *testing.T) {
+	handler := NewHandler(nil, &readingProcessMock{err: errors.New("An error has been ocurred")})
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	router.Use(problem.Middleware)
+	apiRouter := router.Group("/v1")
+	apiRouter.GET("/tax-report", handler.GetReport)
+
+	req, _ := http.NewRequest("GET", "/v1/tax-report?year=2024", nil)
+	req.Header.Add(idpauth.AUTH_HEADER, userToken)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,50 @@
+package taxreport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport/trservice"
+)
+
+const (
+	FORMAT_JSON = "json"
+	FORMAT_CSV  = "csv"
+)
+
+func validateReportParams(yearParam string, format string) (uint, error) {
+	year, _ := strconv.ParseUint(yearParam, 10, 32)
+	if year == uint64(0) {
+		return 0, apperror.Validation(fmt.Sprintf("A param year %s is invalid", yearParam))
+	}
+	if format != FORMAT_JSON && format != FORMAT_CSV {
+		return 0, apperror.Validation(fmt.Sprintf("A param format %s is invalid", format))
+	}
+	return uint(year), nil
+}
+
+// writeReportCSV writes one line per category of each section, followed by the total line of the section
+func writeReportCSV(w io.Writer, report *trservice.TaxReportResponse) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"nature", "section", "category_id", "category", "total"})
+	if err != nil {
+		return err
+	}
+	for _, section := range report.Sections {
+		for _, item := range section.Items {
+			err = writer.Write([]string{section.Nature, section.Title, strconv.FormatUint(uint64(item.CategoryId), 10), item.Category, item.Total.String()})
+			if err != nil {
+				return err
+			}
+		}
+		err = writer.Write([]string{section.Nature, section.Title, "", "Total", section.Total.String()})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Repository interface {
+	SaveMapping(ctx context.Context, mapping CategoryMapping) (*CategoryMapping, error)
+	GetMappings(ctx context.Context, userId string) (*[]CategoryMapping, error)
+	GetGainCategories(ctx context.Context) (*[]Category, error)
+	GetInvoiceCategories(ctx context.Context) (*[]Category, error)
+	GetGainTotals(ctx context.Context, userId string, year uint) (*[]CategoryTotal, error)
+	GetInvoiceTotals(ctx context.Context, userId string, year uint) (*[]CategoryTotal, error)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// SaveMapping creates the mapping of the category or replaces the nature of the existing one
+func (r *repository) SaveMapping(ctx context.Context, mapping CategoryMapping) (*CategoryMapping, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO tax_category_mapping (user_id, entity, category_id, nature)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE nature = VALUES(nature)`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(mapping.UserId, mapping.Entity, mapping.CategoryId, mapping.Nature)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return &mapping, nil
+}
+
+func (r *repository) GetMappings(ctx context.Context, userId string) (*[]CategoryMapping, error) {
+	query := `
+		SELECT
+			user_id,
+			entity,
+			category_id,
+			nature
+		FROM
+			tax_category_mapping
+		WHERE
+			user_id = ?
+		ORDER BY entity, category_id`
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mappingList := []CategoryMapping{}
+	for rows.Next() {
+		var mapping CategoryMapping
+		err := rows.Scan(&mapping.UserId, &mapping.Entity, &mapping.CategoryId, &mapping.Nature)
+		if err != nil {
+			return nil, err
+		}
+		mappingList = append(mappingList, mapping)
+	}
+	return &mappingList, nil
+}
+
+func (r *repository) GetGainCategories(ctx context.Context) (*[]Category, error) {
+	return r.getCategories(ctx, `SELECT id, category FROM gain_category ORDER BY id`)
+}
+
+func (r *repository) GetInvoiceCategories(ctx context.Context) (*[]Category, error) {
+	return r.getCategories(ctx, `SELECT id, category FROM invoice_category ORDER BY id`)
+}
+
+func (r *repository) getCategories(ctx context.Context, query string) (*[]Category, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categoryList := []Category{}
+	for rows.Next() {
+		var category Category
+		err := rows.Scan(&category.Id, &category.Category)
+		if err != nil {
+			return nil, err
+		}
+		categoryList = append(categoryList, category)
+	}
+	return &categoryList, nil
+}
+
+// GetGainTotals returns the gains of the year summed by category, currency and payment date
+func (r *repository) GetGainTotals(ctx context.Context, userId string, year uint) (*[]CategoryTotal, error) {
+	query := `
+		SELECT
+			gc.id,
+			gc.category,
+			g.currency,
+			g.pay_in,
+			SUM(g.value)
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON
+			gc.id = g.category_id
+		WHERE
+			YEAR(g.pay_in) = ? AND g.user_id = ? AND g.deleted_at IS NULL
+		GROUP BY gc.id, gc.category, g.currency, g.pay_in
+		ORDER BY gc.id, g.pay_in`
+	return r.getTotals(ctx, query, userId, year)
+}
+
+// GetInvoiceTotals returns the invoices of the year summed by category, currency and payment date
+func (r *repository) GetInvoiceTotals(ctx context.Context, userId string, year uint) (*[]CategoryTotal, error) {
+	query := `
+		SELECT
+			ic.id,
+			ic.category,
+			i.currency,
+			i.pay_at,
+			SUM(i.value)
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON
+			ic.id = i.category_id
+		WHERE
+			YEAR(i.pay_at) = ? AND i.user_id = ? AND i.deleted_at IS NULL
+		GROUP BY ic.id, ic.category, i.currency, i.pay_at
+		ORDER BY ic.id, i.pay_at`
+	return r.getTotals(ctx, query, userId, year)
+}
+
+func (r *repository) getTotals(ctx context.Context, query string, userId string, year uint) (*[]CategoryTotal, error) {
+	rows, err := r.db.QueryContext(ctx, query, year, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totalList := []CategoryTotal{}
+	for rows.Next() {
+		var total CategoryTotal
+		err := rows.Scan(&total.Category.Id, &total.Category.Category, &total.Currency, &total.PayDate, &total.Value)
+		if err != nil {
+			return nil, err
+		}
+		totalList = append(totalList, total)
+	}
+	return &totalList, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getGainCategoriesQuery = `SELECT id, category FROM gain_category ORDER BY id`
+
+func TestGetGainCategoriesSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category"}).
+		AddRow(1, "Salário").
+		AddRow(2, "13º Salário")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainCategoriesQuery).
+		WillReturnRows(rowsMock)
+
+	result, err := _repository.GetGainCategories(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Category{
+		{Id: 1, Category: "Salário"},
+		{Id: 2, Category: "13º Salário"},
+	}, *result)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainCategoriesScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category"}).
+		AddRow("one", "Salário")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainCategoriesQuery).
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetGainCategories(context.Background())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainCategoriesQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainCategoriesQuery).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetGainCategories(context.Background())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getGainTotalsQuery = `
+		SELECT
+			gc.id,
+			gc.category,
+			g.currency,
+			g.pay_in,
+			SUM(g.value)
+		FROM
+			gain g
+		INNER JOIN gain_category gc ON
+			gc.id = g.category_id
+		WHERE
+			YEAR(g.pay_in) = ? AND g.user_id = ? AND g.deleted_at IS NULL
+		GROUP BY gc.id, gc.category, g.currency, g.pay_in
+		ORDER BY gc.id, g.pay_in`
+
+func TestGetGainTotalsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category", "currency", "pay_date", "value"}).
+		AddRow(1, "Salário", "BRL", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), "5000.00").
+		AddRow(1, "Salário", "USD", time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), "120.50")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainTotalsQuery).
+		WithArgs(2024, "User1").
+		WillReturnRows(rowsMock)
+
+	result, err := _repository.GetGainTotals(context.Background(), "User1", 2024)
+	assert.NoError(t, err)
+	assert.Equal(t, []CategoryTotal{
+		{Category: Category{Id: 1, Category: "Salário"}, Currency: "BRL", PayDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Value: money.FromCents(500000)},
+		{Category: Category{Id: 1, Category: "Salário"}, Currency: "USD", PayDate: time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), Value: money.FromCents(12050)},
+	}, *result)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainTotalsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category", "currency", "pay_date", "value"}).
+		AddRow(1, "Salário", "BRL", "yesterday", "5000.00")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainTotalsQuery).
+		WithArgs(2024, "User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetGainTotals(context.Background(), "User1", 2024)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetGainTotalsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getGainTotalsQuery).
+		WithArgs(2024, "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetGainTotals(context.Background(), "User1", 2024)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
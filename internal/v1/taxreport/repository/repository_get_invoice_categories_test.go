@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getInvoiceCategoriesQuery = `SELECT id, category FROM invoice_category ORDER BY id`
+
+func TestGetInvoiceCategoriesSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category"}).
+		AddRow(4, "Educação").
+		AddRow(5, "Saúde")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceCategoriesQuery).
+		WillReturnRows(rowsMock)
+
+	result, err := _repository.GetInvoiceCategories(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Category{
+		{Id: 4, Category: "Educação"},
+		{Id: 5, Category: "Saúde"},
+	}, *result)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceCategoriesScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category"}).
+		AddRow("one", "Salário")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceCategoriesQuery).
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetInvoiceCategories(context.Background())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceCategoriesQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceCategoriesQuery).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetInvoiceCategories(context.Background())
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+const getInvoiceTotalsQuery = `
+		SELECT
+			ic.id,
+			ic.category,
+			i.currency,
+			i.pay_at,
+			SUM(i.value)
+		FROM
+			invoice i
+		INNER JOIN invoice_category ic ON
+			ic.id = i.category_id
+		WHERE
+			YEAR(i.pay_at) = ? AND i.user_id = ? AND i.deleted_at IS NULL
+		GROUP BY ic.id, ic.category, i.currency, i.pay_at
+		ORDER BY ic.id, i.pay_at`
+
+func TestGetInvoiceTotalsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category", "currency", "pay_date", "value"}).
+		AddRow(5, "Saúde", "BRL", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), "5000.00").
+		AddRow(5, "Saúde", "USD", time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), "120.50")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceTotalsQuery).
+		WithArgs(2024, "User1").
+		WillReturnRows(rowsMock)
+
+	result, err := _repository.GetInvoiceTotals(context.Background(), "User1", 2024)
+	assert.NoError(t, err)
+	assert.Equal(t, []CategoryTotal{
+		{Category: Category{Id: 5, Category: "Saúde"}, Currency: "BRL", PayDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Value: money.FromCents(500000)},
+		{Category: Category{Id: 5, Category: "Saúde"}, Currency: "USD", PayDate: time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), Value: money.FromCents(12050)},
+	}, *result)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceTotalsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"id", "category", "currency", "pay_date", "value"}).
+		AddRow(5, "Saúde", "BRL", "yesterday", "5000.00")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceTotalsQuery).
+		WithArgs(2024, "User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetInvoiceTotals(context.Background(), "User1", 2024)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInvoiceTotalsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getInvoiceTotalsQuery).
+		WithArgs(2024, "User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetInvoiceTotals(context.Background(), "User1", 2024)
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
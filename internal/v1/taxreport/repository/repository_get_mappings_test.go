@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const getMappingsQuery = `
+		SELECT
+			user_id,
+			entity,
+			category_id,
+			nature
+		FROM
+			tax_category_mapping
+		WHERE
+			user_id = ?
+		ORDER BY entity, category_id`
+
+func TestGetMappingsSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"user_id", "entity", "category_id", "nature"}).
+		AddRow("User1", "gain", 7, "exempt").
+		AddRow("User1", "invoice", 6, "health")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getMappingsQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	result, err := _repository.GetMappings(context.Background(), "User1")
+	assert.NoError(t, err)
+	assert.Equal(t, []CategoryMapping{
+		{UserId: "User1", Entity: "gain", CategoryId: 7, Nature: "exempt"},
+		{UserId: "User1", Entity: "invoice", CategoryId: 6, Nature: "health"},
+	}, *result)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetMappingsScanFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	rowsMock := sqlMock.NewRows([]string{"user_id", "entity", "category_id", "nature"}).
+		AddRow("User1", "gain", "seven", "exempt")
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getMappingsQuery).
+		WithArgs("User1").
+		WillReturnRows(rowsMock)
+
+	_, err = _repository.GetMappings(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetMappingsQueryFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectQuery(getMappingsQuery).
+		WithArgs("User1").
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	_, err = _repository.GetMappings(context.Background(), "User1")
+	assert.Error(t, err)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
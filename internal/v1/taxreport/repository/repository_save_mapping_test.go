@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const saveMappingQuery = `
+		INSERT INTO tax_category_mapping (user_id, entity, category_id, nature)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE nature = VALUES(nature)`
+
+func buildMappingMock() CategoryMapping {
+	return CategoryMapping{UserId: "User1", Entity: "gain", CategoryId: 7, Nature: "exempt"}
+}
+
+func TestSaveMappingSuccess(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveMappingQuery).
+		ExpectExec().
+		WithArgs("User1", "gain", 7, "exempt").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	mappingSaved, err := _repository.SaveMapping(context.Background(), buildMappingMock())
+	assert.NoError(t, err)
+	assert.Equal(t, buildMappingMock(), *mappingSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveMappingFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectPrepare(saveMappingQuery).
+		ExpectExec().
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	mappingSaved, err := _repository.SaveMapping(context.Background(), buildMappingMock())
+	assert.Error(t, err)
+	assert.Nil(t, mappingSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSaveMappingBeginFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_repository := New(dbMock)
+
+	sqlMock.ExpectBegin().WillReturnError(errors.New("An error has been ocurred"))
+
+	mappingSaved, err := _repository.SaveMapping(context.Background(), buildMappingMock())
+	assert.Error(t, err)
+	assert.Nil(t, mappingSaved)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
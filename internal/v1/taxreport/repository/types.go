@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+type Category struct {
+	Id       uint
+	Category string
+}
+
+// CategoryMapping is the tax nature chosen by the user for a category of gains or invoices
+type CategoryMapping struct {
+	UserId     string
+	Entity     string
+	CategoryId uint
+	Nature     string
+}
+
+// CategoryTotal is the sum of the records of a category kept in a currency and paid on a date
+type CategoryTotal struct {
+	Category Category
+	Currency string
+	PayDate  time.Time
+	Value    money.Money
+}
@@ -0,0 +1,158 @@
+package trservice
+
+import (
+	"context"
+
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport/repository"
+)
+
+type ReadingProcess interface {
+	GetMappings(searchCtx SearchContext) (*CategoryMappingListResponse, error)
+	GetReport(reportCtx ReportContext) (*TaxReportResponse, error)
+}
+
+type readingProcess struct {
+	repository repository.Repository
+	converter  erservice.Converter
+}
+
+func NewReadingProcess(repository repository.Repository, converter erservice.Converter) ReadingProcess {
+	return &readingProcess{repository: repository, converter: converter}
+}
+
+// natures holds the mappings of the user by entity and category id
+type natures map[string]map[uint]string
+
+// get returns the nature mapped by the user, or else the default one, and tells whether it is the default
+func (n natures) get(entity string, categoryId uint) (string, bool) {
+	if nature, found := n[entity][categoryId]; found {
+		return nature, false
+	}
+	defaults := DEFAULT_INVOICE_NATURES
+	if entity == ENTITY_GAIN {
+		defaults = DEFAULT_GAIN_NATURES
+	}
+	if nature, found := defaults[categoryId]; found {
+		return nature, true
+	}
+	return NATURE_IGNORED, true
+}
+
+func (rp *readingProcess) getNatures(ctx context.Context, userId string) (natures, error) {
+	mappingList, err := rp.repository.GetMappings(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	userNatures := natures{ENTITY_GAIN: {}, ENTITY_INVOICE: {}}
+	for _, mapping := range *mappingList {
+		if _, found := userNatures[mapping.Entity]; found {
+			userNatures[mapping.Entity][mapping.CategoryId] = mapping.Nature
+		}
+	}
+	return userNatures, nil
+}
+
+// GetMappings returns the nature of every category, the ones not mapped by the user come with the default nature
+func (rp *readingProcess) GetMappings(searchCtx SearchContext) (*CategoryMappingListResponse, error) {
+	user := idpauth.GetUser(searchCtx.UserToken)
+	userNatures, err := rp.getNatures(searchCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	gainCategoryList, err := rp.repository.GetGainCategories(searchCtx.Ctx)
+	if err != nil {
+		return nil, err
+	}
+	invoiceCategoryList, err := rp.repository.GetInvoiceCategories(searchCtx.Ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mappingResponseList := []CategoryMappingResponse{}
+	categoriesByEntity := []struct {
+		entity     string
+		categories []repository.Category
+	}{
+		{entity: ENTITY_GAIN, categories: *gainCategoryList},
+		{entity: ENTITY_INVOICE, categories: *invoiceCategoryList},
+	}
+	for _, entityCategories := range categoriesByEntity {
+		for _, category := range entityCategories.categories {
+			nature, isDefault := userNatures.get(entityCategories.entity, category.Id)
+			mappingResponseList = append(mappingResponseList, CategoryMappingResponse{
+				Entity:     entityCategories.entity,
+				CategoryId: category.Id,
+				Category:   category.Category,
+				Nature:     nature,
+				IsDefault:  isDefault,
+			})
+		}
+	}
+	return &CategoryMappingListResponse{Records: mappingResponseList}, nil
+}
+
+// GetReport sums the gains and invoices of the year in the sections of their natures, converted to the currency of the declaration
+// on the payment date. Every section is returned, even the empty ones, in the order of the declaration.
+func (rp *readingProcess) GetReport(reportCtx ReportContext) (*TaxReportResponse, error) {
+	user := idpauth.GetUser(reportCtx.UserToken)
+	userNatures, err := rp.getNatures(reportCtx.Ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	gainTotalList, err := rp.repository.GetGainTotals(reportCtx.Ctx, user.Id, reportCtx.Year)
+	if err != nil {
+		return nil, err
+	}
+	invoiceTotalList, err := rp.repository.GetInvoiceTotals(reportCtx.Ctx, user.Id, reportCtx.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TaxReportResponse{Year: reportCtx.Year, Currency: REPORT_CURRENCY, Sections: []TaxReportSectionResponse{}}
+	sectionIndexes := map[string]int{}
+	for index, reportSection := range reportSections {
+		sectionIndexes[reportSection.nature] = index
+		report.Sections = append(report.Sections, TaxReportSectionResponse{
+			Nature: reportSection.nature,
+			Title:  reportSection.title,
+			Items:  []TaxReportItemResponse{},
+		})
+	}
+	totalsByEntity := []struct {
+		entity string
+		totals []repository.CategoryTotal
+	}{
+		{entity: ENTITY_GAIN, totals: *gainTotalList},
+		{entity: ENTITY_INVOICE, totals: *invoiceTotalList},
+	}
+	for _, entityTotals := range totalsByEntity {
+		for _, total := range entityTotals.totals {
+			nature, _ := userNatures.get(entityTotals.entity, total.Category.Id)
+			index, found := sectionIndexes[nature]
+			if !found || !isNatureOf(entityTotals.entity, nature) {
+				continue
+			}
+			value, err := rp.converter.Convert(erservice.ConvertContext{
+				Ctx:    reportCtx.Ctx,
+				UserId: user.Id,
+				Amount: total.Value,
+				From:   total.Currency,
+				To:     REPORT_CURRENCY,
+				Date:   total.PayDate,
+			})
+			if err != nil {
+				return nil, err
+			}
+			reportSection := &report.Sections[index]
+			reportSection.Total += value
+			items := reportSection.Items
+			if len(items) == 0 || items[len(items)-1].CategoryId != total.Category.Id {
+				reportSection.Items = append(reportSection.Items, TaxReportItemResponse{CategoryId: total.Category.Id, Category: total.Category.Category})
+			}
+			reportSection.Items[len(reportSection.Items)-1].Total += value
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,49 @@
+package trservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMappingsSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{
+		mappings: []repository.CategoryMapping{
+			{UserId: "5832a502-bede-492d-8dc1-b13b32c30f29", Entity: ENTITY_GAIN, CategoryId: 7, Nature: NATURE_IGNORED},
+			{UserId: "5832a502-bede-492d-8dc1-b13b32c30f29", Entity: ENTITY_INVOICE, CategoryId: 6, Nature: NATURE_HEALTH},
+		},
+		gainCategories: []repository.Category{
+			{Id: 1, Category: "Salário"},
+			{Id: 7, Category: "Aluguéis"},
+			{Id: 11, Category: "Outros"},
+		},
+		invoiceCategories: []repository.Category{
+			{Id: 5, Category: "Saúde"},
+			{Id: 6, Category: "Cuidado Pessoal e Beleza"},
+		},
+	}
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{})
+
+	response, err := _readingProcess.GetMappings(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, &CategoryMappingListResponse{
+		Records: []CategoryMappingResponse{
+			{Entity: ENTITY_GAIN, CategoryId: 1, Category: "Salário", Nature: NATURE_TAXABLE, IsDefault: true},
+			{Entity: ENTITY_GAIN, CategoryId: 7, Category: "Aluguéis", Nature: NATURE_IGNORED, IsDefault: false},
+			{Entity: ENTITY_GAIN, CategoryId: 11, Category: "Outros", Nature: NATURE_IGNORED, IsDefault: true},
+			{Entity: ENTITY_INVOICE, CategoryId: 5, Category: "Saúde", Nature: NATURE_HEALTH, IsDefault: true},
+			{Entity: ENTITY_INVOICE, CategoryId: 6, Category: "Cuidado Pessoal e Beleza", Nature: NATURE_HEALTH, IsDefault: false},
+		},
+	}, response)
+}
+
+func TestGetMappingsFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	response, err := _readingProcess.GetMappings(SearchContext{Ctx: context.TODO(), UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
@@ -0,0 +1,105 @@
+package trservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildReportRepositoryMock() *mockRepository {
+	salary := repository.Category{Id: 1, Category: "Salário"}
+	thirteenth := repository.Category{Id: 2, Category: "13º Salário"}
+	dividends := repository.Category{Id: 6, Category: "Dividendos"}
+	rentals := repository.Category{Id: 7, Category: "Aluguéis"}
+	health := repository.Category{Id: 5, Category: "Saúde"}
+	food := repository.Category{Id: 2, Category: "Alimentação"}
+	return &mockRepository{
+		mappings: []repository.CategoryMapping{
+			{Entity: ENTITY_GAIN, CategoryId: 7, Nature: NATURE_IGNORED},
+		},
+		gainTotals: []repository.CategoryTotal{
+			{Category: salary, Currency: "BRL", PayDate: date(2024, 1, 5), Value: money.FromCents(500000)},
+			{Category: salary, Currency: "BRL", PayDate: date(2024, 2, 5), Value: money.FromCents(500000)},
+			{Category: thirteenth, Currency: "BRL", PayDate: date(2024, 12, 20), Value: money.FromCents(250000)},
+			{Category: dividends, Currency: "USD", PayDate: date(2024, 3, 15), Value: money.FromCents(10000)},
+			{Category: rentals, Currency: "BRL", PayDate: date(2024, 3, 10), Value: money.FromCents(150000)},
+		},
+		invoiceTotals: []repository.CategoryTotal{
+			{Category: food, Currency: "BRL", PayDate: date(2024, 1, 10), Value: money.FromCents(80000)},
+			{Category: health, Currency: "BRL", PayDate: date(2024, 4, 2), Value: money.FromCents(35050)},
+		},
+	}
+}
+
+func TestGetReportSuccess(t *testing.T) {
+	_mockRepository := buildReportRepositoryMock()
+	_readingProcess := NewReadingProcess(_mockRepository, &mockConverter{})
+
+	response, err := _readingProcess.GetReport(ReportContext{Ctx: context.TODO(), Year: 2024, UserToken: tokenMock})
+	assert.NoError(t, err)
+	assert.Equal(t, &TaxReportResponse{
+		Year:     2024,
+		Currency: "BRL",
+		Sections: []TaxReportSectionResponse{
+			{
+				Nature: NATURE_TAXABLE,
+				Title:  "Rendimentos Tributáveis",
+				Total:  money.FromCents(1000000),
+				Items:  []TaxReportItemResponse{{CategoryId: 1, Category: "Salário", Total: money.FromCents(1000000)}},
+			},
+			{
+				Nature: NATURE_EXCLUSIVE,
+				Title:  "Rendimentos Sujeitos à Tributação Exclusiva/Definitiva",
+				Total:  money.FromCents(250000),
+				Items:  []TaxReportItemResponse{{CategoryId: 2, Category: "13º Salário", Total: money.FromCents(250000)}},
+			},
+			{
+				Nature: NATURE_EXEMPT,
+				Title:  "Rendimentos Isentos e Não Tributáveis",
+				Total:  money.FromCents(20000),
+				Items:  []TaxReportItemResponse{{CategoryId: 6, Category: "Dividendos", Total: money.FromCents(20000)}},
+			},
+			{
+				Nature: NATURE_HEALTH,
+				Title:  "Pagamentos Efetuados - Saúde",
+				Total:  money.FromCents(35050),
+				Items:  []TaxReportItemResponse{{CategoryId: 5, Category: "Saúde", Total: money.FromCents(35050)}},
+			},
+			{
+				Nature: NATURE_EDUCATION,
+				Title:  "Pagamentos Efetuados - Educação",
+				Items:  []TaxReportItemResponse{},
+			},
+		},
+	}, response)
+	assert.Equal(t, uint(2024), _mockRepository.year)
+}
+
+func TestGetReportConvertFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(buildReportRepositoryMock(), &mockConverter{err: apperror.Unprocessable("There is no exchange rate from USD to BRL on 2024-03-15")})
+
+	response, err := _readingProcess.GetReport(ReportContext{Ctx: context.TODO(), Year: 2024, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestGetReportGetMappingsFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{err: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	response, err := _readingProcess.GetReport(ReportContext{Ctx: context.TODO(), Year: 2024, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestGetReportGetTotalsFail(t *testing.T) {
+	_readingProcess := NewReadingProcess(&mockRepository{totalsErr: errors.New("An error has been ocurred")}, &mockConverter{})
+
+	response, err := _readingProcess.GetReport(ReportContext{Ctx: context.TODO(), Year: 2024, UserToken: tokenMock})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
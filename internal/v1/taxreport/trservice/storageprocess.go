@@ -0,0 +1,66 @@
+package trservice
+
+import (
+	"github.com/ruanlas/wallet-core-api/internal/idpauth"
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+)
+
+type StorageProcess interface {
+	SaveMapping(saveCtx SaveMappingContext) (*CategoryMappingResponse, error)
+}
+
+type storageProcess struct {
+	repository       repository.Repository
+	referenceChecker validation.ReferenceChecker
+}
+
+func NewStorageProcess(repository repository.Repository, referenceChecker validation.ReferenceChecker) StorageProcess {
+	return &storageProcess{repository: repository, referenceChecker: referenceChecker}
+}
+
+func (sp *storageProcess) SaveMapping(saveCtx SaveMappingContext) (*CategoryMappingResponse, error) {
+	request := saveCtx.Request
+	user := idpauth.GetUser(saveCtx.UserToken)
+	if !isNatureOf(request.Entity, request.Nature) {
+		return nil, validation.Errors{validation.Invalid("nature")}
+	}
+	categoryExists := sp.referenceChecker.InvoiceCategoryExists
+	if request.Entity == ENTITY_GAIN {
+		categoryExists = sp.referenceChecker.GainCategoryExists
+	}
+	exists, err := categoryExists(saveCtx.Ctx, request.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, validation.Errors{validation.NotFound("category_id")}
+	}
+
+	mappingSaved, err := sp.repository.SaveMapping(saveCtx.Ctx, repository.CategoryMapping{
+		UserId:     user.Id,
+		Entity:     request.Entity,
+		CategoryId: request.CategoryId,
+		Nature:     request.Nature,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CategoryMappingResponse{
+		Entity:     mappingSaved.Entity,
+		CategoryId: mappingSaved.CategoryId,
+		Nature:     mappingSaved.Nature,
+	}, nil
+}
+
+func isNatureOf(entity string, nature string) bool {
+	if nature == NATURE_IGNORED {
+		return true
+	}
+	for _, reportSection := range reportSections {
+		if reportSection.nature == nature {
+			return reportSection.entity == entity
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package trservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate/erservice"
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport/repository"
+	"github.com/ruanlas/wallet-core-api/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+const tokenMock = "eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICJRSnVoUjlFSFBIWTZFT195VjV4M1BTZWUzakRLNUs4M0lQMjJwYjFxZXFvIn0.eyJleHAiOjE3MDM4ODk3NTIsImlhdCI6MTcwMzg4OTQ1MiwianRpIjoiNTE4ZDM2MDctZjQ2NC00MDI5LTkwN2ItYjRjNzI1OWY0ZjU0IiwiaXNzIjoiaHR0cDovL2xvY2FsaG9zdDo4MDgxL3JlYWxtcy93YWxsZXQiLCJhdWQiOiJhY2NvdW50Iiwic3ViIjoiNTgzMmE1MDItYmVkZS00OTJkLThkYzEtYjEzYjMyYzMwZjI5IiwidHlwIjoiQmVhcmVyIiwiYXpwIjoid2FsbGV0LWFwaSIsInNlc3Npb25fc3RhdGUiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJhY3IiOiIxIiwicmVhbG1fYWNjZXNzIjp7InJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwidW1hX2F1dGhvcml6YXRpb24iLCJkZWZhdWx0LXJvbGVzLXdhbGxldCJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJzaWQiOiJhMmE2MDM5YS0zZTQxLTQ0MDEtOWJjNC01NWIyNDlkNmY3ZDYiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInByZWZlcnJlZF91c2VybmFtZSI6InRlc3RldXNlciJ9.AcRSnpgzjsuJL2n_QaRF1idkwDzwNpWNX3wiEOFXkqTG35lr4PYVYPxnhryvRvVVOvN_CUY-AaVmF_YSgR4s6JM3Oca5JFFf7T6fX5lXgj0SbQCUbbyh7Em3BemiNKr_T3wucAyO824MjGXP0smciCnnlWvq-apJDTB_R4EisDJubY_E_zpCmTfYMm0NcJ8aKB2ku8mACKgE2ZJ7WsHkKNmjaFeyU9KjGMmNKtFthYISKqRQW-6u2xPjCkpFt4_HoJ01PgjFrrJacWDlUHxVoSILcaH_Vg-WHrKppIkzgdOg5phB2zVtcakRhPhqzV4EX_jXJp2SgK4umf6ivTC3lg"
+
+type mockRepository struct {
+	mappings          []repository.CategoryMapping
+	gainCategories    []repository.Category
+	invoiceCategories []repository.Category
+	gainTotals        []repository.CategoryTotal
+	invoiceTotals     []repository.CategoryTotal
+	mappingSaved      *repository.CategoryMapping
+	year              uint
+	err               error
+	totalsErr         error
+}
+
+func (m *mockRepository) SaveMapping(ctx context.Context, mapping repository.CategoryMapping) (*repository.CategoryMapping, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.mappingSaved = &mapping
+	return &mapping, nil
+}
+
+func (m *mockRepository) GetMappings(ctx context.Context, userId string) (*[]repository.CategoryMapping, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &m.mappings, nil
+}
+
+func (m *mockRepository) GetGainCategories(ctx context.Context) (*[]repository.Category, error) {
+	return &m.gainCategories, nil
+}
+
+func (m *mockRepository) GetInvoiceCategories(ctx context.Context) (*[]repository.Category, error) {
+	return &m.invoiceCategories, nil
+}
+
+func (m *mockRepository) GetGainTotals(ctx context.Context, userId string, year uint) (*[]repository.CategoryTotal, error) {
+	m.year = year
+	if m.totalsErr != nil {
+		return nil, m.totalsErr
+	}
+	return &m.gainTotals, nil
+}
+
+func (m *mockRepository) GetInvoiceTotals(ctx context.Context, userId string, year uint) (*[]repository.CategoryTotal, error) {
+	return &m.invoiceTotals, nil
+}
+
+type mockReferenceChecker struct {
+	missingGainCategories    map[uint]bool
+	missingInvoiceCategories map[uint]bool
+	err                      error
+}
+
+func (rc *mockReferenceChecker) GainCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingGainCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) InvoiceCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return !rc.missingInvoiceCategories[id], rc.err
+}
+
+func (rc *mockReferenceChecker) PaymentTypeExists(ctx context.Context, id uint) (bool, error) {
+	return true, rc.err
+}
+
+// mockConverter doubles the amounts in USD
+type mockConverter struct {
+	err error
+}
+
+func (m *mockConverter) Convert(convertCtx erservice.ConvertContext) (money.Money, error) {
+	if convertCtx.From == convertCtx.To {
+		return convertCtx.Amount, nil
+	}
+	if m.err != nil {
+		return 0, m.err
+	}
+	return convertCtx.Amount * 2, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSaveMappingSuccess(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, &mockReferenceChecker{})
+
+	response, err := _storageProcess.SaveMapping(SaveMappingContext{
+		Ctx:       context.TODO(),
+		Request:   SaveMappingRequest{Entity: ENTITY_GAIN, CategoryId: 11, Nature: NATURE_EXEMPT},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &CategoryMappingResponse{Entity: ENTITY_GAIN, CategoryId: 11, Nature: NATURE_EXEMPT}, response)
+	assert.Equal(t, &repository.CategoryMapping{
+		UserId:     "5832a502-bede-492d-8dc1-b13b32c30f29",
+		Entity:     ENTITY_GAIN,
+		CategoryId: 11,
+		Nature:     NATURE_EXEMPT,
+	}, _mockRepository.mappingSaved)
+}
+
+func TestSaveMappingIgnored(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, &mockReferenceChecker{})
+
+	response, err := _storageProcess.SaveMapping(SaveMappingContext{
+		Ctx:       context.TODO(),
+		Request:   SaveMappingRequest{Entity: ENTITY_INVOICE, CategoryId: 4, Nature: NATURE_IGNORED},
+		UserToken: tokenMock,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, NATURE_IGNORED, response.Nature)
+}
+
+func TestSaveMappingNatureOfAnotherEntity(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, &mockReferenceChecker{})
+
+	response, err := _storageProcess.SaveMapping(SaveMappingContext{
+		Ctx:       context.TODO(),
+		Request:   SaveMappingRequest{Entity: ENTITY_INVOICE, CategoryId: 5, Nature: NATURE_TAXABLE},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, validation.Errors{validation.Invalid("nature")}, err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.mappingSaved)
+}
+
+func TestSaveMappingCategoryNotFound(t *testing.T) {
+	_mockRepository := &mockRepository{}
+	_storageProcess := NewStorageProcess(_mockRepository, &mockReferenceChecker{missingGainCategories: map[uint]bool{99: true}})
+
+	response, err := _storageProcess.SaveMapping(SaveMappingContext{
+		Ctx:       context.TODO(),
+		Request:   SaveMappingRequest{Entity: ENTITY_GAIN, CategoryId: 99, Nature: NATURE_TAXABLE},
+		UserToken: tokenMock,
+	})
+	assert.Equal(t, validation.Errors{validation.NotFound("category_id")}, err)
+	assert.Nil(t, response)
+	assert.Nil(t, _mockRepository.mappingSaved)
+}
+
+func TestSaveMappingReferenceCheckFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{}, &mockReferenceChecker{err: errors.New("An error has been ocurred")})
+
+	response, err := _storageProcess.SaveMapping(SaveMappingContext{
+		Ctx:       context.TODO(),
+		Request:   SaveMappingRequest{Entity: ENTITY_GAIN, CategoryId: 1, Nature: NATURE_TAXABLE},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func TestSaveMappingFail(t *testing.T) {
+	_storageProcess := NewStorageProcess(&mockRepository{err: errors.New("An error has been ocurred")}, &mockReferenceChecker{})
+
+	response, err := _storageProcess.SaveMapping(SaveMappingContext{
+		Ctx:       context.TODO(),
+		Request:   SaveMappingRequest{Entity: ENTITY_GAIN, CategoryId: 1, Nature: NATURE_TAXABLE},
+		UserToken: tokenMock,
+	})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
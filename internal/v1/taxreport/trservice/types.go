@@ -0,0 +1,112 @@
+package trservice
+
+import (
+	"context"
+
+	"github.com/ruanlas/wallet-core-api/internal/money"
+)
+
+const (
+	ENTITY_GAIN    = "gain"
+	ENTITY_INVOICE = "invoice"
+)
+
+// The natures mirror the sections of the IRPF declaration, the ignored categories are left out of the report
+const (
+	NATURE_TAXABLE   = "taxable"
+	NATURE_EXCLUSIVE = "exclusive"
+	NATURE_EXEMPT    = "exempt"
+	NATURE_HEALTH    = "health"
+	NATURE_EDUCATION = "education"
+	NATURE_IGNORED   = "ignored"
+)
+
+// REPORT_CURRENCY is the currency of the declaration, the records kept in other currencies are converted to it
+const REPORT_CURRENCY = "BRL"
+
+// DEFAULT_GAIN_NATURES is the nature of the gain categories that the user has not mapped, by category id
+var DEFAULT_GAIN_NATURES = map[uint]string{
+	1: NATURE_TAXABLE,   // Salário
+	2: NATURE_EXCLUSIVE, // 13º Salário
+	3: NATURE_TAXABLE,   // Férias
+	4: NATURE_TAXABLE,   // Prestação de Serviços
+	5: NATURE_EXCLUSIVE, // Premiação
+	6: NATURE_EXEMPT,    // Dividendos
+	7: NATURE_TAXABLE,   // Aluguéis
+}
+
+// DEFAULT_INVOICE_NATURES is the nature of the invoice categories that the user has not mapped, by category id
+var DEFAULT_INVOICE_NATURES = map[uint]string{
+	4: NATURE_EDUCATION, // Educação
+	5: NATURE_HEALTH,    // Saúde
+}
+
+type section struct {
+	nature string
+	entity string
+	title  string
+}
+
+var reportSections = []section{
+	{nature: NATURE_TAXABLE, entity: ENTITY_GAIN, title: "Rendimentos Tributáveis"},
+	{nature: NATURE_EXCLUSIVE, entity: ENTITY_GAIN, title: "Rendimentos Sujeitos à Tributação Exclusiva/Definitiva"},
+	{nature: NATURE_EXEMPT, entity: ENTITY_GAIN, title: "Rendimentos Isentos e Não Tributáveis"},
+	{nature: NATURE_HEALTH, entity: ENTITY_INVOICE, title: "Pagamentos Efetuados - Saúde"},
+	{nature: NATURE_EDUCATION, entity: ENTITY_INVOICE, title: "Pagamentos Efetuados - Educação"},
+}
+
+type SaveMappingContext struct {
+	Ctx       context.Context
+	Request   SaveMappingRequest
+	UserToken string
+}
+
+type SearchContext struct {
+	Ctx       context.Context
+	UserToken string
+}
+
+type ReportContext struct {
+	Ctx       context.Context
+	Year      uint
+	UserToken string
+}
+
+// SaveMappingRequest accepts the natures of gains (taxable, exclusive, exempt) or of invoices (health, education)
+// according to the entity, the ignored nature takes the category out of the report
+type SaveMappingRequest struct {
+	Entity     string `json:"entity" binding:"required,oneof=gain invoice"`
+	CategoryId uint   `json:"category_id" binding:"required,min=1"`
+	Nature     string `json:"nature" binding:"required,oneof=taxable exclusive exempt health education ignored"`
+}
+
+type CategoryMappingResponse struct {
+	Entity     string `json:"entity"`
+	CategoryId uint   `json:"category_id"`
+	Category   string `json:"category,omitempty"`
+	Nature     string `json:"nature"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+type CategoryMappingListResponse struct {
+	Records []CategoryMappingResponse `json:"records"`
+}
+
+type TaxReportResponse struct {
+	Year     uint                       `json:"year"`
+	Currency string                     `json:"currency"`
+	Sections []TaxReportSectionResponse `json:"sections"`
+}
+
+type TaxReportSectionResponse struct {
+	Nature string                  `json:"nature"`
+	Title  string                  `json:"title"`
+	Total  money.Money             `json:"total" swaggertype:"number"`
+	Items  []TaxReportItemResponse `json:"items"`
+}
+
+type TaxReportItemResponse struct {
+	CategoryId uint        `json:"category_id"`
+	Category   string      `json:"category"`
+	Total      money.Money `json:"total" swaggertype:"number"`
+}
@@ -1,10 +1,22 @@
 package v1
 
 import (
+	"github.com/ruanlas/wallet-core-api/internal/v1/analytics"
+	"github.com/ruanlas/wallet-core-api/internal/v1/attachment"
+	"github.com/ruanlas/wallet-core-api/internal/v1/audit"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categoryrule"
+	"github.com/ruanlas/wallet-core-api/internal/v1/categorysuggestion"
+	"github.com/ruanlas/wallet-core-api/internal/v1/exchangerate"
+	"github.com/ruanlas/wallet-core-api/internal/v1/forecast"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gain"
 	"github.com/ruanlas/wallet-core-api/internal/v1/gainprojection"
+	"github.com/ruanlas/wallet-core-api/internal/v1/goal"
+	"github.com/ruanlas/wallet-core-api/internal/v1/investment"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoice"
 	"github.com/ruanlas/wallet-core-api/internal/v1/invoiceprojection"
+	"github.com/ruanlas/wallet-core-api/internal/v1/loan"
+	"github.com/ruanlas/wallet-core-api/internal/v1/split"
+	"github.com/ruanlas/wallet-core-api/internal/v1/taxreport"
 )
 
 type Api interface {
@@ -12,21 +24,57 @@ type Api interface {
 	GetGainHandler() gain.Handler
 	GetInvoiceProjectionHandler() invoiceprojection.Handler
 	GetInvoiceHandler() invoice.Handler
+	GetAuditHandler() audit.Handler
+	GetExchangeRateHandler() exchangerate.Handler
+	GetAttachmentHandler() attachment.Handler
+	GetCategoryRuleHandler() categoryrule.Handler
+	GetCategorySuggestionHandler() categorysuggestion.Handler
+	GetForecastHandler() forecast.Handler
+	GetAnalyticsHandler() analytics.Handler
+	GetTaxReportHandler() taxreport.Handler
+	GetGoalHandler() goal.Handler
+	GetLoanHandler() loan.Handler
+	GetInvestmentHandler() investment.Handler
+	GetSplitHandler() split.Handler
 }
 
-func NewApi(gainProjectionHandler gainprojection.Handler, gainHandler gain.Handler, invoiceProjectionHandler invoiceprojection.Handler, invoiceHandler invoice.Handler) Api {
+func NewApi(gainProjectionHandler gainprojection.Handler, gainHandler gain.Handler, invoiceProjectionHandler invoiceprojection.Handler, invoiceHandler invoice.Handler, auditHandler audit.Handler, exchangeRateHandler exchangerate.Handler, attachmentHandler attachment.Handler, categoryRuleHandler categoryrule.Handler, categorySuggestionHandler categorysuggestion.Handler, forecastHandler forecast.Handler, analyticsHandler analytics.Handler, taxReportHandler taxreport.Handler, goalHandler goal.Handler, loanHandler loan.Handler, investmentHandler investment.Handler, splitHandler split.Handler) Api {
 	return &api{
-		gainProjectionHandler:    gainProjectionHandler,
-		gainHandler:              gainHandler,
-		invoiceProjectionHandler: invoiceProjectionHandler,
-		invoiceHandler:           invoiceHandler}
+		gainProjectionHandler:     gainProjectionHandler,
+		gainHandler:               gainHandler,
+		invoiceProjectionHandler:  invoiceProjectionHandler,
+		invoiceHandler:            invoiceHandler,
+		auditHandler:              auditHandler,
+		exchangeRateHandler:       exchangeRateHandler,
+		attachmentHandler:         attachmentHandler,
+		categoryRuleHandler:       categoryRuleHandler,
+		categorySuggestionHandler: categorySuggestionHandler,
+		forecastHandler:           forecastHandler,
+		analyticsHandler:          analyticsHandler,
+		taxReportHandler:          taxReportHandler,
+		goalHandler:               goalHandler,
+		loanHandler:               loanHandler,
+		investmentHandler:         investmentHandler,
+		splitHandler:              splitHandler}
 }
 
 type api struct {
-	gainProjectionHandler    gainprojection.Handler
-	gainHandler              gain.Handler
-	invoiceProjectionHandler invoiceprojection.Handler
-	invoiceHandler           invoice.Handler
+	gainProjectionHandler     gainprojection.Handler
+	gainHandler               gain.Handler
+	invoiceProjectionHandler  invoiceprojection.Handler
+	invoiceHandler            invoice.Handler
+	auditHandler              audit.Handler
+	exchangeRateHandler       exchangerate.Handler
+	attachmentHandler         attachment.Handler
+	categoryRuleHandler       categoryrule.Handler
+	categorySuggestionHandler categorysuggestion.Handler
+	forecastHandler           forecast.Handler
+	analyticsHandler          analytics.Handler
+	taxReportHandler          taxreport.Handler
+	goalHandler               goal.Handler
+	loanHandler               loan.Handler
+	investmentHandler         investment.Handler
+	splitHandler              split.Handler
 }
 
 func (a *api) GetGainProjectionHandler() gainprojection.Handler {
@@ -44,3 +92,51 @@ func (a *api) GetInvoiceProjectionHandler() invoiceprojection.Handler {
 func (a *api) GetInvoiceHandler() invoice.Handler {
 	return a.invoiceHandler
 }
+
+func (a *api) GetAuditHandler() audit.Handler {
+	return a.auditHandler
+}
+
+func (a *api) GetExchangeRateHandler() exchangerate.Handler {
+	return a.exchangeRateHandler
+}
+
+func (a *api) GetAttachmentHandler() attachment.Handler {
+	return a.attachmentHandler
+}
+
+func (a *api) GetCategoryRuleHandler() categoryrule.Handler {
+	return a.categoryRuleHandler
+}
+
+func (a *api) GetCategorySuggestionHandler() categorysuggestion.Handler {
+	return a.categorySuggestionHandler
+}
+
+func (a *api) GetForecastHandler() forecast.Handler {
+	return a.forecastHandler
+}
+
+func (a *api) GetAnalyticsHandler() analytics.Handler {
+	return a.analyticsHandler
+}
+
+func (a *api) GetTaxReportHandler() taxreport.Handler {
+	return a.taxReportHandler
+}
+
+func (a *api) GetGoalHandler() goal.Handler {
+	return a.goalHandler
+}
+
+func (a *api) GetLoanHandler() loan.Handler {
+	return a.loanHandler
+}
+
+func (a *api) GetInvestmentHandler() investment.Handler {
+	return a.investmentHandler
+}
+
+func (a *api) GetSplitHandler() split.Handler {
+	return a.splitHandler
+}
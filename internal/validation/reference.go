@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ReferenceChecker verifies that the ids referenced by a request are registered
+type ReferenceChecker interface {
+	GainCategoryExists(ctx context.Context, id uint) (bool, error)
+	InvoiceCategoryExists(ctx context.Context, id uint) (bool, error)
+	PaymentTypeExists(ctx context.Context, id uint) (bool, error)
+}
+
+type referenceChecker struct {
+	db *sql.DB
+}
+
+func NewReferenceChecker(db *sql.DB) ReferenceChecker {
+	return &referenceChecker{db: db}
+}
+
+func (rc *referenceChecker) GainCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return rc.exists(ctx, `SELECT COUNT(*) FROM gain_category WHERE id = ?`, id)
+}
+
+func (rc *referenceChecker) InvoiceCategoryExists(ctx context.Context, id uint) (bool, error) {
+	return rc.exists(ctx, `SELECT COUNT(*) FROM invoice_category WHERE id = ?`, id)
+}
+
+func (rc *referenceChecker) PaymentTypeExists(ctx context.Context, id uint) (bool, error) {
+	return rc.exists(ctx, `SELECT COUNT(*) FROM payment_type WHERE id = ?`, id)
+}
+
+func (rc *referenceChecker) exists(ctx context.Context, query string, id uint) (bool, error) {
+	var total uint
+	err := rc.db.QueryRowContext(ctx, query, id).Scan(&total)
+	if err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
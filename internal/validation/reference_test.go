@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGainCategoryExists(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_referenceChecker := NewReferenceChecker(dbMock)
+	sqlMock.ExpectQuery(`SELECT COUNT(*) FROM gain_category WHERE id = ?`).
+		WithArgs(2).
+		WillReturnRows(sqlMock.NewRows([]string{"total"}).AddRow(1))
+
+	exists, err := _referenceChecker.GainCategoryExists(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestInvoiceCategoryNotExists(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_referenceChecker := NewReferenceChecker(dbMock)
+	sqlMock.ExpectQuery(`SELECT COUNT(*) FROM invoice_category WHERE id = ?`).
+		WithArgs(99).
+		WillReturnRows(sqlMock.NewRows([]string{"total"}).AddRow(0))
+
+	exists, err := _referenceChecker.InvoiceCategoryExists(context.Background(), 99)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPaymentTypeExistsFail(t *testing.T) {
+	dbMock, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer dbMock.Close()
+
+	_referenceChecker := NewReferenceChecker(dbMock)
+	sqlMock.ExpectQuery(`SELECT COUNT(*) FROM payment_type WHERE id = ?`).
+		WithArgs(1).
+		WillReturnError(errors.New("An error has been ocurred"))
+
+	exists, err := _referenceChecker.PaymentTypeExists(context.Background(), 1)
+	assert.Error(t, err)
+	assert.False(t, exists)
+
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,133 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+)
+
+const (
+	CODE_REQUIRED  = "required"
+	CODE_TOO_SMALL = "too_small"
+	CODE_TOO_LARGE = "too_large"
+	CODE_INVALID   = "invalid"
+	CODE_NOT_FOUND = "not_found"
+)
+
+const (
+	MESSAGE_INVALID_REQUEST = "The request contains invalid fields"
+	MESSAGE_MALFORMED_BODY  = "The request body is not a valid JSON"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors is the list of fields that made a request invalid
+type Errors []FieldError
+
+func (errs Errors) Error() string {
+	messages := make([]string, len(errs))
+	for index, fieldError := range errs {
+		messages[index] = fieldError.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func Required(field string) FieldError {
+	return FieldError{Field: field, Code: CODE_REQUIRED, Message: fmt.Sprintf("The %s must be informed", field)}
+}
+
+func Invalid(field string) FieldError {
+	return FieldError{Field: field, Code: CODE_INVALID, Message: fmt.Sprintf("The %s is not valid", field)}
+}
+
+func NotFound(field string) FieldError {
+	return FieldError{Field: field, Code: CODE_NOT_FOUND, Message: fmt.Sprintf("The %s does not exist", field)}
+}
+
+var setupOnce sync.Once
+
+var currencyValidator = validator.New()
+
+// IsCurrency reports whether the code is an ISO 4217 currency, the same rule of the iso4217 binding tag
+func IsCurrency(code string) bool {
+	return currencyValidator.Var(code, "iso4217") == nil
+}
+
+// Bind decodes the JSON body into the request and checks the rules declared in its binding tags.
+// The broken rules and the fields with a wrong type are returned as Errors,
+// a body that could not be decoded is returned as a validation apperror.
+func Bind(c *gin.Context, request any) error {
+	setupOnce.Do(setup)
+	err := c.ShouldBindJSON(request)
+	if err == nil {
+		return nil
+	}
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return translate(validationErrs)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return Errors{Invalid(typeErr.Field)}
+	}
+	return apperror.Validation(MESSAGE_MALFORMED_BODY).Wrap(err)
+}
+
+func setup() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	engine.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+}
+
+func translate(validationErrs validator.ValidationErrors) Errors {
+	errs := Errors{}
+	for _, validationErr := range validationErrs {
+		errs = append(errs, translateField(validationErr))
+	}
+	return errs
+}
+
+func translateField(validationErr validator.FieldError) FieldError {
+	field := validationErr.Field()
+	isText := validationErr.Kind() == reflect.String
+	switch validationErr.Tag() {
+	case "required", "notblank":
+		return Required(field)
+	case "gt":
+		return FieldError{Field: field, Code: CODE_TOO_SMALL, Message: fmt.Sprintf("The %s must be greater than %s", field, validationErr.Param())}
+	case "min", "gte":
+		if isText {
+			return FieldError{Field: field, Code: CODE_TOO_SMALL, Message: fmt.Sprintf("The %s must have at least %s characters", field, validationErr.Param())}
+		}
+		return FieldError{Field: field, Code: CODE_TOO_SMALL, Message: fmt.Sprintf("The %s must be at least %s", field, validationErr.Param())}
+	case "max", "lte":
+		if isText {
+			return FieldError{Field: field, Code: CODE_TOO_LARGE, Message: fmt.Sprintf("The %s must have at most %s characters", field, validationErr.Param())}
+		}
+		return FieldError{Field: field, Code: CODE_TOO_LARGE, Message: fmt.Sprintf("The %s must be at most %s", field, validationErr.Param())}
+	}
+	return Invalid(field)
+}
@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ruanlas/wallet-core-api/internal/apperror"
+	"github.com/stretchr/testify/assert"
+)
+
+type requestMock struct {
+	Description string  `json:"description" binding:"notblank,max=10"`
+	Value       float64 `json:"value" binding:"gt=0"`
+	Recurrence  uint    `json:"recurrence" binding:"max=12"`
+	CategoryId  *uint   `json:"category_id" binding:"omitempty,min=1"`
+	Kind        string  `json:"kind" binding:"omitempty,oneof=a b"`
+}
+
+func bind(body string) (*requestMock, error) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/", bytes.NewReader([]byte(body)))
+	c.Request.Header.Add("Content-Type", "application/json")
+	var request requestMock
+	err := Bind(c, &request)
+	return &request, err
+}
+
+func TestBindSuccess(t *testing.T) {
+	request, err := bind(`{"description": "Teste", "value": 10.5, "recurrence": 3, "category_id": 2, "kind": "a"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Teste", request.Description)
+	assert.Equal(t, uint(2), *request.CategoryId)
+}
+
+func TestBindInvalidFields(t *testing.T) {
+	_, err := bind(`{"description": "   ", "value": 0, "recurrence": 10000, "category_id": 0, "kind": "c"}`)
+	var errs Errors
+	assert.ErrorAs(t, err, &errs)
+	assert.Equal(t, Errors{
+		{Field: "description", Code: CODE_REQUIRED, Message: "The description must be informed"},
+		{Field: "value", Code: CODE_TOO_SMALL, Message: "The value must be greater than 0"},
+		{Field: "recurrence", Code: CODE_TOO_LARGE, Message: "The recurrence must be at most 12"},
+		{Field: "category_id", Code: CODE_TOO_SMALL, Message: "The category_id must be at least 1"},
+		{Field: "kind", Code: CODE_INVALID, Message: "The kind is not valid"},
+	}, errs)
+}
+
+func TestBindTextTooLarge(t *testing.T) {
+	_, err := bind(`{"description": "A very long description", "value": 1}`)
+	var errs Errors
+	assert.ErrorAs(t, err, &errs)
+	assert.Equal(t, Errors{
+		{Field: "description", Code: CODE_TOO_LARGE, Message: "The description must have at most 10 characters"},
+	}, errs)
+}
+
+func TestBindMalformedBody(t *testing.T) {
+	_, err := bind(`{"description": "Teste" broken`)
+	var errs Errors
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &errs))
+	assert.Equal(t, apperror.KIND_VALIDATION, apperror.KindOf(err))
+	assert.NotContains(t, err.(*apperror.Error).Message, "invalid character")
+}
+
+func TestErrorsMessage(t *testing.T) {
+	errs := Errors{Required("description"), NotFound("category_id")}
+	assert.Equal(t, "The description must be informed; The category_id does not exist", errs.Error())
+}
+
+func TestBindWrongType(t *testing.T) {
+	_, err := bind(`{"description": "Teste", "value": "ten"}`)
+	assert.Equal(t, Errors{Invalid("value")}, err)
+}
+
+func TestIsCurrency(t *testing.T) {
+	assert.True(t, IsCurrency("BRL"))
+	assert.True(t, IsCurrency("USD"))
+	assert.False(t, IsCurrency("usd"))
+	assert.False(t, IsCurrency("REAL"))
+	assert.False(t, IsCurrency(""))
+}
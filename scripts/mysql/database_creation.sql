@@ -24,10 +24,14 @@ CREATE TABLE IF NOT EXISTS invoice_projection (
     pay_in DATE NOT NULL,
     description VARCHAR(255) NOT NULL,
     value DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    boleto_code VARCHAR(44) NOT NULL DEFAULT '',
     is_already_done BOOLEAN NOT NULL,
     user_id VARCHAR(255) NOT NULL,
     payment_type_id INT NOT NULL,
     category_id INT NOT NULL,
+    deleted_at INT NULL,
+    version INT NOT NULL DEFAULT 1,
     CONSTRAINT FK_invoice_projection_payment_type FOREIGN KEY (payment_type_id) REFERENCES payment_type(id),
     CONSTRAINT FK_invoice_projection_category FOREIGN KEY (category_id) REFERENCES invoice_category(id)
 );
@@ -39,10 +43,13 @@ CREATE TABLE IF NOT EXISTS invoice (
     pay_at DATE NOT NULL,
     description VARCHAR(255) NOT NULL,
     value DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
     user_id VARCHAR(255) NOT NULL,
     payment_type_id INT NOT NULL,
     category_id INT NOT NULL,
     invoice_projection_id VARCHAR(255),
+    deleted_at INT NULL,
+    version INT NOT NULL DEFAULT 1,
     CONSTRAINT FK_invoice_payment_type FOREIGN KEY (payment_type_id) REFERENCES payment_type(id),
     CONSTRAINT FK_invoice_category FOREIGN KEY (category_id) REFERENCES invoice_category(id),
     CONSTRAINT FK_invoice_invoice_projection FOREIGN KEY (invoice_projection_id) REFERENCES invoice_projection(id)
@@ -54,10 +61,13 @@ CREATE TABLE IF NOT EXISTS gain_projection (
     pay_in DATE NOT NULL,
     description VARCHAR(255) NOT NULL,
     value DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
     is_already_done BOOLEAN NOT NULL,
     is_passive BOOLEAN NOT NULL,
     user_id VARCHAR(255) NOT NULL,
     category_id INT NOT NULL,
+    deleted_at INT NULL,
+    version INT NOT NULL DEFAULT 1,
     CONSTRAINT FK_gain_projection_category FOREIGN KEY (category_id) REFERENCES gain_category(id)
 );
 
@@ -67,10 +77,13 @@ CREATE TABLE IF NOT EXISTS gain (
     pay_in DATE NOT NULL,
     description VARCHAR(255) NOT NULL,
     value DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
     is_passive BOOLEAN NOT NULL,
     user_id VARCHAR(255) NOT NULL,
     category_id INT NOT NULL,
     gain_projection_id VARCHAR(255),
+    deleted_at INT NULL,
+    version INT NOT NULL DEFAULT 1,
     CONSTRAINT FK_gain_category FOREIGN KEY (category_id) REFERENCES gain_category(id),
     CONSTRAINT FK_gain_gain_projection FOREIGN KEY (gain_projection_id) REFERENCES gain_projection(id)
 );
@@ -78,5 +91,213 @@ CREATE TABLE IF NOT EXISTS gain (
 CREATE TABLE IF NOT EXISTS label (
     id VARCHAR(255) NOT NULL PRIMARY KEY,
     user_id VARCHAR(255) NOT NULL,
-    label VARCHAR(255) NOT NULL
+    label VARCHAR(255) NOT NULL,
+    UNIQUE KEY UK_label_user_label (user_id, label)
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    entity VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255),
+    before_data JSON,
+    after_data JSON,
+    INDEX IDX_audit_log_entity (entity, entity_id),
+    INDEX IDX_audit_log_user_created_at (user_id, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS idempotency_key (
+    user_id VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    response_body MEDIUMTEXT,
+    created_at INT NOT NULL,
+    PRIMARY KEY (user_id, idempotency_key),
+    INDEX IDX_idempotency_key_created_at (created_at)
+);
+
+CREATE TABLE IF NOT EXISTS exchange_rate (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate DECIMAL(18,8) NOT NULL,
+    UNIQUE KEY UK_exchange_rate (user_id, base_currency, quote_currency, rate_date)
+);
+
+CREATE TABLE IF NOT EXISTS attachment (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    entity VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(1024) NOT NULL,
+    INDEX IDX_attachment_entity (entity, entity_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS category_rule (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    entity VARCHAR(50) NOT NULL,
+    match_type VARCHAR(10) NOT NULL DEFAULT 'contains',
+    pattern VARCHAR(255) NOT NULL DEFAULT '',
+    min_value DECIMAL(15,2) NULL,
+    max_value DECIMAL(15,2) NULL,
+    payment_type_id INT NULL,
+    category_id INT NULL,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    is_passive BOOLEAN NULL,
+    priority INT NOT NULL DEFAULT 0,
+    INDEX IDX_category_rule_user_entity (user_id, entity)
+);
+
+CREATE TABLE IF NOT EXISTS record_label (
+    entity VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(255) NOT NULL,
+    label_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (entity, entity_id, label_id),
+    CONSTRAINT FK_record_label_label FOREIGN KEY (label_id) REFERENCES label(id)
+);
+
+CREATE TABLE IF NOT EXISTS tax_category_mapping (
+    user_id VARCHAR(255) NOT NULL,
+    entity VARCHAR(50) NOT NULL,
+    category_id INT NOT NULL,
+    nature VARCHAR(20) NOT NULL,
+    PRIMARY KEY (user_id, entity, category_id)
+);
+
+CREATE TABLE IF NOT EXISTS goal (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    target_value DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    start_at DATE NOT NULL,
+    deadline DATE NOT NULL,
+    category_id INT NULL,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    INDEX IDX_goal_user_deadline (user_id, deadline),
+    CONSTRAINT FK_goal_category FOREIGN KEY (category_id) REFERENCES invoice_category(id)
+);
+
+CREATE TABLE IF NOT EXISTS goal_projection (
+    goal_id VARCHAR(255) NOT NULL,
+    invoice_projection_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (goal_id, invoice_projection_id),
+    CONSTRAINT FK_goal_projection_goal FOREIGN KEY (goal_id) REFERENCES goal(id),
+    CONSTRAINT FK_goal_projection_invoice_projection FOREIGN KEY (invoice_projection_id) REFERENCES invoice_projection(id)
+);
+
+CREATE TABLE IF NOT EXISTS loan (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    principal DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    interest_rate DECIMAL(18,8) NOT NULL,
+    term INT NOT NULL,
+    amortization VARCHAR(10) NOT NULL,
+    first_due_date DATE NOT NULL,
+    category_id INT NOT NULL,
+    payment_type_id INT NOT NULL,
+    INDEX IDX_loan_user (user_id),
+    CONSTRAINT FK_loan_category FOREIGN KEY (category_id) REFERENCES invoice_category(id),
+    CONSTRAINT FK_loan_payment_type FOREIGN KEY (payment_type_id) REFERENCES payment_type(id)
+);
+
+CREATE TABLE IF NOT EXISTS loan_installment (
+    loan_id VARCHAR(255) NOT NULL,
+    number INT NOT NULL,
+    due_date DATE NOT NULL,
+    amortization DECIMAL(15,2) NOT NULL,
+    interest DECIMAL(15,2) NOT NULL,
+    value DECIMAL(15,2) NOT NULL,
+    invoice_projection_id VARCHAR(255) NULL,
+    PRIMARY KEY (loan_id, number),
+    CONSTRAINT FK_loan_installment_loan FOREIGN KEY (loan_id) REFERENCES loan(id),
+    CONSTRAINT FK_loan_installment_invoice_projection FOREIGN KEY (invoice_projection_id) REFERENCES invoice_projection(id)
+);
+
+CREATE TABLE IF NOT EXISTS asset (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    ticker VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    asset_type VARCHAR(20) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    price DECIMAL(15,2) NULL,
+    price_at DATE NULL,
+    UNIQUE KEY UQ_asset_user_ticker (user_id, ticker)
+);
+
+CREATE TABLE IF NOT EXISTS asset_operation (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    asset_id VARCHAR(255) NOT NULL,
+    operation_type VARCHAR(10) NOT NULL,
+    operated_at DATE NOT NULL,
+    quantity DECIMAL(18,8) NOT NULL,
+    price DECIMAL(15,2) NOT NULL,
+    fees DECIMAL(15,2) NOT NULL DEFAULT 0,
+    INDEX IDX_asset_operation_asset (asset_id, operated_at),
+    CONSTRAINT FK_asset_operation_asset FOREIGN KEY (asset_id) REFERENCES asset(id)
+);
+
+CREATE TABLE IF NOT EXISTS asset_dividend (
+    gain_id VARCHAR(255) NOT NULL PRIMARY KEY,
+    asset_id VARCHAR(255) NOT NULL,
+    INDEX IDX_asset_dividend_asset (asset_id),
+    CONSTRAINT FK_asset_dividend_gain FOREIGN KEY (gain_id) REFERENCES gain(id),
+    CONSTRAINT FK_asset_dividend_asset FOREIGN KEY (asset_id) REFERENCES asset(id)
+);
+
+CREATE TABLE IF NOT EXISTS split_contact (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    UNIQUE KEY UQ_split_contact_user_name (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS invoice_split (
+    id VARCHAR(255) NOT NULL PRIMARY KEY,
+    created_at INT NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    direction VARCHAR(10) NOT NULL DEFAULT 'receivable',
+    invoice_id VARCHAR(255) NULL,
+    method VARCHAR(10) NOT NULL,
+    owner_share INT NOT NULL DEFAULT 0,
+    owner_value DECIMAL(15,2) NOT NULL,
+    pay_in DATE NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    value DECIMAL(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    UNIQUE KEY UQ_invoice_split_invoice (invoice_id)
+);
+
+CREATE TABLE IF NOT EXISTS invoice_split_share (
+    split_id VARCHAR(255) NOT NULL,
+    contact_id VARCHAR(255) NOT NULL,
+    share INT NOT NULL DEFAULT 0,
+    value DECIMAL(15,2) NOT NULL,
+    gain_projection_id VARCHAR(255) NULL,
+    invoice_projection_id VARCHAR(255) NULL,
+    PRIMARY KEY (split_id, contact_id),
+    INDEX IDX_invoice_split_share_contact (contact_id),
+    CONSTRAINT FK_invoice_split_share_split FOREIGN KEY (split_id) REFERENCES invoice_split(id),
+    CONSTRAINT FK_invoice_split_share_contact FOREIGN KEY (contact_id) REFERENCES split_contact(id)
 );
@@ -10,5 +10,21 @@ TRUNCATE TABLE invoice_projection;
 TRUNCATE TABLE gain_category;
 TRUNCATE TABLE invoice_category;
 TRUNCATE TABLE payment_type;
+TRUNCATE TABLE audit_log;
+TRUNCATE TABLE idempotency_key;
+TRUNCATE TABLE exchange_rate;
+TRUNCATE TABLE attachment;
+TRUNCATE TABLE category_rule;
+TRUNCATE TABLE record_label;
+TRUNCATE TABLE tax_category_mapping;
+TRUNCATE TABLE goal;
+TRUNCATE TABLE loan;
+TRUNCATE TABLE loan_installment;
+TRUNCATE TABLE asset;
+TRUNCATE TABLE asset_operation;
+TRUNCATE TABLE asset_dividend;
+TRUNCATE TABLE split_contact;
+TRUNCATE TABLE invoice_split;
+TRUNCATE TABLE invoice_split_share;
 
 SET FOREIGN_KEY_CHECKS = 1;
\ No newline at end of file
@@ -4,7 +4,8 @@ INSERT INTO payment_type(id, type_name)
 VALUES
 (1, 'Boleto'),
 (2, 'Transferência'),
-(3, 'Crédito');
+(3, 'Crédito'),
+(4, 'Pix');
 
 INSERT INTO invoice_category(id, category)
 VALUES